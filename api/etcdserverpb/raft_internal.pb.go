@@ -101,6 +101,8 @@ type InternalRaftRequest struct {
 	LeaseRevoke              *LeaseRevokeRequest                       `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke,proto3" json:"lease_revoke,omitempty"`
 	Alarm                    *AlarmRequest                             `protobuf:"bytes,10,opt,name=alarm,proto3" json:"alarm,omitempty"`
 	LeaseCheckpoint          *LeaseCheckpointRequest                   `protobuf:"bytes,11,opt,name=lease_checkpoint,json=leaseCheckpoint,proto3" json:"lease_checkpoint,omitempty"`
+	LeaseTransfer            *LeaseTransferRequest                     `protobuf:"bytes,12,opt,name=lease_transfer,json=leaseTransfer,proto3" json:"lease_transfer,omitempty"`
+	LeaseExpire              *LeaseRevokeRequest                       `protobuf:"bytes,13,opt,name=lease_expire,json=leaseExpire,proto3" json:"lease_expire,omitempty"`
 	AuthEnable               *AuthEnableRequest                        `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable,proto3" json:"auth_enable,omitempty"`
 	AuthDisable              *AuthDisableRequest                       `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable,proto3" json:"auth_disable,omitempty"`
 	AuthStatus               *AuthStatusRequest                        `protobuf:"bytes,1013,opt,name=auth_status,json=authStatus,proto3" json:"auth_status,omitempty"`
@@ -233,6 +235,20 @@ func (x *InternalRaftRequest) GetLeaseCheckpoint() *LeaseCheckpointRequest {
 	return nil
 }
 
+func (x *InternalRaftRequest) GetLeaseTransfer() *LeaseTransferRequest {
+	if x != nil {
+		return x.LeaseTransfer
+	}
+	return nil
+}
+
+func (x *InternalRaftRequest) GetLeaseExpire() *LeaseRevokeRequest {
+	if x != nil {
+		return x.LeaseExpire
+	}
+	return nil
+}
+
 func (x *InternalRaftRequest) GetAuthEnable() *AuthEnableRequest {
 	if x != nil {
 		return x.AuthEnable
@@ -488,7 +504,7 @@ const file_raft_internal_proto_rawDesc = "" +
 	"\rRequestHeader\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x04R\x02ID\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12,\n" +
-	"\rauth_revision\x18\x03 \x01(\x04B\a\x8a\xb5\x18\x033.1R\fauthRevision:\a\x82\xb5\x18\x033.0\"\x98\x13\n" +
+	"\rauth_revision\x18\x03 \x01(\x04B\a\x8a\xb5\x18\x033.1R\fauthRevision:\a\x82\xb5\x18\x033.0\"\xba\x14\n" +
 	"\x13InternalRaftRequest\x123\n" +
 	"\x06header\x18d \x01(\v2\x1b.etcdserverpb.RequestHeaderR\x06header\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x04R\x02ID\x120\n" +
@@ -504,7 +520,9 @@ const file_raft_internal_proto_rawDesc = "" +
 	"\flease_revoke\x18\t \x01(\v2 .etcdserverpb.LeaseRevokeRequestR\vleaseRevoke\x120\n" +
 	"\x05alarm\x18\n" +
 	" \x01(\v2\x1a.etcdserverpb.AlarmRequestR\x05alarm\x12X\n" +
-	"\x10lease_checkpoint\x18\v \x01(\v2$.etcdserverpb.LeaseCheckpointRequestB\a\x8a\xb5\x18\x033.4R\x0fleaseCheckpoint\x12A\n" +
+	"\x10lease_checkpoint\x18\v \x01(\v2$.etcdserverpb.LeaseCheckpointRequestB\a\x8a\xb5\x18\x033.4R\x0fleaseCheckpoint\x12R\n" +
+	"\x0elease_transfer\x18\f \x01(\v2\".etcdserverpb.LeaseTransferRequestB\a\x8a\xb5\x18\x033.8R\rleaseTransfer\x12L\n" +
+	"\flease_expire\x18\r \x01(\v2 .etcdserverpb.LeaseRevokeRequestB\a\x8a\xb5\x18\x033.8R\vleaseExpire\x12A\n" +
 	"\vauth_enable\x18\xe8\a \x01(\v2\x1f.etcdserverpb.AuthEnableRequestR\n" +
 	"authEnable\x12D\n" +
 	"\fauth_disable\x18\xf3\a \x01(\v2 .etcdserverpb.AuthDisableRequestR\vauthDisable\x12J\n" +
@@ -564,26 +582,27 @@ var file_raft_internal_proto_goTypes = []any{
 	(*LeaseRevokeRequest)(nil),                       // 10: etcdserverpb.LeaseRevokeRequest
 	(*AlarmRequest)(nil),                             // 11: etcdserverpb.AlarmRequest
 	(*LeaseCheckpointRequest)(nil),                   // 12: etcdserverpb.LeaseCheckpointRequest
-	(*AuthEnableRequest)(nil),                        // 13: etcdserverpb.AuthEnableRequest
-	(*AuthDisableRequest)(nil),                       // 14: etcdserverpb.AuthDisableRequest
-	(*AuthStatusRequest)(nil),                        // 15: etcdserverpb.AuthStatusRequest
-	(*AuthUserAddRequest)(nil),                       // 16: etcdserverpb.AuthUserAddRequest
-	(*AuthUserDeleteRequest)(nil),                    // 17: etcdserverpb.AuthUserDeleteRequest
-	(*AuthUserGetRequest)(nil),                       // 18: etcdserverpb.AuthUserGetRequest
-	(*AuthUserChangePasswordRequest)(nil),            // 19: etcdserverpb.AuthUserChangePasswordRequest
-	(*AuthUserGrantRoleRequest)(nil),                 // 20: etcdserverpb.AuthUserGrantRoleRequest
-	(*AuthUserRevokeRoleRequest)(nil),                // 21: etcdserverpb.AuthUserRevokeRoleRequest
-	(*AuthUserListRequest)(nil),                      // 22: etcdserverpb.AuthUserListRequest
-	(*AuthRoleListRequest)(nil),                      // 23: etcdserverpb.AuthRoleListRequest
-	(*AuthRoleAddRequest)(nil),                       // 24: etcdserverpb.AuthRoleAddRequest
-	(*AuthRoleDeleteRequest)(nil),                    // 25: etcdserverpb.AuthRoleDeleteRequest
-	(*AuthRoleGetRequest)(nil),                       // 26: etcdserverpb.AuthRoleGetRequest
-	(*AuthRoleGrantPermissionRequest)(nil),           // 27: etcdserverpb.AuthRoleGrantPermissionRequest
-	(*AuthRoleRevokePermissionRequest)(nil),          // 28: etcdserverpb.AuthRoleRevokePermissionRequest
-	(*membershippb.ClusterVersionSetRequest)(nil),    // 29: membershippb.ClusterVersionSetRequest
-	(*membershippb.ClusterMemberAttrSetRequest)(nil), // 30: membershippb.ClusterMemberAttrSetRequest
-	(*membershippb.DowngradeInfoSetRequest)(nil),     // 31: membershippb.DowngradeInfoSetRequest
-	(*DowngradeVersionTestRequest)(nil),              // 32: etcdserverpb.DowngradeVersionTestRequest
+	(*LeaseTransferRequest)(nil),                     // 13: etcdserverpb.LeaseTransferRequest
+	(*AuthEnableRequest)(nil),                        // 14: etcdserverpb.AuthEnableRequest
+	(*AuthDisableRequest)(nil),                       // 15: etcdserverpb.AuthDisableRequest
+	(*AuthStatusRequest)(nil),                        // 16: etcdserverpb.AuthStatusRequest
+	(*AuthUserAddRequest)(nil),                       // 17: etcdserverpb.AuthUserAddRequest
+	(*AuthUserDeleteRequest)(nil),                    // 18: etcdserverpb.AuthUserDeleteRequest
+	(*AuthUserGetRequest)(nil),                       // 19: etcdserverpb.AuthUserGetRequest
+	(*AuthUserChangePasswordRequest)(nil),            // 20: etcdserverpb.AuthUserChangePasswordRequest
+	(*AuthUserGrantRoleRequest)(nil),                 // 21: etcdserverpb.AuthUserGrantRoleRequest
+	(*AuthUserRevokeRoleRequest)(nil),                // 22: etcdserverpb.AuthUserRevokeRoleRequest
+	(*AuthUserListRequest)(nil),                      // 23: etcdserverpb.AuthUserListRequest
+	(*AuthRoleListRequest)(nil),                      // 24: etcdserverpb.AuthRoleListRequest
+	(*AuthRoleAddRequest)(nil),                       // 25: etcdserverpb.AuthRoleAddRequest
+	(*AuthRoleDeleteRequest)(nil),                    // 26: etcdserverpb.AuthRoleDeleteRequest
+	(*AuthRoleGetRequest)(nil),                       // 27: etcdserverpb.AuthRoleGetRequest
+	(*AuthRoleGrantPermissionRequest)(nil),           // 28: etcdserverpb.AuthRoleGrantPermissionRequest
+	(*AuthRoleRevokePermissionRequest)(nil),          // 29: etcdserverpb.AuthRoleRevokePermissionRequest
+	(*membershippb.ClusterVersionSetRequest)(nil),    // 30: membershippb.ClusterVersionSetRequest
+	(*membershippb.ClusterMemberAttrSetRequest)(nil), // 31: membershippb.ClusterMemberAttrSetRequest
+	(*membershippb.DowngradeInfoSetRequest)(nil),     // 32: membershippb.DowngradeInfoSetRequest
+	(*DowngradeVersionTestRequest)(nil),              // 33: etcdserverpb.DowngradeVersionTestRequest
 }
 var file_raft_internal_proto_depIdxs = []int32{
 	0,  // 0: etcdserverpb.InternalRaftRequest.header:type_name -> etcdserverpb.RequestHeader
@@ -596,32 +615,34 @@ var file_raft_internal_proto_depIdxs = []int32{
 	10, // 7: etcdserverpb.InternalRaftRequest.lease_revoke:type_name -> etcdserverpb.LeaseRevokeRequest
 	11, // 8: etcdserverpb.InternalRaftRequest.alarm:type_name -> etcdserverpb.AlarmRequest
 	12, // 9: etcdserverpb.InternalRaftRequest.lease_checkpoint:type_name -> etcdserverpb.LeaseCheckpointRequest
-	13, // 10: etcdserverpb.InternalRaftRequest.auth_enable:type_name -> etcdserverpb.AuthEnableRequest
-	14, // 11: etcdserverpb.InternalRaftRequest.auth_disable:type_name -> etcdserverpb.AuthDisableRequest
-	15, // 12: etcdserverpb.InternalRaftRequest.auth_status:type_name -> etcdserverpb.AuthStatusRequest
-	3,  // 13: etcdserverpb.InternalRaftRequest.authenticate:type_name -> etcdserverpb.InternalAuthenticateRequest
-	16, // 14: etcdserverpb.InternalRaftRequest.auth_user_add:type_name -> etcdserverpb.AuthUserAddRequest
-	17, // 15: etcdserverpb.InternalRaftRequest.auth_user_delete:type_name -> etcdserverpb.AuthUserDeleteRequest
-	18, // 16: etcdserverpb.InternalRaftRequest.auth_user_get:type_name -> etcdserverpb.AuthUserGetRequest
-	19, // 17: etcdserverpb.InternalRaftRequest.auth_user_change_password:type_name -> etcdserverpb.AuthUserChangePasswordRequest
-	20, // 18: etcdserverpb.InternalRaftRequest.auth_user_grant_role:type_name -> etcdserverpb.AuthUserGrantRoleRequest
-	21, // 19: etcdserverpb.InternalRaftRequest.auth_user_revoke_role:type_name -> etcdserverpb.AuthUserRevokeRoleRequest
-	22, // 20: etcdserverpb.InternalRaftRequest.auth_user_list:type_name -> etcdserverpb.AuthUserListRequest
-	23, // 21: etcdserverpb.InternalRaftRequest.auth_role_list:type_name -> etcdserverpb.AuthRoleListRequest
-	24, // 22: etcdserverpb.InternalRaftRequest.auth_role_add:type_name -> etcdserverpb.AuthRoleAddRequest
-	25, // 23: etcdserverpb.InternalRaftRequest.auth_role_delete:type_name -> etcdserverpb.AuthRoleDeleteRequest
-	26, // 24: etcdserverpb.InternalRaftRequest.auth_role_get:type_name -> etcdserverpb.AuthRoleGetRequest
-	27, // 25: etcdserverpb.InternalRaftRequest.auth_role_grant_permission:type_name -> etcdserverpb.AuthRoleGrantPermissionRequest
-	28, // 26: etcdserverpb.InternalRaftRequest.auth_role_revoke_permission:type_name -> etcdserverpb.AuthRoleRevokePermissionRequest
-	29, // 27: etcdserverpb.InternalRaftRequest.cluster_version_set:type_name -> membershippb.ClusterVersionSetRequest
-	30, // 28: etcdserverpb.InternalRaftRequest.cluster_member_attr_set:type_name -> membershippb.ClusterMemberAttrSetRequest
-	31, // 29: etcdserverpb.InternalRaftRequest.downgrade_info_set:type_name -> membershippb.DowngradeInfoSetRequest
-	32, // 30: etcdserverpb.InternalRaftRequest.downgrade_version_test:type_name -> etcdserverpb.DowngradeVersionTestRequest
-	31, // [31:31] is the sub-list for method output_type
-	31, // [31:31] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	13, // 10: etcdserverpb.InternalRaftRequest.lease_transfer:type_name -> etcdserverpb.LeaseTransferRequest
+	10, // 11: etcdserverpb.InternalRaftRequest.lease_expire:type_name -> etcdserverpb.LeaseRevokeRequest
+	14, // 12: etcdserverpb.InternalRaftRequest.auth_enable:type_name -> etcdserverpb.AuthEnableRequest
+	15, // 13: etcdserverpb.InternalRaftRequest.auth_disable:type_name -> etcdserverpb.AuthDisableRequest
+	16, // 14: etcdserverpb.InternalRaftRequest.auth_status:type_name -> etcdserverpb.AuthStatusRequest
+	3,  // 15: etcdserverpb.InternalRaftRequest.authenticate:type_name -> etcdserverpb.InternalAuthenticateRequest
+	17, // 16: etcdserverpb.InternalRaftRequest.auth_user_add:type_name -> etcdserverpb.AuthUserAddRequest
+	18, // 17: etcdserverpb.InternalRaftRequest.auth_user_delete:type_name -> etcdserverpb.AuthUserDeleteRequest
+	19, // 18: etcdserverpb.InternalRaftRequest.auth_user_get:type_name -> etcdserverpb.AuthUserGetRequest
+	20, // 19: etcdserverpb.InternalRaftRequest.auth_user_change_password:type_name -> etcdserverpb.AuthUserChangePasswordRequest
+	21, // 20: etcdserverpb.InternalRaftRequest.auth_user_grant_role:type_name -> etcdserverpb.AuthUserGrantRoleRequest
+	22, // 21: etcdserverpb.InternalRaftRequest.auth_user_revoke_role:type_name -> etcdserverpb.AuthUserRevokeRoleRequest
+	23, // 22: etcdserverpb.InternalRaftRequest.auth_user_list:type_name -> etcdserverpb.AuthUserListRequest
+	24, // 23: etcdserverpb.InternalRaftRequest.auth_role_list:type_name -> etcdserverpb.AuthRoleListRequest
+	25, // 24: etcdserverpb.InternalRaftRequest.auth_role_add:type_name -> etcdserverpb.AuthRoleAddRequest
+	26, // 25: etcdserverpb.InternalRaftRequest.auth_role_delete:type_name -> etcdserverpb.AuthRoleDeleteRequest
+	27, // 26: etcdserverpb.InternalRaftRequest.auth_role_get:type_name -> etcdserverpb.AuthRoleGetRequest
+	28, // 27: etcdserverpb.InternalRaftRequest.auth_role_grant_permission:type_name -> etcdserverpb.AuthRoleGrantPermissionRequest
+	29, // 28: etcdserverpb.InternalRaftRequest.auth_role_revoke_permission:type_name -> etcdserverpb.AuthRoleRevokePermissionRequest
+	30, // 29: etcdserverpb.InternalRaftRequest.cluster_version_set:type_name -> membershippb.ClusterVersionSetRequest
+	31, // 30: etcdserverpb.InternalRaftRequest.cluster_member_attr_set:type_name -> membershippb.ClusterMemberAttrSetRequest
+	32, // 31: etcdserverpb.InternalRaftRequest.downgrade_info_set:type_name -> membershippb.DowngradeInfoSetRequest
+	33, // 32: etcdserverpb.InternalRaftRequest.downgrade_version_test:type_name -> etcdserverpb.DowngradeVersionTestRequest
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_raft_internal_proto_init() }
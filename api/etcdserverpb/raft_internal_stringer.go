@@ -40,6 +40,16 @@ func (as *InternalRaftStringer) String() string {
 			as.Request.Header.String(),
 			as.Request.LeaseRevoke.ID,
 		)
+	case as.Request.LeaseTransfer != nil:
+		return fmt.Sprintf("header:<%s> lease_transfer:<id:%016x>",
+			as.Request.Header.String(),
+			as.Request.LeaseTransfer.ID,
+		)
+	case as.Request.LeaseExpire != nil:
+		return fmt.Sprintf("header:<%s> lease_expire:<id:%016x>",
+			as.Request.Header.String(),
+			as.Request.LeaseExpire.ID,
+		)
 	case as.Request.Authenticate != nil:
 		return fmt.Sprintf("header:<%s> authenticate:<name:%s simple_token:%s>",
 			as.Request.Header.String(),
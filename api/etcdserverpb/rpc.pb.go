@@ -335,6 +335,105 @@ func (WatchCreateRequest_FilterType) EnumDescriptor() ([]byte, []int) {
 	return file_rpc_proto_rawDescGZIP(), []int{21, 0}
 }
 
+type WatchCreateRequest_FilterOrder int32
+
+const (
+	// evaluate the type filters (NOPUT, NODELETE) first, so that events they
+	// drop are never compared against value_prefix or value_selector.
+	WatchCreateRequest_TYPE_FIRST WatchCreateRequest_FilterOrder = 0
+	// evaluate value_prefix and value_selector first, before the type
+	// filters.
+	WatchCreateRequest_VALUE_FIRST WatchCreateRequest_FilterOrder = 1
+)
+
+// Enum value maps for WatchCreateRequest_FilterOrder.
+var (
+	WatchCreateRequest_FilterOrder_name = map[int32]string{
+		0: "TYPE_FIRST",
+		1: "VALUE_FIRST",
+	}
+	WatchCreateRequest_FilterOrder_value = map[string]int32{
+		"TYPE_FIRST":  0,
+		"VALUE_FIRST": 1,
+	}
+)
+
+func (x WatchCreateRequest_FilterOrder) Enum() *WatchCreateRequest_FilterOrder {
+	p := new(WatchCreateRequest_FilterOrder)
+	*p = x
+	return p
+}
+
+func (x WatchCreateRequest_FilterOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchCreateRequest_FilterOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_proto_enumTypes[6].Descriptor()
+}
+
+func (WatchCreateRequest_FilterOrder) Type() protoreflect.EnumType {
+	return &file_rpc_proto_enumTypes[6]
+}
+
+func (x WatchCreateRequest_FilterOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchCreateRequest_FilterOrder.Descriptor instead.
+func (WatchCreateRequest_FilterOrder) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{21, 1}
+}
+
+type LeaseEvent_EventType int32
+
+const (
+	LeaseEvent_GRANTED LeaseEvent_EventType = 0
+	LeaseEvent_REVOKED LeaseEvent_EventType = 1
+	LeaseEvent_EXPIRED LeaseEvent_EventType = 2
+)
+
+// Enum value maps for LeaseEvent_EventType.
+var (
+	LeaseEvent_EventType_name = map[int32]string{
+		0: "GRANTED",
+		1: "REVOKED",
+		2: "EXPIRED",
+	}
+	LeaseEvent_EventType_value = map[string]int32{
+		"GRANTED": 0,
+		"REVOKED": 1,
+		"EXPIRED": 2,
+	}
+)
+
+func (x LeaseEvent_EventType) Enum() *LeaseEvent_EventType {
+	p := new(LeaseEvent_EventType)
+	*p = x
+	return p
+}
+
+func (x LeaseEvent_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaseEvent_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_proto_enumTypes[7].Descriptor()
+}
+
+func (LeaseEvent_EventType) Type() protoreflect.EnumType {
+	return &file_rpc_proto_enumTypes[7]
+}
+
+func (x LeaseEvent_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaseEvent_EventType.Descriptor instead.
+func (LeaseEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{44, 0}
+}
+
 type AlarmRequest_AlarmAction int32
 
 const (
@@ -368,11 +467,11 @@ func (x AlarmRequest_AlarmAction) String() string {
 }
 
 func (AlarmRequest_AlarmAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_rpc_proto_enumTypes[6].Descriptor()
+	return file_rpc_proto_enumTypes[8].Descriptor()
 }
 
 func (AlarmRequest_AlarmAction) Type() protoreflect.EnumType {
-	return &file_rpc_proto_enumTypes[6]
+	return &file_rpc_proto_enumTypes[8]
 }
 
 func (x AlarmRequest_AlarmAction) Number() protoreflect.EnumNumber {
@@ -381,7 +480,7 @@ func (x AlarmRequest_AlarmAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AlarmRequest_AlarmAction.Descriptor instead.
 func (AlarmRequest_AlarmAction) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{54, 0}
+	return file_rpc_proto_rawDescGZIP(), []int{61, 0}
 }
 
 type DowngradeRequest_DowngradeAction int32
@@ -417,11 +516,11 @@ func (x DowngradeRequest_DowngradeAction) String() string {
 }
 
 func (DowngradeRequest_DowngradeAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_rpc_proto_enumTypes[7].Descriptor()
+	return file_rpc_proto_enumTypes[9].Descriptor()
 }
 
 func (DowngradeRequest_DowngradeAction) Type() protoreflect.EnumType {
-	return &file_rpc_proto_enumTypes[7]
+	return &file_rpc_proto_enumTypes[9]
 }
 
 func (x DowngradeRequest_DowngradeAction) Number() protoreflect.EnumNumber {
@@ -430,7 +529,7 @@ func (x DowngradeRequest_DowngradeAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DowngradeRequest_DowngradeAction.Descriptor instead.
 func (DowngradeRequest_DowngradeAction) EnumDescriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{57, 0}
+	return file_rpc_proto_rawDescGZIP(), []int{64, 0}
 }
 
 type ResponseHeader struct {
@@ -553,8 +652,16 @@ type RangeRequest struct {
 	// max_create_revision is the upper bound for returned key create revisions; all keys with
 	// greater create revisions will be filtered away.
 	MaxCreateRevision int64 `protobuf:"varint,13,opt,name=max_create_revision,json=maxCreateRevision,proto3" json:"max_create_revision,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// cursor continues a paginated range from the next_cursor of the previous
+	// response. The range is read from the later of key and the key following
+	// the last key of the previous page, at the revision of the first page;
+	// revision must be unset or equal to it. If that revision has been
+	// compacted, the range is continued at the oldest revision left and
+	// consistency_downgraded is set in the response. Cursors require the
+	// default key ordering, and are not supported by RangeStream.
+	Cursor        []byte `protobuf:"bytes,14,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RangeRequest) Reset() {
@@ -678,6 +785,13 @@ func (x *RangeRequest) GetMaxCreateRevision() int64 {
 	return 0
 }
 
+func (x *RangeRequest) GetCursor() []byte {
+	if x != nil {
+		return x.Cursor
+	}
+	return nil
+}
+
 type RangeResponse struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	Header *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -689,9 +803,17 @@ type RangeResponse struct {
 	// count is set to the actual number of keys within the range when requested.
 	// Unlike Kvs, it is unaffected by limits and filters (e.g., Min/Max, Create/Modify, Revisions)
 	// and reflects the full count within the specified range.
-	Count         int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Count int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// next_cursor is an opaque cursor to pass in the next request to get the
+	// following page of the range. It is set when more is set and the range is in
+	// the default key ordering.
+	NextCursor []byte `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// consistency_downgraded is set when the revision a paginated range was
+	// pinned to has been compacted during the iteration, so that this page and
+	// the following ones were read at a later revision than the first pages.
+	ConsistencyDowngraded bool `protobuf:"varint,6,opt,name=consistency_downgraded,json=consistencyDowngraded,proto3" json:"consistency_downgraded,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *RangeResponse) Reset() {
@@ -752,6 +874,20 @@ func (x *RangeResponse) GetCount() int64 {
 	return 0
 }
 
+func (x *RangeResponse) GetNextCursor() []byte {
+	if x != nil {
+		return x.NextCursor
+	}
+	return nil
+}
+
+func (x *RangeResponse) GetConsistencyDowngraded() bool {
+	if x != nil {
+		return x.ConsistencyDowngraded
+	}
+	return false
+}
+
 type PutRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// key is the key, in bytes, to put into the key-value store.
@@ -2005,6 +2141,8 @@ type WatchRequest struct {
 	//	*WatchRequest_CreateRequest
 	//	*WatchRequest_CancelRequest
 	//	*WatchRequest_ProgressRequest
+	//	*WatchRequest_AckRequest
+	//	*WatchRequest_CancelRangeRequest
 	RequestUnion  isWatchRequest_RequestUnion `protobuf_oneof:"request_union"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -2074,6 +2212,24 @@ func (x *WatchRequest) GetProgressRequest() *WatchProgressRequest {
 	return nil
 }
 
+func (x *WatchRequest) GetAckRequest() *WatchAckRequest {
+	if x != nil {
+		if x, ok := x.RequestUnion.(*WatchRequest_AckRequest); ok {
+			return x.AckRequest
+		}
+	}
+	return nil
+}
+
+func (x *WatchRequest) GetCancelRangeRequest() *WatchCancelRangeRequest {
+	if x != nil {
+		if x, ok := x.RequestUnion.(*WatchRequest_CancelRangeRequest); ok {
+			return x.CancelRangeRequest
+		}
+	}
+	return nil
+}
+
 type isWatchRequest_RequestUnion interface {
 	isWatchRequest_RequestUnion()
 }
@@ -2090,12 +2246,24 @@ type WatchRequest_ProgressRequest struct {
 	ProgressRequest *WatchProgressRequest `protobuf:"bytes,3,opt,name=progress_request,json=progressRequest,proto3,oneof"`
 }
 
+type WatchRequest_AckRequest struct {
+	AckRequest *WatchAckRequest `protobuf:"bytes,4,opt,name=ack_request,json=ackRequest,proto3,oneof"`
+}
+
+type WatchRequest_CancelRangeRequest struct {
+	CancelRangeRequest *WatchCancelRangeRequest `protobuf:"bytes,5,opt,name=cancel_range_request,json=cancelRangeRequest,proto3,oneof"`
+}
+
 func (*WatchRequest_CreateRequest) isWatchRequest_RequestUnion() {}
 
 func (*WatchRequest_CancelRequest) isWatchRequest_RequestUnion() {}
 
 func (*WatchRequest_ProgressRequest) isWatchRequest_RequestUnion() {}
 
+func (*WatchRequest_AckRequest) isWatchRequest_RequestUnion() {}
+
+func (*WatchRequest_CancelRangeRequest) isWatchRequest_RequestUnion() {}
+
 type WatchCreateRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// key is the key to register for watching.
@@ -2125,7 +2293,104 @@ type WatchCreateRequest struct {
 	// use on the stream will cause an error to be returned.
 	WatchId int64 `protobuf:"varint,7,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
 	// fragment enables splitting large revisions into multiple watch responses.
-	Fragment      bool `protobuf:"varint,8,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	Fragment bool `protobuf:"varint,8,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// keys_only, if set, makes the server omit the values of the key-value pairs
+	// (including prev_kv) in the events sent to the watcher. The key, revisions,
+	// version, lease and event type are still returned.
+	KeysOnly bool `protobuf:"varint,9,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
+	// snapshot_fallback, if set, keeps the watcher alive when the revision it
+	// needs has been compacted. Instead of canceling the watcher with
+	// compact_revision, the server sends the current state of the watched range
+	// as PUT events in a response with snapshot set, and continues with the
+	// events after the revision in its header. The history between the
+	// compacted revision and the snapshot, including deletions, is lost.
+	SnapshotFallback bool `protobuf:"varint,10,opt,name=snapshot_fallback,json=snapshotFallback,proto3" json:"snapshot_fallback,omitempty"`
+	// latest_per_key, if set, makes the server send only the latest event of
+	// each key while the watcher catches up on the history from start_revision.
+	// Older revisions of a key are skipped, so the events of the catch-up are not
+	// a complete history. Once the watcher has caught up, every event is sent.
+	LatestPerKey bool `protobuf:"varint,11,opt,name=latest_per_key,json=latestPerKey,proto3" json:"latest_per_key,omitempty"`
+	// value_prefix, if set, makes the server send only the PUT events whose
+	// value starts with it. DELETE events carry no value and are not affected;
+	// use the NODELETE filter to drop them.
+	ValuePrefix []byte `protobuf:"bytes,12,opt,name=value_prefix,json=valuePrefix,proto3" json:"value_prefix,omitempty"`
+	// filter_order is the order in which the server evaluates the filters.
+	// Either order sends the same events; it only changes the cost of
+	// filtering. An event is dropped by the first filter that rejects it, so
+	// the later filters are not evaluated for it. Filtering happens before the
+	// previous key-value is fetched for prev_kv, so dropped events never cost a
+	// prev_kv lookup.
+	FilterOrder WatchCreateRequest_FilterOrder `protobuf:"varint,13,opt,name=filter_order,json=filterOrder,proto3,enum=etcdserverpb.WatchCreateRequest_FilterOrder" json:"filter_order,omitempty"`
+	// sample_every_n, if greater than 1, makes the server send only every Nth
+	// PUT event of each key, starting with the first one. DELETE events are
+	// always sent and restart the count of their key. The sampling applies to
+	// the events that pass the filters. Sampling is lossy: the skipped events
+	// are never sent, so it must not be used by consumers that need every
+	// change, such as caches or replicators.
+	SampleEveryN int64 `protobuf:"varint,14,opt,name=sample_every_n,json=sampleEveryN,proto3" json:"sample_every_n,omitempty"`
+	// max_events_per_response, if greater than 0, makes the server split the
+	// events it sends at once into multiple watch responses of at most that
+	// many events each. The events of a revision are never split, so a
+	// response may exceed the limit when a single revision has more events.
+	// Snapshot responses are not split. Each of those responses is still
+	// fragmented on its size if fragment is set.
+	MaxEventsPerResponse int64 `protobuf:"varint,15,opt,name=max_events_per_response,json=maxEventsPerResponse,proto3" json:"max_events_per_response,omitempty"`
+	// version_only, if set, makes the server send events that carry only the
+	// key, mod_revision and version of their key-value pair, along with the
+	// event type. The value, create_revision and lease are omitted and prev_kv
+	// is never returned, which keeps the events small for watchers that only
+	// need to know that a key changed. It supersedes keys_only.
+	VersionOnly bool `protobuf:"varint,16,opt,name=version_only,json=versionOnly,proto3" json:"version_only,omitempty"`
+	// compact_warning_margin, if greater than 0, makes the server warn the
+	// watcher when a compaction gets within that many revisions of the next
+	// revision the watcher has to receive, while it is catching up on the
+	// history. The warning is sent in compact_warning_revision, before the
+	// watcher falls behind the compaction and is canceled, so that the client
+	// gets a chance to speed up or to reset its state.
+	CompactWarningMargin int64 `protobuf:"varint,17,opt,name=compact_warning_margin,json=compactWarningMargin,proto3" json:"compact_warning_margin,omitempty"`
+	// keep_alive_interval, if greater than 0, makes the server send a heartbeat
+	// response on the stream of the watcher whenever nothing was sent on it for
+	// that many milliseconds, so that proxies and load balancers do not close
+	// the stream while it is idle. The stream uses the smallest interval of its
+	// watchers.
+	KeepAliveInterval int64 `protobuf:"varint,18,opt,name=keep_alive_interval,json=keepAliveInterval,proto3" json:"keep_alive_interval,omitempty"`
+	// linearizable_start, if true and start_revision is not set, makes the
+	// server wait for a linearizable read before creating the watcher, so that
+	// it starts from the current revision of the cluster rather than from the
+	// possibly stale revision of the member. The watcher is canceled if the
+	// read fails.
+	LinearizableStart bool `protobuf:"varint,19,opt,name=linearizable_start,json=linearizableStart,proto3" json:"linearizable_start,omitempty"`
+	// ack_window, if greater than 0, makes the server send the watcher only the
+	// events of the revisions up to ack_window past the last revision the client
+	// acknowledged with a WatchAckRequest. The revisions before the start of the
+	// watcher count as acknowledged. The server holds back the later events,
+	// without buffering them, until the client acknowledges more revisions.
+	AckWindow int64 `protobuf:"varint,20,opt,name=ack_window,json=ackWindow,proto3" json:"ack_window,omitempty"`
+	// value_selector, if set, makes the server send only the PUT events whose
+	// value is a JSON document matching it. It is a JSONPath-like predicate
+	// such as `$.spec.replicas > 2 && $.metadata.labels.app == "web"`; see the
+	// documentation of mvcc.NewValueSelectorFilter for its syntax. DELETE events
+	// are not affected. It is evaluated with value_prefix, according to
+	// filter_order. The server rejects the watcher with a cancel_reason if the
+	// selector is malformed, costs more than the server allows, or if the
+	// server does not enable the experimental WatchValueSelector feature.
+	ValueSelector string `protobuf:"bytes,21,opt,name=value_selector,json=valueSelector,proto3" json:"value_selector,omitempty"`
+	// collapse_deletes, if true, makes the server send a single DELETE_RANGE
+	// event in place of the DELETE events of the keys deleted by a delete range
+	// operation, for clients that only need to invalidate a cache. The event
+	// holds the part of the deleted range within the range of the watcher and
+	// the number of keys deleted in it, but neither the deleted keys nor their
+	// previous key-value pairs. Only the events sent while the watcher is
+	// synced are collapsed; the events read from the history, and the deletes
+	// of expired leases, are sent one key at a time. It does not apply to the
+	// watchers of a single key.
+	CollapseDeletes bool `protobuf:"varint,22,opt,name=collapse_deletes,json=collapseDeletes,proto3" json:"collapse_deletes,omitempty"`
+	// compare, if set, makes the watcher only receive the events that flip the
+	// result of the comparison, evaluated as in a txn, on the watched key. The
+	// watcher must be on the single key of the comparison. The results are
+	// reported with compare_result. It is experimental, and requires the
+	// WatchCompare feature gate.
+	Compare       *Compare `protobuf:"bytes,23,opt,name=compare,proto3" json:"compare,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2216,6 +2481,111 @@ func (x *WatchCreateRequest) GetFragment() bool {
 	return false
 }
 
+func (x *WatchCreateRequest) GetKeysOnly() bool {
+	if x != nil {
+		return x.KeysOnly
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetSnapshotFallback() bool {
+	if x != nil {
+		return x.SnapshotFallback
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetLatestPerKey() bool {
+	if x != nil {
+		return x.LatestPerKey
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetValuePrefix() []byte {
+	if x != nil {
+		return x.ValuePrefix
+	}
+	return nil
+}
+
+func (x *WatchCreateRequest) GetFilterOrder() WatchCreateRequest_FilterOrder {
+	if x != nil {
+		return x.FilterOrder
+	}
+	return WatchCreateRequest_TYPE_FIRST
+}
+
+func (x *WatchCreateRequest) GetSampleEveryN() int64 {
+	if x != nil {
+		return x.SampleEveryN
+	}
+	return 0
+}
+
+func (x *WatchCreateRequest) GetMaxEventsPerResponse() int64 {
+	if x != nil {
+		return x.MaxEventsPerResponse
+	}
+	return 0
+}
+
+func (x *WatchCreateRequest) GetVersionOnly() bool {
+	if x != nil {
+		return x.VersionOnly
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetCompactWarningMargin() int64 {
+	if x != nil {
+		return x.CompactWarningMargin
+	}
+	return 0
+}
+
+func (x *WatchCreateRequest) GetKeepAliveInterval() int64 {
+	if x != nil {
+		return x.KeepAliveInterval
+	}
+	return 0
+}
+
+func (x *WatchCreateRequest) GetLinearizableStart() bool {
+	if x != nil {
+		return x.LinearizableStart
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetAckWindow() int64 {
+	if x != nil {
+		return x.AckWindow
+	}
+	return 0
+}
+
+func (x *WatchCreateRequest) GetValueSelector() string {
+	if x != nil {
+		return x.ValueSelector
+	}
+	return ""
+}
+
+func (x *WatchCreateRequest) GetCollapseDeletes() bool {
+	if x != nil {
+		return x.CollapseDeletes
+	}
+	return false
+}
+
+func (x *WatchCreateRequest) GetCompare() *Compare {
+	if x != nil {
+		return x.Compare
+	}
+	return nil
+}
+
 type WatchCancelRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// watch_id is the watcher id to cancel so that no more events are transmitted.
@@ -2261,28 +2631,35 @@ func (x *WatchCancelRequest) GetWatchId() int64 {
 	return 0
 }
 
-// Requests the a watch stream progress status be sent in the watch response stream as soon as
-// possible.
-type WatchProgressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// Cancels every watcher of the stream whose keys are all in a key range, for
+// clients that lost track of the IDs of their watchers. A canceled response is
+// sent for each canceled watcher.
+type WatchCancelRangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// key is the first key of the range.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// range_end is the end of the range [key, range_end), following the same
+	// conventions as the range_end of a WatchCreateRequest. If range_end is not
+	// given, only the watchers of the single key are canceled.
+	RangeEnd      []byte `protobuf:"bytes,2,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchProgressRequest) Reset() {
-	*x = WatchProgressRequest{}
+func (x *WatchCancelRangeRequest) Reset() {
+	*x = WatchCancelRangeRequest{}
 	mi := &file_rpc_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchProgressRequest) String() string {
+func (x *WatchCancelRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchProgressRequest) ProtoMessage() {}
+func (*WatchCancelRangeRequest) ProtoMessage() {}
 
-func (x *WatchProgressRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchCancelRangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2294,57 +2671,50 @@ func (x *WatchProgressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchProgressRequest.ProtoReflect.Descriptor instead.
-func (*WatchProgressRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchCancelRangeRequest.ProtoReflect.Descriptor instead.
+func (*WatchCancelRangeRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_proto_rawDescGZIP(), []int{23}
 }
 
-type WatchResponse struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	Header *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	// watch_id is the ID of the watcher that corresponds to the response.
-	WatchId int64 `protobuf:"varint,2,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
-	// created is set to true if the response is for a create watch request.
-	// The client should record the watch_id and expect to receive events for
-	// the created watcher from the same stream.
-	// All events sent to the created watcher will attach with the same watch_id.
-	Created bool `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
-	// canceled is set to true if the response is for a cancel watch request
-	// or if the start_revision has already been compacted.
-	// No further events will be sent to the canceled watcher.
-	Canceled bool `protobuf:"varint,4,opt,name=canceled,proto3" json:"canceled,omitempty"`
-	// compact_revision is set to the minimum index if a watcher tries to watch
-	// at a compacted index.
-	//
-	// This happens when creating a watcher at a compacted revision or the watcher cannot
-	// catch up with the progress of the key-value store.
-	//
-	// The client should treat the watcher as canceled and should not try to create any
-	// watcher with the same start_revision again.
-	CompactRevision int64 `protobuf:"varint,5,opt,name=compact_revision,json=compactRevision,proto3" json:"compact_revision,omitempty"`
-	// cancel_reason indicates the reason for canceling the watcher.
-	CancelReason string `protobuf:"bytes,6,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
-	// framgment is true if large watch response was split over multiple responses.
-	Fragment      bool            `protobuf:"varint,7,opt,name=fragment,proto3" json:"fragment,omitempty"`
-	Events        []*mvccpb.Event `protobuf:"bytes,11,rep,name=events,proto3" json:"events,omitempty"`
+func (x *WatchCancelRangeRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *WatchCancelRangeRequest) GetRangeEnd() []byte {
+	if x != nil {
+		return x.RangeEnd
+	}
+	return nil
+}
+
+// Acknowledges the revisions a watcher created with an ack_window has processed.
+type WatchAckRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// watch_id is the ID of the watcher whose revisions are acknowledged.
+	WatchId int64 `protobuf:"varint,1,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	// revision is the highest revision the client has processed for the watcher.
+	Revision      int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchResponse) Reset() {
-	*x = WatchResponse{}
+func (x *WatchAckRequest) Reset() {
+	*x = WatchAckRequest{}
 	mi := &file_rpc_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WatchResponse) String() string {
+func (x *WatchAckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WatchResponse) ProtoMessage() {}
+func (*WatchAckRequest) ProtoMessage() {}
 
-func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+func (x *WatchAckRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2356,41 +2726,185 @@ func (x *WatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
-func (*WatchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchAckRequest.ProtoReflect.Descriptor instead.
+func (*WatchAckRequest) Descriptor() ([]byte, []int) {
 	return file_rpc_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *WatchResponse) GetHeader() *ResponseHeader {
-	if x != nil {
-		return x.Header
-	}
-	return nil
-}
-
-func (x *WatchResponse) GetWatchId() int64 {
+func (x *WatchAckRequest) GetWatchId() int64 {
 	if x != nil {
 		return x.WatchId
 	}
 	return 0
 }
 
-func (x *WatchResponse) GetCreated() bool {
+func (x *WatchAckRequest) GetRevision() int64 {
 	if x != nil {
-		return x.Created
+		return x.Revision
 	}
-	return false
+	return 0
 }
 
-func (x *WatchResponse) GetCanceled() bool {
-	if x != nil {
-		return x.Canceled
-	}
-	return false
+// Requests the a watch stream progress status be sent in the watch response stream as soon as
+// possible.
+type WatchProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WatchResponse) GetCompactRevision() int64 {
-	if x != nil {
+func (x *WatchProgressRequest) Reset() {
+	*x = WatchProgressRequest{}
+	mi := &file_rpc_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchProgressRequest) ProtoMessage() {}
+
+func (x *WatchProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchProgressRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{25}
+}
+
+type WatchResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Header *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// watch_id is the ID of the watcher that corresponds to the response.
+	WatchId int64 `protobuf:"varint,2,opt,name=watch_id,json=watchId,proto3" json:"watch_id,omitempty"`
+	// created is set to true if the response is for a create watch request.
+	// The client should record the watch_id and expect to receive events for
+	// the created watcher from the same stream.
+	// All events sent to the created watcher will attach with the same watch_id.
+	Created bool `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	// canceled is set to true if the response is for a cancel watch request
+	// or if the start_revision has already been compacted.
+	// No further events will be sent to the canceled watcher.
+	Canceled bool `protobuf:"varint,4,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	// compact_revision is set to the minimum index if a watcher tries to watch
+	// at a compacted index.
+	//
+	// This happens when creating a watcher at a compacted revision or the watcher cannot
+	// catch up with the progress of the key-value store.
+	//
+	// The client should treat the watcher as canceled and should not try to create any
+	// watcher with the same start_revision again.
+	CompactRevision int64 `protobuf:"varint,5,opt,name=compact_revision,json=compactRevision,proto3" json:"compact_revision,omitempty"`
+	// cancel_reason indicates the reason for canceling the watcher.
+	CancelReason string `protobuf:"bytes,6,opt,name=cancel_reason,json=cancelReason,proto3" json:"cancel_reason,omitempty"`
+	// framgment is true if large watch response was split over multiple responses.
+	Fragment bool `protobuf:"varint,7,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// snapshot is true if the events are the full state of the watched range
+	// at the header revision, sent to a watcher with snapshot_fallback because
+	// the revision it needed was compacted. The client should replace any state
+	// it has built for the range with these events.
+	Snapshot bool `protobuf:"varint,8,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// warning is set on the created response when the start_revision is so far
+	// behind the current revision that catching up requires a large scan of the
+	// history. It reports the expected number of revisions to catch up on. The
+	// client should rather get the range and watch from its revision.
+	Warning string `protobuf:"bytes,9,opt,name=warning,proto3" json:"warning,omitempty"`
+	// catch_up is true if the events are replayed from the history to a watcher
+	// that has not caught up with the current revision yet, rather than sent
+	// live as they happen. It is also set on snapshot responses.
+	CatchUp bool            `protobuf:"varint,10,opt,name=catch_up,json=catchUp,proto3" json:"catch_up,omitempty"`
+	Events  []*mvccpb.Event `protobuf:"bytes,11,rep,name=events,proto3" json:"events,omitempty"`
+	// compact_warning_revision is set to the revision of a compaction that got
+	// within compact_warning_margin revisions of the next revision the watcher
+	// has to receive. The watcher is not canceled, but it will be if it falls
+	// behind a later compaction. The header revision of a response that only
+	// carries the warning is the revision the watcher has received all the
+	// events up to.
+	CompactWarningRevision int64 `protobuf:"varint,12,opt,name=compact_warning_revision,json=compactWarningRevision,proto3" json:"compact_warning_revision,omitempty"`
+	// heartbeat is true if the response is only sent to keep an idle stream
+	// alive, to the watchers created with keep_alive_interval. Unlike a progress
+	// notification, it has watch_id -1 and no header revision, and carries no
+	// information about the progress of the watchers.
+	Heartbeat bool `protobuf:"varint,13,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	// compare_result is the result of the compare of the watcher at the header
+	// revision, for the watchers created with a compare. In the created
+	// response, it is the result before the start revision of the watcher.
+	CompareResult bool `protobuf:"varint,14,opt,name=compare_result,json=compareResult,proto3" json:"compare_result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
+	mi := &file_rpc_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResponse) ProtoMessage() {}
+
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *WatchResponse) GetWatchId() int64 {
+	if x != nil {
+		return x.WatchId
+	}
+	return 0
+}
+
+func (x *WatchResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *WatchResponse) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+func (x *WatchResponse) GetCompactRevision() int64 {
+	if x != nil {
 		return x.CompactRevision
 	}
 	return 0
@@ -2410,6 +2924,27 @@ func (x *WatchResponse) GetFragment() bool {
 	return false
 }
 
+func (x *WatchResponse) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *WatchResponse) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+func (x *WatchResponse) GetCatchUp() bool {
+	if x != nil {
+		return x.CatchUp
+	}
+	return false
+}
+
 func (x *WatchResponse) GetEvents() []*mvccpb.Event {
 	if x != nil {
 		return x.Events
@@ -2417,6 +2952,27 @@ func (x *WatchResponse) GetEvents() []*mvccpb.Event {
 	return nil
 }
 
+func (x *WatchResponse) GetCompactWarningRevision() int64 {
+	if x != nil {
+		return x.CompactWarningRevision
+	}
+	return 0
+}
+
+func (x *WatchResponse) GetHeartbeat() bool {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return false
+}
+
+func (x *WatchResponse) GetCompareResult() bool {
+	if x != nil {
+		return x.CompareResult
+	}
+	return false
+}
+
 type LeaseGrantRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// TTL is the advisory time-to-live in seconds. Expired lease will return -1.
@@ -2429,7 +2985,7 @@ type LeaseGrantRequest struct {
 
 func (x *LeaseGrantRequest) Reset() {
 	*x = LeaseGrantRequest{}
-	mi := &file_rpc_proto_msgTypes[25]
+	mi := &file_rpc_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2441,7 +2997,7 @@ func (x *LeaseGrantRequest) String() string {
 func (*LeaseGrantRequest) ProtoMessage() {}
 
 func (x *LeaseGrantRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[25]
+	mi := &file_rpc_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2454,7 +3010,7 @@ func (x *LeaseGrantRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseGrantRequest.ProtoReflect.Descriptor instead.
 func (*LeaseGrantRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{25}
+	return file_rpc_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *LeaseGrantRequest) GetTTL() int64 {
@@ -2477,15 +3033,18 @@ type LeaseGrantResponse struct {
 	// ID is the lease ID for the granted lease.
 	ID int64 `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
 	// TTL is the server chosen lease time-to-live in seconds.
-	TTL           int64  `protobuf:"varint,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	TTL   int64  `protobuf:"varint,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// epoch is the epoch of the granted lease, which keep alives of its holder may echo.
+	// It is 0 if the server does not support lease transfers.
+	Epoch         uint64 `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LeaseGrantResponse) Reset() {
 	*x = LeaseGrantResponse{}
-	mi := &file_rpc_proto_msgTypes[26]
+	mi := &file_rpc_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2497,7 +3056,7 @@ func (x *LeaseGrantResponse) String() string {
 func (*LeaseGrantResponse) ProtoMessage() {}
 
 func (x *LeaseGrantResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[26]
+	mi := &file_rpc_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2510,7 +3069,7 @@ func (x *LeaseGrantResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseGrantResponse.ProtoReflect.Descriptor instead.
 func (*LeaseGrantResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{26}
+	return file_rpc_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *LeaseGrantResponse) GetHeader() *ResponseHeader {
@@ -2541,6 +3100,13 @@ func (x *LeaseGrantResponse) GetError() string {
 	return ""
 }
 
+func (x *LeaseGrantResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type LeaseRevokeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ID is the lease ID to revoke. When the ID is revoked, all associated keys will be deleted.
@@ -2551,7 +3117,7 @@ type LeaseRevokeRequest struct {
 
 func (x *LeaseRevokeRequest) Reset() {
 	*x = LeaseRevokeRequest{}
-	mi := &file_rpc_proto_msgTypes[27]
+	mi := &file_rpc_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2563,7 +3129,7 @@ func (x *LeaseRevokeRequest) String() string {
 func (*LeaseRevokeRequest) ProtoMessage() {}
 
 func (x *LeaseRevokeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[27]
+	mi := &file_rpc_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2576,7 +3142,7 @@ func (x *LeaseRevokeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseRevokeRequest.ProtoReflect.Descriptor instead.
 func (*LeaseRevokeRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{27}
+	return file_rpc_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *LeaseRevokeRequest) GetID() int64 {
@@ -2595,7 +3161,7 @@ type LeaseRevokeResponse struct {
 
 func (x *LeaseRevokeResponse) Reset() {
 	*x = LeaseRevokeResponse{}
-	mi := &file_rpc_proto_msgTypes[28]
+	mi := &file_rpc_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2607,7 +3173,7 @@ func (x *LeaseRevokeResponse) String() string {
 func (*LeaseRevokeResponse) ProtoMessage() {}
 
 func (x *LeaseRevokeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[28]
+	mi := &file_rpc_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2620,7 +3186,7 @@ func (x *LeaseRevokeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseRevokeResponse.ProtoReflect.Descriptor instead.
 func (*LeaseRevokeResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{28}
+	return file_rpc_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *LeaseRevokeResponse) GetHeader() *ResponseHeader {
@@ -2642,7 +3208,7 @@ type LeaseCheckpoint struct {
 
 func (x *LeaseCheckpoint) Reset() {
 	*x = LeaseCheckpoint{}
-	mi := &file_rpc_proto_msgTypes[29]
+	mi := &file_rpc_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2654,7 +3220,7 @@ func (x *LeaseCheckpoint) String() string {
 func (*LeaseCheckpoint) ProtoMessage() {}
 
 func (x *LeaseCheckpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[29]
+	mi := &file_rpc_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2667,7 +3233,7 @@ func (x *LeaseCheckpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseCheckpoint.ProtoReflect.Descriptor instead.
 func (*LeaseCheckpoint) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{29}
+	return file_rpc_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *LeaseCheckpoint) GetID() int64 {
@@ -2693,7 +3259,7 @@ type LeaseCheckpointRequest struct {
 
 func (x *LeaseCheckpointRequest) Reset() {
 	*x = LeaseCheckpointRequest{}
-	mi := &file_rpc_proto_msgTypes[30]
+	mi := &file_rpc_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2705,7 +3271,7 @@ func (x *LeaseCheckpointRequest) String() string {
 func (*LeaseCheckpointRequest) ProtoMessage() {}
 
 func (x *LeaseCheckpointRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[30]
+	mi := &file_rpc_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2718,7 +3284,7 @@ func (x *LeaseCheckpointRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseCheckpointRequest.ProtoReflect.Descriptor instead.
 func (*LeaseCheckpointRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{30}
+	return file_rpc_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *LeaseCheckpointRequest) GetCheckpoints() []*LeaseCheckpoint {
@@ -2737,7 +3303,7 @@ type LeaseCheckpointResponse struct {
 
 func (x *LeaseCheckpointResponse) Reset() {
 	*x = LeaseCheckpointResponse{}
-	mi := &file_rpc_proto_msgTypes[31]
+	mi := &file_rpc_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2749,7 +3315,7 @@ func (x *LeaseCheckpointResponse) String() string {
 func (*LeaseCheckpointResponse) ProtoMessage() {}
 
 func (x *LeaseCheckpointResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[31]
+	mi := &file_rpc_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2762,7 +3328,7 @@ func (x *LeaseCheckpointResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseCheckpointResponse.ProtoReflect.Descriptor instead.
 func (*LeaseCheckpointResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{31}
+	return file_rpc_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *LeaseCheckpointResponse) GetHeader() *ResponseHeader {
@@ -2775,14 +3341,18 @@ func (x *LeaseCheckpointResponse) GetHeader() *ResponseHeader {
 type LeaseKeepAliveRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ID is the lease ID for the lease to keep alive.
-	ID            int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	ID int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// epoch is the epoch of the lease held by the client, as returned by LeaseGrant or LeaseTransfer.
+	// If set, the keep alive is rejected once the lease has been transferred to another holder.
+	// If 0, the lease is kept alive whatever its epoch.
+	Epoch         uint64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LeaseKeepAliveRequest) Reset() {
 	*x = LeaseKeepAliveRequest{}
-	mi := &file_rpc_proto_msgTypes[32]
+	mi := &file_rpc_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2794,7 +3364,7 @@ func (x *LeaseKeepAliveRequest) String() string {
 func (*LeaseKeepAliveRequest) ProtoMessage() {}
 
 func (x *LeaseKeepAliveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[32]
+	mi := &file_rpc_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2807,7 +3377,7 @@ func (x *LeaseKeepAliveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseKeepAliveRequest.ProtoReflect.Descriptor instead.
 func (*LeaseKeepAliveRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{32}
+	return file_rpc_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *LeaseKeepAliveRequest) GetID() int64 {
@@ -2817,6 +3387,13 @@ func (x *LeaseKeepAliveRequest) GetID() int64 {
 	return 0
 }
 
+func (x *LeaseKeepAliveRequest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type LeaseKeepAliveResponse struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	Header *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -2830,7 +3407,7 @@ type LeaseKeepAliveResponse struct {
 
 func (x *LeaseKeepAliveResponse) Reset() {
 	*x = LeaseKeepAliveResponse{}
-	mi := &file_rpc_proto_msgTypes[33]
+	mi := &file_rpc_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2842,7 +3419,7 @@ func (x *LeaseKeepAliveResponse) String() string {
 func (*LeaseKeepAliveResponse) ProtoMessage() {}
 
 func (x *LeaseKeepAliveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[33]
+	mi := &file_rpc_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2855,7 +3432,7 @@ func (x *LeaseKeepAliveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseKeepAliveResponse.ProtoReflect.Descriptor instead.
 func (*LeaseKeepAliveResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{33}
+	return file_rpc_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *LeaseKeepAliveResponse) GetHeader() *ResponseHeader {
@@ -2879,6 +3456,122 @@ func (x *LeaseKeepAliveResponse) GetTTL() int64 {
 	return 0
 }
 
+type LeaseTransferRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID is the lease ID of the lease to transfer.
+	ID            int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTransferRequest) Reset() {
+	*x = LeaseTransferRequest{}
+	mi := &file_rpc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTransferRequest) ProtoMessage() {}
+
+func (x *LeaseTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTransferRequest.ProtoReflect.Descriptor instead.
+func (*LeaseTransferRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LeaseTransferRequest) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+type LeaseTransferResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Header *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// ID is the lease ID of the transferred lease.
+	ID int64 `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	// TTL is the new time-to-live of the lease in seconds.
+	TTL int64 `protobuf:"varint,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	// epoch is the new epoch of the lease, which keep alives of its new holder should echo.
+	Epoch         uint64 `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseTransferResponse) Reset() {
+	*x = LeaseTransferResponse{}
+	mi := &file_rpc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseTransferResponse) ProtoMessage() {}
+
+func (x *LeaseTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseTransferResponse.ProtoReflect.Descriptor instead.
+func (*LeaseTransferResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *LeaseTransferResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *LeaseTransferResponse) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+func (x *LeaseTransferResponse) GetTTL() int64 {
+	if x != nil {
+		return x.TTL
+	}
+	return 0
+}
+
+func (x *LeaseTransferResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type LeaseTimeToLiveRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// ID is the lease ID for the lease.
@@ -2891,7 +3584,7 @@ type LeaseTimeToLiveRequest struct {
 
 func (x *LeaseTimeToLiveRequest) Reset() {
 	*x = LeaseTimeToLiveRequest{}
-	mi := &file_rpc_proto_msgTypes[34]
+	mi := &file_rpc_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2903,7 +3596,7 @@ func (x *LeaseTimeToLiveRequest) String() string {
 func (*LeaseTimeToLiveRequest) ProtoMessage() {}
 
 func (x *LeaseTimeToLiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[34]
+	mi := &file_rpc_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2916,7 +3609,7 @@ func (x *LeaseTimeToLiveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseTimeToLiveRequest.ProtoReflect.Descriptor instead.
 func (*LeaseTimeToLiveRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{34}
+	return file_rpc_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *LeaseTimeToLiveRequest) GetID() int64 {
@@ -2950,7 +3643,7 @@ type LeaseTimeToLiveResponse struct {
 
 func (x *LeaseTimeToLiveResponse) Reset() {
 	*x = LeaseTimeToLiveResponse{}
-	mi := &file_rpc_proto_msgTypes[35]
+	mi := &file_rpc_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2962,7 +3655,7 @@ func (x *LeaseTimeToLiveResponse) String() string {
 func (*LeaseTimeToLiveResponse) ProtoMessage() {}
 
 func (x *LeaseTimeToLiveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[35]
+	mi := &file_rpc_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2975,7 +3668,7 @@ func (x *LeaseTimeToLiveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseTimeToLiveResponse.ProtoReflect.Descriptor instead.
 func (*LeaseTimeToLiveResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{35}
+	return file_rpc_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *LeaseTimeToLiveResponse) GetHeader() *ResponseHeader {
@@ -3021,7 +3714,7 @@ type LeaseLeasesRequest struct {
 
 func (x *LeaseLeasesRequest) Reset() {
 	*x = LeaseLeasesRequest{}
-	mi := &file_rpc_proto_msgTypes[36]
+	mi := &file_rpc_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3033,7 +3726,7 @@ func (x *LeaseLeasesRequest) String() string {
 func (*LeaseLeasesRequest) ProtoMessage() {}
 
 func (x *LeaseLeasesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[36]
+	mi := &file_rpc_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3046,7 +3739,7 @@ func (x *LeaseLeasesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaseLeasesRequest.ProtoReflect.Descriptor instead.
 func (*LeaseLeasesRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{36}
+	return file_rpc_proto_rawDescGZIP(), []int{40}
 }
 
 type LeaseStatus struct {
@@ -3058,7 +3751,7 @@ type LeaseStatus struct {
 
 func (x *LeaseStatus) Reset() {
 	*x = LeaseStatus{}
-	mi := &file_rpc_proto_msgTypes[37]
+	mi := &file_rpc_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3070,7 +3763,155 @@ func (x *LeaseStatus) String() string {
 func (*LeaseStatus) ProtoMessage() {}
 
 func (x *LeaseStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[37]
+	mi := &file_rpc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseStatus.ProtoReflect.Descriptor instead.
+func (*LeaseStatus) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *LeaseStatus) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+type LeaseLeasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Leases        []*LeaseStatus         `protobuf:"bytes,2,rep,name=leases,proto3" json:"leases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseLeasesResponse) Reset() {
+	*x = LeaseLeasesResponse{}
+	mi := &file_rpc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseLeasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseLeasesResponse) ProtoMessage() {}
+
+func (x *LeaseLeasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseLeasesResponse.ProtoReflect.Descriptor instead.
+func (*LeaseLeasesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *LeaseLeasesResponse) GetHeader() *ResponseHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *LeaseLeasesResponse) GetLeases() []*LeaseStatus {
+	if x != nil {
+		return x.Leases
+	}
+	return nil
+}
+
+type LeaseWatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID is the lease ID of the lease to watch. If 0, the events of all leases are watched.
+	ID            int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseWatchRequest) Reset() {
+	*x = LeaseWatchRequest{}
+	mi := &file_rpc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseWatchRequest) ProtoMessage() {}
+
+func (x *LeaseWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseWatchRequest.ProtoReflect.Descriptor instead.
+func (*LeaseWatchRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *LeaseWatchRequest) GetID() int64 {
+	if x != nil {
+		return x.ID
+	}
+	return 0
+}
+
+type LeaseEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is the kind of event. A GRANTED event is sent when a lease is granted, a REVOKED event
+	// when it is revoked by a client, and an EXPIRED event when it is revoked because its TTL has
+	// elapsed.
+	Type LeaseEvent_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=etcdserverpb.LeaseEvent_EventType" json:"type,omitempty"`
+	// ID is the lease ID of the lease the event is about.
+	ID int64 `protobuf:"varint,2,opt,name=ID,proto3" json:"ID,omitempty"`
+	// TTL is the granted time-to-live of the lease in seconds.
+	TTL           int64 `protobuf:"varint,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseEvent) Reset() {
+	*x = LeaseEvent{}
+	mi := &file_rpc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseEvent) ProtoMessage() {}
+
+func (x *LeaseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3081,41 +3922,55 @@ func (x *LeaseStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaseStatus.ProtoReflect.Descriptor instead.
-func (*LeaseStatus) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use LeaseEvent.ProtoReflect.Descriptor instead.
+func (*LeaseEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *LeaseStatus) GetID() int64 {
+func (x *LeaseEvent) GetType() LeaseEvent_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return LeaseEvent_GRANTED
+}
+
+func (x *LeaseEvent) GetID() int64 {
 	if x != nil {
 		return x.ID
 	}
 	return 0
 }
 
-type LeaseLeasesResponse struct {
+func (x *LeaseEvent) GetTTL() int64 {
+	if x != nil {
+		return x.TTL
+	}
+	return 0
+}
+
+type LeaseWatchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *ResponseHeader        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Leases        []*LeaseStatus         `protobuf:"bytes,2,rep,name=leases,proto3" json:"leases,omitempty"`
+	Events        []*LeaseEvent          `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaseLeasesResponse) Reset() {
-	*x = LeaseLeasesResponse{}
-	mi := &file_rpc_proto_msgTypes[38]
+func (x *LeaseWatchResponse) Reset() {
+	*x = LeaseWatchResponse{}
+	mi := &file_rpc_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaseLeasesResponse) String() string {
+func (x *LeaseWatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaseLeasesResponse) ProtoMessage() {}
+func (*LeaseWatchResponse) ProtoMessage() {}
 
-func (x *LeaseLeasesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[38]
+func (x *LeaseWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3126,21 +3981,21 @@ func (x *LeaseLeasesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaseLeasesResponse.ProtoReflect.Descriptor instead.
-func (*LeaseLeasesResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use LeaseWatchResponse.ProtoReflect.Descriptor instead.
+func (*LeaseWatchResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *LeaseLeasesResponse) GetHeader() *ResponseHeader {
+func (x *LeaseWatchResponse) GetHeader() *ResponseHeader {
 	if x != nil {
 		return x.Header
 	}
 	return nil
 }
 
-func (x *LeaseLeasesResponse) GetLeases() []*LeaseStatus {
+func (x *LeaseWatchResponse) GetEvents() []*LeaseEvent {
 	if x != nil {
-		return x.Leases
+		return x.Events
 	}
 	return nil
 }
@@ -3163,7 +4018,7 @@ type Member struct {
 
 func (x *Member) Reset() {
 	*x = Member{}
-	mi := &file_rpc_proto_msgTypes[39]
+	mi := &file_rpc_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3175,7 +4030,7 @@ func (x *Member) String() string {
 func (*Member) ProtoMessage() {}
 
 func (x *Member) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[39]
+	mi := &file_rpc_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3188,7 +4043,7 @@ func (x *Member) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Member.ProtoReflect.Descriptor instead.
 func (*Member) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{39}
+	return file_rpc_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *Member) GetID() uint64 {
@@ -3238,7 +4093,7 @@ type MemberAddRequest struct {
 
 func (x *MemberAddRequest) Reset() {
 	*x = MemberAddRequest{}
-	mi := &file_rpc_proto_msgTypes[40]
+	mi := &file_rpc_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3250,7 +4105,7 @@ func (x *MemberAddRequest) String() string {
 func (*MemberAddRequest) ProtoMessage() {}
 
 func (x *MemberAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[40]
+	mi := &file_rpc_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3263,7 +4118,7 @@ func (x *MemberAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberAddRequest.ProtoReflect.Descriptor instead.
 func (*MemberAddRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{40}
+	return file_rpc_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *MemberAddRequest) GetPeerURLs() []string {
@@ -3293,7 +4148,7 @@ type MemberAddResponse struct {
 
 func (x *MemberAddResponse) Reset() {
 	*x = MemberAddResponse{}
-	mi := &file_rpc_proto_msgTypes[41]
+	mi := &file_rpc_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3305,7 +4160,7 @@ func (x *MemberAddResponse) String() string {
 func (*MemberAddResponse) ProtoMessage() {}
 
 func (x *MemberAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[41]
+	mi := &file_rpc_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3318,7 +4173,7 @@ func (x *MemberAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberAddResponse.ProtoReflect.Descriptor instead.
 func (*MemberAddResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{41}
+	return file_rpc_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *MemberAddResponse) GetHeader() *ResponseHeader {
@@ -3352,7 +4207,7 @@ type MemberRemoveRequest struct {
 
 func (x *MemberRemoveRequest) Reset() {
 	*x = MemberRemoveRequest{}
-	mi := &file_rpc_proto_msgTypes[42]
+	mi := &file_rpc_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3364,7 +4219,7 @@ func (x *MemberRemoveRequest) String() string {
 func (*MemberRemoveRequest) ProtoMessage() {}
 
 func (x *MemberRemoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[42]
+	mi := &file_rpc_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3377,7 +4232,7 @@ func (x *MemberRemoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberRemoveRequest.ProtoReflect.Descriptor instead.
 func (*MemberRemoveRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{42}
+	return file_rpc_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *MemberRemoveRequest) GetID() uint64 {
@@ -3398,7 +4253,7 @@ type MemberRemoveResponse struct {
 
 func (x *MemberRemoveResponse) Reset() {
 	*x = MemberRemoveResponse{}
-	mi := &file_rpc_proto_msgTypes[43]
+	mi := &file_rpc_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3410,7 +4265,7 @@ func (x *MemberRemoveResponse) String() string {
 func (*MemberRemoveResponse) ProtoMessage() {}
 
 func (x *MemberRemoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[43]
+	mi := &file_rpc_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3423,7 +4278,7 @@ func (x *MemberRemoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberRemoveResponse.ProtoReflect.Descriptor instead.
 func (*MemberRemoveResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{43}
+	return file_rpc_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *MemberRemoveResponse) GetHeader() *ResponseHeader {
@@ -3452,7 +4307,7 @@ type MemberUpdateRequest struct {
 
 func (x *MemberUpdateRequest) Reset() {
 	*x = MemberUpdateRequest{}
-	mi := &file_rpc_proto_msgTypes[44]
+	mi := &file_rpc_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3464,7 +4319,7 @@ func (x *MemberUpdateRequest) String() string {
 func (*MemberUpdateRequest) ProtoMessage() {}
 
 func (x *MemberUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[44]
+	mi := &file_rpc_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3477,7 +4332,7 @@ func (x *MemberUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberUpdateRequest.ProtoReflect.Descriptor instead.
 func (*MemberUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{44}
+	return file_rpc_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *MemberUpdateRequest) GetID() uint64 {
@@ -3505,7 +4360,7 @@ type MemberUpdateResponse struct {
 
 func (x *MemberUpdateResponse) Reset() {
 	*x = MemberUpdateResponse{}
-	mi := &file_rpc_proto_msgTypes[45]
+	mi := &file_rpc_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3517,7 +4372,7 @@ func (x *MemberUpdateResponse) String() string {
 func (*MemberUpdateResponse) ProtoMessage() {}
 
 func (x *MemberUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[45]
+	mi := &file_rpc_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3530,7 +4385,7 @@ func (x *MemberUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberUpdateResponse.ProtoReflect.Descriptor instead.
 func (*MemberUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{45}
+	return file_rpc_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *MemberUpdateResponse) GetHeader() *ResponseHeader {
@@ -3556,7 +4411,7 @@ type MemberListRequest struct {
 
 func (x *MemberListRequest) Reset() {
 	*x = MemberListRequest{}
-	mi := &file_rpc_proto_msgTypes[46]
+	mi := &file_rpc_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3568,7 +4423,7 @@ func (x *MemberListRequest) String() string {
 func (*MemberListRequest) ProtoMessage() {}
 
 func (x *MemberListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[46]
+	mi := &file_rpc_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3581,7 +4436,7 @@ func (x *MemberListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberListRequest.ProtoReflect.Descriptor instead.
 func (*MemberListRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{46}
+	return file_rpc_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *MemberListRequest) GetLinearizable() bool {
@@ -3602,7 +4457,7 @@ type MemberListResponse struct {
 
 func (x *MemberListResponse) Reset() {
 	*x = MemberListResponse{}
-	mi := &file_rpc_proto_msgTypes[47]
+	mi := &file_rpc_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3614,7 +4469,7 @@ func (x *MemberListResponse) String() string {
 func (*MemberListResponse) ProtoMessage() {}
 
 func (x *MemberListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[47]
+	mi := &file_rpc_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3627,7 +4482,7 @@ func (x *MemberListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberListResponse.ProtoReflect.Descriptor instead.
 func (*MemberListResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{47}
+	return file_rpc_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *MemberListResponse) GetHeader() *ResponseHeader {
@@ -3654,7 +4509,7 @@ type MemberPromoteRequest struct {
 
 func (x *MemberPromoteRequest) Reset() {
 	*x = MemberPromoteRequest{}
-	mi := &file_rpc_proto_msgTypes[48]
+	mi := &file_rpc_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3666,7 +4521,7 @@ func (x *MemberPromoteRequest) String() string {
 func (*MemberPromoteRequest) ProtoMessage() {}
 
 func (x *MemberPromoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[48]
+	mi := &file_rpc_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3679,7 +4534,7 @@ func (x *MemberPromoteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberPromoteRequest.ProtoReflect.Descriptor instead.
 func (*MemberPromoteRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{48}
+	return file_rpc_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *MemberPromoteRequest) GetID() uint64 {
@@ -3700,7 +4555,7 @@ type MemberPromoteResponse struct {
 
 func (x *MemberPromoteResponse) Reset() {
 	*x = MemberPromoteResponse{}
-	mi := &file_rpc_proto_msgTypes[49]
+	mi := &file_rpc_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3712,7 +4567,7 @@ func (x *MemberPromoteResponse) String() string {
 func (*MemberPromoteResponse) ProtoMessage() {}
 
 func (x *MemberPromoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[49]
+	mi := &file_rpc_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3725,7 +4580,7 @@ func (x *MemberPromoteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemberPromoteResponse.ProtoReflect.Descriptor instead.
 func (*MemberPromoteResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{49}
+	return file_rpc_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *MemberPromoteResponse) GetHeader() *ResponseHeader {
@@ -3750,7 +4605,7 @@ type DefragmentRequest struct {
 
 func (x *DefragmentRequest) Reset() {
 	*x = DefragmentRequest{}
-	mi := &file_rpc_proto_msgTypes[50]
+	mi := &file_rpc_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3762,7 +4617,7 @@ func (x *DefragmentRequest) String() string {
 func (*DefragmentRequest) ProtoMessage() {}
 
 func (x *DefragmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[50]
+	mi := &file_rpc_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3775,7 +4630,7 @@ func (x *DefragmentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DefragmentRequest.ProtoReflect.Descriptor instead.
 func (*DefragmentRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{50}
+	return file_rpc_proto_rawDescGZIP(), []int{57}
 }
 
 type DefragmentResponse struct {
@@ -3787,7 +4642,7 @@ type DefragmentResponse struct {
 
 func (x *DefragmentResponse) Reset() {
 	*x = DefragmentResponse{}
-	mi := &file_rpc_proto_msgTypes[51]
+	mi := &file_rpc_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3799,7 +4654,7 @@ func (x *DefragmentResponse) String() string {
 func (*DefragmentResponse) ProtoMessage() {}
 
 func (x *DefragmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[51]
+	mi := &file_rpc_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3812,7 +4667,7 @@ func (x *DefragmentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DefragmentResponse.ProtoReflect.Descriptor instead.
 func (*DefragmentResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{51}
+	return file_rpc_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *DefragmentResponse) GetHeader() *ResponseHeader {
@@ -3832,7 +4687,7 @@ type MoveLeaderRequest struct {
 
 func (x *MoveLeaderRequest) Reset() {
 	*x = MoveLeaderRequest{}
-	mi := &file_rpc_proto_msgTypes[52]
+	mi := &file_rpc_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3844,7 +4699,7 @@ func (x *MoveLeaderRequest) String() string {
 func (*MoveLeaderRequest) ProtoMessage() {}
 
 func (x *MoveLeaderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[52]
+	mi := &file_rpc_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3857,7 +4712,7 @@ func (x *MoveLeaderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveLeaderRequest.ProtoReflect.Descriptor instead.
 func (*MoveLeaderRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{52}
+	return file_rpc_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *MoveLeaderRequest) GetTargetID() uint64 {
@@ -3876,7 +4731,7 @@ type MoveLeaderResponse struct {
 
 func (x *MoveLeaderResponse) Reset() {
 	*x = MoveLeaderResponse{}
-	mi := &file_rpc_proto_msgTypes[53]
+	mi := &file_rpc_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3888,7 +4743,7 @@ func (x *MoveLeaderResponse) String() string {
 func (*MoveLeaderResponse) ProtoMessage() {}
 
 func (x *MoveLeaderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[53]
+	mi := &file_rpc_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3901,7 +4756,7 @@ func (x *MoveLeaderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveLeaderResponse.ProtoReflect.Descriptor instead.
 func (*MoveLeaderResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{53}
+	return file_rpc_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *MoveLeaderResponse) GetHeader() *ResponseHeader {
@@ -3928,7 +4783,7 @@ type AlarmRequest struct {
 
 func (x *AlarmRequest) Reset() {
 	*x = AlarmRequest{}
-	mi := &file_rpc_proto_msgTypes[54]
+	mi := &file_rpc_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3940,7 +4795,7 @@ func (x *AlarmRequest) String() string {
 func (*AlarmRequest) ProtoMessage() {}
 
 func (x *AlarmRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[54]
+	mi := &file_rpc_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3953,7 +4808,7 @@ func (x *AlarmRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlarmRequest.ProtoReflect.Descriptor instead.
 func (*AlarmRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{54}
+	return file_rpc_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *AlarmRequest) GetAction() AlarmRequest_AlarmAction {
@@ -3989,7 +4844,7 @@ type AlarmMember struct {
 
 func (x *AlarmMember) Reset() {
 	*x = AlarmMember{}
-	mi := &file_rpc_proto_msgTypes[55]
+	mi := &file_rpc_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4001,7 +4856,7 @@ func (x *AlarmMember) String() string {
 func (*AlarmMember) ProtoMessage() {}
 
 func (x *AlarmMember) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[55]
+	mi := &file_rpc_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4014,7 +4869,7 @@ func (x *AlarmMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlarmMember.ProtoReflect.Descriptor instead.
 func (*AlarmMember) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{55}
+	return file_rpc_proto_rawDescGZIP(), []int{62}
 }
 
 func (x *AlarmMember) GetMemberID() uint64 {
@@ -4042,7 +4897,7 @@ type AlarmResponse struct {
 
 func (x *AlarmResponse) Reset() {
 	*x = AlarmResponse{}
-	mi := &file_rpc_proto_msgTypes[56]
+	mi := &file_rpc_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4054,7 +4909,7 @@ func (x *AlarmResponse) String() string {
 func (*AlarmResponse) ProtoMessage() {}
 
 func (x *AlarmResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[56]
+	mi := &file_rpc_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4067,7 +4922,7 @@ func (x *AlarmResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AlarmResponse.ProtoReflect.Descriptor instead.
 func (*AlarmResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{56}
+	return file_rpc_proto_rawDescGZIP(), []int{63}
 }
 
 func (x *AlarmResponse) GetHeader() *ResponseHeader {
@@ -4098,7 +4953,7 @@ type DowngradeRequest struct {
 
 func (x *DowngradeRequest) Reset() {
 	*x = DowngradeRequest{}
-	mi := &file_rpc_proto_msgTypes[57]
+	mi := &file_rpc_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4110,7 +4965,7 @@ func (x *DowngradeRequest) String() string {
 func (*DowngradeRequest) ProtoMessage() {}
 
 func (x *DowngradeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[57]
+	mi := &file_rpc_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4123,7 +4978,7 @@ func (x *DowngradeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DowngradeRequest.ProtoReflect.Descriptor instead.
 func (*DowngradeRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{57}
+	return file_rpc_proto_rawDescGZIP(), []int{64}
 }
 
 func (x *DowngradeRequest) GetAction() DowngradeRequest_DowngradeAction {
@@ -4151,7 +5006,7 @@ type DowngradeResponse struct {
 
 func (x *DowngradeResponse) Reset() {
 	*x = DowngradeResponse{}
-	mi := &file_rpc_proto_msgTypes[58]
+	mi := &file_rpc_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4163,7 +5018,7 @@ func (x *DowngradeResponse) String() string {
 func (*DowngradeResponse) ProtoMessage() {}
 
 func (x *DowngradeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[58]
+	mi := &file_rpc_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4176,7 +5031,7 @@ func (x *DowngradeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DowngradeResponse.ProtoReflect.Descriptor instead.
 func (*DowngradeResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{58}
+	return file_rpc_proto_rawDescGZIP(), []int{65}
 }
 
 func (x *DowngradeResponse) GetHeader() *ResponseHeader {
@@ -4206,7 +5061,7 @@ type DowngradeVersionTestRequest struct {
 
 func (x *DowngradeVersionTestRequest) Reset() {
 	*x = DowngradeVersionTestRequest{}
-	mi := &file_rpc_proto_msgTypes[59]
+	mi := &file_rpc_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4218,7 +5073,7 @@ func (x *DowngradeVersionTestRequest) String() string {
 func (*DowngradeVersionTestRequest) ProtoMessage() {}
 
 func (x *DowngradeVersionTestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[59]
+	mi := &file_rpc_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4231,7 +5086,7 @@ func (x *DowngradeVersionTestRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DowngradeVersionTestRequest.ProtoReflect.Descriptor instead.
 func (*DowngradeVersionTestRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{59}
+	return file_rpc_proto_rawDescGZIP(), []int{66}
 }
 
 func (x *DowngradeVersionTestRequest) GetVer() string {
@@ -4249,7 +5104,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_rpc_proto_msgTypes[60]
+	mi := &file_rpc_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4261,7 +5116,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[60]
+	mi := &file_rpc_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4274,7 +5129,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{60}
+	return file_rpc_proto_rawDescGZIP(), []int{67}
 }
 
 type StatusResponse struct {
@@ -4304,13 +5159,29 @@ type StatusResponse struct {
 	DbSizeQuota int64 `protobuf:"varint,12,opt,name=dbSizeQuota,proto3" json:"dbSizeQuota,omitempty"`
 	// downgradeInfo indicates if there is downgrade process.
 	DowngradeInfo *DowngradeInfo `protobuf:"bytes,13,opt,name=downgradeInfo,proto3" json:"downgradeInfo,omitempty"`
+	// compactRevision is the revision of the last compaction of the responding member.
+	CompactRevision int64 `protobuf:"varint,14,opt,name=compactRevision,proto3" json:"compactRevision,omitempty"`
+	// maxClockSkew is the maximum estimated clock skew, in nanoseconds, between any two members
+	// of the cluster, as observed by the responding member.
+	MaxClockSkew int64 `protobuf:"varint,15,opt,name=maxClockSkew,proto3" json:"maxClockSkew,omitempty"`
+	// valueEncoding is the encoding the responding member writes values with.
+	ValueEncoding string `protobuf:"bytes,16,opt,name=valueEncoding,proto3" json:"valueEncoding,omitempty"`
+	// valueEncodingMigrationPercent is the percentage of the values stored before valueEncoding was
+	// configured that the responding member has migrated to it.
+	ValueEncodingMigrationPercent float64 `protobuf:"fixed64,17,opt,name=valueEncodingMigrationPercent,proto3" json:"valueEncodingMigrationPercent,omitempty"`
+	// diskUsage is the disk usage of the WAL and snapshot files of the responding member.
+	DiskUsage *DiskUsage `protobuf:"bytes,18,opt,name=diskUsage,proto3" json:"diskUsage,omitempty"`
+	// goVersion is the version of the Go runtime the responding member was built with.
+	GoVersion string `protobuf:"bytes,19,opt,name=goVersion,proto3" json:"goVersion,omitempty"`
+	// gitSha is the git commit the responding member was built from.
+	GitSha        string `protobuf:"bytes,20,opt,name=gitSha,proto3" json:"gitSha,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StatusResponse) Reset() {
 	*x = StatusResponse{}
-	mi := &file_rpc_proto_msgTypes[61]
+	mi := &file_rpc_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4322,7 +5193,7 @@ func (x *StatusResponse) String() string {
 func (*StatusResponse) ProtoMessage() {}
 
 func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[61]
+	mi := &file_rpc_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4335,7 +5206,7 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{61}
+	return file_rpc_proto_rawDescGZIP(), []int{68}
 }
 
 func (x *StatusResponse) GetHeader() *ResponseHeader {
@@ -4366,67 +5237,197 @@ func (x *StatusResponse) GetLeader() uint64 {
 	return 0
 }
 
-func (x *StatusResponse) GetRaftIndex() uint64 {
+func (x *StatusResponse) GetRaftIndex() uint64 {
+	if x != nil {
+		return x.RaftIndex
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetRaftTerm() uint64 {
+	if x != nil {
+		return x.RaftTerm
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetRaftAppliedIndex() uint64 {
+	if x != nil {
+		return x.RaftAppliedIndex
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetDbSizeInUse() int64 {
+	if x != nil {
+		return x.DbSizeInUse
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetIsLearner() bool {
+	if x != nil {
+		return x.IsLearner
+	}
+	return false
+}
+
+func (x *StatusResponse) GetStorageVersion() string {
+	if x != nil {
+		return x.StorageVersion
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetDbSizeQuota() int64 {
+	if x != nil {
+		return x.DbSizeQuota
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDowngradeInfo() *DowngradeInfo {
+	if x != nil {
+		return x.DowngradeInfo
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetCompactRevision() int64 {
+	if x != nil {
+		return x.CompactRevision
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetMaxClockSkew() int64 {
+	if x != nil {
+		return x.MaxClockSkew
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetValueEncoding() string {
+	if x != nil {
+		return x.ValueEncoding
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetValueEncodingMigrationPercent() float64 {
+	if x != nil {
+		return x.ValueEncodingMigrationPercent
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDiskUsage() *DiskUsage {
 	if x != nil {
-		return x.RaftIndex
+		return x.DiskUsage
 	}
-	return 0
+	return nil
 }
 
-func (x *StatusResponse) GetRaftTerm() uint64 {
+func (x *StatusResponse) GetGoVersion() string {
 	if x != nil {
-		return x.RaftTerm
+		return x.GoVersion
 	}
-	return 0
+	return ""
 }
 
-func (x *StatusResponse) GetRaftAppliedIndex() uint64 {
+func (x *StatusResponse) GetGitSha() string {
 	if x != nil {
-		return x.RaftAppliedIndex
+		return x.GitSha
 	}
-	return 0
+	return ""
 }
 
-func (x *StatusResponse) GetErrors() []string {
+type DiskUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// walFileCount is the number of WAL files retained by the member.
+	WalFileCount int64 `protobuf:"varint,1,opt,name=walFileCount,proto3" json:"walFileCount,omitempty"`
+	// walSize is the total size of the WAL files, in bytes.
+	WalSize int64 `protobuf:"varint,2,opt,name=walSize,proto3" json:"walSize,omitempty"`
+	// oldestWalIndex is the raft index of the first entry of the oldest retained WAL file.
+	OldestWalIndex uint64 `protobuf:"varint,3,opt,name=oldestWalIndex,proto3" json:"oldestWalIndex,omitempty"`
+	// snapshotFileCount is the number of snapshot files retained by the member.
+	SnapshotFileCount int64 `protobuf:"varint,4,opt,name=snapshotFileCount,proto3" json:"snapshotFileCount,omitempty"`
+	// snapshotSize is the total size of the snapshot files, in bytes.
+	SnapshotSize  int64 `protobuf:"varint,5,opt,name=snapshotSize,proto3" json:"snapshotSize,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiskUsage) Reset() {
+	*x = DiskUsage{}
+	mi := &file_rpc_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiskUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskUsage) ProtoMessage() {}
+
+func (x *DiskUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_proto_msgTypes[69]
 	if x != nil {
-		return x.Errors
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *StatusResponse) GetDbSizeInUse() int64 {
+// Deprecated: Use DiskUsage.ProtoReflect.Descriptor instead.
+func (*DiskUsage) Descriptor() ([]byte, []int) {
+	return file_rpc_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DiskUsage) GetWalFileCount() int64 {
 	if x != nil {
-		return x.DbSizeInUse
+		return x.WalFileCount
 	}
 	return 0
 }
 
-func (x *StatusResponse) GetIsLearner() bool {
+func (x *DiskUsage) GetWalSize() int64 {
 	if x != nil {
-		return x.IsLearner
+		return x.WalSize
 	}
-	return false
+	return 0
 }
 
-func (x *StatusResponse) GetStorageVersion() string {
+func (x *DiskUsage) GetOldestWalIndex() uint64 {
 	if x != nil {
-		return x.StorageVersion
+		return x.OldestWalIndex
 	}
-	return ""
+	return 0
 }
 
-func (x *StatusResponse) GetDbSizeQuota() int64 {
+func (x *DiskUsage) GetSnapshotFileCount() int64 {
 	if x != nil {
-		return x.DbSizeQuota
+		return x.SnapshotFileCount
 	}
 	return 0
 }
 
-func (x *StatusResponse) GetDowngradeInfo() *DowngradeInfo {
+func (x *DiskUsage) GetSnapshotSize() int64 {
 	if x != nil {
-		return x.DowngradeInfo
+		return x.SnapshotSize
 	}
-	return nil
+	return 0
 }
 
 type DowngradeInfo struct {
@@ -4441,7 +5442,7 @@ type DowngradeInfo struct {
 
 func (x *DowngradeInfo) Reset() {
 	*x = DowngradeInfo{}
-	mi := &file_rpc_proto_msgTypes[62]
+	mi := &file_rpc_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4453,7 +5454,7 @@ func (x *DowngradeInfo) String() string {
 func (*DowngradeInfo) ProtoMessage() {}
 
 func (x *DowngradeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[62]
+	mi := &file_rpc_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4466,7 +5467,7 @@ func (x *DowngradeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DowngradeInfo.ProtoReflect.Descriptor instead.
 func (*DowngradeInfo) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{62}
+	return file_rpc_proto_rawDescGZIP(), []int{70}
 }
 
 func (x *DowngradeInfo) GetEnabled() bool {
@@ -4491,7 +5492,7 @@ type AuthEnableRequest struct {
 
 func (x *AuthEnableRequest) Reset() {
 	*x = AuthEnableRequest{}
-	mi := &file_rpc_proto_msgTypes[63]
+	mi := &file_rpc_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4503,7 +5504,7 @@ func (x *AuthEnableRequest) String() string {
 func (*AuthEnableRequest) ProtoMessage() {}
 
 func (x *AuthEnableRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[63]
+	mi := &file_rpc_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4516,7 +5517,7 @@ func (x *AuthEnableRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthEnableRequest.ProtoReflect.Descriptor instead.
 func (*AuthEnableRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{63}
+	return file_rpc_proto_rawDescGZIP(), []int{71}
 }
 
 type AuthDisableRequest struct {
@@ -4527,7 +5528,7 @@ type AuthDisableRequest struct {
 
 func (x *AuthDisableRequest) Reset() {
 	*x = AuthDisableRequest{}
-	mi := &file_rpc_proto_msgTypes[64]
+	mi := &file_rpc_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4539,7 +5540,7 @@ func (x *AuthDisableRequest) String() string {
 func (*AuthDisableRequest) ProtoMessage() {}
 
 func (x *AuthDisableRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[64]
+	mi := &file_rpc_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4552,7 +5553,7 @@ func (x *AuthDisableRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthDisableRequest.ProtoReflect.Descriptor instead.
 func (*AuthDisableRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{64}
+	return file_rpc_proto_rawDescGZIP(), []int{72}
 }
 
 type AuthStatusRequest struct {
@@ -4563,7 +5564,7 @@ type AuthStatusRequest struct {
 
 func (x *AuthStatusRequest) Reset() {
 	*x = AuthStatusRequest{}
-	mi := &file_rpc_proto_msgTypes[65]
+	mi := &file_rpc_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4575,7 +5576,7 @@ func (x *AuthStatusRequest) String() string {
 func (*AuthStatusRequest) ProtoMessage() {}
 
 func (x *AuthStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[65]
+	mi := &file_rpc_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4588,7 +5589,7 @@ func (x *AuthStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthStatusRequest.ProtoReflect.Descriptor instead.
 func (*AuthStatusRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{65}
+	return file_rpc_proto_rawDescGZIP(), []int{73}
 }
 
 type AuthenticateRequest struct {
@@ -4601,7 +5602,7 @@ type AuthenticateRequest struct {
 
 func (x *AuthenticateRequest) Reset() {
 	*x = AuthenticateRequest{}
-	mi := &file_rpc_proto_msgTypes[66]
+	mi := &file_rpc_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4613,7 +5614,7 @@ func (x *AuthenticateRequest) String() string {
 func (*AuthenticateRequest) ProtoMessage() {}
 
 func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[66]
+	mi := &file_rpc_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4626,7 +5627,7 @@ func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticateRequest.ProtoReflect.Descriptor instead.
 func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{66}
+	return file_rpc_proto_rawDescGZIP(), []int{74}
 }
 
 func (x *AuthenticateRequest) GetName() string {
@@ -4655,7 +5656,7 @@ type AuthUserAddRequest struct {
 
 func (x *AuthUserAddRequest) Reset() {
 	*x = AuthUserAddRequest{}
-	mi := &file_rpc_proto_msgTypes[67]
+	mi := &file_rpc_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4667,7 +5668,7 @@ func (x *AuthUserAddRequest) String() string {
 func (*AuthUserAddRequest) ProtoMessage() {}
 
 func (x *AuthUserAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[67]
+	mi := &file_rpc_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4680,7 +5681,7 @@ func (x *AuthUserAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserAddRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserAddRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{67}
+	return file_rpc_proto_rawDescGZIP(), []int{75}
 }
 
 func (x *AuthUserAddRequest) GetName() string {
@@ -4720,7 +5721,7 @@ type AuthUserGetRequest struct {
 
 func (x *AuthUserGetRequest) Reset() {
 	*x = AuthUserGetRequest{}
-	mi := &file_rpc_proto_msgTypes[68]
+	mi := &file_rpc_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4732,7 +5733,7 @@ func (x *AuthUserGetRequest) String() string {
 func (*AuthUserGetRequest) ProtoMessage() {}
 
 func (x *AuthUserGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[68]
+	mi := &file_rpc_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4745,7 +5746,7 @@ func (x *AuthUserGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserGetRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserGetRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{68}
+	return file_rpc_proto_rawDescGZIP(), []int{76}
 }
 
 func (x *AuthUserGetRequest) GetName() string {
@@ -4765,7 +5766,7 @@ type AuthUserDeleteRequest struct {
 
 func (x *AuthUserDeleteRequest) Reset() {
 	*x = AuthUserDeleteRequest{}
-	mi := &file_rpc_proto_msgTypes[69]
+	mi := &file_rpc_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4777,7 +5778,7 @@ func (x *AuthUserDeleteRequest) String() string {
 func (*AuthUserDeleteRequest) ProtoMessage() {}
 
 func (x *AuthUserDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[69]
+	mi := &file_rpc_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4790,7 +5791,7 @@ func (x *AuthUserDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserDeleteRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{69}
+	return file_rpc_proto_rawDescGZIP(), []int{77}
 }
 
 func (x *AuthUserDeleteRequest) GetName() string {
@@ -4814,7 +5815,7 @@ type AuthUserChangePasswordRequest struct {
 
 func (x *AuthUserChangePasswordRequest) Reset() {
 	*x = AuthUserChangePasswordRequest{}
-	mi := &file_rpc_proto_msgTypes[70]
+	mi := &file_rpc_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4826,7 +5827,7 @@ func (x *AuthUserChangePasswordRequest) String() string {
 func (*AuthUserChangePasswordRequest) ProtoMessage() {}
 
 func (x *AuthUserChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[70]
+	mi := &file_rpc_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4839,7 +5840,7 @@ func (x *AuthUserChangePasswordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserChangePasswordRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{70}
+	return file_rpc_proto_rawDescGZIP(), []int{78}
 }
 
 func (x *AuthUserChangePasswordRequest) GetName() string {
@@ -4875,7 +5876,7 @@ type AuthUserGrantRoleRequest struct {
 
 func (x *AuthUserGrantRoleRequest) Reset() {
 	*x = AuthUserGrantRoleRequest{}
-	mi := &file_rpc_proto_msgTypes[71]
+	mi := &file_rpc_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4887,7 +5888,7 @@ func (x *AuthUserGrantRoleRequest) String() string {
 func (*AuthUserGrantRoleRequest) ProtoMessage() {}
 
 func (x *AuthUserGrantRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[71]
+	mi := &file_rpc_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4900,7 +5901,7 @@ func (x *AuthUserGrantRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserGrantRoleRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserGrantRoleRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{71}
+	return file_rpc_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *AuthUserGrantRoleRequest) GetUser() string {
@@ -4927,7 +5928,7 @@ type AuthUserRevokeRoleRequest struct {
 
 func (x *AuthUserRevokeRoleRequest) Reset() {
 	*x = AuthUserRevokeRoleRequest{}
-	mi := &file_rpc_proto_msgTypes[72]
+	mi := &file_rpc_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4939,7 +5940,7 @@ func (x *AuthUserRevokeRoleRequest) String() string {
 func (*AuthUserRevokeRoleRequest) ProtoMessage() {}
 
 func (x *AuthUserRevokeRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[72]
+	mi := &file_rpc_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4952,7 +5953,7 @@ func (x *AuthUserRevokeRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserRevokeRoleRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserRevokeRoleRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{72}
+	return file_rpc_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *AuthUserRevokeRoleRequest) GetName() string {
@@ -4979,7 +5980,7 @@ type AuthRoleAddRequest struct {
 
 func (x *AuthRoleAddRequest) Reset() {
 	*x = AuthRoleAddRequest{}
-	mi := &file_rpc_proto_msgTypes[73]
+	mi := &file_rpc_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4991,7 +5992,7 @@ func (x *AuthRoleAddRequest) String() string {
 func (*AuthRoleAddRequest) ProtoMessage() {}
 
 func (x *AuthRoleAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[73]
+	mi := &file_rpc_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5004,7 +6005,7 @@ func (x *AuthRoleAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleAddRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleAddRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{73}
+	return file_rpc_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *AuthRoleAddRequest) GetName() string {
@@ -5023,7 +6024,7 @@ type AuthRoleGetRequest struct {
 
 func (x *AuthRoleGetRequest) Reset() {
 	*x = AuthRoleGetRequest{}
-	mi := &file_rpc_proto_msgTypes[74]
+	mi := &file_rpc_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5035,7 +6036,7 @@ func (x *AuthRoleGetRequest) String() string {
 func (*AuthRoleGetRequest) ProtoMessage() {}
 
 func (x *AuthRoleGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[74]
+	mi := &file_rpc_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5048,7 +6049,7 @@ func (x *AuthRoleGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleGetRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleGetRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{74}
+	return file_rpc_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *AuthRoleGetRequest) GetRole() string {
@@ -5066,7 +6067,7 @@ type AuthUserListRequest struct {
 
 func (x *AuthUserListRequest) Reset() {
 	*x = AuthUserListRequest{}
-	mi := &file_rpc_proto_msgTypes[75]
+	mi := &file_rpc_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5078,7 +6079,7 @@ func (x *AuthUserListRequest) String() string {
 func (*AuthUserListRequest) ProtoMessage() {}
 
 func (x *AuthUserListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[75]
+	mi := &file_rpc_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5091,7 +6092,7 @@ func (x *AuthUserListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserListRequest.ProtoReflect.Descriptor instead.
 func (*AuthUserListRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{75}
+	return file_rpc_proto_rawDescGZIP(), []int{83}
 }
 
 type AuthRoleListRequest struct {
@@ -5102,7 +6103,7 @@ type AuthRoleListRequest struct {
 
 func (x *AuthRoleListRequest) Reset() {
 	*x = AuthRoleListRequest{}
-	mi := &file_rpc_proto_msgTypes[76]
+	mi := &file_rpc_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5114,7 +6115,7 @@ func (x *AuthRoleListRequest) String() string {
 func (*AuthRoleListRequest) ProtoMessage() {}
 
 func (x *AuthRoleListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[76]
+	mi := &file_rpc_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5127,7 +6128,7 @@ func (x *AuthRoleListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleListRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleListRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{76}
+	return file_rpc_proto_rawDescGZIP(), []int{84}
 }
 
 type AuthRoleDeleteRequest struct {
@@ -5139,7 +6140,7 @@ type AuthRoleDeleteRequest struct {
 
 func (x *AuthRoleDeleteRequest) Reset() {
 	*x = AuthRoleDeleteRequest{}
-	mi := &file_rpc_proto_msgTypes[77]
+	mi := &file_rpc_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5151,7 +6152,7 @@ func (x *AuthRoleDeleteRequest) String() string {
 func (*AuthRoleDeleteRequest) ProtoMessage() {}
 
 func (x *AuthRoleDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[77]
+	mi := &file_rpc_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5164,7 +6165,7 @@ func (x *AuthRoleDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleDeleteRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{77}
+	return file_rpc_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *AuthRoleDeleteRequest) GetRole() string {
@@ -5186,7 +6187,7 @@ type AuthRoleGrantPermissionRequest struct {
 
 func (x *AuthRoleGrantPermissionRequest) Reset() {
 	*x = AuthRoleGrantPermissionRequest{}
-	mi := &file_rpc_proto_msgTypes[78]
+	mi := &file_rpc_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5198,7 +6199,7 @@ func (x *AuthRoleGrantPermissionRequest) String() string {
 func (*AuthRoleGrantPermissionRequest) ProtoMessage() {}
 
 func (x *AuthRoleGrantPermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[78]
+	mi := &file_rpc_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5211,7 +6212,7 @@ func (x *AuthRoleGrantPermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleGrantPermissionRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleGrantPermissionRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{78}
+	return file_rpc_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *AuthRoleGrantPermissionRequest) GetName() string {
@@ -5239,7 +6240,7 @@ type AuthRoleRevokePermissionRequest struct {
 
 func (x *AuthRoleRevokePermissionRequest) Reset() {
 	*x = AuthRoleRevokePermissionRequest{}
-	mi := &file_rpc_proto_msgTypes[79]
+	mi := &file_rpc_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5251,7 +6252,7 @@ func (x *AuthRoleRevokePermissionRequest) String() string {
 func (*AuthRoleRevokePermissionRequest) ProtoMessage() {}
 
 func (x *AuthRoleRevokePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[79]
+	mi := &file_rpc_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5264,7 +6265,7 @@ func (x *AuthRoleRevokePermissionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleRevokePermissionRequest.ProtoReflect.Descriptor instead.
 func (*AuthRoleRevokePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{79}
+	return file_rpc_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *AuthRoleRevokePermissionRequest) GetRole() string {
@@ -5297,7 +6298,7 @@ type AuthEnableResponse struct {
 
 func (x *AuthEnableResponse) Reset() {
 	*x = AuthEnableResponse{}
-	mi := &file_rpc_proto_msgTypes[80]
+	mi := &file_rpc_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5309,7 +6310,7 @@ func (x *AuthEnableResponse) String() string {
 func (*AuthEnableResponse) ProtoMessage() {}
 
 func (x *AuthEnableResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[80]
+	mi := &file_rpc_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5322,7 +6323,7 @@ func (x *AuthEnableResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthEnableResponse.ProtoReflect.Descriptor instead.
 func (*AuthEnableResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{80}
+	return file_rpc_proto_rawDescGZIP(), []int{88}
 }
 
 func (x *AuthEnableResponse) GetHeader() *ResponseHeader {
@@ -5341,7 +6342,7 @@ type AuthDisableResponse struct {
 
 func (x *AuthDisableResponse) Reset() {
 	*x = AuthDisableResponse{}
-	mi := &file_rpc_proto_msgTypes[81]
+	mi := &file_rpc_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5353,7 +6354,7 @@ func (x *AuthDisableResponse) String() string {
 func (*AuthDisableResponse) ProtoMessage() {}
 
 func (x *AuthDisableResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[81]
+	mi := &file_rpc_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5366,7 +6367,7 @@ func (x *AuthDisableResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthDisableResponse.ProtoReflect.Descriptor instead.
 func (*AuthDisableResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{81}
+	return file_rpc_proto_rawDescGZIP(), []int{89}
 }
 
 func (x *AuthDisableResponse) GetHeader() *ResponseHeader {
@@ -5388,7 +6389,7 @@ type AuthStatusResponse struct {
 
 func (x *AuthStatusResponse) Reset() {
 	*x = AuthStatusResponse{}
-	mi := &file_rpc_proto_msgTypes[82]
+	mi := &file_rpc_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5400,7 +6401,7 @@ func (x *AuthStatusResponse) String() string {
 func (*AuthStatusResponse) ProtoMessage() {}
 
 func (x *AuthStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[82]
+	mi := &file_rpc_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5413,7 +6414,7 @@ func (x *AuthStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthStatusResponse.ProtoReflect.Descriptor instead.
 func (*AuthStatusResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{82}
+	return file_rpc_proto_rawDescGZIP(), []int{90}
 }
 
 func (x *AuthStatusResponse) GetHeader() *ResponseHeader {
@@ -5448,7 +6449,7 @@ type AuthenticateResponse struct {
 
 func (x *AuthenticateResponse) Reset() {
 	*x = AuthenticateResponse{}
-	mi := &file_rpc_proto_msgTypes[83]
+	mi := &file_rpc_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5460,7 +6461,7 @@ func (x *AuthenticateResponse) String() string {
 func (*AuthenticateResponse) ProtoMessage() {}
 
 func (x *AuthenticateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[83]
+	mi := &file_rpc_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5473,7 +6474,7 @@ func (x *AuthenticateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthenticateResponse.ProtoReflect.Descriptor instead.
 func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{83}
+	return file_rpc_proto_rawDescGZIP(), []int{91}
 }
 
 func (x *AuthenticateResponse) GetHeader() *ResponseHeader {
@@ -5499,7 +6500,7 @@ type AuthUserAddResponse struct {
 
 func (x *AuthUserAddResponse) Reset() {
 	*x = AuthUserAddResponse{}
-	mi := &file_rpc_proto_msgTypes[84]
+	mi := &file_rpc_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5511,7 +6512,7 @@ func (x *AuthUserAddResponse) String() string {
 func (*AuthUserAddResponse) ProtoMessage() {}
 
 func (x *AuthUserAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[84]
+	mi := &file_rpc_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5524,7 +6525,7 @@ func (x *AuthUserAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserAddResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserAddResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{84}
+	return file_rpc_proto_rawDescGZIP(), []int{92}
 }
 
 func (x *AuthUserAddResponse) GetHeader() *ResponseHeader {
@@ -5544,7 +6545,7 @@ type AuthUserGetResponse struct {
 
 func (x *AuthUserGetResponse) Reset() {
 	*x = AuthUserGetResponse{}
-	mi := &file_rpc_proto_msgTypes[85]
+	mi := &file_rpc_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5556,7 +6557,7 @@ func (x *AuthUserGetResponse) String() string {
 func (*AuthUserGetResponse) ProtoMessage() {}
 
 func (x *AuthUserGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[85]
+	mi := &file_rpc_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5569,7 +6570,7 @@ func (x *AuthUserGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserGetResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserGetResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{85}
+	return file_rpc_proto_rawDescGZIP(), []int{93}
 }
 
 func (x *AuthUserGetResponse) GetHeader() *ResponseHeader {
@@ -5595,7 +6596,7 @@ type AuthUserDeleteResponse struct {
 
 func (x *AuthUserDeleteResponse) Reset() {
 	*x = AuthUserDeleteResponse{}
-	mi := &file_rpc_proto_msgTypes[86]
+	mi := &file_rpc_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5607,7 +6608,7 @@ func (x *AuthUserDeleteResponse) String() string {
 func (*AuthUserDeleteResponse) ProtoMessage() {}
 
 func (x *AuthUserDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[86]
+	mi := &file_rpc_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5620,7 +6621,7 @@ func (x *AuthUserDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserDeleteResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{86}
+	return file_rpc_proto_rawDescGZIP(), []int{94}
 }
 
 func (x *AuthUserDeleteResponse) GetHeader() *ResponseHeader {
@@ -5639,7 +6640,7 @@ type AuthUserChangePasswordResponse struct {
 
 func (x *AuthUserChangePasswordResponse) Reset() {
 	*x = AuthUserChangePasswordResponse{}
-	mi := &file_rpc_proto_msgTypes[87]
+	mi := &file_rpc_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5651,7 +6652,7 @@ func (x *AuthUserChangePasswordResponse) String() string {
 func (*AuthUserChangePasswordResponse) ProtoMessage() {}
 
 func (x *AuthUserChangePasswordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[87]
+	mi := &file_rpc_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5664,7 +6665,7 @@ func (x *AuthUserChangePasswordResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserChangePasswordResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserChangePasswordResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{87}
+	return file_rpc_proto_rawDescGZIP(), []int{95}
 }
 
 func (x *AuthUserChangePasswordResponse) GetHeader() *ResponseHeader {
@@ -5683,7 +6684,7 @@ type AuthUserGrantRoleResponse struct {
 
 func (x *AuthUserGrantRoleResponse) Reset() {
 	*x = AuthUserGrantRoleResponse{}
-	mi := &file_rpc_proto_msgTypes[88]
+	mi := &file_rpc_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5695,7 +6696,7 @@ func (x *AuthUserGrantRoleResponse) String() string {
 func (*AuthUserGrantRoleResponse) ProtoMessage() {}
 
 func (x *AuthUserGrantRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[88]
+	mi := &file_rpc_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5708,7 +6709,7 @@ func (x *AuthUserGrantRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserGrantRoleResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserGrantRoleResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{88}
+	return file_rpc_proto_rawDescGZIP(), []int{96}
 }
 
 func (x *AuthUserGrantRoleResponse) GetHeader() *ResponseHeader {
@@ -5727,7 +6728,7 @@ type AuthUserRevokeRoleResponse struct {
 
 func (x *AuthUserRevokeRoleResponse) Reset() {
 	*x = AuthUserRevokeRoleResponse{}
-	mi := &file_rpc_proto_msgTypes[89]
+	mi := &file_rpc_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5739,7 +6740,7 @@ func (x *AuthUserRevokeRoleResponse) String() string {
 func (*AuthUserRevokeRoleResponse) ProtoMessage() {}
 
 func (x *AuthUserRevokeRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[89]
+	mi := &file_rpc_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5752,7 +6753,7 @@ func (x *AuthUserRevokeRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserRevokeRoleResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserRevokeRoleResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{89}
+	return file_rpc_proto_rawDescGZIP(), []int{97}
 }
 
 func (x *AuthUserRevokeRoleResponse) GetHeader() *ResponseHeader {
@@ -5771,7 +6772,7 @@ type AuthRoleAddResponse struct {
 
 func (x *AuthRoleAddResponse) Reset() {
 	*x = AuthRoleAddResponse{}
-	mi := &file_rpc_proto_msgTypes[90]
+	mi := &file_rpc_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5783,7 +6784,7 @@ func (x *AuthRoleAddResponse) String() string {
 func (*AuthRoleAddResponse) ProtoMessage() {}
 
 func (x *AuthRoleAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[90]
+	mi := &file_rpc_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5796,7 +6797,7 @@ func (x *AuthRoleAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleAddResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleAddResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{90}
+	return file_rpc_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *AuthRoleAddResponse) GetHeader() *ResponseHeader {
@@ -5816,7 +6817,7 @@ type AuthRoleGetResponse struct {
 
 func (x *AuthRoleGetResponse) Reset() {
 	*x = AuthRoleGetResponse{}
-	mi := &file_rpc_proto_msgTypes[91]
+	mi := &file_rpc_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5828,7 +6829,7 @@ func (x *AuthRoleGetResponse) String() string {
 func (*AuthRoleGetResponse) ProtoMessage() {}
 
 func (x *AuthRoleGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[91]
+	mi := &file_rpc_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5841,7 +6842,7 @@ func (x *AuthRoleGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleGetResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleGetResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{91}
+	return file_rpc_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *AuthRoleGetResponse) GetHeader() *ResponseHeader {
@@ -5868,7 +6869,7 @@ type AuthRoleListResponse struct {
 
 func (x *AuthRoleListResponse) Reset() {
 	*x = AuthRoleListResponse{}
-	mi := &file_rpc_proto_msgTypes[92]
+	mi := &file_rpc_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5880,7 +6881,7 @@ func (x *AuthRoleListResponse) String() string {
 func (*AuthRoleListResponse) ProtoMessage() {}
 
 func (x *AuthRoleListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[92]
+	mi := &file_rpc_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5893,7 +6894,7 @@ func (x *AuthRoleListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleListResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleListResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{92}
+	return file_rpc_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *AuthRoleListResponse) GetHeader() *ResponseHeader {
@@ -5920,7 +6921,7 @@ type AuthUserListResponse struct {
 
 func (x *AuthUserListResponse) Reset() {
 	*x = AuthUserListResponse{}
-	mi := &file_rpc_proto_msgTypes[93]
+	mi := &file_rpc_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5932,7 +6933,7 @@ func (x *AuthUserListResponse) String() string {
 func (*AuthUserListResponse) ProtoMessage() {}
 
 func (x *AuthUserListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[93]
+	mi := &file_rpc_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5945,7 +6946,7 @@ func (x *AuthUserListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthUserListResponse.ProtoReflect.Descriptor instead.
 func (*AuthUserListResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{93}
+	return file_rpc_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *AuthUserListResponse) GetHeader() *ResponseHeader {
@@ -5971,7 +6972,7 @@ type AuthRoleDeleteResponse struct {
 
 func (x *AuthRoleDeleteResponse) Reset() {
 	*x = AuthRoleDeleteResponse{}
-	mi := &file_rpc_proto_msgTypes[94]
+	mi := &file_rpc_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5983,7 +6984,7 @@ func (x *AuthRoleDeleteResponse) String() string {
 func (*AuthRoleDeleteResponse) ProtoMessage() {}
 
 func (x *AuthRoleDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[94]
+	mi := &file_rpc_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5996,7 +6997,7 @@ func (x *AuthRoleDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleDeleteResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{94}
+	return file_rpc_proto_rawDescGZIP(), []int{102}
 }
 
 func (x *AuthRoleDeleteResponse) GetHeader() *ResponseHeader {
@@ -6015,7 +7016,7 @@ type AuthRoleGrantPermissionResponse struct {
 
 func (x *AuthRoleGrantPermissionResponse) Reset() {
 	*x = AuthRoleGrantPermissionResponse{}
-	mi := &file_rpc_proto_msgTypes[95]
+	mi := &file_rpc_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6027,7 +7028,7 @@ func (x *AuthRoleGrantPermissionResponse) String() string {
 func (*AuthRoleGrantPermissionResponse) ProtoMessage() {}
 
 func (x *AuthRoleGrantPermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[95]
+	mi := &file_rpc_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6040,7 +7041,7 @@ func (x *AuthRoleGrantPermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleGrantPermissionResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleGrantPermissionResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{95}
+	return file_rpc_proto_rawDescGZIP(), []int{103}
 }
 
 func (x *AuthRoleGrantPermissionResponse) GetHeader() *ResponseHeader {
@@ -6059,7 +7060,7 @@ type AuthRoleRevokePermissionResponse struct {
 
 func (x *AuthRoleRevokePermissionResponse) Reset() {
 	*x = AuthRoleRevokePermissionResponse{}
-	mi := &file_rpc_proto_msgTypes[96]
+	mi := &file_rpc_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6071,7 +7072,7 @@ func (x *AuthRoleRevokePermissionResponse) String() string {
 func (*AuthRoleRevokePermissionResponse) ProtoMessage() {}
 
 func (x *AuthRoleRevokePermissionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[96]
+	mi := &file_rpc_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6084,7 +7085,7 @@ func (x *AuthRoleRevokePermissionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthRoleRevokePermissionResponse.ProtoReflect.Descriptor instead.
 func (*AuthRoleRevokePermissionResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{96}
+	return file_rpc_proto_rawDescGZIP(), []int{104}
 }
 
 func (x *AuthRoleRevokePermissionResponse) GetHeader() *ResponseHeader {
@@ -6115,7 +7116,7 @@ type RangeStreamResponse struct {
 
 func (x *RangeStreamResponse) Reset() {
 	*x = RangeStreamResponse{}
-	mi := &file_rpc_proto_msgTypes[97]
+	mi := &file_rpc_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6127,7 +7128,7 @@ func (x *RangeStreamResponse) String() string {
 func (*RangeStreamResponse) ProtoMessage() {}
 
 func (x *RangeStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rpc_proto_msgTypes[97]
+	mi := &file_rpc_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6140,7 +7141,7 @@ func (x *RangeStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RangeStreamResponse.ProtoReflect.Descriptor instead.
 func (*RangeStreamResponse) Descriptor() ([]byte, []int) {
-	return file_rpc_proto_rawDescGZIP(), []int{97}
+	return file_rpc_proto_rawDescGZIP(), []int{105}
 }
 
 func (x *RangeStreamResponse) GetRangeResponse() *RangeResponse {
@@ -6160,7 +7161,7 @@ const file_rpc_proto_rawDesc = "" +
 	"cluster_id\x18\x01 \x01(\x04R\tclusterId\x12\x1b\n" +
 	"\tmember_id\x18\x02 \x01(\x04R\bmemberId\x12\x1a\n" +
 	"\brevision\x18\x03 \x01(\x03R\brevision\x12\x1b\n" +
-	"\traft_term\x18\x04 \x01(\x04R\braftTerm:\a\x82\xb5\x18\x033.0\"\xc3\x05\n" +
+	"\traft_term\x18\x04 \x01(\x04R\braftTerm:\a\x82\xb5\x18\x033.0\"\xe4\x05\n" +
 	"\fRangeRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\fR\x03key\x12\x1b\n" +
 	"\trange_end\x18\x02 \x01(\fR\brangeEnd\x12\x14\n" +
@@ -6178,7 +7179,8 @@ const file_rpc_proto_rawDesc = "" +
 	" \x01(\x03B\a\x8a\xb5\x18\x033.1R\x0eminModRevision\x121\n" +
 	"\x10max_mod_revision\x18\v \x01(\x03B\a\x8a\xb5\x18\x033.1R\x0emaxModRevision\x127\n" +
 	"\x13min_create_revision\x18\f \x01(\x03B\a\x8a\xb5\x18\x033.1R\x11minCreateRevision\x127\n" +
-	"\x13max_create_revision\x18\r \x01(\x03B\a\x8a\xb5\x18\x033.1R\x11maxCreateRevision\"7\n" +
+	"\x13max_create_revision\x18\r \x01(\x03B\a\x8a\xb5\x18\x033.1R\x11maxCreateRevision\x12\x1f\n" +
+	"\x06cursor\x18\x0e \x01(\fB\a\x8a\xb5\x18\x033.8R\x06cursor\"7\n" +
 	"\tSortOrder\x12\b\n" +
 	"\x04NONE\x10\x00\x12\n" +
 	"\n" +
@@ -6191,12 +7193,15 @@ const file_rpc_proto_rawDesc = "" +
 	"\n" +
 	"\x06CREATE\x10\x02\x12\a\n" +
 	"\x03MOD\x10\x03\x12\t\n" +
-	"\x05VALUE\x10\x04\x1a\a\x92\xb5\x18\x033.0:\a\x82\xb5\x18\x033.0\"\x9c\x01\n" +
+	"\x05VALUE\x10\x04\x1a\a\x92\xb5\x18\x033.0:\a\x82\xb5\x18\x033.0\"\x86\x02\n" +
 	"\rRangeResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\"\n" +
 	"\x03kvs\x18\x02 \x03(\v2\x10.mvccpb.KeyValueR\x03kvs\x12\x12\n" +
 	"\x04more\x18\x03 \x01(\bR\x04more\x12\x14\n" +
-	"\x05count\x18\x04 \x01(\x03R\x05count:\a\x82\xb5\x18\x033.0\"\xcd\x01\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\x12(\n" +
+	"\vnext_cursor\x18\x05 \x01(\fB\a\x8a\xb5\x18\x033.8R\n" +
+	"nextCursor\x12>\n" +
+	"\x16consistency_downgraded\x18\x06 \x01(\bB\a\x8a\xb5\x18\x033.8R\x15consistencyDowngraded:\a\x82\xb5\x18\x033.0\"\xcd\x01\n" +
 	"\n" +
 	"PutRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\fR\x03key\x12\x14\n" +
@@ -6285,12 +7290,15 @@ const file_rpc_proto_rawDesc = "" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12'\n" +
 	"\x0fremaining_bytes\x18\x02 \x01(\x04R\x0eremainingBytes\x12\x12\n" +
 	"\x04blob\x18\x03 \x01(\fR\x04blob\x12!\n" +
-	"\aversion\x18\x04 \x01(\tB\a\x8a\xb5\x18\x033.6R\aversion:\a\x82\xb5\x18\x033.3\"\x98\x02\n" +
+	"\aversion\x18\x04 \x01(\tB\a\x8a\xb5\x18\x033.6R\aversion:\a\x82\xb5\x18\x033.3\"\xc7\x03\n" +
 	"\fWatchRequest\x12I\n" +
 	"\x0ecreate_request\x18\x01 \x01(\v2 .etcdserverpb.WatchCreateRequestH\x00R\rcreateRequest\x12I\n" +
 	"\x0ecancel_request\x18\x02 \x01(\v2 .etcdserverpb.WatchCancelRequestH\x00R\rcancelRequest\x12X\n" +
-	"\x10progress_request\x18\x03 \x01(\v2\".etcdserverpb.WatchProgressRequestB\a\x8a\xb5\x18\x033.4H\x00R\x0fprogressRequest:\a\x82\xb5\x18\x033.0B\x0f\n" +
-	"\rrequest_union\"\x87\x03\n" +
+	"\x10progress_request\x18\x03 \x01(\v2\".etcdserverpb.WatchProgressRequestB\a\x8a\xb5\x18\x033.4H\x00R\x0fprogressRequest\x12I\n" +
+	"\vack_request\x18\x04 \x01(\v2\x1d.etcdserverpb.WatchAckRequestB\a\x8a\xb5\x18\x033.8H\x00R\n" +
+	"ackRequest\x12b\n" +
+	"\x14cancel_range_request\x18\x05 \x01(\v2%.etcdserverpb.WatchCancelRangeRequestB\a\x8a\xb5\x18\x033.8H\x00R\x12cancelRangeRequest:\a\x82\xb5\x18\x033.0B\x0f\n" +
+	"\rrequest_union\"\xe2\t\n" +
 	"\x12WatchCreateRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\fR\x03key\x12\x1b\n" +
 	"\trange_end\x18\x02 \x01(\fR\brangeEnd\x12%\n" +
@@ -6299,14 +7307,41 @@ const file_rpc_proto_rawDesc = "" +
 	"\afilters\x18\x05 \x03(\x0e2+.etcdserverpb.WatchCreateRequest.FilterTypeB\a\x8a\xb5\x18\x033.1R\afilters\x12 \n" +
 	"\aprev_kv\x18\x06 \x01(\bB\a\x8a\xb5\x18\x033.1R\x06prevKv\x12\"\n" +
 	"\bwatch_id\x18\a \x01(\x03B\a\x8a\xb5\x18\x033.4R\awatchId\x12#\n" +
-	"\bfragment\x18\b \x01(\bB\a\x8a\xb5\x18\x033.4R\bfragment\".\n" +
+	"\bfragment\x18\b \x01(\bB\a\x8a\xb5\x18\x033.4R\bfragment\x12$\n" +
+	"\tkeys_only\x18\t \x01(\bB\a\x8a\xb5\x18\x033.8R\bkeysOnly\x124\n" +
+	"\x11snapshot_fallback\x18\n" +
+	" \x01(\bB\a\x8a\xb5\x18\x033.8R\x10snapshotFallback\x12-\n" +
+	"\x0elatest_per_key\x18\v \x01(\bB\a\x8a\xb5\x18\x033.8R\flatestPerKey\x12*\n" +
+	"\fvalue_prefix\x18\f \x01(\fB\a\x8a\xb5\x18\x033.8R\vvaluePrefix\x12X\n" +
+	"\ffilter_order\x18\r \x01(\x0e2,.etcdserverpb.WatchCreateRequest.FilterOrderB\a\x8a\xb5\x18\x033.8R\vfilterOrder\x12-\n" +
+	"\x0esample_every_n\x18\x0e \x01(\x03B\a\x8a\xb5\x18\x033.8R\fsampleEveryN\x12>\n" +
+	"\x17max_events_per_response\x18\x0f \x01(\x03B\a\x8a\xb5\x18\x033.8R\x14maxEventsPerResponse\x12*\n" +
+	"\fversion_only\x18\x10 \x01(\bB\a\x8a\xb5\x18\x033.8R\vversionOnly\x12=\n" +
+	"\x16compact_warning_margin\x18\x11 \x01(\x03B\a\x8a\xb5\x18\x033.8R\x14compactWarningMargin\x127\n" +
+	"\x13keep_alive_interval\x18\x12 \x01(\x03B\a\x8a\xb5\x18\x033.8R\x11keepAliveInterval\x126\n" +
+	"\x12linearizable_start\x18\x13 \x01(\bB\a\x8a\xb5\x18\x033.8R\x11linearizableStart\x12&\n" +
+	"\n" +
+	"ack_window\x18\x14 \x01(\x03B\a\x8a\xb5\x18\x033.8R\tackWindow\x12.\n" +
+	"\x0evalue_selector\x18\x15 \x01(\tB\a\x8a\xb5\x18\x033.8R\rvalueSelector\x122\n" +
+	"\x10collapse_deletes\x18\x16 \x01(\bB\a\x8a\xb5\x18\x033.8R\x0fcollapseDeletes\x128\n" +
+	"\acompare\x18\x17 \x01(\v2\x15.etcdserverpb.CompareB\a\x8a\xb5\x18\x033.8R\acompare\".\n" +
 	"\n" +
 	"FilterType\x12\t\n" +
 	"\x05NOPUT\x10\x00\x12\f\n" +
-	"\bNODELETE\x10\x01\x1a\a\x92\xb5\x18\x033.1:\a\x82\xb5\x18\x033.0\"A\n" +
+	"\bNODELETE\x10\x01\x1a\a\x92\xb5\x18\x033.1\"7\n" +
+	"\vFilterOrder\x12\x0e\n" +
+	"\n" +
+	"TYPE_FIRST\x10\x00\x12\x0f\n" +
+	"\vVALUE_FIRST\x10\x01\x1a\a\x92\xb5\x18\x033.8:\a\x82\xb5\x18\x033.0\"A\n" +
 	"\x12WatchCancelRequest\x12\"\n" +
-	"\bwatch_id\x18\x01 \x01(\x03B\a\x8a\xb5\x18\x033.1R\awatchId:\a\x82\xb5\x18\x033.1\"\x1f\n" +
-	"\x14WatchProgressRequest:\a\x82\xb5\x18\x033.4\"\xc4\x02\n" +
+	"\bwatch_id\x18\x01 \x01(\x03B\a\x8a\xb5\x18\x033.1R\awatchId:\a\x82\xb5\x18\x033.1\"Q\n" +
+	"\x17WatchCancelRangeRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\x12\x1b\n" +
+	"\trange_end\x18\x02 \x01(\fR\brangeEnd:\a\x82\xb5\x18\x033.8\"Q\n" +
+	"\x0fWatchAckRequest\x12\x19\n" +
+	"\bwatch_id\x18\x01 \x01(\x03R\awatchId\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x03R\brevision:\a\x82\xb5\x18\x033.8\"\x1f\n" +
+	"\x14WatchProgressRequest:\a\x82\xb5\x18\x033.4\"\xca\x04\n" +
 	"\rWatchResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\x19\n" +
 	"\bwatch_id\x18\x02 \x01(\x03R\awatchId\x12\x18\n" +
@@ -6314,16 +7349,24 @@ const file_rpc_proto_rawDesc = "" +
 	"\bcanceled\x18\x04 \x01(\bR\bcanceled\x12)\n" +
 	"\x10compact_revision\x18\x05 \x01(\x03R\x0fcompactRevision\x12,\n" +
 	"\rcancel_reason\x18\x06 \x01(\tB\a\x8a\xb5\x18\x033.4R\fcancelReason\x12#\n" +
-	"\bfragment\x18\a \x01(\bB\a\x8a\xb5\x18\x033.4R\bfragment\x12%\n" +
-	"\x06events\x18\v \x03(\v2\r.mvccpb.EventR\x06events:\a\x82\xb5\x18\x033.0\">\n" +
+	"\bfragment\x18\a \x01(\bB\a\x8a\xb5\x18\x033.4R\bfragment\x12#\n" +
+	"\bsnapshot\x18\b \x01(\bB\a\x8a\xb5\x18\x033.8R\bsnapshot\x12!\n" +
+	"\awarning\x18\t \x01(\tB\a\x8a\xb5\x18\x033.8R\awarning\x12\"\n" +
+	"\bcatch_up\x18\n" +
+	" \x01(\bB\a\x8a\xb5\x18\x033.8R\acatchUp\x12%\n" +
+	"\x06events\x18\v \x03(\v2\r.mvccpb.EventR\x06events\x12A\n" +
+	"\x18compact_warning_revision\x18\f \x01(\x03B\a\x8a\xb5\x18\x033.8R\x16compactWarningRevision\x12%\n" +
+	"\theartbeat\x18\r \x01(\bB\a\x8a\xb5\x18\x033.8R\theartbeat\x12.\n" +
+	"\x0ecompare_result\x18\x0e \x01(\bB\a\x8a\xb5\x18\x033.8R\rcompareResult:\a\x82\xb5\x18\x033.0\">\n" +
 	"\x11LeaseGrantRequest\x12\x10\n" +
 	"\x03TTL\x18\x01 \x01(\x03R\x03TTL\x12\x0e\n" +
-	"\x02ID\x18\x02 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.0\"\x8b\x01\n" +
+	"\x02ID\x18\x02 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.0\"\xaa\x01\n" +
 	"\x12LeaseGrantResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\x0e\n" +
 	"\x02ID\x18\x02 \x01(\x03R\x02ID\x12\x10\n" +
 	"\x03TTL\x18\x03 \x01(\x03R\x03TTL\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error:\a\x82\xb5\x18\x033.0\"-\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\x05epoch\x18\x05 \x01(\x04B\a\x8a\xb5\x18\x033.8R\x05epoch:\a\x82\xb5\x18\x033.0\"-\n" +
 	"\x12LeaseRevokeRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.0\"T\n" +
 	"\x13LeaseRevokeResponse\x124\n" +
@@ -6334,13 +7377,21 @@ const file_rpc_proto_rawDesc = "" +
 	"\x16LeaseCheckpointRequest\x12?\n" +
 	"\vcheckpoints\x18\x01 \x03(\v2\x1d.etcdserverpb.LeaseCheckpointR\vcheckpoints:\a\x82\xb5\x18\x033.4\"X\n" +
 	"\x17LeaseCheckpointResponse\x124\n" +
-	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header:\a\x82\xb5\x18\x033.4\"0\n" +
+	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header:\a\x82\xb5\x18\x033.4\"O\n" +
 	"\x15LeaseKeepAliveRequest\x12\x0e\n" +
-	"\x02ID\x18\x01 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.0\"y\n" +
+	"\x02ID\x18\x01 \x01(\x03R\x02ID\x12\x1d\n" +
+	"\x05epoch\x18\x02 \x01(\x04B\a\x8a\xb5\x18\x033.8R\x05epoch:\a\x82\xb5\x18\x033.0\"y\n" +
 	"\x16LeaseKeepAliveResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\x0e\n" +
 	"\x02ID\x18\x02 \x01(\x03R\x02ID\x12\x10\n" +
-	"\x03TTL\x18\x03 \x01(\x03R\x03TTL:\a\x82\xb5\x18\x033.0\"E\n" +
+	"\x03TTL\x18\x03 \x01(\x03R\x03TTL:\a\x82\xb5\x18\x033.0\"/\n" +
+	"\x14LeaseTransferRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.8\"\x8e\x01\n" +
+	"\x15LeaseTransferResponse\x124\n" +
+	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\x03R\x02ID\x12\x10\n" +
+	"\x03TTL\x18\x03 \x01(\x03R\x03TTL\x12\x14\n" +
+	"\x05epoch\x18\x04 \x01(\x04R\x05epoch:\a\x82\xb5\x18\x033.8\"E\n" +
 	"\x16LeaseTimeToLiveRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x03R\x02ID\x12\x12\n" +
 	"\x04keys\x18\x02 \x01(\bR\x04keys:\a\x82\xb5\x18\x033.1\"\xae\x01\n" +
@@ -6357,7 +7408,21 @@ const file_rpc_proto_rawDesc = "" +
 	"\x02ID\x18\x01 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.3\"\x87\x01\n" +
 	"\x13LeaseLeasesResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x121\n" +
-	"\x06leases\x18\x02 \x03(\v2\x19.etcdserverpb.LeaseStatusR\x06leases:\a\x82\xb5\x18\x033.3\"\x98\x01\n" +
+	"\x06leases\x18\x02 \x03(\v2\x19.etcdserverpb.LeaseStatusR\x06leases:\a\x82\xb5\x18\x033.3\",\n" +
+	"\x11LeaseWatchRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\x03R\x02ID:\a\x82\xb5\x18\x033.8\"\xa3\x01\n" +
+	"\n" +
+	"LeaseEvent\x126\n" +
+	"\x04type\x18\x01 \x01(\x0e2\".etcdserverpb.LeaseEvent.EventTypeR\x04type\x12\x0e\n" +
+	"\x02ID\x18\x02 \x01(\x03R\x02ID\x12\x10\n" +
+	"\x03TTL\x18\x03 \x01(\x03R\x03TTL\"2\n" +
+	"\tEventType\x12\v\n" +
+	"\aGRANTED\x10\x00\x12\v\n" +
+	"\aREVOKED\x10\x01\x12\v\n" +
+	"\aEXPIRED\x10\x02:\a\x82\xb5\x18\x033.8\"\x85\x01\n" +
+	"\x12LeaseWatchResponse\x124\n" +
+	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x120\n" +
+	"\x06events\x18\x02 \x03(\v2\x18.etcdserverpb.LeaseEventR\x06events:\a\x82\xb5\x18\x033.8\"\x98\x01\n" +
 	"\x06Member\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x04R\x02ID\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -6430,7 +7495,7 @@ const file_rpc_proto_rawDesc = "" +
 	"\aversion\x18\x02 \x01(\tR\aversion:\a\x82\xb5\x18\x033.5\"8\n" +
 	"\x1bDowngradeVersionTestRequest\x12\x10\n" +
 	"\x03ver\x18\x01 \x01(\tR\x03ver:\a\x82\xb5\x18\x033.6\"\x18\n" +
-	"\rStatusRequest:\a\x82\xb5\x18\x033.0\"\xa3\x04\n" +
+	"\rStatusRequest:\a\x82\xb5\x18\x033.0\"\x89\a\n" +
 	"\x0eStatusResponse\x124\n" +
 	"\x06header\x18\x01 \x01(\v2\x1c.etcdserverpb.ResponseHeaderR\x06header\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
@@ -6445,7 +7510,20 @@ const file_rpc_proto_rawDesc = "" +
 	" \x01(\bB\a\x8a\xb5\x18\x033.4R\tisLearner\x12/\n" +
 	"\x0estorageVersion\x18\v \x01(\tB\a\x8a\xb5\x18\x033.6R\x0estorageVersion\x12)\n" +
 	"\vdbSizeQuota\x18\f \x01(\x03B\a\x8a\xb5\x18\x033.6R\vdbSizeQuota\x12J\n" +
-	"\rdowngradeInfo\x18\r \x01(\v2\x1b.etcdserverpb.DowngradeInfoB\a\x8a\xb5\x18\x033.6R\rdowngradeInfo:\a\x82\xb5\x18\x033.0\"O\n" +
+	"\rdowngradeInfo\x18\r \x01(\v2\x1b.etcdserverpb.DowngradeInfoB\a\x8a\xb5\x18\x033.6R\rdowngradeInfo\x121\n" +
+	"\x0fcompactRevision\x18\x0e \x01(\x03B\a\x8a\xb5\x18\x033.8R\x0fcompactRevision\x12+\n" +
+	"\fmaxClockSkew\x18\x0f \x01(\x03B\a\x8a\xb5\x18\x033.8R\fmaxClockSkew\x12-\n" +
+	"\rvalueEncoding\x18\x10 \x01(\tB\a\x8a\xb5\x18\x033.8R\rvalueEncoding\x12M\n" +
+	"\x1dvalueEncodingMigrationPercent\x18\x11 \x01(\x01B\a\x8a\xb5\x18\x033.8R\x1dvalueEncodingMigrationPercent\x12>\n" +
+	"\tdiskUsage\x18\x12 \x01(\v2\x17.etcdserverpb.DiskUsageB\a\x8a\xb5\x18\x033.8R\tdiskUsage\x12%\n" +
+	"\tgoVersion\x18\x13 \x01(\tB\a\x8a\xb5\x18\x033.8R\tgoVersion\x12\x1f\n" +
+	"\x06gitSha\x18\x14 \x01(\tB\a\x8a\xb5\x18\x033.8R\x06gitSha:\a\x82\xb5\x18\x033.0\"\xcc\x01\n" +
+	"\tDiskUsage\x12\"\n" +
+	"\fwalFileCount\x18\x01 \x01(\x03R\fwalFileCount\x12\x18\n" +
+	"\awalSize\x18\x02 \x01(\x03R\awalSize\x12&\n" +
+	"\x0eoldestWalIndex\x18\x03 \x01(\x04R\x0eoldestWalIndex\x12,\n" +
+	"\x11snapshotFileCount\x18\x04 \x01(\x03R\x11snapshotFileCount\x12\"\n" +
+	"\fsnapshotSize\x18\x05 \x01(\x03R\fsnapshotSize:\a\x82\xb5\x18\x033.8\"O\n" +
 	"\rDowngradeInfo\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12$\n" +
 	"\rtargetVersion\x18\x02 \x01(\tR\rtargetVersion\"\x1c\n" +
@@ -6546,14 +7624,17 @@ const file_rpc_proto_rawDesc = "" +
 	"/v3/kv/txn\x12j\n" +
 	"\aCompact\x12\x1f.etcdserverpb.CompactionRequest\x1a .etcdserverpb.CompactionResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v3/kv/compaction2c\n" +
 	"\x05Watch\x12Z\n" +
-	"\x05Watch\x12\x1a.etcdserverpb.WatchRequest\x1a\x1b.etcdserverpb.WatchResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v3/watch(\x010\x012\xad\x05\n" +
+	"\x05Watch\x12\x1a.etcdserverpb.WatchRequest\x1a\x1b.etcdserverpb.WatchResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v3/watch(\x010\x012\x95\a\n" +
 	"\x05Lease\x12k\n" +
 	"\n" +
 	"LeaseGrant\x12\x1f.etcdserverpb.LeaseGrantRequest\x1a .etcdserverpb.LeaseGrantResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v3/lease/grant\x12\x89\x01\n" +
 	"\vLeaseRevoke\x12 .etcdserverpb.LeaseRevokeRequest\x1a!.etcdserverpb.LeaseRevokeResponse\"5\x82\xd3\xe4\x93\x02/:\x01*Z\x18:\x01*\"\x13/v3/kv/lease/revoke\"\x10/v3/lease/revoke\x12\x7f\n" +
-	"\x0eLeaseKeepAlive\x12#.etcdserverpb.LeaseKeepAliveRequest\x1a$.etcdserverpb.LeaseKeepAliveResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v3/lease/keepalive(\x010\x01\x12\x9d\x01\n" +
+	"\x0eLeaseKeepAlive\x12#.etcdserverpb.LeaseKeepAliveRequest\x1a$.etcdserverpb.LeaseKeepAliveResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v3/lease/keepalive(\x010\x01\x12w\n" +
+	"\rLeaseTransfer\x12\".etcdserverpb.LeaseTransferRequest\x1a#.etcdserverpb.LeaseTransferResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/v3/lease/transfer\x12\x9d\x01\n" +
 	"\x0fLeaseTimeToLive\x12$.etcdserverpb.LeaseTimeToLiveRequest\x1a%.etcdserverpb.LeaseTimeToLiveResponse\"=\x82\xd3\xe4\x93\x027:\x01*Z\x1c:\x01*\"\x17/v3/kv/lease/timetolive\"\x14/v3/lease/timetolive\x12\x89\x01\n" +
-	"\vLeaseLeases\x12 .etcdserverpb.LeaseLeasesRequest\x1a!.etcdserverpb.LeaseLeasesResponse\"5\x82\xd3\xe4\x93\x02/:\x01*Z\x18:\x01*\"\x13/v3/kv/lease/leases\"\x10/v3/lease/leases2\xea\x04\n" +
+	"\vLeaseLeases\x12 .etcdserverpb.LeaseLeasesRequest\x1a!.etcdserverpb.LeaseLeasesResponse\"5\x82\xd3\xe4\x93\x02/:\x01*Z\x18:\x01*\"\x13/v3/kv/lease/leases\"\x10/v3/lease/leases\x12m\n" +
+	"\n" +
+	"LeaseWatch\x12\x1f.etcdserverpb.LeaseWatchRequest\x1a .etcdserverpb.LeaseWatchResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v3/lease/watch0\x012\xea\x04\n" +
 	"\aCluster\x12o\n" +
 	"\tMemberAdd\x12\x1e.etcdserverpb.MemberAddRequest\x1a\x1f.etcdserverpb.MemberAddResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v3/cluster/member/add\x12{\n" +
 	"\fMemberRemove\x12!.etcdserverpb.MemberRemoveRequest\x1a\".etcdserverpb.MemberRemoveResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/v3/cluster/member/remove\x12{\n" +
@@ -6612,8 +7693,8 @@ func file_rpc_proto_rawDescGZIP() []byte {
 	return file_rpc_proto_rawDescData
 }
 
-var file_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
-var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 98)
+var file_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 106)
 var file_rpc_proto_goTypes = []any{
 	(AlarmType)(0),                           // 0: etcdserverpb.AlarmType
 	(RangeRequest_SortOrder)(0),              // 1: etcdserverpb.RangeRequest.SortOrder
@@ -6621,285 +7702,308 @@ var file_rpc_proto_goTypes = []any{
 	(Compare_CompareResult)(0),               // 3: etcdserverpb.Compare.CompareResult
 	(Compare_CompareTarget)(0),               // 4: etcdserverpb.Compare.CompareTarget
 	(WatchCreateRequest_FilterType)(0),       // 5: etcdserverpb.WatchCreateRequest.FilterType
-	(AlarmRequest_AlarmAction)(0),            // 6: etcdserverpb.AlarmRequest.AlarmAction
-	(DowngradeRequest_DowngradeAction)(0),    // 7: etcdserverpb.DowngradeRequest.DowngradeAction
-	(*ResponseHeader)(nil),                   // 8: etcdserverpb.ResponseHeader
-	(*RangeRequest)(nil),                     // 9: etcdserverpb.RangeRequest
-	(*RangeResponse)(nil),                    // 10: etcdserverpb.RangeResponse
-	(*PutRequest)(nil),                       // 11: etcdserverpb.PutRequest
-	(*PutResponse)(nil),                      // 12: etcdserverpb.PutResponse
-	(*DeleteRangeRequest)(nil),               // 13: etcdserverpb.DeleteRangeRequest
-	(*DeleteRangeResponse)(nil),              // 14: etcdserverpb.DeleteRangeResponse
-	(*RequestOp)(nil),                        // 15: etcdserverpb.RequestOp
-	(*ResponseOp)(nil),                       // 16: etcdserverpb.ResponseOp
-	(*Compare)(nil),                          // 17: etcdserverpb.Compare
-	(*TxnRequest)(nil),                       // 18: etcdserverpb.TxnRequest
-	(*TxnResponse)(nil),                      // 19: etcdserverpb.TxnResponse
-	(*CompactionRequest)(nil),                // 20: etcdserverpb.CompactionRequest
-	(*CompactionResponse)(nil),               // 21: etcdserverpb.CompactionResponse
-	(*HashRequest)(nil),                      // 22: etcdserverpb.HashRequest
-	(*HashKVRequest)(nil),                    // 23: etcdserverpb.HashKVRequest
-	(*HashKVResponse)(nil),                   // 24: etcdserverpb.HashKVResponse
-	(*HashResponse)(nil),                     // 25: etcdserverpb.HashResponse
-	(*SnapshotRequest)(nil),                  // 26: etcdserverpb.SnapshotRequest
-	(*SnapshotResponse)(nil),                 // 27: etcdserverpb.SnapshotResponse
-	(*WatchRequest)(nil),                     // 28: etcdserverpb.WatchRequest
-	(*WatchCreateRequest)(nil),               // 29: etcdserverpb.WatchCreateRequest
-	(*WatchCancelRequest)(nil),               // 30: etcdserverpb.WatchCancelRequest
-	(*WatchProgressRequest)(nil),             // 31: etcdserverpb.WatchProgressRequest
-	(*WatchResponse)(nil),                    // 32: etcdserverpb.WatchResponse
-	(*LeaseGrantRequest)(nil),                // 33: etcdserverpb.LeaseGrantRequest
-	(*LeaseGrantResponse)(nil),               // 34: etcdserverpb.LeaseGrantResponse
-	(*LeaseRevokeRequest)(nil),               // 35: etcdserverpb.LeaseRevokeRequest
-	(*LeaseRevokeResponse)(nil),              // 36: etcdserverpb.LeaseRevokeResponse
-	(*LeaseCheckpoint)(nil),                  // 37: etcdserverpb.LeaseCheckpoint
-	(*LeaseCheckpointRequest)(nil),           // 38: etcdserverpb.LeaseCheckpointRequest
-	(*LeaseCheckpointResponse)(nil),          // 39: etcdserverpb.LeaseCheckpointResponse
-	(*LeaseKeepAliveRequest)(nil),            // 40: etcdserverpb.LeaseKeepAliveRequest
-	(*LeaseKeepAliveResponse)(nil),           // 41: etcdserverpb.LeaseKeepAliveResponse
-	(*LeaseTimeToLiveRequest)(nil),           // 42: etcdserverpb.LeaseTimeToLiveRequest
-	(*LeaseTimeToLiveResponse)(nil),          // 43: etcdserverpb.LeaseTimeToLiveResponse
-	(*LeaseLeasesRequest)(nil),               // 44: etcdserverpb.LeaseLeasesRequest
-	(*LeaseStatus)(nil),                      // 45: etcdserverpb.LeaseStatus
-	(*LeaseLeasesResponse)(nil),              // 46: etcdserverpb.LeaseLeasesResponse
-	(*Member)(nil),                           // 47: etcdserverpb.Member
-	(*MemberAddRequest)(nil),                 // 48: etcdserverpb.MemberAddRequest
-	(*MemberAddResponse)(nil),                // 49: etcdserverpb.MemberAddResponse
-	(*MemberRemoveRequest)(nil),              // 50: etcdserverpb.MemberRemoveRequest
-	(*MemberRemoveResponse)(nil),             // 51: etcdserverpb.MemberRemoveResponse
-	(*MemberUpdateRequest)(nil),              // 52: etcdserverpb.MemberUpdateRequest
-	(*MemberUpdateResponse)(nil),             // 53: etcdserverpb.MemberUpdateResponse
-	(*MemberListRequest)(nil),                // 54: etcdserverpb.MemberListRequest
-	(*MemberListResponse)(nil),               // 55: etcdserverpb.MemberListResponse
-	(*MemberPromoteRequest)(nil),             // 56: etcdserverpb.MemberPromoteRequest
-	(*MemberPromoteResponse)(nil),            // 57: etcdserverpb.MemberPromoteResponse
-	(*DefragmentRequest)(nil),                // 58: etcdserverpb.DefragmentRequest
-	(*DefragmentResponse)(nil),               // 59: etcdserverpb.DefragmentResponse
-	(*MoveLeaderRequest)(nil),                // 60: etcdserverpb.MoveLeaderRequest
-	(*MoveLeaderResponse)(nil),               // 61: etcdserverpb.MoveLeaderResponse
-	(*AlarmRequest)(nil),                     // 62: etcdserverpb.AlarmRequest
-	(*AlarmMember)(nil),                      // 63: etcdserverpb.AlarmMember
-	(*AlarmResponse)(nil),                    // 64: etcdserverpb.AlarmResponse
-	(*DowngradeRequest)(nil),                 // 65: etcdserverpb.DowngradeRequest
-	(*DowngradeResponse)(nil),                // 66: etcdserverpb.DowngradeResponse
-	(*DowngradeVersionTestRequest)(nil),      // 67: etcdserverpb.DowngradeVersionTestRequest
-	(*StatusRequest)(nil),                    // 68: etcdserverpb.StatusRequest
-	(*StatusResponse)(nil),                   // 69: etcdserverpb.StatusResponse
-	(*DowngradeInfo)(nil),                    // 70: etcdserverpb.DowngradeInfo
-	(*AuthEnableRequest)(nil),                // 71: etcdserverpb.AuthEnableRequest
-	(*AuthDisableRequest)(nil),               // 72: etcdserverpb.AuthDisableRequest
-	(*AuthStatusRequest)(nil),                // 73: etcdserverpb.AuthStatusRequest
-	(*AuthenticateRequest)(nil),              // 74: etcdserverpb.AuthenticateRequest
-	(*AuthUserAddRequest)(nil),               // 75: etcdserverpb.AuthUserAddRequest
-	(*AuthUserGetRequest)(nil),               // 76: etcdserverpb.AuthUserGetRequest
-	(*AuthUserDeleteRequest)(nil),            // 77: etcdserverpb.AuthUserDeleteRequest
-	(*AuthUserChangePasswordRequest)(nil),    // 78: etcdserverpb.AuthUserChangePasswordRequest
-	(*AuthUserGrantRoleRequest)(nil),         // 79: etcdserverpb.AuthUserGrantRoleRequest
-	(*AuthUserRevokeRoleRequest)(nil),        // 80: etcdserverpb.AuthUserRevokeRoleRequest
-	(*AuthRoleAddRequest)(nil),               // 81: etcdserverpb.AuthRoleAddRequest
-	(*AuthRoleGetRequest)(nil),               // 82: etcdserverpb.AuthRoleGetRequest
-	(*AuthUserListRequest)(nil),              // 83: etcdserverpb.AuthUserListRequest
-	(*AuthRoleListRequest)(nil),              // 84: etcdserverpb.AuthRoleListRequest
-	(*AuthRoleDeleteRequest)(nil),            // 85: etcdserverpb.AuthRoleDeleteRequest
-	(*AuthRoleGrantPermissionRequest)(nil),   // 86: etcdserverpb.AuthRoleGrantPermissionRequest
-	(*AuthRoleRevokePermissionRequest)(nil),  // 87: etcdserverpb.AuthRoleRevokePermissionRequest
-	(*AuthEnableResponse)(nil),               // 88: etcdserverpb.AuthEnableResponse
-	(*AuthDisableResponse)(nil),              // 89: etcdserverpb.AuthDisableResponse
-	(*AuthStatusResponse)(nil),               // 90: etcdserverpb.AuthStatusResponse
-	(*AuthenticateResponse)(nil),             // 91: etcdserverpb.AuthenticateResponse
-	(*AuthUserAddResponse)(nil),              // 92: etcdserverpb.AuthUserAddResponse
-	(*AuthUserGetResponse)(nil),              // 93: etcdserverpb.AuthUserGetResponse
-	(*AuthUserDeleteResponse)(nil),           // 94: etcdserverpb.AuthUserDeleteResponse
-	(*AuthUserChangePasswordResponse)(nil),   // 95: etcdserverpb.AuthUserChangePasswordResponse
-	(*AuthUserGrantRoleResponse)(nil),        // 96: etcdserverpb.AuthUserGrantRoleResponse
-	(*AuthUserRevokeRoleResponse)(nil),       // 97: etcdserverpb.AuthUserRevokeRoleResponse
-	(*AuthRoleAddResponse)(nil),              // 98: etcdserverpb.AuthRoleAddResponse
-	(*AuthRoleGetResponse)(nil),              // 99: etcdserverpb.AuthRoleGetResponse
-	(*AuthRoleListResponse)(nil),             // 100: etcdserverpb.AuthRoleListResponse
-	(*AuthUserListResponse)(nil),             // 101: etcdserverpb.AuthUserListResponse
-	(*AuthRoleDeleteResponse)(nil),           // 102: etcdserverpb.AuthRoleDeleteResponse
-	(*AuthRoleGrantPermissionResponse)(nil),  // 103: etcdserverpb.AuthRoleGrantPermissionResponse
-	(*AuthRoleRevokePermissionResponse)(nil), // 104: etcdserverpb.AuthRoleRevokePermissionResponse
-	(*RangeStreamResponse)(nil),              // 105: etcdserverpb.RangeStreamResponse
-	(*mvccpb.KeyValue)(nil),                  // 106: mvccpb.KeyValue
-	(*mvccpb.Event)(nil),                     // 107: mvccpb.Event
-	(*authpb.UserAddOptions)(nil),            // 108: authpb.UserAddOptions
-	(*authpb.Permission)(nil),                // 109: authpb.Permission
+	(WatchCreateRequest_FilterOrder)(0),      // 6: etcdserverpb.WatchCreateRequest.FilterOrder
+	(LeaseEvent_EventType)(0),                // 7: etcdserverpb.LeaseEvent.EventType
+	(AlarmRequest_AlarmAction)(0),            // 8: etcdserverpb.AlarmRequest.AlarmAction
+	(DowngradeRequest_DowngradeAction)(0),    // 9: etcdserverpb.DowngradeRequest.DowngradeAction
+	(*ResponseHeader)(nil),                   // 10: etcdserverpb.ResponseHeader
+	(*RangeRequest)(nil),                     // 11: etcdserverpb.RangeRequest
+	(*RangeResponse)(nil),                    // 12: etcdserverpb.RangeResponse
+	(*PutRequest)(nil),                       // 13: etcdserverpb.PutRequest
+	(*PutResponse)(nil),                      // 14: etcdserverpb.PutResponse
+	(*DeleteRangeRequest)(nil),               // 15: etcdserverpb.DeleteRangeRequest
+	(*DeleteRangeResponse)(nil),              // 16: etcdserverpb.DeleteRangeResponse
+	(*RequestOp)(nil),                        // 17: etcdserverpb.RequestOp
+	(*ResponseOp)(nil),                       // 18: etcdserverpb.ResponseOp
+	(*Compare)(nil),                          // 19: etcdserverpb.Compare
+	(*TxnRequest)(nil),                       // 20: etcdserverpb.TxnRequest
+	(*TxnResponse)(nil),                      // 21: etcdserverpb.TxnResponse
+	(*CompactionRequest)(nil),                // 22: etcdserverpb.CompactionRequest
+	(*CompactionResponse)(nil),               // 23: etcdserverpb.CompactionResponse
+	(*HashRequest)(nil),                      // 24: etcdserverpb.HashRequest
+	(*HashKVRequest)(nil),                    // 25: etcdserverpb.HashKVRequest
+	(*HashKVResponse)(nil),                   // 26: etcdserverpb.HashKVResponse
+	(*HashResponse)(nil),                     // 27: etcdserverpb.HashResponse
+	(*SnapshotRequest)(nil),                  // 28: etcdserverpb.SnapshotRequest
+	(*SnapshotResponse)(nil),                 // 29: etcdserverpb.SnapshotResponse
+	(*WatchRequest)(nil),                     // 30: etcdserverpb.WatchRequest
+	(*WatchCreateRequest)(nil),               // 31: etcdserverpb.WatchCreateRequest
+	(*WatchCancelRequest)(nil),               // 32: etcdserverpb.WatchCancelRequest
+	(*WatchCancelRangeRequest)(nil),          // 33: etcdserverpb.WatchCancelRangeRequest
+	(*WatchAckRequest)(nil),                  // 34: etcdserverpb.WatchAckRequest
+	(*WatchProgressRequest)(nil),             // 35: etcdserverpb.WatchProgressRequest
+	(*WatchResponse)(nil),                    // 36: etcdserverpb.WatchResponse
+	(*LeaseGrantRequest)(nil),                // 37: etcdserverpb.LeaseGrantRequest
+	(*LeaseGrantResponse)(nil),               // 38: etcdserverpb.LeaseGrantResponse
+	(*LeaseRevokeRequest)(nil),               // 39: etcdserverpb.LeaseRevokeRequest
+	(*LeaseRevokeResponse)(nil),              // 40: etcdserverpb.LeaseRevokeResponse
+	(*LeaseCheckpoint)(nil),                  // 41: etcdserverpb.LeaseCheckpoint
+	(*LeaseCheckpointRequest)(nil),           // 42: etcdserverpb.LeaseCheckpointRequest
+	(*LeaseCheckpointResponse)(nil),          // 43: etcdserverpb.LeaseCheckpointResponse
+	(*LeaseKeepAliveRequest)(nil),            // 44: etcdserverpb.LeaseKeepAliveRequest
+	(*LeaseKeepAliveResponse)(nil),           // 45: etcdserverpb.LeaseKeepAliveResponse
+	(*LeaseTransferRequest)(nil),             // 46: etcdserverpb.LeaseTransferRequest
+	(*LeaseTransferResponse)(nil),            // 47: etcdserverpb.LeaseTransferResponse
+	(*LeaseTimeToLiveRequest)(nil),           // 48: etcdserverpb.LeaseTimeToLiveRequest
+	(*LeaseTimeToLiveResponse)(nil),          // 49: etcdserverpb.LeaseTimeToLiveResponse
+	(*LeaseLeasesRequest)(nil),               // 50: etcdserverpb.LeaseLeasesRequest
+	(*LeaseStatus)(nil),                      // 51: etcdserverpb.LeaseStatus
+	(*LeaseLeasesResponse)(nil),              // 52: etcdserverpb.LeaseLeasesResponse
+	(*LeaseWatchRequest)(nil),                // 53: etcdserverpb.LeaseWatchRequest
+	(*LeaseEvent)(nil),                       // 54: etcdserverpb.LeaseEvent
+	(*LeaseWatchResponse)(nil),               // 55: etcdserverpb.LeaseWatchResponse
+	(*Member)(nil),                           // 56: etcdserverpb.Member
+	(*MemberAddRequest)(nil),                 // 57: etcdserverpb.MemberAddRequest
+	(*MemberAddResponse)(nil),                // 58: etcdserverpb.MemberAddResponse
+	(*MemberRemoveRequest)(nil),              // 59: etcdserverpb.MemberRemoveRequest
+	(*MemberRemoveResponse)(nil),             // 60: etcdserverpb.MemberRemoveResponse
+	(*MemberUpdateRequest)(nil),              // 61: etcdserverpb.MemberUpdateRequest
+	(*MemberUpdateResponse)(nil),             // 62: etcdserverpb.MemberUpdateResponse
+	(*MemberListRequest)(nil),                // 63: etcdserverpb.MemberListRequest
+	(*MemberListResponse)(nil),               // 64: etcdserverpb.MemberListResponse
+	(*MemberPromoteRequest)(nil),             // 65: etcdserverpb.MemberPromoteRequest
+	(*MemberPromoteResponse)(nil),            // 66: etcdserverpb.MemberPromoteResponse
+	(*DefragmentRequest)(nil),                // 67: etcdserverpb.DefragmentRequest
+	(*DefragmentResponse)(nil),               // 68: etcdserverpb.DefragmentResponse
+	(*MoveLeaderRequest)(nil),                // 69: etcdserverpb.MoveLeaderRequest
+	(*MoveLeaderResponse)(nil),               // 70: etcdserverpb.MoveLeaderResponse
+	(*AlarmRequest)(nil),                     // 71: etcdserverpb.AlarmRequest
+	(*AlarmMember)(nil),                      // 72: etcdserverpb.AlarmMember
+	(*AlarmResponse)(nil),                    // 73: etcdserverpb.AlarmResponse
+	(*DowngradeRequest)(nil),                 // 74: etcdserverpb.DowngradeRequest
+	(*DowngradeResponse)(nil),                // 75: etcdserverpb.DowngradeResponse
+	(*DowngradeVersionTestRequest)(nil),      // 76: etcdserverpb.DowngradeVersionTestRequest
+	(*StatusRequest)(nil),                    // 77: etcdserverpb.StatusRequest
+	(*StatusResponse)(nil),                   // 78: etcdserverpb.StatusResponse
+	(*DiskUsage)(nil),                        // 79: etcdserverpb.DiskUsage
+	(*DowngradeInfo)(nil),                    // 80: etcdserverpb.DowngradeInfo
+	(*AuthEnableRequest)(nil),                // 81: etcdserverpb.AuthEnableRequest
+	(*AuthDisableRequest)(nil),               // 82: etcdserverpb.AuthDisableRequest
+	(*AuthStatusRequest)(nil),                // 83: etcdserverpb.AuthStatusRequest
+	(*AuthenticateRequest)(nil),              // 84: etcdserverpb.AuthenticateRequest
+	(*AuthUserAddRequest)(nil),               // 85: etcdserverpb.AuthUserAddRequest
+	(*AuthUserGetRequest)(nil),               // 86: etcdserverpb.AuthUserGetRequest
+	(*AuthUserDeleteRequest)(nil),            // 87: etcdserverpb.AuthUserDeleteRequest
+	(*AuthUserChangePasswordRequest)(nil),    // 88: etcdserverpb.AuthUserChangePasswordRequest
+	(*AuthUserGrantRoleRequest)(nil),         // 89: etcdserverpb.AuthUserGrantRoleRequest
+	(*AuthUserRevokeRoleRequest)(nil),        // 90: etcdserverpb.AuthUserRevokeRoleRequest
+	(*AuthRoleAddRequest)(nil),               // 91: etcdserverpb.AuthRoleAddRequest
+	(*AuthRoleGetRequest)(nil),               // 92: etcdserverpb.AuthRoleGetRequest
+	(*AuthUserListRequest)(nil),              // 93: etcdserverpb.AuthUserListRequest
+	(*AuthRoleListRequest)(nil),              // 94: etcdserverpb.AuthRoleListRequest
+	(*AuthRoleDeleteRequest)(nil),            // 95: etcdserverpb.AuthRoleDeleteRequest
+	(*AuthRoleGrantPermissionRequest)(nil),   // 96: etcdserverpb.AuthRoleGrantPermissionRequest
+	(*AuthRoleRevokePermissionRequest)(nil),  // 97: etcdserverpb.AuthRoleRevokePermissionRequest
+	(*AuthEnableResponse)(nil),               // 98: etcdserverpb.AuthEnableResponse
+	(*AuthDisableResponse)(nil),              // 99: etcdserverpb.AuthDisableResponse
+	(*AuthStatusResponse)(nil),               // 100: etcdserverpb.AuthStatusResponse
+	(*AuthenticateResponse)(nil),             // 101: etcdserverpb.AuthenticateResponse
+	(*AuthUserAddResponse)(nil),              // 102: etcdserverpb.AuthUserAddResponse
+	(*AuthUserGetResponse)(nil),              // 103: etcdserverpb.AuthUserGetResponse
+	(*AuthUserDeleteResponse)(nil),           // 104: etcdserverpb.AuthUserDeleteResponse
+	(*AuthUserChangePasswordResponse)(nil),   // 105: etcdserverpb.AuthUserChangePasswordResponse
+	(*AuthUserGrantRoleResponse)(nil),        // 106: etcdserverpb.AuthUserGrantRoleResponse
+	(*AuthUserRevokeRoleResponse)(nil),       // 107: etcdserverpb.AuthUserRevokeRoleResponse
+	(*AuthRoleAddResponse)(nil),              // 108: etcdserverpb.AuthRoleAddResponse
+	(*AuthRoleGetResponse)(nil),              // 109: etcdserverpb.AuthRoleGetResponse
+	(*AuthRoleListResponse)(nil),             // 110: etcdserverpb.AuthRoleListResponse
+	(*AuthUserListResponse)(nil),             // 111: etcdserverpb.AuthUserListResponse
+	(*AuthRoleDeleteResponse)(nil),           // 112: etcdserverpb.AuthRoleDeleteResponse
+	(*AuthRoleGrantPermissionResponse)(nil),  // 113: etcdserverpb.AuthRoleGrantPermissionResponse
+	(*AuthRoleRevokePermissionResponse)(nil), // 114: etcdserverpb.AuthRoleRevokePermissionResponse
+	(*RangeStreamResponse)(nil),              // 115: etcdserverpb.RangeStreamResponse
+	(*mvccpb.KeyValue)(nil),                  // 116: mvccpb.KeyValue
+	(*mvccpb.Event)(nil),                     // 117: mvccpb.Event
+	(*authpb.UserAddOptions)(nil),            // 118: authpb.UserAddOptions
+	(*authpb.Permission)(nil),                // 119: authpb.Permission
 }
 var file_rpc_proto_depIdxs = []int32{
 	1,   // 0: etcdserverpb.RangeRequest.sort_order:type_name -> etcdserverpb.RangeRequest.SortOrder
 	2,   // 1: etcdserverpb.RangeRequest.sort_target:type_name -> etcdserverpb.RangeRequest.SortTarget
-	8,   // 2: etcdserverpb.RangeResponse.header:type_name -> etcdserverpb.ResponseHeader
-	106, // 3: etcdserverpb.RangeResponse.kvs:type_name -> mvccpb.KeyValue
-	8,   // 4: etcdserverpb.PutResponse.header:type_name -> etcdserverpb.ResponseHeader
-	106, // 5: etcdserverpb.PutResponse.prev_kv:type_name -> mvccpb.KeyValue
-	8,   // 6: etcdserverpb.DeleteRangeResponse.header:type_name -> etcdserverpb.ResponseHeader
-	106, // 7: etcdserverpb.DeleteRangeResponse.prev_kvs:type_name -> mvccpb.KeyValue
-	9,   // 8: etcdserverpb.RequestOp.request_range:type_name -> etcdserverpb.RangeRequest
-	11,  // 9: etcdserverpb.RequestOp.request_put:type_name -> etcdserverpb.PutRequest
-	13,  // 10: etcdserverpb.RequestOp.request_delete_range:type_name -> etcdserverpb.DeleteRangeRequest
-	18,  // 11: etcdserverpb.RequestOp.request_txn:type_name -> etcdserverpb.TxnRequest
-	10,  // 12: etcdserverpb.ResponseOp.response_range:type_name -> etcdserverpb.RangeResponse
-	12,  // 13: etcdserverpb.ResponseOp.response_put:type_name -> etcdserverpb.PutResponse
-	14,  // 14: etcdserverpb.ResponseOp.response_delete_range:type_name -> etcdserverpb.DeleteRangeResponse
-	19,  // 15: etcdserverpb.ResponseOp.response_txn:type_name -> etcdserverpb.TxnResponse
+	10,  // 2: etcdserverpb.RangeResponse.header:type_name -> etcdserverpb.ResponseHeader
+	116, // 3: etcdserverpb.RangeResponse.kvs:type_name -> mvccpb.KeyValue
+	10,  // 4: etcdserverpb.PutResponse.header:type_name -> etcdserverpb.ResponseHeader
+	116, // 5: etcdserverpb.PutResponse.prev_kv:type_name -> mvccpb.KeyValue
+	10,  // 6: etcdserverpb.DeleteRangeResponse.header:type_name -> etcdserverpb.ResponseHeader
+	116, // 7: etcdserverpb.DeleteRangeResponse.prev_kvs:type_name -> mvccpb.KeyValue
+	11,  // 8: etcdserverpb.RequestOp.request_range:type_name -> etcdserverpb.RangeRequest
+	13,  // 9: etcdserverpb.RequestOp.request_put:type_name -> etcdserverpb.PutRequest
+	15,  // 10: etcdserverpb.RequestOp.request_delete_range:type_name -> etcdserverpb.DeleteRangeRequest
+	20,  // 11: etcdserverpb.RequestOp.request_txn:type_name -> etcdserverpb.TxnRequest
+	12,  // 12: etcdserverpb.ResponseOp.response_range:type_name -> etcdserverpb.RangeResponse
+	14,  // 13: etcdserverpb.ResponseOp.response_put:type_name -> etcdserverpb.PutResponse
+	16,  // 14: etcdserverpb.ResponseOp.response_delete_range:type_name -> etcdserverpb.DeleteRangeResponse
+	21,  // 15: etcdserverpb.ResponseOp.response_txn:type_name -> etcdserverpb.TxnResponse
 	3,   // 16: etcdserverpb.Compare.result:type_name -> etcdserverpb.Compare.CompareResult
 	4,   // 17: etcdserverpb.Compare.target:type_name -> etcdserverpb.Compare.CompareTarget
-	17,  // 18: etcdserverpb.TxnRequest.compare:type_name -> etcdserverpb.Compare
-	15,  // 19: etcdserverpb.TxnRequest.success:type_name -> etcdserverpb.RequestOp
-	15,  // 20: etcdserverpb.TxnRequest.failure:type_name -> etcdserverpb.RequestOp
-	8,   // 21: etcdserverpb.TxnResponse.header:type_name -> etcdserverpb.ResponseHeader
-	16,  // 22: etcdserverpb.TxnResponse.responses:type_name -> etcdserverpb.ResponseOp
-	8,   // 23: etcdserverpb.CompactionResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 24: etcdserverpb.HashKVResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 25: etcdserverpb.HashResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 26: etcdserverpb.SnapshotResponse.header:type_name -> etcdserverpb.ResponseHeader
-	29,  // 27: etcdserverpb.WatchRequest.create_request:type_name -> etcdserverpb.WatchCreateRequest
-	30,  // 28: etcdserverpb.WatchRequest.cancel_request:type_name -> etcdserverpb.WatchCancelRequest
-	31,  // 29: etcdserverpb.WatchRequest.progress_request:type_name -> etcdserverpb.WatchProgressRequest
-	5,   // 30: etcdserverpb.WatchCreateRequest.filters:type_name -> etcdserverpb.WatchCreateRequest.FilterType
-	8,   // 31: etcdserverpb.WatchResponse.header:type_name -> etcdserverpb.ResponseHeader
-	107, // 32: etcdserverpb.WatchResponse.events:type_name -> mvccpb.Event
-	8,   // 33: etcdserverpb.LeaseGrantResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 34: etcdserverpb.LeaseRevokeResponse.header:type_name -> etcdserverpb.ResponseHeader
-	37,  // 35: etcdserverpb.LeaseCheckpointRequest.checkpoints:type_name -> etcdserverpb.LeaseCheckpoint
-	8,   // 36: etcdserverpb.LeaseCheckpointResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 37: etcdserverpb.LeaseKeepAliveResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 38: etcdserverpb.LeaseTimeToLiveResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 39: etcdserverpb.LeaseLeasesResponse.header:type_name -> etcdserverpb.ResponseHeader
-	45,  // 40: etcdserverpb.LeaseLeasesResponse.leases:type_name -> etcdserverpb.LeaseStatus
-	8,   // 41: etcdserverpb.MemberAddResponse.header:type_name -> etcdserverpb.ResponseHeader
-	47,  // 42: etcdserverpb.MemberAddResponse.member:type_name -> etcdserverpb.Member
-	47,  // 43: etcdserverpb.MemberAddResponse.members:type_name -> etcdserverpb.Member
-	8,   // 44: etcdserverpb.MemberRemoveResponse.header:type_name -> etcdserverpb.ResponseHeader
-	47,  // 45: etcdserverpb.MemberRemoveResponse.members:type_name -> etcdserverpb.Member
-	8,   // 46: etcdserverpb.MemberUpdateResponse.header:type_name -> etcdserverpb.ResponseHeader
-	47,  // 47: etcdserverpb.MemberUpdateResponse.members:type_name -> etcdserverpb.Member
-	8,   // 48: etcdserverpb.MemberListResponse.header:type_name -> etcdserverpb.ResponseHeader
-	47,  // 49: etcdserverpb.MemberListResponse.members:type_name -> etcdserverpb.Member
-	8,   // 50: etcdserverpb.MemberPromoteResponse.header:type_name -> etcdserverpb.ResponseHeader
-	47,  // 51: etcdserverpb.MemberPromoteResponse.members:type_name -> etcdserverpb.Member
-	8,   // 52: etcdserverpb.DefragmentResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 53: etcdserverpb.MoveLeaderResponse.header:type_name -> etcdserverpb.ResponseHeader
-	6,   // 54: etcdserverpb.AlarmRequest.action:type_name -> etcdserverpb.AlarmRequest.AlarmAction
-	0,   // 55: etcdserverpb.AlarmRequest.alarm:type_name -> etcdserverpb.AlarmType
-	0,   // 56: etcdserverpb.AlarmMember.alarm:type_name -> etcdserverpb.AlarmType
-	8,   // 57: etcdserverpb.AlarmResponse.header:type_name -> etcdserverpb.ResponseHeader
-	63,  // 58: etcdserverpb.AlarmResponse.alarms:type_name -> etcdserverpb.AlarmMember
-	7,   // 59: etcdserverpb.DowngradeRequest.action:type_name -> etcdserverpb.DowngradeRequest.DowngradeAction
-	8,   // 60: etcdserverpb.DowngradeResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 61: etcdserverpb.StatusResponse.header:type_name -> etcdserverpb.ResponseHeader
-	70,  // 62: etcdserverpb.StatusResponse.downgradeInfo:type_name -> etcdserverpb.DowngradeInfo
-	108, // 63: etcdserverpb.AuthUserAddRequest.options:type_name -> authpb.UserAddOptions
-	109, // 64: etcdserverpb.AuthRoleGrantPermissionRequest.perm:type_name -> authpb.Permission
-	8,   // 65: etcdserverpb.AuthEnableResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 66: etcdserverpb.AuthDisableResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 67: etcdserverpb.AuthStatusResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 68: etcdserverpb.AuthenticateResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 69: etcdserverpb.AuthUserAddResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 70: etcdserverpb.AuthUserGetResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 71: etcdserverpb.AuthUserDeleteResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 72: etcdserverpb.AuthUserChangePasswordResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 73: etcdserverpb.AuthUserGrantRoleResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 74: etcdserverpb.AuthUserRevokeRoleResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 75: etcdserverpb.AuthRoleAddResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 76: etcdserverpb.AuthRoleGetResponse.header:type_name -> etcdserverpb.ResponseHeader
-	109, // 77: etcdserverpb.AuthRoleGetResponse.perm:type_name -> authpb.Permission
-	8,   // 78: etcdserverpb.AuthRoleListResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 79: etcdserverpb.AuthUserListResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 80: etcdserverpb.AuthRoleDeleteResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 81: etcdserverpb.AuthRoleGrantPermissionResponse.header:type_name -> etcdserverpb.ResponseHeader
-	8,   // 82: etcdserverpb.AuthRoleRevokePermissionResponse.header:type_name -> etcdserverpb.ResponseHeader
-	10,  // 83: etcdserverpb.RangeStreamResponse.range_response:type_name -> etcdserverpb.RangeResponse
-	9,   // 84: etcdserverpb.KV.Range:input_type -> etcdserverpb.RangeRequest
-	9,   // 85: etcdserverpb.KV.RangeStream:input_type -> etcdserverpb.RangeRequest
-	11,  // 86: etcdserverpb.KV.Put:input_type -> etcdserverpb.PutRequest
-	13,  // 87: etcdserverpb.KV.DeleteRange:input_type -> etcdserverpb.DeleteRangeRequest
-	18,  // 88: etcdserverpb.KV.Txn:input_type -> etcdserverpb.TxnRequest
-	20,  // 89: etcdserverpb.KV.Compact:input_type -> etcdserverpb.CompactionRequest
-	28,  // 90: etcdserverpb.Watch.Watch:input_type -> etcdserverpb.WatchRequest
-	33,  // 91: etcdserverpb.Lease.LeaseGrant:input_type -> etcdserverpb.LeaseGrantRequest
-	35,  // 92: etcdserverpb.Lease.LeaseRevoke:input_type -> etcdserverpb.LeaseRevokeRequest
-	40,  // 93: etcdserverpb.Lease.LeaseKeepAlive:input_type -> etcdserverpb.LeaseKeepAliveRequest
-	42,  // 94: etcdserverpb.Lease.LeaseTimeToLive:input_type -> etcdserverpb.LeaseTimeToLiveRequest
-	44,  // 95: etcdserverpb.Lease.LeaseLeases:input_type -> etcdserverpb.LeaseLeasesRequest
-	48,  // 96: etcdserverpb.Cluster.MemberAdd:input_type -> etcdserverpb.MemberAddRequest
-	50,  // 97: etcdserverpb.Cluster.MemberRemove:input_type -> etcdserverpb.MemberRemoveRequest
-	52,  // 98: etcdserverpb.Cluster.MemberUpdate:input_type -> etcdserverpb.MemberUpdateRequest
-	54,  // 99: etcdserverpb.Cluster.MemberList:input_type -> etcdserverpb.MemberListRequest
-	56,  // 100: etcdserverpb.Cluster.MemberPromote:input_type -> etcdserverpb.MemberPromoteRequest
-	62,  // 101: etcdserverpb.Maintenance.Alarm:input_type -> etcdserverpb.AlarmRequest
-	68,  // 102: etcdserverpb.Maintenance.Status:input_type -> etcdserverpb.StatusRequest
-	58,  // 103: etcdserverpb.Maintenance.Defragment:input_type -> etcdserverpb.DefragmentRequest
-	22,  // 104: etcdserverpb.Maintenance.Hash:input_type -> etcdserverpb.HashRequest
-	23,  // 105: etcdserverpb.Maintenance.HashKV:input_type -> etcdserverpb.HashKVRequest
-	26,  // 106: etcdserverpb.Maintenance.Snapshot:input_type -> etcdserverpb.SnapshotRequest
-	60,  // 107: etcdserverpb.Maintenance.MoveLeader:input_type -> etcdserverpb.MoveLeaderRequest
-	65,  // 108: etcdserverpb.Maintenance.Downgrade:input_type -> etcdserverpb.DowngradeRequest
-	71,  // 109: etcdserverpb.Auth.AuthEnable:input_type -> etcdserverpb.AuthEnableRequest
-	72,  // 110: etcdserverpb.Auth.AuthDisable:input_type -> etcdserverpb.AuthDisableRequest
-	73,  // 111: etcdserverpb.Auth.AuthStatus:input_type -> etcdserverpb.AuthStatusRequest
-	74,  // 112: etcdserverpb.Auth.Authenticate:input_type -> etcdserverpb.AuthenticateRequest
-	75,  // 113: etcdserverpb.Auth.UserAdd:input_type -> etcdserverpb.AuthUserAddRequest
-	76,  // 114: etcdserverpb.Auth.UserGet:input_type -> etcdserverpb.AuthUserGetRequest
-	83,  // 115: etcdserverpb.Auth.UserList:input_type -> etcdserverpb.AuthUserListRequest
-	77,  // 116: etcdserverpb.Auth.UserDelete:input_type -> etcdserverpb.AuthUserDeleteRequest
-	78,  // 117: etcdserverpb.Auth.UserChangePassword:input_type -> etcdserverpb.AuthUserChangePasswordRequest
-	79,  // 118: etcdserverpb.Auth.UserGrantRole:input_type -> etcdserverpb.AuthUserGrantRoleRequest
-	80,  // 119: etcdserverpb.Auth.UserRevokeRole:input_type -> etcdserverpb.AuthUserRevokeRoleRequest
-	81,  // 120: etcdserverpb.Auth.RoleAdd:input_type -> etcdserverpb.AuthRoleAddRequest
-	82,  // 121: etcdserverpb.Auth.RoleGet:input_type -> etcdserverpb.AuthRoleGetRequest
-	84,  // 122: etcdserverpb.Auth.RoleList:input_type -> etcdserverpb.AuthRoleListRequest
-	85,  // 123: etcdserverpb.Auth.RoleDelete:input_type -> etcdserverpb.AuthRoleDeleteRequest
-	86,  // 124: etcdserverpb.Auth.RoleGrantPermission:input_type -> etcdserverpb.AuthRoleGrantPermissionRequest
-	87,  // 125: etcdserverpb.Auth.RoleRevokePermission:input_type -> etcdserverpb.AuthRoleRevokePermissionRequest
-	10,  // 126: etcdserverpb.KV.Range:output_type -> etcdserverpb.RangeResponse
-	105, // 127: etcdserverpb.KV.RangeStream:output_type -> etcdserverpb.RangeStreamResponse
-	12,  // 128: etcdserverpb.KV.Put:output_type -> etcdserverpb.PutResponse
-	14,  // 129: etcdserverpb.KV.DeleteRange:output_type -> etcdserverpb.DeleteRangeResponse
-	19,  // 130: etcdserverpb.KV.Txn:output_type -> etcdserverpb.TxnResponse
-	21,  // 131: etcdserverpb.KV.Compact:output_type -> etcdserverpb.CompactionResponse
-	32,  // 132: etcdserverpb.Watch.Watch:output_type -> etcdserverpb.WatchResponse
-	34,  // 133: etcdserverpb.Lease.LeaseGrant:output_type -> etcdserverpb.LeaseGrantResponse
-	36,  // 134: etcdserverpb.Lease.LeaseRevoke:output_type -> etcdserverpb.LeaseRevokeResponse
-	41,  // 135: etcdserverpb.Lease.LeaseKeepAlive:output_type -> etcdserverpb.LeaseKeepAliveResponse
-	43,  // 136: etcdserverpb.Lease.LeaseTimeToLive:output_type -> etcdserverpb.LeaseTimeToLiveResponse
-	46,  // 137: etcdserverpb.Lease.LeaseLeases:output_type -> etcdserverpb.LeaseLeasesResponse
-	49,  // 138: etcdserverpb.Cluster.MemberAdd:output_type -> etcdserverpb.MemberAddResponse
-	51,  // 139: etcdserverpb.Cluster.MemberRemove:output_type -> etcdserverpb.MemberRemoveResponse
-	53,  // 140: etcdserverpb.Cluster.MemberUpdate:output_type -> etcdserverpb.MemberUpdateResponse
-	55,  // 141: etcdserverpb.Cluster.MemberList:output_type -> etcdserverpb.MemberListResponse
-	57,  // 142: etcdserverpb.Cluster.MemberPromote:output_type -> etcdserverpb.MemberPromoteResponse
-	64,  // 143: etcdserverpb.Maintenance.Alarm:output_type -> etcdserverpb.AlarmResponse
-	69,  // 144: etcdserverpb.Maintenance.Status:output_type -> etcdserverpb.StatusResponse
-	59,  // 145: etcdserverpb.Maintenance.Defragment:output_type -> etcdserverpb.DefragmentResponse
-	25,  // 146: etcdserverpb.Maintenance.Hash:output_type -> etcdserverpb.HashResponse
-	24,  // 147: etcdserverpb.Maintenance.HashKV:output_type -> etcdserverpb.HashKVResponse
-	27,  // 148: etcdserverpb.Maintenance.Snapshot:output_type -> etcdserverpb.SnapshotResponse
-	61,  // 149: etcdserverpb.Maintenance.MoveLeader:output_type -> etcdserverpb.MoveLeaderResponse
-	66,  // 150: etcdserverpb.Maintenance.Downgrade:output_type -> etcdserverpb.DowngradeResponse
-	88,  // 151: etcdserverpb.Auth.AuthEnable:output_type -> etcdserverpb.AuthEnableResponse
-	89,  // 152: etcdserverpb.Auth.AuthDisable:output_type -> etcdserverpb.AuthDisableResponse
-	90,  // 153: etcdserverpb.Auth.AuthStatus:output_type -> etcdserverpb.AuthStatusResponse
-	91,  // 154: etcdserverpb.Auth.Authenticate:output_type -> etcdserverpb.AuthenticateResponse
-	92,  // 155: etcdserverpb.Auth.UserAdd:output_type -> etcdserverpb.AuthUserAddResponse
-	93,  // 156: etcdserverpb.Auth.UserGet:output_type -> etcdserverpb.AuthUserGetResponse
-	101, // 157: etcdserverpb.Auth.UserList:output_type -> etcdserverpb.AuthUserListResponse
-	94,  // 158: etcdserverpb.Auth.UserDelete:output_type -> etcdserverpb.AuthUserDeleteResponse
-	95,  // 159: etcdserverpb.Auth.UserChangePassword:output_type -> etcdserverpb.AuthUserChangePasswordResponse
-	96,  // 160: etcdserverpb.Auth.UserGrantRole:output_type -> etcdserverpb.AuthUserGrantRoleResponse
-	97,  // 161: etcdserverpb.Auth.UserRevokeRole:output_type -> etcdserverpb.AuthUserRevokeRoleResponse
-	98,  // 162: etcdserverpb.Auth.RoleAdd:output_type -> etcdserverpb.AuthRoleAddResponse
-	99,  // 163: etcdserverpb.Auth.RoleGet:output_type -> etcdserverpb.AuthRoleGetResponse
-	100, // 164: etcdserverpb.Auth.RoleList:output_type -> etcdserverpb.AuthRoleListResponse
-	102, // 165: etcdserverpb.Auth.RoleDelete:output_type -> etcdserverpb.AuthRoleDeleteResponse
-	103, // 166: etcdserverpb.Auth.RoleGrantPermission:output_type -> etcdserverpb.AuthRoleGrantPermissionResponse
-	104, // 167: etcdserverpb.Auth.RoleRevokePermission:output_type -> etcdserverpb.AuthRoleRevokePermissionResponse
-	126, // [126:168] is the sub-list for method output_type
-	84,  // [84:126] is the sub-list for method input_type
-	84,  // [84:84] is the sub-list for extension type_name
-	84,  // [84:84] is the sub-list for extension extendee
-	0,   // [0:84] is the sub-list for field type_name
+	19,  // 18: etcdserverpb.TxnRequest.compare:type_name -> etcdserverpb.Compare
+	17,  // 19: etcdserverpb.TxnRequest.success:type_name -> etcdserverpb.RequestOp
+	17,  // 20: etcdserverpb.TxnRequest.failure:type_name -> etcdserverpb.RequestOp
+	10,  // 21: etcdserverpb.TxnResponse.header:type_name -> etcdserverpb.ResponseHeader
+	18,  // 22: etcdserverpb.TxnResponse.responses:type_name -> etcdserverpb.ResponseOp
+	10,  // 23: etcdserverpb.CompactionResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 24: etcdserverpb.HashKVResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 25: etcdserverpb.HashResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 26: etcdserverpb.SnapshotResponse.header:type_name -> etcdserverpb.ResponseHeader
+	31,  // 27: etcdserverpb.WatchRequest.create_request:type_name -> etcdserverpb.WatchCreateRequest
+	32,  // 28: etcdserverpb.WatchRequest.cancel_request:type_name -> etcdserverpb.WatchCancelRequest
+	35,  // 29: etcdserverpb.WatchRequest.progress_request:type_name -> etcdserverpb.WatchProgressRequest
+	34,  // 30: etcdserverpb.WatchRequest.ack_request:type_name -> etcdserverpb.WatchAckRequest
+	33,  // 31: etcdserverpb.WatchRequest.cancel_range_request:type_name -> etcdserverpb.WatchCancelRangeRequest
+	5,   // 32: etcdserverpb.WatchCreateRequest.filters:type_name -> etcdserverpb.WatchCreateRequest.FilterType
+	6,   // 33: etcdserverpb.WatchCreateRequest.filter_order:type_name -> etcdserverpb.WatchCreateRequest.FilterOrder
+	19,  // 34: etcdserverpb.WatchCreateRequest.compare:type_name -> etcdserverpb.Compare
+	10,  // 35: etcdserverpb.WatchResponse.header:type_name -> etcdserverpb.ResponseHeader
+	117, // 36: etcdserverpb.WatchResponse.events:type_name -> mvccpb.Event
+	10,  // 37: etcdserverpb.LeaseGrantResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 38: etcdserverpb.LeaseRevokeResponse.header:type_name -> etcdserverpb.ResponseHeader
+	41,  // 39: etcdserverpb.LeaseCheckpointRequest.checkpoints:type_name -> etcdserverpb.LeaseCheckpoint
+	10,  // 40: etcdserverpb.LeaseCheckpointResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 41: etcdserverpb.LeaseKeepAliveResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 42: etcdserverpb.LeaseTransferResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 43: etcdserverpb.LeaseTimeToLiveResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 44: etcdserverpb.LeaseLeasesResponse.header:type_name -> etcdserverpb.ResponseHeader
+	51,  // 45: etcdserverpb.LeaseLeasesResponse.leases:type_name -> etcdserverpb.LeaseStatus
+	7,   // 46: etcdserverpb.LeaseEvent.type:type_name -> etcdserverpb.LeaseEvent.EventType
+	10,  // 47: etcdserverpb.LeaseWatchResponse.header:type_name -> etcdserverpb.ResponseHeader
+	54,  // 48: etcdserverpb.LeaseWatchResponse.events:type_name -> etcdserverpb.LeaseEvent
+	10,  // 49: etcdserverpb.MemberAddResponse.header:type_name -> etcdserverpb.ResponseHeader
+	56,  // 50: etcdserverpb.MemberAddResponse.member:type_name -> etcdserverpb.Member
+	56,  // 51: etcdserverpb.MemberAddResponse.members:type_name -> etcdserverpb.Member
+	10,  // 52: etcdserverpb.MemberRemoveResponse.header:type_name -> etcdserverpb.ResponseHeader
+	56,  // 53: etcdserverpb.MemberRemoveResponse.members:type_name -> etcdserverpb.Member
+	10,  // 54: etcdserverpb.MemberUpdateResponse.header:type_name -> etcdserverpb.ResponseHeader
+	56,  // 55: etcdserverpb.MemberUpdateResponse.members:type_name -> etcdserverpb.Member
+	10,  // 56: etcdserverpb.MemberListResponse.header:type_name -> etcdserverpb.ResponseHeader
+	56,  // 57: etcdserverpb.MemberListResponse.members:type_name -> etcdserverpb.Member
+	10,  // 58: etcdserverpb.MemberPromoteResponse.header:type_name -> etcdserverpb.ResponseHeader
+	56,  // 59: etcdserverpb.MemberPromoteResponse.members:type_name -> etcdserverpb.Member
+	10,  // 60: etcdserverpb.DefragmentResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 61: etcdserverpb.MoveLeaderResponse.header:type_name -> etcdserverpb.ResponseHeader
+	8,   // 62: etcdserverpb.AlarmRequest.action:type_name -> etcdserverpb.AlarmRequest.AlarmAction
+	0,   // 63: etcdserverpb.AlarmRequest.alarm:type_name -> etcdserverpb.AlarmType
+	0,   // 64: etcdserverpb.AlarmMember.alarm:type_name -> etcdserverpb.AlarmType
+	10,  // 65: etcdserverpb.AlarmResponse.header:type_name -> etcdserverpb.ResponseHeader
+	72,  // 66: etcdserverpb.AlarmResponse.alarms:type_name -> etcdserverpb.AlarmMember
+	9,   // 67: etcdserverpb.DowngradeRequest.action:type_name -> etcdserverpb.DowngradeRequest.DowngradeAction
+	10,  // 68: etcdserverpb.DowngradeResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 69: etcdserverpb.StatusResponse.header:type_name -> etcdserverpb.ResponseHeader
+	80,  // 70: etcdserverpb.StatusResponse.downgradeInfo:type_name -> etcdserverpb.DowngradeInfo
+	79,  // 71: etcdserverpb.StatusResponse.diskUsage:type_name -> etcdserverpb.DiskUsage
+	118, // 72: etcdserverpb.AuthUserAddRequest.options:type_name -> authpb.UserAddOptions
+	119, // 73: etcdserverpb.AuthRoleGrantPermissionRequest.perm:type_name -> authpb.Permission
+	10,  // 74: etcdserverpb.AuthEnableResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 75: etcdserverpb.AuthDisableResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 76: etcdserverpb.AuthStatusResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 77: etcdserverpb.AuthenticateResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 78: etcdserverpb.AuthUserAddResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 79: etcdserverpb.AuthUserGetResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 80: etcdserverpb.AuthUserDeleteResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 81: etcdserverpb.AuthUserChangePasswordResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 82: etcdserverpb.AuthUserGrantRoleResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 83: etcdserverpb.AuthUserRevokeRoleResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 84: etcdserverpb.AuthRoleAddResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 85: etcdserverpb.AuthRoleGetResponse.header:type_name -> etcdserverpb.ResponseHeader
+	119, // 86: etcdserverpb.AuthRoleGetResponse.perm:type_name -> authpb.Permission
+	10,  // 87: etcdserverpb.AuthRoleListResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 88: etcdserverpb.AuthUserListResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 89: etcdserverpb.AuthRoleDeleteResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 90: etcdserverpb.AuthRoleGrantPermissionResponse.header:type_name -> etcdserverpb.ResponseHeader
+	10,  // 91: etcdserverpb.AuthRoleRevokePermissionResponse.header:type_name -> etcdserverpb.ResponseHeader
+	12,  // 92: etcdserverpb.RangeStreamResponse.range_response:type_name -> etcdserverpb.RangeResponse
+	11,  // 93: etcdserverpb.KV.Range:input_type -> etcdserverpb.RangeRequest
+	11,  // 94: etcdserverpb.KV.RangeStream:input_type -> etcdserverpb.RangeRequest
+	13,  // 95: etcdserverpb.KV.Put:input_type -> etcdserverpb.PutRequest
+	15,  // 96: etcdserverpb.KV.DeleteRange:input_type -> etcdserverpb.DeleteRangeRequest
+	20,  // 97: etcdserverpb.KV.Txn:input_type -> etcdserverpb.TxnRequest
+	22,  // 98: etcdserverpb.KV.Compact:input_type -> etcdserverpb.CompactionRequest
+	30,  // 99: etcdserverpb.Watch.Watch:input_type -> etcdserverpb.WatchRequest
+	37,  // 100: etcdserverpb.Lease.LeaseGrant:input_type -> etcdserverpb.LeaseGrantRequest
+	39,  // 101: etcdserverpb.Lease.LeaseRevoke:input_type -> etcdserverpb.LeaseRevokeRequest
+	44,  // 102: etcdserverpb.Lease.LeaseKeepAlive:input_type -> etcdserverpb.LeaseKeepAliveRequest
+	46,  // 103: etcdserverpb.Lease.LeaseTransfer:input_type -> etcdserverpb.LeaseTransferRequest
+	48,  // 104: etcdserverpb.Lease.LeaseTimeToLive:input_type -> etcdserverpb.LeaseTimeToLiveRequest
+	50,  // 105: etcdserverpb.Lease.LeaseLeases:input_type -> etcdserverpb.LeaseLeasesRequest
+	53,  // 106: etcdserverpb.Lease.LeaseWatch:input_type -> etcdserverpb.LeaseWatchRequest
+	57,  // 107: etcdserverpb.Cluster.MemberAdd:input_type -> etcdserverpb.MemberAddRequest
+	59,  // 108: etcdserverpb.Cluster.MemberRemove:input_type -> etcdserverpb.MemberRemoveRequest
+	61,  // 109: etcdserverpb.Cluster.MemberUpdate:input_type -> etcdserverpb.MemberUpdateRequest
+	63,  // 110: etcdserverpb.Cluster.MemberList:input_type -> etcdserverpb.MemberListRequest
+	65,  // 111: etcdserverpb.Cluster.MemberPromote:input_type -> etcdserverpb.MemberPromoteRequest
+	71,  // 112: etcdserverpb.Maintenance.Alarm:input_type -> etcdserverpb.AlarmRequest
+	77,  // 113: etcdserverpb.Maintenance.Status:input_type -> etcdserverpb.StatusRequest
+	67,  // 114: etcdserverpb.Maintenance.Defragment:input_type -> etcdserverpb.DefragmentRequest
+	24,  // 115: etcdserverpb.Maintenance.Hash:input_type -> etcdserverpb.HashRequest
+	25,  // 116: etcdserverpb.Maintenance.HashKV:input_type -> etcdserverpb.HashKVRequest
+	28,  // 117: etcdserverpb.Maintenance.Snapshot:input_type -> etcdserverpb.SnapshotRequest
+	69,  // 118: etcdserverpb.Maintenance.MoveLeader:input_type -> etcdserverpb.MoveLeaderRequest
+	74,  // 119: etcdserverpb.Maintenance.Downgrade:input_type -> etcdserverpb.DowngradeRequest
+	81,  // 120: etcdserverpb.Auth.AuthEnable:input_type -> etcdserverpb.AuthEnableRequest
+	82,  // 121: etcdserverpb.Auth.AuthDisable:input_type -> etcdserverpb.AuthDisableRequest
+	83,  // 122: etcdserverpb.Auth.AuthStatus:input_type -> etcdserverpb.AuthStatusRequest
+	84,  // 123: etcdserverpb.Auth.Authenticate:input_type -> etcdserverpb.AuthenticateRequest
+	85,  // 124: etcdserverpb.Auth.UserAdd:input_type -> etcdserverpb.AuthUserAddRequest
+	86,  // 125: etcdserverpb.Auth.UserGet:input_type -> etcdserverpb.AuthUserGetRequest
+	93,  // 126: etcdserverpb.Auth.UserList:input_type -> etcdserverpb.AuthUserListRequest
+	87,  // 127: etcdserverpb.Auth.UserDelete:input_type -> etcdserverpb.AuthUserDeleteRequest
+	88,  // 128: etcdserverpb.Auth.UserChangePassword:input_type -> etcdserverpb.AuthUserChangePasswordRequest
+	89,  // 129: etcdserverpb.Auth.UserGrantRole:input_type -> etcdserverpb.AuthUserGrantRoleRequest
+	90,  // 130: etcdserverpb.Auth.UserRevokeRole:input_type -> etcdserverpb.AuthUserRevokeRoleRequest
+	91,  // 131: etcdserverpb.Auth.RoleAdd:input_type -> etcdserverpb.AuthRoleAddRequest
+	92,  // 132: etcdserverpb.Auth.RoleGet:input_type -> etcdserverpb.AuthRoleGetRequest
+	94,  // 133: etcdserverpb.Auth.RoleList:input_type -> etcdserverpb.AuthRoleListRequest
+	95,  // 134: etcdserverpb.Auth.RoleDelete:input_type -> etcdserverpb.AuthRoleDeleteRequest
+	96,  // 135: etcdserverpb.Auth.RoleGrantPermission:input_type -> etcdserverpb.AuthRoleGrantPermissionRequest
+	97,  // 136: etcdserverpb.Auth.RoleRevokePermission:input_type -> etcdserverpb.AuthRoleRevokePermissionRequest
+	12,  // 137: etcdserverpb.KV.Range:output_type -> etcdserverpb.RangeResponse
+	115, // 138: etcdserverpb.KV.RangeStream:output_type -> etcdserverpb.RangeStreamResponse
+	14,  // 139: etcdserverpb.KV.Put:output_type -> etcdserverpb.PutResponse
+	16,  // 140: etcdserverpb.KV.DeleteRange:output_type -> etcdserverpb.DeleteRangeResponse
+	21,  // 141: etcdserverpb.KV.Txn:output_type -> etcdserverpb.TxnResponse
+	23,  // 142: etcdserverpb.KV.Compact:output_type -> etcdserverpb.CompactionResponse
+	36,  // 143: etcdserverpb.Watch.Watch:output_type -> etcdserverpb.WatchResponse
+	38,  // 144: etcdserverpb.Lease.LeaseGrant:output_type -> etcdserverpb.LeaseGrantResponse
+	40,  // 145: etcdserverpb.Lease.LeaseRevoke:output_type -> etcdserverpb.LeaseRevokeResponse
+	45,  // 146: etcdserverpb.Lease.LeaseKeepAlive:output_type -> etcdserverpb.LeaseKeepAliveResponse
+	47,  // 147: etcdserverpb.Lease.LeaseTransfer:output_type -> etcdserverpb.LeaseTransferResponse
+	49,  // 148: etcdserverpb.Lease.LeaseTimeToLive:output_type -> etcdserverpb.LeaseTimeToLiveResponse
+	52,  // 149: etcdserverpb.Lease.LeaseLeases:output_type -> etcdserverpb.LeaseLeasesResponse
+	55,  // 150: etcdserverpb.Lease.LeaseWatch:output_type -> etcdserverpb.LeaseWatchResponse
+	58,  // 151: etcdserverpb.Cluster.MemberAdd:output_type -> etcdserverpb.MemberAddResponse
+	60,  // 152: etcdserverpb.Cluster.MemberRemove:output_type -> etcdserverpb.MemberRemoveResponse
+	62,  // 153: etcdserverpb.Cluster.MemberUpdate:output_type -> etcdserverpb.MemberUpdateResponse
+	64,  // 154: etcdserverpb.Cluster.MemberList:output_type -> etcdserverpb.MemberListResponse
+	66,  // 155: etcdserverpb.Cluster.MemberPromote:output_type -> etcdserverpb.MemberPromoteResponse
+	73,  // 156: etcdserverpb.Maintenance.Alarm:output_type -> etcdserverpb.AlarmResponse
+	78,  // 157: etcdserverpb.Maintenance.Status:output_type -> etcdserverpb.StatusResponse
+	68,  // 158: etcdserverpb.Maintenance.Defragment:output_type -> etcdserverpb.DefragmentResponse
+	27,  // 159: etcdserverpb.Maintenance.Hash:output_type -> etcdserverpb.HashResponse
+	26,  // 160: etcdserverpb.Maintenance.HashKV:output_type -> etcdserverpb.HashKVResponse
+	29,  // 161: etcdserverpb.Maintenance.Snapshot:output_type -> etcdserverpb.SnapshotResponse
+	70,  // 162: etcdserverpb.Maintenance.MoveLeader:output_type -> etcdserverpb.MoveLeaderResponse
+	75,  // 163: etcdserverpb.Maintenance.Downgrade:output_type -> etcdserverpb.DowngradeResponse
+	98,  // 164: etcdserverpb.Auth.AuthEnable:output_type -> etcdserverpb.AuthEnableResponse
+	99,  // 165: etcdserverpb.Auth.AuthDisable:output_type -> etcdserverpb.AuthDisableResponse
+	100, // 166: etcdserverpb.Auth.AuthStatus:output_type -> etcdserverpb.AuthStatusResponse
+	101, // 167: etcdserverpb.Auth.Authenticate:output_type -> etcdserverpb.AuthenticateResponse
+	102, // 168: etcdserverpb.Auth.UserAdd:output_type -> etcdserverpb.AuthUserAddResponse
+	103, // 169: etcdserverpb.Auth.UserGet:output_type -> etcdserverpb.AuthUserGetResponse
+	111, // 170: etcdserverpb.Auth.UserList:output_type -> etcdserverpb.AuthUserListResponse
+	104, // 171: etcdserverpb.Auth.UserDelete:output_type -> etcdserverpb.AuthUserDeleteResponse
+	105, // 172: etcdserverpb.Auth.UserChangePassword:output_type -> etcdserverpb.AuthUserChangePasswordResponse
+	106, // 173: etcdserverpb.Auth.UserGrantRole:output_type -> etcdserverpb.AuthUserGrantRoleResponse
+	107, // 174: etcdserverpb.Auth.UserRevokeRole:output_type -> etcdserverpb.AuthUserRevokeRoleResponse
+	108, // 175: etcdserverpb.Auth.RoleAdd:output_type -> etcdserverpb.AuthRoleAddResponse
+	109, // 176: etcdserverpb.Auth.RoleGet:output_type -> etcdserverpb.AuthRoleGetResponse
+	110, // 177: etcdserverpb.Auth.RoleList:output_type -> etcdserverpb.AuthRoleListResponse
+	112, // 178: etcdserverpb.Auth.RoleDelete:output_type -> etcdserverpb.AuthRoleDeleteResponse
+	113, // 179: etcdserverpb.Auth.RoleGrantPermission:output_type -> etcdserverpb.AuthRoleGrantPermissionResponse
+	114, // 180: etcdserverpb.Auth.RoleRevokePermission:output_type -> etcdserverpb.AuthRoleRevokePermissionResponse
+	137, // [137:181] is the sub-list for method output_type
+	93,  // [93:137] is the sub-list for method input_type
+	93,  // [93:93] is the sub-list for extension type_name
+	93,  // [93:93] is the sub-list for extension extendee
+	0,   // [0:93] is the sub-list for field type_name
 }
 
 func init() { file_rpc_proto_init() }
@@ -6930,14 +8034,16 @@ func file_rpc_proto_init() {
 		(*WatchRequest_CreateRequest)(nil),
 		(*WatchRequest_CancelRequest)(nil),
 		(*WatchRequest_ProgressRequest)(nil),
+		(*WatchRequest_AckRequest)(nil),
+		(*WatchRequest_CancelRangeRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_proto_rawDesc), len(file_rpc_proto_rawDesc)),
-			NumEnums:      8,
-			NumMessages:   98,
+			NumEnums:      10,
+			NumMessages:   106,
 			NumExtensions: 0,
 			NumServices:   6,
 		},
@@ -463,8 +463,10 @@ const (
 	Lease_LeaseGrant_FullMethodName      = "/etcdserverpb.Lease/LeaseGrant"
 	Lease_LeaseRevoke_FullMethodName     = "/etcdserverpb.Lease/LeaseRevoke"
 	Lease_LeaseKeepAlive_FullMethodName  = "/etcdserverpb.Lease/LeaseKeepAlive"
+	Lease_LeaseTransfer_FullMethodName   = "/etcdserverpb.Lease/LeaseTransfer"
 	Lease_LeaseTimeToLive_FullMethodName = "/etcdserverpb.Lease/LeaseTimeToLive"
 	Lease_LeaseLeases_FullMethodName     = "/etcdserverpb.Lease/LeaseLeases"
+	Lease_LeaseWatch_FullMethodName      = "/etcdserverpb.Lease/LeaseWatch"
 )
 
 // LeaseClient is the client API for Lease service.
@@ -480,10 +482,16 @@ type LeaseClient interface {
 	// LeaseKeepAlive keeps the lease alive by streaming keep alive requests from the client
 	// to the server and streaming keep alive responses from the server to the client.
 	LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse], error)
+	// LeaseTransfer transfers the ownership of a lease to the caller. It resets the TTL of the lease
+	// and increments its epoch, so that keep alives sent with the previous epoch are rejected.
+	LeaseTransfer(ctx context.Context, in *LeaseTransferRequest, opts ...grpc.CallOption) (*LeaseTransferResponse, error)
 	// LeaseTimeToLive retrieves lease information.
 	LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error)
 	// LeaseLeases lists all existing leases.
 	LeaseLeases(ctx context.Context, in *LeaseLeasesRequest, opts ...grpc.CallOption) (*LeaseLeasesResponse, error)
+	// LeaseWatch streams the lifecycle events of leases, as they are granted, revoked or expire,
+	// from the time the watch is created. Past events are not replayed.
+	LeaseWatch(ctx context.Context, in *LeaseWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaseWatchResponse], error)
 }
 
 type leaseClient struct {
@@ -527,6 +535,16 @@ func (c *leaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOptio
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Lease_LeaseKeepAliveClient = grpc.BidiStreamingClient[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
 
+func (c *leaseClient) LeaseTransfer(ctx context.Context, in *LeaseTransferRequest, opts ...grpc.CallOption) (*LeaseTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaseTransferResponse)
+	err := c.cc.Invoke(ctx, Lease_LeaseTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *leaseClient) LeaseTimeToLive(ctx context.Context, in *LeaseTimeToLiveRequest, opts ...grpc.CallOption) (*LeaseTimeToLiveResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LeaseTimeToLiveResponse)
@@ -547,6 +565,25 @@ func (c *leaseClient) LeaseLeases(ctx context.Context, in *LeaseLeasesRequest, o
 	return out, nil
 }
 
+func (c *leaseClient) LeaseWatch(ctx context.Context, in *LeaseWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LeaseWatchResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Lease_ServiceDesc.Streams[1], Lease_LeaseWatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LeaseWatchRequest, LeaseWatchResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lease_LeaseWatchClient = grpc.ServerStreamingClient[LeaseWatchResponse]
+
 // LeaseServer is the server API for Lease service.
 // All implementations must embed UnimplementedLeaseServer
 // for forward compatibility.
@@ -560,10 +597,16 @@ type LeaseServer interface {
 	// LeaseKeepAlive keeps the lease alive by streaming keep alive requests from the client
 	// to the server and streaming keep alive responses from the server to the client.
 	LeaseKeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error
+	// LeaseTransfer transfers the ownership of a lease to the caller. It resets the TTL of the lease
+	// and increments its epoch, so that keep alives sent with the previous epoch are rejected.
+	LeaseTransfer(context.Context, *LeaseTransferRequest) (*LeaseTransferResponse, error)
 	// LeaseTimeToLive retrieves lease information.
 	LeaseTimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error)
 	// LeaseLeases lists all existing leases.
 	LeaseLeases(context.Context, *LeaseLeasesRequest) (*LeaseLeasesResponse, error)
+	// LeaseWatch streams the lifecycle events of leases, as they are granted, revoked or expire,
+	// from the time the watch is created. Past events are not replayed.
+	LeaseWatch(*LeaseWatchRequest, grpc.ServerStreamingServer[LeaseWatchResponse]) error
 	mustEmbedUnimplementedLeaseServer()
 }
 
@@ -583,12 +626,18 @@ func (UnimplementedLeaseServer) LeaseRevoke(context.Context, *LeaseRevokeRequest
 func (UnimplementedLeaseServer) LeaseKeepAlive(grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]) error {
 	return status.Error(codes.Unimplemented, "method LeaseKeepAlive not implemented")
 }
+func (UnimplementedLeaseServer) LeaseTransfer(context.Context, *LeaseTransferRequest) (*LeaseTransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseTransfer not implemented")
+}
 func (UnimplementedLeaseServer) LeaseTimeToLive(context.Context, *LeaseTimeToLiveRequest) (*LeaseTimeToLiveResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method LeaseTimeToLive not implemented")
 }
 func (UnimplementedLeaseServer) LeaseLeases(context.Context, *LeaseLeasesRequest) (*LeaseLeasesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method LeaseLeases not implemented")
 }
+func (UnimplementedLeaseServer) LeaseWatch(*LeaseWatchRequest, grpc.ServerStreamingServer[LeaseWatchResponse]) error {
+	return status.Error(codes.Unimplemented, "method LeaseWatch not implemented")
+}
 func (UnimplementedLeaseServer) mustEmbedUnimplementedLeaseServer() {}
 func (UnimplementedLeaseServer) testEmbeddedByValue()               {}
 
@@ -653,6 +702,24 @@ func _Lease_LeaseKeepAlive_Handler(srv interface{}, stream grpc.ServerStream) er
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Lease_LeaseKeepAliveServer = grpc.BidiStreamingServer[LeaseKeepAliveRequest, LeaseKeepAliveResponse]
 
+func _Lease_LeaseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaseServer).LeaseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Lease_LeaseTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaseServer).LeaseTransfer(ctx, req.(*LeaseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Lease_LeaseTimeToLive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LeaseTimeToLiveRequest)
 	if err := dec(in); err != nil {
@@ -689,6 +756,17 @@ func _Lease_LeaseLeases_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lease_LeaseWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LeaseWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaseServer).LeaseWatch(m, &grpc.GenericServerStream[LeaseWatchRequest, LeaseWatchResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Lease_LeaseWatchServer = grpc.ServerStreamingServer[LeaseWatchResponse]
+
 // Lease_ServiceDesc is the grpc.ServiceDesc for Lease service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -704,6 +782,10 @@ var Lease_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LeaseRevoke",
 			Handler:    _Lease_LeaseRevoke_Handler,
 		},
+		{
+			MethodName: "LeaseTransfer",
+			Handler:    _Lease_LeaseTransfer_Handler,
+		},
 		{
 			MethodName: "LeaseTimeToLive",
 			Handler:    _Lease_LeaseTimeToLive_Handler,
@@ -720,6 +802,11 @@ var Lease_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "LeaseWatch",
+			Handler:       _Lease_LeaseWatch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
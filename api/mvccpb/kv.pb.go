@@ -27,6 +27,9 @@ type Event_EventType int32
 const (
 	Event_PUT    Event_EventType = 0
 	Event_DELETE Event_EventType = 1
+	// DELETE_RANGE summarizes the keys deleted by a single delete range
+	// operation, for the watchers that collapse deletes.
+	Event_DELETE_RANGE Event_EventType = 2
 )
 
 // Enum value maps for Event_EventType.
@@ -34,10 +37,12 @@ var (
 	Event_EventType_name = map[int32]string{
 		0: "PUT",
 		1: "DELETE",
+		2: "DELETE_RANGE",
 	}
 	Event_EventType_value = map[string]int32{
-		"PUT":    0,
-		"DELETE": 1,
+		"PUT":          0,
+		"DELETE":       1,
+		"DELETE_RANGE": 2,
 	}
 )
 
@@ -166,7 +171,8 @@ type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// type is the kind of event. If type is a PUT, it indicates
 	// new data has been stored to the key. If type is a DELETE,
-	// it indicates the key was deleted.
+	// it indicates the key was deleted. If type is a DELETE_RANGE,
+	// it indicates the keys in [kv.key, range_end) were deleted.
 	Type Event_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=mvccpb.Event_EventType" json:"type,omitempty"`
 	// kv holds the KeyValue for the event.
 	// A PUT event contains current kv pair.
@@ -175,7 +181,14 @@ type Event struct {
 	// its modification revision set to the revision of deletion.
 	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
 	// prev_kv holds the key-value pair before the event happens.
-	PrevKv        *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// range_end is the end of the range of a DELETE_RANGE event, whose
+	// kv only holds the start of the range and the revision of deletion.
+	// A range_end of '\0' means all the keys from kv.key on.
+	RangeEnd []byte `protobuf:"bytes,4,opt,name=range_end,json=rangeEnd,proto3" json:"range_end,omitempty"`
+	// deleted is the number of keys deleted in the range of a DELETE_RANGE
+	// event.
+	Deleted       int64 `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -231,6 +244,20 @@ func (x *Event) GetPrevKv() *KeyValue {
 	return nil
 }
 
+func (x *Event) GetRangeEnd() []byte {
+	if x != nil {
+		return x.RangeEnd
+	}
+	return nil
+}
+
+func (x *Event) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
 var File_kv_proto protoreflect.FileDescriptor
 
 const file_kv_proto_rawDesc = "" +
@@ -242,15 +269,18 @@ const file_kv_proto_rawDesc = "" +
 	"\fmod_revision\x18\x03 \x01(\x03R\vmodRevision\x12\x18\n" +
 	"\aversion\x18\x04 \x01(\x03R\aversion\x12\x14\n" +
 	"\x05value\x18\x05 \x01(\fR\x05value\x12\x14\n" +
-	"\x05lease\x18\x06 \x01(\x03R\x05lease\"\xa3\x01\n" +
+	"\x05lease\x18\x06 \x01(\x03R\x05lease\"\xec\x01\n" +
 	"\x05Event\x12+\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x17.mvccpb.Event.EventTypeR\x04type\x12 \n" +
 	"\x02kv\x18\x02 \x01(\v2\x10.mvccpb.KeyValueR\x02kv\x12)\n" +
-	"\aprev_kv\x18\x03 \x01(\v2\x10.mvccpb.KeyValueR\x06prevKv\" \n" +
+	"\aprev_kv\x18\x03 \x01(\v2\x10.mvccpb.KeyValueR\x06prevKv\x12\x1b\n" +
+	"\trange_end\x18\x04 \x01(\fR\brangeEnd\x12\x18\n" +
+	"\adeleted\x18\x05 \x01(\x03R\adeleted\"2\n" +
 	"\tEventType\x12\a\n" +
 	"\x03PUT\x10\x00\x12\n" +
 	"\n" +
-	"\x06DELETE\x10\x01B\x1fZ\x1dgo.etcd.io/etcd/api/v3/mvccpbb\x06proto3"
+	"\x06DELETE\x10\x01\x12\x10\n" +
+	"\fDELETE_RANGE\x10\x02B\x1fZ\x1dgo.etcd.io/etcd/api/v3/mvccpbb\x06proto3"
 
 var (
 	file_kv_proto_rawDescOnce sync.Once
@@ -29,6 +29,7 @@ var (
 	ErrGRPCDuplicateKey            = status.Error(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
 	ErrGRPCInvalidClientAPIVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid client api version")
 	ErrGRPCInvalidSortOption       = status.Error(codes.InvalidArgument, "etcdserver: invalid sort option")
+	ErrGRPCInvalidRangeCursor      = status.Error(codes.InvalidArgument, "etcdserver: invalid range cursor")
 	ErrGRPCCompacted               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision has been compacted")
 	ErrGRPCFutureRev               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace                 = status.Error(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
@@ -37,7 +38,11 @@ var (
 	ErrGRPCLeaseExist       = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
 	ErrGRPCLeaseTTLTooLarge = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
 
-	ErrGRPCWatchCanceled = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCLeaseTransferNotSupported = status.Error(codes.FailedPrecondition, "etcdserver: lease transfer requires cluster version 3.8 or later")
+	ErrGRPCLeaseWatchCanceled        = status.Error(codes.Canceled, "etcdserver: lease watch canceled as the watcher fell behind")
+
+	ErrGRPCWatchCanceled            = status.Error(codes.Canceled, "etcdserver: watch canceled")
+	ErrGRPCWatchStartRevisionTooOld = status.Error(codes.FailedPrecondition, "etcdserver: watch start revision is too old, get the range and watch from its revision instead")
 
 	ErrGRPCMemberExist            = status.Error(codes.FailedPrecondition, "etcdserver: member ID already exist")
 	ErrGRPCPeerURLExist           = status.Error(codes.FailedPrecondition, "etcdserver: Peer URLs already exists")
@@ -79,6 +84,7 @@ var (
 	ErrGRPCLeaderChanged              = status.Error(codes.Unavailable, "etcdserver: leader changed")
 	ErrGRPCNotCapable                 = status.Error(codes.FailedPrecondition, "etcdserver: not capable")
 	ErrGRPCStopped                    = status.Error(codes.Unavailable, "etcdserver: server stopped")
+	ErrGRPCServerClosing              = status.Error(codes.Unavailable, "etcdserver: server closing")
 	ErrGRPCTimeout                    = status.Error(codes.Unavailable, "etcdserver: request timed out")
 	ErrGRPCTimeoutDueToLeaderFail     = status.Error(codes.Unavailable, "etcdserver: request timed out, possibly due to previous leader failure")
 	ErrGRPCTimeoutDueToConnectionLost = status.Error(codes.Unavailable, "etcdserver: request timed out, possibly due to connection lost")
@@ -110,10 +116,17 @@ var (
 		ErrorDesc(ErrGRPCFutureRev):         ErrGRPCFutureRev,
 		ErrorDesc(ErrGRPCNoSpace):           ErrGRPCNoSpace,
 
+		ErrorDesc(ErrGRPCInvalidRangeCursor): ErrGRPCInvalidRangeCursor,
+
 		ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
 		ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
 		ErrorDesc(ErrGRPCLeaseTTLTooLarge): ErrGRPCLeaseTTLTooLarge,
 
+		ErrorDesc(ErrGRPCLeaseTransferNotSupported): ErrGRPCLeaseTransferNotSupported,
+		ErrorDesc(ErrGRPCLeaseWatchCanceled):        ErrGRPCLeaseWatchCanceled,
+
+		ErrorDesc(ErrGRPCWatchStartRevisionTooOld): ErrGRPCWatchStartRevisionTooOld,
+
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
 		ErrorDesc(ErrGRPCMemberNotEnoughStarted): ErrGRPCMemberNotEnoughStarted,
@@ -149,6 +162,7 @@ var (
 		ErrorDesc(ErrGRPCLeaderChanged):              ErrGRPCLeaderChanged,
 		ErrorDesc(ErrGRPCNotCapable):                 ErrGRPCNotCapable,
 		ErrorDesc(ErrGRPCStopped):                    ErrGRPCStopped,
+		ErrorDesc(ErrGRPCServerClosing):              ErrGRPCServerClosing,
 		ErrorDesc(ErrGRPCTimeout):                    ErrGRPCTimeout,
 		ErrorDesc(ErrGRPCTimeoutDueToLeaderFail):     ErrGRPCTimeoutDueToLeaderFail,
 		ErrorDesc(ErrGRPCTimeoutDueToConnectionLost): ErrGRPCTimeoutDueToConnectionLost,
@@ -178,10 +192,17 @@ var (
 	ErrFutureRev         = Error(ErrGRPCFutureRev)
 	ErrNoSpace           = Error(ErrGRPCNoSpace)
 
+	ErrInvalidRangeCursor = Error(ErrGRPCInvalidRangeCursor)
+
 	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
 	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
 	ErrLeaseTTLTooLarge = Error(ErrGRPCLeaseTTLTooLarge)
 
+	ErrLeaseTransferNotSupported = Error(ErrGRPCLeaseTransferNotSupported)
+	ErrLeaseWatchCanceled        = Error(ErrGRPCLeaseWatchCanceled)
+
+	ErrWatchStartRevisionTooOld = Error(ErrGRPCWatchStartRevisionTooOld)
+
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
 	ErrMemberNotEnoughStarted = Error(ErrGRPCMemberNotEnoughStarted)
@@ -221,6 +242,7 @@ var (
 	ErrLeaderChanged              = Error(ErrGRPCLeaderChanged)
 	ErrNotCapable                 = Error(ErrGRPCNotCapable)
 	ErrStopped                    = Error(ErrGRPCStopped)
+	ErrServerClosing              = Error(ErrGRPCServerClosing)
 	ErrTimeout                    = Error(ErrGRPCTimeout)
 	ErrTimeoutDueToLeaderFail     = Error(ErrGRPCTimeoutDueToLeaderFail)
 	ErrTimeoutDueToConnectionLost = Error(ErrGRPCTimeoutDueToConnectionLost)
@@ -19,4 +19,8 @@ var (
 	MetadataHasLeader        = "true"
 
 	MetadataClientAPIVersionKey = "client-api-version"
+
+	// MetadataTargetMemberKey names the member, by name or hexadecimal ID,
+	// that a gRPC proxy forwards maintenance Status and HashKV requests to.
+	MetadataTargetMemberKey = "target-member"
 )
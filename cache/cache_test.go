@@ -548,6 +548,8 @@ func (m *mockWatcher) Watch(ctx context.Context, _ string, opts ...clientv3.OpOp
 
 func (m *mockWatcher) RequestProgress(_ context.Context) error { return m.progressErr }
 
+func (m *mockWatcher) CancelAll(_ context.Context) error { return nil }
+
 func (m *mockWatcher) Close() error {
 	m.closeOnce.Do(func() { close(m.responses) })
 	m.wg.Wait()
@@ -652,6 +654,10 @@ func (s *kvStub) Put(ctx context.Context, key, val string, _ ...clientv3.OpOptio
 	return nil, nil
 }
 
+func (s *kvStub) PutWithConfirm(ctx context.Context, key, val string, _ ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	return nil, nil
+}
+
 func (s *kvStub) Delete(ctx context.Context, key string, _ ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
 	return nil, nil
 }
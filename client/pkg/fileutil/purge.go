@@ -57,41 +57,14 @@ func purgeFile(lg *zap.Logger, dirname string, suffix string, max uint, interval
 			defer close(donec)
 		}
 		for {
-			fnamesWithSuffix, err := readDirWithSuffix(dirname, suffix)
+			purged, err := purgeFileOnce(lg, dirname, suffix, max, flock)
 			if err != nil {
 				errC <- err
 				return
 			}
-			nPurged := 0
-			for nPurged < len(fnamesWithSuffix)-int(max) {
-				f := filepath.Join(dirname, fnamesWithSuffix[nPurged])
-				var l *LockedFile
-				if flock {
-					l, err = TryLockFile(f, os.O_WRONLY, PrivateFileMode)
-					if err != nil {
-						lg.Warn("failed to lock file", zap.String("path", f), zap.Error(err))
-						break
-					}
-				}
-				if err = os.Remove(f); err != nil {
-					lg.Error("failed to remove file", zap.String("path", f), zap.Error(err))
-					errC <- err
-					return
-				}
-				if flock {
-					if err = l.Close(); err != nil {
-						lg.Error("failed to unlock/close", zap.String("path", l.Name()), zap.Error(err))
-						errC <- err
-						return
-					}
-				}
-				lg.Info("purged", zap.String("path", f))
-				nPurged++
-			}
-
 			if purgec != nil {
-				for i := 0; i < nPurged; i++ {
-					purgec <- fnamesWithSuffix[i]
+				for _, fname := range purged {
+					purgec <- fname
 				}
 			}
 			select {
@@ -104,6 +77,49 @@ func purgeFile(lg *zap.Logger, dirname string, suffix string, max uint, interval
 	return errC
 }
 
+// PurgeFileOnce removes the oldest files with the given suffix in dirname
+// until at most max of them remain, and returns the names of the removed
+// files. If flock is true, files locked by another process are kept, and so
+// are all the files that follow them.
+func PurgeFileOnce(lg *zap.Logger, dirname string, suffix string, max uint, flock bool) ([]string, error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	return purgeFileOnce(lg, dirname, suffix, max, flock)
+}
+
+func purgeFileOnce(lg *zap.Logger, dirname string, suffix string, max uint, flock bool) ([]string, error) {
+	fnamesWithSuffix, err := readDirWithSuffix(dirname, suffix)
+	if err != nil {
+		return nil, err
+	}
+	nPurged := 0
+	for nPurged < len(fnamesWithSuffix)-int(max) {
+		f := filepath.Join(dirname, fnamesWithSuffix[nPurged])
+		var l *LockedFile
+		if flock {
+			l, err = TryLockFile(f, os.O_WRONLY, PrivateFileMode)
+			if err != nil {
+				lg.Warn("failed to lock file", zap.String("path", f), zap.Error(err))
+				break
+			}
+		}
+		if err = os.Remove(f); err != nil {
+			lg.Error("failed to remove file", zap.String("path", f), zap.Error(err))
+			return nil, err
+		}
+		if flock {
+			if err = l.Close(); err != nil {
+				lg.Error("failed to unlock/close", zap.String("path", l.Name()), zap.Error(err))
+				return nil, err
+			}
+		}
+		lg.Info("purged", zap.String("path", f))
+		nPurged++
+	}
+	return fnamesWithSuffix[:nPurged], nil
+}
+
 func readDirWithSuffix(dirname string, suffix string) ([]string, error) {
 	fnames, err := ReadDir(dirname)
 	if err != nil {
@@ -155,3 +155,27 @@ func TestPurgeFileHoldingLockFile(t *testing.T) {
 
 	close(stop)
 }
+
+func TestPurgeFileOnce(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 5; i++ {
+		f, ferr := os.Create(filepath.Join(dir, fmt.Sprintf("%d.test", i)))
+		require.NoError(t, ferr)
+		f.Close()
+	}
+	f, ferr := os.Create(filepath.Join(dir, "0.other"))
+	require.NoError(t, ferr)
+	f.Close()
+
+	purged, err := PurgeFileOnce(zaptest.NewLogger(t), dir, "test", 2, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.test", "1.test", "2.test"}, purged)
+
+	fnames, err := ReadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0.other", "3.test", "4.test"}, fnames)
+
+	purged, err = PurgeFileOnce(zaptest.NewLogger(t), dir, "test", 2, true)
+	require.NoError(t, err)
+	require.Empty(t, purged)
+}
@@ -0,0 +1,155 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// defaultMaxOpsPerTxn matches the default --max-txn-ops of the server.
+	defaultMaxOpsPerTxn = 128
+	// defaultBatchConcurrency is the number of batches written at once.
+	defaultBatchConcurrency = 4
+)
+
+// KeyValue is a key-value pair written by BatchPut.
+type KeyValue struct {
+	Key   string
+	Value string
+	// Lease, if set, attaches the key to the lease.
+	Lease LeaseID
+}
+
+type batchOptions struct {
+	maxOpsPerTxn    int
+	concurrency     int
+	continueOnError bool
+}
+
+// BatchOption configures BatchPut.
+type BatchOption func(*batchOptions)
+
+// WithMaxOpsPerTxn sets the number of puts of each transaction, which must
+// not exceed the --max-txn-ops of the server. It defaults to 128.
+func WithMaxOpsPerTxn(n int) BatchOption {
+	return func(o *batchOptions) { o.maxOpsPerTxn = n }
+}
+
+// WithBatchConcurrency sets the number of transactions in flight at once.
+// It defaults to 4.
+func WithBatchConcurrency(n int) BatchOption {
+	return func(o *batchOptions) { o.concurrency = n }
+}
+
+// WithContinueOnError makes BatchPut write all the batches even if some of
+// them fail, instead of stopping at the first failure.
+func WithContinueOnError() BatchOption {
+	return func(o *batchOptions) { o.continueOnError = true }
+}
+
+// BatchPutResponse holds the outcome of each batch of a BatchPut.
+type BatchPutResponse struct {
+	// Revisions holds the revision each batch was written at, or 0 if the
+	// batch failed or was not written.
+	Revisions []int64
+}
+
+// BatchError is the error of the first failed batch of a BatchPut.
+type BatchError struct {
+	// Batch is the index of the failed batch. Its key-value pairs start at
+	// Batch times the number of puts per transaction.
+	Batch int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("etcdclient: batch %d failed: %v", e.Batch, e.Err)
+}
+
+func (e *BatchError) Unwrap() error { return e.Err }
+
+// BatchPut writes the key-value pairs in transactions of a bounded number of
+// puts, several transactions at a time. Each batch is atomic, but the whole
+// write is not: the batches are written at different revisions, in any
+// order. By default, no more batches are started once one fails, and the
+// returned *BatchError holds the lowest index of the failed batches, so the
+// caller can resume from it. The revisions of the response tell which of the
+// other batches were written.
+func BatchPut(ctx context.Context, kv KV, kvs []KeyValue, opts ...BatchOption) (*BatchPutResponse, error) {
+	o := batchOptions{maxOpsPerTxn: defaultMaxOpsPerTxn, concurrency: defaultBatchConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.maxOpsPerTxn <= 0 {
+		o.maxOpsPerTxn = defaultMaxOpsPerTxn
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = 1
+	}
+
+	batches := (len(kvs) + o.maxOpsPerTxn - 1) / o.maxOpsPerTxn
+	resp := &BatchPutResponse{Revisions: make([]int64, batches)}
+	errs := make([]error, batches)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+	sem := make(chan struct{}, o.concurrency)
+	for i := 0; i < batches; i++ {
+		sem <- struct{}{}
+		mu.Lock()
+		stop := failed && !o.continueOnError
+		mu.Unlock()
+		if stop {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			batch := kvs[i*o.maxOpsPerTxn : min((i+1)*o.maxOpsPerTxn, len(kvs))]
+			ops := make([]Op, len(batch))
+			for j, p := range batch {
+				ops[j] = OpPut(p.Key, p.Value, WithLease(p.Lease))
+			}
+			tresp, err := kv.Txn(ctx).Then(ops...).Commit()
+			if err != nil {
+				errs[i] = err
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+				return
+			}
+			resp.Revisions[i] = tresp.Header.Revision
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return resp, &BatchError{Batch: i, Err: err}
+		}
+	}
+	return resp, nil
+}
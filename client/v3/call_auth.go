@@ -0,0 +1,172 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+type callAuthKey struct{}
+
+// callAuth holds the credentials of the requests made with a context returned
+// by WithAuthToken or WithUser.
+type callAuth struct {
+	token    string
+	user     string
+	password string
+}
+
+// streamKey identifies the credentials in the key of a watch stream, so that
+// watchers with different credentials do not share a stream.
+func (a *callAuth) streamKey() string {
+	if a.user != "" {
+		return "user:" + a.user
+	}
+	return "token:" + a.token
+}
+
+func callAuthFromContext(ctx context.Context) *callAuth {
+	a, _ := ctx.Value(callAuthKey{}).(*callAuth)
+	return a
+}
+
+// callTokens caches the tokens of the users of WithUser.
+type callTokens struct {
+	mu     sync.Mutex
+	tokens map[string]callToken
+}
+
+type callToken struct {
+	password string
+	token    string
+}
+
+func (t *callTokens) get(user, password string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ct, ok := t.tokens[user]
+	if !ok || ct.password != password {
+		return "", false
+	}
+	return ct.token, true
+}
+
+func (t *callTokens) set(user, password, token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tokens == nil {
+		t.tokens = make(map[string]callToken)
+	}
+	t.tokens[user] = callToken{password: password, token: token}
+}
+
+// callToken returns the token of the credentials of a request. The token of a
+// user is authenticated unless it is cached, or if refresh is set. It returns
+// an empty token if auth is not enabled.
+func (c *Client) callToken(ctx context.Context, a *callAuth, refresh bool) (string, error) {
+	if a.user == "" {
+		return a.token, nil
+	}
+	if !refresh {
+		if token, ok := c.callTokens.get(a.user, a.password); ok {
+			return token, nil
+		}
+	}
+	// the authentication request itself is made without the credentials
+	resp, err := c.Auth.Authenticate(context.WithValue(ctx, callAuthKey{}, (*callAuth)(nil)), a.user, a.password)
+	if err != nil {
+		if errors.Is(err, rpctypes.ErrAuthNotEnabled) {
+			return "", nil
+		}
+		return "", err
+	}
+	c.callTokens.set(a.user, a.password, resp.Token)
+	return resp.Token, nil
+}
+
+// withCallToken returns ctx with the token of the credentials of the request
+// in its outgoing metadata, which overrides the token of the client.
+func (c *Client) withCallToken(ctx context.Context, a *callAuth, refresh bool) (context.Context, error) {
+	token, err := c.callToken(ctx, a, refresh)
+	if err != nil || token == "" {
+		return ctx, err
+	}
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return metadata.NewOutgoingContext(ctx, metadata.Pairs(rpctypes.TokenFieldNameGRPC, token)), nil
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.TokenFieldNameGRPC, token)
+	return metadata.NewOutgoingContext(ctx, copied), nil
+}
+
+// isExpiredTokenError returns whether err is returned for a token that has to
+// be refreshed.
+func isExpiredTokenError(err error) bool {
+	err = rpctypes.Error(err)
+	return errors.Is(err, rpctypes.ErrInvalidAuthToken) || errors.Is(err, rpctypes.ErrAuthOldRevision)
+}
+
+// callAuthUnaryInterceptor returns a unary client interceptor attaching the
+// credentials set with WithAuthToken or WithUser to every attempt of a call.
+// The token of a user is refreshed and the attempt retried once if the server
+// rejects it as expired.
+func (c *Client) callAuthUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		a := callAuthFromContext(ctx)
+		if a == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		actx, err := c.withCallToken(ctx, a, false)
+		if err != nil {
+			return err
+		}
+		err = invoker(actx, method, req, reply, cc, opts...)
+		if a.user == "" || !isExpiredTokenError(err) {
+			return err
+		}
+		c.GetLogger().Debug("refreshing expired auth token of user", zap.String("user", a.user), zap.Error(err))
+		if actx, err = c.withCallToken(ctx, a, true); err != nil {
+			return err
+		}
+		return invoker(actx, method, req, reply, cc, opts...)
+	}
+}
+
+// callAuthStreamInterceptor returns a stream client interceptor attaching the
+// credentials set with WithAuthToken or WithUser to a stream. Like the token
+// of the client, the token of a user is refreshed for every stream, as a
+// stream may outlive it and is only authenticated once it is opened.
+func (c *Client) callAuthStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		a := callAuthFromContext(ctx)
+		if a == nil {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		actx, err := c.withCallToken(ctx, a, true)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(actx, desc, cc, method, opts...)
+	}
+}
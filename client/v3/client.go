@@ -76,9 +76,30 @@ type Client struct {
 	Token string
 
 	authTokenBundle credentials.PerRPCCredentialsBundle
+	// callTokens caches the tokens of the users of WithUser.
+	callTokens callTokens
+
+	// memberSyncFailed is set while the auto sync with the member list fails.
+	memberSyncFailed atomic.Bool
+
+	// endpointHealth takes the suspected endpoints, and with
+	// EnableEndpointHealthCheck the failing ones, out of the rotation.
+	endpointHealth *endpointHealth
+
+	// tokenRefresh re-authenticates before the token expires.
+	tokenRefreshMu sync.Mutex
+	tokenRefresh   *time.Timer
 
 	callOpts []grpc.CallOption
 
+	// rpcGate refuses new RPCs once the client is shutting down.
+	rpcGate rpcGate
+
+	// compression is the codec compressing messages, or "" if disabled.
+	compression string
+	// compressionDisabled is set once a server did not support compression.
+	compressionDisabled atomic.Bool
+
 	lg atomic.Pointer[zap.Logger]
 }
 
@@ -126,6 +147,15 @@ func WithZapLogger(lg *zap.Logger) Option {
 	}
 }
 
+// WithWatchRetryBackoff is a NewCtxClient option that sets the
+// WatchRetryBackoff of the client: its watchers wait from minBackoff up to
+// maxBackoff, randomized by up to jitterFraction, before they reconnect.
+func WithWatchRetryBackoff(minBackoff, maxBackoff time.Duration, jitterFraction float64) Option {
+	return func(c *Client) {
+		c.cfg.WatchRetryBackoff = WatchRetryBackoff{Min: minBackoff, Max: maxBackoff, JitterFraction: jitterFraction}
+	}
+}
+
 // WithLogger overrides the logger.
 //
 // Deprecated: Please use WithZapLogger or Logger field in clientv3.Config
@@ -146,6 +176,7 @@ func (c *Client) GetLogger() *zap.Logger {
 // Close shuts down the client's etcd connections.
 func (c *Client) Close() error {
 	c.cancel()
+	c.stopTokenRefresh()
 	if c.Watcher != nil {
 		c.Watcher.Close()
 	}
@@ -220,6 +251,7 @@ func (c *Client) autoSync() {
 			if err != nil && !errors.Is(err, c.ctx.Err()) {
 				c.GetLogger().Info("Auto sync endpoints failed.", zap.Error(err))
 			}
+			c.memberSyncFailed.Store(err != nil)
 		}
 	}
 }
@@ -249,26 +281,49 @@ func (c *Client) dialSetupOpts(creds grpccredentials.TransportCredentials, dopts
 		unaryMaxRetries = c.cfg.MaxUnaryRetries
 	}
 
-	backoffWaitBetween := defaultBackoffWaitBetween
-	if c.cfg.BackoffWaitBetween > 0 {
-		backoffWaitBetween = c.cfg.BackoffWaitBetween
-	}
-
-	backoffJitterFraction := defaultBackoffJitterFraction
-	if c.cfg.BackoffJitterFraction > 0 {
-		backoffJitterFraction = c.cfg.BackoffJitterFraction
-	}
+	backoffWaitBetween := c.backoffWaitBetween()
+	backoffJitterFraction := c.backoffJitterFraction()
 
 	// Interceptor retry and backoff.
 	// TODO: Replace all of clientv3/retry.go with RetryPolicy:
 	// https://github.com/grpc/grpc-proto/blob/cdd9ed5c3d3f87aef62f373b93361cf7bddc620d/grpc/service_config/service_config.proto#L130
 	rrBackoff := withBackoff(c.roundRobinQuorumBackoff(backoffWaitBetween, backoffJitterFraction))
+	unaryInterceptor := c.unaryClientInterceptor(withMax(unaryMaxRetries), rrBackoff)
+	if c.cfg.TracerProvider != nil {
+		// Trace requests above the retries, once per request.
+		unaryInterceptor = c.tracingUnaryInterceptor(unaryInterceptor)
+	}
+	// Track the in-flight requests for Shutdown, once per request.
+	unaryInterceptor = c.shutdownUnaryInterceptor(unaryInterceptor)
 	opts = append(opts,
 		// Disable stream retry by default since go-grpc-middleware/retry does not support client streams.
 		// Streams that are safe to retry are enabled individually.
 		grpc.WithStreamInterceptor(c.streamClientInterceptor(withMax(0), rrBackoff)),
-		grpc.WithUnaryInterceptor(c.unaryClientInterceptor(withMax(unaryMaxRetries), rrBackoff)),
+		grpc.WithUnaryInterceptor(unaryInterceptor),
+		// Refuse the streams opened once the client is shutting down.
+		grpc.WithChainStreamInterceptor(c.shutdownStreamInterceptor()),
+		// Attach the credentials of the call to every attempt.
+		grpc.WithChainStreamInterceptor(c.callAuthStreamInterceptor()),
+		grpc.WithChainUnaryInterceptor(c.callAuthUnaryInterceptor()),
 	)
+	if c.cfg.EnableEndpointHealthCheck {
+		// Report the outcome of every attempt.
+		opts = append(opts, grpc.WithChainUnaryInterceptor(c.endpointHealthUnaryInterceptor()))
+	}
+	if c.compression != "" {
+		// Compress every attempt, below the retry interceptors.
+		opts = append(opts,
+			grpc.WithChainStreamInterceptor(c.compressionStreamInterceptor()),
+			grpc.WithChainUnaryInterceptor(c.compressionUnaryInterceptor()),
+		)
+	}
+	// The interceptors of the user see every attempt, as sent.
+	if len(c.cfg.StreamInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(c.cfg.StreamInterceptors...))
+	}
+	if len(c.cfg.UnaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(c.cfg.UnaryInterceptors...))
+	}
 
 	return opts
 }
@@ -303,6 +358,7 @@ func (c *Client) getToken(ctx context.Context) error {
 		return err
 	}
 	c.authTokenBundle.UpdateAuthToken(resp.Token)
+	c.scheduleTokenRefresh(resp.Token)
 	return nil
 }
 
@@ -435,7 +491,17 @@ func newClient(cfg *Config) (*Client, error) {
 		client.callOpts = callOpts
 	}
 
+	client.compression, err = compressionCodec(cfg.CompressionCodec)
+	if err != nil {
+		client.cancel()
+		return nil, err
+	}
+
 	client.resolver = resolver.New(cfg.Endpoints...)
+	// The endpoints are only ejected for failing with
+	// EnableEndpointHealthCheck, but can always be suspected.
+	client.endpointHealth = newEndpointHealth(client)
+	client.resolver.SetHealthTracker(client.endpointHealth)
 
 	if len(cfg.Endpoints) < 1 {
 		client.cancel()
@@ -483,9 +549,24 @@ func newClient(cfg *Config) (*Client, error) {
 	}
 
 	go client.autoSync()
+	go client.autoSyncSRV()
 	return client, nil
 }
 
+func (c *Client) backoffWaitBetween() time.Duration {
+	if c.cfg.BackoffWaitBetween > 0 {
+		return c.cfg.BackoffWaitBetween
+	}
+	return defaultBackoffWaitBetween
+}
+
+func (c *Client) backoffJitterFraction() float64 {
+	if c.cfg.BackoffJitterFraction > 0 {
+		return c.cfg.BackoffJitterFraction
+	}
+	return defaultBackoffJitterFraction
+}
+
 // roundRobinQuorumBackoff retries against quorum between each backoff.
 // This is intended for use with a round robin load balancer.
 func (c *Client) roundRobinQuorumBackoff(waitBetween time.Duration, jitterFraction float64) backoffFunc {
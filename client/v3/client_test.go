@@ -486,6 +486,14 @@ func (mm mockMaintenance) Defragment(ctx context.Context, endpoint string) (*Def
 	return nil, nil
 }
 
+func (mm mockMaintenance) DefragmentCluster(ctx context.Context, opts ...DefragmentClusterOption) ([]DefragmentReport, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) StatusAll(ctx context.Context, opts ...StatusAllOption) ([]EndpointStatus, error) {
+	return nil, nil
+}
+
 func (mm mockMaintenance) HashKV(ctx context.Context, endpoint string, rev int64) (*HashKVResponse, error) {
 	return nil, nil
 }
@@ -494,6 +502,10 @@ func (mm mockMaintenance) SnapshotWithVersion(ctx context.Context) (*SnapshotRes
 	return nil, nil
 }
 
+func (mm mockMaintenance) SnapshotWithProgress(ctx context.Context, opts ...SnapshotOption) (*SnapshotResponse, <-chan SnapshotProgress, error) {
+	return nil, nil, nil
+}
+
 func (mm mockMaintenance) Snapshot(ctx context.Context) (io.ReadCloser, error) {
 	return nil, nil
 }
@@ -0,0 +1,47 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clientv3test provides an in-memory etcd keyspace for the unit tests
+// of clientv3 users, without running any server.
+//
+// A Store serves the KV, Watcher and Lease of a clientv3.Client from memory.
+// The requests go through the same clientv3 code as with a real cluster, so
+// that all the options of the client apply, and the store follows the
+// semantics of an etcd server: the revisions, versions and create and mod
+// revisions of the keys, the ranges and prefixes, the compares of the
+// transactions, the replays of the watches from past revisions, the
+// compaction, and the leases and the keys attached to them.
+//
+// First, create a store, optionally with some keys:
+//
+//	s := clientv3test.New(map[string]string{"foo": "bar"})
+//	defer s.Close()
+//
+// Next, use its client where a client is expected:
+//
+//	cli := s.Client()
+//	resp, err := cli.Get(ctx, "foo")
+//
+// The store can fail the requests touching a key, to test the handling of
+// errors:
+//
+//	s.InjectError("foo", rpctypes.ErrGRPCNoLeader)
+//
+// Only the KV, Watcher and Lease of the client are served; the other APIs of
+// the client are not set. The watchers never fall behind, the watch responses
+// are never fragmented, no periodic progress notifications are sent, and the
+// watch options that tune how the server sends the events, such as
+// WithLatestPerKey or WithAckWindow, are rejected. Lease transfers and lease
+// watches are not supported either.
+package clientv3test
@@ -0,0 +1,121 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// kvClient serves the KV requests of a client from the store.
+type kvClient struct{ s *Store }
+
+func (c *kvClient) Range(ctx context.Context, in *pb.RangeRequest, _ ...grpc.CallOption) (*pb.RangeResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.injectedError(in.Key, in.RangeEnd); err != nil {
+		return nil, err
+	}
+	resp, err := s.rangeRequest(in, s.rev)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header = s.header()
+	return resp, nil
+}
+
+// RangeStream sends the whole range in a single response.
+func (c *kvClient) RangeStream(ctx context.Context, in *pb.RangeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.RangeStreamResponse], error) {
+	resp, err := c.Range(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	st := newStream[struct{}, pb.RangeStreamResponse](ctx, nil)
+	st.push(&pb.RangeStreamResponse{RangeResponse: resp})
+	st.close()
+	return st, nil
+}
+
+func (c *kvClient) Put(ctx context.Context, in *pb.PutRequest, _ ...grpc.CallOption) (*pb.PutResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.injectedError(in.Key, nil); err != nil {
+		return nil, err
+	}
+	if err := s.checkPutRequest(in, s.rev); err != nil {
+		return nil, err
+	}
+	w := s.write()
+	resp := w.putRequest(in)
+	w.end()
+	resp.Header = s.header()
+	return resp, nil
+}
+
+func (c *kvClient) DeleteRange(ctx context.Context, in *pb.DeleteRangeRequest, _ ...grpc.CallOption) (*pb.DeleteRangeResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.injectedError(in.Key, in.RangeEnd); err != nil {
+		return nil, err
+	}
+	if len(in.Key) == 0 {
+		return nil, rpctypes.ErrGRPCEmptyKey
+	}
+	w := s.write()
+	resp := w.deleteRangeRequest(in)
+	w.end()
+	resp.Header = s.header()
+	return resp, nil
+}
+
+func (c *kvClient) Txn(ctx context.Context, in *pb.TxnRequest, _ ...grpc.CallOption) (*pb.TxnResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.txnError(in); err != nil {
+		return nil, err
+	}
+	return s.txnRequest(in)
+}
+
+func (c *kvClient) Compact(ctx context.Context, in *pb.CompactionRequest, _ ...grpc.CallOption) (*pb.CompactionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compactRequest(in)
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type lease struct {
+	id     int64
+	ttl    int64
+	expiry time.Time
+	timer  *time.Timer
+	// keys are the keys attached to the lease.
+	keys map[string]struct{}
+}
+
+// ExpireLease expires the lease with the given ID now, deleting its keys,
+// rather than when its TTL elapses without keep alive.
+func (s *Store) ExpireLease(id clientv3.LeaseID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.leases[int64(id)]; !ok {
+		return rpctypes.ErrLeaseNotFound
+	}
+	s.revoke(int64(id))
+	return nil
+}
+
+func (s *Store) grant(id, ttl int64) *lease {
+	l := &lease{id: id, ttl: ttl, keys: make(map[string]struct{})}
+	l.expiry = time.Now().Add(time.Duration(ttl) * time.Second)
+	l.timer = time.AfterFunc(time.Duration(ttl)*time.Second, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// the lease may have been revoked, or even granted again, since
+		if s.leases[id] == l {
+			s.revoke(id)
+		}
+	})
+	s.leases[id] = l
+	return l
+}
+
+// revoke revokes the lease, deleting its keys in a single revision.
+func (s *Store) revoke(id int64) {
+	l := s.leases[id]
+	l.timer.Stop()
+	delete(s.leases, id)
+	w := s.write()
+	for _, k := range slices.Sorted(maps.Keys(l.keys)) {
+		w.deleteRange([]byte(k), nil)
+	}
+	w.end()
+}
+
+func (l *lease) renew() {
+	l.expiry = time.Now().Add(time.Duration(l.ttl) * time.Second)
+	l.timer.Reset(time.Duration(l.ttl) * time.Second)
+}
+
+// leaseClient serves the lease requests of a client from the store.
+type leaseClient struct{ s *Store }
+
+func (c *leaseClient) LeaseGrant(ctx context.Context, in *pb.LeaseGrantRequest, _ ...grpc.CallOption) (*pb.LeaseGrantResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := in.ID
+	switch _, ok := s.leases[id]; {
+	case id == 0:
+		for id = s.lastLeaseID + 1; s.leases[id] != nil; id++ {
+		}
+		s.lastLeaseID = id
+	case ok:
+		return nil, rpctypes.ErrGRPCLeaseExist
+	}
+	l := s.grant(id, max(in.TTL, 1))
+	return &pb.LeaseGrantResponse{Header: s.header(), ID: l.id, TTL: l.ttl}, nil
+}
+
+func (c *leaseClient) LeaseRevoke(ctx context.Context, in *pb.LeaseRevokeRequest, _ ...grpc.CallOption) (*pb.LeaseRevokeResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.leases[in.ID]; !ok {
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
+	s.revoke(in.ID)
+	return &pb.LeaseRevokeResponse{Header: s.header()}, nil
+}
+
+func (c *leaseClient) LeaseKeepAlive(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[pb.LeaseKeepAliveRequest, pb.LeaseKeepAliveResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	var st *stream[pb.LeaseKeepAliveRequest, pb.LeaseKeepAliveResponse]
+	st = newStream[pb.LeaseKeepAliveRequest, pb.LeaseKeepAliveResponse](ctx, func(req *pb.LeaseKeepAliveRequest) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		resp := &pb.LeaseKeepAliveResponse{Header: s.header(), ID: req.ID}
+		// the TTL of a lease not found is 0
+		if l := s.leases[req.ID]; l != nil {
+			l.renew()
+			resp.TTL = l.ttl
+		}
+		st.push(resp)
+	})
+	return st, nil
+}
+
+func (c *leaseClient) LeaseTransfer(context.Context, *pb.LeaseTransferRequest, ...grpc.CallOption) (*pb.LeaseTransferResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "clientv3test: lease transfer is not supported")
+}
+
+func (c *leaseClient) LeaseTimeToLive(ctx context.Context, in *pb.LeaseTimeToLiveRequest, _ ...grpc.CallOption) (*pb.LeaseTimeToLiveResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := s.leases[in.ID]
+	if l == nil {
+		return &pb.LeaseTimeToLiveResponse{Header: s.header(), ID: in.ID, TTL: -1}, nil
+	}
+	resp := &pb.LeaseTimeToLiveResponse{
+		Header:     s.header(),
+		ID:         l.id,
+		TTL:        max(int64(time.Until(l.expiry).Seconds()), 0),
+		GrantedTTL: l.ttl,
+	}
+	if in.Keys {
+		for _, k := range slices.Sorted(maps.Keys(l.keys)) {
+			resp.Keys = append(resp.Keys, []byte(k))
+		}
+	}
+	return resp, nil
+}
+
+func (c *leaseClient) LeaseLeases(ctx context.Context, _ *pb.LeaseLeasesRequest, _ ...grpc.CallOption) (*pb.LeaseLeasesResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &pb.LeaseLeasesResponse{Header: s.header()}
+	for _, id := range slices.Sorted(maps.Keys(s.leases)) {
+		resp.Leases = append(resp.Leases, &pb.LeaseStatus{ID: id})
+	}
+	return resp, nil
+}
+
+func (c *leaseClient) LeaseWatch(context.Context, *pb.LeaseWatchRequest, ...grpc.CallOption) (grpc.ServerStreamingClient[pb.LeaseWatchResponse], error) {
+	return nil, status.Error(codes.Unimplemented, "clientv3test: lease watch is not supported")
+}
@@ -0,0 +1,547 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// ClusterID and MemberID are the IDs in the headers of the responses of
+	// a Store.
+	ClusterID = 0x1
+	MemberID  = 0x1
+
+	// maxTxnOps is the default maximum number of operations of a txn of an
+	// etcd server.
+	maxTxnOps = 128
+)
+
+// Store is an in-memory etcd keyspace serving the KV, Watcher and Lease of a
+// client. It is safe for concurrent use.
+type Store struct {
+	client *clientv3.Client
+
+	mu sync.Mutex
+	// rev is the current revision of the keyspace. It starts at 1, like the
+	// one of a new cluster.
+	rev int64
+	// compactRev is the revision of the last compaction, the oldest
+	// revision that can still be read, or 0 before any compaction.
+	compactRev int64
+	// keys holds the revisions of each key not compacted yet, in order.
+	keys map[string][]keyRev
+	// events holds the events of the revisions not compacted yet, in order,
+	// along with their previous key-values.
+	events []*mvccpb.Event
+	// errs holds the errors injected for keys.
+	errs map[string]error
+
+	leases      map[int64]*lease
+	lastLeaseID int64
+
+	streams map[*watchStream]struct{}
+}
+
+// keyRev is a revision of a key.
+type keyRev struct {
+	rev int64
+	// kv is nil once the key is deleted.
+	kv *mvccpb.KeyValue
+}
+
+// New returns a store holding the keys of seed, put in a single revision.
+func New(seed map[string]string) *Store {
+	s := &Store{
+		rev:     1,
+		keys:    make(map[string][]keyRev),
+		errs:    make(map[string]error),
+		leases:  make(map[int64]*lease),
+		streams: make(map[*watchStream]struct{}),
+	}
+	w := s.write()
+	for _, k := range slices.Sorted(maps.Keys(seed)) {
+		w.put([]byte(k), []byte(seed[k]), 0)
+	}
+	w.end()
+
+	c := clientv3.NewCtxClient(context.Background())
+	c.KV = clientv3.NewKVFromKVClient(&kvClient{s}, c)
+	c.Watcher = clientv3.NewWatchFromWatchClient(&watchClient{s}, c)
+	c.Lease = clientv3.NewLeaseFromLeaseClient(&leaseClient{s}, c, time.Second)
+	s.client = c
+	return s
+}
+
+// Client returns a client whose KV, Watcher and Lease are served by the
+// store. It is closed by Close.
+func (s *Store) Client() *clientv3.Client { return s.client }
+
+// Close closes the client of the store, and stops the expiry of its leases.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	for _, l := range s.leases {
+		l.timer.Stop()
+	}
+	s.mu.Unlock()
+	return s.client.Close()
+}
+
+// Rev returns the current revision of the store.
+func (s *Store) Rev() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rev
+}
+
+// Compact compacts the history of the store before rev, like the Compact of
+// the KV of its client.
+func (s *Store) Compact(rev int64) error {
+	_, err := s.client.Compact(context.Background(), rev)
+	return err
+}
+
+// InjectError has the KV requests touching key fail with err, until it is
+// injected again with a nil error. A request touches a key if the key is in
+// one of its ranges, including the ones of the compares and operations of a
+// txn. Errors of gRPC status, such as rpctypes.ErrGRPCNoLeader, are returned
+// by the client as the errors of a server would be.
+func (s *Store) InjectError(key string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == nil {
+		delete(s.errs, key)
+		return
+	}
+	s.errs[key] = err
+}
+
+func (s *Store) header() *pb.ResponseHeader {
+	return &pb.ResponseHeader{ClusterId: ClusterID, MemberId: MemberID, Revision: s.rev, RaftTerm: 1}
+}
+
+// injectedError returns the error injected for a key of the range.
+func (s *Store) injectedError(key, end []byte) error {
+	for k, err := range s.errs {
+		if inRange([]byte(k), key, end) {
+			return err
+		}
+	}
+	return nil
+}
+
+// get returns the key-value of key at rev, or nil if there is none.
+func (s *Store) get(key []byte, rev int64) *mvccpb.KeyValue {
+	revs := s.keys[string(key)]
+	for i := len(revs) - 1; i >= 0; i-- {
+		if revs[i].rev <= rev {
+			return revs[i].kv
+		}
+	}
+	return nil
+}
+
+// rangeKVs returns the key-values of the range at rev, ordered by key.
+func (s *Store) rangeKVs(key, end []byte, rev int64) []*mvccpb.KeyValue {
+	if len(end) == 0 {
+		if kv := s.get(key, rev); kv != nil {
+			return []*mvccpb.KeyValue{kv}
+		}
+		return nil
+	}
+	var kvs []*mvccpb.KeyValue
+	for _, k := range slices.Sorted(maps.Keys(s.keys)) {
+		if !inRange([]byte(k), key, end) {
+			continue
+		}
+		if kv := s.get([]byte(k), rev); kv != nil {
+			kvs = append(kvs, kv)
+		}
+	}
+	return kvs
+}
+
+// compact drops the history before rev.
+func (s *Store) compact(rev int64) {
+	for k, revs := range s.keys {
+		i := 0
+		for i+1 < len(revs) && revs[i+1].rev <= rev {
+			i++
+		}
+		revs = revs[i:]
+		if revs[0].rev <= rev && revs[0].kv == nil {
+			revs = revs[1:]
+		}
+		if len(revs) == 0 {
+			delete(s.keys, k)
+			continue
+		}
+		s.keys[k] = revs
+	}
+	i := 0
+	for i < len(s.events) && s.events[i].Kv.ModRevision < rev {
+		i++
+	}
+	s.events = slices.Clone(s.events[i:])
+	s.compactRev = rev
+}
+
+// writeTxn applies the writes of a request at the next revision of the
+// store. The reads of the request see its writes.
+type writeTxn struct {
+	s      *Store
+	rev    int64
+	events []*mvccpb.Event
+}
+
+func (s *Store) write() *writeTxn {
+	return &writeTxn{s: s, rev: s.rev + 1}
+}
+
+// readRev returns the revision the reads of the request are served at.
+func (w *writeTxn) readRev() int64 {
+	if len(w.events) != 0 {
+		return w.rev
+	}
+	return w.s.rev
+}
+
+func (w *writeTxn) put(key, val []byte, leaseID int64) {
+	prev := w.s.get(key, w.readRev())
+	kv := &mvccpb.KeyValue{
+		Key:            key,
+		Value:          val,
+		CreateRevision: w.rev,
+		ModRevision:    w.rev,
+		Version:        1,
+		Lease:          leaseID,
+	}
+	if prev != nil {
+		kv.CreateRevision = prev.CreateRevision
+		kv.Version = prev.Version + 1
+		w.s.detach(prev)
+	}
+	if l := w.s.leases[leaseID]; l != nil {
+		l.keys[string(key)] = struct{}{}
+	}
+	w.s.keys[string(key)] = append(w.s.keys[string(key)], keyRev{rev: w.rev, kv: kv})
+	w.events = append(w.events, &mvccpb.Event{Type: mvccpb.Event_PUT, Kv: kv, PrevKv: prev})
+}
+
+func (w *writeTxn) deleteRange(key, end []byte) int64 {
+	var n int64
+	for _, prev := range w.s.rangeKVs(key, end, w.readRev()) {
+		w.s.detach(prev)
+		w.s.keys[string(prev.Key)] = append(w.s.keys[string(prev.Key)], keyRev{rev: w.rev})
+		w.events = append(w.events, &mvccpb.Event{
+			Type:   mvccpb.Event_DELETE,
+			Kv:     &mvccpb.KeyValue{Key: prev.Key, ModRevision: w.rev},
+			PrevKv: prev,
+		})
+		n++
+	}
+	return n
+}
+
+// end moves the store to the revision of the writes, if any, and sends their
+// events to the watchers.
+func (w *writeTxn) end() {
+	if len(w.events) == 0 {
+		return
+	}
+	w.s.rev = w.rev
+	w.s.events = append(w.s.events, w.events...)
+	for ws := range w.s.streams {
+		ws.notify(w.events)
+	}
+}
+
+// detach detaches the key of kv from its lease.
+func (s *Store) detach(kv *mvccpb.KeyValue) {
+	if l := s.leases[kv.Lease]; l != nil {
+		delete(l.keys, string(kv.Key))
+	}
+}
+
+func (s *Store) rangeRequest(r *pb.RangeRequest, rev int64) (*pb.RangeResponse, error) {
+	if err := checkRangeRequest(r); err != nil {
+		return nil, err
+	}
+	key, readRev := r.Key, r.Revision
+	var cur rangeCursor
+	if len(r.Cursor) != 0 {
+		var err error
+		if cur, err = s.resolveRangeCursor(r, rev); err != nil {
+			return nil, err
+		}
+		key, readRev = cur.key, cur.rev
+	}
+	switch {
+	case readRev == 0:
+		readRev = rev
+	case readRev > rev:
+		return nil, rpctypes.ErrGRPCFutureRev
+	case readRev < s.compactRev:
+		return nil, rpctypes.ErrGRPCCompacted
+	}
+
+	kvs := s.rangeKVs(key, r.RangeEnd, readRev)
+	resp := &pb.RangeResponse{
+		Header:                &pb.ResponseHeader{Revision: rev},
+		Count:                 int64(len(kvs)),
+		ConsistencyDowngraded: cur.downgraded,
+	}
+	if r.CountOnly {
+		return resp, nil
+	}
+	kvs = slices.DeleteFunc(kvs, func(kv *mvccpb.KeyValue) bool {
+		return (r.MaxModRevision != 0 && kv.ModRevision > r.MaxModRevision) ||
+			(r.MinModRevision != 0 && kv.ModRevision < r.MinModRevision) ||
+			(r.MaxCreateRevision != 0 && kv.CreateRevision > r.MaxCreateRevision) ||
+			(r.MinCreateRevision != 0 && kv.CreateRevision < r.MinCreateRevision)
+	})
+	sortKVs(kvs, r.SortTarget, r.SortOrder)
+	if r.Limit > 0 && len(kvs) > int(r.Limit) {
+		kvs = kvs[:r.Limit]
+		resp.More = true
+	}
+	for _, kv := range kvs {
+		kv = proto.Clone(kv).(*mvccpb.KeyValue)
+		if r.KeysOnly {
+			kv.Value = nil
+		}
+		resp.Kvs = append(resp.Kvs, kv)
+	}
+	if resp.More && len(resp.Kvs) != 0 && isDefaultOrdering(r.SortTarget, r.SortOrder) {
+		cur.rev = readRev
+		cur.key = append(bytes.Clone(resp.Kvs[len(resp.Kvs)-1].Key), 0)
+		resp.NextCursor = cur.encode()
+	}
+	return resp, nil
+}
+
+// checkPutRequest checks that the put can be applied at rev.
+func (s *Store) checkPutRequest(r *pb.PutRequest, rev int64) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	if r.IgnoreValue && len(r.Value) != 0 {
+		return rpctypes.ErrGRPCValueProvided
+	}
+	if r.IgnoreLease && r.Lease != 0 {
+		return rpctypes.ErrGRPCLeaseProvided
+	}
+	if _, ok := s.leases[r.Lease]; r.Lease != 0 && !ok {
+		return rpctypes.ErrGRPCLeaseNotFound
+	}
+	if (r.IgnoreValue || r.IgnoreLease) && s.get(r.Key, rev) == nil {
+		return rpctypes.ErrGRPCKeyNotFound
+	}
+	return nil
+}
+
+func (w *writeTxn) putRequest(r *pb.PutRequest) *pb.PutResponse {
+	prev := w.s.get(r.Key, w.readRev())
+	val, leaseID := r.Value, r.Lease
+	if r.IgnoreValue {
+		val = prev.Value
+	}
+	if r.IgnoreLease {
+		leaseID = prev.Lease
+	}
+	w.put(r.Key, val, leaseID)
+	resp := &pb.PutResponse{Header: &pb.ResponseHeader{Revision: w.rev}}
+	if r.PrevKv && prev != nil {
+		resp.PrevKv = proto.Clone(prev).(*mvccpb.KeyValue)
+	}
+	return resp
+}
+
+func (w *writeTxn) deleteRangeRequest(r *pb.DeleteRangeRequest) *pb.DeleteRangeResponse {
+	resp := &pb.DeleteRangeResponse{Header: &pb.ResponseHeader{}}
+	if r.PrevKv {
+		for _, kv := range w.s.rangeKVs(r.Key, r.RangeEnd, w.readRev()) {
+			resp.PrevKvs = append(resp.PrevKvs, proto.Clone(kv).(*mvccpb.KeyValue))
+		}
+	}
+	resp.Deleted = w.deleteRange(r.Key, r.RangeEnd)
+	resp.Header.Revision = w.readRev()
+	return resp
+}
+
+func (s *Store) txnRequest(r *pb.TxnRequest) (*pb.TxnResponse, error) {
+	if err := checkTxnRequest(r, maxTxnOps); err != nil {
+		return nil, err
+	}
+	if _, _, err := checkIntervals(r.Success); err != nil {
+		return nil, err
+	}
+	if _, _, err := checkIntervals(r.Failure); err != nil {
+		return nil, err
+	}
+	// the compares of the nested txns are evaluated before any operation,
+	// like the ones of the txn
+	succeeded := make(map[*pb.TxnRequest]bool)
+	s.compareTxn(r, succeeded)
+	if err := s.checkTxn(r, succeeded); err != nil {
+		return nil, err
+	}
+	w := s.write()
+	resp := w.txn(r, succeeded)
+	w.end()
+	resp.Header = s.header()
+	return resp, nil
+}
+
+// compareTxn records whether the compares of r and of its nested txns
+// succeed.
+func (s *Store) compareTxn(r *pb.TxnRequest, succeeded map[*pb.TxnRequest]bool) {
+	ok := true
+	for _, c := range r.Compare {
+		if !s.compare(c) {
+			ok = false
+			break
+		}
+	}
+	succeeded[r] = ok
+	for _, op := range txnOps(r, ok) {
+		if tv, _ := op.Request.(*pb.RequestOp_RequestTxn); tv != nil && tv.RequestTxn != nil {
+			s.compareTxn(tv.RequestTxn, succeeded)
+		}
+	}
+}
+
+func (s *Store) compare(c *pb.Compare) bool {
+	kvs := s.rangeKVs(c.Key, c.RangeEnd, s.rev)
+	if len(kvs) == 0 {
+		// a missing key has no value to compare
+		if c.Target == pb.Compare_VALUE {
+			return false
+		}
+		return compareKV(c, &mvccpb.KeyValue{})
+	}
+	for _, kv := range kvs {
+		if !compareKV(c, kv) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkTxn checks that the operations of the branches taken by the txn can be
+// applied.
+func (s *Store) checkTxn(r *pb.TxnRequest, succeeded map[*pb.TxnRequest]bool) error {
+	for _, op := range txnOps(r, succeeded[r]) {
+		var err error
+		switch tv := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			switch rev := tv.RequestRange.Revision; {
+			case len(tv.RequestRange.Cursor) != 0:
+				_, err = s.resolveRangeCursor(tv.RequestRange, s.rev)
+			case rev > s.rev:
+				err = rpctypes.ErrGRPCFutureRev
+			case rev != 0 && rev < s.compactRev:
+				err = rpctypes.ErrGRPCCompacted
+			}
+		case *pb.RequestOp_RequestPut:
+			err = s.checkPutRequest(tv.RequestPut, s.rev)
+		case *pb.RequestOp_RequestTxn:
+			err = s.checkTxn(tv.RequestTxn, succeeded)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *writeTxn) txn(r *pb.TxnRequest, succeeded map[*pb.TxnRequest]bool) *pb.TxnResponse {
+	resp := &pb.TxnResponse{Header: &pb.ResponseHeader{}, Succeeded: succeeded[r]}
+	for _, op := range txnOps(r, succeeded[r]) {
+		switch tv := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			// the revision of the range was checked before the writes
+			rresp, _ := w.s.rangeRequest(tv.RequestRange, w.readRev())
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponseRange{ResponseRange: rresp}})
+		case *pb.RequestOp_RequestPut:
+			presp := w.putRequest(tv.RequestPut)
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponsePut{ResponsePut: presp}})
+		case *pb.RequestOp_RequestDeleteRange:
+			dresp := w.deleteRangeRequest(tv.RequestDeleteRange)
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: dresp}})
+		case *pb.RequestOp_RequestTxn:
+			tresp := w.txn(tv.RequestTxn, succeeded)
+			resp.Responses = append(resp.Responses, &pb.ResponseOp{Response: &pb.ResponseOp_ResponseTxn{ResponseTxn: tresp}})
+		}
+	}
+	resp.Header.Revision = w.readRev()
+	return resp
+}
+
+// txnError returns the error injected for a key touched by the txn.
+func (s *Store) txnError(r *pb.TxnRequest) error {
+	for _, c := range r.Compare {
+		if err := s.injectedError(c.Key, c.RangeEnd); err != nil {
+			return err
+		}
+	}
+	for _, op := range append(slices.Clone(r.Success), r.Failure...) {
+		var err error
+		switch tv := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			err = s.injectedError(tv.RequestRange.Key, tv.RequestRange.RangeEnd)
+		case *pb.RequestOp_RequestPut:
+			err = s.injectedError(tv.RequestPut.Key, nil)
+		case *pb.RequestOp_RequestDeleteRange:
+			err = s.injectedError(tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd)
+		case *pb.RequestOp_RequestTxn:
+			err = s.txnError(tv.RequestTxn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func txnOps(r *pb.TxnRequest, succeeded bool) []*pb.RequestOp {
+	if succeeded {
+		return r.Success
+	}
+	return r.Failure
+}
+
+func (s *Store) compactRequest(r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	switch {
+	case r.Revision <= s.compactRev:
+		return nil, rpctypes.ErrGRPCCompacted
+	case r.Revision > s.rev:
+		return nil, rpctypes.ErrGRPCFutureRev
+	}
+	s.compact(r.Revision)
+	return &pb.CompactionResponse{Header: s.header()}, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+func TestStoreSeed(t *testing.T) {
+	s := New(map[string]string{"b": "2", "a": "1"})
+	defer s.Close()
+	require.Equal(t, int64(2), s.Rev())
+
+	resp, err := s.Client().Get(t.Context(), "", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 2)
+	for i, want := range []string{"a", "b"} {
+		kv := resp.Kvs[i]
+		assert.Equal(t, want, string(kv.Key))
+		assert.Equal(t, int64(2), kv.CreateRevision)
+		assert.Equal(t, int64(2), kv.ModRevision)
+		assert.Equal(t, int64(1), kv.Version)
+	}
+	assert.Equal(t, int64(2), resp.Header.Revision)
+}
+
+func TestStoreKV(t *testing.T) {
+	s := New(nil)
+	defer s.Close()
+	cli, ctx := s.Client(), t.Context()
+
+	_, err := cli.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	presp, err := cli.Put(ctx, "foo", "baz", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), presp.Header.Revision)
+	require.Equal(t, "bar", string(presp.PrevKv.Value))
+
+	gresp, err := cli.Get(ctx, "foo", clientv3.WithRev(2))
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(gresp.Kvs[0].Value))
+	gresp, err = cli.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, int64(2), gresp.Kvs[0].Version)
+
+	tresp, err := cli.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value("foo"), "=", "baz")).
+		Then(clientv3.OpPut("foo", "qux"), clientv3.OpGet("foo")).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	require.Equal(t, "qux", string(tresp.Responses[1].GetResponseRange().Kvs[0].Value))
+
+	dresp, err := cli.Delete(ctx, "foo")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), dresp.Deleted)
+
+	require.NoError(t, s.Compact(4))
+	_, err = cli.Get(ctx, "foo", clientv3.WithRev(3))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	_, err = cli.Get(ctx, "foo", clientv3.WithRev(6))
+	require.ErrorIs(t, err, rpctypes.ErrFutureRev)
+}
+
+func TestStoreInjectError(t *testing.T) {
+	s := New(map[string]string{"foo": "bar"})
+	defer s.Close()
+	cli, ctx := s.Client(), t.Context()
+
+	s.InjectError("foo", rpctypes.ErrGRPCNoLeader)
+	_, err := cli.Get(ctx, "foo")
+	require.ErrorIs(t, err, rpctypes.ErrNoLeader)
+	_, err = cli.Get(ctx, "f", clientv3.WithPrefix())
+	require.ErrorIs(t, err, rpctypes.ErrNoLeader)
+	_, err = cli.Txn(ctx).If(clientv3.Compare(clientv3.Version("foo"), ">", 0)).Commit()
+	require.ErrorIs(t, err, rpctypes.ErrNoLeader)
+	_, err = cli.Put(ctx, "other", "v")
+	require.NoError(t, err)
+
+	s.InjectError("foo", nil)
+	_, err = cli.Get(ctx, "foo")
+	require.NoError(t, err)
+}
+
+func TestStoreWatch(t *testing.T) {
+	s := New(map[string]string{"foo": "bar"})
+	defer s.Close()
+	cli, ctx := s.Client(), t.Context()
+
+	_, err := cli.Put(ctx, "foo", "baz")
+	require.NoError(t, err)
+	wch := cli.Watch(ctx, "foo", clientv3.WithRev(2), clientv3.WithPrevKV())
+	var evs []*clientv3.Event
+	for len(evs) < 3 {
+		if len(evs) == 2 {
+			_, err = cli.Delete(ctx, "foo")
+			require.NoError(t, err)
+		}
+		wresp := <-wch
+		require.NoError(t, wresp.Err())
+		evs = append(evs, wresp.Events...)
+	}
+	require.Equal(t, int64(2), evs[0].Kv.ModRevision)
+	require.Equal(t, "baz", string(evs[1].Kv.Value))
+	require.Equal(t, "bar", string(evs[1].PrevKv.Value))
+	require.Equal(t, mvccpb.Event_DELETE, evs[2].Type)
+	require.Equal(t, "baz", string(evs[2].PrevKv.Value))
+
+	require.NoError(t, s.Compact(3))
+	wresp := <-cli.Watch(ctx, "foo", clientv3.WithRev(2))
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+	require.Equal(t, int64(3), wresp.CompactRevision)
+}
+
+func TestStoreWatchRejected(t *testing.T) {
+	s := New(map[string]string{"foo": "bar"})
+	defer s.Close()
+	cli := s.Client()
+
+	// a watcher closing as another is rejected must not keep the client
+	// from closing
+	ctx, cancel := context.WithCancel(t.Context())
+	wresp := <-cli.Watch(ctx, "foo", clientv3.WithRev(1))
+	require.NoError(t, wresp.Err())
+	cancel()
+
+	wresp = <-cli.Watch(t.Context(), "foo", clientv3.WithLatestPerKey())
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), "latest_per_key is not supported")
+	wresp = <-cli.Watch(t.Context(), "foo", clientv3.WithRange("a"))
+	require.ErrorContains(t, wresp.Err(), "watcher range is empty")
+}
+
+func TestStoreExpireLease(t *testing.T) {
+	s := New(nil)
+	defer s.Close()
+	cli, ctx := s.Client(), t.Context()
+
+	lresp, err := cli.Grant(ctx, 60)
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo", "bar", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	ttl, err := cli.TimeToLive(ctx, lresp.ID, clientv3.WithAttachedKeys())
+	require.NoError(t, err)
+	require.Equal(t, int64(60), ttl.GrantedTTL)
+	require.Equal(t, [][]byte{[]byte("foo")}, ttl.Keys)
+
+	require.NoError(t, s.ExpireLease(lresp.ID))
+	require.ErrorIs(t, s.ExpireLease(lresp.ID), rpctypes.ErrLeaseNotFound)
+	gresp, err := cli.Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Empty(t, gresp.Kvs)
+	ttl, err = cli.TimeToLive(ctx, lresp.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), ttl.TTL)
+}
+
+func TestStoreSession(t *testing.T) {
+	s := New(nil)
+	defer s.Close()
+
+	sess, err := concurrency.NewSession(s.Client(), concurrency.WithTTL(1))
+	require.NoError(t, err)
+	m := concurrency.NewMutex(sess, "lock")
+	require.NoError(t, m.Lock(t.Context()))
+
+	// the session keeps its lease alive past its TTL
+	time.Sleep(1500 * time.Millisecond)
+	resp, err := s.Client().Get(t.Context(), "lock", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+
+	sess2, err := concurrency.NewSession(s.Client(), concurrency.WithTTL(60))
+	require.NoError(t, err)
+	defer sess2.Close()
+	m2 := concurrency.NewMutex(sess2, "lock")
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, m2.Lock(ctx), context.DeadlineExceeded)
+
+	// the lock is released once the lease of its holder expires
+	require.NoError(t, s.ExpireLease(sess.Lease()))
+	require.NoError(t, m2.Lock(t.Context()))
+}
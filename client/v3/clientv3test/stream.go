@@ -0,0 +1,112 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// stream is the client side of an in-memory gRPC stream. The requests sent
+// on it are handled synchronously, and the responses pushed by the handler
+// are queued until they are received.
+type stream[Req, Resp any] struct {
+	ctx    context.Context
+	handle func(*Req)
+
+	mu    sync.Mutex
+	queue []*Resp
+	// closed is set once no response is pushed anymore.
+	closed bool
+	// notifyc is signaled when a response is pushed or the stream is closed.
+	notifyc chan struct{}
+}
+
+func newStream[Req, Resp any](ctx context.Context, handle func(*Req)) *stream[Req, Resp] {
+	return &stream[Req, Resp]{ctx: ctx, handle: handle, notifyc: make(chan struct{}, 1)}
+}
+
+func (st *stream[Req, Resp]) Send(req *Req) error {
+	if st.ctx.Err() != nil {
+		return io.EOF
+	}
+	st.handle(req)
+	return nil
+}
+
+func (st *stream[Req, Resp]) push(resp *Resp) {
+	st.mu.Lock()
+	st.queue = append(st.queue, resp)
+	st.mu.Unlock()
+	st.signal()
+}
+
+// close has Recv return io.EOF once the queued responses are received.
+func (st *stream[Req, Resp]) close() {
+	st.mu.Lock()
+	st.closed = true
+	st.mu.Unlock()
+	st.signal()
+}
+
+func (st *stream[Req, Resp]) signal() {
+	select {
+	case st.notifyc <- struct{}{}:
+	default:
+	}
+}
+
+func (st *stream[Req, Resp]) Recv() (*Resp, error) {
+	for {
+		st.mu.Lock()
+		if len(st.queue) != 0 {
+			resp := st.queue[0]
+			st.queue = st.queue[1:]
+			st.mu.Unlock()
+			return resp, nil
+		}
+		closed := st.closed
+		st.mu.Unlock()
+		if closed {
+			return nil, io.EOF
+		}
+		select {
+		case <-st.notifyc:
+		case <-st.ctx.Done():
+			return nil, status.FromContextError(st.ctx.Err()).Err()
+		}
+	}
+}
+
+func (st *stream[Req, Resp]) Header() (metadata.MD, error) { return nil, nil }
+func (st *stream[Req, Resp]) Trailer() metadata.MD         { return nil }
+func (st *stream[Req, Resp]) CloseSend() error             { return nil }
+func (st *stream[Req, Resp]) Context() context.Context     { return st.ctx }
+
+func (st *stream[Req, Resp]) SendMsg(m any) error { return st.Send(m.(*Req)) }
+
+func (st *stream[Req, Resp]) RecvMsg(m any) error {
+	resp, err := st.Recv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(proto.Message), any(resp).(proto.Message))
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"slices"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// The checks of the requests follow the ones of the server, in
+// server/etcdserver/api/v3rpc/key.go and server/etcdserver/txn.
+
+// inRange returns whether k is in the range [key, end). An empty end stands
+// for the single key, and an end of "\x00" for all the keys from key on.
+func inRange(k, key, end []byte) bool {
+	switch {
+	case len(end) == 0:
+		return bytes.Equal(k, key)
+	case bytes.Equal(end, []byte{0}):
+		return bytes.Compare(k, key) >= 0
+	}
+	return bytes.Compare(k, key) >= 0 && bytes.Compare(k, end) < 0
+}
+
+// rangeWithin returns whether all the keys of the range [wkey, wend) are in
+// the range [key, end).
+func rangeWithin(wkey, wend, key, end []byte) bool {
+	all := []byte{0}
+	switch {
+	case bytes.Compare(wkey, key) < 0:
+		return false
+	case len(end) == 0:
+		return len(wend) == 0 && bytes.Equal(wkey, key)
+	case bytes.Equal(end, all):
+		return true
+	case len(wend) == 0:
+		return bytes.Compare(wkey, end) < 0
+	case bytes.Equal(wend, all):
+		return false
+	}
+	return bytes.Compare(wend, end) <= 0
+}
+
+func isDefaultOrdering(target pb.RangeRequest_SortTarget, order pb.RangeRequest_SortOrder) bool {
+	return order == pb.RangeRequest_NONE || (target == pb.RangeRequest_KEY && order == pb.RangeRequest_ASCEND)
+}
+
+func sortKVs(kvs []*mvccpb.KeyValue, target pb.RangeRequest_SortTarget, order pb.RangeRequest_SortOrder) {
+	if target != pb.RangeRequest_KEY && order == pb.RangeRequest_NONE {
+		order = pb.RangeRequest_ASCEND
+	}
+	if isDefaultOrdering(target, order) {
+		return
+	}
+	less := func(a, b *mvccpb.KeyValue) int {
+		switch target {
+		case pb.RangeRequest_VERSION:
+			return cmp.Compare(a.Version, b.Version)
+		case pb.RangeRequest_CREATE:
+			return cmp.Compare(a.CreateRevision, b.CreateRevision)
+		case pb.RangeRequest_MOD:
+			return cmp.Compare(a.ModRevision, b.ModRevision)
+		case pb.RangeRequest_VALUE:
+			return bytes.Compare(a.Value, b.Value)
+		}
+		return bytes.Compare(a.Key, b.Key)
+	}
+	if order == pb.RangeRequest_DESCEND {
+		slices.SortStableFunc(kvs, func(a, b *mvccpb.KeyValue) int { return less(b, a) })
+		return
+	}
+	slices.SortStableFunc(kvs, less)
+}
+
+func compareKV(c *pb.Compare, kv *mvccpb.KeyValue) bool {
+	var result int
+	switch c.Target {
+	case pb.Compare_VALUE:
+		result = bytes.Compare(kv.Value, c.GetValue())
+	case pb.Compare_CREATE:
+		result = cmp.Compare(kv.CreateRevision, c.GetCreateRevision())
+	case pb.Compare_MOD:
+		result = cmp.Compare(kv.ModRevision, c.GetModRevision())
+	case pb.Compare_VERSION:
+		result = cmp.Compare(kv.Version, c.GetVersion())
+	case pb.Compare_LEASE:
+		result = cmp.Compare(kv.Lease, c.GetLease())
+	}
+	switch c.Result {
+	case pb.Compare_EQUAL:
+		return result == 0
+	case pb.Compare_NOT_EQUAL:
+		return result != 0
+	case pb.Compare_GREATER:
+		return result > 0
+	case pb.Compare_LESS:
+		return result < 0
+	}
+	return false
+}
+
+func checkRangeRequest(r *pb.RangeRequest) error {
+	if len(r.Key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	if _, ok := pb.RangeRequest_SortOrder_name[int32(r.SortOrder)]; !ok {
+		return rpctypes.ErrGRPCInvalidSortOption
+	}
+	if _, ok := pb.RangeRequest_SortTarget_name[int32(r.SortTarget)]; !ok {
+		return rpctypes.ErrGRPCInvalidSortOption
+	}
+	if len(r.Cursor) != 0 && !isDefaultOrdering(r.SortTarget, r.SortOrder) {
+		return rpctypes.ErrGRPCInvalidRangeCursor
+	}
+	return nil
+}
+
+func checkTxnRequest(r *pb.TxnRequest, maxOps int) error {
+	opc := max(len(r.Compare), len(r.Success), len(r.Failure))
+	if opc > maxOps {
+		return rpctypes.ErrGRPCTooManyOps
+	}
+	for _, c := range r.Compare {
+		if len(c.Key) == 0 {
+			return rpctypes.ErrGRPCEmptyKey
+		}
+	}
+	for _, op := range append(slices.Clone(r.Success), r.Failure...) {
+		var err error
+		switch tv := op.Request.(type) {
+		case *pb.RequestOp_RequestRange:
+			err = checkRangeRequest(tv.RequestRange)
+		case *pb.RequestOp_RequestPut:
+			if len(tv.RequestPut.Key) == 0 {
+				err = rpctypes.ErrGRPCEmptyKey
+			}
+		case *pb.RequestOp_RequestDeleteRange:
+			if len(tv.RequestDeleteRange.Key) == 0 {
+				err = rpctypes.ErrGRPCEmptyKey
+			}
+		case *pb.RequestOp_RequestTxn:
+			err = checkTxnRequest(tv.RequestTxn, maxOps-opc)
+		default:
+			err = rpctypes.ErrGRPCKeyNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyRange is a range of keys, with the conventions of inRange.
+type keyRange struct{ key, end []byte }
+
+func intersects(dels []keyRange, k string) bool {
+	return slices.ContainsFunc(dels, func(r keyRange) bool { return inRange([]byte(k), r.key, r.end) })
+}
+
+// checkIntervals returns ErrGRPCDuplicateKey if the puts and deletes of the
+// operations, and of their nested txns, overlap. Otherwise it returns the
+// keys of the puts and the ranges of the deletes.
+func checkIntervals(ops []*pb.RequestOp) (map[string]struct{}, []keyRange, error) {
+	var dels []keyRange
+	for _, op := range ops {
+		if tv, _ := op.Request.(*pb.RequestOp_RequestDeleteRange); tv != nil && tv.RequestDeleteRange != nil {
+			dels = append(dels, keyRange{tv.RequestDeleteRange.Key, tv.RequestDeleteRange.RangeEnd})
+		}
+	}
+
+	puts := make(map[string]struct{})
+	for _, op := range ops {
+		tv, _ := op.Request.(*pb.RequestOp_RequestTxn)
+		if tv == nil || tv.RequestTxn == nil {
+			continue
+		}
+		putsThen, delsThen, err := checkIntervals(tv.RequestTxn.Success)
+		if err != nil {
+			return nil, nil, err
+		}
+		putsElse, delsElse, err := checkIntervals(tv.RequestTxn.Failure)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k := range putsThen {
+			if _, ok := puts[k]; ok || intersects(dels, k) {
+				return nil, nil, rpctypes.ErrGRPCDuplicateKey
+			}
+			puts[k] = struct{}{}
+		}
+		for k := range putsElse {
+			if _, ok := puts[k]; ok {
+				// then and else are mutually exclusive
+				if _, ok := putsThen[k]; !ok {
+					return nil, nil, rpctypes.ErrGRPCDuplicateKey
+				}
+			}
+			if intersects(dels, k) {
+				return nil, nil, rpctypes.ErrGRPCDuplicateKey
+			}
+			puts[k] = struct{}{}
+		}
+		dels = append(append(dels, delsThen...), delsElse...)
+	}
+
+	for _, op := range ops {
+		tv, _ := op.Request.(*pb.RequestOp_RequestPut)
+		if tv == nil || tv.RequestPut == nil {
+			continue
+		}
+		k := string(tv.RequestPut.Key)
+		if _, ok := puts[k]; ok || intersects(dels, k) {
+			return nil, nil, rpctypes.ErrGRPCDuplicateKey
+		}
+		puts[k] = struct{}{}
+	}
+	return puts, dels, nil
+}
+
+// rangeCursor is the position of a paginated range: the first key of the
+// next page, at the revision the range is read at.
+type rangeCursor struct {
+	rev        int64
+	key        []byte
+	downgraded bool
+}
+
+const (
+	rangeCursorVersion    = 1
+	rangeCursorDowngraded = 1 << 0
+)
+
+func (c rangeCursor) encode() []byte {
+	var flags byte
+	if c.downgraded {
+		flags |= rangeCursorDowngraded
+	}
+	b := binary.AppendVarint([]byte{rangeCursorVersion, flags}, c.rev)
+	return append(b, c.key...)
+}
+
+func decodeRangeCursor(b []byte) (rangeCursor, error) {
+	if len(b) < 2 || b[0] != rangeCursorVersion {
+		return rangeCursor{}, rpctypes.ErrGRPCInvalidRangeCursor
+	}
+	c := rangeCursor{downgraded: b[1]&rangeCursorDowngraded != 0}
+	rev, n := binary.Varint(b[2:])
+	if n <= 0 || rev <= 0 {
+		return rangeCursor{}, rpctypes.ErrGRPCInvalidRangeCursor
+	}
+	c.rev, c.key = rev, b[2+n:]
+	return c, nil
+}
+
+// resolveRangeCursor returns the position of the range of r, read at rev. The
+// position moves to the oldest revision if the one of the cursor is
+// compacted.
+func (s *Store) resolveRangeCursor(r *pb.RangeRequest, rev int64) (rangeCursor, error) {
+	c, err := decodeRangeCursor(r.Cursor)
+	if err != nil {
+		return rangeCursor{}, err
+	}
+	if r.Revision != 0 && r.Revision != c.rev {
+		return rangeCursor{}, rpctypes.ErrGRPCInvalidRangeCursor
+	}
+	if bytes.Compare(c.key, r.Key) < 0 {
+		c.key = r.Key
+	}
+	switch {
+	case c.rev > rev:
+		return rangeCursor{}, rpctypes.ErrGRPCFutureRev
+	case c.rev < s.compactRev:
+		c.rev, c.downgraded = s.compactRev, true
+	}
+	return c, nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// The cancel reasons of the watch create requests the store rejects, as
+// worded by the server.
+const (
+	reasonEmptyRange  = "mvcc: watcher range is empty"
+	reasonDuplicateID = "mvcc: duplicate watch ID provided on the WatchStream"
+)
+
+// watchClient serves the watch streams of a client from the store.
+type watchClient struct{ s *Store }
+
+func (c *watchClient) Watch(ctx context.Context, _ ...grpc.CallOption) (grpc.BidiStreamingClient[pb.WatchRequest, pb.WatchResponse], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	s := c.s
+	ws := &watchStream{s: s, watchers: make(map[int64]*watcher)}
+	ws.stream = newStream[pb.WatchRequest, pb.WatchResponse](ctx, ws.handle)
+	s.mu.Lock()
+	s.streams[ws] = struct{}{}
+	s.mu.Unlock()
+	context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		delete(s.streams, ws)
+		s.mu.Unlock()
+	})
+	return ws.stream, nil
+}
+
+// watchStream is a watch stream of a client. Its watchers are only accessed
+// with the lock of the store held.
+type watchStream struct {
+	s      *Store
+	stream *stream[pb.WatchRequest, pb.WatchResponse]
+
+	watchers map[int64]*watcher
+	nextID   int64
+}
+
+type watcher struct {
+	id int64
+	// key and end are the range of the watcher, with the conventions of
+	// inRange.
+	key, end []byte
+
+	noPut, noDelete  bool
+	prevKV, keysOnly bool
+}
+
+func (w *watcher) event(ev *mvccpb.Event) *mvccpb.Event {
+	switch {
+	case !inRange(ev.Kv.Key, w.key, w.end):
+		return nil
+	case ev.Type == mvccpb.Event_PUT && w.noPut:
+		return nil
+	case ev.Type == mvccpb.Event_DELETE && w.noDelete:
+		return nil
+	}
+	ev = proto.Clone(ev).(*mvccpb.Event)
+	if !w.prevKV {
+		ev.PrevKv = nil
+	}
+	if w.keysOnly {
+		ev.Kv.Value = nil
+		if ev.PrevKv != nil {
+			ev.PrevKv.Value = nil
+		}
+	}
+	return ev
+}
+
+func (w *watcher) events(evs []*mvccpb.Event) []*mvccpb.Event {
+	var wevs []*mvccpb.Event
+	for _, ev := range evs {
+		if ev = w.event(ev); ev != nil {
+			wevs = append(wevs, ev)
+		}
+	}
+	return wevs
+}
+
+func (ws *watchStream) handle(req *pb.WatchRequest) {
+	s := ws.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch uv := req.RequestUnion.(type) {
+	case *pb.WatchRequest_CreateRequest:
+		if uv.CreateRequest != nil {
+			ws.create(uv.CreateRequest)
+		}
+	case *pb.WatchRequest_CancelRequest:
+		if uv.CancelRequest != nil {
+			ws.cancel(uv.CancelRequest.WatchId)
+		}
+	case *pb.WatchRequest_CancelRangeRequest:
+		if creq := uv.CancelRangeRequest; creq != nil {
+			for _, id := range slices.Sorted(maps.Keys(ws.watchers)) {
+				if w := ws.watchers[id]; rangeWithin(w.key, w.end, creq.Key, creq.RangeEnd) {
+					ws.cancel(id)
+				}
+			}
+		}
+	case *pb.WatchRequest_ProgressRequest:
+		// the watchers are always synced
+		ws.stream.push(&pb.WatchResponse{Header: s.header(), WatchId: clientv3.InvalidWatchID})
+	}
+}
+
+// unsupportedWatchOption returns the name of the first option of the request
+// the store does not support, if any.
+func unsupportedWatchOption(creq *pb.WatchCreateRequest) string {
+	switch {
+	case creq.SnapshotFallback:
+		return "snapshot_fallback"
+	case creq.LatestPerKey:
+		return "latest_per_key"
+	case len(creq.ValuePrefix) != 0:
+		return "value_prefix"
+	case creq.ValueSelector != "":
+		return "value_selector"
+	case creq.SampleEveryN != 0:
+		return "sample_every_n"
+	case creq.MaxEventsPerResponse != 0:
+		return "max_events_per_response"
+	case creq.VersionOnly:
+		return "version_only"
+	case creq.CompactWarningMargin != 0:
+		return "compact_warning_margin"
+	case creq.KeepAliveInterval != 0:
+		return "keep_alive_interval"
+	case creq.AckWindow != 0:
+		return "ack_window"
+	case creq.CollapseDeletes:
+		return "collapse_deletes"
+	case creq.Compare != nil:
+		return "compare"
+	}
+	return ""
+}
+
+func (ws *watchStream) create(creq *pb.WatchCreateRequest) {
+	s := ws.s
+	reject := func(reason string) {
+		ws.stream.push(&pb.WatchResponse{
+			Header:       s.header(),
+			WatchId:      clientv3.InvalidWatchID,
+			Created:      true,
+			Canceled:     true,
+			CancelReason: reason,
+		})
+	}
+	if opt := unsupportedWatchOption(creq); opt != "" {
+		reject("clientv3test: " + opt + " is not supported")
+		return
+	}
+	if creq.StartRevision < 0 {
+		reject(rpctypes.ErrCompacted.Error())
+		return
+	}
+	w := &watcher{
+		key:      creq.Key,
+		end:      creq.RangeEnd,
+		prevKV:   creq.PrevKv,
+		keysOnly: creq.KeysOnly,
+	}
+	if len(w.key) == 0 {
+		// \x00 is the smallest key
+		w.key = []byte{0}
+	}
+	if len(w.end) != 0 && !bytes.Equal(w.end, []byte{0}) && bytes.Compare(w.key, w.end) >= 0 {
+		reject(reasonEmptyRange)
+		return
+	}
+	for _, f := range creq.Filters {
+		switch f {
+		case pb.WatchCreateRequest_NOPUT:
+			w.noPut = true
+		case pb.WatchCreateRequest_NODELETE:
+			w.noDelete = true
+		}
+	}
+
+	switch w.id = creq.WatchId; {
+	case w.id == clientv3.AutoWatchID:
+		for ws.watchers[ws.nextID] != nil {
+			ws.nextID++
+		}
+		w.id = ws.nextID
+		ws.nextID++
+	case ws.watchers[w.id] != nil:
+		reject(reasonDuplicateID)
+		return
+	}
+	ws.stream.push(&pb.WatchResponse{Header: s.header(), WatchId: w.id, Created: true})
+
+	startRev := creq.StartRevision
+	if startRev == 0 {
+		startRev = s.rev + 1
+	}
+	if startRev < s.compactRev {
+		ws.stream.push(&pb.WatchResponse{
+			Header:          s.header(),
+			WatchId:         w.id,
+			Canceled:        true,
+			CompactRevision: s.compactRev,
+		})
+		return
+	}
+	ws.watchers[w.id] = w
+
+	i, _ := slices.BinarySearchFunc(s.events, startRev, func(ev *mvccpb.Event, rev int64) int {
+		return cmp.Compare(ev.Kv.ModRevision, rev)
+	})
+	if evs := w.events(s.events[i:]); len(evs) != 0 {
+		ws.stream.push(&pb.WatchResponse{Header: s.header(), WatchId: w.id, Events: evs})
+	}
+}
+
+func (ws *watchStream) cancel(id int64) {
+	if ws.watchers[id] == nil {
+		return
+	}
+	delete(ws.watchers, id)
+	ws.stream.push(&pb.WatchResponse{Header: ws.s.header(), WatchId: id, Canceled: true})
+}
+
+// notify sends the events of a revision to the watchers of the stream.
+func (ws *watchStream) notify(evs []*mvccpb.Event) {
+	for _, id := range slices.Sorted(maps.Keys(ws.watchers)) {
+		if wevs := ws.watchers[id].events(evs); len(wevs) != 0 {
+			ws.stream.push(&pb.WatchResponse{Header: ws.s.header(), WatchId: id, Events: wevs})
+		}
+	}
+}
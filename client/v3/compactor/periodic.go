@@ -0,0 +1,186 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compactor implements a client side compactor of the keyspace of an
+// etcd cluster, for clusters running without --auto-compaction-retention.
+package compactor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const retryDivisor = 10
+
+// Periodic compacts the keyspace of a cluster, keeping the revisions of the
+// retention window, like the periodic auto compaction of the server does.
+//
+// The current revision is sampled every 1/10 of the retention, or of one hour
+// if the retention is longer, and the latest sample older than the retention
+// is compacted once per retention, or per hour if the retention is longer.
+// Compactions are not physical.
+//
+// It does not depend on which member is the leader, and several clients may
+// run it on the same cluster: a revision already compacted by another one
+// counts as a success.
+type Periodic struct {
+	lg        *zap.Logger
+	clock     clockwork.Clock
+	retention time.Duration
+	kv        clientv3.KV
+
+	// mu protects the fields below
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	donec  chan struct{}
+}
+
+// revSample is the revision of the cluster at a point in time.
+type revSample struct {
+	t   time.Time
+	rev int64
+}
+
+// NewPeriodic returns a compactor of the keyspace of the cluster of c that
+// keeps the revisions of the last retention. It starts compacting on Start.
+func NewPeriodic(c *clientv3.Client, retention time.Duration) *Periodic {
+	return newPeriodic(c.GetLogger(), clockwork.NewRealClock(), retention, c)
+}
+
+func newPeriodic(lg *zap.Logger, clock clockwork.Clock, retention time.Duration, kv clientv3.KV) *Periodic {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	return &Periodic{lg: lg, clock: clock, retention: retention, kv: kv}
+}
+
+// Start starts compacting in the background. It is a no-op if the compactor
+// is already started.
+func (pc *Periodic) Start() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.cancel != nil {
+		return
+	}
+	var ctx context.Context
+	ctx, pc.cancel = context.WithCancel(context.Background())
+	pc.donec = make(chan struct{})
+	go pc.run(ctx, pc.donec)
+}
+
+// Stop stops compacting, and waits for an ongoing compaction to return. The
+// compactor can be started again, from a new retention window.
+func (pc *Periodic) Stop() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.cancel == nil {
+		return
+	}
+	pc.cancel()
+	<-pc.donec
+	pc.cancel, pc.donec = nil, nil
+}
+
+func (pc *Periodic) run(ctx context.Context, donec chan<- struct{}) {
+	defer close(donec)
+
+	sampleInterval, compactInterval := pc.sampleInterval(), pc.compactInterval()
+	var samples []revSample
+	// lastRev is the last revision compacted
+	var lastRev int64
+	lastSuccess := pc.clock.Now()
+	// compactions are not attempted before nextAttempt after a failure
+	var backoff time.Duration
+	var nextAttempt time.Time
+	for {
+		if rev, err := pc.currentRev(ctx); err == nil {
+			samples = append(samples, revSample{t: pc.clock.Now(), rev: rev})
+		} else if ctx.Err() == nil {
+			pc.lg.Warn("failed to get the current revision for periodic compaction", zap.Error(err))
+		}
+
+		t := pc.clock.NewTimer(sampleInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.Chan():
+		}
+
+		// samples[0] is kept as the latest sample older than the retention
+		now := pc.clock.Now()
+		i := -1
+		for i+1 < len(samples) && now.Sub(samples[i+1].t) >= pc.retention {
+			i++
+		}
+		if i < 0 {
+			continue
+		}
+		samples = samples[i:]
+		rev := samples[0].rev
+		if rev <= lastRev || now.Sub(lastSuccess) < compactInterval || now.Before(nextAttempt) {
+			continue
+		}
+
+		pc.lg.Info("starting periodic compaction", zap.Int64("revision", rev), zap.Duration("retention", pc.retention))
+		_, err := pc.kv.Compact(ctx, rev)
+		switch {
+		case err == nil || errors.Is(err, rpctypes.ErrCompacted):
+			// the revision may have been compacted by another client
+			pc.lg.Info("completed periodic compaction", zap.Int64("revision", rev), zap.Duration("retention", pc.retention))
+			lastRev, lastSuccess, backoff = rev, now, 0
+		case ctx.Err() != nil:
+			return
+		default:
+			if errors.Is(err, rpctypes.ErrFutureRev) {
+				// the revisions of the cluster went back, for instance after
+				// a restore from a snapshot, so the samples are stale
+				samples, lastRev = nil, 0
+			}
+			backoff = min(max(2*backoff, sampleInterval), compactInterval)
+			nextAttempt = now.Add(backoff)
+			pc.lg.Warn(
+				"failed periodic compaction",
+				zap.Int64("revision", rev),
+				zap.Duration("retention", pc.retention),
+				zap.Duration("retry-interval", backoff),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (pc *Periodic) currentRev(ctx context.Context) (int64, error) {
+	resp, err := pc.kv.Get(ctx, "\x00", clientv3.WithCountOnly())
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.GetRevision(), nil
+}
+
+func (pc *Periodic) compactInterval() time.Duration {
+	return min(pc.retention, time.Hour)
+}
+
+func (pc *Periodic) sampleInterval() time.Duration {
+	return pc.compactInterval() / retryDivisor
+}
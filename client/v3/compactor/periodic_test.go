@@ -0,0 +1,175 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compactor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// fakeKV returns a revision incremented on every Get, and records the
+// compactions, failing them with the queued errors.
+type fakeKV struct {
+	clientv3.KV
+
+	mu       sync.Mutex
+	rev      int64
+	errs     []error
+	compacts chan int64
+}
+
+func newFakeKV(errs ...error) *fakeKV {
+	return &fakeKV{errs: errs, compacts: make(chan int64, 100)}
+}
+
+func (kv *fakeKV) Get(context.Context, string, ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.rev++
+	return &clientv3.GetResponse{Header: &pb.ResponseHeader{Revision: kv.rev}}, nil
+}
+
+func (kv *fakeKV) Compact(_ context.Context, rev int64, _ ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.compacts <- rev
+	if len(kv.errs) > 0 {
+		err := kv.errs[0]
+		kv.errs = kv.errs[1:]
+		return nil, err
+	}
+	return &clientv3.CompactResponse{}, nil
+}
+
+// advance advances the clock by n sample intervals of pc, and returns the
+// revisions compacted meanwhile.
+func advance(t *testing.T, fc *clockwork.FakeClock, pc *Periodic, kv *fakeKV, n int) []int64 {
+	t.Helper()
+	for range n {
+		require.NoError(t, fc.BlockUntilContext(t.Context(), 1))
+		fc.Advance(pc.sampleInterval())
+	}
+	require.NoError(t, fc.BlockUntilContext(t.Context(), 1))
+	var revs []int64
+	for {
+		select {
+		case rev := <-kv.compacts:
+			revs = append(revs, rev)
+		default:
+			return revs
+		}
+	}
+}
+
+func TestPeriodic(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV()
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 10*time.Minute, kv)
+	pc.Start()
+	defer pc.Stop()
+
+	// nothing is compacted until the retention elapses
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	// then the revision sampled at the start is compacted
+	require.Equal(t, []int64{1}, advance(t, fc, pc, kv, 1))
+	// and the revision sampled one retention ago, once per retention
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	require.Equal(t, []int64{11}, advance(t, fc, pc, kv, 1))
+	require.Equal(t, []int64{21}, advance(t, fc, pc, kv, 10))
+}
+
+func TestPeriodicLongRetention(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV()
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 2*time.Hour, kv)
+	require.Equal(t, 6*time.Minute, pc.sampleInterval())
+	pc.Start()
+	defer pc.Stop()
+
+	require.Empty(t, advance(t, fc, pc, kv, 19))
+	require.Equal(t, []int64{1}, advance(t, fc, pc, kv, 1))
+	// longer retentions are compacted every hour
+	require.Equal(t, []int64{11}, advance(t, fc, pc, kv, 10))
+}
+
+func TestPeriodicAlreadyCompacted(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV(rpctypes.ErrCompacted)
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 10*time.Minute, kv)
+	pc.Start()
+	defer pc.Stop()
+
+	// a revision compacted by another client is not retried
+	require.Equal(t, []int64{1}, advance(t, fc, pc, kv, 10))
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	require.Equal(t, []int64{11}, advance(t, fc, pc, kv, 1))
+}
+
+func TestPeriodicBackoff(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV(errors.New("unavailable"), errors.New("unavailable"))
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 10*time.Minute, kv)
+	pc.Start()
+	defer pc.Stop()
+
+	require.Equal(t, []int64{1}, advance(t, fc, pc, kv, 10))
+	// the first retry is one sample interval later
+	require.Equal(t, []int64{2}, advance(t, fc, pc, kv, 1))
+	// then the interval doubles
+	require.Empty(t, advance(t, fc, pc, kv, 1))
+	require.Equal(t, []int64{4}, advance(t, fc, pc, kv, 1))
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	require.Equal(t, []int64{14}, advance(t, fc, pc, kv, 1))
+}
+
+func TestPeriodicFutureRev(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV(rpctypes.ErrFutureRev)
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 10*time.Minute, kv)
+	pc.Start()
+	defer pc.Stop()
+
+	require.Equal(t, []int64{1}, advance(t, fc, pc, kv, 10))
+	// the samples are dropped, so the compactor waits for a whole retention
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	require.Equal(t, []int64{11}, advance(t, fc, pc, kv, 1))
+}
+
+func TestPeriodicRestart(t *testing.T) {
+	fc := clockwork.NewFakeClock()
+	kv := newFakeKV()
+	pc := newPeriodic(zaptest.NewLogger(t), fc, 10*time.Minute, kv)
+	pc.Start()
+	pc.Start()
+	require.Empty(t, advance(t, fc, pc, kv, 5))
+	pc.Stop()
+	pc.Stop()
+
+	pc.Start()
+	defer pc.Stop()
+	require.Empty(t, advance(t, fc, pc, kv, 9))
+	require.Equal(t, []int64{7}, advance(t, fc, pc, kv, 1))
+}
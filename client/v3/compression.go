@@ -0,0 +1,222 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"go.etcd.io/etcd/client/v3/encoding/zstd"
+)
+
+const (
+	// CompressionNone disables the compression of messages.
+	CompressionNone = "none"
+	// CompressionGzip compresses messages with gzip.
+	CompressionGzip = gzip.Name
+	// CompressionZstd compresses messages with zstd.
+	CompressionZstd = zstd.Name
+)
+
+// compressionCodec validates codec and returns it, or "" if messages are not
+// compressed.
+func compressionCodec(codec string) (string, error) {
+	switch codec {
+	case "", CompressionNone:
+		return "", nil
+	case CompressionGzip:
+		return codec, nil
+	case CompressionZstd:
+		zstd.Register()
+		return codec, nil
+	}
+	return "", fmt.Errorf("unknown compression codec %q, expected one of %q, %q or %q", codec, CompressionNone, CompressionGzip, CompressionZstd)
+}
+
+// isCompressionUnsupportedError returns true if the server failed a call
+// because it cannot decompress the request.
+func isCompressionUnsupportedError(err error) bool {
+	ev, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch ev.Code() {
+	case codes.Unimplemented, codes.Internal:
+		// "grpc: Decompressor is not installed for grpc-encoding ..." or
+		// "grpc: failed to decompress the received message ..."
+		msg := ev.Message()
+		return strings.Contains(msg, "grpc-encoding") || strings.Contains(msg, "decompress")
+	}
+	return false
+}
+
+// disableCompression records that a server does not support the compression
+// codec, so that later calls are not compressed.
+func (c *Client) disableCompression(method string, err error) {
+	if !c.compressionDisabled.Swap(true) {
+		c.GetLogger().Warn(
+			"server does not support the compression codec, falling back to uncompressed calls",
+			zap.String("codec", c.compression),
+			zap.String("method", method),
+			zap.Error(err),
+		)
+	}
+}
+
+// compressionUnaryInterceptor returns a unary client interceptor compressing
+// requests with the configured codec, which retries a call uncompressed once
+// if the server does not support the codec.
+func (c *Client) compressionUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if c.compressionDisabled.Load() {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.UseCompressor(c.compression))...)
+		if !isCompressionUnsupportedError(err) {
+			return err
+		}
+		c.disableCompression(method, err)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// compressionStreamInterceptor returns a stream client interceptor compressing
+// messages with the configured codec. A server not supporting the codec fails
+// the stream once it receives the first message, so the messages sent before
+// the first response are kept to replay them on an uncompressed stream.
+func (c *Client) compressionStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if c.compressionDisabled.Load() {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		cs, err := streamer(ctx, desc, cc, method, append(opts, grpc.UseCompressor(c.compression))...)
+		if err != nil {
+			return nil, err
+		}
+		return &compressedClientStream{
+			client: c,
+			cs:     cs,
+			newUncompressed: func() (grpc.ClientStream, error) {
+				return streamer(ctx, desc, cc, method, opts...)
+			},
+			method: method,
+		}, nil
+	}
+}
+
+// compressedClientStream is a compressed client stream which is replaced by
+// an uncompressed one if the server does not support the compression codec.
+type compressedClientStream struct {
+	client          *Client
+	newUncompressed func() (grpc.ClientStream, error)
+	method          string
+
+	mu sync.Mutex
+	cs grpc.ClientStream
+	// confirmed is true once the stream cannot fail anymore because of the
+	// compression codec.
+	confirmed bool
+	// sent holds the messages sent before the stream is confirmed.
+	sent      []any
+	closeSent bool
+}
+
+func (s *compressedClientStream) stream() grpc.ClientStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cs
+}
+
+func (s *compressedClientStream) Header() (metadata.MD, error) {
+	return s.stream().Header()
+}
+
+func (s *compressedClientStream) Trailer() metadata.MD {
+	return s.stream().Trailer()
+}
+
+func (s *compressedClientStream) Context() context.Context {
+	return s.stream().Context()
+}
+
+func (s *compressedClientStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeSent = true
+	return s.cs.CloseSend()
+}
+
+func (s *compressedClientStream) SendMsg(m any) error {
+	s.mu.Lock()
+	if s.confirmed {
+		cs := s.cs
+		s.mu.Unlock()
+		return cs.SendMsg(m)
+	}
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, m)
+	err := s.cs.SendMsg(m)
+	if errors.Is(err, io.EOF) {
+		// the stream failed, RecvMsg finds out why and replays the message
+		// if the stream is replaced
+		return nil
+	}
+	return err
+}
+
+func (s *compressedClientStream) RecvMsg(m any) error {
+	err := s.stream().RecvMsg(m)
+
+	s.mu.Lock()
+	if s.confirmed {
+		s.mu.Unlock()
+		return err
+	}
+	if !isCompressionUnsupportedError(err) {
+		s.confirmed, s.sent = true, nil
+		s.mu.Unlock()
+		return err
+	}
+	s.client.disableCompression(s.method, err)
+	cs, nerr := s.newUncompressed()
+	if nerr != nil {
+		s.mu.Unlock()
+		return nerr
+	}
+	s.cs, s.confirmed = cs, true
+	sent := s.sent
+	s.sent = nil
+	for _, msg := range sent {
+		if err := cs.SendMsg(msg); err != nil {
+			break
+		}
+	}
+	if s.closeSent {
+		cs.CloseSend()
+	}
+	s.mu.Unlock()
+	return cs.RecvMsg(m)
+}
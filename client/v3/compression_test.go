@@ -0,0 +1,205 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errNoDecompressor = status.Error(codes.Unimplemented, `grpc: Decompressor is not installed for grpc-encoding "zstd"`)
+
+func newCompressionTestClient(codec string) *Client {
+	c := &Client{compression: codec}
+	c.lg.Store(zap.NewNop())
+	return c
+}
+
+func compressorFromCallOptions(opts []grpc.CallOption) string {
+	for _, o := range opts {
+		if co, ok := o.(grpc.CompressorCallOption); ok {
+			return co.CompressorType
+		}
+	}
+	return ""
+}
+
+func TestCompressionCodec(t *testing.T) {
+	for codec, want := range map[string]string{
+		"":     "",
+		"none": "",
+		"gzip": "gzip",
+		"zstd": "zstd",
+	} {
+		got, err := compressionCodec(codec)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := compressionCodec("snappy")
+	require.ErrorContains(t, err, `unknown compression codec "snappy"`)
+}
+
+func TestIsCompressionUnsupportedError(t *testing.T) {
+	assert.True(t, isCompressionUnsupportedError(errNoDecompressor))
+	assert.True(t, isCompressionUnsupportedError(status.Error(codes.Internal, "grpc: failed to decompress the received message: bad input")))
+	assert.False(t, isCompressionUnsupportedError(status.Error(codes.Unimplemented, "unknown service etcdserverpb.KV")))
+	assert.False(t, isCompressionUnsupportedError(status.Error(codes.Unavailable, "grpc-encoding")))
+	assert.False(t, isCompressionUnsupportedError(nil))
+	assert.False(t, isCompressionUnsupportedError(io.EOF))
+}
+
+func TestCompressionUnaryInterceptorFallback(t *testing.T) {
+	c := newCompressionTestClient(CompressionZstd)
+	interceptor := c.compressionUnaryInterceptor()
+
+	var calls []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		codec := compressorFromCallOptions(opts)
+		calls = append(calls, codec)
+		if codec != "" {
+			return errNoDecompressor
+		}
+		return nil
+	}
+
+	require.NoError(t, interceptor(t.Context(), "/etcdserverpb.KV/Put", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"zstd", ""}, calls)
+	assert.True(t, c.compressionDisabled.Load())
+
+	// the result is cached, later calls are not compressed
+	calls = nil
+	require.NoError(t, interceptor(t.Context(), "/etcdserverpb.KV/Put", nil, nil, nil, invoker))
+	assert.Equal(t, []string{""}, calls)
+}
+
+func TestCompressionUnaryInterceptorSupported(t *testing.T) {
+	c := newCompressionTestClient(CompressionGzip)
+	interceptor := c.compressionUnaryInterceptor()
+
+	var calls []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls = append(calls, compressorFromCallOptions(opts))
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	err := interceptor(t.Context(), "/etcdserverpb.KV/Put", nil, nil, nil, invoker)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, []string{"gzip"}, calls)
+	assert.False(t, c.compressionDisabled.Load())
+}
+
+// fakeClientStream fails on receive with recvErr if set, and otherwise
+// echoes the messages it was sent.
+type fakeClientStream struct {
+	grpc.ClientStream
+	recvErr   error
+	sent      []any
+	closeSent bool
+}
+
+func (s *fakeClientStream) SendMsg(m any) error {
+	if s.recvErr != nil {
+		return io.EOF
+	}
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	if s.recvErr != nil {
+		return s.recvErr
+	}
+	if len(s.sent) == 0 {
+		return io.EOF
+	}
+	*(m.(*string)) = s.sent[0].(string)
+	s.sent = s.sent[1:]
+	return nil
+}
+
+func (s *fakeClientStream) CloseSend() error {
+	s.closeSent = true
+	return nil
+}
+
+func TestCompressionStreamInterceptorFallback(t *testing.T) {
+	c := newCompressionTestClient(CompressionZstd)
+	interceptor := c.compressionStreamInterceptor()
+
+	var streams []*fakeClientStream
+	var codecs []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		codec := compressorFromCallOptions(opts)
+		codecs = append(codecs, codec)
+		s := &fakeClientStream{}
+		if codec != "" {
+			s.recvErr = errNoDecompressor
+		}
+		streams = append(streams, s)
+		return s, nil
+	}
+
+	cs, err := interceptor(t.Context(), &grpc.StreamDesc{}, nil, "/etcdserverpb.Watch/Watch", streamer)
+	require.NoError(t, err)
+	require.NoError(t, cs.SendMsg("a"))
+	require.NoError(t, cs.SendMsg("b"))
+	require.NoError(t, cs.CloseSend())
+
+	// the messages sent before the failure are replayed on an uncompressed
+	// stream
+	var m string
+	require.NoError(t, cs.RecvMsg(&m))
+	assert.Equal(t, "a", m)
+	require.NoError(t, cs.RecvMsg(&m))
+	assert.Equal(t, "b", m)
+	assert.Equal(t, []string{"zstd", ""}, codecs)
+	assert.True(t, streams[1].closeSent)
+	assert.True(t, c.compressionDisabled.Load())
+
+	// later streams are not compressed
+	_, err = interceptor(t.Context(), &grpc.StreamDesc{}, nil, "/etcdserverpb.Watch/Watch", streamer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zstd", "", ""}, codecs)
+}
+
+func TestCompressionStreamInterceptorSupported(t *testing.T) {
+	c := newCompressionTestClient(CompressionZstd)
+	interceptor := c.compressionStreamInterceptor()
+
+	var codecs []string
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		codecs = append(codecs, compressorFromCallOptions(opts))
+		return &fakeClientStream{}, nil
+	}
+
+	cs, err := interceptor(t.Context(), &grpc.StreamDesc{}, nil, "/etcdserverpb.Watch/Watch", streamer)
+	require.NoError(t, err)
+	require.NoError(t, cs.SendMsg("a"))
+	var m string
+	require.NoError(t, cs.RecvMsg(&m))
+	assert.Equal(t, "a", m)
+	require.NoError(t, cs.SendMsg("b"))
+	require.NoError(t, cs.RecvMsg(&m))
+	assert.Equal(t, "b", m)
+	assert.Equal(t, []string{"zstd"}, codecs)
+	assert.False(t, c.compressionDisabled.Load())
+}
@@ -21,6 +21,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	v3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -244,6 +245,156 @@ func (e *Election) observe(ctx context.Context, ch chan<- *v3.GetResponse) {
 	}
 }
 
+// LeaderRecord is a change of the leader of an election, or of the value
+// proclaimed by the leader.
+type LeaderRecord struct {
+	// Revision is the revision the change happened at.
+	Revision int64
+	// Key is the key of the leader, or empty if the election has no leader
+	// anymore.
+	Key string
+	// Value is the value proclaimed by the leader.
+	Value []byte
+	// CreateRevision is the creation revision of the leader key, which stays
+	// the same until the leader resigns.
+	CreateRevision int64
+	// Lease is the lease of the session of the leader.
+	Lease v3.LeaseID
+	// Err is set on the last record before the channel closes on an error.
+	Err error
+}
+
+// ElectionCompactedError is the error of the history of an election that is
+// no longer available since it has been compacted.
+type ElectionCompactedError struct {
+	CompactRevision int64
+}
+
+func (e *ElectionCompactedError) Error() string {
+	return fmt.Sprintf("election: history compacted at revision %d", e.CompactRevision)
+}
+
+func (e *ElectionCompactedError) Unwrap() error { return rpctypes.ErrCompacted }
+
+// ObserveWithHistory returns a channel that receives every change of the
+// leader of the election, in order, from revision fromRev on. The first
+// record holds the leader before fromRev, if there was one. A fromRev of 0
+// starts from the current revision.
+//
+// Unlike Observe, no change is skipped. If the history from fromRev has been
+// compacted, the channel receives a record with an *ElectionCompactedError
+// and closes. The channel also closes when the context is canceled, or after
+// a record with the error if the underlying watcher fails.
+func (e *Election) ObserveWithHistory(ctx context.Context, fromRev int64) <-chan LeaderRecord {
+	retc := make(chan LeaderRecord)
+	go e.observeWithHistory(ctx, fromRev, retc)
+	return retc
+}
+
+func (e *Election) observeWithHistory(ctx context.Context, fromRev int64, ch chan<- LeaderRecord) {
+	client := e.session.Client()
+
+	defer close(ch)
+	send := func(rec LeaderRecord) bool {
+		select {
+		case ch <- rec:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// the candidates of the election before fromRev
+	cands := make(map[string]*mvccpb.KeyValue)
+	if fromRev != 1 {
+		opts := []v3.OpOption{v3.WithPrefix()}
+		if fromRev > 1 {
+			opts = append(opts, v3.WithRev(fromRev-1))
+		}
+		resp, err := client.Get(ctx, e.keyPrefix, opts...)
+		switch {
+		case errors.Is(err, rpctypes.ErrCompacted):
+			// the watcher below gets the compact revision
+		case err != nil:
+			send(LeaderRecord{Err: err})
+			return
+		default:
+			for _, kv := range resp.Kvs {
+				cands[string(kv.Key)] = kv
+			}
+			if fromRev <= 0 {
+				fromRev = resp.Header.Revision + 1
+			}
+		}
+	}
+	leader := electionLeader(cands)
+	if leader != nil && !send(newLeaderRecord(leader.ModRevision, leader)) {
+		return
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch := client.Watch(cctx, e.keyPrefix, v3.WithPrefix(), v3.WithRev(fromRev))
+	for wr := range wch {
+		if wr.CompactRevision != 0 {
+			send(LeaderRecord{Err: &ElectionCompactedError{CompactRevision: wr.CompactRevision}})
+			return
+		}
+		if err := wr.Err(); err != nil {
+			send(LeaderRecord{Err: err})
+			return
+		}
+		for i, ev := range wr.Events {
+			if ev.Type == mvccpb.Event_DELETE {
+				delete(cands, string(ev.Kv.Key))
+			} else {
+				cands[string(ev.Kv.Key)] = ev.Kv
+			}
+			rev := ev.Kv.ModRevision
+			// a txn may change several candidates at the same revision
+			if i+1 < len(wr.Events) && wr.Events[i+1].Kv.ModRevision == rev {
+				continue
+			}
+			next := electionLeader(cands)
+			switch {
+			case next == nil && leader == nil:
+				continue
+			case next == nil:
+				if !send(LeaderRecord{Revision: rev}) {
+					return
+				}
+			case leader == nil || next.CreateRevision != leader.CreateRevision || next.ModRevision != leader.ModRevision:
+				if !send(newLeaderRecord(rev, next)) {
+					return
+				}
+			}
+			leader = next
+		}
+	}
+}
+
+// electionLeader returns the candidate created first, or nil if there are
+// none.
+func electionLeader(cands map[string]*mvccpb.KeyValue) *mvccpb.KeyValue {
+	var leader *mvccpb.KeyValue
+	for _, kv := range cands {
+		if leader == nil || kv.CreateRevision < leader.CreateRevision {
+			leader = kv
+		}
+	}
+	return leader
+}
+
+func newLeaderRecord(rev int64, kv *mvccpb.KeyValue) LeaderRecord {
+	return LeaderRecord{
+		Revision:       rev,
+		Key:            string(kv.Key),
+		Value:          kv.Value,
+		CreateRevision: kv.CreateRevision,
+		Lease:          v3.LeaseID(kv.Lease),
+	}
+}
+
 // Key returns the leader key if elected, empty string otherwise.
 func (e *Election) Key() string { return e.leaderKey }
 
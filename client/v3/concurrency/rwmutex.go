@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// RWMutex is a reader/writer mutual exclusion lock with etcd. The lock can be
+// held by any number of readers or by a single writer.
+//
+// Readers and writers put a key under the prefix of the lock and are served
+// in the order of its create revision: a reader waits for the writers that
+// came before it, and a writer waits for everyone that came before it. So a
+// waiting writer blocks the readers that come after it, and is not starved.
+type RWMutex struct {
+	s *Session
+
+	pfx   string
+	myKey string
+	myRev int64
+	hdr   *pb.ResponseHeader
+}
+
+func NewRWMutex(s *Session, pfx string) *RWMutex {
+	return &RWMutex{s, pfx + "/", "", -1, nil}
+}
+
+func (rwm *RWMutex) readPfx() string  { return rwm.pfx + "read/" }
+func (rwm *RWMutex) writePfx() string { return rwm.pfx + "write/" }
+
+// RLock locks the mutex for reading with a cancelable context. If the context
+// is canceled while trying to acquire the lock, the mutex tries to clean its
+// stale lock entry.
+func (rwm *RWMutex) RLock(ctx context.Context) error {
+	return rwm.lock(ctx, rwm.readPfx(), rwm.writePfx())
+}
+
+// TryRLock locks the mutex for reading if no writer of another session holds
+// or waits for the lock. Otherwise, it returns ErrLocked immediately after
+// attempting necessary cleanup.
+func (rwm *RWMutex) TryRLock(ctx context.Context) error {
+	return rwm.tryLock(ctx, rwm.readPfx(), rwm.writePfx())
+}
+
+// RUnlock releases the lock held for reading.
+func (rwm *RWMutex) RUnlock(ctx context.Context) error {
+	return rwm.unlock(ctx, rwm.readPfx())
+}
+
+// Lock locks the mutex for writing with a cancelable context. If the context
+// is canceled while trying to acquire the lock, the mutex tries to clean its
+// stale lock entry.
+func (rwm *RWMutex) Lock(ctx context.Context) error {
+	return rwm.lock(ctx, rwm.writePfx(), rwm.pfx)
+}
+
+// TryLock locks the mutex for writing if no reader or writer of another
+// session holds or waits for the lock. Otherwise, it returns ErrLocked
+// immediately after attempting necessary cleanup.
+func (rwm *RWMutex) TryLock(ctx context.Context) error {
+	return rwm.tryLock(ctx, rwm.writePfx(), rwm.pfx)
+}
+
+// Unlock releases the lock held for writing.
+func (rwm *RWMutex) Unlock(ctx context.Context) error {
+	return rwm.unlock(ctx, rwm.writePfx())
+}
+
+// lock puts the key of the session under keyPfx, and waits for the keys under
+// waitPfx created before it to be deleted.
+func (rwm *RWMutex) lock(ctx context.Context, keyPfx, waitPfx string) error {
+	resp, err := rwm.tryAcquire(ctx, keyPfx, waitPfx)
+	if err != nil {
+		return err
+	}
+	if rwm.acquired(resp) {
+		rwm.hdr = resp.Header
+		return nil
+	}
+	client := rwm.s.Client()
+	// wait for deletion revisions prior to myKey
+	werr := waitDeletes(ctx, client, waitPfx, rwm.myRev-1)
+	// release lock key if wait failed
+	if werr != nil {
+		rwm.unlock(client.Ctx(), keyPfx)
+		return werr
+	}
+
+	// make sure the session is not expired, and the owner key still exists.
+	gresp, werr := client.Get(ctx, rwm.myKey)
+	if werr != nil {
+		rwm.unlock(client.Ctx(), keyPfx)
+		return werr
+	}
+
+	if len(gresp.Kvs) == 0 { // is the session key lost?
+		return ErrSessionExpired
+	}
+	rwm.hdr = gresp.Header
+
+	return nil
+}
+
+func (rwm *RWMutex) tryLock(ctx context.Context, keyPfx, waitPfx string) error {
+	resp, err := rwm.tryAcquire(ctx, keyPfx, waitPfx)
+	if err != nil {
+		return err
+	}
+	if rwm.acquired(resp) {
+		rwm.hdr = resp.Header
+		return nil
+	}
+	client := rwm.s.Client()
+	// Cannot lock, so delete the key
+	if _, err := client.Delete(ctx, rwm.myKey); err != nil {
+		return err
+	}
+	rwm.myKey = "\x00"
+	rwm.myRev = -1
+	return ErrLocked
+}
+
+// acquired returns whether no key under the prefix waited for was created
+// before myKey, in the response of tryAcquire.
+func (rwm *RWMutex) acquired(resp *v3.TxnResponse) bool {
+	firstKey := resp.Responses[1].GetResponseRange().Kvs
+	return len(firstKey) == 0 || firstKey[0].CreateRevision >= rwm.myRev
+}
+
+func (rwm *RWMutex) tryAcquire(ctx context.Context, keyPfx, waitPfx string) (*v3.TxnResponse, error) {
+	s := rwm.s
+	client := rwm.s.Client()
+
+	rwm.myKey = fmt.Sprintf("%s%x", keyPfx, s.Lease())
+	cmp := v3.Compare(v3.CreateRevision(rwm.myKey), "=", 0)
+	// put self in lock waiters via myKey
+	put := v3.OpPut(rwm.myKey, "", v3.WithLease(s.Lease()))
+	// reuse key in case this session already holds the lock
+	get := v3.OpGet(rwm.myKey)
+	// fetch the oldest waited for key to complete uncontended path with only one RPC
+	getFirst := v3.OpGet(waitPfx, v3.WithFirstCreate()...)
+	resp, err := client.Txn(ctx).If(cmp).Then(put, getFirst).Else(get, getFirst).Commit()
+	if err != nil {
+		return nil, err
+	}
+	rwm.myRev = resp.Header.Revision
+	if !resp.Succeeded {
+		rwm.myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+	return resp, nil
+}
+
+func (rwm *RWMutex) unlock(ctx context.Context, keyPfx string) error {
+	if rwm.myKey == "" || rwm.myRev <= 0 || rwm.myKey == "\x00" {
+		return ErrLockReleased
+	}
+
+	if !strings.HasPrefix(rwm.myKey, keyPfx) {
+		return fmt.Errorf("invalid key %q, it should have prefix %q", rwm.myKey, keyPfx)
+	}
+
+	client := rwm.s.Client()
+	if _, err := client.Delete(ctx, rwm.myKey); err != nil {
+		return err
+	}
+	rwm.myKey = "\x00"
+	rwm.myRev = -1
+	return nil
+}
+
+// IsOwner returns a comparison that holds while the session holds the lock,
+// for reading or writing, to guard a txn.
+func (rwm *RWMutex) IsOwner() v3.Cmp {
+	return v3.Compare(v3.CreateRevision(rwm.myKey), "=", rwm.myRev)
+}
+
+func (rwm *RWMutex) Key() string { return rwm.myKey }
+
+// Header is the response header received from etcd on acquiring the lock.
+func (rwm *RWMutex) Header() *pb.ResponseHeader { return rwm.hdr }
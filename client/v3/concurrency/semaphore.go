@@ -0,0 +1,228 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+var (
+	// ErrNoSlot is returned by TryAcquire when all the slots of the
+	// semaphore are held by other sessions.
+	ErrNoSlot = errors.New("semaphore: no slot available")
+	// ErrSlotReleased is returned by Release when the semaphore is not held.
+	ErrSlotReleased = errors.New("semaphore: slot has already been released")
+)
+
+// Semaphore is a counting semaphore with etcd, which limits the number of
+// sessions holding it at the same time to its capacity.
+//
+// Each waiter puts a key owned by its session under the prefix of the
+// semaphore, and holds a slot once fewer keys than the capacity were created
+// before its own. Slots are handed out in the order the waiters came in, and
+// the slot of a session that crashed is freed when its lease expires.
+type Semaphore struct {
+	s *Session
+
+	pfx      string
+	capacity int
+	myKey    string
+	myRev    int64
+	hdr      *pb.ResponseHeader
+}
+
+// NewSemaphore creates a semaphore with n slots, unless a capacity was set
+// for the prefix with SetCapacity.
+func NewSemaphore(s *Session, pfx string, n int) *Semaphore {
+	return &Semaphore{s, pfx + "/", n, "", -1, nil}
+}
+
+func (sm *Semaphore) holdersPfx() string  { return sm.pfx + "holders/" }
+func (sm *Semaphore) capacityKey() string { return sm.pfx + "capacity" }
+
+// SetCapacity sets the number of slots of the semaphore, for all of its
+// sessions. Waiters get the slots freed by a larger capacity right away,
+// while a smaller capacity only applies to the next waiters; the holders of
+// the slots beyond it keep them until they release them.
+func (sm *Semaphore) SetCapacity(ctx context.Context, n int) error {
+	if n < 0 {
+		return fmt.Errorf("semaphore: invalid capacity %d", n)
+	}
+	_, err := sm.s.Client().Put(ctx, sm.capacityKey(), strconv.Itoa(n))
+	return err
+}
+
+// TryAcquire acquires a slot of the semaphore if one is free. Otherwise, it
+// returns ErrNoSlot immediately after attempting necessary cleanup.
+func (sm *Semaphore) TryAcquire(ctx context.Context) error {
+	if err := sm.tryAcquire(ctx); err != nil {
+		return err
+	}
+	acquired, err := sm.acquired(ctx)
+	if err != nil {
+		return err
+	}
+	if acquired {
+		return nil
+	}
+	client := sm.s.Client()
+	// Cannot acquire, so delete the key
+	if _, err := client.Delete(ctx, sm.myKey); err != nil {
+		return err
+	}
+	sm.myKey = "\x00"
+	sm.myRev = -1
+	return ErrNoSlot
+}
+
+// Acquire acquires a slot of the semaphore with a cancelable context. If the
+// context is canceled while waiting for a slot, the semaphore tries to clean
+// its stale entry.
+func (sm *Semaphore) Acquire(ctx context.Context) error {
+	if err := sm.tryAcquire(ctx); err != nil {
+		return err
+	}
+	client := sm.s.Client()
+	// release the key if waiting failed
+	if err := sm.waitSlot(ctx); err != nil {
+		if !errors.Is(err, ErrSessionExpired) {
+			sm.Release(client.Ctx())
+		}
+		return err
+	}
+	return nil
+}
+
+// waitSlot waits until fewer keys than the capacity were created before
+// myKey, watching the prefix for the deletion of those keys and for the
+// changes of the capacity.
+func (sm *Semaphore) waitSlot(ctx context.Context) error {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wch v3.WatchChan
+	for {
+		acquired, err := sm.acquired(ctx)
+		if err != nil || acquired {
+			return err
+		}
+		if wch == nil {
+			wch = sm.s.Client().Watch(cctx, sm.pfx, v3.WithPrefix(), v3.WithRev(sm.hdr.Revision+1))
+		}
+		if err = sm.waitChange(ctx, wch); err != nil {
+			return err
+		}
+	}
+}
+
+// waitChange waits for an event that may free a slot for myKey.
+func (sm *Semaphore) waitChange(ctx context.Context, wch v3.WatchChan) error {
+	for wr := range wch {
+		if err := wr.Err(); err != nil {
+			return err
+		}
+		for _, ev := range wr.Events {
+			// the events of deleted keys carry no create revision, so any
+			// deleted key may have been ahead of myKey
+			if ev.Type == mvccpb.Event_DELETE || string(ev.Kv.Key) == sm.capacityKey() {
+				return nil
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("lost watcher waiting for a semaphore slot")
+}
+
+// acquired returns whether fewer keys than the capacity were created before
+// myKey, reading the keys and the capacity at the same revision.
+func (sm *Semaphore) acquired(ctx context.Context) (bool, error) {
+	resp, err := sm.s.Client().Txn(ctx).Then(
+		v3.OpGet(sm.myKey),
+		// the count of a range ignores the revision filters, so the keys
+		// created before myKey are counted here
+		v3.OpGet(sm.holdersPfx(), v3.WithPrefix(), v3.WithKeysOnly(), v3.WithMaxCreateRev(sm.myRev-1)),
+		v3.OpGet(sm.capacityKey()),
+	).Commit()
+	if err != nil {
+		return false, err
+	}
+	sm.hdr = resp.Header
+	if len(resp.Responses[0].GetResponseRange().Kvs) == 0 { // is the session key lost?
+		return false, ErrSessionExpired
+	}
+	capacity := sm.capacity
+	if kvs := resp.Responses[2].GetResponseRange().Kvs; len(kvs) != 0 {
+		if capacity, err = strconv.Atoi(string(kvs[0].Value)); err != nil {
+			return false, fmt.Errorf("semaphore: invalid capacity %q: %w", kvs[0].Value, err)
+		}
+	}
+	return len(resp.Responses[1].GetResponseRange().Kvs) < capacity, nil
+}
+
+func (sm *Semaphore) tryAcquire(ctx context.Context) error {
+	s := sm.s
+	client := sm.s.Client()
+
+	sm.myKey = fmt.Sprintf("%s%x", sm.holdersPfx(), s.Lease())
+	cmp := v3.Compare(v3.CreateRevision(sm.myKey), "=", 0)
+	// put self in the waiters via myKey
+	put := v3.OpPut(sm.myKey, "", v3.WithLease(s.Lease()))
+	// reuse key in case this session already holds a slot
+	get := v3.OpGet(sm.myKey)
+	resp, err := client.Txn(ctx).If(cmp).Then(put).Else(get).Commit()
+	if err != nil {
+		return err
+	}
+	sm.myRev = resp.Header.Revision
+	if !resp.Succeeded {
+		sm.myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+	return nil
+}
+
+// Release releases the slot held by the session.
+func (sm *Semaphore) Release(ctx context.Context) error {
+	if sm.myKey == "" || sm.myRev <= 0 || sm.myKey == "\x00" {
+		return ErrSlotReleased
+	}
+
+	client := sm.s.Client()
+	if _, err := client.Delete(ctx, sm.myKey); err != nil {
+		return err
+	}
+	sm.myKey = "\x00"
+	sm.myRev = -1
+	return nil
+}
+
+// IsOwner returns a comparison that holds while the session holds its key,
+// to guard a txn.
+func (sm *Semaphore) IsOwner() v3.Cmp {
+	return v3.Compare(v3.CreateRevision(sm.myKey), "=", sm.myRev)
+}
+
+func (sm *Semaphore) Key() string { return sm.myKey }
+
+// Header is the response header received from etcd on acquiring the slot.
+func (sm *Semaphore) Header() *pb.ResponseHeader { return sm.hdr }
@@ -16,6 +16,8 @@ package concurrency
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
@@ -25,6 +27,21 @@ import (
 
 const defaultSessionTTL = 60
 
+// ErrLeaseNotFound is the error of a session on a lease that does not exist,
+// or has expired.
+var ErrLeaseNotFound = errors.New("session: lease not found")
+
+// LeaseExpiringError is the error of a session on a lease whose remaining TTL
+// is less than the minimum set by WithMinLeaseTTL.
+type LeaseExpiringError struct {
+	// RemainingTTL is the remaining TTL of the lease in seconds.
+	RemainingTTL int64
+}
+
+func (e *LeaseExpiringError) Error() string {
+	return fmt.Sprintf("session: lease expires in %d seconds", e.RemainingTTL)
+}
+
 // Session represents a lease kept alive for the lifetime of a client.
 // Fault-tolerant applications may use sessions to reason about liveness.
 type Session struct {
@@ -40,30 +57,49 @@ type Session struct {
 // NewSession gets the leased session for a client.
 func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	lg := client.GetLogger()
-	ops := &sessionOptions{ttl: defaultSessionTTL, ctx: client.Ctx()}
+	ops := &sessionOptions{ttl: defaultSessionTTL, ctx: client.Ctx(), validate: true, keepAlive: true}
 	for _, opt := range opts {
 		opt(ops, lg)
 	}
 
-	id := ops.leaseID
-	if id == v3.NoLease {
+	id, ttl := ops.leaseID, int64(ops.ttl)
+	if ops.adopt {
+		resp, err := client.Transfer(ops.ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ttl, ops.ttl = resp.TTL, int(resp.TTL)
+	} else if id == v3.NoLease {
 		resp, err := client.Grant(ops.ctx, int64(ops.ttl))
 		if err != nil {
 			return nil, err
 		}
 		id = resp.ID
+	} else if ops.validate {
+		var err error
+		if ttl, err = leaseRemainingTTL(ops.ctx, client, id); err != nil {
+			return nil, err
+		}
+		if ttl <= 0 || ttl < int64(ops.minTTL) {
+			return nil, &LeaseExpiringError{RemainingTTL: ttl}
+		}
 	}
 
 	ctx, cancel := context.WithCancel(ops.ctx)
+	donec := make(chan struct{})
+	s := &Session{client: client, opts: ops, id: id, ctx: ctx, cancel: cancel, donec: donec}
+
+	if !ops.keepAlive {
+		go s.monitorLease(donec, ttl)
+		return s, nil
+	}
+
 	keepAlive, err := client.KeepAlive(ctx, id)
 	if err != nil || keepAlive == nil {
 		cancel()
 		return nil, err
 	}
 
-	donec := make(chan struct{})
-	s := &Session{client: client, opts: ops, id: id, ctx: ctx, cancel: cancel, donec: donec}
-
 	// keep the lease alive until client error or cancelled context
 	go func() {
 		defer func() {
@@ -78,6 +114,53 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	return s, nil
 }
 
+// monitorLease closes donec once the lease of a session that does not keep
+// it alive expires, or the session is orphaned. The lease is checked each
+// time its last known TTL, in seconds, runs out, as its owner may keep it
+// alive meanwhile.
+func (s *Session) monitorLease(donec chan<- struct{}, ttl int64) {
+	defer func() {
+		close(donec)
+		s.cancel()
+	}()
+	// the TTL is rounded down to the second, so the lease is checked at
+	// least every half second
+	const minWait = 500 * time.Millisecond
+	wait := max(time.Duration(ttl)*time.Second, minWait)
+	for {
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-s.ctx.Done():
+			t.Stop()
+			return
+		}
+		ttl, err := leaseRemainingTTL(s.ctx, s.client, s.id)
+		switch {
+		case errors.Is(err, ErrLeaseNotFound):
+			return
+		case err != nil:
+			// retry shortly on transient errors
+			wait = minWait
+		default:
+			wait = max(time.Duration(ttl)*time.Second, minWait)
+		}
+	}
+}
+
+// leaseRemainingTTL returns the remaining TTL of the lease in seconds, or
+// ErrLeaseNotFound if it does not exist.
+func leaseRemainingTTL(ctx context.Context, client *v3.Client, id v3.LeaseID) (int64, error) {
+	resp, err := client.TimeToLive(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if resp.TTL < 0 {
+		return 0, ErrLeaseNotFound
+	}
+	return resp.TTL, nil
+}
+
 // Client is the etcd client that is attached to the session.
 func (s *Session) Client() *v3.Client {
 	return s.client
@@ -96,6 +179,13 @@ func (s *Session) Ctx() context.Context {
 // is otherwise no longer being refreshed.
 func (s *Session) Done() <-chan struct{} { return s.donec }
 
+// RemainingTTL returns the remaining TTL of the session lease in seconds, as
+// seen by the server. It returns ErrLeaseNotFound if the lease has expired
+// or was revoked. This is useful for health checks.
+func (s *Session) RemainingTTL(ctx context.Context) (int64, error) {
+	return leaseRemainingTTL(ctx, s.client, s.id)
+}
+
 // Orphan ends the refresh for the session lease. This is useful
 // in case the state of the client connection is indeterminate (revoke
 // would fail) or when transferring lease ownership.
@@ -104,9 +194,13 @@ func (s *Session) Orphan() {
 	<-s.donec
 }
 
-// Close orphans the session and revokes the session lease.
+// Close orphans the session and revokes the session lease. The lease of a
+// session created with WithAdoptKeepAlive(false) is left to its owner.
 func (s *Session) Close() error {
 	s.Orphan()
+	if !s.opts.keepAlive {
+		return nil
+	}
 	// if revoke takes longer than the ttl, lease is expired anyway
 	ctx, cancel := context.WithTimeout(s.opts.ctx, time.Duration(s.opts.ttl)*time.Second)
 	_, err := s.client.Revoke(ctx, s.id)
@@ -115,9 +209,13 @@ func (s *Session) Close() error {
 }
 
 type sessionOptions struct {
-	ttl     int
-	leaseID v3.LeaseID
-	ctx     context.Context
+	ttl       int
+	leaseID   v3.LeaseID
+	adopt     bool
+	validate  bool
+	minTTL    int
+	keepAlive bool
+	ctx       context.Context
 }
 
 // SessionOption configures Session.
@@ -137,13 +235,54 @@ func WithTTL(ttl int) SessionOption {
 
 // WithLease specifies the existing leaseID to be used for the session.
 // This is useful in process restart scenario, for example, to reclaim
-// leadership from an election prior to restart.
+// leadership from an election prior to restart. NewSession returns
+// ErrLeaseNotFound if the lease does not exist, or a *LeaseExpiringError if
+// it is about to expire, see WithMinLeaseTTL and WithLeaseValidation.
 func WithLease(leaseID v3.LeaseID) SessionOption {
 	return func(so *sessionOptions, _ *zap.Logger) {
 		so.leaseID = leaseID
 	}
 }
 
+// WithAdoptLease takes over the existing leaseID from its current holder
+// and uses it for the session. Keep alives from the previous holder are
+// rejected afterwards, so its sessions on the lease end. This is useful in
+// blue/green deployments, where the new instance should inherit the keys
+// and election leadership bound to the old instance's lease.
+func WithAdoptLease(leaseID v3.LeaseID) SessionOption {
+	return func(so *sessionOptions, _ *zap.Logger) {
+		so.leaseID = leaseID
+		so.adopt = true
+	}
+}
+
+// WithMinLeaseTTL sets the minimum remaining TTL in seconds of the lease set
+// by WithLease. NewSession returns a *LeaseExpiringError if the lease expires
+// sooner. By default, the lease must not have expired yet.
+func WithMinLeaseTTL(ttl int) SessionOption {
+	return func(so *sessionOptions, _ *zap.Logger) {
+		so.minTTL = ttl
+	}
+}
+
+// WithLeaseValidation sets whether NewSession checks the lease set by
+// WithLease, which it does by default.
+func WithLeaseValidation(validate bool) SessionOption {
+	return func(so *sessionOptions, _ *zap.Logger) {
+		so.validate = validate
+	}
+}
+
+// WithAdoptKeepAlive sets whether the session keeps its lease alive, which it
+// does by default. A session on a lease set by WithLease that is kept alive
+// elsewhere can skip it. Such a session ends once the lease expires, and its
+// Close does not revoke the lease.
+func WithAdoptKeepAlive(keepAlive bool) SessionOption {
+	return func(so *sessionOptions, _ *zap.Logger) {
+		so.keepAlive = keepAlive
+	}
+}
+
 // WithContext assigns a context to the session instead of defaulting to
 // using the client context. This is useful for canceling NewSession and
 // Close operations immediately without having to close the client. If the
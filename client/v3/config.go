@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 
@@ -33,6 +34,23 @@ type Config struct {
 	// 0 disables auto-sync. By default auto-sync is disabled.
 	AutoSyncInterval time.Duration `json:"auto-sync-interval"`
 
+	// DiscoverySRV is the domain whose DNS SRV records list the endpoints of
+	// the cluster, as with the --discovery-srv flag of etcdctl. It is used to
+	// re-resolve the endpoints every AutoSyncSRVInterval.
+	DiscoverySRV string `json:"discovery-srv"`
+
+	// DiscoverySRVName is the suffix of the service name of the SRV records,
+	// as with the --discovery-srv-name flag of etcdctl.
+	DiscoverySRVName string `json:"discovery-srv-name"`
+
+	// AutoSyncSRVInterval is the interval to replace the endpoints with those
+	// of the SRV records of DiscoverySRV, so that a long lived client follows
+	// the members replaced behind them. The endpoints are kept if the records
+	// cannot be resolved. If AutoSyncInterval is set too, the records are
+	// only resolved while syncing with the member list fails, for instance
+	// once every endpoint is gone. 0 disables it.
+	AutoSyncSRVInterval time.Duration `json:"auto-sync-srv-interval"`
+
 	// DialTimeout is the timeout used for certain operations, such as fetching
 	// an authentication token and checking the cluster version (when
 	// RejectOldCluster is true). It is also used to derive the initial
@@ -76,6 +94,18 @@ type Config struct {
 	// Token is a JWT used for authentication instead of a password.
 	Token string `json:"token"`
 
+	// TokenTTL is the time to live of the tokens the client gets from the
+	// server with Username and Password, so that it refreshes them before they
+	// expire. It is only needed for simple tokens, as the expiry of a JWT is
+	// read from its exp claim. If 0, simple tokens are only refreshed once the
+	// server rejects them.
+	TokenTTL time.Duration `json:"token-ttl"`
+
+	// TokenRefreshFraction is the fraction of the time to live of a token after
+	// which the client re-authenticates in the background, between 0 and 1.
+	// Defaults to 0.75.
+	TokenRefreshFraction float64 `json:"token-refresh-fraction"`
+
 	// RejectOldCluster when set will refuse to create a client against an outdated cluster.
 	RejectOldCluster bool `json:"reject-old-cluster"`
 
@@ -109,9 +139,98 @@ type Config struct {
 	// BackoffJitterFraction is the jitter fraction to randomize backoff wait time.
 	BackoffJitterFraction float64 `json:"backoff-jitter-fraction"`
 
+	// RetryPolicy configures the retries of reads, writes and server streams
+	// separately. It takes precedence over MaxUnaryRetries and
+	// BackoffWaitBetween. The fields left zero keep the default behavior.
+	RetryPolicy RetryPolicies `json:"retry-policy"`
+
+	// CompressionCodec is the codec used to compress the messages of unary and
+	// stream calls: "gzip", "zstd" or "none", the default. Servers respond with
+	// the same codec if they support it. Servers only accept zstd if started
+	// with --enable-grpc-zstd-compression; if a server does not support the
+	// codec, the call is retried uncompressed once and the client stops
+	// compressing from then on.
+	CompressionCodec string `json:"compression-codec"`
+
+	// EnableEndpointHealthCheck ejects an endpoint from the rotation of
+	// requests after a few consecutive unary requests to it timed out or found
+	// it unavailable, even if its connection is up. An ejected endpoint is
+	// probed every second with a serializable range request and readmitted once
+	// it answers. Streams already open on an ejected endpoint are left alone.
+	EnableEndpointHealthCheck bool `json:"enable-endpoint-health-check"`
+
+	// WatchObserver, if set, is notified when watchers are created, receive
+	// events, fail and are canceled, so that applications can record metrics
+	// or traces of their watches.
+	WatchObserver WatchObserver
+
+	// LeaseStallHandler, if set, is called when a lease kept alive with
+	// KeepAlive has not received a keep alive response for
+	// LeaseStallFraction of its TTL, before the client assumes it expired.
+	LeaseStallHandler LeaseStallHandler `json:"-"`
+
+	// LeaseStallFraction is the fraction of the TTL of a lease without keep
+	// alive response after which LeaseStallHandler is called, between 0 and
+	// 1. Defaults to 2/3.
+	LeaseStallFraction float64 `json:"lease-stall-fraction"`
+
+	// UnaryInterceptors are chained below the retry interceptors of the
+	// client, in order, so that they are called for every attempt of a unary
+	// request rather than once per request. Use them to record metrics or
+	// traces instead of passing interceptors in DialOptions, which would
+	// replace the interceptors of the client.
+	UnaryInterceptors []grpc.UnaryClientInterceptor `json:"-"`
+
+	// StreamInterceptors are chained below the retry interceptors of the
+	// client, in order, so that they are called for every attempt to open a
+	// stream.
+	StreamInterceptors []grpc.StreamClientInterceptor `json:"-"`
+
+	// TracerProvider, if set, traces unary requests with a span per request,
+	// whatever the number of attempts, annotated with the etcd method, the
+	// key of the request and the revision of the response.
+	TracerProvider trace.TracerProvider `json:"-"`
+
+	// RedactKeys records the SHA-256 of the keys in the spans of
+	// TracerProvider instead of the keys.
+	RedactKeys bool `json:"redact-keys"`
+
+	// WatchRetryBackoff configures the waits of the watchers before they
+	// reconnect when their stream fails because the endpoint is unavailable.
+	WatchRetryBackoff WatchRetryBackoff `json:"watch-retry-backoff"`
+
 	// TODO: support custom balancer picker
 }
 
+// WatchRetryBackoff configures the exponential backoff of the reconnections
+// of the watchers. A zero field keeps the default behavior.
+type WatchRetryBackoff struct {
+	// Min is the wait before the first reconnection, increased by 25% after
+	// every following one that fails. Defaults to 1ms.
+	Min time.Duration `json:"min"`
+
+	// Max is the maximum wait between two reconnections. Defaults to 100ms.
+	Max time.Duration `json:"max"`
+
+	// JitterFraction randomizes each wait by up to this fraction, so that the
+	// watchers of many clients do not all reconnect at once after a server
+	// restart. Defaults to no jitter.
+	JitterFraction float64 `json:"jitter-fraction"`
+}
+
+// withDefaults returns b with the defaults for the fields left zero.
+func (b WatchRetryBackoff) withDefaults() WatchRetryBackoff {
+	if b.Min <= 0 {
+		b.Min = defaultWatchRetryBackoffMin
+	}
+	if b.Max <= 0 {
+		b.Max = max(defaultWatchRetryBackoffMax, b.Min)
+	}
+	b.Max = max(b.Max, b.Min)
+	b.JitterFraction = max(b.JitterFraction, 0)
+	return b
+}
+
 // ConfigSpec is the configuration from users, which comes from command-line flags,
 // environment variables or config file. It is a fully declarative configuration,
 // and can be serialized & deserialized to/from JSON.
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	grpccredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
@@ -67,6 +68,10 @@ type perRPCCredential struct {
 func (rc *perRPCCredential) RequireTransportSecurity() bool { return false }
 
 func (rc *perRPCCredential) GetRequestMetadata(ctx context.Context, s ...string) (map[string]string, error) {
+	// a token set on the request itself overrides the one of the client
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(rpctypes.TokenFieldNameGRPC)) > 0 {
+		return nil, nil
+	}
 	rc.authTokenMu.RLock()
 	authToken := rc.authToken
 	rc.authTokenMu.RUnlock()
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
 
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
@@ -34,3 +35,12 @@ func TestUpdateAuthToken(t *testing.T) {
 	metadataAfterUpdate, _ := bundle.PerRPCCredentials().GetRequestMetadata(ctx)
 	assert.Equal(t, "abcdefg", metadataAfterUpdate[rpctypes.TokenFieldNameGRPC])
 }
+
+func TestRequestAuthTokenOverride(t *testing.T) {
+	bundle := NewPerRPCCredentialBundle()
+	bundle.UpdateAuthToken("abcdefg")
+	ctx := metadata.AppendToOutgoingContext(t.Context(), rpctypes.TokenFieldNameGRPC, "hijklmn")
+
+	md, _ := bundle.PerRPCCredentials().GetRequestMetadata(ctx)
+	assert.Empty(t, md)
+}
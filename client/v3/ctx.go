@@ -37,6 +37,37 @@ func WithRequireLeader(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, copied)
 }
 
+// WithTargetMember makes a gRPC proxy forward the maintenance Status and
+// HashKV requests made with the returned context to the given upstream member,
+// identified by name or hexadecimal ID, instead of any member it is connected
+// to. It has no effect on requests served by etcd members directly.
+func WithTargetMember(ctx context.Context, member string) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok { // no outgoing metadata ctx key, create one
+		md = metadata.Pairs(rpctypes.MetadataTargetMemberKey, member)
+		return metadata.NewOutgoingContext(ctx, md)
+	}
+	copied := md.Copy() // avoid racey updates
+	copied.Set(rpctypes.MetadataTargetMemberKey, member)
+	return metadata.NewOutgoingContext(ctx, copied)
+}
+
+// WithAuthToken returns a context whose requests, and the watchers created
+// with it, are authenticated with the given token instead of the credentials
+// of the client. The token is not refreshed once it expires.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, callAuthKey{}, &callAuth{token: token})
+}
+
+// WithUser returns a context whose requests, and the watchers created with it,
+// are authenticated as the given user instead of with the credentials of the
+// client. The user is authenticated on demand, and its token is cached by the
+// client for the requests of the same user, and refreshed once it expires.
+// This lets a single client act on behalf of several users.
+func WithUser(ctx context.Context, name, password string) context.Context {
+	return context.WithValue(ctx, callAuthKey{}, &callAuth{user: name, password: password})
+}
+
 // embeds client version
 func withVersion(ctx context.Context) context.Context {
 	md, ok := metadata.FromOutgoingContext(ctx)
@@ -0,0 +1,266 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// defragHealthPollInterval is the interval at which DefragmentCluster polls
+// the status of a defragmented member until it is healthy again.
+// non-const so modifiable by tests
+var defragHealthPollInterval = 100 * time.Millisecond
+
+// DefragmentReport is the outcome of the defragmentation of a member by
+// DefragmentCluster.
+type DefragmentReport struct {
+	MemberID  uint64
+	Name      string
+	Endpoint  string
+	IsLearner bool
+	IsLeader  bool
+
+	// Skipped is set if the member was not defragmented, because of
+	// WithDefragDryRun or WithDefragSkipLeader, or because DefragmentCluster
+	// stopped before reaching it.
+	Skipped bool
+
+	// DbSizeBefore and DbSizeInUseBefore are the sizes of the backend
+	// database of the member before it was defragmented, and DbSizeAfter and
+	// DbSizeInUseAfter once it is healthy again.
+	DbSizeBefore      int64
+	DbSizeInUseBefore int64
+	DbSizeAfter       int64
+	DbSizeInUseAfter  int64
+
+	// Duration is the time the defragmentation took, and RecoveryDuration
+	// the time the member then took to apply the entries it missed.
+	Duration         time.Duration
+	RecoveryDuration time.Duration
+
+	// Err is the error that stopped DefragmentCluster at this member.
+	Err error
+}
+
+type defragClusterOptions struct {
+	pause      time.Duration
+	skipLeader bool
+	dryRun     bool
+}
+
+// DefragmentClusterOption configures DefragmentCluster.
+type DefragmentClusterOption func(*defragClusterOptions)
+
+// WithDefragPause makes DefragmentCluster wait for d after a member is
+// healthy again before defragmenting the next one.
+func WithDefragPause(d time.Duration) DefragmentClusterOption {
+	return func(o *defragClusterOptions) { o.pause = d }
+}
+
+// WithDefragSkipLeader makes DefragmentCluster skip the leader, so that the
+// cluster does not stall while the leader is defragmented.
+func WithDefragSkipLeader() DefragmentClusterOption {
+	return func(o *defragClusterOptions) { o.skipLeader = true }
+}
+
+// WithDefragDryRun makes DefragmentCluster check the members and report the
+// order it would defragment them in, without defragmenting any.
+func WithDefragDryRun() DefragmentClusterOption {
+	return func(o *defragClusterOptions) { o.dryRun = true }
+}
+
+func (m *maintenance) DefragmentCluster(ctx context.Context, opts ...DefragmentClusterOption) ([]DefragmentReport, error) {
+	var o defragClusterOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if m.members == nil {
+		return nil, errors.New("etcdclient: DefragmentCluster requires the member list of a client")
+	}
+	members, err := m.members(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch etcd cluster member list: %w", err)
+	}
+
+	reports, endpoints, err := m.defragPlan(ctx, members)
+	if err != nil {
+		return reports, err
+	}
+
+	defragmented := 0
+	for i := range reports {
+		r := &reports[i]
+		if o.dryRun || (o.skipLeader && r.IsLeader) {
+			continue
+		}
+		if defragmented > 0 && o.pause > 0 {
+			select {
+			case <-time.After(o.pause):
+			case <-ctx.Done():
+				return reports, ctx.Err()
+			}
+		}
+		if err := m.defragmentMember(ctx, r, endpoints); err != nil {
+			r.Err = err
+			return reports, fmt.Errorf("failed to defragment member %x (%s): %w", r.MemberID, r.Endpoint, err)
+		}
+		defragmented++
+	}
+	return reports, nil
+}
+
+// defragPlan returns the reports of the members in the order they are to be
+// defragmented, learners first and the leader last, along with the endpoint
+// of each member. It fails unless every member is reachable and the cluster
+// has a leader, as defragmenting a member of a degraded cluster may lose its
+// quorum.
+func (m *maintenance) defragPlan(ctx context.Context, members []*pb.Member) ([]DefragmentReport, map[uint64]string, error) {
+	// the endpoints of the client are preferred to the client URLs the
+	// members advertise, which may not be reachable from the client
+	byID := make(map[uint64]EndpointStatus)
+	statuses, _ := m.StatusAll(ctx)
+	for _, st := range statuses {
+		if st.Err != nil {
+			continue
+		}
+		if _, ok := byID[st.Status.Header.MemberId]; !ok {
+			byID[st.Status.Header.MemberId] = st
+		}
+	}
+
+	reports := make([]DefragmentReport, len(members))
+	endpoints := make(map[uint64]string, len(members))
+	var leader uint64
+	var unhealthy error
+	for i, mem := range members {
+		r := &reports[i]
+		*r = DefragmentReport{MemberID: mem.ID, Name: mem.Name, IsLearner: mem.IsLearner, Skipped: true}
+		st, ok := byID[mem.ID]
+		if !ok {
+			st = m.memberStatus(ctx, mem)
+		}
+		r.Endpoint = st.Endpoint
+		if st.Err != nil {
+			r.Err = st.Err
+			if unhealthy == nil {
+				unhealthy = fmt.Errorf("member %x is not healthy: %w", mem.ID, st.Err)
+			}
+			continue
+		}
+		endpoints[mem.ID] = st.Endpoint
+		r.DbSizeBefore, r.DbSizeInUseBefore = st.Status.DbSize, st.Status.DbSizeInUse
+		if st.Status.Leader != 0 {
+			leader = st.Status.Leader
+		}
+	}
+
+	rank := func(r DefragmentReport) int {
+		switch {
+		case r.IsLearner:
+			return 0
+		case r.MemberID == leader:
+			return 2
+		}
+		return 1
+	}
+	for i := range reports {
+		reports[i].IsLeader = reports[i].MemberID == leader
+	}
+	sort.SliceStable(reports, func(i, j int) bool { return rank(reports[i]) < rank(reports[j]) })
+
+	switch {
+	case unhealthy != nil:
+		return reports, nil, unhealthy
+	case leader == 0:
+		return reports, nil, errors.New("etcd cluster has no leader")
+	}
+	return reports, endpoints, nil
+}
+
+// memberStatus returns the status of the first client URL of the member
+// that answers.
+func (m *maintenance) memberStatus(ctx context.Context, mem *pb.Member) EndpointStatus {
+	if len(mem.ClientURLs) == 0 {
+		return EndpointStatus{Err: errors.New("member has not started")}
+	}
+	var st EndpointStatus
+	for _, ep := range mem.ClientURLs {
+		resp, err := m.Status(ctx, ep)
+		st = EndpointStatus{Endpoint: ep, Status: resp, Err: err}
+		if err == nil {
+			break
+		}
+	}
+	return st
+}
+
+// defragmentMember defragments the member of the report, and waits until it
+// has a leader and has applied the entries committed by the leader while it
+// was blocked.
+func (m *maintenance) defragmentMember(ctx context.Context, r *DefragmentReport, endpoints map[uint64]string) error {
+	r.Skipped = false
+	start := time.Now()
+	if _, err := m.Defragment(ctx, r.Endpoint); err != nil {
+		return err
+	}
+	r.Duration = time.Since(start)
+
+	start = time.Now()
+	var commitIndex uint64
+	for {
+		st, err := m.Status(ctx, r.Endpoint)
+		if err == nil && st.Leader != 0 {
+			if commitIndex == 0 {
+				commitIndex = m.leaderCommitIndex(ctx, st, endpoints)
+			}
+			if commitIndex != 0 && st.RaftAppliedIndex >= commitIndex {
+				r.RecoveryDuration = time.Since(start)
+				r.DbSizeAfter, r.DbSizeInUseAfter = st.DbSize, st.DbSizeInUse
+				return nil
+			}
+		}
+		select {
+		case <-time.After(defragHealthPollInterval):
+		case <-ctx.Done():
+			if err == nil {
+				err = errors.New("member did not catch up with the leader")
+			}
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		}
+	}
+}
+
+// leaderCommitIndex returns the commit index of the leader of the member
+// with the given status, or 0 if it cannot be fetched.
+func (m *maintenance) leaderCommitIndex(ctx context.Context, st *StatusResponse, endpoints map[uint64]string) uint64 {
+	if st.Leader == st.Header.MemberId {
+		return st.RaftIndex
+	}
+	ep, ok := endpoints[st.Leader]
+	if !ok {
+		return 0
+	}
+	lst, err := m.Status(ctx, ep)
+	if err != nil {
+		return 0
+	}
+	return lst.RaftIndex
+}
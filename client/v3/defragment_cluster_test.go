@@ -0,0 +1,208 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// defragCluster is a fake cluster whose members fall behind the leader while
+// they are defragmented, and catch up one entry per status request.
+type defragCluster struct {
+	mu          sync.Mutex
+	leader      uint64
+	commitIndex uint64
+	members     map[string]*defragMember
+	// defragmented are the IDs of the members defragmented, in order.
+	defragmented []uint64
+}
+
+type defragMember struct {
+	id      uint64
+	applied uint64
+	dbSize  int64
+	down    bool
+	// stuck members never catch up.
+	stuck bool
+}
+
+type defragMaintenanceClient struct {
+	pb.MaintenanceClient
+	c        *defragCluster
+	endpoint string
+}
+
+func (c *defragMaintenanceClient) member() (*defragMember, error) {
+	m, ok := c.c.members[c.endpoint]
+	if !ok || m.down {
+		return nil, errors.New("connection refused")
+	}
+	return m, nil
+}
+
+func (c *defragMaintenanceClient) Status(context.Context, *pb.StatusRequest, ...grpc.CallOption) (*pb.StatusResponse, error) {
+	c.c.mu.Lock()
+	defer c.c.mu.Unlock()
+	m, err := c.member()
+	if err != nil {
+		return nil, err
+	}
+	if m.applied < c.c.commitIndex && !m.stuck {
+		m.applied++
+	}
+	return &pb.StatusResponse{
+		Header:           &pb.ResponseHeader{MemberId: m.id},
+		Leader:           c.c.leader,
+		RaftIndex:        c.c.commitIndex,
+		RaftAppliedIndex: m.applied,
+		DbSize:           m.dbSize,
+		DbSizeInUse:      m.dbSize / 2,
+	}, nil
+}
+
+func (c *defragMaintenanceClient) Defragment(context.Context, *pb.DefragmentRequest, ...grpc.CallOption) (*pb.DefragmentResponse, error) {
+	c.c.mu.Lock()
+	defer c.c.mu.Unlock()
+	m, err := c.member()
+	if err != nil {
+		return nil, err
+	}
+	c.c.defragmented = append(c.c.defragmented, m.id)
+	m.dbSize /= 2
+	// the rest of the cluster moves on while the member is blocked
+	c.c.commitIndex += 3
+	for _, other := range c.c.members {
+		if other != m && !other.stuck {
+			other.applied = c.c.commitIndex
+		}
+	}
+	return &pb.DefragmentResponse{}, nil
+}
+
+// newDefragCluster returns a cluster of a leader "a", a follower "b" and a
+// learner "c", listed in that order, and a maintenance whose client only has
+// the endpoint of the leader.
+func newDefragCluster() (*defragCluster, *maintenance) {
+	c := &defragCluster{
+		leader:      1,
+		commitIndex: 10,
+		members: map[string]*defragMember{
+			"a": {id: 1, applied: 10, dbSize: 100},
+			"b": {id: 2, applied: 10, dbSize: 200},
+			"c": {id: 3, applied: 10, dbSize: 300},
+		},
+	}
+	members := []*pb.Member{
+		{ID: 1, Name: "a", ClientURLs: []string{"a"}},
+		{ID: 2, Name: "b", ClientURLs: []string{"x", "b"}},
+		{ID: 3, Name: "c", ClientURLs: []string{"c"}, IsLearner: true},
+	}
+	m := &maintenance{
+		dial: func(endpoint string) (pb.MaintenanceClient, func(), error) {
+			return &defragMaintenanceClient{c: c, endpoint: endpoint}, func() {}, nil
+		},
+		endpoints: func() []string { return []string{"a"} },
+		members:   func(context.Context) ([]*pb.Member, error) { return members, nil },
+	}
+	return c, m
+}
+
+func TestDefragmentCluster(t *testing.T) {
+	oldInterval := defragHealthPollInterval
+	defer func() { defragHealthPollInterval = oldInterval }()
+	defragHealthPollInterval = time.Millisecond
+
+	c, m := newDefragCluster()
+	reports, err := m.DefragmentCluster(t.Context())
+	require.NoError(t, err)
+
+	// learners first, the leader last
+	assert.Equal(t, []uint64{3, 2, 1}, c.defragmented)
+	require.Len(t, reports, 3)
+	var eps []string
+	for _, r := range reports {
+		eps = append(eps, r.Endpoint)
+		assert.False(t, r.Skipped)
+		require.NoError(t, r.Err)
+		assert.Equal(t, r.DbSizeBefore/2, r.DbSizeAfter)
+		assert.Equal(t, r.DbSizeAfter/2, r.DbSizeInUseAfter)
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, eps)
+	assert.True(t, reports[0].IsLearner)
+	assert.True(t, reports[2].IsLeader)
+	for _, mem := range c.members {
+		assert.Equal(t, c.commitIndex, mem.applied)
+	}
+}
+
+func TestDefragmentClusterOptions(t *testing.T) {
+	oldInterval := defragHealthPollInterval
+	defer func() { defragHealthPollInterval = oldInterval }()
+	defragHealthPollInterval = time.Millisecond
+
+	c, m := newDefragCluster()
+	reports, err := m.DefragmentCluster(t.Context(), WithDefragDryRun())
+	require.NoError(t, err)
+	assert.Empty(t, c.defragmented)
+	for _, r := range reports {
+		assert.True(t, r.Skipped)
+		assert.NotZero(t, r.DbSizeBefore)
+	}
+
+	start := time.Now()
+	reports, err = m.DefragmentCluster(t.Context(), WithDefragSkipLeader(), WithDefragPause(50*time.Millisecond))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 2}, c.defragmented)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.True(t, reports[2].IsLeader)
+	assert.True(t, reports[2].Skipped)
+}
+
+func TestDefragmentClusterUnhealthy(t *testing.T) {
+	oldInterval := defragHealthPollInterval
+	defer func() { defragHealthPollInterval = oldInterval }()
+	defragHealthPollInterval = time.Millisecond
+
+	c, m := newDefragCluster()
+	c.members["b"].down = true
+	reports, err := m.DefragmentCluster(t.Context())
+	require.ErrorContains(t, err, "member 2 is not healthy")
+	assert.Empty(t, c.defragmented)
+	require.Len(t, reports, 3)
+	assert.Equal(t, uint64(2), reports[1].MemberID)
+	require.Error(t, reports[1].Err)
+
+	// a member that does not catch up stops the roll
+	c.members["b"].down = false
+	c.members["c"].stuck = true
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	reports, err = m.DefragmentCluster(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []uint64{3}, c.defragmented)
+	require.ErrorIs(t, reports[0].Err, context.DeadlineExceeded)
+	assert.True(t, reports[1].Skipped)
+	assert.True(t, reports[2].Skipped)
+}
@@ -0,0 +1,289 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics implements client side checks of the behavior of an
+// etcd cluster, meant to find bugs in production rather than to be relied on.
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// DiscrepancyKind is the kind of a difference between the state built from
+// the watch stream and the state read by a re-list.
+type DiscrepancyKind string
+
+const (
+	// MissingKey is a key the re-list read that the watch stream never
+	// created, or deleted since.
+	MissingKey DiscrepancyKind = "missing key"
+	// StaleValue is a key whose latest modification the watch stream did
+	// not deliver.
+	StaleValue DiscrepancyKind = "stale value"
+	// PhantomKey is a key the watch stream holds that the re-list did not
+	// read, because its deletion was not delivered.
+	PhantomKey DiscrepancyKind = "phantom key"
+)
+
+// Discrepancy is a difference between the state built from the watch stream
+// and the state read by a re-list, which persisted after the watch stream
+// had been received past the revision of the re-list.
+type Discrepancy struct {
+	Kind DiscrepancyKind
+	Key  []byte
+	// Listed is the key-value pair read by the re-list, nil for a phantom
+	// key.
+	Listed *mvccpb.KeyValue
+	// Watched is the key-value pair built from the watch stream when the
+	// discrepancy was confirmed, nil for a missing key.
+	Watched *mvccpb.KeyValue
+	// ListRevision is the revision of the re-list that found the
+	// discrepancy.
+	ListRevision int64
+	// WatchRevision is the revision the watch stream had been received up
+	// to when the discrepancy was confirmed, at least ListRevision.
+	WatchRevision int64
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s %q: listed %s at revision %d, watched %s up to revision %d",
+		d.Kind, d.Key, describeKV(d.Listed), d.ListRevision, describeKV(d.Watched), d.WatchRevision)
+}
+
+func describeKV(kv *mvccpb.KeyValue) string {
+	if kv == nil {
+		return "nothing"
+	}
+	return fmt.Sprintf("mod revision %d version %d", kv.ModRevision, kv.Version)
+}
+
+// VerifiedWatcher watches a prefix and periodically re-lists it, to check
+// that the state built from the watch stream matches the state of the
+// cluster.
+type VerifiedWatcher struct {
+	c             *clientv3.Client
+	prefix        string
+	checkInterval time.Duration
+
+	// shadow is the state of the prefix built from the watch stream. Keys
+	// deleted since the last check are kept as tombstones, which only
+	// carry the revision of the deletion.
+	shadow map[string]*shadowKV
+	// watchRev is the revision the watch stream has been received up to.
+	watchRev int64
+	// pending are the discrepancies found by the last checks, confirmed
+	// once the watch stream is received past their list revision.
+	pending map[string]Discrepancy
+}
+
+type shadowKV struct {
+	kv      *mvccpb.KeyValue
+	deleted bool
+	// modRev is the revision of the last event of the key.
+	modRev int64
+}
+
+// NewVerifiedWatcher returns a VerifiedWatcher of the keys with the given
+// prefix, re-listed every checkInterval.
+func NewVerifiedWatcher(c *clientv3.Client, prefix string, checkInterval time.Duration) *VerifiedWatcher {
+	return &VerifiedWatcher{
+		c:             c,
+		prefix:        prefix,
+		checkInterval: checkInterval,
+		shadow:        make(map[string]*shadowKV),
+		pending:       make(map[string]Discrepancy),
+	}
+}
+
+// Run lists the prefix, watches it from the revision of the list, and checks
+// the state built from the watch stream against a re-list every check
+// interval, until the context is done or the watch fails.
+//
+// A re-list races the events in flight on the watch stream, so a difference
+// it finds is only reported to the callback if the next check finds that
+// the watch stream, received past the revision of the re-list, still did not
+// deliver the listed state. The state of the key is then set to the listed
+// one, so that each discrepancy is reported once.
+func (vw *VerifiedWatcher) Run(ctx context.Context, report func(Discrepancy)) error {
+	kvs, rev, err := vw.list(ctx)
+	if err != nil {
+		return err
+	}
+	for _, kv := range kvs {
+		vw.shadow[string(kv.Key)] = &shadowKV{kv: kv, modRev: kv.ModRevision}
+	}
+	vw.watchRev = rev
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch := vw.c.Watch(wctx, vw.prefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1), clientv3.WithProgressNotify())
+
+	ticker := time.NewTicker(vw.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case wresp, ok := <-wch:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return errors.New("diagnostics: watch channel closed")
+			}
+			if err := wresp.Err(); err != nil {
+				return fmt.Errorf("diagnostics: watch failed: %w", err)
+			}
+			vw.apply(wresp)
+
+		case <-ticker.C:
+			if err := vw.check(ctx, report); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
+			}
+			// a progress notification moves the watch revision forward
+			// even if the prefix is not modified, so that the
+			// discrepancies found by this check can be confirmed. It is
+			// best effort, a failed request only delays the next check.
+			_ = vw.c.RequestProgress(wctx)
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (vw *VerifiedWatcher) list(ctx context.Context) ([]*mvccpb.KeyValue, int64, error) {
+	it := vw.c.NewPagedGet(vw.prefix, clientv3.WithPrefix(), clientv3.WithStrictRevision())
+	var kvs []*mvccpb.KeyValue
+	for !it.Done() {
+		page, err := it.Next(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("diagnostics: failed to list %q: %w", vw.prefix, err)
+		}
+		kvs = append(kvs, page.Kvs...)
+	}
+	return kvs, it.Revision(), nil
+}
+
+func (vw *VerifiedWatcher) apply(wresp clientv3.WatchResponse) {
+	for _, ev := range wresp.Events {
+		switch ev.Type {
+		case mvccpb.Event_PUT:
+			vw.shadow[string(ev.Kv.Key)] = &shadowKV{kv: ev.Kv, modRev: ev.Kv.ModRevision}
+		case mvccpb.Event_DELETE:
+			vw.shadow[string(ev.Kv.Key)] = &shadowKV{deleted: true, modRev: ev.Kv.ModRevision}
+		}
+	}
+	vw.watchRev = max(vw.watchRev, wresp.Header.Revision)
+}
+
+// check confirms the pending discrepancies the watch stream has been
+// received past, then re-lists the prefix to find new ones.
+func (vw *VerifiedWatcher) check(ctx context.Context, report func(Discrepancy)) error {
+	for key, d := range vw.pending {
+		if vw.watchRev < d.ListRevision {
+			continue
+		}
+		delete(vw.pending, key)
+		if vw.delivered(d) {
+			continue
+		}
+		d.Watched = vw.watched(key)
+		d.WatchRevision = vw.watchRev
+		report(d)
+		// the listed state is the latest one the key is known to have, so
+		// that the discrepancy is reported once
+		if d.Kind == PhantomKey {
+			delete(vw.shadow, key)
+		} else {
+			vw.shadow[key] = &shadowKV{kv: d.Listed, modRev: d.Listed.ModRevision}
+		}
+	}
+
+	kvs, rev, err := vw.list(ctx)
+	if err != nil {
+		return err
+	}
+	listed := make(map[string]struct{}, len(kvs))
+	for _, kv := range kvs {
+		key := string(kv.Key)
+		listed[key] = struct{}{}
+		switch s := vw.shadow[key]; {
+		case s == nil || s.deleted:
+			vw.addPending(Discrepancy{Kind: MissingKey, Key: kv.Key, Listed: kv, ListRevision: rev})
+		case s.kv.ModRevision != kv.ModRevision:
+			vw.addPending(Discrepancy{Kind: StaleValue, Key: kv.Key, Listed: kv, ListRevision: rev})
+		case !bytes.Equal(s.kv.Value, kv.Value):
+			// the same revision with another value is a bug of its own,
+			// confirmed on the next check like any other
+			vw.addPending(Discrepancy{Kind: StaleValue, Key: kv.Key, Listed: kv, ListRevision: rev})
+		}
+	}
+	for key, s := range vw.shadow {
+		if _, ok := listed[key]; ok {
+			continue
+		}
+		if s.deleted {
+			// tombstones are only needed to confirm the discrepancies
+			// of their key, and the deletions after this re-list
+			if _, ok := vw.pending[key]; !ok && s.modRev <= rev {
+				delete(vw.shadow, key)
+			}
+			continue
+		}
+		vw.addPending(Discrepancy{Kind: PhantomKey, Key: []byte(key), Watched: s.kv, ListRevision: rev})
+	}
+	return nil
+}
+
+// addPending records a discrepancy, unless one of the same key is already
+// waiting for the watch stream.
+func (vw *VerifiedWatcher) addPending(d Discrepancy) {
+	if _, ok := vw.pending[string(d.Key)]; !ok {
+		vw.pending[string(d.Key)] = d
+	}
+}
+
+// delivered returns whether the watch stream has delivered the listed state
+// of the key of the discrepancy, or a later event of the key.
+func (vw *VerifiedWatcher) delivered(d Discrepancy) bool {
+	s := vw.shadow[string(d.Key)]
+	if d.Kind == PhantomKey {
+		// the key was not listed, so it was deleted at or before the
+		// list revision, past the last event the shadow holds
+		return s == nil || s.deleted || s.modRev != d.Watched.ModRevision
+	}
+	if s == nil {
+		return false
+	}
+	if s.modRev > d.Listed.ModRevision {
+		return true
+	}
+	return !s.deleted && s.modRev == d.Listed.ModRevision && bytes.Equal(s.kv.Value, d.Listed.Value)
+}
+
+func (vw *VerifiedWatcher) watched(key string) *mvccpb.KeyValue {
+	if s := vw.shadow[key]; s != nil && !s.deleted {
+		return s.kv
+	}
+	return nil
+}
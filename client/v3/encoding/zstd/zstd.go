@@ -0,0 +1,112 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zstd implements a gRPC compressor using zstd at its fastest level,
+// which costs much less CPU than gzip for a comparable ratio on etcd payloads.
+//
+// Unlike google.golang.org/grpc/encoding/gzip, importing the package does not
+// register the compressor, so that servers only accept zstd if configured to.
+// Call Register to install it.
+package zstd
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the name the compressor is registered with, which is sent as the
+// grpc-encoding of compressed messages.
+const Name = "zstd"
+
+var registerOnce sync.Once
+
+// Register installs the zstd compressor in the gRPC compressor registry of
+// the process. It is safe to call more than once. Like
+// encoding.RegisterCompressor, it must be called before any gRPC server or
+// client of the process is started.
+func Register() {
+	registerOnce.Do(func() {
+		encoding.RegisterCompressor(&compressor{})
+	})
+}
+
+type compressor struct {
+	encoders sync.Pool // *writer
+	decoders sync.Pool // *reader
+}
+
+func (c *compressor) Name() string { return Name }
+
+func (c *compressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if z, ok := c.encoders.Get().(*writer); ok {
+		z.Reset(w)
+		return z, nil
+	}
+	// a concurrency of 1 encodes synchronously without spawning goroutines
+	enc, err := zstd.NewWriter(w,
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(1),
+		zstd.WithLowerEncoderMem(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *compressor) Decompress(r io.Reader) (io.Reader, error) {
+	if z, ok := c.decoders.Get().(*reader); ok {
+		if err := z.Reset(r); err != nil {
+			c.decoders.Put(z)
+			return nil, err
+		}
+		return z, nil
+	}
+	// a concurrency of 1 decodes synchronously without spawning goroutines
+	dec, err := zstd.NewReader(r,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderLowmem(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &reader{Decoder: dec, pool: &c.decoders}, nil
+}
+
+type writer struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (z *writer) Close() error {
+	defer z.pool.Put(z)
+	return z.Encoder.Close()
+}
+
+type reader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (z *reader) Read(p []byte) (n int, err error) {
+	n, err = z.Decoder.Read(p)
+	if errors.Is(err, io.EOF) {
+		z.pool.Put(z)
+	}
+	return n, err
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zstd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestRegister(t *testing.T) {
+	require.Nil(t, encoding.GetCompressor(Name))
+	Register()
+	Register()
+	c := encoding.GetCompressor(Name)
+	require.NotNil(t, c)
+	require.Equal(t, Name, c.Name())
+}
+
+func TestCompressorRoundTrip(t *testing.T) {
+	c := &compressor{}
+	msg := bytes.Repeat([]byte(`{"key":"/registry/pods/default/foo","value":"bar"}`), 1000)
+
+	// run twice to reuse the pooled encoder and decoder
+	for range 2 {
+		var buf bytes.Buffer
+		w, err := c.Compress(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(msg)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.Less(t, buf.Len(), len(msg)/10)
+
+		r, err := c.Decompress(&buf)
+		require.NoError(t, err)
+		got, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, msg, got)
+	}
+
+	r, err := c.Decompress(bytes.NewReader([]byte("not zstd")))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.Error(t, err)
+}
@@ -0,0 +1,258 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/v3/internal/healthbalancer"
+)
+
+// non-const so modifiable by tests
+var (
+	// endpointEjectFailures is the number of consecutive failed requests after
+	// which an endpoint is ejected from the rotation.
+	endpointEjectFailures = 3
+	// endpointProbeInterval is the interval at which an ejected endpoint is
+	// probed, and the timeout of a probe.
+	endpointProbeInterval = time.Second
+)
+
+// endpointHealthDecay is the weight of the previous requests in the error
+// rate and latency averages of an endpoint.
+const endpointHealthDecay = 0.9
+
+var (
+	endpointEjections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "client",
+		Name:      "endpoint_ejections_total",
+		Help:      "The total number of times an endpoint was ejected from the rotation by the endpoint health check.",
+	}, []string{"endpoint"})
+	endpointReadmissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "client",
+		Name:      "endpoint_readmissions_total",
+		Help:      "The total number of times an ejected endpoint was readmitted into the rotation by the endpoint health check.",
+	}, []string{"endpoint"})
+	endpointEjected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "client",
+		Name:      "endpoint_ejected",
+		Help:      "Whether the endpoint is ejected from the rotation by the endpoint health check (1) or not (0).",
+	}, []string{"endpoint"})
+)
+
+// RegisterEndpointHealthMetrics registers the metrics of the endpoint health
+// check enabled by Config.EnableEndpointHealthCheck with reg. They are not
+// registered by default, so that importing the client does not add metrics
+// to the default registry.
+func RegisterEndpointHealthMetrics(reg prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{endpointEjections, endpointReadmissions, endpointEjected} {
+		if err := reg.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// endpointHealth tracks the outcome of the unary requests sent to each
+// endpoint, and ejects the endpoints that keep failing from the rotation
+// until they answer a probe.
+type endpointHealth struct {
+	c *Client
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointStats
+}
+
+type endpointStats struct {
+	// failures is the number of consecutive failed requests.
+	failures  int
+	errorRate float64
+	latency   time.Duration
+	ejected   bool
+	// suspectUntil is the time until which the endpoint is out of the
+	// rotation after SuspectEndpoint.
+	suspectUntil time.Time
+}
+
+func newEndpointHealth(c *Client) *endpointHealth {
+	return &endpointHealth{c: c, endpoints: make(map[string]*endpointStats)}
+}
+
+// isEndpointHealthFailure returns whether the error of a request shows the
+// endpoint is not serving, rather than the request being rejected.
+func isEndpointHealthFailure(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func (h *endpointHealth) Report(addr string, err error, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st, ok := h.endpoints[addr]
+	if !ok {
+		st = &endpointStats{}
+		h.endpoints[addr] = st
+	}
+	if !isEndpointHealthFailure(err) {
+		st.failures = 0
+		st.errorRate *= endpointHealthDecay
+		st.latency = time.Duration(endpointHealthDecay*float64(st.latency) + (1-endpointHealthDecay)*float64(latency))
+		return
+	}
+	st.failures++
+	st.errorRate = endpointHealthDecay*st.errorRate + (1 - endpointHealthDecay)
+	if st.ejected || st.failures < endpointEjectFailures {
+		return
+	}
+	st.ejected = true
+	h.c.GetLogger().Warn(
+		"ejecting endpoint from the rotation",
+		zap.String("endpoint", addr),
+		zap.Int("consecutive-failures", st.failures),
+		zap.Float64("error-rate", st.errorRate),
+		zap.Duration("latency", st.latency),
+		zap.Error(err),
+	)
+	endpointEjections.WithLabelValues(addr).Inc()
+	endpointEjected.WithLabelValues(addr).Set(1)
+	go h.probe(addr)
+}
+
+func (h *endpointHealth) Ejected(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st, ok := h.endpoints[addr]
+	return ok && (st.ejected || time.Now().Before(st.suspectUntil))
+}
+
+// suspect takes addr out of the rotation for d.
+func (h *endpointHealth) suspect(addr string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st, ok := h.endpoints[addr]
+	if !ok {
+		st = &endpointStats{}
+		h.endpoints[addr] = st
+	}
+	if until := time.Now().Add(d); until.After(st.suspectUntil) {
+		st.suspectUntil = until
+	}
+	h.c.GetLogger().Info("suspecting endpoint", zap.String("endpoint", addr), zap.Duration("duration", d))
+}
+
+// WithServingEndpoint returns a context whose requests set *addr to the
+// address of the endpoint they are sent to, for every attempt, so that the
+// endpoint that served a bad response can be passed to SuspectEndpoint. The
+// address is left unchanged until the client is connected to an endpoint.
+func WithServingEndpoint(ctx context.Context, addr *string) context.Context {
+	return healthbalancer.WithPickRecorder(ctx, func(a string) { *addr = a })
+}
+
+// SuspectEndpoint takes the endpoint with the given address, as set by
+// WithServingEndpoint, out of the rotation of requests for d, unless every
+// endpoint is out of it. Wrappers of the client use it once an endpoint served
+// a bad response, for instance a stale revision.
+func (c *Client) SuspectEndpoint(addr string, d time.Duration) {
+	if c.endpointHealth != nil {
+		c.endpointHealth.suspect(addr, d)
+	}
+}
+
+func (h *endpointHealth) SetAddresses(addrs []string) {
+	keep := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		keep[addr] = struct{}{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for addr, st := range h.endpoints {
+		if _, ok := keep[addr]; ok {
+			continue
+		}
+		if st.ejected {
+			endpointEjected.DeleteLabelValues(addr)
+		}
+		delete(h.endpoints, addr)
+	}
+}
+
+// probe sends a serializable range request to the ejected endpoint at every
+// endpointProbeInterval, until it answers or is no longer an endpoint.
+func (h *endpointHealth) probe(addr string) {
+	ticker := time.NewTicker(endpointProbeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-h.c.ctx.Done():
+			return
+		}
+		if !h.Ejected(addr) {
+			return
+		}
+		ctx, cancel := context.WithTimeout(healthbalancer.WithProbe(h.c.ctx, addr), endpointProbeInterval)
+		ctx = WithRetryPolicy(ctx, RetryPolicy{MaxAttempts: 1})
+		_, err := pb.NewKVClient(h.c.conn).Range(ctx, &pb.RangeRequest{Key: []byte("health"), Serializable: true}, grpc.WaitForReady(false))
+		cancel()
+		if isEndpointHealthFailure(err) {
+			continue
+		}
+		h.readmit(addr)
+		return
+	}
+}
+
+func (h *endpointHealth) readmit(addr string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st, ok := h.endpoints[addr]
+	if !ok || !st.ejected {
+		return
+	}
+	st.ejected = false
+	st.failures = 0
+	h.c.GetLogger().Info("readmitting endpoint into the rotation", zap.String("endpoint", addr))
+	endpointReadmissions.WithLabelValues(addr).Inc()
+	endpointEjected.WithLabelValues(addr).Set(0)
+}
+
+// endpointHealthUnaryInterceptor returns a unary client interceptor reporting
+// the outcome of every attempt of a call to the endpoint health tracker.
+// Streams are not tracked, as a stream lasts as long as it is needed and
+// usually ends by being canceled.
+func (c *Client) endpointHealthUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(healthbalancer.WithTracking(ctx), method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func TestEndpointHealthEject(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	// stop the probes right away
+	cancel()
+	c := NewCtxClient(ctx)
+	h := newEndpointHealth(c)
+	unavailable := status.Error(codes.Unavailable, "injected")
+
+	// a success resets the count of consecutive failures
+	for range endpointEjectFailures - 1 {
+		h.Report("a", unavailable, time.Millisecond)
+	}
+	h.Report("a", nil, time.Millisecond)
+	h.Report("a", unavailable, time.Millisecond)
+	assert.False(t, h.Ejected("a"))
+
+	// errors returned by the server do not count
+	for range endpointEjectFailures {
+		h.Report("b", rpctypes.ErrGRPCKeyNotFound, time.Millisecond)
+	}
+	assert.False(t, h.Ejected("b"))
+
+	for range endpointEjectFailures {
+		h.Report("c", context.DeadlineExceeded, time.Millisecond)
+	}
+	assert.True(t, h.Ejected("c"))
+	assert.InDelta(t, 1.0, testutil.ToFloat64(endpointEjected.WithLabelValues("c")), 0)
+
+	h.readmit("c")
+	assert.False(t, h.Ejected("c"))
+	assert.InDelta(t, 0.0, testutil.ToFloat64(endpointEjected.WithLabelValues("c")), 0)
+
+	for range endpointEjectFailures {
+		h.Report("c", unavailable, time.Millisecond)
+	}
+	assert.True(t, h.Ejected("c"))
+	h.SetAddresses([]string{"a", "b"})
+	assert.False(t, h.Ejected("c"))
+}
+
+func TestRegisterEndpointHealthMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, RegisterEndpointHealthMetrics(reg))
+	for _, c := range []prometheus.Collector{endpointEjections, endpointReadmissions, endpointEjected} {
+		assert.True(t, reg.Unregister(c), "expected the collector to be registered")
+	}
+}
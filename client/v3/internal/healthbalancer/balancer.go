@@ -0,0 +1,221 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package healthbalancer implements a round robin load balancing policy that
+// skips the endpoints a Tracker ejected because they keep failing, even if
+// their connection is up.
+package healthbalancer
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/endpointsharding"
+	"google.golang.org/grpc/balancer/pickfirst"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// Name is the name of the load balancing policy. It reads the Tracker from
+// the attributes of the resolver state, set with SetTracker.
+const Name = "etcd_health_round_robin"
+
+func init() {
+	balancer.Register(builder{})
+}
+
+// Tracker decides which endpoints are out of the rotation from the outcome
+// of the RPCs sent to them.
+type Tracker interface {
+	// Report records the outcome of an RPC sent to the address with a context
+	// returned by WithTracking.
+	Report(addr string, err error, latency time.Duration)
+	// Ejected returns whether the address is out of the rotation.
+	Ejected(addr string) bool
+	// SetAddresses is called with the addresses of the resolver state, so
+	// that the tracker forgets about the others.
+	SetAddresses(addrs []string)
+}
+
+type trackerKey struct{}
+
+// SetTracker returns attrs with the tracker of the balancer.
+func SetTracker(attrs *attributes.Attributes, t Tracker) *attributes.Attributes {
+	return attrs.WithValue(trackerKey{}, t)
+}
+
+func getTracker(state resolver.State) Tracker {
+	t, _ := state.Attributes.Value(trackerKey{}).(Tracker)
+	return t
+}
+
+type trackingKey struct{}
+
+// WithTracking returns a context whose RPCs have their outcome reported to
+// the tracker.
+func WithTracking(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trackingKey{}, true)
+}
+
+func isTracked(ctx context.Context) bool {
+	tracked, _ := ctx.Value(trackingKey{}).(bool)
+	return tracked
+}
+
+type probeKey struct{}
+
+// WithProbe returns a context whose RPCs are sent to the address even if it is
+// ejected, and fail if it is not ready. Their outcome is not reported to the
+// tracker.
+func WithProbe(ctx context.Context, addr string) context.Context {
+	return context.WithValue(ctx, probeKey{}, addr)
+}
+
+func probeFromContext(ctx context.Context) (string, bool) {
+	addr, ok := ctx.Value(probeKey{}).(string)
+	return addr, ok
+}
+
+type pickRecorderKey struct{}
+
+// WithPickRecorder returns a context whose RPCs call record with the address
+// they are sent to, every time they are.
+func WithPickRecorder(ctx context.Context, record func(addr string)) context.Context {
+	return context.WithValue(ctx, pickRecorderKey{}, record)
+}
+
+func recordPick(ctx context.Context, addr string) {
+	if record, ok := ctx.Value(pickRecorderKey{}).(func(string)); ok {
+		record(addr)
+	}
+}
+
+type builder struct{}
+
+func (builder) Name() string { return Name }
+
+func (builder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	hcc := &healthClientConn{ClientConn: cc}
+	return &healthBalancer{
+		Balancer: endpointsharding.NewBalancer(hcc, opts, balancer.Get(pickfirst.Name).Build, endpointsharding.Options{}),
+		cc:       hcc,
+	}
+}
+
+// healthBalancer connects to every endpoint like round_robin does, and
+// replaces the round robin picker with one that skips the ejected endpoints.
+type healthBalancer struct {
+	balancer.Balancer
+	cc *healthClientConn
+}
+
+func (b *healthBalancer) UpdateClientConnState(ccs balancer.ClientConnState) error {
+	b.cc.tracker = getTracker(ccs.ResolverState)
+	if b.cc.tracker != nil {
+		var addrs []string
+		for _, ep := range ccs.ResolverState.Endpoints {
+			for _, addr := range ep.Addresses {
+				addrs = append(addrs, addr.Addr)
+			}
+		}
+		b.cc.tracker.SetAddresses(addrs)
+	}
+	return b.Balancer.UpdateClientConnState(balancer.ClientConnState{
+		ResolverState: pickfirst.EnableHealthListener(ccs.ResolverState),
+	})
+}
+
+// healthClientConn is only called by gRPC synchronously with the balancer, so
+// its tracker needs no lock.
+type healthClientConn struct {
+	balancer.ClientConn
+	tracker Tracker
+}
+
+func (cc *healthClientConn) UpdateState(state balancer.State) {
+	if state.ConnectivityState == connectivity.Ready && cc.tracker != nil {
+		p := &healthPicker{tracker: cc.tracker}
+		for _, cs := range endpointsharding.ChildStatesFromPicker(state.Picker) {
+			if cs.State.ConnectivityState != connectivity.Ready || len(cs.Endpoint.Addresses) == 0 {
+				continue
+			}
+			p.children = append(p.children, healthChild{addr: cs.Endpoint.Addresses[0].Addr, picker: cs.State.Picker})
+		}
+		if len(p.children) > 0 {
+			p.next.Store(rand.Uint32())
+			state.Picker = p
+		}
+	}
+	cc.ClientConn.UpdateState(state)
+}
+
+type healthChild struct {
+	addr   string
+	picker balancer.Picker
+}
+
+type healthPicker struct {
+	tracker  Tracker
+	children []healthChild
+	next     atomic.Uint32
+}
+
+func (p *healthPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	if addr, ok := probeFromContext(info.Ctx); ok {
+		for _, c := range p.children {
+			if c.addr == addr {
+				return c.picker.Pick(info)
+			}
+		}
+		return balancer.PickResult{}, status.Errorf(codes.Unavailable, "endpoint %s is not ready", addr)
+	}
+
+	c := p.pickChild()
+	res, err := c.picker.Pick(info)
+	if err != nil {
+		return res, err
+	}
+	recordPick(info.Ctx, c.addr)
+	if !isTracked(info.Ctx) {
+		return res, nil
+	}
+	start := time.Now()
+	done := res.Done
+	res.Done = func(di balancer.DoneInfo) {
+		p.tracker.Report(c.addr, di.Err, time.Since(start))
+		if done != nil {
+			done(di)
+		}
+	}
+	return res, nil
+}
+
+// pickChild returns the next child in the rotation that is not ejected, or
+// the next child if all of them are, so that the client keeps working.
+func (p *healthPicker) pickChild() healthChild {
+	n := uint32(len(p.children))
+	start := p.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		if c := p.children[(start+i)%n]; !p.tracker.Ejected(c.addr) {
+			return c
+		}
+	}
+	return p.children[start%n]
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthbalancer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeTracker struct {
+	ejected map[string]bool
+	reports []string
+}
+
+func (t *fakeTracker) Report(addr string, err error, latency time.Duration) {
+	t.reports = append(t.reports, addr)
+}
+func (t *fakeTracker) Ejected(addr string) bool    { return t.ejected[addr] }
+func (t *fakeTracker) SetAddresses(addrs []string) {}
+
+// fakePicker returns the address of its endpoint in the metadata of the
+// picks.
+type fakePicker struct{ addr string }
+
+func (p fakePicker) Pick(balancer.PickInfo) (balancer.PickResult, error) {
+	return balancer.PickResult{Metadata: metadata.Pairs("addr", p.addr)}, nil
+}
+
+func newTestPicker(t *fakeTracker, addrs ...string) *healthPicker {
+	p := &healthPicker{tracker: t}
+	for _, addr := range addrs {
+		p.children = append(p.children, healthChild{addr: addr, picker: fakePicker{addr: addr}})
+	}
+	return p
+}
+
+func TestHealthPickerSkipsEjected(t *testing.T) {
+	tr := &fakeTracker{ejected: map[string]bool{"b": true}}
+	p := newTestPicker(tr, "a", "b", "c")
+
+	picked := make(map[string]int)
+	for range 10 {
+		picked[p.pickChild().addr]++
+	}
+	assert.Zero(t, picked["b"])
+	assert.Equal(t, 10, picked["a"]+picked["c"])
+
+	// all ejected: keep sending requests rather than failing them
+	tr.ejected = map[string]bool{"a": true, "b": true, "c": true}
+	picked = make(map[string]int)
+	for range 9 {
+		picked[p.pickChild().addr]++
+	}
+	assert.Equal(t, map[string]int{"a": 3, "b": 3, "c": 3}, picked)
+}
+
+func TestHealthPickerReports(t *testing.T) {
+	tr := &fakeTracker{}
+	p := newTestPicker(tr, "a")
+
+	res, err := p.Pick(balancer.PickInfo{Ctx: context.Background()})
+	require.NoError(t, err)
+	assert.Nil(t, res.Done)
+
+	res, err = p.Pick(balancer.PickInfo{Ctx: WithTracking(context.Background())})
+	require.NoError(t, err)
+	res.Done(balancer.DoneInfo{Err: errors.New("injected")})
+	assert.Equal(t, []string{"a"}, tr.reports)
+}
+
+func TestHealthPickerRecordsPicks(t *testing.T) {
+	p := newTestPicker(&fakeTracker{}, "a", "b")
+	var picked []string
+	ctx := WithPickRecorder(context.Background(), func(addr string) { picked = append(picked, addr) })
+	for range 4 {
+		_, err := p.Pick(balancer.PickInfo{Ctx: ctx})
+		require.NoError(t, err)
+	}
+	assert.ElementsMatch(t, []string{"a", "a", "b", "b"}, picked)
+}
+
+func TestHealthPickerProbe(t *testing.T) {
+	tr := &fakeTracker{ejected: map[string]bool{"b": true}}
+	p := newTestPicker(tr, "a", "b")
+
+	for range 4 {
+		res, err := p.Pick(balancer.PickInfo{Ctx: WithTracking(WithProbe(context.Background(), "b"))})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b"}, res.Metadata.Get("addr"))
+		assert.Nil(t, res.Done, "probes must not be reported")
+	}
+
+	_, err := p.Pick(balancer.PickInfo{Ctx: WithProbe(context.Background(), "c")})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -15,11 +15,14 @@
 package resolver
 
 import (
+	"fmt"
+
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
 	"google.golang.org/grpc/serviceconfig"
 
 	"go.etcd.io/etcd/client/v3/internal/endpoint"
+	"go.etcd.io/etcd/client/v3/internal/healthbalancer"
 )
 
 const (
@@ -32,6 +35,8 @@ type EtcdManualResolver struct {
 	*manual.Resolver
 	endpoints     []string
 	serviceConfig *serviceconfig.ParseResult
+	// tracker, if set, selects the healthbalancer policy.
+	tracker healthbalancer.Tracker
 }
 
 func New(endpoints ...string) *EtcdManualResolver {
@@ -39,9 +44,19 @@ func New(endpoints ...string) *EtcdManualResolver {
 	return &EtcdManualResolver{Resolver: r, endpoints: endpoints, serviceConfig: nil}
 }
 
+// SetHealthTracker makes the connections built by the resolver skip the
+// endpoints ejected by the tracker. It must be called before Build.
+func (r *EtcdManualResolver) SetHealthTracker(t healthbalancer.Tracker) {
+	r.tracker = t
+}
+
 // Build returns itself for Resolver, because it's both a builder and a resolver.
 func (r *EtcdManualResolver) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
-	r.serviceConfig = cc.ParseServiceConfig(`{"loadBalancingPolicy": "round_robin"}`)
+	policy := "round_robin"
+	if r.tracker != nil {
+		policy = healthbalancer.Name
+	}
+	r.serviceConfig = cc.ParseServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy": %q}`, policy))
 	if r.serviceConfig.Err != nil {
 		return nil, r.serviceConfig.Err
 	}
@@ -72,6 +87,9 @@ func (r EtcdManualResolver) updateState() {
 			Endpoints:     eps,
 			ServiceConfig: r.serviceConfig,
 		}
+		if r.tracker != nil {
+			state.Attributes = healthbalancer.SetTracker(state.Attributes, r.tracker)
+		}
 		r.UpdateState(state)
 	}
 }
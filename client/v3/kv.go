@@ -15,17 +15,26 @@
 package clientv3
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/golang/protobuf/proto" //nolint:staticcheck // TODO: remove for a supported version
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
+// errMaxResponseBytesCountOnly is returned by a Get with both
+// WithMaxResponseBytes and WithCountOnly, whose response has no key-value
+// pairs to bound.
+var errMaxResponseBytesCountOnly = errors.New("etcdclient: WithMaxResponseBytes cannot be combined with WithCountOnly")
+
 type (
 	CompactResponse pb.CompactionResponse
 	PutResponse     pb.PutResponse
@@ -42,6 +51,25 @@ type KV interface {
 	// To get a string of bytes, do string([]byte{0x10, 0x20}).
 	Put(ctx context.Context, key, val string, opts ...OpOption) (*PutResponse, error)
 
+	// PutWithConfirm puts a key-value pair into etcd like Put, but when the
+	// Put fails with an error that leaves it unknown whether the write was
+	// applied (e.g. the connection broke or the server timed out after the
+	// request was sent), it reads the key with a linearizable Get to find out.
+	// If the key holds the value and lease of the Put, the write is considered
+	// applied and the returned response header carries the mod revision of the
+	// key; otherwise the Put is retried. The original error is returned if ctx
+	// is done or the key cannot be read.
+	//
+	// The check compares content only: if the key already held the same value
+	// and lease, or another writer concurrently put identical content, the
+	// write is reported as applied even if this Put was not. A Put that timed
+	// out on the server may also still be applied after the check, so a
+	// retried write can be applied twice. PrevKv is not set in a confirmed
+	// response. The Put is made at most 5 times, waiting 100ms before the
+	// first retry and twice as long before each following one, after which
+	// the last error is returned even if ctx is not done.
+	PutWithConfirm(ctx context.Context, key, val string, opts ...OpOption) (*PutResponse, error)
+
 	// Get retrieves keys.
 	// By default, Get will return the value for "key", if any.
 	// When passed WithRange(end), Get will return the keys in the range [key, end).
@@ -135,6 +163,15 @@ func (resp *TxnResponse) OpResponse() OpResponse {
 	return OpResponse{txn: resp}
 }
 
+const (
+	// putWithConfirmMaxAttempts bounds the number of Puts made by
+	// PutWithConfirm.
+	putWithConfirmMaxAttempts = 5
+	// putWithConfirmBackoff is the wait of PutWithConfirm before its first
+	// retry, doubled before each following one.
+	putWithConfirmBackoff = 100 * time.Millisecond
+)
+
 type kv struct {
 	remote   pb.KVClient
 	callOpts []grpc.CallOption
@@ -161,6 +198,72 @@ func (kv *kv) Put(ctx context.Context, key, val string, opts ...OpOption) (*PutR
 	return r.put, ContextError(ctx, err)
 }
 
+func (kv *kv) PutWithConfirm(ctx context.Context, key, val string, opts ...OpOption) (*PutResponse, error) {
+	op := OpPut(key, val, opts...)
+	backoff := backoffExponentialWithJitter(putWithConfirmBackoff, defaultBackoffJitterFraction)
+	for attempt := uint(1); ; attempt++ {
+		resp, err := kv.Put(ctx, key, val, opts...)
+		if err == nil || ctx.Err() != nil || !isAmbiguousPutError(err) {
+			return resp, err
+		}
+		resp, cerr := kv.confirmPut(ctx, op)
+		if cerr != nil {
+			return nil, err
+		}
+		if resp != nil {
+			return resp, nil
+		}
+		if attempt == putWithConfirmMaxAttempts {
+			return nil, err
+		}
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// confirmPut returns a response for the put op if the key holds its value
+// and lease, or nil if it does not.
+func (kv *kv) confirmPut(ctx context.Context, op Op) (*PutResponse, error) {
+	resp, err := kv.Get(ctx, string(op.key))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	got := resp.Kvs[0]
+	if !op.ignoreValue && !bytes.Equal(got.Value, op.val) {
+		return nil, nil
+	}
+	if !op.ignoreLease && LeaseID(got.Lease) != op.leaseID {
+		return nil, nil
+	}
+	return &PutResponse{Header: &pb.ResponseHeader{
+		ClusterId: resp.Header.ClusterId,
+		MemberId:  resp.Header.MemberId,
+		Revision:  got.ModRevision,
+		RaftTerm:  resp.Header.RaftTerm,
+	}}, nil
+}
+
+// isAmbiguousPutError returns "true" when a Put that failed with err may
+// still have been applied.
+func isAmbiguousPutError(err error) bool {
+	eErr := rpctypes.Error(err)
+	if errors.Is(eErr, rpctypes.ErrTimeout) ||
+		errors.Is(eErr, rpctypes.ErrTimeoutDueToLeaderFail) ||
+		errors.Is(eErr, rpctypes.ErrTimeoutDueToConnectionLost) {
+		return true
+	}
+	ev, ok := status.FromError(err)
+	return ok && (ev.Code() == codes.Unavailable || ev.Code() == codes.DeadlineExceeded)
+}
+
 func (kv *kv) Get(ctx context.Context, key string, opts ...OpOption) (*GetResponse, error) {
 	r, err := kv.Do(ctx, OpGet(key, opts...))
 	return r.get, ContextError(ctx, err)
@@ -216,7 +319,11 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 	case tRange:
 		if op.IsSortOptionValid() {
 			var resp *pb.RangeResponse
-			resp, err = kv.remote.Range(ctx, op.toRangeRequest(), kv.callOpts...)
+			if op.maxResponseBytes > 0 {
+				resp, err = kv.boundedRange(ctx, op)
+			} else {
+				resp, err = kv.remote.Range(ctx, op.toRangeRequest(), kv.callOpts...)
+			}
 			if err == nil {
 				return OpResponse{get: (*GetResponse)(resp)}, nil
 			}
@@ -248,3 +355,62 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 	}
 	return OpResponse{}, ContextError(ctx, err)
 }
+
+// boundedRange gets the range of op in pages, all read at the revision of the
+// first one, until the key-value pairs read add up to op.maxResponseBytes. It
+// returns the pairs that fit, at least one, with More set if the range has
+// more keys.
+func (kv *kv) boundedRange(ctx context.Context, op Op) (*pb.RangeResponse, error) {
+	if op.countOnly {
+		return nil, errMaxResponseBytesCountOnly
+	}
+	r := op.toRangeRequest()
+	// a range in key order is paged by key, while a range sorted by another
+	// target is read again from the start with a larger limit
+	byKey := r.SortOrder == pb.RangeRequest_NONE || r.SortTarget == pb.RangeRequest_KEY
+	var resp *pb.RangeResponse
+	size := int64(0)
+	for pageSize := int64(defaultPageSize); ; {
+		r.Limit = pageSize
+		if op.limit > 0 {
+			r.Limit = min(pageSize, op.limit)
+			if byKey && resp != nil {
+				r.Limit = min(pageSize, op.limit-int64(len(resp.Kvs)))
+			}
+		}
+		page, err := kv.remote.Range(ctx, r, kv.callOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			resp = &pb.RangeResponse{Header: page.Header, Count: page.Count}
+			// the following pages are read at the revision of the first one
+			r.Revision, r.Cursor = page.Header.Revision, nil
+		}
+		if !byKey {
+			resp.Kvs, size = nil, 0
+		}
+		for _, pair := range page.Kvs {
+			n := int64(proto.Size(pair))
+			if len(resp.Kvs) != 0 && size+n > op.maxResponseBytes {
+				resp.More = true
+				return resp, nil
+			}
+			resp.Kvs, size = append(resp.Kvs, pair), size+n
+		}
+		if !page.More || len(page.Kvs) == 0 || (op.limit > 0 && int64(len(resp.Kvs)) >= op.limit) {
+			resp.More = page.More
+			return resp, nil
+		}
+
+		last := page.Kvs[len(page.Kvs)-1].Key
+		switch {
+		case !byKey:
+			pageSize *= 2
+		case r.SortOrder == pb.RangeRequest_DESCEND:
+			r.RangeEnd = last
+		default:
+			r.Key = append(bytes.Clone(last), '\x00')
+		}
+	}
+}
@@ -39,6 +39,18 @@ type LeaseGrantResponse struct {
 	ID    LeaseID
 	TTL   int64
 	Error string
+	// Epoch is the ownership epoch of the new lease. It is zero if the
+	// server does not support lease transfer.
+	Epoch uint64
+}
+
+// LeaseTransferResponse wraps the protobuf message LeaseTransferResponse.
+type LeaseTransferResponse struct {
+	*pb.ResponseHeader
+	ID  LeaseID
+	TTL int64
+	// Epoch is the ownership epoch of the lease after the transfer.
+	Epoch uint64
 }
 
 // LeaseKeepAliveResponse wraps the protobuf message LeaseKeepAliveResponse.
@@ -106,6 +118,17 @@ func (e ErrKeepAliveHalted) Error() string {
 	return s
 }
 
+// LeaseStallHandler is called when the keep alives of a lease stall, for
+// instance because the network silently drops them, so that the application
+// can fence or shed the work guarded by the lease before it may expire.
+// lastResponse is when the last keep alive response of the lease was
+// received, or when KeepAlive was called if none was. It is called once per
+// stall, from the goroutine of the keep alives, so it must not block. The
+// KeepAlive channels of the lease are left open until the lease is assumed
+// expired, and the handler is called again if the keep alives stall again
+// after a response. It is set with Config.LeaseStallHandler.
+type LeaseStallHandler func(id LeaseID, lastResponse time.Time)
+
 type Lease interface {
 	// Grant creates a new lease.
 	Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error)
@@ -119,6 +142,23 @@ type Lease interface {
 	// Leases retrieves all leases.
 	Leases(ctx context.Context) (*LeaseLeasesResponse, error)
 
+	// Transfer takes over ownership of the given lease and resets its TTL.
+	// Keep alive requests sent by the previous holder are rejected afterwards
+	// and its KeepAlive channels close as if the lease had expired.
+	// Transfer requires cluster version 3.8 or later.
+	Transfer(ctx context.Context, id LeaseID) (*LeaseTransferResponse, error)
+
+	// WatchLeases watches the lifecycle events of the given lease, or of all
+	// leases if id is NoLease, from the time the watch is created. The first
+	// response has no events and is sent once the watch is created.
+	//
+	// Past events are not replayed. If the watch fails, e.g. because the
+	// member loses its leader or the events are not consumed promptly, the
+	// last response carries the error in Err and the channel closes; the
+	// leases should then be listed again before watching anew. The channel
+	// closes without error once ctx is canceled.
+	WatchLeases(ctx context.Context, id LeaseID) LeaseWatchChan
+
 	// KeepAlive attempts to keep the given lease alive forever. If the keepalive responses posted
 	// to the channel are not consumed promptly the channel may become full. When full, the lease
 	// client will continue sending keep alive requests to the etcd server, but will drop responses
@@ -172,6 +212,10 @@ type lessor struct {
 
 	keepAlives map[LeaseID]*keepAlive
 
+	// epochs tracks the ownership epoch of leases granted or transferred
+	// by this client, which is echoed in keep alive requests.
+	epochs map[LeaseID]*leaseEpoch
+
 	// firstKeepAliveTimeout is the timeout for the first keepalive request
 	// before the actual TTL is known to the lease client
 	firstKeepAliveTimeout time.Duration
@@ -181,6 +225,11 @@ type lessor struct {
 
 	callOpts []grpc.CallOption
 
+	// stallHandler is called when the keep alives of a lease stall for
+	// stallFraction of its TTL
+	stallHandler  LeaseStallHandler
+	stallFraction float64
+
 	lg *zap.Logger
 }
 
@@ -192,10 +241,23 @@ type keepAlive struct {
 	deadline time.Time
 	// nextKeepAlive is when to send the next keep alive message
 	nextKeepAlive time.Time
+	// lastResponse is when the last keep alive response was received
+	lastResponse time.Time
+	// stallDeadline is when the keep alives are stalled if no response
+	stallDeadline time.Time
+	// stalled is set once the stall handler was called for the current stall
+	stalled bool
 	// donec is closed on lease revoke, expiration, or cancel.
 	donec chan struct{}
 }
 
+// leaseEpoch is the ownership epoch of a lease held by this client.
+type leaseEpoch struct {
+	epoch uint64
+	// deadline is when the lease is assumed expired if it was not renewed.
+	deadline time.Time
+}
+
 func NewLease(c *Client) Lease {
 	return NewLeaseFromLeaseClient(RetryLeaseClient(c), c, c.cfg.DialTimeout+time.Second)
 }
@@ -204,6 +266,7 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, c *Client, keepAliveTimeout
 	l := &lessor{
 		donec:                 make(chan struct{}),
 		keepAlives:            make(map[LeaseID]*keepAlive),
+		epochs:                make(map[LeaseID]*leaseEpoch),
 		remote:                remote,
 		firstKeepAliveTimeout: keepAliveTimeout,
 	}
@@ -213,6 +276,11 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, c *Client, keepAliveTimeout
 	if c != nil {
 		l.lg = c.GetLogger()
 		l.callOpts = c.callOpts
+		l.stallHandler = c.cfg.LeaseStallHandler
+		l.stallFraction = c.cfg.LeaseStallFraction
+	}
+	if l.stallFraction <= 0 || l.stallFraction >= 1 {
+		l.stallFraction = defaultLeaseStallFraction
 	}
 	reqLeaderCtx := WithRequireLeader(context.Background())
 	l.stopCtx, l.stopCancel = context.WithCancel(reqLeaderCtx)
@@ -228,7 +296,9 @@ func (l *lessor) Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, err
 			ID:             LeaseID(resp.ID),
 			TTL:            resp.TTL,
 			Error:          resp.Error,
+			Epoch:          resp.Epoch,
 		}
+		l.setEpoch(gresp.ID, gresp.Epoch, gresp.TTL)
 		return gresp, nil
 	}
 	return nil, ContextError(ctx, err)
@@ -238,11 +308,51 @@ func (l *lessor) Revoke(ctx context.Context, id LeaseID) (*LeaseRevokeResponse,
 	r := &pb.LeaseRevokeRequest{ID: int64(id)}
 	resp, err := l.remote.LeaseRevoke(ctx, r, l.callOpts...)
 	if err == nil {
+		l.mu.Lock()
+		delete(l.epochs, id)
+		l.mu.Unlock()
 		return (*LeaseRevokeResponse)(resp), nil
 	}
 	return nil, ContextError(ctx, err)
 }
 
+func (l *lessor) Transfer(ctx context.Context, id LeaseID) (*LeaseTransferResponse, error) {
+	r := &pb.LeaseTransferRequest{ID: int64(id)}
+	resp, err := l.remote.LeaseTransfer(ctx, r, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	tresp := &LeaseTransferResponse{
+		ResponseHeader: resp.GetHeader(),
+		ID:             LeaseID(resp.ID),
+		TTL:            resp.TTL,
+		Epoch:          resp.Epoch,
+	}
+	l.setEpoch(tresp.ID, tresp.Epoch, tresp.TTL)
+	return tresp, nil
+}
+
+// setEpoch records the ownership epoch of a lease so later keep alive
+// requests carry it. A zero epoch comes from servers without lease transfer
+// support and is not recorded.
+func (l *lessor) setEpoch(id LeaseID, epoch uint64, ttl int64) {
+	if epoch == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.epochs[id] = &leaseEpoch{epoch: epoch, deadline: time.Now().Add(time.Duration(ttl) * time.Second)}
+}
+
+// epoch returns the recorded ownership epoch of a lease, or zero if there is none.
+// l.mu must be held.
+func (l *lessor) epoch(id LeaseID) uint64 {
+	if e, ok := l.epochs[id]; ok {
+		return e.epoch
+	}
+	return 0
+}
+
 func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption) (*LeaseTimeToLiveResponse, error) {
 	r := toLeaseTimeToLiveRequest(id, opts...)
 	resp, err := l.remote.LeaseTimeToLive(ctx, r, l.callOpts...)
@@ -297,11 +407,14 @@ func (l *lessor) KeepAlive(ctx context.Context, id LeaseID) (<-chan *LeaseKeepAl
 	}
 	if !ok {
 		// create fresh keep alive
+		now := time.Now()
 		ka = &keepAlive{
 			chs:           []chan<- *LeaseKeepAliveResponse{ch},
 			ctxs:          []context.Context{ctx},
-			deadline:      time.Now().Add(l.firstKeepAliveTimeout),
-			nextKeepAlive: time.Now(),
+			deadline:      now.Add(l.firstKeepAliveTimeout),
+			nextKeepAlive: now,
+			lastResponse:  now,
+			stallDeadline: now.Add(l.stallTimeout(l.firstKeepAliveTimeout)),
 			donec:         make(chan struct{}),
 		}
 		l.keepAlives[id] = ka
@@ -435,7 +548,11 @@ func (l *lessor) keepAliveOnce(ctx context.Context, id LeaseID) (karesp *LeaseKe
 		}
 	}()
 
-	err = stream.Send(&pb.LeaseKeepAliveRequest{ID: int64(id)})
+	l.mu.Lock()
+	epoch := l.epoch(id)
+	l.mu.Unlock()
+
+	err = stream.Send(&pb.LeaseKeepAliveRequest{ID: int64(id), Epoch: epoch})
 	if err != nil {
 		return nil, ContextError(ctx, err)
 	}
@@ -539,15 +656,25 @@ func (l *lessor) recvKeepAlive(resp *pb.LeaseKeepAliveResponse) {
 	}
 
 	if karesp.TTL <= 0 {
-		// lease expired; close all keep alive channels
+		// lease expired or transferred; close all keep alive channels
 		delete(l.keepAlives, karesp.ID)
+		delete(l.epochs, karesp.ID)
 		ka.close()
 		return
 	}
 
+	if e, ok := l.epochs[karesp.ID]; ok {
+		e.deadline = time.Now().Add(time.Duration(karesp.TTL) * time.Second)
+	}
+
 	// send update to all channels
-	nextKeepAlive := time.Now().Add((time.Duration(karesp.TTL) * time.Second) / 3.0)
-	ka.deadline = time.Now().Add(time.Duration(karesp.TTL) * time.Second)
+	now := time.Now()
+	ttl := time.Duration(karesp.TTL) * time.Second
+	nextKeepAlive := now.Add(ttl / 3.0)
+	ka.deadline = now.Add(ttl)
+	ka.lastResponse = now
+	ka.stallDeadline = now.Add(l.stallTimeout(ttl))
+	ka.stalled = false
 	for _, ch := range ka.chs {
 		select {
 		case ch <- karesp:
@@ -577,34 +704,59 @@ func (l *lessor) deadlineLoop() {
 			return
 		}
 		now := time.Now()
+		var stalls []leaseStall
 		l.mu.Lock()
 		for id, ka := range l.keepAlives {
 			if ka.deadline.Before(now) {
 				// waited too long for response; lease may be expired
 				ka.close()
 				delete(l.keepAlives, id)
+				continue
+			}
+			if l.stallHandler != nil && !ka.stalled && ka.stallDeadline.Before(now) {
+				ka.stalled = true
+				stalls = append(stalls, leaseStall{id: id, lastResponse: ka.lastResponse})
+			}
+		}
+		for id, e := range l.epochs {
+			if _, ok := l.keepAlives[id]; !ok && e.deadline.Before(now) {
+				// lease is no longer kept alive by this client and has expired
+				delete(l.epochs, id)
 			}
 		}
 		l.mu.Unlock()
+		for _, st := range stalls {
+			l.stallHandler(st.id, st.lastResponse)
+		}
 	}
 }
 
+type leaseStall struct {
+	id           LeaseID
+	lastResponse time.Time
+}
+
+// stallTimeout returns how long the keep alives of a lease with the given
+// TTL can go without response before they are stalled.
+func (l *lessor) stallTimeout(ttl time.Duration) time.Duration {
+	return time.Duration(l.stallFraction * float64(ttl))
+}
+
 // sendKeepAliveLoop sends keep alive requests for the lifetime of the given stream.
 func (l *lessor) sendKeepAliveLoop(stream pb.Lease_LeaseKeepAliveClient) {
 	for {
-		var tosend []LeaseID
+		var tosend []*pb.LeaseKeepAliveRequest
 
 		now := time.Now()
 		l.mu.Lock()
 		for id, ka := range l.keepAlives {
 			if ka.nextKeepAlive.Before(now) {
-				tosend = append(tosend, id)
+				tosend = append(tosend, &pb.LeaseKeepAliveRequest{ID: int64(id), Epoch: l.epoch(id)})
 			}
 		}
 		l.mu.Unlock()
 
-		for _, id := range tosend {
-			r := &pb.LeaseKeepAliveRequest{ID: int64(id)}
+		for _, r := range tosend {
 			if err := stream.Send(r); err != nil {
 				l.lg.Warn("error occurred during lease keep alive request sending",
 					zap.Error(err),
@@ -0,0 +1,103 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+type LeaseEventType = pb.LeaseEvent_EventType
+
+const (
+	LeaseEventGranted = pb.LeaseEvent_GRANTED
+	LeaseEventRevoked = pb.LeaseEvent_REVOKED
+	LeaseEventExpired = pb.LeaseEvent_EXPIRED
+)
+
+// LeaseEvent is a lifecycle event of a lease. An expired lease sends a
+// LeaseEventRevoked event until the cluster version reaches 3.8.
+type LeaseEvent struct {
+	Type LeaseEventType
+	ID   LeaseID
+	// TTL is the granted TTL of the lease in seconds.
+	TTL int64
+}
+
+// LeaseWatchResponse wraps the protobuf message LeaseWatchResponse.
+type LeaseWatchResponse struct {
+	*pb.ResponseHeader
+	Events []LeaseEvent
+
+	closeErr error
+}
+
+// Err is the error the watch failed with, set on the last response before
+// the channel closes.
+func (r *LeaseWatchResponse) Err() error {
+	return r.closeErr
+}
+
+type LeaseWatchChan <-chan LeaseWatchResponse
+
+func (l *lessor) WatchLeases(ctx context.Context, id LeaseID) LeaseWatchChan {
+	ch := make(chan LeaseWatchResponse, LeaseResponseChSize)
+	// a member without leader does not apply the events of the cluster
+	// anymore, so fail the watch rather than silently stall it
+	wctx, cancel := context.WithCancel(WithRequireLeader(ctx))
+	go func() {
+		defer close(ch)
+		defer cancel()
+		stopc := context.AfterFunc(l.stopCtx, cancel)
+		defer stopc()
+
+		send := func(resp LeaseWatchResponse) bool {
+			select {
+			case ch <- resp:
+				return true
+			case <-wctx.Done():
+				return false
+			}
+		}
+		fail := func(err error) {
+			// a watch canceled by the caller or by Close ends without error
+			if wctx.Err() == nil {
+				send(LeaseWatchResponse{closeErr: ContextError(wctx, err)})
+			}
+		}
+
+		stream, err := l.remote.LeaseWatch(wctx, &pb.LeaseWatchRequest{ID: int64(id)}, l.callOpts...)
+		if err != nil {
+			fail(err)
+			return
+		}
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				fail(err)
+				return
+			}
+			wresp := LeaseWatchResponse{ResponseHeader: resp.GetHeader()}
+			for _, ev := range resp.Events {
+				wresp.Events = append(wresp.Events, LeaseEvent{Type: ev.Type, ID: LeaseID(ev.ID), TTL: ev.TTL})
+			}
+			if !send(wresp) {
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -90,6 +90,11 @@ func (lkv *leasingKV) Put(ctx context.Context, key, val string, opts ...v3.OpOpt
 	return lkv.put(ctx, v3.OpPut(key, val, opts...))
 }
 
+// PutWithConfirm is not supported by leasingKV.
+func (lkv *leasingKV) PutWithConfirm(ctx context.Context, key, val string, opts ...v3.OpOption) (*v3.PutResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "PutWithConfirm is not supported by leasingKV")
+}
+
 // GetStream is not supported by leasingKV.
 func (lkv *leasingKV) GetStream(ctx context.Context, key string, opts ...v3.OpOption) (v3.GetStreamChan, error) {
 	return nil, status.Error(codes.Unimplemented, "GetStream is not supported by leasingKV")
@@ -121,6 +121,9 @@ func copyGetResponseMetadataOnly(resp *v3.GetResponse) *v3.GetResponse {
 		Kvs:    nil,
 		More:   resp.More,
 		Count:  resp.Count,
+
+		NextCursor:            bytes.Clone(resp.NextCursor),
+		ConsistencyDowngraded: resp.ConsistencyDowngraded,
 	}
 }
 
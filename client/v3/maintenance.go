@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -60,9 +61,26 @@ type Maintenance interface {
 	// times with different endpoints.
 	Defragment(ctx context.Context, endpoint string) (*DefragmentResponse, error)
 
+	// DefragmentCluster defragments every member of the cluster one at a
+	// time, learners first and the leader last. It waits for each member to
+	// catch up with the leader before moving on to the next one, and does
+	// not start unless every member is reachable and the cluster has a
+	// leader. It returns a report of every member in the order they were
+	// processed, along with the error that stopped it, if any. The context
+	// should have a deadline, as a member that does not catch up is waited
+	// for until the context is done.
+	DefragmentCluster(ctx context.Context, opts ...DefragmentClusterOption) ([]DefragmentReport, error)
+
 	// Status gets the status of the endpoint.
 	Status(ctx context.Context, endpoint string) (*StatusResponse, error)
 
+	// StatusAll gets the status of every endpoint of the client concurrently,
+	// or of every member of the cluster with WithMemberEndpoints. The
+	// statuses are returned in the order of the endpoints, each with its own
+	// error; the returned error is only set if the endpoints could not be
+	// listed.
+	StatusAll(ctx context.Context, opts ...StatusAllOption) ([]EndpointStatus, error)
+
 	// HashKV returns a hash of the KV state at the time of the RPC.
 	// If revision is zero, the hash is computed on all keys. If the revision
 	// is non-zero, the hash is computed on all keys at or below the given revision.
@@ -73,6 +91,15 @@ type Maintenance interface {
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
 	SnapshotWithVersion(ctx context.Context) (*SnapshotResponse, error)
 
+	// SnapshotWithProgress is like SnapshotWithVersion, but also returns a
+	// channel of the progress of the read, closed once the stream is done,
+	// and verifies the sha256 digest at the end of the snapshot. The reader
+	// still returns the digest, but its last read fails with
+	// ErrSnapshotIntegrity if it does not match, or with
+	// ErrSnapshotIncomplete if the stream ended before it. Close returns
+	// that error as well if the stream was done by then.
+	SnapshotWithProgress(ctx context.Context, opts ...SnapshotOption) (*SnapshotResponse, <-chan SnapshotProgress, error)
+
 	// Snapshot provides a reader for a point-in-time snapshot of etcd.
 	// If the context "ctx" is canceled or timed out, reading from returned
 	// "io.ReadCloser" would error out (e.g. context.Canceled, context.DeadlineExceeded).
@@ -89,6 +116,36 @@ type Maintenance interface {
 	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
 }
 
+const defaultStatusAllConcurrency = 8
+
+// EndpointStatus is the status of an endpoint returned by StatusAll.
+type EndpointStatus struct {
+	Endpoint string
+	// Status is nil if the status of the endpoint could not be fetched.
+	Status *StatusResponse
+	Err    error
+}
+
+type statusAllOptions struct {
+	memberEndpoints bool
+	concurrency     int
+}
+
+// StatusAllOption configures StatusAll.
+type StatusAllOption func(*statusAllOptions)
+
+// WithMemberEndpoints makes StatusAll get the status of the client URLs of
+// every member in the member list, instead of the endpoints of the client.
+func WithMemberEndpoints() StatusAllOption {
+	return func(o *statusAllOptions) { o.memberEndpoints = true }
+}
+
+// WithStatusConcurrency sets the number of endpoints StatusAll queries at
+// once. It defaults to 8.
+func WithStatusConcurrency(n int) StatusAllOption {
+	return func(o *statusAllOptions) { o.concurrency = n }
+}
+
 // SnapshotResponse is aggregated response from the snapshot stream.
 // Consumer is responsible for closing steam by calling .Snapshot.Close()
 type SnapshotResponse struct {
@@ -109,6 +166,9 @@ type maintenance struct {
 	dial     func(endpoint string) (pb.MaintenanceClient, func(), error)
 	remote   pb.MaintenanceClient
 	callOpts []grpc.CallOption
+	// endpoints and members list the endpoints queried by StatusAll.
+	endpoints func() []string
+	members   func(ctx context.Context) ([]*pb.Member, error)
 }
 
 func NewMaintenance(c *Client) Maintenance {
@@ -127,6 +187,7 @@ func NewMaintenance(c *Client) Maintenance {
 	}
 	if c != nil {
 		api.callOpts = c.callOpts
+		api.setClient(c)
 	}
 	return api
 }
@@ -141,10 +202,22 @@ func NewMaintenanceFromMaintenanceClient(remote pb.MaintenanceClient, c *Client)
 	if c != nil {
 		api.callOpts = c.callOpts
 		api.lg = c.GetLogger()
+		api.setClient(c)
 	}
 	return api
 }
 
+func (m *maintenance) setClient(c *Client) {
+	m.endpoints = c.Endpoints
+	m.members = func(ctx context.Context) ([]*pb.Member, error) {
+		resp, err := c.MemberList(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Members, nil
+	}
+}
+
 func (m *maintenance) AlarmList(ctx context.Context) (*AlarmResponse, error) {
 	req := &pb.AlarmRequest{
 		Action:   pb.AlarmRequest_GET,
@@ -214,6 +287,48 @@ func (m *maintenance) Status(ctx context.Context, endpoint string) (*StatusRespo
 	return (*StatusResponse)(resp), nil
 }
 
+func (m *maintenance) StatusAll(ctx context.Context, opts ...StatusAllOption) ([]EndpointStatus, error) {
+	o := statusAllOptions{concurrency: defaultStatusAllConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = 1
+	}
+
+	var eps []string
+	switch {
+	case o.memberEndpoints && m.members != nil:
+		members, err := m.members(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch endpoints from etcd cluster member list: %w", err)
+		}
+		for _, mem := range members {
+			eps = append(eps, mem.ClientURLs...)
+		}
+	case m.endpoints != nil:
+		eps = m.endpoints()
+	}
+
+	statuses := make([]EndpointStatus, len(eps))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, o.concurrency)
+	for i, ep := range eps {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp, err := m.Status(ctx, ep)
+			statuses[i] = EndpointStatus{Endpoint: ep, Status: resp, Err: err}
+		}()
+	}
+	wg.Wait()
+	return statuses, nil
+}
+
 func (m *maintenance) HashKV(ctx context.Context, endpoint string, rev int64) (*HashKVResponse, error) {
 	remote, cancel, err := m.dial(endpoint)
 	if err != nil {
@@ -0,0 +1,205 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+var (
+	// ErrSnapshotIntegrity is the error of a snapshot whose sha256 digest
+	// does not match its content.
+	ErrSnapshotIntegrity = errors.New("etcdclient: snapshot integrity check failed")
+	// ErrSnapshotIncomplete is the error of a snapshot stream that ended
+	// before its sha256 digest, for instance on a server error.
+	ErrSnapshotIncomplete = errors.New("etcdclient: snapshot stream ended before its sha256 digest")
+)
+
+// SnapshotProgress is the progress of the read of a snapshot.
+type SnapshotProgress struct {
+	// BytesRead is the number of bytes of the snapshot read so far.
+	BytesRead int64
+	// TotalHint is the size of the snapshot announced by the server,
+	// including its sha256 digest, or 0 if it is unknown.
+	TotalHint int64
+}
+
+type snapshotOptions struct {
+	rateLimit int64
+}
+
+// SnapshotOption configures SnapshotWithProgress.
+type SnapshotOption func(*snapshotOptions)
+
+// WithSnapshotRateLimit limits the read of the snapshot to bytesPerSecond.
+// It is not limited by default.
+func WithSnapshotRateLimit(bytesPerSecond int64) SnapshotOption {
+	return func(o *snapshotOptions) { o.rateLimit = bytesPerSecond }
+}
+
+func (m *maintenance) SnapshotWithProgress(ctx context.Context, opts ...SnapshotOption) (*SnapshotResponse, <-chan SnapshotProgress, error) {
+	var o snapshotOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	ss, err := m.remote.Snapshot(sctx, &pb.SnapshotRequest{}, append(m.callOpts, withMax(defaultStreamMaxRetries))...)
+	if err != nil {
+		cancel()
+		return nil, nil, ContextError(ctx, err)
+	}
+
+	m.lg.Info("opened snapshot stream; downloading")
+	resp, err := ss.Recv()
+	if err != nil {
+		cancel()
+		return nil, nil, ContextError(ctx, err)
+	}
+
+	pr, pw := io.Pipe()
+	rc := &snapshotProgressReadCloser{
+		snapshotReadCloser: snapshotReadCloser{ctx: ctx, ReadCloser: pr},
+		cancel:             cancel,
+		donec:              make(chan struct{}),
+	}
+	progressc := make(chan SnapshotProgress, 1)
+	go func() {
+		defer close(rc.donec)
+		defer close(progressc)
+		r := &snapshotReceiver{
+			ss:        ss,
+			pw:        pw,
+			opts:      o,
+			progressc: progressc,
+			h:         sha256.New(),
+			start:     time.Now(),
+		}
+		rc.err = r.receive(sctx, resp)
+		m.logAndCloseWithError(rc.err, pw)
+	}()
+
+	return &SnapshotResponse{
+		Header:   resp.GetHeader(),
+		Snapshot: rc,
+		Version:  resp.GetVersion(),
+	}, progressc, nil
+}
+
+// snapshotReceiver copies a snapshot stream to a pipe, verifying its sha256
+// digest.
+type snapshotReceiver struct {
+	ss        pb.Maintenance_SnapshotClient
+	pw        *io.PipeWriter
+	opts      snapshotOptions
+	progressc chan SnapshotProgress
+
+	// h hashes the blobs of the snapshot, up to its digest
+	h        hash.Hash
+	start    time.Time
+	progress SnapshotProgress
+}
+
+// receive copies the stream starting with resp to the pipe. It returns
+// io.EOF once the snapshot is fully received and verified.
+func (r *snapshotReceiver) receive(ctx context.Context, resp *pb.SnapshotResponse) error {
+	r.progress.TotalHint = int64(resp.RemainingBytes) + int64(len(resp.Blob)) + sha256.Size
+	// the digest is sent once the server announced no remaining bytes
+	dataDone := false
+	for {
+		if dataDone {
+			if err := r.write(ctx, resp.Blob); err != nil {
+				return err
+			}
+			if sum := r.h.Sum(nil); !bytes.Equal(sum, resp.Blob) {
+				return fmt.Errorf("%w: expected sha256 %x, got %x", ErrSnapshotIntegrity, resp.Blob, sum)
+			}
+			return io.EOF
+		}
+		if err := r.write(ctx, resp.Blob); err != nil {
+			return err
+		}
+		r.h.Write(resp.Blob)
+		dataDone = resp.RemainingBytes == 0
+
+		var err error
+		if resp, err = r.ss.Recv(); err != nil {
+			if ctx.Err() == nil {
+				err = fmt.Errorf("%w: %w", ErrSnapshotIncomplete, ContextError(ctx, err))
+			}
+			return err
+		}
+	}
+}
+
+// write writes b to the pipe, once the rate limit allows it, and updates the
+// progress once it is read.
+func (r *snapshotReceiver) write(ctx context.Context, b []byte) error {
+	if r.opts.rateLimit > 0 {
+		next := r.start.Add(time.Duration(float64(r.progress.BytesRead) / float64(r.opts.rateLimit) * float64(time.Second)))
+		if d := time.Until(next); d > 0 {
+			t := time.NewTimer(d)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return ctx.Err()
+			}
+		}
+	}
+	if _, err := r.pw.Write(b); err != nil {
+		return err
+	}
+	r.progress.BytesRead += int64(len(b))
+	// only the latest progress is kept for a slow consumer
+	select {
+	case <-r.progressc:
+	default:
+	}
+	r.progressc <- r.progress
+	return nil
+}
+
+// snapshotProgressReadCloser is the reader of SnapshotWithProgress. Its last
+// read fails if the snapshot is incomplete or corrupted.
+type snapshotProgressReadCloser struct {
+	snapshotReadCloser
+	cancel context.CancelFunc
+	// donec closes once the stream is done, with its error
+	donec chan struct{}
+	err   error
+}
+
+// Close stops the stream. It returns ErrSnapshotIntegrity or
+// ErrSnapshotIncomplete if the stream failed with it before, so that the
+// error is not lost if the last read was skipped.
+func (rc *snapshotProgressReadCloser) Close() error {
+	rc.ReadCloser.Close()
+	rc.cancel()
+	<-rc.donec
+	if errors.Is(rc.err, ErrSnapshotIntegrity) || errors.Is(rc.err, ErrSnapshotIncomplete) {
+		return rc.err
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// endpointMaintenanceClient answers Status with the member ID of its
+// endpoint, or fails if it has none.
+type endpointMaintenanceClient struct {
+	pb.MaintenanceClient
+	s        *statusAllServer
+	endpoint string
+}
+
+func (c *endpointMaintenanceClient) Status(context.Context, *pb.StatusRequest, ...grpc.CallOption) (*pb.StatusResponse, error) {
+	c.s.mu.Lock()
+	c.s.inflight++
+	c.s.maxInflight = max(c.s.maxInflight, c.s.inflight)
+	c.s.mu.Unlock()
+	defer func() {
+		c.s.mu.Lock()
+		c.s.inflight--
+		c.s.mu.Unlock()
+	}()
+
+	id, ok := c.s.memberIDs[c.endpoint]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return &pb.StatusResponse{Header: &pb.ResponseHeader{MemberId: id}}, nil
+}
+
+type statusAllServer struct {
+	memberIDs map[string]uint64
+
+	mu          sync.Mutex
+	inflight    int
+	maxInflight int
+}
+
+func (s *statusAllServer) maintenance(endpoints []string, members []*pb.Member) *maintenance {
+	return &maintenance{
+		dial: func(endpoint string) (pb.MaintenanceClient, func(), error) {
+			return &endpointMaintenanceClient{s: s, endpoint: endpoint}, func() {}, nil
+		},
+		endpoints: func() []string { return endpoints },
+		members: func(context.Context) ([]*pb.Member, error) {
+			if members == nil {
+				return nil, errors.New("no leader")
+			}
+			return members, nil
+		},
+	}
+}
+
+func TestStatusAll(t *testing.T) {
+	s := &statusAllServer{memberIDs: map[string]uint64{"a": 1, "b": 2, "c": 3}}
+
+	m := s.maintenance([]string{"c", "x", "a"}, nil)
+	statuses, err := m.StatusAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.Equal(t, "c", statuses[0].Endpoint)
+	assert.Equal(t, uint64(3), statuses[0].Status.Header.MemberId)
+	assert.Equal(t, "x", statuses[1].Endpoint)
+	assert.Nil(t, statuses[1].Status)
+	assert.EqualError(t, statuses[1].Err, "connection refused")
+	assert.Equal(t, "a", statuses[2].Endpoint)
+	assert.Equal(t, uint64(1), statuses[2].Status.Header.MemberId)
+
+	_, err = m.StatusAll(t.Context(), WithMemberEndpoints())
+	require.ErrorContains(t, err, "no leader")
+}
+
+func TestStatusAllMemberEndpoints(t *testing.T) {
+	s := &statusAllServer{memberIDs: map[string]uint64{"a": 1, "b1": 2, "b2": 2}}
+	members := []*pb.Member{
+		{ID: 1, ClientURLs: []string{"a"}},
+		{ID: 2, ClientURLs: []string{"b1", "b2"}},
+		// a member that has not started yet has no client URLs
+		{ID: 3},
+	}
+
+	m := s.maintenance([]string{"a"}, members)
+	statuses, err := m.StatusAll(t.Context(), WithMemberEndpoints(), WithStatusConcurrency(1))
+	require.NoError(t, err)
+	var eps []string
+	for _, st := range statuses {
+		require.NoError(t, st.Err)
+		eps = append(eps, st.Endpoint)
+	}
+	assert.Equal(t, []string{"a", "b1", "b2"}, eps)
+	assert.Equal(t, 1, s.maxInflight)
+}
+
+// snapshotMaintenanceClient streams its responses, then fails with err.
+type snapshotMaintenanceClient struct {
+	pb.MaintenanceClient
+	resps []*pb.SnapshotResponse
+	err   error
+}
+
+func (c *snapshotMaintenanceClient) Snapshot(ctx context.Context, _ *pb.SnapshotRequest, _ ...grpc.CallOption) (pb.Maintenance_SnapshotClient, error) {
+	return &snapshotStream{ctx: ctx, c: c}, nil
+}
+
+type snapshotStream struct {
+	grpc.ClientStream
+	ctx context.Context
+	c   *snapshotMaintenanceClient
+}
+
+func (s *snapshotStream) Recv() (*pb.SnapshotResponse, error) {
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.c.resps) == 0 {
+		return nil, s.c.err
+	}
+	resp := s.c.resps[0]
+	s.c.resps = s.c.resps[1:]
+	return resp, nil
+}
+
+func snapshotResponses(blobs ...string) []*pb.SnapshotResponse {
+	var resps []*pb.SnapshotResponse
+	h := sha256.New()
+	remaining := 0
+	for _, b := range blobs {
+		remaining += len(b)
+	}
+	for _, b := range blobs {
+		remaining -= len(b)
+		resps = append(resps, &pb.SnapshotResponse{RemainingBytes: uint64(remaining), Blob: []byte(b)})
+		h.Write([]byte(b))
+	}
+	return append(resps, &pb.SnapshotResponse{Blob: h.Sum(nil)})
+}
+
+func TestSnapshotWithProgress(t *testing.T) {
+	resps := snapshotResponses("0123456789", "abcdefghij")
+	digest := resps[2].Blob
+	corrupted := snapshotResponses("0123456789", "abcdefghij")
+	corrupted[1].Blob = []byte("ABCDEFGHIJ")
+
+	tests := []struct {
+		name    string
+		c       *snapshotMaintenanceClient
+		opts    []SnapshotOption
+		want    string
+		wantErr error
+		// minTook is the least time the read must take
+		minTook time.Duration
+	}{
+		{
+			name: "verified",
+			c:    &snapshotMaintenanceClient{resps: resps, err: io.EOF},
+			want: "0123456789abcdefghij" + string(digest),
+		},
+		{
+			name: "rate limited",
+			c:    &snapshotMaintenanceClient{resps: snapshotResponses("0123456789", "abcdefghij"), err: io.EOF},
+			opts: []SnapshotOption{WithSnapshotRateLimit(100)},
+			want: "0123456789abcdefghij" + string(digest),
+			// the digest is sent once 20 bytes were read
+			minTook: 200 * time.Millisecond,
+		},
+		{
+			name:    "corrupted",
+			c:       &snapshotMaintenanceClient{resps: corrupted, err: io.EOF},
+			want:    "0123456789ABCDEFGHIJ" + string(digest),
+			wantErr: ErrSnapshotIntegrity,
+		},
+		{
+			name:    "server error before the digest",
+			c:       &snapshotMaintenanceClient{resps: resps[:2], err: status.Error(codes.Unavailable, "etcdserver: server stopped")},
+			want:    "0123456789abcdefghij",
+			wantErr: ErrSnapshotIncomplete,
+		},
+		{
+			name:    "stream ended before the digest",
+			c:       &snapshotMaintenanceClient{resps: resps[:1], err: io.EOF},
+			want:    "0123456789",
+			wantErr: ErrSnapshotIncomplete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &maintenance{lg: zaptest.NewLogger(t), remote: tt.c}
+			start := time.Now()
+			resp, progressc, err := m.SnapshotWithProgress(t.Context(), tt.opts...)
+			require.NoError(t, err)
+
+			var last SnapshotProgress
+			progressDone := make(chan struct{})
+			go func() {
+				defer close(progressDone)
+				for p := range progressc {
+					assert.GreaterOrEqual(t, p.BytesRead, last.BytesRead)
+					last = p
+				}
+			}()
+
+			data, err := io.ReadAll(resp.Snapshot)
+			assert.Equal(t, tt.want, string(data))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.GreaterOrEqual(t, time.Since(start), tt.minTook)
+			closeErr := resp.Snapshot.Close()
+			if tt.wantErr == nil {
+				require.NoError(t, closeErr)
+			} else {
+				require.ErrorIs(t, closeErr, tt.wantErr)
+			}
+
+			<-progressDone
+			assert.Equal(t, SnapshotProgress{BytesRead: int64(len(tt.want)), TotalHint: 20 + sha256.Size}, last)
+		})
+	}
+}
+
+func TestSnapshotWithProgressClosedEarly(t *testing.T) {
+	m := &maintenance{lg: zaptest.NewLogger(t), remote: &snapshotMaintenanceClient{resps: snapshotResponses("0123456789", "abcdefghij"), err: io.EOF}}
+	resp, progressc, err := m.SnapshotWithProgress(t.Context())
+	require.NoError(t, err)
+
+	_, err = io.ReadFull(resp.Snapshot, make([]byte, 5))
+	require.NoError(t, err)
+	require.NoError(t, resp.Snapshot.Close())
+	for range progressc {
+	}
+}
@@ -18,6 +18,7 @@ package mirror
 import (
 	"context"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -35,15 +36,58 @@ type Syncer interface {
 	SyncUpdates(ctx context.Context) clientv3.WatchChan
 }
 
+// SyncerOption configures a Syncer.
+type SyncerOption func(*syncer)
+
+// WithDestPrefix rewrites the prefix of the keys synced by the Syncer to
+// destPrefix, both in the responses of SyncBase and in the events of
+// SyncUpdates. The rest of each key is kept as is. An empty destPrefix
+// removes the prefix.
+func WithDestPrefix(destPrefix string) SyncerOption {
+	return func(s *syncer) {
+		s.destPrefix = []byte(destPrefix)
+		s.rewrite = true
+	}
+}
+
+// WithProgress calls f after each batch of keys of SyncBase is sent, with the
+// number of keys synced so far and the revision the base state is synced at.
+func WithProgress(f func(keysSynced int64, currentRev int64)) SyncerOption {
+	return func(s *syncer) { s.progress = f }
+}
+
 // NewSyncer creates a Syncer.
-func NewSyncer(c *clientv3.Client, prefix string, rev int64) Syncer {
-	return &syncer{c: c, prefix: prefix, rev: rev}
+func NewSyncer(c *clientv3.Client, prefix string, rev int64, opts ...SyncerOption) Syncer {
+	s := &syncer{c: c, prefix: prefix, rev: rev}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 type syncer struct {
 	c      *clientv3.Client
 	rev    int64
 	prefix string
+
+	rewrite    bool
+	destPrefix []byte
+	progress   func(keysSynced int64, currentRev int64)
+}
+
+// rewriteKey returns key with its prefix replaced by the destination prefix.
+// Keys are always under the prefix, as they come from a range or a watch on it.
+func (s *syncer) rewriteKey(key []byte) []byte {
+	suffix := key[len(s.prefix):]
+	rk := make([]byte, 0, len(s.destPrefix)+len(suffix))
+	rk = append(rk, s.destPrefix...)
+	return append(rk, suffix...)
+}
+
+func (s *syncer) rewriteKv(kv *mvccpb.KeyValue) {
+	if kv != nil {
+		kv.Key = s.rewriteKey(kv.Key)
+	}
 }
 
 func (s *syncer) SyncBase(ctx context.Context) (<-chan *clientv3.GetResponse, chan error) {
@@ -73,6 +117,7 @@ func (s *syncer) SyncBase(ctx context.Context) (<-chan *clientv3.GetResponse, ch
 		defer close(errchan)
 
 		var key string
+		var synced int64
 
 		opts := []clientv3.OpOption{
 			clientv3.WithLimit(batchLimit), clientv3.WithRev(s.rev),
@@ -99,13 +144,26 @@ func (s *syncer) SyncBase(ctx context.Context) (<-chan *clientv3.GetResponse, ch
 				return
 			}
 
+			// move to next key, before the keys of the batch are rewritten
+			if resp.More {
+				key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+			}
+			if s.rewrite {
+				for _, kv := range resp.Kvs {
+					s.rewriteKv(kv)
+				}
+			}
+
 			respchan <- resp
 
+			synced += int64(len(resp.Kvs))
+			if s.progress != nil {
+				s.progress(synced, s.rev)
+			}
+
 			if !resp.More {
 				return
 			}
-			// move to next key
-			key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
 		}
 	}()
 
@@ -116,5 +174,24 @@ func (s *syncer) SyncUpdates(ctx context.Context) clientv3.WatchChan {
 	if s.rev == 0 {
 		panic("unexpected revision = 0. Calling SyncUpdates before SyncBase finishes?")
 	}
-	return s.c.Watch(ctx, s.prefix, clientv3.WithPrefix(), clientv3.WithRev(s.rev+1))
+	wch := s.c.Watch(ctx, s.prefix, clientv3.WithPrefix(), clientv3.WithRev(s.rev+1))
+	if !s.rewrite {
+		return wch
+	}
+	rch := make(chan clientv3.WatchResponse)
+	go func() {
+		defer close(rch)
+		for wr := range wch {
+			for _, ev := range wr.Events {
+				s.rewriteKv(ev.Kv)
+				s.rewriteKv(ev.PrevKv)
+			}
+			select {
+			case rch <- wr:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return rch
 }
@@ -213,10 +213,18 @@ func (s *mockLeaseServer) LeaseRevoke(context.Context, *pb.LeaseRevokeRequest) (
 	return &pb.LeaseRevokeResponse{}, nil
 }
 
+func (s *mockLeaseServer) LeaseTransfer(context.Context, *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	return &pb.LeaseTransferResponse{}, nil
+}
+
 func (s *mockLeaseServer) LeaseKeepAlive(pb.Lease_LeaseKeepAliveServer) error {
 	return nil
 }
 
+func (s *mockLeaseServer) LeaseWatch(*pb.LeaseWatchRequest, pb.Lease_LeaseWatchServer) error {
+	return nil
+}
+
 func (s *mockLeaseServer) LeaseTimeToLive(context.Context, *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
 	return &pb.LeaseTimeToLiveResponse{}, nil
 }
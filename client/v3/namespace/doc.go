@@ -28,6 +28,7 @@
 //	cli.KV = namespace.NewKV(cli.KV, "my-prefix/")
 //	cli.Watcher = namespace.NewWatcher(cli.Watcher, "my-prefix/")
 //	cli.Lease = namespace.NewLease(cli.Lease, "my-prefix/")
+//	cli.Maintenance = namespace.NewMaintenance(cli.Maintenance, "my-prefix/")
 //
 // Now calls using 'cli' will namespace / prefix all keys with "my-prefix/":
 //
@@ -50,6 +50,18 @@ func (kv *kvPrefix) Put(ctx context.Context, key, val string, opts ...clientv3.O
 	return put, nil
 }
 
+func (kv *kvPrefix) PutWithConfirm(ctx context.Context, key, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	if len(key) == 0 {
+		return nil, rpctypes.ErrEmptyKey
+	}
+	put, err := kv.KV.PutWithConfirm(ctx, kv.pfx+key, val, opts...)
+	if err != nil {
+		return nil, err
+	}
+	kv.unprefixPutResponse(put)
+	return put, nil
+}
+
 func (kv *kvPrefix) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
 	if len(key) == 0 && !(clientv3.IsOptsWithFromKey(opts) || clientv3.IsOptsWithPrefix(opts)) {
 		return nil, rpctypes.ErrEmptyKey
@@ -0,0 +1,52 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+type maintenancePrefix struct {
+	clientv3.Maintenance
+}
+
+// NewMaintenance wraps a Maintenance interface so that it does not return the
+// keys outside of the prefix. Snapshots hold the keys of the whole keyspace,
+// so they are not supported. The other methods do not take or return keys and
+// are passed through; in particular HashKV hashes the whole keyspace.
+func NewMaintenance(m clientv3.Maintenance, prefix string) clientv3.Maintenance {
+	return &maintenancePrefix{m}
+}
+
+// SnapshotWithVersion is not supported by maintenancePrefix.
+func (m *maintenancePrefix) SnapshotWithVersion(ctx context.Context) (*clientv3.SnapshotResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "SnapshotWithVersion is not supported by maintenancePrefix")
+}
+
+// SnapshotWithProgress is not supported by maintenancePrefix.
+func (m *maintenancePrefix) SnapshotWithProgress(ctx context.Context, opts ...clientv3.SnapshotOption) (*clientv3.SnapshotResponse, <-chan clientv3.SnapshotProgress, error) {
+	return nil, nil, status.Error(codes.Unimplemented, "SnapshotWithProgress is not supported by maintenancePrefix")
+}
+
+// Snapshot is not supported by maintenancePrefix.
+func (m *maintenancePrefix) Snapshot(ctx context.Context) (io.ReadCloser, error) {
+	return nil, status.Error(codes.Unimplemented, "Snapshot is not supported by maintenancePrefix")
+}
@@ -57,4 +57,27 @@
 //		em := endpoints.NewManager(c, service)
 //		return em.AddEndpoint(c.Ctx(), service+"/"+addr, endpoints.Endpoint{Addr:addr}, clientv3.WithLease(lid));
 //	}
+//
+// Or keep an endpoint registered with a session of a 10 seconds TTL, which is
+// registered again if the session expires, until it is deregistered:
+//
+//	func etcdRegister(c *clientv3.Client, service, addr string) (*endpoints.Registration, error) {
+//		em := endpoints.NewManager(c, service)
+//		return em.Register(c.Ctx(), service+"/"+addr, endpoints.Endpoint{Addr:addr}, 10)
+//	}
+//
+//	r, err := etcdRegister(c, service, addr)
+//	...
+//	defer r.Deregister(c.Ctx())
+//
+// To bias traffic towards larger servers, register endpoints with a weight
+// and dial with the weighted round-robin balancer of the resolver package:
+//
+//	func etcdAddWeighted(c *clientv3.Client, service, addr string, weight uint32) error {
+//		em := endpoints.NewManager(c, service)
+//		return em.AddEndpoint(c.Ctx(), service+"/"+addr, endpoints.Endpoint{Addr:addr, Weight:weight});
+//	}
+//
+//	conn, err := grpc.NewClient("etcd:///"+service, grpc.WithResolvers(etcdResolver),
+//		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"`+resolver.WeightedRoundRobin+`"}`))
 package naming
@@ -32,6 +32,12 @@ type Endpoint struct {
 	// Metadata is the information associated with Addr.
 	// Since etcd 3.1
 	Metadata any
+
+	// Weight is the relative share of traffic Addr should receive when
+	// dialed with the weighted round-robin balancer of the resolver
+	// package. Zero is treated as 1.
+	// Since etcd 3.8
+	Weight uint32
 }
 
 type Operation uint8
@@ -41,11 +47,15 @@ const (
 	Add Operation = iota
 	// Delete indicates an existing address is deleted.
 	Delete
+	// Modify indicates the endpoint stored under an existing key is
+	// overwritten, for example to change its Metadata or Weight.
+	// Since etcd 3.8
+	Modify
 )
 
 // Update describes a single edit action of an Endpoint.
 type Update struct {
-	// Op - action Add or Delete.
+	// Op - action Add, Delete or Modify.
 	Op       Operation
 	Key      string
 	Endpoint Endpoint
@@ -57,6 +67,12 @@ type WatchChannel <-chan []*Update
 // Key2EndpointMap maps etcd key into struct describing the endpoint.
 type Key2EndpointMap map[string]Endpoint
 
+// WithLease attaches the endpoints added with AddEndpoint or Update to the
+// given lease, so that they are deleted once it expires.
+func WithLease(leaseID clientv3.LeaseID) clientv3.OpOption {
+	return clientv3.WithLease(leaseID)
+}
+
 // UpdateWithOpts describes endpoint update (add or delete) together
 // with etcd options (e.g. to attach an endpoint to a lease).
 type UpdateWithOpts struct {
@@ -86,6 +102,12 @@ type Manager interface {
 	// DeleteEndpoint deletes a single endpoint stored in etcd.
 	// For more advanced use-cases use the Update method.
 	DeleteEndpoint(ctx context.Context, key string, opts ...clientv3.OpOption) error
+	// Register adds a single endpoint attached to a session of the given
+	// TTL (in seconds), and keeps it registered until Deregister is called
+	// on the returned Registration or the client is closed. The endpoint is
+	// added again if its key is deleted, or with a new session if the
+	// session expires, for example during a network partition.
+	Register(ctx context.Context, key string, endpoint Endpoint, ttl int) (*Registration, error)
 
 	// List returns all the endpoints for the current target as a map.
 	List(ctx context.Context) (Key2EndpointMap, error)
@@ -62,17 +62,11 @@ func (m *endpointManager) Update(ctx context.Context, updates []*UpdateWithOpts)
 
 		switch update.Op {
 		case Add:
-			internalUpdate := &internal.Update{
-				Op:       internal.Add,
-				Addr:     update.Endpoint.Addr,
-				Metadata: update.Endpoint.Metadata,
+			var v string
+			if v, err = encodeEndpoint(update.Endpoint); err != nil {
+				return err
 			}
-
-			var v []byte
-			if v, err = json.Marshal(internalUpdate); err != nil {
-				return status.Error(codes.InvalidArgument, err.Error())
-			}
-			ops = append(ops, clientv3.OpPut(update.Key, string(v), update.Opts...))
+			ops = append(ops, clientv3.OpPut(update.Key, v, update.Opts...))
 		case Delete:
 			ops = append(ops, clientv3.OpDelete(update.Key, update.Opts...))
 		default:
@@ -83,6 +77,21 @@ func (m *endpointManager) Update(ctx context.Context, updates []*UpdateWithOpts)
 	return err
 }
 
+// encodeEndpoint returns the value stored in etcd for the endpoint.
+func encodeEndpoint(ep Endpoint) (string, error) {
+	internalUpdate := &internal.Update{
+		Op:       internal.Add,
+		Addr:     ep.Addr,
+		Metadata: ep.Metadata,
+		Weight:   ep.Weight,
+	}
+	v, err := json.Marshal(internalUpdate)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return string(v), nil
+}
+
 func (m *endpointManager) AddEndpoint(ctx context.Context, key string, endpoint Endpoint, opts ...clientv3.OpOption) error {
 	return m.Update(ctx, []*UpdateWithOpts{NewAddUpdateOpts(key, endpoint, opts...)})
 }
@@ -100,6 +109,7 @@ func (m *endpointManager) NewWatchChannel(ctx context.Context) (WatchChannel, er
 
 	lg := m.client.GetLogger()
 	initUpdates := make([]*Update, 0, len(resp.Kvs))
+	keys := make(map[string]struct{}, len(resp.Kvs))
 	for _, kv := range resp.Kvs {
 		var iup internal.Update
 		if err := json.Unmarshal(kv.Value, &iup); err != nil {
@@ -109,20 +119,23 @@ func (m *endpointManager) NewWatchChannel(ctx context.Context) (WatchChannel, er
 		up := &Update{
 			Op:       Add,
 			Key:      string(kv.Key),
-			Endpoint: Endpoint{Addr: iup.Addr, Metadata: iup.Metadata},
+			Endpoint: Endpoint{Addr: iup.Addr, Metadata: iup.Metadata, Weight: iup.Weight},
 		}
 		initUpdates = append(initUpdates, up)
+		keys[up.Key] = struct{}{}
 	}
 
 	upch := make(chan []*Update, 1)
 	if len(initUpdates) > 0 {
 		upch <- initUpdates
 	}
-	go m.watch(ctx, resp.Header.Revision+1, upch)
+	go m.watch(ctx, resp.Header.Revision+1, keys, upch)
 	return upch, nil
 }
 
-func (m *endpointManager) watch(ctx context.Context, rev int64, upch chan []*Update) {
+// watch sends the updates of the endpoints from the given revision. The keys
+// are the ones of the endpoints already sent, whose puts are modifications.
+func (m *endpointManager) watch(ctx context.Context, rev int64, keys map[string]struct{}, upch chan []*Update) {
 	defer close(upch)
 
 	lg := m.client.GetLogger()
@@ -151,18 +164,23 @@ func (m *endpointManager) watch(ctx context.Context, rev int64, upch chan []*Upd
 				switch e.Type {
 				case clientv3.EventTypePut:
 					err = json.Unmarshal(e.Kv.Value, &iup)
-					op = Add
 					if err != nil {
 						lg.Warn("unmarshal endpoint update failed", zap.String("key", string(e.Kv.Key)), zap.Error(err))
 						continue
 					}
+					op = Add
+					if _, ok := keys[string(e.Kv.Key)]; ok {
+						op = Modify
+					}
+					keys[string(e.Kv.Key)] = struct{}{}
 				case clientv3.EventTypeDelete:
 					iup = internal.Update{Op: internal.Delete}
 					op = Delete
+					delete(keys, string(e.Kv.Key))
 				default:
 					continue
 				}
-				up := &Update{Op: op, Key: string(e.Kv.Key), Endpoint: Endpoint{Addr: iup.Addr, Metadata: iup.Metadata}}
+				up := &Update{Op: op, Key: string(e.Kv.Key), Endpoint: Endpoint{Addr: iup.Addr, Metadata: iup.Metadata, Weight: iup.Weight}}
 				deltaUps = append(deltaUps, up)
 			}
 			if len(deltaUps) > 0 {
@@ -186,7 +204,7 @@ func (m *endpointManager) List(ctx context.Context) (Key2EndpointMap, error) {
 			continue
 		}
 
-		eps[string(kv.Key)] = Endpoint{Addr: iup.Addr, Metadata: iup.Metadata}
+		eps[string(kv.Key)] = Endpoint{Addr: iup.Addr, Metadata: iup.Metadata, Weight: iup.Weight}
 	}
 	return eps, nil
 }
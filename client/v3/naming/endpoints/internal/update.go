@@ -49,4 +49,9 @@ type Update struct {
 	// Metadata is not required for a custom naming implementation.
 	// Since etcd 3.1.
 	Metadata any
+	// Weight is the relative share of traffic the address should receive
+	// from a weighted balancer. It is omitted when zero so that older
+	// clients can keep reading the update.
+	// Since etcd 3.8.
+	Weight uint32 `json:",omitempty"`
 }
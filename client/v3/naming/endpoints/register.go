@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+// registerRetryInterval is the delay before retrying to register an endpoint
+// whose session was lost.
+const registerRetryInterval = time.Second
+
+// Registration is the registration of an endpoint made by Manager.Register.
+type Registration struct {
+	m   *endpointManager
+	key string
+	ep  Endpoint
+	ttl int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	donec  chan struct{}
+
+	mu sync.Mutex
+	// ss is the session of the registered endpoint key
+	ss *concurrency.Session
+}
+
+func (m *endpointManager) Register(ctx context.Context, key string, endpoint Endpoint, ttl int) (*Registration, error) {
+	if !strings.HasPrefix(key, m.target+"/") {
+		return nil, status.Errorf(codes.InvalidArgument, "endpoints: endpoint key should be prefixed with '%s/' got: '%s'", m.target, key)
+	}
+	r := &Registration{
+		m:     m,
+		key:   key,
+		ep:    endpoint,
+		ttl:   ttl,
+		donec: make(chan struct{}),
+	}
+	ss, rev, err := r.register(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.ss = ss
+	r.ctx, r.cancel = context.WithCancel(m.client.Ctx())
+	go r.run(ss, rev)
+	return r, nil
+}
+
+// register puts the endpoint key with a new session, and returns the session
+// with the revision of the put.
+func (r *Registration) register(ctx context.Context) (*concurrency.Session, int64, error) {
+	ss, err := concurrency.NewSession(r.m.client, concurrency.WithTTL(r.ttl))
+	if err != nil {
+		return nil, 0, err
+	}
+	rev, err := r.put(ctx, ss)
+	if err != nil {
+		ss.Close()
+		return nil, 0, err
+	}
+	return ss, rev, nil
+}
+
+// put puts the endpoint key with the lease of the session, and returns the
+// revision of the put.
+func (r *Registration) put(ctx context.Context, ss *concurrency.Session) (int64, error) {
+	v, err := encodeEndpoint(r.ep)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := r.m.client.Put(ctx, r.key, v, WithLease(ss.Lease()))
+	if err != nil {
+		return 0, err
+	}
+	return resp.Header.Revision, nil
+}
+
+func (r *Registration) run(ss *concurrency.Session, rev int64) {
+	defer close(r.donec)
+
+	lg := r.m.client.GetLogger()
+	for {
+		if !r.keepRegistered(ss, rev) {
+			return
+		}
+		// stop keeping the lost session alive; its lease expires by itself
+		ss.Orphan()
+		r.mu.Lock()
+		r.ss = nil
+		r.mu.Unlock()
+
+		var err error
+		for {
+			if ss, rev, err = r.register(r.ctx); err == nil {
+				break
+			}
+			lg.Warn("failed to register endpoint", zap.String("key", r.key), zap.Error(err))
+			select {
+			case <-time.After(registerRetryInterval):
+			case <-r.ctx.Done():
+				return
+			}
+		}
+		r.mu.Lock()
+		r.ss = ss
+		r.mu.Unlock()
+		lg.Info("registered endpoint with a new session", zap.String("key", r.key))
+	}
+}
+
+// keepRegistered puts the endpoint key again whenever it is deleted while the
+// session is alive, and returns whether the endpoint should be registered
+// again with a new session.
+func (r *Registration) keepRegistered(ss *concurrency.Session, rev int64) bool {
+	lg := r.m.client.GetLogger()
+
+	wctx, wcancel := context.WithCancel(r.ctx)
+	defer wcancel()
+	wch := r.m.client.Watch(wctx, r.key, clientv3.WithRev(rev+1), clientv3.WithFilterPut())
+	for {
+		select {
+		case <-r.ctx.Done():
+			return false
+
+		case <-ss.Done():
+			lg.Warn("session expired; possible network partition or server restart", zap.String("key", r.key))
+			return true
+
+		case wresp, ok := <-wch:
+			if ok && wresp.Err() == nil && len(wresp.Events) == 0 {
+				continue
+			}
+			if r.ctx.Err() != nil {
+				return false
+			}
+			// the key was deleted, or the watch failed and may have missed
+			// its deletion
+			if rev, err := r.put(r.ctx, ss); err != nil {
+				lg.Warn("failed to put endpoint again", zap.String("key", r.key), zap.Error(err))
+				return true
+			} else if !ok || wresp.Err() != nil {
+				// the failed watch is closed, so it is only replaced
+				wch = r.m.client.Watch(wctx, r.key, clientv3.WithRev(rev+1), clientv3.WithFilterPut())
+			}
+		}
+	}
+}
+
+// Done returns a channel that is closed when the endpoint stops being kept
+// registered, because the client is closed or Deregister is called.
+func (r *Registration) Done() <-chan struct{} {
+	return r.donec
+}
+
+// Deregister stops keeping the endpoint registered, deletes its key so that
+// watchers of the target observe its removal right away instead of after the
+// session TTL, and closes the session. It is safe to call more than once.
+func (r *Registration) Deregister(ctx context.Context) error {
+	r.cancel()
+	<-r.donec
+
+	r.mu.Lock()
+	ss := r.ss
+	r.ss = nil
+	r.mu.Unlock()
+	if ss == nil {
+		return nil
+	}
+
+	err := r.m.DeleteEndpoint(ctx, r.key)
+	if cerr := ss.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
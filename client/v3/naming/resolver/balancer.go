@@ -0,0 +1,125 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/endpointsharding"
+	"google.golang.org/grpc/balancer/pickfirst"
+	"google.golang.org/grpc/connectivity"
+	gresolver "google.golang.org/grpc/resolver"
+)
+
+// WeightedRoundRobin is the name of a load balancing policy that spreads
+// requests over the ready endpoints in proportion to the Weight they were
+// registered with. Select it in the service config of the connection:
+//
+//	grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"etcd_weighted_round_robin"}`)
+const WeightedRoundRobin = "etcd_weighted_round_robin"
+
+func init() {
+	balancer.Register(weightedBuilder{})
+}
+
+type weightKey struct{}
+
+func setWeight(attrs *attributes.Attributes, weight uint32) *attributes.Attributes {
+	return attrs.WithValue(weightKey{}, weight)
+}
+
+// getWeight returns the weight of the endpoint, defaulting to 1.
+func getWeight(ep gresolver.Endpoint) uint32 {
+	w, _ := ep.Attributes.Value(weightKey{}).(uint32)
+	if w == 0 {
+		return 1
+	}
+	return w
+}
+
+type weightedBuilder struct{}
+
+func (weightedBuilder) Name() string { return WeightedRoundRobin }
+
+func (weightedBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	wcc := &weightedClientConn{ClientConn: cc}
+	return &weightedBalancer{
+		Balancer: endpointsharding.NewBalancer(wcc, opts, balancer.Get(pickfirst.Name).Build, endpointsharding.Options{}),
+	}
+}
+
+// weightedBalancer connects to every endpoint like round_robin does, and
+// replaces the round robin picker with a weighted one once endpoints are ready.
+type weightedBalancer struct {
+	balancer.Balancer
+}
+
+func (b *weightedBalancer) UpdateClientConnState(ccs balancer.ClientConnState) error {
+	return b.Balancer.UpdateClientConnState(balancer.ClientConnState{
+		ResolverState: pickfirst.EnableHealthListener(ccs.ResolverState),
+	})
+}
+
+type weightedClientConn struct {
+	balancer.ClientConn
+}
+
+func (cc *weightedClientConn) UpdateState(state balancer.State) {
+	if state.ConnectivityState == connectivity.Ready {
+		p := &weightedPicker{}
+		for _, cs := range endpointsharding.ChildStatesFromPicker(state.Picker) {
+			if cs.State.ConnectivityState != connectivity.Ready {
+				continue
+			}
+			p.children = append(p.children, &weightedChild{picker: cs.State.Picker, weight: int64(getWeight(cs.Endpoint))})
+			p.total += int64(getWeight(cs.Endpoint))
+		}
+		if len(p.children) > 0 {
+			state.Picker = p
+		}
+	}
+	cc.ClientConn.UpdateState(state)
+}
+
+type weightedChild struct {
+	picker  balancer.Picker
+	weight  int64
+	current int64
+}
+
+// weightedPicker implements smooth weighted round-robin, which interleaves
+// the picks of heavier endpoints with the lighter ones instead of sending
+// them in bursts.
+type weightedPicker struct {
+	mu       sync.Mutex
+	children []*weightedChild
+	total    int64
+}
+
+func (p *weightedPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	p.mu.Lock()
+	var best *weightedChild
+	for _, c := range p.children {
+		c.current += c.weight
+		if best == nil || c.current > best.current {
+			best = c
+		}
+	}
+	best.current -= p.total
+	p.mu.Unlock()
+	return best.picker.Pick(info)
+}
@@ -16,6 +16,7 @@ package resolver
 
 import (
 	"context"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -93,7 +94,7 @@ func (r *resolver) watch() {
 
 			for _, up := range ups {
 				switch up.Op {
-				case endpoints.Add:
+				case endpoints.Add, endpoints.Modify:
 					allUps[up.Key] = up
 				case endpoints.Delete:
 					delete(allUps, up.Key)
@@ -116,11 +117,39 @@ func convertToGRPCEndpoint(ups map[string]*endpoints.Update) []gresolver.Endpoin
 				},
 			},
 		}
+		if up.Endpoint.Weight != 0 {
+			ep.Attributes = setWeight(ep.Attributes, up.Endpoint.Weight)
+		}
+		if up.Endpoint.Metadata != nil {
+			ep.Attributes = ep.Attributes.WithValue(metadataKey{}, metadataValue{md: up.Endpoint.Metadata})
+		}
 		eps = append(eps, ep)
 	}
 	return eps
 }
 
+type metadataKey struct{}
+
+// metadataValue holds the Metadata of an endpoint as an attribute. It is
+// compared deeply, as the decoded JSON objects are not comparable.
+type metadataValue struct {
+	md any
+}
+
+func (v metadataValue) Equal(o any) bool {
+	ov, ok := o.(metadataValue)
+	return ok && reflect.DeepEqual(v.md, ov.md)
+}
+
+// EndpointMetadata returns the Metadata of an endpoint resolved by the etcd
+// resolver, or nil if it has none. The resolver updates the state of the
+// connection when the Metadata of an endpoint is modified, so balancers can
+// select endpoints by their Metadata.
+func EndpointMetadata(ep gresolver.Endpoint) any {
+	v, _ := ep.Attributes.Value(metadataKey{}).(metadataValue)
+	return v.md
+}
+
 // ResolveNow is a no-op here.
 // It's just a hint, resolver can ignore this if it's not necessary.
 func (r *resolver) ResolveNow(gresolver.ResolveNowOptions) {}
@@ -14,7 +14,11 @@
 
 package clientv3
 
-import pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+import (
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
 
 type opType int
 
@@ -44,6 +48,12 @@ type Op struct {
 	maxModRev    int64
 	minCreateRev int64
 	maxCreateRev int64
+	cursor       []byte
+	// strictRevision fails a Pager once its revision is compacted
+	strictRevision bool
+	// maxResponseBytes bounds the size of the key-value pairs of a Get,
+	// which is read in pages
+	maxResponseBytes int64
 
 	// for range, watch
 	rev int64
@@ -57,6 +67,39 @@ type Op struct {
 	// "--max-request-bytes" flag value + 512-byte
 	fragment           bool
 	watchBufLogEnabled bool
+	// snapshotFallback sends the current state of the range instead of
+	// canceling the watcher when its revision is compacted
+	snapshotFallback bool
+	// latestPerKey only sends the latest event of each key while the
+	// watcher catches up
+	latestPerKey bool
+	// sampleEveryN only sends every Nth PUT event of each key
+	sampleEveryN int64
+	// maxEventsPerResponse caps the number of events of each response
+	maxEventsPerResponse int64
+	// versionOnly only sends the key, mod revision and version of events
+	versionOnly bool
+	// compactWarningMargin warns of compactions within that many revisions
+	compactWarningMargin int64
+	// keepAliveInterval has the server send heartbeats on an idle stream
+	keepAliveInterval time.Duration
+	// linearizableStart has the server wait for a linearizable read before
+	// creating a watcher from the current revision
+	linearizableStart bool
+	// ackWindow has the server hold back the revisions past that many
+	// revisions after the last one acknowledged by the watcher
+	ackWindow int64
+	// collapseDeletes has the server send a range deletion as a single
+	// DELETE_RANGE event
+	collapseDeletes bool
+	// compare has the server only send the events that flip its result on
+	// the watched key
+	compare *pb.Compare
+	// compactionRetry re-creates the watcher from the revision it returns
+	// when the server cancels the watcher on compaction
+	compactionRetry func(compactRev int64) (int64, error)
+	// watchHandle is closed with the error that closed the watch channel
+	watchHandle *WatchHandle
 
 	// for put
 	ignoreValue bool
@@ -67,8 +110,11 @@ type Op struct {
 	// createdNotify is for created event
 	createdNotify bool
 	// filters for watchers
-	filterPut    bool
-	filterDelete bool
+	filterPut           bool
+	filterDelete        bool
+	filterValuePrefix   []byte
+	filterValueSelector string
+	valueFiltersFirst   bool
 
 	// for put
 	val     []byte
@@ -190,6 +236,7 @@ func (op Op) toRangeRequest() *pb.RangeRequest {
 		MaxModRevision:    op.maxModRev,
 		MinCreateRevision: op.minCreateRev,
 		MaxCreateRevision: op.maxCreateRev,
+		Cursor:            op.cursor,
 	}
 	if op.sort != nil {
 		r.SortOrder = pb.RangeRequest_SortOrder(op.sort.Order)
@@ -277,6 +324,8 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected lease in delete")
 	case ret.limit != 0:
 		panic("unexpected limit in delete")
+	case ret.cursor != nil:
+		panic("unexpected cursor in delete")
 	case ret.rev != 0:
 		panic("unexpected revision in delete")
 	case ret.sort != nil:
@@ -289,7 +338,7 @@ func OpDelete(key string, opts ...OpOption) Op {
 		panic("unexpected mod revision filter in delete")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in delete")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterValuePrefix != nil, ret.filterValueSelector != "":
 		panic("unexpected filter in delete")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in delete")
@@ -306,6 +355,8 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected range in put")
 	case ret.limit != 0:
 		panic("unexpected limit in put")
+	case ret.cursor != nil:
+		panic("unexpected cursor in put")
 	case ret.rev != 0:
 		panic("unexpected revision in put")
 	case ret.sort != nil:
@@ -318,7 +369,7 @@ func OpPut(key, val string, opts ...OpOption) Op {
 		panic("unexpected mod revision filter in put")
 	case ret.minCreateRev != 0, ret.maxCreateRev != 0:
 		panic("unexpected create revision filter in put")
-	case ret.filterDelete, ret.filterPut:
+	case ret.filterDelete, ret.filterPut, ret.filterValuePrefix != nil, ret.filterValueSelector != "":
 		panic("unexpected filter in put")
 	case ret.createdNotify:
 		panic("unexpected createdNotify in put")
@@ -343,6 +394,8 @@ func OpWatch(key string, opts ...OpOption) Op {
 		panic("unexpected lease in watch")
 	case ret.limit != 0:
 		panic("unexpected limit in watch")
+	case ret.cursor != nil:
+		panic("unexpected cursor in watch")
 	case ret.sort != nil:
 		panic("unexpected sort in watch")
 	case ret.serializable:
@@ -379,6 +432,16 @@ func WithLimit(n int64) OpOption { return func(op *Op) { op.limit = n } }
 // Or the start revision of 'Watch' request.
 func WithRev(rev int64) OpOption { return func(op *Op) { op.rev = rev } }
 
+// WithCursor continues a paginated 'Get' request from the NextCursor of the
+// response to the previous page. See Pager to iterate over a range in pages.
+func WithCursor(cursor []byte) OpOption { return func(op *Op) { op.cursor = cursor } }
+
+// WithStrictRevision makes a Pager fail with rpctypes.ErrCompacted once the
+// revision of its pages is compacted, instead of reading the following pages
+// at a later revision, so that the pages form a consistent snapshot of the
+// range. It is ignored by other requests.
+func WithStrictRevision() OpOption { return func(op *Op) { op.strictRevision = true } }
+
 // WithSort specifies the ordering in 'Get' request. It requires
 // 'WithRange' and/or 'WithPrefix' to be specified too.
 // 'target' specifies the target to sort by: key, version, revisions, value.
@@ -466,7 +529,8 @@ func WithSerializable() OpOption {
 }
 
 // WithKeysOnly makes the 'Get' request return only the keys and the corresponding
-// values will be omitted.
+// values will be omitted. For 'Watch', the values of the key-value pairs in the
+// events (including the previous key-value pairs) will be omitted.
 func WithKeysOnly() OpOption {
 	return func(op *Op) { op.keysOnly = true }
 }
@@ -476,6 +540,19 @@ func WithCountOnly() OpOption {
 	return func(op *Op) { op.countOnly = true }
 }
 
+// WithMaxResponseBytes makes 'Get' read the range in pages, all at the
+// revision of the first one, and stop once the key-value pairs read add up to
+// n bytes. The response holds the pairs that fit in n bytes, or the first pair
+// if it alone is larger, and More is set if the range has more keys. The
+// caller continues from the key following the last one of the response, or,
+// with WithSort(SortByKey, SortDescend), up to that last key. The range is
+// paged by key in the order given by WithSort; sorted by another target, it is
+// read again with larger limits until the bound is reached. It cannot be
+// combined with WithCountOnly, and does not apply to the operations of a Txn.
+func WithMaxResponseBytes(n int64) OpOption {
+	return func(op *Op) { op.maxResponseBytes = n }
+}
+
 // WithMinModRev filters out keys for Get with modification revisions less than the given revision.
 func WithMinModRev(rev int64) OpOption { return func(op *Op) { op.minModRev = rev } }
 
@@ -537,6 +614,30 @@ func WithFilterDelete() OpOption {
 	return func(op *Op) { op.filterDelete = true }
 }
 
+// WithFilterValuePrefix discards PUT events whose value does not start with
+// the given prefix from the watcher. DELETE events are not affected.
+func WithFilterValuePrefix(prefix string) OpOption {
+	return func(op *Op) { op.filterValuePrefix = []byte(prefix) }
+}
+
+// WithFilterValueSelector discards PUT events whose value is not a JSON
+// document matching the given selector from the watcher, for instance
+// `$.kind == "Pod" && $.spec.replicas > 1`. DELETE events are not affected.
+// The server rejects the watch if the selector is malformed or too costly, or
+// if it does not enable the WatchValueSelector feature.
+func WithFilterValueSelector(selector string) OpOption {
+	return func(op *Op) { op.filterValueSelector = selector }
+}
+
+// WithValueFiltersFirst makes the server evaluate the value filters set by
+// WithFilterValuePrefix and WithFilterValueSelector before the type filters
+// set by WithFilterPut and WithFilterDelete. By default the type filters are
+// evaluated first, which is cheaper when they drop most events. The events
+// sent are the same in either order.
+func WithValueFiltersFirst() OpOption {
+	return func(op *Op) { op.valueFiltersFirst = true }
+}
+
 // WithPrevKV gets the previous key-value pair before the event happens. If the previous KV is already compacted,
 // nothing will be returned.
 func WithPrevKV() OpOption {
@@ -556,6 +657,150 @@ func WithFragment() OpOption {
 	return func(op *Op) { op.fragment = true }
 }
 
+// WithSnapshotFallback makes the watcher survive compaction. When the revision
+// the watcher needs has been compacted, instead of canceling the watcher with
+// ErrCompacted the server sends the current state of the watched range as PUT
+// events in a response with Snapshot set, and then continues with the events
+// after the revision of that response. The history between the compacted
+// revision and the snapshot is lost, including deletions, so on a Snapshot
+// response the receiver should replace its state for the range with the events.
+func WithSnapshotFallback() OpOption {
+	return func(op *Op) { op.snapshotFallback = true }
+}
+
+// WithLatestPerKey makes the server send only the latest event of each key
+// while the watcher catches up on the history from the requested revision,
+// skipping the older revisions of a key. This suits rebuilding a cache where
+// only the latest value of every key matters. Once the watcher has caught up,
+// every event is sent.
+func WithLatestPerKey() OpOption {
+	return func(op *Op) { op.latestPerKey = true }
+}
+
+// WithSampleEveryN makes the server send only every Nth PUT event of each
+// key, starting with the first one, which suits observing keys that are
+// updated at a high rate. DELETE events are always sent and restart the count
+// of their key. Sampling is lossy: the skipped events are never sent, so it
+// must not be used when every change matters. Values below 2 disable it.
+func WithSampleEveryN(n int64) OpOption {
+	return func(op *Op) { op.sampleEveryN = n }
+}
+
+// WithMaxEventsPerResponse makes the server split the events it sends at once
+// into responses of at most n events each, which helps receivers that cannot
+// handle the thousands of events of a large transaction or of catching up.
+// The events of a revision are never split, so a response still holds more
+// than n events when a single revision has more. Unlike WithFragment, every
+// response is complete and delivered on its own. Values below 1 disable it.
+func WithMaxEventsPerResponse(n int64) OpOption {
+	return func(op *Op) { op.maxEventsPerResponse = n }
+}
+
+// WithVersionOnly makes the server send watch events that only carry their
+// type and the key, mod revision and version of their key-value pair. The
+// value, create revision and lease are omitted and WithPrevKV has no effect,
+// which keeps the events small for watchers that only need to know that a key
+// changed, such as a large fan-out of configuration watchers.
+func WithVersionOnly() OpOption {
+	return func(op *Op) { op.versionOnly = true }
+}
+
+// WithCompactionRetry keeps a watcher alive when the revision it needs has been
+// compacted. Instead of closing the channel with ErrCompacted, once the events
+// received before the compaction have been consumed, the handler is called with
+// the compact revision and the watcher is re-created on the same channel from
+// the revision the handler returns. The handler typically lists the watched
+// range at compactRev to rebuild its state, and returns compactRev + 1. The
+// events between the revision the watcher needed and compactRev are lost.
+// The handler runs on the goroutine of the watcher, so no events are delivered
+// while it runs. If it returns a revision below 1, the watcher is closed with
+// ErrCompacted as without this option.
+func WithCompactionRetry(handler func(compactRev int64) int64) OpOption {
+	return func(op *Op) {
+		op.compactionRetry = func(compactRev int64) (int64, error) { return handler(compactRev), nil }
+	}
+}
+
+// WithOnCompacted is like WithCompactionRetry, but lets the callback fail.
+// When the revision the watcher needs has been compacted, the callback is
+// called with the compact revision once the events received before the
+// compaction have been consumed, and the watcher is re-created on the same
+// channel from the revision it returns. If the callback returns an error, the
+// watcher is closed with a final response whose Err wraps both ErrCompacted
+// and that error. If it returns a revision below 1 and no error, the watcher
+// is closed with ErrCompacted as without this option.
+func WithOnCompacted(onCompacted func(compactRev int64) (newRev int64, err error)) OpOption {
+	return func(op *Op) { op.compactionRetry = onCompacted }
+}
+
+// WithCompactWarning makes the server warn the watcher when a compaction gets
+// within margin revisions of the next revision the watcher has to receive,
+// while it catches up on the history. The warning is received in a response
+// with CompactWarningRevision set, before the watcher falls behind the
+// compaction and is canceled with ErrCompacted. A slow consumer can then speed
+// up, or get the current state of the range and watch again from there.
+func WithCompactWarning(margin int64) OpOption {
+	return func(op *Op) { op.compactWarningMargin = margin }
+}
+
+// WithKeepAlive makes the server send a heartbeat on the stream of the
+// watcher whenever nothing was sent on it for the interval, so that proxies
+// and load balancers that close idle streams keep it open. Unlike progress
+// notifications, heartbeats say nothing about the revision of the watcher, and
+// they are not delivered on the watch channel. The server does not send them
+// more often than every 100ms; it ignores the option if it is older than v3.8.
+func WithKeepAlive(interval time.Duration) OpOption {
+	return func(op *Op) { op.keepAliveInterval = interval }
+}
+
+// WithLinearizableStart makes the server wait for a linearizable read before
+// creating a watcher without a start revision. The watcher then starts from
+// the current revision of the cluster, rather than from the revision of the
+// member the client is connected to, which may lag behind. It only applies
+// to Watch. The watch is canceled if the read fails, for instance if the
+// cluster has no leader, and the option is ignored by servers older than
+// v3.8.
+func WithLinearizableStart() OpOption {
+	return func(op *Op) { op.linearizableStart = true }
+}
+
+// WithAckWindow makes the server send the watcher only the revisions up to n
+// past the last revision it acknowledged, rather than as many as the grpc
+// stream can take. The watcher acknowledges the revision of a response once
+// it is received from the watch channel, so that a slow consumer holds the
+// later events back on the server instead of in the buffers of the client.
+// It only applies to Watch, and is ignored by servers older than v3.8.
+func WithAckWindow(n int64) OpOption {
+	return func(op *Op) { op.ackWindow = n }
+}
+
+// WithCollapseDeletes makes the server send a single DELETE_RANGE event in
+// place of the DELETE events of the keys deleted by a delete range request,
+// for watchers that only need to invalidate a cache. The event holds the
+// part of the deleted range within the range of the watcher, in Kv.Key and
+// RangeEnd, and the number of keys deleted in it, in Deleted, but neither
+// the deleted keys nor their previous key-value pairs. Only the events sent
+// while the watcher is synced are collapsed: the events read from the
+// history, for instance when the watcher starts from a past revision or
+// falls behind, and the deletes of expired leases are still sent one key at
+// a time. It only applies to Watch on a range, and is ignored by servers
+// older than v3.8 and by the grpc proxy.
+func WithCollapseDeletes() OpOption {
+	return func(op *Op) { op.collapseDeletes = true }
+}
+
+// WithCompare makes the server only send the events that flip the result of
+// cmp, for instance when a leader key gets or loses a given value. cmp is
+// evaluated as in a Txn, on the watched key whatever the key of cmp. Each
+// response has the result after its events in CompareResult, and the created
+// response the result before the start of the watcher. It only applies to
+// Watch on a single key. It is experimental, and rejected by servers without
+// the WatchCompare feature and by the grpc proxy.
+func WithCompare(cmp Cmp) OpOption {
+	c := cloneCompare(cmp.GetCompare())
+	return func(op *Op) { op.compare = c }
+}
+
 // WithWatchBufLog enables watch response buffer logging.
 func WithWatchBufLog() OpOption {
 	return func(op *Op) { op.watchBufLogEnabled = true }
@@ -54,6 +54,18 @@ var (
 
 	// client-side retry backoff default jitter fraction.
 	defaultBackoffJitterFraction = 0.10
+
+	// bounds of the wait of the watchers before they reconnect.
+	defaultWatchRetryBackoffMin = time.Millisecond
+	defaultWatchRetryBackoffMax = 100 * time.Millisecond
+
+	// fraction of the time to live of an auth token after which the client
+	// re-authenticates in the background.
+	defaultTokenRefreshFraction = 0.75
+
+	// fraction of the TTL of a lease without keep alive response after which
+	// the keep alives of the lease are stalled.
+	defaultLeaseStallFraction = 2.0 / 3
 )
 
 // defaultCallOpts defines a list of default "gRPC.CallOption".
@@ -38,4 +38,14 @@
 //	cli.KV = ordering.NewKV(cli.KV, vf)
 //
 // Now calls using 'cli' will reject order violations with an error.
+//
+// Alternatively, NewKVWithEndpointSwitch takes the endpoints that served stale
+// responses out of the rotation of the client for a while and sends the
+// requests again, so that they are served by up to date members:
+//
+//	cli.KV = ordering.NewKVWithEndpointSwitch(cli)
+//
+// Calls then only fail with ErrNoGreaterRev if every endpoint is stale. The
+// violations are counted by endpoint by the etcd_client_ordering_violations_total
+// metric.
 package ordering
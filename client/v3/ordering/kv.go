@@ -29,13 +29,44 @@ import (
 // returned revision.
 type kvOrdering struct {
 	clientv3.KV
-	orderViolationFunc OrderViolationFunc
-	prevRev            int64
-	revMu              sync.RWMutex
+	// newViolationHandler returns the handler of the violations of a
+	// request.
+	newViolationHandler func() violationHandler
+	prevRev             int64
+	revMu               sync.RWMutex
 }
 
 func NewKV(kv clientv3.KV, orderViolationFunc OrderViolationFunc) clientv3.KV {
-	return &kvOrdering{kv, orderViolationFunc, 0, sync.RWMutex{}}
+	return &kvOrdering{KV: kv, newViolationHandler: func() violationHandler {
+		return func(_ string, op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
+			return orderViolationFunc(op, resp, prevRev)
+		}
+	}}
+}
+
+// NewKVWithEndpointSwitch wraps the KV of c so that the endpoint that served
+// a stale response is suspected, taking it out of the rotation of c for a
+// while, and the request is sent again to another endpoint. ErrNoGreaterRev is
+// returned once every endpoint of c served a stale response to the request.
+func NewKVWithEndpointSwitch(c *clientv3.Client) clientv3.KV {
+	return &kvOrdering{KV: c.KV, newViolationHandler: func() violationHandler {
+		suspected := make(map[string]struct{})
+		violations := 0
+		return func(ep string, _ clientv3.Op, _ clientv3.OpResponse, _ int64) error {
+			violations++
+			n := len(c.Endpoints())
+			if ep != "" {
+				suspected[ep] = struct{}{}
+				c.SuspectEndpoint(ep, endpointSuspectDuration)
+			}
+			// the endpoint is unknown if the request was not sent through
+			// the balancer of c, so bound the attempts as well
+			if len(suspected) >= n || violations > 5*n {
+				return ErrNoGreaterRev
+			}
+			return nil
+		}
+	}}
 }
 
 func (kv *kvOrdering) getPrevRev() int64 {
@@ -59,6 +90,9 @@ func (kv *kvOrdering) Get(ctx context.Context, key string, opts ...clientv3.OpOp
 	// middle of the Get operation.
 	prevRev := kv.getPrevRev()
 	op := clientv3.OpGet(key, opts...)
+	var ep string
+	ctx = clientv3.WithServingEndpoint(ctx, &ep)
+	onViolation := kv.newViolationHandler()
 	for {
 		r, err := kv.KV.Do(ctx, op)
 		if err != nil {
@@ -71,7 +105,8 @@ func (kv *kvOrdering) Get(ctx context.Context, key string, opts ...clientv3.OpOp
 			kv.setPrevRev(resp.Header.Revision)
 			return resp, nil
 		}
-		err = kv.orderViolationFunc(op, r, prevRev)
+		orderingViolations.WithLabelValues(ep).Inc()
+		err = onViolation(ep, op, r, prevRev)
 		if err != nil {
 			return nil, err
 		}
@@ -139,8 +174,11 @@ func (txn *txnOrdering) Commit() (*clientv3.TxnResponse, error) {
 	// middle of the Commit operation.
 	prevRev := txn.getPrevRev()
 	opTxn := clientv3.OpTxn(txn.cmps, txn.thenOps, txn.elseOps)
+	var ep string
+	ctx := clientv3.WithServingEndpoint(txn.ctx, &ep)
+	onViolation := txn.newViolationHandler()
 	for {
-		opResp, err := txn.KV.Do(txn.ctx, opTxn)
+		opResp, err := txn.KV.Do(ctx, opTxn)
 		if err != nil {
 			return nil, err
 		}
@@ -149,7 +187,8 @@ func (txn *txnOrdering) Commit() (*clientv3.TxnResponse, error) {
 			txn.setPrevRev(txnResp.Header.Revision)
 			return txnResp, nil
 		}
-		err = txn.orderViolationFunc(opTxn, opResp, prevRev)
+		orderingViolations.WithLabelValues(ep).Inc()
+		err = onViolation(ep, opTxn, opResp, prevRev)
 		if err != nil {
 			return nil, err
 		}
@@ -66,15 +66,16 @@ func TestKvOrdering(t *testing.T) {
 	for i, tt := range rangeTests {
 		mKV := &mockKV{clientv3.NewKVFromKVClient(nil, nil), tt.response.OpResponse()}
 		kv := &kvOrdering{
-			mKV,
-			func(r *clientv3.GetResponse) OrderViolationFunc {
-				return func(op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
-					r.Header.Revision++
-					return nil
+			KV: mKV,
+			newViolationHandler: func(r *clientv3.GetResponse) func() violationHandler {
+				return func() violationHandler {
+					return func(_ string, op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
+						r.Header.Revision++
+						return nil
+					}
 				}
 			}(tt.response),
-			tt.prevRev,
-			sync.RWMutex{},
+			prevRev: tt.prevRev,
 		}
 		res, err := kv.Get(t.Context(), "mockKey")
 		if err != nil {
@@ -120,15 +121,16 @@ func TestTxnOrdering(t *testing.T) {
 	for i, tt := range txnTests {
 		mKV := &mockKV{clientv3.NewKVFromKVClient(nil, nil), tt.response.OpResponse()}
 		kv := &kvOrdering{
-			mKV,
-			func(r *clientv3.TxnResponse) OrderViolationFunc {
-				return func(op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
-					r.Header.Revision++
-					return nil
+			KV: mKV,
+			newViolationHandler: func(r *clientv3.TxnResponse) func() violationHandler {
+				return func() violationHandler {
+					return func(_ string, op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error {
+						r.Header.Revision++
+						return nil
+					}
 				}
 			}(tt.response),
-			tt.prevRev,
-			sync.RWMutex{},
+			prevRev: tt.prevRev,
 		}
 		txn := &txnOrdering{
 			kv.Txn(t.Context()),
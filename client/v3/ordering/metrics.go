@@ -0,0 +1,31 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ordering
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var orderingViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "etcd",
+	Subsystem: "client",
+	Name:      "ordering_violations_total",
+	Help:      "The total number of responses with a revision older than a previously received one, by the endpoint that served them.",
+}, []string{"endpoint"})
+
+// RegisterMetrics registers the metrics of the ordering package with reg.
+// They are not registered by default, so that importing the package does not
+// add metrics to the default registry.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	return reg.Register(orderingViolations)
+}
@@ -17,12 +17,21 @@ package ordering
 import (
 	"errors"
 	"sync/atomic"
+	"time"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 type OrderViolationFunc func(op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error
 
+// violationHandler handles the violations of a request, along with the
+// address of the endpoint that served the stale response, if known.
+type violationHandler func(ep string, op clientv3.Op, resp clientv3.OpResponse, prevRev int64) error
+
+// endpointSuspectDuration is how long an endpoint that served a stale
+// response is out of the rotation. Non-const so modifiable by tests.
+var endpointSuspectDuration = 30 * time.Second
+
 var ErrNoGreaterRev = errors.New("etcdclient: no cluster members have a revision higher than the previously received revision")
 
 func NewOrderViolationSwitchEndpointClosure(c *clientv3.Client) OrderViolationFunc {
@@ -0,0 +1,135 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// defaultPageSize is the number of keys of each page of a Pager when no page
+// size is given, and the size of the first page of a Get bounded with
+// WithMaxResponseBytes.
+const defaultPageSize = 1000
+
+var errPageUnsorted = errors.New("etcdclient: pages must be sorted by key in ascending order")
+
+// Pager gets a range in pages of a bounded number of keys, all read at the
+// revision of the first page, or the one given with WithRev.
+//
+// Against members supporting range cursors, the iteration survives the
+// compaction of that revision: the following pages are read at the oldest
+// revision left, and ConsistencyDowngraded reports it so the caller can
+// decide to accept the mixed view or to restart. With WithStrictRevision, or
+// against older members, the compaction fails the iteration with
+// rpctypes.ErrCompacted instead.
+type Pager struct {
+	kv KV
+	op Op
+
+	// rev is the revision the pages are read at, until the consistency is
+	// downgraded.
+	rev int64
+	// cursor is the cursor of the next page, if the member supports them.
+	cursor []byte
+	// downgraded is set once a page was read past the revision of the
+	// first page.
+	downgraded bool
+	done       bool
+}
+
+// NewPager returns a Pager getting the range of key given by opts in pages
+// of at most pageSize keys, or 1000 keys if pageSize is not positive. The
+// range must be in the default key order.
+func NewPager(kv KV, key string, pageSize int64, opts ...OpOption) *Pager {
+	op := OpGet(key, opts...)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	op.limit = pageSize
+	return &Pager{kv: kv, op: op, rev: op.rev}
+}
+
+// NewPagedGet returns a Pager getting the range of key given by opts, such as
+// WithPrefix, WithRange or WithFromKey. The limit given by WithLimit, if any,
+// is the number of keys of each page instead of the whole range, and
+// defaults to 1000. Pass WithStrictRevision for the pages to form a
+// consistent snapshot of the range.
+func (c *Client) NewPagedGet(key string, opts ...OpOption) *Pager {
+	return NewPager(c.KV, key, OpGet(key, opts...).limit, opts...)
+}
+
+// Next gets the next page of the range. It returns nil once the whole range
+// has been read.
+func (p *Pager) Next(ctx context.Context) (*GetResponse, error) {
+	if p.done {
+		return nil, nil
+	}
+	if s := p.op.sort; s != nil && (s.Target != SortByKey || s.Order == SortDescend) {
+		return nil, errPageUnsorted
+	}
+	op := p.op
+	op.cursor = p.cursor
+	op.rev = p.rev
+	if p.downgraded {
+		op.rev = 0
+	}
+	resp, err := p.kv.Do(ctx, op)
+	if errors.Is(err, rpctypes.ErrCompacted) && p.cursor != nil && !p.op.strictRevision {
+		// the revision was compacted while the member resolved the
+		// cursor; it continues past the compaction on a retry.
+		resp, err = p.kv.Do(ctx, op)
+	}
+	if err != nil {
+		return nil, err
+	}
+	get := resp.Get()
+	if get.ConsistencyDowngraded {
+		if p.op.strictRevision {
+			return nil, rpctypes.ErrCompacted
+		}
+		p.downgraded = true
+	}
+	if p.rev == 0 {
+		p.rev = get.Header.Revision
+	}
+	if !get.More || len(get.Kvs) == 0 {
+		p.done = true
+		return get, nil
+	}
+
+	// Besides the cursor, the next request carries the next key and, until
+	// the consistency is downgraded, the revision of the first page, so that
+	// members ignoring cursors still return the next page.
+	p.cursor = get.NextCursor
+	p.op.key = append(bytes.Clone(get.Kvs[len(get.Kvs)-1].Key), '\x00')
+	return get, nil
+}
+
+// Done returns true once the whole range has been read.
+func (p *Pager) Done() bool { return p.done }
+
+// Revision returns the revision the pages are read at, or 0 if no page has
+// been read yet and no revision was given. The pages read once the
+// consistency is downgraded are at later revisions.
+func (p *Pager) Revision() int64 { return p.rev }
+
+// ConsistencyDowngraded returns true if some pages were read at a later
+// revision than the first one, because it was compacted during the
+// iteration.
+func (p *Pager) ConsistencyDowngraded() bool { return p.downgraded }
@@ -155,10 +155,18 @@ func (rlc *retryLeaseClient) LeaseRevoke(ctx context.Context, in *pb.LeaseRevoke
 	return rlc.lc.LeaseRevoke(ctx, in, append(opts, withRepeatablePolicy())...)
 }
 
+func (rlc *retryLeaseClient) LeaseTransfer(ctx context.Context, in *pb.LeaseTransferRequest, opts ...grpc.CallOption) (resp *pb.LeaseTransferResponse, err error) {
+	return rlc.lc.LeaseTransfer(ctx, in, opts...)
+}
+
 func (rlc *retryLeaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (stream pb.Lease_LeaseKeepAliveClient, err error) {
 	return rlc.lc.LeaseKeepAlive(ctx, append(opts, withRepeatablePolicy())...)
 }
 
+func (rlc *retryLeaseClient) LeaseWatch(ctx context.Context, in *pb.LeaseWatchRequest, opts ...grpc.CallOption) (stream pb.Lease_LeaseWatchClient, err error) {
+	return rlc.lc.LeaseWatch(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryClusterClient struct {
 	cc pb.ClusterClient
 }
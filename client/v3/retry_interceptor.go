@@ -46,12 +46,16 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 		var p peer.Peer
 		grpcOpts = append(grpcOpts, grpc.Peer(&p))
 		callOpts := reuseOrNewWithCallOptions(intOpts, retryOpts)
+		callOpts = c.applyRetryPolicy(ctx, unaryRetryClass(method), callOpts)
 		// short circuit for simplicity, and avoiding allocations.
 		if callOpts.max == 0 {
 			return invoker(ctx, method, req, reply, cc, grpcOpts...)
 		}
 		var lastErr error
 		for attempt := uint(0); attempt < callOpts.max; attempt++ {
+			if attempt > 0 && callOpts.onRetry != nil {
+				callOpts.onRetry(attempt, lastErr)
+			}
 			if err := waitRetryBackoff(ctx, attempt, callOpts); err != nil {
 				return err
 			}
@@ -61,7 +65,13 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 				zap.String("method", method),
 				zap.Uint("attempt", attempt),
 			)
-			lastErr = invoker(ctx, method, req, reply, cc, grpcOpts...)
+			attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+			if callOpts.perAttemptTimeout > 0 {
+				attemptCtx, cancel = context.WithTimeout(ctx, callOpts.perAttemptTimeout)
+			}
+			lastErr = invoker(attemptCtx, method, req, reply, cc, grpcOpts...)
+			attemptTimedOut := attemptCtx.Err() != nil
+			cancel()
 			if lastErr == nil {
 				return nil
 			}
@@ -78,10 +88,16 @@ func (c *Client) unaryClientInterceptor(optFuncs ...retryOption) grpc.UnaryClien
 					// its the context deadline or cancellation.
 					return lastErr
 				}
+				// a write whose attempt timed out may have been applied.
+				if attemptTimedOut && callOpts.retryPolicy == nonRepeatable && !isRetryableCode(lastErr, callOpts.retryCodes) {
+					return lastErr
+				}
 				// its the callCtx deadline or cancellation, in which case try again.
 				continue
 			}
-			if c.shouldRefreshToken(lastErr, callOpts) {
+			// the token of a call with its own credentials is not the
+			// one of the client
+			if callAuthFromContext(ctx) == nil && c.shouldRefreshToken(lastErr, callOpts) {
 				gtErr := c.refreshToken(ctx)
 				if gtErr != nil {
 					c.GetLogger().Warn(
@@ -115,13 +131,18 @@ func (c *Client) streamClientInterceptor(optFuncs ...retryOption) grpc.StreamCli
 		ctx = withVersion(ctx)
 		// getToken automatically. Otherwise, auth token may be invalid after watch reconnection because the token has expired
 		// (see https://github.com/etcd-io/etcd/issues/11954 for more).
-		err := c.getToken(ctx)
-		if err != nil {
-			c.GetLogger().Error("clientv3/retry_interceptor: getToken failed", zap.Error(err))
-			return nil, err
+		// The token of a stream with credentials of its own is refreshed by callAuthStreamInterceptor.
+		if callAuthFromContext(ctx) == nil {
+			if err := c.getToken(ctx); err != nil {
+				c.GetLogger().Error("clientv3/retry_interceptor: getToken failed", zap.Error(err))
+				return nil, err
+			}
 		}
 		grpcOpts, retryOpts := filterCallOptions(opts)
 		callOpts := reuseOrNewWithCallOptions(intOpts, retryOpts)
+		if !desc.ClientStreams {
+			callOpts = c.applyRetryPolicy(ctx, retryClassStream, callOpts)
+		}
 		// short circuit for simplicity, and avoiding allocations.
 		if callOpts.max == 0 {
 			return streamer(ctx, desc, cc, method, grpcOpts...)
@@ -239,6 +260,9 @@ func (s *serverStreamingRetryingStream) RecvMsg(m any) error {
 
 	// We start off from attempt 1, because zeroth was already made on normal SendMsg().
 	for attempt := uint(1); attempt < s.callOpts.max; attempt++ {
+		if s.callOpts.onRetry != nil {
+			s.callOpts.onRetry(attempt, lastErr)
+		}
 		if err := waitRetryBackoff(s.ctx, attempt, s.callOpts); err != nil {
 			return err
 		}
@@ -279,7 +303,7 @@ func (s *serverStreamingRetryingStream) receiveMsgAndIndicateRetry(m any) (bool,
 		// its the callCtx deadline or cancellation, in which case try again.
 		return true, err
 	}
-	if s.client.shouldRefreshToken(err, s.callOpts) {
+	if callAuthFromContext(s.ctx) == nil && s.client.shouldRefreshToken(err, s.callOpts) {
 		gtErr := s.client.refreshToken(s.ctx)
 		if gtErr != nil {
 			s.client.GetLogger().Warn("retry failed to fetch new auth token", zap.Error(gtErr))
@@ -342,11 +366,15 @@ func isSafeRetry(c *Client, err error, callOpts *options) bool {
 		return true
 	}
 
+	if len(callOpts.retryCodes) != 0 {
+		return isRetryableCode(err, callOpts.retryCodes)
+	}
+
 	switch callOpts.retryPolicy {
 	case repeatable:
 		return isSafeRetryImmutableRPC(err)
 	case nonRepeatable:
-		return isSafeRetryMutableRPC(err)
+		return !callOpts.disableUnsentRetry && isSafeRetryMutableRPC(err)
 	default:
 		c.GetLogger().Warn("unrecognized retry policy", zap.String("retryPolicy", callOpts.retryPolicy.String()))
 		return false
@@ -409,6 +437,16 @@ type options struct {
 	max         uint
 	backoffFunc backoffFunc
 	retryAuth   bool
+	// retryCodes, if set, replace the retryPolicy to decide which errors
+	// are retried.
+	retryCodes []codes.Code
+	// perAttemptTimeout, if set, bounds each attempt of a unary RPC.
+	perAttemptTimeout time.Duration
+	// disableUnsentRetry stops non-repeatable RPCs from being retried when
+	// they were not sent.
+	disableUnsentRetry bool
+	// onRetry, if set, is called before each retry.
+	onRetry func(attempt uint, err error)
 }
 
 // retryOption is a grpc.CallOption that is local to clientv3's retry interceptor.
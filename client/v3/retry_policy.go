@@ -0,0 +1,237 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures how the client retries the RPCs of a class. A zero
+// field keeps the default behavior of the class.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of an RPC, including the
+	// first one. 1 disables retries.
+	MaxAttempts uint `json:"max-attempts"`
+
+	// BackoffBase is the wait before the first retry, doubled on every
+	// following retry and randomized by BackoffJitterFraction. By default the
+	// client waits BackoffWaitBetween after each round of attempts across a
+	// quorum of the endpoints.
+	BackoffBase time.Duration `json:"backoff-base"`
+
+	// BackoffCap is the maximum wait between two attempts.
+	BackoffCap time.Duration `json:"backoff-cap"`
+
+	// BackoffJitterFraction randomizes the waits between attempts by up to
+	// this fraction. It defaults to the BackoffJitterFraction of the client.
+	BackoffJitterFraction float64 `json:"backoff-jitter-fraction"`
+
+	// PerAttemptTimeout bounds each attempt of a unary RPC, so that an
+	// attempt stuck on an endpoint is retried rather than waiting for the
+	// deadline of the call. A write that timed out may have been applied, so
+	// it is only retried if RetryableCodes contains codes.DeadlineExceeded.
+	// Streams are not bounded, as their attempts last as long as the stream.
+	PerAttemptTimeout time.Duration `json:"per-attempt-timeout"`
+
+	// RetryableCodes are the gRPC status codes an RPC is retried on. By
+	// default reads are retried while the endpoint is unavailable, and writes
+	// only if they could not be sent to any endpoint, so that a write is never
+	// applied twice. Setting it for writes gives up that guarantee.
+	RetryableCodes []codes.Code `json:"retryable-codes"`
+
+	// DisableUnsentRetry stops writes from being retried when they could not
+	// be sent to any endpoint, for callers that would rather fail fast. It has
+	// no effect on the RPCs retried on RetryableCodes.
+	DisableUnsentRetry bool `json:"disable-unsent-retry"`
+
+	// OnRetry, if set, is called before each retry with the number of the
+	// retry, starting at 1, and the error of the previous attempt.
+	OnRetry func(attempt uint, err error) `json:"-"`
+}
+
+func (p RetryPolicy) isZero() bool {
+	return p.MaxAttempts == 0 && p.BackoffBase == 0 && p.BackoffCap == 0 && p.BackoffJitterFraction == 0 &&
+		p.PerAttemptTimeout == 0 && len(p.RetryableCodes) == 0 && !p.DisableUnsentRetry && p.OnRetry == nil
+}
+
+// merge returns p with the non-zero fields of o.
+func (p RetryPolicy) merge(o RetryPolicy) RetryPolicy {
+	if o.MaxAttempts != 0 {
+		p.MaxAttempts = o.MaxAttempts
+	}
+	if o.BackoffBase != 0 {
+		p.BackoffBase = o.BackoffBase
+	}
+	if o.BackoffCap != 0 {
+		p.BackoffCap = o.BackoffCap
+	}
+	if o.BackoffJitterFraction != 0 {
+		p.BackoffJitterFraction = o.BackoffJitterFraction
+	}
+	if o.PerAttemptTimeout != 0 {
+		p.PerAttemptTimeout = o.PerAttemptTimeout
+	}
+	if len(o.RetryableCodes) != 0 {
+		p.RetryableCodes = o.RetryableCodes
+	}
+	if o.DisableUnsentRetry {
+		p.DisableUnsentRetry = true
+	}
+	if o.OnRetry != nil {
+		p.OnRetry = o.OnRetry
+	}
+	return p
+}
+
+// RetryPolicies configures the retries of each class of RPC.
+type RetryPolicies struct {
+	// Reads applies to the unary RPCs that do not change the cluster, such
+	// as Range, LeaseTimeToLive, MemberList and Status.
+	Reads RetryPolicy `json:"reads"`
+
+	// Writes applies to the other unary RPCs, such as Put, Txn and LeaseGrant.
+	Writes RetryPolicy `json:"writes"`
+
+	// Streams applies to the server streaming RPCs, such as Snapshot and
+	// RangeStream. The bidirectional Watch and LeaseKeepAlive streams are
+	// never retried this way, they are re-established by their own clients.
+	Streams RetryPolicy `json:"streams"`
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy overrides the retry policy of the RPCs made with the
+// returned context. The non-zero fields of p replace those of the policy
+// configured for the class of each RPC.
+func WithRetryPolicy(ctx context.Context, p RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, p)
+}
+
+// retryClass is the class of an RPC a retry policy applies to.
+type retryClass uint8
+
+const (
+	retryClassRead retryClass = iota
+	retryClassWrite
+	retryClassStream
+)
+
+// readMethods are the unary RPCs that do not change the cluster.
+var readMethods = map[string]struct{}{
+	"/etcdserverpb.KV/Range":              {},
+	"/etcdserverpb.Lease/LeaseTimeToLive": {},
+	"/etcdserverpb.Lease/LeaseLeases":     {},
+	"/etcdserverpb.Cluster/MemberList":    {},
+	"/etcdserverpb.Maintenance/Status":    {},
+	"/etcdserverpb.Maintenance/Hash":      {},
+	"/etcdserverpb.Maintenance/HashKV":    {},
+	"/etcdserverpb.Auth/AuthStatus":       {},
+	"/etcdserverpb.Auth/UserGet":          {},
+	"/etcdserverpb.Auth/UserList":         {},
+	"/etcdserverpb.Auth/RoleGet":          {},
+	"/etcdserverpb.Auth/RoleList":         {},
+	"/v3electionpb.Election/Leader":       {},
+}
+
+// unaryRetryClass returns the class of the unary RPC with the given full
+// method name.
+func unaryRetryClass(method string) retryClass {
+	if _, ok := readMethods[method]; ok {
+		return retryClassRead
+	}
+	return retryClassWrite
+}
+
+// retryPolicy returns the policy configured for the given class, overridden
+// by the policy of ctx if any.
+func (c *Client) retryPolicy(ctx context.Context, class retryClass) RetryPolicy {
+	var p RetryPolicy
+	switch class {
+	case retryClassRead:
+		p = c.cfg.RetryPolicy.Reads
+	case retryClassWrite:
+		p = c.cfg.RetryPolicy.Writes
+	case retryClassStream:
+		p = c.cfg.RetryPolicy.Streams
+	}
+	if o, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		p = p.merge(o)
+	}
+	return p
+}
+
+// applyRetryPolicy returns callOpts updated with the retry policy of the
+// given class. callOpts is not modified.
+func (c *Client) applyRetryPolicy(ctx context.Context, class retryClass, callOpts *options) *options {
+	p := c.retryPolicy(ctx, class)
+	if p.isZero() {
+		return callOpts
+	}
+	o := *callOpts
+	if p.MaxAttempts != 0 {
+		o.max = p.MaxAttempts
+	}
+	jitterFraction := c.backoffJitterFraction()
+	if p.BackoffJitterFraction != 0 {
+		jitterFraction = p.BackoffJitterFraction
+	}
+	switch {
+	case p.BackoffBase != 0:
+		o.backoffFunc = backoffExponentialWithJitter(p.BackoffBase, jitterFraction)
+	case p.BackoffJitterFraction != 0:
+		o.backoffFunc = c.roundRobinQuorumBackoff(c.backoffWaitBetween(), jitterFraction)
+	}
+	if p.BackoffCap != 0 {
+		o.backoffFunc = backoffWithCap(o.backoffFunc, p.BackoffCap)
+	}
+	if len(p.RetryableCodes) != 0 {
+		o.retryCodes = p.RetryableCodes
+	}
+	o.perAttemptTimeout = p.PerAttemptTimeout
+	o.disableUnsentRetry = p.DisableUnsentRetry
+	o.onRetry = p.OnRetry
+	return &o
+}
+
+// backoffExponentialWithJitter waits base before the first retry, and twice
+// as long before each following one, allowing for jitter.
+func backoffExponentialWithJitter(base time.Duration, jitterFraction float64) backoffFunc {
+	return func(attempt uint) time.Duration {
+		wait := base
+		for i := uint(1); i < attempt && wait < math.MaxInt64/2; i++ {
+			wait *= 2
+		}
+		return jitterUp(wait, jitterFraction)
+	}
+}
+
+// backoffWithCap limits the waits of bf to maxWait.
+func backoffWithCap(bf backoffFunc, maxWait time.Duration) backoffFunc {
+	return func(attempt uint) time.Duration {
+		return min(bf(attempt), maxWait)
+	}
+}
+
+// isRetryableCode returns true if the status code of err is one of codes.
+func isRetryableCode(err error, retryCodes []codes.Code) bool {
+	ev, ok := status.FromError(err)
+	return ok && slices.Contains(retryCodes, ev.Code())
+}
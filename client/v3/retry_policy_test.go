@@ -0,0 +1,262 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingServer fails every RPC with codes.Unavailable and counts the
+// attempts of each method. If hang is set, the RPCs block until they are
+// canceled instead.
+type failingServer struct {
+	mu       sync.Mutex
+	attempts map[string]int
+	hang     bool
+}
+
+func (s *failingServer) handle(_ any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	s.mu.Lock()
+	s.attempts[method]++
+	hang := s.hang
+	s.mu.Unlock()
+	if hang {
+		<-stream.Context().Done()
+		return stream.Context().Err()
+	}
+	return status.Error(codes.Unavailable, "injected failure")
+}
+
+func (s *failingServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.attempts[method]
+	delete(s.attempts, method)
+	return n
+}
+
+func newFailingServer(t *testing.T) (*failingServer, string) {
+	fs := &failingServer{attempts: make(map[string]int)}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(fs.handle))
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	return fs, ln.Addr().String()
+}
+
+func getStream(t *testing.T, c *Client) (*GetResponse, error) {
+	stream, err := c.GetStream(t.Context(), "foo")
+	if err != nil {
+		return nil, err
+	}
+	return GetStreamToGetResponse(stream)
+}
+
+func newRetryPolicyTestClient(t *testing.T, ep string, policy RetryPolicies) *Client {
+	c, err := NewClient(t, Config{
+		Endpoints:          []string{ep},
+		BackoffWaitBetween: time.Millisecond,
+		RetryPolicy:        policy,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// TestRetryPolicyDefaults ensures that without a retry policy, reads are
+// retried up to the default limit, and writes and server streams are not.
+func TestRetryPolicyDefaults(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{})
+
+	_, err := c.Get(t.Context(), "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int(defaultUnaryMaxRetries), fs.count("/etcdserverpb.KV/Range"))
+
+	_, err = c.Put(t.Context(), "foo", "bar")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fs.count("/etcdserverpb.KV/Put"))
+
+	_, err = c.Status(t.Context(), ep)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, int(defaultUnaryMaxRetries), fs.count("/etcdserverpb.Maintenance/Status"))
+
+	_, err = getStream(t, c)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fs.count("/etcdserverpb.KV/RangeStream"))
+}
+
+// TestRetryPolicyPerClass ensures that the configured policy of each class
+// applies to its RPCs only.
+func TestRetryPolicyPerClass(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{
+		Reads:   RetryPolicy{MaxAttempts: 3},
+		Writes:  RetryPolicy{MaxAttempts: 4, RetryableCodes: []codes.Code{codes.Unavailable}},
+		Streams: RetryPolicy{MaxAttempts: 5, RetryableCodes: []codes.Code{codes.Unavailable}},
+	})
+
+	_, err := c.Get(t.Context(), "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/Range"))
+
+	_, err = c.Put(t.Context(), "foo", "bar")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 4, fs.count("/etcdserverpb.KV/Put"))
+
+	_, err = getStream(t, c)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 5, fs.count("/etcdserverpb.KV/RangeStream"))
+
+	// retried without limit by default
+	rc, err := c.Snapshot(t.Context())
+	require.NoError(t, err)
+	_, err = io.ReadAll(rc)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 5, fs.count("/etcdserverpb.Maintenance/Snapshot"))
+}
+
+// TestRetryPolicyRetryableCodes ensures that errors whose code is not
+// retryable are not retried.
+func TestRetryPolicyRetryableCodes(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{
+		Reads: RetryPolicy{MaxAttempts: 3, RetryableCodes: []codes.Code{codes.ResourceExhausted}},
+	})
+
+	_, err := c.Get(t.Context(), "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fs.count("/etcdserverpb.KV/Range"))
+}
+
+// TestWithRetryPolicy ensures that the policy of the context overrides the
+// configured one for the calls made with it.
+func TestWithRetryPolicy(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{
+		Reads: RetryPolicy{MaxAttempts: 3},
+	})
+
+	ctx := WithRetryPolicy(t.Context(), RetryPolicy{MaxAttempts: 2})
+	_, err := c.Get(ctx, "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, fs.count("/etcdserverpb.KV/Range"))
+
+	// a single attempt disables the retries
+	ctx = WithRetryPolicy(t.Context(), RetryPolicy{MaxAttempts: 1})
+	_, err = c.Get(ctx, "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fs.count("/etcdserverpb.KV/Range"))
+
+	_, err = c.Get(context.Background(), "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/Range"))
+}
+
+// TestRetryPolicyOnRetry ensures that OnRetry is called before each retry of
+// unary RPCs and streams.
+func TestRetryPolicyOnRetry(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	var attempts []uint
+	onRetry := func(attempt uint, err error) {
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		attempts = append(attempts, attempt)
+	}
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{
+		Reads:   RetryPolicy{MaxAttempts: 3, OnRetry: onRetry},
+		Streams: RetryPolicy{MaxAttempts: 3, RetryableCodes: []codes.Code{codes.Unavailable}, OnRetry: onRetry},
+	})
+
+	_, err := c.Get(t.Context(), "foo")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/Range"))
+	assert.Equal(t, []uint{1, 2}, attempts)
+
+	attempts = nil
+	_, err = getStream(t, c)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/RangeStream"))
+	assert.Equal(t, []uint{1, 2}, attempts)
+
+	// not called for the RPCs that are not retried
+	attempts = nil
+	_, err = c.Put(t.Context(), "foo", "bar")
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Empty(t, attempts)
+}
+
+// TestRetryPolicyPerAttemptTimeout ensures that reads are retried once an
+// attempt times out, and that writes are not unless DeadlineExceeded is
+// retryable.
+func TestRetryPolicyPerAttemptTimeout(t *testing.T) {
+	fs, ep := newFailingServer(t)
+	fs.hang = true
+	c := newRetryPolicyTestClient(t, ep, RetryPolicies{
+		Reads:  RetryPolicy{MaxAttempts: 3, PerAttemptTimeout: 20 * time.Millisecond},
+		Writes: RetryPolicy{MaxAttempts: 3, PerAttemptTimeout: 20 * time.Millisecond},
+	})
+
+	_, err := c.Get(t.Context(), "foo")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/Range"))
+
+	_, err = c.Put(t.Context(), "foo", "bar")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 1, fs.count("/etcdserverpb.KV/Put"))
+
+	ctx := WithRetryPolicy(t.Context(), RetryPolicy{RetryableCodes: []codes.Code{codes.DeadlineExceeded}})
+	_, err = c.Put(ctx, "foo", "bar")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, 3, fs.count("/etcdserverpb.KV/Put"))
+}
+
+func TestIsSafeRetryDisableUnsentRetry(t *testing.T) {
+	c := &Client{cfg: Config{Endpoints: []string{"a"}}}
+	unsent := status.Error(codes.Unavailable, "there is no address available")
+	assert.True(t, isSafeRetry(c, unsent, &options{retryPolicy: nonRepeatable}))
+	assert.False(t, isSafeRetry(c, unsent, &options{retryPolicy: nonRepeatable, disableUnsentRetry: true}))
+	// reads are retried regardless
+	assert.True(t, isSafeRetry(c, unsent, &options{retryPolicy: repeatable, disableUnsentRetry: true}))
+}
+
+func TestBackoffExponentialWithJitter(t *testing.T) {
+	bf := backoffWithCap(backoffExponentialWithJitter(10*time.Millisecond, 0), 50*time.Millisecond)
+	var waits []time.Duration
+	for attempt := uint(1); attempt <= 5; attempt++ {
+		waits = append(waits, bf(attempt))
+	}
+	assert.Equal(t, []time.Duration{
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		40 * time.Millisecond,
+		50 * time.Millisecond,
+		50 * time.Millisecond,
+	}, waits)
+	// a large attempt must not overflow
+	assert.Equal(t, 50*time.Millisecond, bf(1000))
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// ErrClientShuttingDown is returned by the RPCs and watch requests started
+// once the client is shutting down.
+var ErrClientShuttingDown = errors.New("etcdclient: client is shutting down")
+
+// rpcGate tracks the in-flight unary RPCs of a client, and refuses new RPCs
+// once it is closed.
+type rpcGate struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight int
+	// idlec closes once the gate is closed and no unary RPC is in flight.
+	idlec chan struct{}
+}
+
+// enter registers a new RPC, or returns false if the gate is closed.
+func (g *rpcGate) enter() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.closed {
+		return false
+	}
+	g.inflight++
+	return true
+}
+
+// leave unregisters an RPC registered by enter.
+func (g *rpcGate) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inflight--
+	if g.closed && g.inflight == 0 {
+		close(g.idlec)
+	}
+}
+
+// isClosed returns whether the gate is closed.
+func (g *rpcGate) isClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+// close closes the gate, and returns a channel closing once no unary RPC is
+// in flight.
+func (g *rpcGate) close() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.closed {
+		g.closed = true
+		g.idlec = make(chan struct{})
+		if g.inflight == 0 {
+			close(g.idlec)
+		}
+	}
+	return g.idlec
+}
+
+// shutdownUnaryInterceptor wraps next to refuse the unary RPCs started once
+// the client is shutting down, and to track the in-flight ones. It is set
+// above the retries, so that an RPC is tracked once for all its attempts.
+func (c *Client) shutdownUnaryInterceptor(next grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !c.rpcGate.enter() {
+			return ErrClientShuttingDown
+		}
+		defer c.rpcGate.leave()
+		return next(ctx, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+// shutdownStreamInterceptor returns a stream client interceptor refusing the
+// streams opened once the client is shutting down.
+func (c *Client) shutdownStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if c.rpcGate.isClosed() {
+			return nil, ErrClientShuttingDown
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// Shutdown gracefully closes the client, unlike Close. New RPCs fail with
+// ErrClientShuttingDown, while the in-flight unary RPCs are waited for. The
+// watchers are then canceled on the server, once the responses with the
+// events committed before are received, and their channels get these
+// responses before they close. The connection is closed last.
+//
+// The watch channels must be read for Shutdown to complete. If ctx is done
+// first, the client is closed as with Close and the error of ctx returned.
+func (c *Client) Shutdown(ctx context.Context) error {
+	idlec := c.rpcGate.close()
+	err := func() error {
+		select {
+		case <-idlec:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if w, ok := c.Watcher.(*watcher); ok {
+			return w.drain(ctx)
+		}
+		return nil
+	}()
+	if cerr := c.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -16,7 +16,6 @@ package snapshot
 
 import (
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
@@ -30,13 +29,9 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
-// hasChecksum returns "true" if the file size "n"
-// has appended sha256 hash digest.
-func hasChecksum(n int64) bool {
-	// 512 is chosen because it's a minimum disk sector size
-	// smaller than (and multiplies to) OS page size in most systems
-	return (n % 512) == sha256.Size
-}
+// progressLogInterval is the interval of the logs of the progress of a
+// snapshot read.
+const progressLogInterval = 10 * time.Second
 
 // SaveWithVersion fetches snapshot from remote etcd server, saves data
 // to target path and returns server version. If the context "ctx" is canceled or timed out,
@@ -45,8 +40,9 @@ func hasChecksum(n int64) bool {
 // in client configuration. Snapshot API must be requested to a
 // selected node, and saved snapshot is the point-in-time state of
 // the selected node.
-// Etcd <v3.6 will return "" as version.
-func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, dbPath string) (string, error) {
+// Etcd <v3.6 will return "" as version. The appended sha256 digest is
+// verified before the snapshot is moved to the target path.
+func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, dbPath string, opts ...clientv3.SnapshotOption) (string, error) {
 	cfg.Logger = lg.Named("client")
 	if len(cfg.Endpoints) != 1 {
 		return "", fmt.Errorf("snapshot must be requested to one selected node, not multiple %v", cfg.Endpoints)
@@ -83,7 +79,7 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 	lg.Info("created temporary db file", zap.String("path", partpath))
 
 	start := time.Now()
-	resp, err := cli.SnapshotWithVersion(ctx)
+	resp, progressc, err := cli.SnapshotWithProgress(ctx, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -94,14 +90,12 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 		}
 	}()
 	lg.Info("fetching snapshot", zap.String("endpoint", cfg.Endpoints[0]))
+	go logProgress(lg, progressc)
 	var size int64
 	size, err = io.Copy(f, resp.Snapshot)
 	if err != nil {
 		return resp.Version, fmt.Errorf("could not write snapshot: %w", err)
 	}
-	if !hasChecksum(size) {
-		return resp.Version, fmt.Errorf("sha256 checksum not found [bytes: %d]", size)
-	}
 	if err = fileutil.Fsync(f); err != nil {
 		return resp.Version, fmt.Errorf("could not fsync snapshot: %w", err)
 	}
@@ -121,3 +115,67 @@ func SaveWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, d
 	lg.Info("saved", zap.String("path", dbPath))
 	return resp.Version, nil
 }
+
+// SaveToWriterWithVersion fetches snapshot from remote etcd server, streams
+// it to "w" and returns server version. Unlike SaveWithVersion nothing is
+// written to local disk, so a mismatch of the appended sha256 digest is
+// reported as an error after all bytes have already been written to "w".
+// The same single endpoint requirement as SaveWithVersion applies.
+func SaveToWriterWithVersion(ctx context.Context, lg *zap.Logger, cfg clientv3.Config, w io.Writer, opts ...clientv3.SnapshotOption) (string, error) {
+	cfg.Logger = lg.Named("client")
+	if len(cfg.Endpoints) != 1 {
+		return "", fmt.Errorf("snapshot must be requested to one selected node, not multiple %v", cfg.Endpoints)
+	}
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = cli.Close()
+		if err != nil {
+			lg.Error("Failed to close client", zap.Error(err))
+		}
+	}()
+
+	start := time.Now()
+	resp, progressc, err := cli.SnapshotWithProgress(ctx, opts...)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err = resp.Snapshot.Close()
+		if err != nil {
+			lg.Error("Could not close snapshot stream", zap.Error(err))
+		}
+	}()
+	lg.Info("fetching snapshot", zap.String("endpoint", cfg.Endpoints[0]))
+	go logProgress(lg, progressc)
+
+	size, err := io.Copy(w, resp.Snapshot)
+	if err != nil {
+		return resp.Version, fmt.Errorf("could not write snapshot: %w", err)
+	}
+	lg.Info("fetched snapshot",
+		zap.String("endpoint", cfg.Endpoints[0]),
+		zap.String("size", humanize.Bytes(uint64(size))),
+		zap.Duration("took", time.Since(start)),
+		zap.String("etcd-version", resp.Version),
+	)
+	return resp.Version, nil
+}
+
+// logProgress logs the progress of the snapshot read every
+// progressLogInterval, until the stream is done.
+func logProgress(lg *zap.Logger, progressc <-chan clientv3.SnapshotProgress) {
+	last := time.Now()
+	for p := range progressc {
+		if time.Since(last) < progressLogInterval {
+			continue
+		}
+		last = time.Now()
+		lg.Info("fetching snapshot",
+			zap.String("read", humanize.Bytes(uint64(p.BytesRead))),
+			zap.String("total", humanize.Bytes(uint64(p.TotalHint))),
+		)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/srv"
+)
+
+// lookupSRVEndpoints returns the client endpoints listed by the DNS SRV
+// records of domain. Non-const so modifiable by tests.
+var lookupSRVEndpoints = func(domain, serviceName string) ([]string, error) {
+	srvs, err := srv.GetClient("etcd-client", domain, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	return srvs.Endpoints, nil
+}
+
+// SyncSRV re-resolves the DNS SRV records of Config.DiscoverySRV and
+// replaces the endpoints of the client if they changed. The endpoints are
+// left unchanged if the records cannot be resolved or are empty.
+func (c *Client) SyncSRV() error {
+	eps, err := lookupSRVEndpoints(c.cfg.DiscoverySRV, c.cfg.DiscoverySRVName)
+	if err != nil {
+		return err
+	}
+	if len(eps) == 0 {
+		return ErrNoAvailableEndpoints
+	}
+
+	current := c.Endpoints()
+	var added, removed []string
+	for _, ep := range eps {
+		if !slices.Contains(current, ep) {
+			added = append(added, ep)
+		}
+	}
+	for _, ep := range current {
+		if !slices.Contains(eps, ep) {
+			removed = append(removed, ep)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	c.SetEndpoints(eps...)
+	c.GetLogger().Info(
+		"set etcd endpoints from DNS SRV records",
+		zap.String("discovery-srv", c.cfg.DiscoverySRV),
+		zap.Strings("added", added),
+		zap.Strings("removed", removed),
+	)
+	return nil
+}
+
+func (c *Client) autoSyncSRV() {
+	if c.cfg.AutoSyncSRVInterval == time.Duration(0) || c.cfg.DiscoverySRV == "" {
+		return
+	}
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(c.cfg.AutoSyncSRVInterval):
+			// the member list is more accurate than the records, so they are
+			// only used once the client cannot sync with its endpoints
+			if c.cfg.AutoSyncInterval > 0 && !c.memberSyncFailed.Load() {
+				continue
+			}
+			if err := c.SyncSRV(); err != nil {
+				c.GetLogger().Info("Auto sync endpoints from DNS SRV records failed.", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSRV replaces the DNS SRV lookups with the given endpoints or error.
+type fakeSRV struct {
+	mu      sync.Mutex
+	eps     []string
+	err     error
+	lookups int
+}
+
+func (f *fakeSRV) set(err error, eps ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.eps, f.err = eps, err
+}
+
+func (f *fakeSRV) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lookups
+}
+
+func useFakeSRV(t *testing.T) *fakeSRV {
+	f := &fakeSRV{}
+	old := lookupSRVEndpoints
+	lookupSRVEndpoints = func(domain, serviceName string) ([]string, error) {
+		require.Equal(t, "example.com", domain)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.lookups++
+		return f.eps, f.err
+	}
+	t.Cleanup(func() { lookupSRVEndpoints = old })
+	return f
+}
+
+func TestSyncSRV(t *testing.T) {
+	f := useFakeSRV(t)
+	c, err := NewClient(t, Config{
+		Endpoints:    []string{"http://a:2379", "http://b:2379"},
+		DiscoverySRV: "example.com",
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	f.set(nil, "http://b:2379", "http://c:2379")
+	require.NoError(t, c.SyncSRV())
+	require.Equal(t, []string{"http://b:2379", "http://c:2379"}, c.Endpoints())
+
+	// failures keep the current endpoints
+	f.set(errors.New("no such host"))
+	require.Error(t, c.SyncSRV())
+	require.Equal(t, []string{"http://b:2379", "http://c:2379"}, c.Endpoints())
+	f.set(nil)
+	require.ErrorIs(t, c.SyncSRV(), ErrNoAvailableEndpoints)
+	require.Equal(t, []string{"http://b:2379", "http://c:2379"}, c.Endpoints())
+}
+
+func TestAutoSyncSRV(t *testing.T) {
+	f := useFakeSRV(t)
+	f.set(nil, "http://b:2379")
+	c, err := NewClient(t, Config{
+		Endpoints:           []string{"http://a:2379"},
+		DiscoverySRV:        "example.com",
+		AutoSyncSRVInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.Eventually(t, func() bool {
+		eps := c.Endpoints()
+		return len(eps) == 1 && eps[0] == "http://b:2379"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAutoSyncSRVAfterMemberSyncFailed(t *testing.T) {
+	f := useFakeSRV(t)
+	f.set(nil, "http://b:2379")
+	c, err := NewClient(t, Config{
+		Endpoints:           []string{"http://a:2379"},
+		DiscoverySRV:        "example.com",
+		AutoSyncSRVInterval: 10 * time.Millisecond,
+		AutoSyncInterval:    time.Hour,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+
+	// the records are not resolved while the member list can be synced
+	time.Sleep(100 * time.Millisecond)
+	require.Zero(t, f.count())
+	require.Equal(t, []string{"http://a:2379"}, c.Endpoints())
+
+	c.memberSyncFailed.Store(true)
+	require.Eventually(t, func() bool {
+		eps := c.Endpoints()
+		return len(eps) == 1 && eps[0] == "http://b:2379"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tokenExpiry returns the expiry of a JWT from its exp claim, or the zero time
+// if token is not a JWT with one. The signature is not verified, since the
+// token is only inspected to refresh it in time.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(claims.Exp), 0)
+}
+
+func (c *Client) tokenRefreshFraction() float64 {
+	if f := c.cfg.TokenRefreshFraction; f > 0 && f < 1 {
+		return f
+	}
+	return defaultTokenRefreshFraction
+}
+
+// scheduleTokenRefresh re-authenticates in the background once the refresh
+// fraction of the time to live of token has elapsed, so that requests do not
+// fail on an expired token first. The new token replaces the old one in the
+// credentials bundle, which in-flight requests do not notice. Watch streams
+// keep the token they were opened with until they are re-created, once the
+// server cancels them.
+func (c *Client) scheduleTokenRefresh(token string) {
+	ttl := c.cfg.TokenTTL
+	if exp := tokenExpiry(token); !exp.IsZero() {
+		ttl = time.Until(exp)
+	}
+
+	c.tokenRefreshMu.Lock()
+	defer c.tokenRefreshMu.Unlock()
+	if c.tokenRefresh != nil {
+		c.tokenRefresh.Stop()
+		c.tokenRefresh = nil
+	}
+	if ttl <= 0 || c.ctx.Err() != nil {
+		return
+	}
+	expiry := time.Now().Add(ttl)
+	c.tokenRefresh = time.AfterFunc(time.Duration(float64(ttl)*c.tokenRefreshFraction()), func() {
+		ctx, cancel := context.WithDeadline(c.ctx, expiry)
+		defer cancel()
+		if err := c.getToken(ctx); err != nil && c.ctx.Err() == nil {
+			c.GetLogger().Warn("failed to refresh auth token before it expires", zap.Time("expiry", expiry), zap.Error(err))
+		}
+	})
+}
+
+// stopTokenRefresh stops the background refresh of the token, if any.
+func (c *Client) stopTokenRefresh() {
+	c.tokenRefreshMu.Lock()
+	defer c.tokenRefreshMu.Unlock()
+	if c.tokenRefresh != nil {
+		c.tokenRefresh.Stop()
+		c.tokenRefresh = nil
+	}
+}
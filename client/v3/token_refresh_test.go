@@ -0,0 +1,111 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/v3/credentials"
+)
+
+func TestTokenExpiry(t *testing.T) {
+	jwt := func(payload string) string {
+		return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2ln"
+	}
+	tests := []struct {
+		token string
+		want  time.Time
+	}{
+		{token: jwt(`{"exp":1700000000,"username":"root"}`), want: time.Unix(1700000000, 0)},
+		{token: jwt(`{"username":"root"}`)},
+		{token: jwt(`not json`)},
+		{token: "e30.!!!.c2ln"},
+		// simple token
+		{token: "UjjVXdaAYiBGmdKJ.12"},
+		{token: ""},
+	}
+	for _, tt := range tests {
+		assert.Truef(t, tt.want.Equal(tokenExpiry(tt.token)), "token %q", tt.token)
+	}
+}
+
+// countingAuth hands out a new token on every authentication.
+type countingAuth struct {
+	Auth
+	n atomic.Int64
+}
+
+func (a *countingAuth) Authenticate(ctx context.Context, _, _ string) (*AuthenticateResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	n := a.n.Add(1)
+	return &AuthenticateResponse{Token: fmt.Sprintf("token-%d", n)}, nil
+}
+
+func TestScheduleTokenRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	auth := &countingAuth{}
+	c := &Client{
+		Auth:            auth,
+		ctx:             ctx,
+		cancel:          cancel,
+		cfg:             Config{TokenTTL: 40 * time.Millisecond, TokenRefreshFraction: 0.5},
+		Username:        "root",
+		Password:        "123",
+		authTokenBundle: credentials.NewPerRPCCredentialBundle(),
+	}
+	c.lg.Store(zaptest.NewLogger(t))
+	token := func() string {
+		md, err := c.authTokenBundle.PerRPCCredentials().GetRequestMetadata(t.Context())
+		require.NoError(t, err)
+		return md["token"]
+	}
+
+	require.NoError(t, c.getToken(t.Context()))
+	assert.Equal(t, "token-1", token())
+
+	// the token is refreshed in the background every 20ms
+	require.Eventually(t, func() bool { return auth.n.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	assert.NotEqual(t, "token-1", token())
+
+	// without a connection, Close returns the error of the canceled context
+	c.Close()
+	n := auth.n.Load()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, n, auth.n.Load())
+}
+
+func TestScheduleTokenRefreshWithoutTTL(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := &Client{ctx: ctx, cancel: cancel}
+	c.scheduleTokenRefresh("UjjVXdaAYiBGmdKJ.12")
+	assert.Nil(t, c.tokenRefresh)
+
+	// an expired JWT is not refreshed in the background
+	exp := base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, `{"exp":%d}`, time.Now().Add(-time.Minute).Unix()))
+	c.scheduleTokenRefresh("e30." + exp + ".c2ln")
+	assert.Nil(t, c.tokenRefresh)
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+const tracerName = "go.etcd.io/etcd/client/v3"
+
+// The attributes of the spans of unary requests.
+const (
+	// TraceAttrMethod is the etcd method of the request, e.g. "Range".
+	TraceAttrMethod = attribute.Key("etcd.method")
+	// TraceAttrKey is the key of the request, or its SHA-256 in hex if
+	// Config.RedactKeys is set.
+	TraceAttrKey = attribute.Key("etcd.key")
+	// TraceAttrRevision is the revision of the header of the response.
+	TraceAttrRevision = attribute.Key("etcd.revision")
+	// TraceAttrStatusCode is the gRPC status code of a failed request.
+	TraceAttrStatusCode = attribute.Key("rpc.grpc.status_code")
+)
+
+// tracingUnaryInterceptor wraps the retry interceptor next in a span, so
+// that a request is traced once however many attempts it takes.
+func (c *Client) tracingUnaryInterceptor(next grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	tracer := c.cfg.TracerProvider.Tracer(tracerName)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		attrs := []attribute.KeyValue{TraceAttrMethod.String(path.Base(method))}
+		if r, ok := req.(interface{ GetKey() []byte }); ok {
+			attrs = append(attrs, TraceAttrKey.String(c.traceKey(r.GetKey())))
+		}
+		ctx, span := tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		err := next(ctx, method, req, reply, cc, invoker, opts...)
+		if err != nil {
+			span.SetAttributes(TraceAttrStatusCode.Int64(int64(status.Code(err))))
+			span.RecordError(err)
+			span.SetStatus(otelcodes.Error, err.Error())
+			return err
+		}
+		if r, ok := reply.(interface{ GetHeader() *pb.ResponseHeader }); ok && r.GetHeader() != nil {
+			span.SetAttributes(TraceAttrRevision.Int64(r.GetHeader().Revision))
+		}
+		return nil
+	}
+}
+
+// traceKey returns the key as recorded in spans.
+func (c *Client) traceKey(key []byte) string {
+	if !c.cfg.RedactKeys {
+		return string(key)
+	}
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -33,10 +34,15 @@ import (
 )
 
 const (
-	EventTypeDelete = mvccpb.Event_DELETE
-	EventTypePut    = mvccpb.Event_PUT
+	EventTypeDelete      = mvccpb.Event_DELETE
+	EventTypePut         = mvccpb.Event_PUT
+	EventTypeDeleteRange = mvccpb.Event_DELETE_RANGE
 
 	closeSendErrTimeout = 250 * time.Millisecond
+	// drainProgressInterval is the interval at which a draining stream
+	// requests progress until the server sends it; the server only does so
+	// once all the watchers of the stream are synced
+	drainProgressInterval = 100 * time.Millisecond
 
 	// AutoWatchID is the watcher ID passed in WatchStream.Watch when no
 	// user-provided ID is available. If pass, an ID will automatically be assigned.
@@ -85,6 +91,17 @@ type Watcher interface {
 	// Otherwise, as long as the context has not been canceled or timed out,
 	// watch will retry on other recoverable errors forever until reconnected.
 	//
+	// Use WatchWithHandle to get why the channel was closed once it is
+	// drained, rather than from its last response.
+	//
+	// Identical watches from the current revision on the same stream share
+	// one watcher on the server, unless they are created with
+	// "WithCreatedNotify", "WithCompactionRetry", "WithLinearizableStart" or
+	// "WithAckWindow".
+	// Each of them receives a copy of the responses on its own channel.
+	// Canceling the context of one of them only closes its channel; the
+	// shared watcher is canceled when the context of the last one is done.
+	//
 	// TODO: explicitly set context error in the last "WatchResponse" message and close channel?
 	// Currently, client contexts are overwritten with "valCtx" that never closes.
 	// TODO(v3.4): configure watch retry policy, limit maximum retry number
@@ -94,10 +111,40 @@ type Watcher interface {
 	// RequestProgress requests a progress notify response be sent in all watch channels.
 	RequestProgress(ctx context.Context) error
 
+	// CancelAll cancels all the watch requests of the watcher, and returns
+	// once the server confirmed the cancellation of each of them, or ctx is
+	// done. Their channels are closed, and their handles get
+	// ErrWatchesCanceled. Unlike Close, the watcher can still be used, and
+	// unlike canceling the context of each watch, the watchers are gone
+	// from the server once it returns, for instance during a controlled
+	// shutdown.
+	CancelAll(ctx context.Context) error
+
 	// Close closes the watcher and cancels all watch requests.
 	Close() error
 }
 
+// WatchObserver observes the watchers of a client, so that applications can
+// record metrics or traces of their watches without wrapping the watch
+// channels. It is set with Config.WatchObserver. Its methods are called from
+// the goroutines serving the watch streams, so they must not block. The
+// context is the one given to Watch.
+type WatchObserver interface {
+	// OnWatchCreate is called once the watcher of key is created on the
+	// server. It is not called again when the watcher is resumed on another
+	// stream.
+	OnWatchCreate(ctx context.Context, key, end string)
+	// OnWatchEvents is called with the events of every response of the
+	// watcher of key.
+	OnWatchEvents(ctx context.Context, key string, events []*Event)
+	// OnWatchError is called when the watcher of key fails, or when its
+	// stream fails and it is resumed on another one.
+	OnWatchError(ctx context.Context, key string, err error)
+	// OnWatchCancel is called once the watcher of key created before is
+	// closed, whether canceled by the caller or by the server.
+	OnWatchCancel(ctx context.Context, key string)
+}
+
 type WatchResponse struct {
 	Header *pb.ResponseHeader
 	Events []*Event
@@ -113,6 +160,37 @@ type WatchResponse struct {
 	// Created is used to indicate the creation of the watcher.
 	Created bool
 
+	// Snapshot is set when the watcher was created WithSnapshotFallback and
+	// the revision it needed was compacted. The events are then the full
+	// state of the watched range at Header.Revision.
+	Snapshot bool
+
+	// Warning is set by the server when the watcher has a large history to
+	// catch up on from its start revision. It is set on the created response,
+	// or on the first response if the watcher was not created with
+	// WithCreatedNotify.
+	Warning string
+
+	// CompactWarningRevision is set when the watcher was created
+	// WithCompactWarning and a compaction at that revision got within its
+	// margin of the next revision the watcher has to receive. The watcher is
+	// not canceled.
+	CompactWarningRevision int64
+
+	// CompareResult is the result of the compare of a watcher created
+	// WithCompare at Header.Revision. On the created response, it is the
+	// result before the start revision of the watcher.
+	CompareResult bool
+
+	// catchUp is set when the events are replayed from the history
+	// rather than sent live.
+	catchUp bool
+
+	// drained is set on the final response of a watcher drained by a
+	// member shutting down; it only moves the revision the watcher
+	// resumes from and is not delivered.
+	drained bool
+
 	closeErr error
 
 	// CancelReason is a reason of canceling watch
@@ -135,9 +213,16 @@ func (wr *WatchResponse) Err() error {
 	return nil
 }
 
+// IsCatchUp returns true if the events of the WatchResponse are replayed from
+// the history while the watcher catches up with the current revision, or are
+// a snapshot of the watched range, rather than sent live as they happen.
+func (wr *WatchResponse) IsCatchUp() bool {
+	return wr.catchUp
+}
+
 // IsProgressNotify returns true if the WatchResponse is progress notification.
 func (wr *WatchResponse) IsProgressNotify() bool {
-	return len(wr.Events) == 0 && !wr.Canceled && !wr.Created && wr.CompactRevision == 0 && wr.Header.GetRevision() != 0
+	return len(wr.Events) == 0 && !wr.Canceled && !wr.Created && !wr.Snapshot && wr.CompactRevision == 0 && wr.CompactWarningRevision == 0 && wr.Header.GetRevision() != 0
 }
 
 // watcher implements the Watcher interface
@@ -151,6 +236,18 @@ type watcher struct {
 	// streams holds all the active grpc streams keyed by ctx value.
 	streams map[string]*watchGRPCStream
 	lg      *zap.Logger
+	// draining is set once the watcher is drained by the shutdown of its
+	// client, and refuses new watch requests
+	draining bool
+
+	// observer, if set, is notified of the lifecycle of the watchers
+	observer WatchObserver
+	// backoff is the backoff of the reconnections of the grpc streams
+	backoff WatchRetryBackoff
+
+	// refreshesToken is set if the client authenticates with a user name and
+	// password, and so gets a fresh token for every grpc stream
+	refreshesToken bool
 }
 
 // watchGRPCStream tracks all watch resources attached to a single grpc stream.
@@ -164,11 +261,16 @@ type watchGRPCStream struct {
 	// ctxKey is the key used when looking up this stream's context
 	ctxKey string
 	cancel context.CancelFunc
+	// cancelWatchClient closes the current grpc stream
+	cancelWatchClient context.CancelFunc
 
 	// substreams holds all active watchers on this grpc stream
 	substreams map[int64]*watcherStream
 	// resuming holds all resuming watchers on this grpc stream
 	resuming []*watcherStream
+	// shares holds the substreams shared by identical watch requests, by
+	// their share key
+	shares map[string]*watchShare
 
 	// reqc sends a watch request from Watch() to the main goroutine
 	reqc chan watchStreamRequest
@@ -180,6 +282,16 @@ type watchGRPCStream struct {
 	errc chan error
 	// closingc gets the watcherStream of closing watchers
 	closingc chan *watcherStream
+	// retryc gets the watcherStream of watchers to re-create after their
+	// compaction retry handler returned
+	retryc chan *watcherStream
+	// ackc signals that watchers with an ack window received responses
+	ackc chan struct{}
+	// cancelAllc gets the CancelAll requests of the stream
+	cancelAllc chan *cancelAllRequest
+	// cancelAlls holds the CancelAll requests waiting for their watchers
+	// to close
+	cancelAlls []*cancelAllRequest
 	// wg is Done when all substream goroutines have exited
 	wg sync.WaitGroup
 
@@ -188,7 +300,9 @@ type watchGRPCStream struct {
 	// closeErr is the error that closed the watch stream
 	closeErr error
 
-	lg *zap.Logger
+	lg       *zap.Logger
+	observer WatchObserver
+	backoff  WatchRetryBackoff
 }
 
 // watchStreamRequest is a union of the supported watch request operation types
@@ -216,8 +330,42 @@ type watchRequest struct {
 
 	// filters is the list of events to filter out
 	filters []pb.WatchCreateRequest_FilterType
+	// only send PUT events whose value has this prefix
+	valuePrefix []byte
+	// only send PUT events whose JSON value matches this selector
+	valueSelector string
+	// order in which the filters are evaluated
+	filterOrder pb.WatchCreateRequest_FilterOrder
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// omit the values of the key-value pairs in the events
+	keysOnly bool
+	// send a snapshot of the range instead of canceling on compaction
+	snapshotFallback bool
+	// only send the latest event of each key while catching up
+	latestPerKey bool
+	// only send every Nth PUT event of each key
+	sampleEveryN int64
+	// maximum number of events of each response
+	maxEventsPerResponse int64
+	// only send the key, mod revision and version of the events
+	versionOnly bool
+	// warn of compactions within that many revisions of the next revision
+	compactWarningMargin int64
+	// have the server send heartbeats on the idle stream
+	keepAliveInterval time.Duration
+	// wait for a linearizable read before creating the watcher
+	linearizableStart bool
+	// only be sent the revisions up to that many past the acknowledged one
+	ackWindow int64
+	// send a range deletion as a single DELETE_RANGE event
+	collapseDeletes bool
+	// only send the events that flip the result of the compare on the key
+	compare *pb.Compare
+	// re-create the watcher from the revision it returns on compaction
+	compactionRetry func(compactRev int64) (int64, error)
+	// handle, if set, gets the error that closed the watch channel
+	handle *WatchHandle
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
@@ -225,6 +373,19 @@ type watchRequest struct {
 // progressRequest is issued by the subscriber to request watch progress
 type progressRequest struct{}
 
+// cancelAllRequest is issued by CancelAll to cancel all the watchers of a
+// stream
+type cancelAllRequest struct {
+	// drain is set if the watchers are only canceled once the server sent
+	// their events so far, and flush their buffered responses before their
+	// channels close
+	drain bool
+	// pending holds the watchers still to be closed
+	pending map[*watcherStream]struct{}
+	// donec closes once all the watchers are closed
+	donec chan struct{}
+}
+
 // watcherStream represents a registered watcher
 type watcherStream struct {
 	// initReq is the request that initiated this request
@@ -240,6 +401,27 @@ type watcherStream struct {
 	closing bool
 	// id is the registered watch id on the grpc stream
 	id int64
+	// created is set once the watcher was first created on the server
+	created bool
+	// authRetried is set once the creation of the watcher was retried on a
+	// new grpc stream because the token of the stream had expired
+	authRetried bool
+	// share is set if the substream is shared by identical watch requests
+	share *watchShare
+	// err is the error of the last response sent to outc
+	err error
+	// rejectErr is why the server refused to create the watcher
+	rejectErr error
+	// receivedRev is the revision of the last response received from outc,
+	// for watchers with an ack window
+	receivedRev atomic.Int64
+	// ackedRev is the last revision acknowledged to the server
+	ackedRev int64
+	// canceledAll is set once the watcher is canceled by CancelAll
+	canceledAll bool
+	// drained is set once the watcher is canceled by the drain of the
+	// stream, so it flushes its buffered responses before it closes
+	drained bool
 
 	// buf holds all events received from etcd but not yet consumed by the client
 	buf []*WatchResponse
@@ -257,14 +439,49 @@ func NewWatchFromWatchClient(wc pb.WatchClient, c *Client) Watcher {
 	w := &watcher{
 		remote:  wc,
 		streams: make(map[string]*watchGRPCStream),
+		backoff: WatchRetryBackoff{}.withDefaults(),
 	}
 	if c != nil {
 		w.callOpts = c.callOpts
 		w.lg = c.GetLogger()
+		w.observer = c.cfg.WatchObserver
+		w.backoff = c.cfg.WatchRetryBackoff.withDefaults()
+		w.refreshesToken = c.Username != "" && c.Password != ""
 	}
 	return w
 }
 
+// WatchUntilDelete blocks until the given key is deleted, returning
+// immediately if the key does not exist. It is useful to wait for a key held
+// by a lease, such as a lock, to go away. The watch it opens is closed when it
+// returns.
+func WatchUntilDelete(ctx context.Context, c *Client, key string) error {
+	resp, err := c.Get(ctx, key, WithCountOnly())
+	if err != nil {
+		return err
+	}
+	if resp.Count == 0 {
+		return nil
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for wr := range c.Watch(wctx, key, WithRev(resp.Header.Revision+1), WithFilterPut()) {
+		if err = wr.Err(); err != nil {
+			return err
+		}
+		for _, ev := range wr.Events {
+			if ev.Type == EventTypeDelete {
+				return nil
+			}
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("clientv3: watch closed before the key was deleted")
+}
+
 // never closes
 var (
 	valCtxCh = make(chan struct{})
@@ -288,13 +505,19 @@ func (w *watcher) newWatcherGRPCStream(inctx context.Context) *watchGRPCStream {
 		ctxKey:     streamKeyFromCtx(inctx),
 		cancel:     cancel,
 		substreams: make(map[int64]*watcherStream),
+		shares:     make(map[string]*watchShare),
 		respc:      make(chan *pb.WatchResponse),
 		reqc:       make(chan watchStreamRequest),
 		donec:      make(chan struct{}),
 		errc:       make(chan error, 1),
 		closingc:   make(chan *watcherStream),
+		retryc:     make(chan *watcherStream),
+		ackc:       make(chan struct{}, 1),
+		cancelAllc: make(chan *cancelAllRequest),
 		resumec:    make(chan struct{}),
 		lg:         w.lg,
+		observer:   w.observer,
+		backoff:    w.backoff,
 	}
 	go wgs.run()
 	return wgs
@@ -311,19 +534,43 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	if ow.filterDelete {
 		filters = append(filters, pb.WatchCreateRequest_NODELETE)
 	}
+	filterOrder := pb.WatchCreateRequest_TYPE_FIRST
+	if ow.valueFiltersFirst {
+		filterOrder = pb.WatchCreateRequest_VALUE_FIRST
+	}
 
 	wr := &watchRequest{
-		ctx:                ctx,
-		createdNotify:      ow.createdNotify,
-		key:                string(ow.key),
-		end:                string(ow.end),
-		rev:                ow.rev,
-		progressNotify:     ow.progressNotify,
-		fragment:           ow.fragment,
-		watchBufLogEnabled: ow.watchBufLogEnabled,
-		filters:            filters,
-		prevKV:             ow.prevKV,
-		retc:               make(chan chan WatchResponse, 1),
+		ctx:                  ctx,
+		createdNotify:        ow.createdNotify,
+		key:                  string(ow.key),
+		end:                  string(ow.end),
+		rev:                  ow.rev,
+		progressNotify:       ow.progressNotify,
+		fragment:             ow.fragment,
+		watchBufLogEnabled:   ow.watchBufLogEnabled,
+		filters:              filters,
+		valuePrefix:          ow.filterValuePrefix,
+		valueSelector:        ow.filterValueSelector,
+		filterOrder:          filterOrder,
+		prevKV:               ow.prevKV,
+		keysOnly:             ow.keysOnly,
+		snapshotFallback:     ow.snapshotFallback,
+		latestPerKey:         ow.latestPerKey,
+		sampleEveryN:         ow.sampleEveryN,
+		maxEventsPerResponse: ow.maxEventsPerResponse,
+		versionOnly:          ow.versionOnly,
+		compactWarningMargin: ow.compactWarningMargin,
+		keepAliveInterval:    ow.keepAliveInterval,
+		linearizableStart:    ow.linearizableStart,
+		ackWindow:            ow.ackWindow,
+		collapseDeletes:      ow.collapseDeletes,
+		compare:              ow.compare,
+		compactionRetry:      ow.compactionRetry,
+		handle:               ow.watchHandle,
+		retc:                 make(chan chan WatchResponse, 1),
+	}
+	if wr.handle != nil {
+		wr.handle.bound.Store(true)
 	}
 
 	ok := false
@@ -333,9 +580,14 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 	for {
 		// find or allocate appropriate grpc watch stream
 		w.mu.Lock()
-		if w.streams == nil {
+		if w.streams == nil || w.draining {
 			// closed
+			err := ErrWatcherClosed
+			if w.draining {
+				err = ErrClientShuttingDown
+			}
 			w.mu.Unlock()
+			wr.handle.close(err)
 			ch := make(chan WatchResponse)
 			close(ch)
 			return ch
@@ -364,6 +616,7 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 			ok = false
 			if wgs.closeErr != nil {
 				closeCh <- WatchResponse{Header: &pb.ResponseHeader{}, Canceled: true, closeErr: wgs.closeErr}
+				wr.handle.close(v3rpc.Error(wgs.closeErr))
 				break
 			}
 			// retry; may have dropped stream from no ctxs
@@ -379,6 +632,7 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 			case <-donec:
 				if wgs.closeErr != nil {
 					closeCh <- WatchResponse{Header: &pb.ResponseHeader{}, Canceled: true, closeErr: wgs.closeErr}
+					wr.handle.close(v3rpc.Error(wgs.closeErr))
 					break
 				}
 				// retry; may have dropped stream from no ctxs
@@ -388,6 +642,7 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		break
 	}
 
+	wr.handle.close(ctx.Err())
 	close(closeCh)
 	return closeCh
 }
@@ -443,6 +698,54 @@ func (w *watcher) RequestProgress(ctx context.Context) (err error) {
 	}
 }
 
+// CancelAll cancels all the watch requests on every grpc stream of the watcher.
+func (w *watcher) CancelAll(ctx context.Context) error {
+	return w.cancelStreams(ctx, false)
+}
+
+// drain cancels the watchers of every grpc stream once the server sent them
+// the events committed so far, and waits for their channels to get the
+// buffered responses and close. New watch requests are refused from then on.
+func (w *watcher) drain(ctx context.Context) error {
+	w.mu.Lock()
+	w.draining = true
+	w.mu.Unlock()
+	return w.cancelStreams(ctx, true)
+}
+
+// cancelStreams cancels the watchers of every grpc stream, draining them if
+// drain is set, and waits for their channels to close.
+func (w *watcher) cancelStreams(ctx context.Context, drain bool) error {
+	w.mu.Lock()
+	streams := make([]*watchGRPCStream, 0, len(w.streams))
+	for _, wgs := range w.streams {
+		streams = append(streams, wgs)
+	}
+	w.mu.Unlock()
+
+	reqs := make([]*cancelAllRequest, len(streams))
+	for i, wgs := range streams {
+		reqs[i] = &cancelAllRequest{drain: drain, donec: make(chan struct{})}
+		select {
+		case wgs.cancelAllc <- reqs[i]:
+		case <-wgs.donec:
+			// the stream closed its watchers already
+			close(reqs[i].donec)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for i, wgs := range streams {
+		select {
+		case <-reqs[i].donec:
+		case <-wgs.donec:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (w *watchGRPCStream) close() (err error) {
 	w.cancel()
 	<-w.donec
@@ -463,16 +766,37 @@ func (w *watcher) closeStream(wgs *watchGRPCStream) {
 	w.mu.Unlock()
 }
 
-func (w *watchGRPCStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream) {
+// addSubstream registers ws as the watcher created by resp. It returns false
+// if the server refused to create the watcher, in which case the goroutine of
+// ws is closing.
+func (w *watchGRPCStream) addSubstream(resp *pb.WatchResponse, ws *watcherStream) bool {
 	// check watch ID for backward compatibility (<= v3.3)
 	if resp.WatchId == InvalidWatchID || (resp.Canceled && resp.CancelReason != "") {
 		w.closeErr = v3rpc.Error(errors.New(resp.CancelReason))
+		ws.rejectErr = w.closeErr
+		if w.observer != nil {
+			w.observer.OnWatchError(ws.initReq.ctx, ws.initReq.key, w.closeErr)
+		}
 		// failed; no channel
 		close(ws.recvc)
-		return
+		return false
 	}
 	ws.id = resp.WatchId
+	ws.authRetried = false
+	// the server counts the revisions before the start of the watcher as
+	// acknowledged
+	ws.ackedRev = resp.Header.Revision
+	if ws.initReq.rev != 0 {
+		ws.ackedRev = ws.initReq.rev - 1
+	}
 	w.substreams[ws.id] = ws
+	if !ws.created {
+		ws.created = true
+		if w.observer != nil {
+			w.observer.OnWatchCreate(ws.initReq.ctx, ws.initReq.key, ws.initReq.end)
+		}
+	}
+	return true
 }
 
 func (w *watchGRPCStream) sendCloseSubstream(ws *watcherStream, resp *WatchResponse) {
@@ -481,21 +805,38 @@ func (w *watchGRPCStream) sendCloseSubstream(ws *watcherStream, resp *WatchRespo
 	case <-ws.initReq.ctx.Done():
 	case <-time.After(closeSendErrTimeout):
 	}
+	ws.initReq.handle.close(resp.Err())
 	close(ws.outc)
 }
 
 func (w *watchGRPCStream) closeSubstream(ws *watcherStream) {
+	if ws.created && w.observer != nil {
+		w.observer.OnWatchCancel(ws.initReq.ctx, ws.initReq.key)
+	}
 	// send channel response in case stream was never established
 	select {
 	case ws.initReq.retc <- ws.outc:
 	default:
 	}
-	// close subscriber's channel
-	if closeErr := w.closeErr; closeErr != nil && ws.initReq.ctx.Err() == nil {
-		go w.sendCloseSubstream(ws, &WatchResponse{Header: &pb.ResponseHeader{}, Canceled: true, closeErr: w.closeErr})
+	// close subscriber's channel; the stream may be torn down before a
+	// rejected watcher is closed, which must get its own error
+	closeErr := w.closeErr
+	if ws.rejectErr != nil {
+		closeErr = ws.rejectErr
+	}
+	if closeErr != nil && ws.initReq.ctx.Err() == nil {
+		go w.sendCloseSubstream(ws, &WatchResponse{Header: &pb.ResponseHeader{}, Canceled: true, closeErr: closeErr})
 	} else if ws.outc != nil {
+		ws.initReq.handle.close(w.substreamErr(ws))
 		close(ws.outc)
 	}
+	if ws.share != nil {
+		ws.share.stop()
+		if w.shares[ws.share.key] == ws.share {
+			delete(w.shares, ws.share.key)
+		}
+	}
+	w.doneCancelAll(ws)
 	if ws.id != InvalidWatchID {
 		delete(w.substreams, ws.id)
 		return
@@ -508,14 +849,38 @@ func (w *watchGRPCStream) closeSubstream(ws *watcherStream) {
 	}
 }
 
+// substreamErr returns why the channel of ws is closed, when no response with
+// the error is sent to it.
+func (w *watchGRPCStream) substreamErr(ws *watcherStream) error {
+	switch {
+	case ws.err != nil:
+		return ws.err
+	case ws.drained:
+		return ErrWatcherClosed
+	case ws.canceledAll:
+		return ErrWatchesCanceled
+	case ws.initReq.ctx.Err() != nil:
+		return ws.initReq.ctx.Err()
+	case w.closeErr != nil:
+		return v3rpc.Error(w.closeErr)
+	}
+	return ErrWatcherClosed
+}
+
 // run is the root of the goroutines for managing a watcher client
 func (w *watchGRPCStream) run() {
 	var wc pb.Watch_WatchClient
 	var closeErr error
+	// reopening is set once the grpc stream is closed to be re-opened with a
+	// fresh token
+	reopening := false
 
 	// substreams marked to close but goroutine still running; needed for
 	// avoiding double-closing recvc on grpc stream teardown
 	closing := make(map[*watcherStream]struct{})
+	// substreams canceled on compaction whose goroutine is running their
+	// compaction retry handler; they are neither substreams nor resuming
+	retrying := make(map[*watcherStream]struct{})
 
 	defer func() {
 		w.closeErr = closeErr
@@ -532,9 +897,19 @@ func (w *watchGRPCStream) run() {
 				closing[ws] = struct{}{}
 			}
 		}
+		for ws := range retrying {
+			close(ws.recvc)
+		}
 		w.joinSubstreams()
-		for range closing {
-			w.closeSubstream(<-w.closingc)
+		for len(closing)+len(retrying) != 0 {
+			var ws *watcherStream
+			select {
+			case ws = <-w.closingc:
+			case ws = <-w.retryc:
+			}
+			w.closeSubstream(ws)
+			delete(closing, ws)
+			delete(retrying, ws)
 		}
 		w.wg.Wait()
 		w.owner.closeStream(w)
@@ -547,14 +922,28 @@ func (w *watchGRPCStream) run() {
 
 	cancelSet := make(map[int64]struct{})
 
+	// draining is the drain request waiting for the progress notification
+	// of the server, which drainc times out to request it again
+	var draining *cancelAllRequest
+	var drainc <-chan time.Time
+
 	var cur *pb.WatchResponse
-	backoff := time.Millisecond
+	backoff := w.backoff.Min
 	for {
 		select {
 		// Watch() requested
 		case req := <-w.reqc:
 			switch wreq := req.(type) {
 			case *watchRequest:
+				var share *watchShare
+				if key := wreq.shareKey(); key != "" {
+					if sh := w.shares[key]; sh != nil && sh.join(wreq) {
+						break
+					}
+					share = newWatchShare(key, wreq)
+					w.shares[key] = share
+					wreq = share.req
+				}
 				outc := make(chan WatchResponse, 1)
 				// TODO: pass custom watch ID?
 				ws := &watcherStream{
@@ -563,6 +952,7 @@ func (w *watchGRPCStream) run() {
 					outc:    outc,
 					// unbuffered so resumes won't cause repeat events
 					recvc: make(chan *WatchResponse),
+					share: share,
 				}
 				if ws.initReq.watchBufLogEnabled {
 					ws.bufLogger = w.newWatcherStreamBufLogger(ws, time.Now)
@@ -588,6 +978,12 @@ func (w *watchGRPCStream) run() {
 
 		// new events from the watch client
 		case pbresp := <-w.respc:
+			// the stream is up again
+			backoff = w.backoff.Min
+			if pbresp.Heartbeat {
+				// only keeps the stream alive
+				continue
+			}
 			if cur == nil || pbresp.Created || pbresp.Canceled {
 				cur = pbresp
 			} else if cur.WatchId == pbresp.WatchId {
@@ -602,9 +998,28 @@ func (w *watchGRPCStream) run() {
 				// response to head of queue creation
 				if len(w.resuming) != 0 {
 					if ws := w.resuming[0]; ws != nil {
-						w.addSubstream(pbresp, ws)
+						if w.reopensOnExpiredToken(pbresp, ws) {
+							// the token of the stream expired since it was
+							// opened; the watcher is resumed with the others
+							// on a new stream with a fresh token, once the
+							// current one is closed
+							ws.authRetried = true
+							reopening = true
+							w.cancelWatchClient()
+							cur = nil
+							break
+						}
+						if !w.addSubstream(pbresp, ws) {
+							// neither a substream nor resuming anymore, it
+							// must still be waited for on teardown
+							closing[ws] = struct{}{}
+						}
 						w.dispatchEvent(pbresp)
 						w.resuming[0] = nil
+						if ws.canceledAll && w.substreams[ws.id] == ws {
+							// canceled by CancelAll while being created
+							w.sendCancel(wc, ws.id, cancelSet)
+						}
 					}
 				}
 
@@ -617,9 +1032,20 @@ func (w *watchGRPCStream) run() {
 				// reset for next iteration
 				cur = nil
 
+			case pbresp.Canceled && pbresp.CompactRevision == 0 && pbresp.CancelReason == v3rpc.ErrServerClosing.Error():
+				// the member is shutting down and closes the stream once all
+				// its watchers are drained; the watcher has received all the
+				// events up to the header revision, and is resumed on the next
+				// stream from the revision after the header one
+				w.unicastResponse(&WatchResponse{Header: ensureWatchHeader(pbresp.Header), drained: true}, pbresp.WatchId)
+				cur = nil
+
 			case pbresp.Canceled && pbresp.CompactRevision == 0:
 				delete(cancelSet, pbresp.WatchId)
 				if ws, ok := w.substreams[pbresp.WatchId]; ok {
+					if pbresp.CancelReason != "" && w.observer != nil {
+						w.observer.OnWatchError(ws.initReq.ctx, ws.initReq.key, v3rpc.Error(errors.New(pbresp.CancelReason)))
+					}
 					// signal to stream goroutine to update closingc
 					close(ws.recvc)
 					closing[ws] = struct{}{}
@@ -628,18 +1054,37 @@ func (w *watchGRPCStream) run() {
 				// reset for next iteration
 				cur = nil
 
+			case pbresp.Canceled && pbresp.CompactRevision != 0 && w.retriesOnCompaction(pbresp.WatchId):
+				// the server canceled the watcher on compaction; its goroutine
+				// runs the retry handler and hands it back through retryc
+				ws := w.substreams[pbresp.WatchId]
+				w.dispatchEvent(pbresp)
+				delete(w.substreams, ws.id)
+				ws.id = InvalidWatchID
+				retrying[ws] = struct{}{}
+
+				// reset for next iteration
+				cur = nil
+
 			case cur.Fragment:
 				// watch response events are still fragmented
 				// continue to fetch next fragmented event arrival
 				continue
 
 			default:
+				progress := cur.WatchId == InvalidWatchID
 				// dispatch to appropriate watch stream
 				ok := w.dispatchEvent(cur)
 
 				// reset for next iteration
 				cur = nil
 
+				if progress && draining != nil {
+					// the events up to the progress revision are dispatched
+					w.cancelAll(wc, draining, closing, retrying, cancelSet)
+					draining, drainc = nil, nil
+				}
+
 				if ok {
 					break
 				}
@@ -664,12 +1109,19 @@ func (w *watchGRPCStream) run() {
 
 		// watch client failed on Recv; spawn another if possible
 		case err := <-w.errc:
-			if isHaltErr(w.ctx, err) || errors.Is(ContextError(w.ctx, err), v3rpc.ErrNoLeader) {
-				closeErr = err
-				return
+			if reopening && w.ctx.Err() == nil {
+				// the stream was closed on purpose
+				reopening = false
+			} else {
+				w.observeError(err)
+				if isHaltErr(w.ctx, err) || errors.Is(ContextError(w.ctx, err), v3rpc.ErrNoLeader) {
+					closeErr = err
+					return
+				}
+				backoff = w.backoffIfUnavailable(backoff, err)
 			}
-			backoff = w.backoffIfUnavailable(backoff, err)
 			if wc, closeErr = w.newWatchClient(); closeErr != nil {
+				w.observeError(closeErr)
 				return
 			}
 			if ws := w.nextResume(); ws != nil {
@@ -682,11 +1134,53 @@ func (w *watchGRPCStream) run() {
 		case <-w.ctx.Done():
 			return
 
+		case ws := <-w.retryc:
+			delete(retrying, ws)
+			if ws.canceledAll {
+				// canceled by CancelAll while its handler ran
+				w.closeSubstream(ws)
+				if len(w.substreams)+len(w.resuming)+len(retrying) == 0 {
+					return
+				}
+				break
+			}
+			// re-create the watcher from the revision its handler returned
+			ws.donec = make(chan struct{})
+			w.wg.Add(1)
+			go w.serveSubstream(ws, w.resumec)
+			w.resuming = append(w.resuming, ws)
+			if len(w.resuming) == 1 {
+				if err := wc.Send(ws.initReq.toPB()); err != nil {
+					w.lg.Debug("error when sending request", zap.Error(err))
+				}
+			}
+
+		case <-w.ackc:
+			w.sendAcks(wc)
+
+		case req := <-w.cancelAllc:
+			if req.drain && draining == nil && len(w.substreams) != 0 {
+				// the watchers are canceled once the server notifies the
+				// progress, after all the events so far
+				draining = req
+				w.sendProgress(wc)
+				drainc = time.After(drainProgressInterval)
+				break
+			}
+			w.cancelAll(wc, req, closing, retrying, cancelSet)
+
+		case <-drainc:
+			// the server did not notify the progress, as some watchers were
+			// not synced yet or the grpc stream was re-opened
+			w.sendProgress(wc)
+			drainc = time.After(drainProgressInterval)
+
 		case ws := <-w.closingc:
 			w.closeSubstream(ws)
 			delete(closing, ws)
+			delete(retrying, ws)
 			// no more watchers on this stream, shutdown, skip cancellation
-			if len(w.substreams)+len(w.resuming) == 0 {
+			if len(w.substreams)+len(w.resuming)+len(retrying) == 0 {
 				return
 			}
 			if ws.id != InvalidWatchID {
@@ -708,6 +1202,141 @@ func (w *watchGRPCStream) run() {
 	}
 }
 
+// cancelAll cancels the watchers of the stream for req. The established
+// watchers are canceled on the server, and the others once created, so req
+// is done once the server confirmed the cancellation of each of them.
+func (w *watchGRPCStream) cancelAll(wc pb.Watch_WatchClient, req *cancelAllRequest, closing, retrying map[*watcherStream]struct{}, cancelSet map[int64]struct{}) {
+	req.pending = make(map[*watcherStream]struct{})
+	cancel := func(ws *watcherStream) {
+		req.pending[ws] = struct{}{}
+		ws.canceledAll = true
+		ws.drained = ws.drained || req.drain
+		// identical watch requests must not join the canceled watcher
+		if ws.share != nil && w.shares[ws.share.key] == ws.share {
+			delete(w.shares, ws.share.key)
+		}
+	}
+	for _, ws := range w.substreams {
+		_, ok := closing[ws]
+		if !ok && !ws.canceledAll {
+			w.sendCancel(wc, ws.id, cancelSet)
+		}
+		cancel(ws)
+	}
+	for _, ws := range w.resuming {
+		if ws != nil {
+			cancel(ws)
+		}
+	}
+	for ws := range retrying {
+		cancel(ws)
+	}
+	if len(req.pending) == 0 {
+		close(req.donec)
+		return
+	}
+	w.cancelAlls = append(w.cancelAlls, req)
+}
+
+// doneCancelAll marks the watcher ws as closed for the pending CancelAll
+// requests.
+func (w *watchGRPCStream) doneCancelAll(ws *watcherStream) {
+	reqs := w.cancelAlls[:0]
+	for _, req := range w.cancelAlls {
+		delete(req.pending, ws)
+		if len(req.pending) == 0 {
+			close(req.donec)
+			continue
+		}
+		reqs = append(reqs, req)
+	}
+	w.cancelAlls = reqs
+}
+
+// sendCancel asks the server to cancel the watcher of the given ID.
+func (w *watchGRPCStream) sendCancel(wc pb.Watch_WatchClient, watchID int64, cancelSet map[int64]struct{}) {
+	cancelSet[watchID] = struct{}{}
+	cr := &pb.WatchRequest_CancelRequest{
+		CancelRequest: &pb.WatchCancelRequest{
+			WatchId: watchID,
+		},
+	}
+	req := &pb.WatchRequest{RequestUnion: cr}
+	w.lg.Debug("sending watch cancel request for canceled watcher", zap.Int64("watch-id", watchID))
+	if err := wc.Send(req); err != nil {
+		w.lg.Debug("failed to send watch cancel request", zap.Int64("watch-id", watchID), zap.Error(err))
+	}
+}
+
+// sendProgress asks the server for a progress notification.
+func (w *watchGRPCStream) sendProgress(wc pb.Watch_WatchClient) {
+	if err := wc.Send((&progressRequest{}).toPB()); err != nil {
+		w.lg.Debug("failed to send watch progress request", zap.Error(err))
+	}
+}
+
+// sendAcks acknowledges the revisions received by the watchers with an ack
+// window, once they received half of their window since their last ack, so
+// that the server does not wait for the acks to send the next revisions.
+func (w *watchGRPCStream) sendAcks(wc pb.Watch_WatchClient) {
+	for _, ws := range w.substreams {
+		if ws.initReq.ackWindow <= 0 {
+			continue
+		}
+		rev := ws.receivedRev.Load()
+		if rev < ws.ackedRev+max(ws.initReq.ackWindow/2, 1) {
+			continue
+		}
+		ws.ackedRev = rev
+		ar := &pb.WatchRequest_AckRequest{
+			AckRequest: &pb.WatchAckRequest{WatchId: ws.id, Revision: rev},
+		}
+		if err := wc.Send(&pb.WatchRequest{RequestUnion: ar}); err != nil {
+			w.lg.Debug("failed to send watch ack request", zap.Int64("watch-id", ws.id), zap.Error(err))
+		}
+	}
+}
+
+// reopensOnExpiredToken returns whether the creation of the watcher ws,
+// canceled by the server with the given response, is retried on a new grpc
+// stream with a fresh token. The token of a grpc stream is only checked when
+// a watcher is created, so the stream of long-lived watchers may outlive it.
+func (w *watchGRPCStream) reopensOnExpiredToken(resp *pb.WatchResponse, ws *watcherStream) bool {
+	if !resp.Canceled || ws.authRetried {
+		return false
+	}
+	if resp.CancelReason != v3rpc.ErrGRPCInvalidAuthToken.Error() && resp.CancelReason != v3rpc.ErrGRPCAuthOldRevision.Error() {
+		return false
+	}
+	if a := callAuthFromContext(w.ctx); a != nil {
+		return a.user != ""
+	}
+	return w.owner.refreshesToken
+}
+
+// retriesOnCompaction returns whether the substream of the given watch ID
+// is re-created when the server cancels it on compaction.
+func (w *watchGRPCStream) retriesOnCompaction(watchID int64) bool {
+	ws, ok := w.substreams[watchID]
+	return ok && ws.initReq.compactionRetry != nil
+}
+
+// observeError notifies the observer, if any, of the error of the stream for
+// each of its watchers.
+func (w *watchGRPCStream) observeError(err error) {
+	if w.observer == nil {
+		return
+	}
+	for _, ws := range w.substreams {
+		w.observer.OnWatchError(ws.initReq.ctx, ws.initReq.key, err)
+	}
+	for _, ws := range w.resuming {
+		if ws != nil {
+			w.observer.OnWatchError(ws.initReq.ctx, ws.initReq.key, err)
+		}
+	}
+}
+
 // nextResume chooses the next resuming to register with the grpc stream. Abandoned
 // streams are marked as nil in the queue since the head must wait for its inflight registration.
 func (w *watchGRPCStream) nextResume() *watcherStream {
@@ -724,12 +1353,17 @@ func (w *watchGRPCStream) nextResume() *watcherStream {
 func (w *watchGRPCStream) dispatchEvent(pbresp *pb.WatchResponse) bool {
 	// TODO: return watch ID?
 	wr := &WatchResponse{
-		Header:          ensureWatchHeader(pbresp.Header),
-		Events:          pbresp.Events,
-		CompactRevision: pbresp.CompactRevision,
-		Created:         pbresp.Created,
-		Canceled:        pbresp.Canceled,
-		CancelReason:    pbresp.CancelReason,
+		Header:                 ensureWatchHeader(pbresp.Header),
+		Events:                 pbresp.Events,
+		CompactRevision:        pbresp.CompactRevision,
+		Created:                pbresp.Created,
+		Canceled:               pbresp.Canceled,
+		CancelReason:           pbresp.CancelReason,
+		Snapshot:               pbresp.Snapshot,
+		Warning:                pbresp.Warning,
+		CompactWarningRevision: pbresp.CompactWarningRevision,
+		CompareResult:          pbresp.CompareResult,
+		catchUp:                pbresp.CatchUp,
 	}
 
 	// watch IDs are zero indexed, so request notify watch responses are assigned a watch ID of InvalidWatchID to
@@ -794,12 +1428,24 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 	// nextRev is the minimum expected next revision
 	nextRev := ws.initReq.rev
 	resuming := false
+	// retrying is set once the compaction retry handler returned the
+	// revision to re-create the watcher from
+	retrying := false
+	// compacted is the response that canceled the watcher on compaction,
+	// held back until the events before it are delivered
+	var compacted *WatchResponse
+	// warning of the created response, carried over to the first response
+	// if the created response is not sent
+	var warning string
 	defer func() {
-		if !resuming {
+		if !resuming && !retrying {
 			ws.closing = true
 		}
 		close(ws.donec)
-		if !resuming {
+		switch {
+		case retrying:
+			w.retryc <- ws
+		case !resuming:
 			w.closingc <- ws
 		}
 		w.wg.Done()
@@ -812,6 +1458,20 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 
 	emptyWr := &WatchResponse{Header: &pb.ResponseHeader{}}
 	for {
+		if compacted != nil && len(ws.buf) == 0 {
+			rev, err := ws.initReq.compactionRetry(compacted.CompactRevision)
+			if err == nil && rev > 0 && ws.initReq.ctx.Err() == nil {
+				ws.initReq.rev = rev
+				retrying = true
+				return
+			}
+			if err != nil {
+				compacted.closeErr = fmt.Errorf("%w: %w", v3rpc.ErrCompacted, err)
+			}
+			ws.buf = append(ws.buf, compacted)
+			compacted = nil
+		}
+
 		curWr := emptyWr
 		outc := ws.outc
 
@@ -824,17 +1484,36 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 		select {
 		case outc <- *curWr:
 			w.recordBufWait(ws)
-			if ws.buf[0].Err() != nil {
+			if err := ws.buf[0].Err(); err != nil {
+				ws.err = err
 				return
 			}
+			if ws.initReq.ackWindow > 0 {
+				ws.receivedRev.Store(curWr.Header.Revision)
+				select {
+				case w.ackc <- struct{}{}:
+				default:
+				}
+			}
 			ws.buf[0] = nil
 			ws.buf = ws.buf[1:]
 		case wr, ok := <-ws.recvc:
 			if !ok {
 				// shutdown from closeSubstream
+				if ws.drained {
+					w.flushSubstream(ws)
+				}
 				return
 			}
 
+			if wr.drained {
+				if rev := wr.Header.Revision + 1; rev > nextRev {
+					nextRev = rev
+					ws.initReq.rev = nextRev
+				}
+				continue
+			}
+
 			if wr.Created {
 				if ws.initReq.retc != nil {
 					ws.initReq.retc <- ws.outc
@@ -842,9 +1521,14 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 					// and posting duplicate create events
 					ws.initReq.retc = nil
 
+					if wr.Warning != "" && w.lg != nil {
+						w.lg.Warn("watch created with a warning", zap.String("key", ws.initReq.key), zap.String("warning", wr.Warning))
+					}
 					// send first creation event only if requested
 					if ws.initReq.createdNotify {
 						ws.outc <- *wr
+					} else {
+						warning = wr.Warning
 					}
 					// once the watch channel is returned, a current revision
 					// watch must resume at the store revision. This is necessary
@@ -864,7 +1548,26 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 				nextRev = wr.Header.Revision + 1
 			}
 
-			if len(wr.Events) > 0 {
+			if w.observer != nil {
+				if len(wr.Events) > 0 {
+					w.observer.OnWatchEvents(ws.initReq.ctx, ws.initReq.key, wr.Events)
+				}
+				if err := wr.Err(); err != nil {
+					w.observer.OnWatchError(ws.initReq.ctx, ws.initReq.key, err)
+				}
+			}
+
+			if wr.Canceled && wr.CompactRevision != 0 && ws.initReq.compactionRetry != nil {
+				// the watcher is no longer tracked by the stream, so it must
+				// not resume with it; the events before the compaction are
+				// delivered before the retry handler runs
+				compacted, resumec = wr, nil
+				continue
+			}
+
+			// events of a snapshot are not ordered by revision, and all
+			// revisions up to the header one are reflected in them
+			if len(wr.Events) > 0 && !wr.Snapshot {
 				nextRev = wr.Events[len(wr.Events)-1].Kv.ModRevision + 1
 			}
 
@@ -876,6 +1579,9 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 				continue
 			}
 
+			if warning != "" {
+				wr.Warning, warning = warning, ""
+			}
 			// TODO pause channel if buffer gets too large
 			ws.buf = append(ws.buf, wr)
 		case <-w.ctx.Done():
@@ -891,6 +1597,26 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 	// lazily send cancel message if events on missing id
 }
 
+// flushSubstream sends the buffered responses of the drained watcher ws to
+// its channel, until the stream or the watcher is canceled.
+func (w *watchGRPCStream) flushSubstream(ws *watcherStream) {
+	for len(ws.buf) > 0 {
+		select {
+		case ws.outc <- *ws.buf[0]:
+			if err := ws.buf[0].Err(); err != nil {
+				ws.err = err
+				return
+			}
+			ws.buf[0] = nil
+			ws.buf = ws.buf[1:]
+		case <-w.ctx.Done():
+			return
+		case <-ws.initReq.ctx.Done():
+			return
+		}
+	}
+}
+
 func (w *watchGRPCStream) startBufWait(ws *watcherStream) {
 	if w.lg == nil || ws.bufLogger == nil || !ws.bufWaitStartTime.IsZero() {
 		return
@@ -1019,19 +1745,19 @@ func (w *watchGRPCStream) joinSubstreams() {
 	}
 }
 
-var maxBackoff = 100 * time.Millisecond
-
+// backoffIfUnavailable waits for backoff, randomized by the jitter fraction,
+// if err is from an unavailable endpoint, and returns the next backoff.
 func (w *watchGRPCStream) backoffIfUnavailable(backoff time.Duration, err error) time.Duration {
 	if isUnavailableErr(w.ctx, err) {
 		// retry, but backoff
-		if backoff < maxBackoff {
-			// 25% backoff factor
-			backoff = backoff + backoff/4
-			if backoff > maxBackoff {
-				backoff = maxBackoff
-			}
+		t := time.NewTimer(jitterUp(backoff, w.backoff.JitterFraction))
+		select {
+		case <-t.C:
+		case <-w.ctx.Done():
+			t.Stop()
 		}
-		time.Sleep(backoff)
+		// 25% backoff factor
+		backoff = min(backoff+max(backoff/4, 1), w.backoff.Max)
 	}
 	return backoff
 }
@@ -1040,7 +1766,7 @@ func (w *watchGRPCStream) backoffIfUnavailable(backoff time.Duration, err error)
 // manually retry in case "ws==nil && err==nil"
 // TODO: remove FailFast=false
 func (w *watchGRPCStream) openWatchClient() (ws pb.Watch_WatchClient, err error) {
-	backoff := time.Millisecond
+	backoff := w.backoff.Min
 	for {
 		select {
 		case <-w.ctx.Done():
@@ -1050,9 +1776,15 @@ func (w *watchGRPCStream) openWatchClient() (ws pb.Watch_WatchClient, err error)
 			return nil, err
 		default:
 		}
-		if ws, err = w.remote.Watch(w.ctx, w.callOpts...); ws != nil && err == nil {
+		ctx, cancel := context.WithCancel(w.ctx)
+		if ws, err = w.remote.Watch(ctx, w.callOpts...); ws != nil && err == nil {
+			if w.cancelWatchClient != nil {
+				w.cancelWatchClient()
+			}
+			w.cancelWatchClient = cancel
 			break
 		}
+		cancel()
 		if isHaltErr(w.ctx, err) {
 			return nil, v3rpc.Error(err)
 		}
@@ -1064,13 +1796,35 @@ func (w *watchGRPCStream) openWatchClient() (ws pb.Watch_WatchClient, err error)
 // toPB converts an internal watch request structure to its protobuf WatchRequest structure.
 func (wr *watchRequest) toPB() *pb.WatchRequest {
 	req := &pb.WatchCreateRequest{
-		StartRevision:  wr.rev,
-		Key:            []byte(wr.key),
-		RangeEnd:       []byte(wr.end),
-		ProgressNotify: wr.progressNotify,
-		Filters:        wr.filters,
-		PrevKv:         wr.prevKV,
-		Fragment:       wr.fragment,
+		StartRevision:        wr.rev,
+		Key:                  []byte(wr.key),
+		RangeEnd:             []byte(wr.end),
+		ProgressNotify:       wr.progressNotify,
+		Filters:              wr.filters,
+		PrevKv:               wr.prevKV,
+		Fragment:             wr.fragment,
+		KeysOnly:             wr.keysOnly,
+		SnapshotFallback:     wr.snapshotFallback,
+		LatestPerKey:         wr.latestPerKey,
+		ValuePrefix:          wr.valuePrefix,
+		ValueSelector:        wr.valueSelector,
+		FilterOrder:          wr.filterOrder,
+		SampleEveryN:         wr.sampleEveryN,
+		MaxEventsPerResponse: wr.maxEventsPerResponse,
+		VersionOnly:          wr.versionOnly,
+		CompactWarningMargin: wr.compactWarningMargin,
+		LinearizableStart:    wr.linearizableStart,
+		AckWindow:            wr.ackWindow,
+		CollapseDeletes:      wr.collapseDeletes,
+	}
+	if wr.compare != nil {
+		// the compare applies to the watched key
+		cmp := cloneCompare(wr.compare)
+		cmp.Key, cmp.RangeEnd = []byte(wr.key), nil
+		req.Compare = cmp
+	}
+	if wr.keepAliveInterval > 0 {
+		req.KeepAliveInterval = max(wr.keepAliveInterval.Milliseconds(), 1)
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
@@ -1084,8 +1838,12 @@ func (pr *progressRequest) toPB() *pb.WatchRequest {
 }
 
 func streamKeyFromCtx(ctx context.Context) string {
+	var key string
 	if md, ok := metadata.FromOutgoingContext(ctx); ok {
-		return fmt.Sprintf("%+v", map[string][]string(md))
+		key = fmt.Sprintf("%+v", map[string][]string(md))
+	}
+	if a := callAuthFromContext(ctx); a != nil {
+		key += a.streamKey()
 	}
-	return ""
+	return key
 }
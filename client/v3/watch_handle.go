@@ -0,0 +1,118 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWatcherClosed is the error of the watchers closed by the Close of their
+// Watcher or Client.
+var ErrWatcherClosed = errors.New("etcdclient: watcher closed")
+
+// ErrWatchesCanceled is the error of the watchers canceled by the CancelAll
+// of their Watcher.
+var ErrWatchesCanceled = errors.New("etcdclient: watches canceled")
+
+// WatchHandle tells why the channel of a watcher was closed.
+type WatchHandle struct {
+	donec chan struct{}
+	once  sync.Once
+	err   error
+	// bound is set once a watcher of this package took the handle over
+	bound atomic.Bool
+}
+
+func newWatchHandle() *WatchHandle {
+	return &WatchHandle{donec: make(chan struct{})}
+}
+
+// Done returns a channel that is closed once the watcher is done, at the
+// latest when its watch channel is closed.
+func (h *WatchHandle) Done() <-chan struct{} { return h.donec }
+
+// Err returns nil until Done is closed. Then it returns why the watcher is
+// done: the error of the last response, such as ErrCompacted, the error of
+// the context of the watcher if it was canceled, ErrWatcherClosed if the
+// Watcher was closed, or ErrWatchesCanceled if the watcher was canceled by
+// its CancelAll.
+func (h *WatchHandle) Err() error {
+	select {
+	case <-h.donec:
+		return h.err
+	default:
+		return nil
+	}
+}
+
+// close sets the error of the handle, if not set already. It is a no-op on a
+// nil handle.
+func (h *WatchHandle) close(err error) {
+	if h == nil {
+		return
+	}
+	h.once.Do(func() {
+		h.err = err
+		close(h.donec)
+	})
+}
+
+// WatchWithHandle is like the Watch of w, but also returns a handle that
+// keeps why the watch channel was closed, so that the error is not lost with
+// the last response when the channel is drained. The handle is done by the
+// time the channel is closed.
+//
+// The handle is passed down through watchers wrapping the Watcher of a
+// Client, such as the one of the namespace package. For other watchers, the
+// responses are copied to a channel of its own.
+func WatchWithHandle(ctx context.Context, w Watcher, key string, opts ...OpOption) (WatchChan, *WatchHandle) {
+	h := newWatchHandle()
+	wch := w.Watch(ctx, key, append(opts, withWatchHandle(h))...)
+	if h.bound.Load() {
+		return wch, h
+	}
+
+	ch := make(chan WatchResponse)
+	go func() {
+		defer close(ch)
+		var err error
+		defer func() { h.close(err) }()
+		for wr := range wch {
+			if werr := wr.Err(); werr != nil {
+				err = werr
+			}
+			select {
+			case ch <- wr:
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+			}
+		}
+		if err == nil {
+			if err = ctx.Err(); err == nil {
+				err = ErrWatcherClosed
+			}
+		}
+	}()
+	return ch, h
+}
+
+// withWatchHandle has the watcher close h once its channel is closed.
+func withWatchHandle(h *WatchHandle) OpOption {
+	return func(op *Op) { op.watchHandle = h }
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// watchMapRetryInterval is the interval between the attempts to list the
+// prefix of a WatchMapView again. Non-const so modifiable by tests.
+var watchMapRetryInterval = time.Second
+
+// WatchMapView is an in-memory copy of the keys of a prefix, kept up to date
+// by a watch. It is safe for concurrent use.
+type WatchMapView struct {
+	c      *Client
+	prefix string
+	opts   []OpOption
+
+	mu  sync.RWMutex
+	kvs map[string][]byte
+	rev int64
+
+	changec chan struct{}
+	donec   chan struct{}
+	err     error
+}
+
+// WatchMap gets the keys with the given prefix, and returns a view of them
+// that is updated by the events of a watch on the prefix, until ctx is done.
+// The options are passed to the gets of the prefix, for instance
+// WithSerializable. If the revision watched from is compacted, the prefix is
+// listed again at the current revision, so the view skips the intermediate
+// revisions but does not miss the final state of any key.
+func WatchMap(ctx context.Context, c *Client, prefix string, opts ...OpOption) (*WatchMapView, error) {
+	m := &WatchMapView{
+		c:       c,
+		prefix:  prefix,
+		opts:    append([]OpOption{WithPrefix()}, opts...),
+		changec: make(chan struct{}, 1),
+		donec:   make(chan struct{}),
+	}
+	if err := m.list(ctx); err != nil {
+		return nil, err
+	}
+	go m.run(ctx)
+	return m, nil
+}
+
+// Get returns the value of key, if the view has it. The value must not be
+// modified.
+func (m *WatchMapView) Get(key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.kvs[key]
+	return v, ok
+}
+
+// Snapshot returns a copy of the keys of the view, along with the revision
+// they are current at. The values must not be modified.
+func (m *WatchMapView) Snapshot() (map[string][]byte, int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return maps.Clone(m.kvs), m.rev
+}
+
+// Changes returns a channel that receives a value after the view changed.
+// Changes made while a value is pending are coalesced into it.
+func (m *WatchMapView) Changes() <-chan struct{} { return m.changec }
+
+// Done returns a channel that is closed once the view is no longer updated.
+func (m *WatchMapView) Done() <-chan struct{} { return m.donec }
+
+// Err returns nil until Done is closed. Then it returns why the view is no
+// longer updated: the error of the context, or of the watch.
+func (m *WatchMapView) Err() error {
+	select {
+	case <-m.donec:
+		return m.err
+	default:
+		return nil
+	}
+}
+
+func (m *WatchMapView) run(ctx context.Context) {
+	var err error
+	defer func() {
+		m.err = err
+		close(m.donec)
+	}()
+	for {
+		err = m.watch(ctx)
+		if !errors.Is(err, rpctypes.ErrCompacted) {
+			return
+		}
+		m.c.GetLogger().Info("watched revision compacted, listing the prefix again", zap.String("prefix", m.prefix), zap.Error(err))
+		for {
+			if err = m.list(ctx); err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				return
+			}
+			m.c.GetLogger().Warn("failed to list the prefix of a watch map", zap.String("prefix", m.prefix), zap.Error(err))
+			select {
+			case <-time.After(watchMapRetryInterval):
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+			}
+		}
+	}
+}
+
+// list replaces the keys of the view with the current keys of the prefix.
+func (m *WatchMapView) list(ctx context.Context) error {
+	resp, err := m.c.Get(ctx, m.prefix, m.opts...)
+	if err != nil {
+		return err
+	}
+	kvs := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = kv.Value
+	}
+	m.mu.Lock()
+	m.kvs, m.rev = kvs, resp.Header.Revision
+	m.mu.Unlock()
+	m.notify()
+	return nil
+}
+
+// watch applies the events of the prefix after the revision of the view,
+// until the watch fails.
+func (m *WatchMapView) watch(ctx context.Context) error {
+	m.mu.RLock()
+	rev := m.rev
+	m.mu.RUnlock()
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wch, h := WatchWithHandle(wctx, m.c, m.prefix, WithPrefix(), WithRev(rev+1))
+	for wresp := range wch {
+		if err := wresp.Err(); err != nil {
+			return err
+		}
+		if len(wresp.Events) == 0 {
+			continue
+		}
+		m.mu.Lock()
+		for _, ev := range wresp.Events {
+			if ev.Type == mvccpb.Event_DELETE {
+				delete(m.kvs, string(ev.Kv.Key))
+			} else {
+				m.kvs[string(ev.Kv.Key)] = ev.Kv.Value
+			}
+		}
+		m.rev = wresp.Header.Revision
+		m.mu.Unlock()
+		m.notify()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return h.Err()
+}
+
+func (m *WatchMapView) notify() {
+	select {
+	case m.changec <- struct{}{}:
+	default:
+	}
+}
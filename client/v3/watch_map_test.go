@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// fakeMapKV returns the queued responses to the gets of a prefix.
+type fakeMapKV struct {
+	KV
+
+	mu    sync.Mutex
+	resps []*GetResponse
+	errs  []error
+}
+
+func (kv *fakeMapKV) Get(context.Context, string, ...OpOption) (*GetResponse, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if len(kv.errs) > 0 {
+		err := kv.errs[0]
+		kv.errs = kv.errs[1:]
+		return nil, err
+	}
+	resp := kv.resps[0]
+	kv.resps = kv.resps[1:]
+	return resp, nil
+}
+
+// fakeMapWatcher records the revisions watched from, and sends the responses
+// of the test to the last watch.
+type fakeMapWatcher struct {
+	Watcher
+
+	revs    chan int64
+	watches chan chan WatchResponse
+}
+
+func (w *fakeMapWatcher) Watch(ctx context.Context, _ string, opts ...OpOption) WatchChan {
+	op := OpGet("", opts...)
+	w.revs <- op.rev
+	wch := make(chan WatchResponse)
+	out := make(chan WatchResponse)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case wr, ok := <-wch:
+				if !ok {
+					return
+				}
+				out <- wr
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	w.watches <- wch
+	return out
+}
+
+func getResponse(rev int64, kvs ...string) *GetResponse {
+	resp := &GetResponse{Header: &pb.ResponseHeader{Revision: rev}}
+	for i := 0; i < len(kvs); i += 2 {
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])})
+	}
+	return resp
+}
+
+func newWatchMapTestClient(kv *fakeMapKV) (*Client, *fakeMapWatcher) {
+	w := &fakeMapWatcher{revs: make(chan int64, 10), watches: make(chan chan WatchResponse, 10)}
+	c := NewCtxClient(context.Background())
+	c.KV, c.Watcher = kv, w
+	return c, w
+}
+
+func waitChange(t *testing.T, m *WatchMapView) {
+	t.Helper()
+	select {
+	case <-m.Changes():
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to change")
+	}
+}
+
+func requireSnapshot(t *testing.T, m *WatchMapView, rev int64, kvs ...string) {
+	t.Helper()
+	want := make(map[string][]byte)
+	for i := 0; i < len(kvs); i += 2 {
+		want[kvs[i]] = []byte(kvs[i+1])
+	}
+	got, gotRev := m.Snapshot()
+	require.Equal(t, want, got)
+	require.Equal(t, rev, gotRev)
+}
+
+func TestWatchMap(t *testing.T) {
+	kv := &fakeMapKV{resps: []*GetResponse{getResponse(5, "a", "1", "b", "2")}}
+	c, w := newWatchMapTestClient(kv)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	m, err := WatchMap(ctx, c, "")
+	require.NoError(t, err)
+	waitChange(t, m)
+	requireSnapshot(t, m, 5, "a", "1", "b", "2")
+	require.Equal(t, int64(6), <-w.revs)
+
+	wch := <-w.watches
+	wch <- WatchResponse{
+		Header: &pb.ResponseHeader{Revision: 7},
+		Events: []*Event{
+			{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Key: []byte("c"), Value: []byte("3")}},
+			{Type: mvccpb.Event_DELETE, Kv: &mvccpb.KeyValue{Key: []byte("a")}},
+		},
+	}
+	waitChange(t, m)
+	requireSnapshot(t, m, 7, "b", "2", "c", "3")
+	v, ok := m.Get("c")
+	require.True(t, ok)
+	require.Equal(t, []byte("3"), v)
+	_, ok = m.Get("a")
+	require.False(t, ok)
+
+	// progress notifications do not change the view
+	wch <- WatchResponse{Header: &pb.ResponseHeader{Revision: 8}}
+	select {
+	case <-m.Changes():
+		t.Fatal("unexpected change")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	<-m.Done()
+	require.ErrorIs(t, m.Err(), context.Canceled)
+}
+
+func TestWatchMapCompacted(t *testing.T) {
+	old := watchMapRetryInterval
+	watchMapRetryInterval = time.Millisecond
+	defer func() { watchMapRetryInterval = old }()
+
+	kv := &fakeMapKV{resps: []*GetResponse{
+		getResponse(5, "a", "1"),
+		getResponse(20, "b", "2"),
+	}}
+	c, w := newWatchMapTestClient(kv)
+	m, err := WatchMap(t.Context(), c, "")
+	require.NoError(t, err)
+	waitChange(t, m)
+	require.Equal(t, int64(6), <-w.revs)
+
+	// the listing fails once, then the prefix is listed again
+	kv.mu.Lock()
+	kv.errs = []error{errors.New("unavailable")}
+	kv.mu.Unlock()
+	wch := <-w.watches
+	wch <- WatchResponse{Header: &pb.ResponseHeader{Revision: 20}, CompactRevision: 10, Canceled: true}
+	waitChange(t, m)
+	requireSnapshot(t, m, 20, "b", "2")
+	require.Equal(t, int64(21), <-w.revs)
+	require.Nil(t, m.Err())
+}
+
+func TestWatchMapWatchFailed(t *testing.T) {
+	kv := &fakeMapKV{resps: []*GetResponse{getResponse(5)}}
+	c, w := newWatchMapTestClient(kv)
+	m, err := WatchMap(t.Context(), c, "")
+	require.NoError(t, err)
+
+	wch := <-w.watches
+	wch <- WatchResponse{Header: &pb.ResponseHeader{Revision: 5}, Canceled: true, closeErr: rpctypes.ErrGRPCPermissionDenied}
+	<-m.Done()
+	require.ErrorIs(t, m.Err(), rpctypes.ErrPermissionDenied)
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// shareKey returns the key of the watchers the request can share a substream
+// with, or an empty string if it needs a substream of its own. Only watchers
+// from the current revision share a substream, as the events of the others
+// depend on when they joined; watchers with a created notification or a
+// compaction retry handler do not either, as those are per watcher, nor
+// watchers with a linearizable start, as the shared substream may lag, nor
+// watchers with an ack window, as they acknowledge their own revisions.
+func (wr *watchRequest) shareKey() string {
+	if wr.rev != 0 || wr.createdNotify || wr.compactionRetry != nil || wr.linearizableStart || wr.ackWindow > 0 {
+		return ""
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(wr.toPB())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// watchShare is a substream shared by identical watch requests. Its
+// responses are copied to the channel of every watcher, and it is canceled
+// once the last watcher goes away.
+type watchShare struct {
+	key string
+	// req is the request of the shared substream
+	req *watchRequest
+	// cancel cancels the context of the shared substream
+	cancel context.CancelFunc
+	// leavec gets the watchers whose context is done
+	leavec chan *watchSubscriber
+	// stopc closes once the shared substream is closed
+	stopc chan struct{}
+	// donec closes once the watchers are all gone
+	donec chan struct{}
+
+	// mu protects the fields below
+	mu   sync.Mutex
+	subs []*watchSubscriber
+	// created is set once the shared substream is established
+	created bool
+	// closed is set once no watcher can join anymore
+	closed bool
+}
+
+type watchSubscriber struct {
+	ctx    context.Context
+	outc   chan WatchResponse
+	handle *WatchHandle
+	// retc receives outc once the shared substream is established
+	retc chan chan WatchResponse
+}
+
+// newWatchShare returns a share of the substream of wr with key. The shared
+// substream keeps the values of the context of wr, but is only canceled
+// when its last watcher goes away.
+func newWatchShare(key string, wr *watchRequest) *watchShare {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(wr.ctx))
+	req := *wr
+	req.ctx = ctx
+	req.retc = make(chan chan WatchResponse, 1)
+	// the handle of the shared substream gives its error to the watchers
+	req.handle = newWatchHandle()
+	sh := &watchShare{
+		key:    key,
+		req:    &req,
+		cancel: cancel,
+		leavec: make(chan *watchSubscriber),
+		stopc:  make(chan struct{}),
+		donec:  make(chan struct{}),
+	}
+	sh.join(wr)
+	go sh.run()
+	return sh
+}
+
+// join adds the watcher of wr to the share. It returns false if the shared
+// substream is closing.
+func (sh *watchShare) join(wr *watchRequest) bool {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	if sh.closed {
+		return false
+	}
+	sub := &watchSubscriber{ctx: wr.ctx, outc: make(chan WatchResponse, 1), handle: wr.handle}
+	if sh.created {
+		wr.retc <- sub.outc
+	} else {
+		sub.retc = wr.retc
+	}
+	sh.subs = append(sh.subs, sub)
+	go func() {
+		select {
+		case <-sub.ctx.Done():
+			select {
+			case sh.leavec <- sub:
+			case <-sh.donec:
+			}
+		case <-sh.donec:
+		}
+	}()
+	return true
+}
+
+// run copies the responses of the shared substream to its watchers.
+func (sh *watchShare) run() {
+	defer close(sh.donec)
+	defer sh.cancel()
+
+	var outc chan WatchResponse
+	for outc == nil {
+		select {
+		case outc = <-sh.req.retc:
+		case sub := <-sh.leavec:
+			sh.leave(sub)
+		}
+	}
+	sh.mu.Lock()
+	sh.created = true
+	for _, sub := range sh.subs {
+		sub.retc <- sub.outc
+		sub.retc = nil
+	}
+	sh.mu.Unlock()
+
+	for {
+		select {
+		case wr, ok := <-outc:
+			if !ok {
+				sh.mu.Lock()
+				sh.closed = true
+				for _, sub := range sh.subs {
+					sub.handle.close(sh.req.handle.Err())
+					close(sub.outc)
+				}
+				sh.subs = nil
+				sh.mu.Unlock()
+				return
+			}
+			sh.broadcast(wr)
+		case sub := <-sh.leavec:
+			sh.leave(sub)
+		}
+	}
+}
+
+// broadcast sends wr to every watcher. All but the first one get a copy of
+// the events, so that the watchers can modify them.
+func (sh *watchShare) broadcast(wr WatchResponse) {
+	sh.mu.Lock()
+	subs := sh.subs
+	sh.mu.Unlock()
+	for i, sub := range subs {
+		swr := wr
+		if i > 0 && len(wr.Events) > 0 {
+			swr.Events = make([]*Event, len(wr.Events))
+			for j, ev := range wr.Events {
+				swr.Events[j] = proto.Clone(ev).(*mvccpb.Event)
+			}
+		}
+		sh.send(sub, swr)
+	}
+}
+
+// send sends wr to the watcher. Once the shared substream is closed, like
+// the last response of a watcher of its own, wr is dropped if the watcher
+// does not receive it in time.
+func (sh *watchShare) send(sub *watchSubscriber, wr WatchResponse) {
+	select {
+	case sub.outc <- wr:
+		return
+	case <-sub.ctx.Done():
+		sh.leave(sub)
+		return
+	case <-sh.stopc:
+	}
+	select {
+	case sub.outc <- wr:
+	case <-sub.ctx.Done():
+		sh.leave(sub)
+	case <-time.After(closeSendErrTimeout):
+	}
+}
+
+// stop is called by the grpc stream once the shared substream is closed.
+func (sh *watchShare) stop() {
+	close(sh.stopc)
+}
+
+// leave removes the watcher from the share and closes its channel. The shared
+// substream is canceled once the last watcher leaves.
+func (sh *watchShare) leave(sub *watchSubscriber) {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+	for i, s := range sh.subs {
+		if s != sub {
+			continue
+		}
+		sh.subs = append(sh.subs[:i:i], sh.subs[i+1:]...)
+		if sub.retc == nil {
+			sub.handle.close(sub.ctx.Err())
+			close(sub.outc)
+		}
+		break
+	}
+	if len(sh.subs) == 0 && !sh.closed {
+		sh.closed = true
+		sh.cancel()
+	}
+}
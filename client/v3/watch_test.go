@@ -16,6 +16,8 @@ package clientv3
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +27,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 )
 
 func TestEvent(t *testing.T) {
@@ -204,3 +207,114 @@ func TestServeSubstreamLogsSlowConsumer(t *testing.T) {
 	}
 	w.wg.Wait()
 }
+
+func TestServeSubstreamDrainedResumeRevision(t *testing.T) {
+	ws := &watcherStream{
+		initReq: watchRequest{ctx: t.Context(), rev: 5},
+		outc:    make(chan WatchResponse, 1),
+		recvc:   make(chan *WatchResponse),
+		donec:   make(chan struct{}),
+	}
+	w := &watchGRPCStream{ctx: t.Context(), lg: zap.NewNop()}
+	resumec := make(chan struct{})
+	w.wg.Add(1)
+	go w.serveSubstream(ws, resumec)
+
+	ws.recvc <- &WatchResponse{Header: &pb.ResponseHeader{Revision: 10}, drained: true}
+	close(resumec)
+	select {
+	case <-ws.donec:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for serveSubstream to resume")
+	}
+	w.wg.Wait()
+
+	if ws.initReq.rev != 11 {
+		t.Fatalf("expected the watcher to resume from revision 11, got %d", ws.initReq.rev)
+	}
+	if len(ws.outc) != 0 || len(ws.buf) != 0 {
+		t.Fatal("expected the drained response not to be delivered")
+	}
+}
+
+// chanWatcher is a Watcher of another package, which ignores the handle.
+type chanWatcher struct {
+	Watcher
+	ch chan WatchResponse
+}
+
+func (w *chanWatcher) Watch(context.Context, string, ...OpOption) WatchChan { return w.ch }
+
+func TestWatchWithHandleCopiesForeignWatcher(t *testing.T) {
+	w := &chanWatcher{ch: make(chan WatchResponse, 2)}
+	wch, h := WatchWithHandle(t.Context(), w, "foo")
+
+	w.ch <- WatchResponse{Header: &pb.ResponseHeader{Revision: 2}}
+	w.ch <- WatchResponse{Header: &pb.ResponseHeader{}, Canceled: true, CompactRevision: 2}
+	close(w.ch)
+	n := 0
+	for range wch {
+		n++
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 responses, got %d", n)
+	}
+	select {
+	case <-h.Done():
+	default:
+		t.Fatal("watch channel closed before the handle is done")
+	}
+	if !errors.Is(h.Err(), rpctypes.ErrCompacted) {
+		t.Fatalf("expected %v, got %v", rpctypes.ErrCompacted, h.Err())
+	}
+
+	// a watcher closed without an error
+	w = &chanWatcher{ch: make(chan WatchResponse)}
+	close(w.ch)
+	wch, h = WatchWithHandle(t.Context(), w, "foo")
+	for range wch {
+	}
+	if !errors.Is(h.Err(), ErrWatcherClosed) {
+		t.Fatalf("expected %v, got %v", ErrWatcherClosed, h.Err())
+	}
+}
+
+func TestWatchRetryBackoff(t *testing.T) {
+	if b := (WatchRetryBackoff{}).withDefaults(); b != (WatchRetryBackoff{Min: time.Millisecond, Max: 100 * time.Millisecond}) {
+		t.Fatalf("default backoff = %+v", b)
+	}
+	if b := (WatchRetryBackoff{Min: time.Second}).withDefaults(); b.Max != time.Second {
+		t.Fatalf("max backoff = %v, want the min backoff", b.Max)
+	}
+
+	c := NewCtxClient(t.Context(), WithWatchRetryBackoff(time.Millisecond, 2*time.Millisecond, 0.5))
+	wb := NewWatchFromWatchClient(nil, c).(*watcher).backoff
+	if wb != (WatchRetryBackoff{Min: time.Millisecond, Max: 2 * time.Millisecond, JitterFraction: 0.5}) {
+		t.Fatalf("backoff = %+v", wb)
+	}
+
+	w := &watchGRPCStream{ctx: t.Context(), backoff: wb}
+	backoff := wb.Min
+	var backoffs []time.Duration
+	for i := 0; i < 5; i++ {
+		backoff = w.backoffIfUnavailable(backoff, rpctypes.ErrGRPCNoLeader)
+		backoffs = append(backoffs, backoff)
+	}
+	want := []time.Duration{1250 * time.Microsecond, 1562500, 1953125, 2 * time.Millisecond, 2 * time.Millisecond}
+	if !slices.Equal(backoffs, want) {
+		t.Fatalf("backoffs = %v, want %v", backoffs, want)
+	}
+	if backoff = w.backoffIfUnavailable(time.Hour, errors.New("other")); backoff != time.Hour {
+		t.Fatalf("backoff after another error = %v, want unchanged", backoff)
+	}
+
+	// the wait ends with the stream
+	ctx, cancel := context.WithCancel(t.Context())
+	w.ctx = ctx
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	w.backoffIfUnavailable(time.Hour, rpctypes.ErrGRPCNoLeader)
+	if d := time.Since(start); d > 10*time.Second {
+		t.Fatalf("waited %v after the stream closed", d)
+	}
+}
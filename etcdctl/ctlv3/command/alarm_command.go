@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/cobra"
 
 	v3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdctl/v3/pkg/clusterops"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
@@ -50,13 +51,13 @@ func NewAlarmDisarmCommand() *cobra.Command {
 // alarmDisarmCommandFunc executes the "alarm disarm" command.
 func alarmDisarmCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("alarm disarm command accepts no arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("alarm disarm command accepts no arguments"))
 	}
 	ctx, cancel := commandCtx(cmd)
 	resp, err := mustClientFromCmd(cmd).AlarmDisarm(ctx, &v3.AlarmMember{})
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.Alarm(resp)
 }
@@ -73,13 +74,13 @@ func NewAlarmListCommand() *cobra.Command {
 // alarmListCommandFunc executes the "alarm list" command.
 func alarmListCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("alarm list command accepts no arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("alarm list command accepts no arguments"))
 	}
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).AlarmList(ctx)
+	resp, err := clusterops.AlarmList(ctx, *mustClientConfigFromCmd(cmd))
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.Alarm(resp)
 }
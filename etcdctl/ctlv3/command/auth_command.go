@@ -51,14 +51,14 @@ func newAuthStatusCommand() *cobra.Command {
 // authStatusCommandFunc executes the "auth status" command.
 func authStatusCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth status command does not accept any arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth status command does not accept any arguments"))
 	}
 
 	ctx, cancel := commandCtx(cmd)
 	result, err := mustClientFromCmd(cmd).Auth.AuthStatus(ctx)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.AuthStatus(result)
@@ -75,7 +75,7 @@ func newAuthEnableCommand() *cobra.Command {
 // authEnableCommandFunc executes the "auth enable" command.
 func authEnableCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth enable command does not accept any arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth enable command does not accept any arguments"))
 	}
 
 	ctx, cancel := commandCtx(cmd)
@@ -96,7 +96,7 @@ func authEnableCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	fmt.Println("Authentication Enabled")
@@ -113,14 +113,14 @@ func newAuthDisableCommand() *cobra.Command {
 // authDisableCommandFunc executes the "auth disable" command.
 func authDisableCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth disable command does not accept any arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("auth disable command does not accept any arguments"))
 	}
 
 	ctx, cancel := commandCtx(cmd)
 	_, err := mustClientFromCmd(cmd).Auth.AuthDisable(ctx)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	fmt.Println("Authentication Disabled")
@@ -22,6 +22,7 @@ import (
 	"math/rand"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"sync"
 	"time"
@@ -40,6 +41,10 @@ var (
 	checkPerfPrefix      string
 	checkDatascaleLoad   string
 	checkDatascalePrefix string
+	checkWatchPrefix     string
+	checkWatchKeys       int
+	checkWatchWriters    int
+	checkWatchDuration   time.Duration
 	autoCompact          bool
 	autoDefrag           bool
 )
@@ -115,6 +120,7 @@ func NewCheckCommand() *cobra.Command {
 
 	cc.AddCommand(NewCheckPerfCommand())
 	cc.AddCommand(NewCheckDatascaleCommand())
+	cc.AddCommand(NewCheckWatchCommand())
 
 	return cc
 }
@@ -150,7 +156,7 @@ func newCheckPerfCommand(cmd *cobra.Command, args []string) {
 
 	model, ok := checkPerfAlias[checkPerfLoad]
 	if !ok {
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkPerfLoad))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkPerfLoad))
 	}
 	cfg := checkPerfCfgMap[model]
 
@@ -172,10 +178,10 @@ func newCheckPerfCommand(cmd *cobra.Command, args []string) {
 	resp, err := clients[0].Get(gctx, checkPerfPrefix, v3.WithPrefix(), v3.WithLimit(1))
 	gcancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	if len(resp.Kvs) > 0 {
-		cobrautl.ExitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with 'etcdctl del --prefix %s' first", checkPerfPrefix, checkPerfPrefix))
+		exitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with 'etcdctl del --prefix %s' first", checkPerfPrefix, checkPerfPrefix))
 	}
 
 	ksize, vsize := 256, 1024
@@ -322,7 +328,7 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 
 	model, ok := checkDatascaleAlias[checkDatascaleLoad]
 	if !ok {
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkDatascaleLoad))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown load option %v", checkDatascaleLoad))
 	}
 	cfg := checkDatascaleCfgMap[model]
 
@@ -337,7 +343,7 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	// get endpoints
 	eps, errEndpoints := endpointsFromCmd(cmd)
 	if errEndpoints != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, errEndpoints)
+		exitWithError(cobrautl.ExitError, errEndpoints)
 	}
 
 	sec := secureCfgFromCmd(cmd)
@@ -346,10 +352,10 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	resp, err := clients[0].Get(ctx, checkDatascalePrefix, v3.WithPrefix(), v3.WithLimit(1))
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	if len(resp.Kvs) > 0 {
-		cobrautl.ExitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with etcdctl del --prefix %s first", checkDatascalePrefix, checkDatascalePrefix))
+		exitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with etcdctl del --prefix %s first", checkDatascalePrefix, checkDatascalePrefix))
 	}
 
 	ksize, vsize := 512, 512
@@ -408,7 +414,7 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	dresp, derr := clients[0].Delete(ctx, checkDatascalePrefix, v3.WithPrefix())
 	defer cancel()
 	if derr != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, derr)
+		exitWithError(cobrautl.ExitError, derr)
 	}
 
 	if autoCompact {
@@ -438,3 +444,198 @@ func newCheckDatascaleCommand(cmd *cobra.Command, args []string) {
 	}
 	fmt.Printf("PASS: Approximate system memory used : %v MB.\n", strconv.FormatFloat(mbUsed, 'f', 2, 64))
 }
+
+// NewCheckWatchCommand returns the cobra command for "check watch".
+func NewCheckWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [options]",
+		Short: "Check that a watch observes every revision exactly once under concurrent writes",
+		Run:   newCheckWatchCommand,
+	}
+
+	cmd.Flags().StringVar(&checkWatchPrefix, "prefix", "/etcdctl-check-watch/", "The prefix for writing the watch check's keys.")
+	cmd.Flags().IntVar(&checkWatchKeys, "keys", 10, "The number of distinct keys written by the watch check.")
+	cmd.Flags().IntVar(&checkWatchWriters, "writers", 10, "The number of concurrent clients writing keys.")
+	cmd.Flags().DurationVar(&checkWatchDuration, "duration", 10*time.Second, "How long the writers keep writing keys.")
+	cmd.Flags().BoolVar(&autoCompact, "auto-compact", false, "Compact storage with last revision after test is finished.")
+
+	return cmd
+}
+
+// newCheckWatchCommand executes the "check watch" command.
+func newCheckWatchCommand(cmd *cobra.Command, args []string) {
+	if checkWatchKeys <= 0 {
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--keys must be positive, got %d", checkWatchKeys))
+	}
+	if checkWatchWriters <= 0 {
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--writers must be positive, got %d", checkWatchWriters))
+	}
+	if checkWatchDuration <= 0 {
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--duration must be positive, got %v", checkWatchDuration))
+	}
+
+	cc := clientConfigFromCmd(cmd)
+	clients := make([]*v3.Client, checkWatchWriters+1)
+	for i := range clients {
+		clients[i] = mustClient(cc)
+	}
+	// the first client only watches, so writes cannot delay the watch stream
+	watcher, writers := clients[0], clients[1:]
+
+	cleanup := func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer dcancel()
+		dresp, err := watcher.Delete(dctx, checkWatchPrefix, v3.WithPrefix())
+		if err != nil {
+			fmt.Printf("FAIL: Cleanup failed during key deletion: ERROR(%v)\n", err)
+			return
+		}
+		if autoCompact {
+			compact(watcher, dresp.Header.Revision)
+		}
+	}
+
+	ctx, icancel := interruptableContext(context.Background(), cleanup)
+	defer icancel()
+
+	gctx, gcancel := context.WithCancel(ctx)
+	resp, err := watcher.Get(gctx, checkWatchPrefix, v3.WithPrefix(), v3.WithLimit(1))
+	gcancel()
+	if err != nil {
+		exitWithError(cobrautl.ExitError, err)
+	}
+	if len(resp.Kvs) > 0 {
+		exitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("prefix %q has keys. Delete with 'etcdctl del --prefix %s' first", checkWatchPrefix, checkWatchPrefix))
+	}
+
+	wctx, wcancel := context.WithCancel(ctx)
+	defer wcancel()
+	wch := watcher.Watch(wctx, checkWatchPrefix, v3.WithPrefix(), v3.WithRev(resp.Header.Revision+1))
+
+	fmt.Printf("Start watch check for %v [%v keys, %v concurrent writers].\n", checkWatchDuration, checkWatchKeys, checkWatchWriters)
+
+	var (
+		mu      sync.Mutex
+		written = make(map[int64]struct{})
+		maxRev  int64
+		werrs   = make(map[string]int)
+	)
+	pctx, pcancel := context.WithTimeout(ctx, checkWatchDuration)
+	defer pcancel()
+	var wg sync.WaitGroup
+	wg.Add(len(writers))
+	for i := range writers {
+		go func(c *v3.Client) {
+			defer wg.Done()
+			for pctx.Err() == nil {
+				key := checkWatchPrefix + strconv.Itoa(rand.Intn(checkWatchKeys))
+				// puts outlive pctx so that every committed write is recorded
+				presp, perr := c.Put(ctx, key, "")
+				mu.Lock()
+				if perr == nil {
+					written[presp.Header.Revision] = struct{}{}
+					maxRev = max(maxRev, presp.Header.Revision)
+				} else {
+					werrs[perr.Error()]++
+				}
+				mu.Unlock()
+			}
+		}(writers[i])
+	}
+	wg.Wait()
+
+	// every write has been acknowledged; wait for the watch to catch up
+	seen := make(map[int64]int)
+	var lastRev int64
+	outOfOrder := 0
+	timeout := time.After(30 * time.Second)
+watchLoop:
+	for lastRev < maxRev {
+		select {
+		case wresp, ok := <-wch:
+			if !ok {
+				exitWithError(cobrautl.ExitError, fmt.Errorf("watch closed before observing revision %d", maxRev))
+			}
+			if werr := wresp.Err(); werr != nil {
+				exitWithError(cobrautl.ExitError, werr)
+			}
+			for _, ev := range wresp.Events {
+				rev := ev.Kv.ModRevision
+				seen[rev]++
+				if rev <= lastRev {
+					outOfOrder++
+				}
+				lastRev = max(lastRev, rev)
+			}
+		case <-timeout:
+			fmt.Printf("FAIL: Timed out waiting for the watch to observe revision %d, last observed %d\n", maxRev, lastRev)
+			break watchLoop
+		}
+	}
+	wcancel()
+
+	cleanup()
+
+	ok := true
+	if len(werrs) != 0 {
+		fmt.Println("FAIL: too many errors")
+		for k, v := range werrs {
+			fmt.Printf("FAIL: ERROR(%v) -> %d\n", k, v)
+		}
+		ok = false
+	}
+
+	var gaps, duplicates []int64
+	for rev := range written {
+		switch n := seen[rev]; {
+		case n == 0:
+			gaps = append(gaps, rev)
+		case n > 1:
+			duplicates = append(duplicates, rev)
+		}
+	}
+	var unexpected []int64
+	for rev := range seen {
+		if _, found := written[rev]; !found {
+			unexpected = append(unexpected, rev)
+		}
+	}
+	slices.Sort(gaps)
+	slices.Sort(duplicates)
+	slices.Sort(unexpected)
+
+	if len(gaps) != 0 {
+		fmt.Printf("FAIL: %d revisions were never observed: %v\n", len(gaps), summarizeRevisions(gaps))
+		ok = false
+	}
+	if len(duplicates) != 0 {
+		fmt.Printf("FAIL: %d revisions were observed more than once: %v\n", len(duplicates), summarizeRevisions(duplicates))
+		ok = false
+	}
+	if outOfOrder != 0 {
+		fmt.Printf("FAIL: %d events were observed out of revision order\n", outOfOrder)
+		ok = false
+	}
+	if len(unexpected) != 0 {
+		fmt.Printf("FAIL: %d observed revisions were not written by the check: %v\n", len(unexpected), summarizeRevisions(unexpected))
+		ok = false
+	}
+	if ok {
+		fmt.Printf("PASS: Observed all %d writes exactly once\n", len(written))
+	}
+
+	if !ok {
+		fmt.Println("FAIL")
+		os.Exit(cobrautl.ExitError)
+	}
+	fmt.Println("PASS")
+}
+
+// summarizeRevisions formats at most the first 10 revisions of the given list.
+func summarizeRevisions(revs []int64) string {
+	const limit = 10
+	if len(revs) <= limit {
+		return fmt.Sprint(revs)
+	}
+	return fmt.Sprintf("%v and %d more", revs[:limit], len(revs)-limit)
+}
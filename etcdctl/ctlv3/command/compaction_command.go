@@ -41,12 +41,12 @@ func NewCompactionCommand() *cobra.Command {
 // compactionCommandFunc executes the "compaction" command.
 func compactionCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction command needs 1 argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction command needs 1 argument"))
 	}
 
 	rev, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	var opts []clientv3.CompactOption
@@ -56,10 +56,10 @@ func compactionCommandFunc(cmd *cobra.Command, args []string) {
 
 	c := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
-	_, cerr := c.Compact(ctx, rev, opts...)
+	resp, cerr := c.Compact(ctx, rev, opts...)
 	cancel()
 	if cerr != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, cerr)
+		exitWithError(cobrautl.ExitError, cerr)
 	}
-	fmt.Println("compacted revision", rev)
+	display.Compact(rev, resp)
 }
@@ -55,25 +55,25 @@ func delCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := mustClientFromCmd(cmd).Delete(ctx, key, opts...)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.Del(resp)
 }
 
 func getDelOp(args []string) (string, []clientv3.OpOption) {
 	if len(args) == 0 || len(args) > 2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("del command needs one argument as key and an optional argument as range_end"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("del command needs one argument as key and an optional argument as range_end"))
 	}
 
 	if delPrefix && delFromKey {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
 	}
 
 	var opts []clientv3.OpOption
 	key := args[0]
 	if len(args) > 1 {
 		if delPrefix || delFromKey {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("too many arguments, only accept one argument when `--prefix` or `--from-key` is set"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("too many arguments, only accept one argument when `--prefix` or `--from-key` is set"))
 		}
 		opts = append(opts, clientv3.WithRange(args[1]))
 		if !delRange {
@@ -75,15 +75,15 @@ func NewDowngradeCancelCommand() *cobra.Command {
 // downgradeValidateCommandFunc executes the "downgrade validate" command.
 func downgradeValidateCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("TARGET_VERSION not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("TARGET_VERSION not provided"))
 	}
 	if len(args) > 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments"))
 	}
 	targetVersion := args[0]
 
 	if len(targetVersion) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("target version not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("target version not provided"))
 	}
 
 	ctx, cancel := commandCtx(cmd)
@@ -92,7 +92,7 @@ func downgradeValidateCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := cli.Downgrade(ctx, clientv3.DowngradeValidate, targetVersion)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.DowngradeValidate(resp)
@@ -101,15 +101,15 @@ func downgradeValidateCommandFunc(cmd *cobra.Command, args []string) {
 // downgradeEnableCommandFunc executes the "downgrade enable" command.
 func downgradeEnableCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("TARGET_VERSION not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("TARGET_VERSION not provided"))
 	}
 	if len(args) > 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("too many arguments"))
 	}
 	targetVersion := args[0]
 
 	if len(targetVersion) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("target version not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("target version not provided"))
 	}
 
 	ctx, cancel := commandCtx(cmd)
@@ -118,7 +118,7 @@ func downgradeEnableCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := cli.Downgrade(ctx, clientv3.DowngradeEnable, targetVersion)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.DowngradeEnable(resp)
@@ -132,7 +132,7 @@ func downgradeCancelCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := cli.Downgrade(ctx, clientv3.DowngradeCancel, "")
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.DowngradeCancel(resp)
@@ -44,24 +44,24 @@ func NewElectCommand() *cobra.Command {
 
 func electCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 && len(args) != 2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("elect takes one election name argument and an optional proposal argument"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("elect takes one election name argument and an optional proposal argument"))
 	}
 	c := mustClientFromCmd(cmd)
 
 	var err error
 	if len(args) == 1 {
 		if !electListen {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("no proposal argument but -l not set"))
+			exitWithError(cobrautl.ExitBadArgs, errors.New("no proposal argument but -l not set"))
 		}
 		err = observe(c, args[0])
 	} else {
 		if electListen {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("proposal given but -l is set"))
+			exitWithError(cobrautl.ExitBadArgs, errors.New("proposal given but -l is set"))
 		}
 		err = campaign(c, args[0], args[1])
 	}
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 }
 
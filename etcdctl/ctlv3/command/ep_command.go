@@ -15,7 +15,7 @@
 package command
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"os"
 	"sync"
@@ -24,16 +24,19 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
-	"go.etcd.io/etcd/api/v3/etcdserverpb"
-	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/client/pkg/v3/logutil"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdctl/v3/pkg/endpointops"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
 var (
 	epClusterEndpoints bool
 	epHashKVRev        int64
+	epRequireQuorum    bool
+	epStatusRetry      time.Duration
+	epCheckWatch       bool
+	epWatchTimeout     time.Duration
 )
 
 // NewEndpointCommand returns the cobra command for "endpoint".
@@ -49,6 +52,7 @@ func NewEndpointCommand() *cobra.Command {
 	ec.AddCommand(newEpHealthCommand())
 	ec.AddCommand(newEpStatusCommand())
 	ec.AddCommand(newEpHashKVCommand())
+	ec.AddCommand(newEpDiskUsageCommand())
 
 	return ec
 }
@@ -59,19 +63,40 @@ func newEpHealthCommand() *cobra.Command {
 		Short: "Checks the healthiness of endpoints specified in `--endpoints` flag",
 		Run:   epHealthCommandFunc,
 	}
-
+	cmd.Flags().BoolVar(&epCheckWatch, "check-watch", false, "also mark endpoints unhealthy unless a watch is created on them within --check-watch-timeout")
+	cmd.Flags().DurationVar(&epWatchTimeout, "check-watch-timeout", 3*time.Second, "timeout of the watch creation of --check-watch")
 	return cmd
 }
 
 func newEpStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Prints out the status of endpoints specified in `--endpoints` flag",
 		Long: `When --write-out is set to simple, this command prints out comma-separated status lists for each endpoint.
 The items in the lists are endpoint, ID, version, db size, is leader, is learner, raft term, raft index, raft applied index, errors.
+
+When --require-quorum is set, this command exits with an error unless a strict majority of the endpoints
+responded and agree on the leader and the raft term, and succeeds even if the other endpoints failed.
+
+The status of an endpoint that fails is fetched once more with --status-retry-timeout. The endpoints that
+required a retry are marked as retried in the output.
 `,
 		Run: epStatusCommandFunc,
 	}
+	cmd.Flags().BoolVar(&epRequireQuorum, "require-quorum", false, "exit with an error unless a majority of the endpoints report the same leader and raft term")
+	cmd.Flags().DurationVar(&epStatusRetry, "status-retry-timeout", 10*time.Second, "timeout of the retry of the endpoints whose status could not be fetched (0 disables the retry)")
+	return cmd
+}
+
+func newEpDiskUsageCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disk-usage",
+		Short: "Prints out the WAL and snapshot disk usage of endpoints specified in `--endpoints` flag",
+		Long: `When --write-out is set to simple, this command prints out comma-separated disk usage lists for each endpoint.
+The items in the lists are endpoint, ID, wal files, wal size, oldest wal index, snapshot files, snapshot size.
+`,
+		Run: epDiskUsageCommandFunc,
+	}
 }
 
 func newEpHashKVCommand() *cobra.Command {
@@ -84,82 +109,41 @@ func newEpHashKVCommand() *cobra.Command {
 	return hc
 }
 
-type epHealth struct {
-	Ep     string `json:"endpoint"`
-	Health bool   `json:"health"`
-	Took   string `json:"took"`
-	Error  string `json:"error,omitempty"`
-}
+type (
+	epHealth = endpointops.Health
+	epStatus = endpointops.Status
+	epHashKV = endpointops.HashKV
+)
 
 // epHealthCommandFunc executes the "endpoint-health" command.
 func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
-	cfgSpec := clientConfigFromCmd(cmd)
+	cfg, err := clientv3.NewClientConfig(clientConfigFromCmd(cmd), lg)
+	if err != nil {
+		exitWithError(cobrautl.ExitBadArgs, err)
+	}
+	cfg.Logger = lg.Named("client")
 
-	var cfgs []*clientv3.Config
-	for _, ep := range endpointsFromCluster(cmd) {
-		cloneCfgSpec := cfgSpec.Clone()
-		cloneCfgSpec.Endpoints = []string{ep}
-		cfg, err := clientv3.NewClientConfig(cloneCfgSpec, lg)
-		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
-		}
-		cfgs = append(cfgs, cfg)
+	var opts []endpointops.HealthOption
+	if epCheckWatch {
+		opts = append(opts, endpointops.WithWatchCheck(epWatchTimeout))
 	}
 
+	eps := endpointsFromCluster(cmd)
 	var wg sync.WaitGroup
-	hch := make(chan epHealth, len(cfgs))
-	for _, cfg := range cfgs {
+	hch := make(chan epHealth, len(eps))
+	for _, ep := range eps {
 		wg.Add(1)
-		go func(cfg *clientv3.Config) {
+		go func(ep string) {
 			defer wg.Done()
-			ep := cfg.Endpoints[0]
-			cfg.Logger = lg.Named("client")
-			cli, err := clientv3.New(*cfg)
-			if err != nil {
-				hch <- epHealth{Ep: ep, Health: false, Error: err.Error()}
-				return
-			}
-			st := time.Now()
-			// get a random key. As long as we can get the response without an error, the
-			// endpoint is health.
 			ctx, cancel := commandCtx(cmd)
-			_, err = cli.Get(ctx, "health")
-			eh := epHealth{Ep: ep, Health: false, Took: time.Since(st).String()}
-			// permission denied is OK since proposal goes through consensus to get it
-			if err == nil || errors.Is(err, rpctypes.ErrPermissionDenied) {
-				eh.Health = true
-			} else {
-				eh.Error = err.Error()
-			}
-
-			if eh.Health {
-				resp, err := cli.AlarmList(ctx)
-				if err == nil && len(resp.Alarms) > 0 {
-					eh.Health = false
-					eh.Error = "Active Alarm(s): "
-					for _, v := range resp.Alarms {
-						switch v.Alarm {
-						case etcdserverpb.AlarmType_NOSPACE:
-							eh.Error = eh.Error + "NOSPACE "
-						case etcdserverpb.AlarmType_CORRUPT:
-							eh.Error = eh.Error + "CORRUPT "
-						default:
-							eh.Error = eh.Error + "UNKNOWN "
-						}
-					}
-				} else if err != nil {
-					eh.Health = false
-					eh.Error = "Unable to fetch the alarm list"
-				}
-			}
-			cancel()
-			hch <- eh
-		}(cfg)
+			defer cancel()
+			hch <- endpointops.CheckHealth(ctx, *cfg, ep, opts...)
+		}(ep)
 	}
 
 	wg.Wait()
@@ -175,71 +159,150 @@ func epHealthCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	display.EndpointHealth(healthList)
 	if errs {
-		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("unhealthy cluster"))
+		exitWithError(cobrautl.ExitError, fmt.Errorf("unhealthy cluster"))
 	}
 }
 
-type epStatus struct {
-	Ep   string                   `json:"Endpoint"`
-	Resp *clientv3.StatusResponse `json:"Status"`
-}
-
 func epStatusCommandFunc(cmd *cobra.Command, args []string) {
-	cfg := clientConfigFromCmd(cmd)
+	eps := endpointsFromCluster(cmd)
+	statusList, err := endpointStatuses(cmd, eps, epStatusRetry)
+	display.EndpointStatus(statusList)
 
-	var statusList []epStatus
-	var err error
-	for _, ep := range endpointsFromCluster(cmd) {
-		cfg.Endpoints = []string{ep}
-		c := mustClient(cfg)
-		ctx, cancel := commandCtx(cmd)
-		resp, serr := c.Status(ctx, ep)
-		cancel()
-		c.Close()
-		if serr != nil {
-			err = serr
-			fmt.Fprintf(os.Stderr, "Failed to get the status of endpoint %s (%v)\n", ep, serr)
+	if epRequireQuorum {
+		if qerr := statusQuorum(statusList, len(eps)); qerr != nil {
+			exitWithError(cobrautl.ExitError, qerr)
+		}
+		return
+	}
+	if err != nil {
+		os.Exit(cobrautl.ExitError)
+	}
+}
+
+// statusQuorum returns an error unless a strict majority of the total
+// endpoints reported the same leader at the same raft term. Endpoints that
+// did not respond count against the majority.
+func statusQuorum(statusList []epStatus, total int) error {
+	type view struct {
+		leader uint64
+		term   uint64
+	}
+	counts := make(map[view]int)
+	var best view
+	for _, st := range statusList {
+		if st.Resp == nil || st.Resp.Leader == 0 {
 			continue
 		}
-		statusList = append(statusList, epStatus{Ep: ep, Resp: resp})
+		v := view{leader: st.Resp.Leader, term: st.Resp.RaftTerm}
+		counts[v]++
+		if counts[v] > counts[best] {
+			best = v
+		}
+	}
+	if counts[best] == 0 {
+		return fmt.Errorf("no quorum: none of the %d endpoints reported a leader", total)
 	}
+	if counts[best] <= total/2 {
+		return fmt.Errorf("no quorum: %d of %d endpoints agree on leader %x at raft term %d", counts[best], total, best.leader, best.term)
+	}
+	return nil
+}
 
-	display.EndpointStatus(statusList)
+func epDiskUsageCommandFunc(cmd *cobra.Command, args []string) {
+	statusList, err := endpointStatuses(cmd, endpointsFromCluster(cmd), 0)
+	display.EndpointDiskUsage(statusList)
 
 	if err != nil {
 		os.Exit(cobrautl.ExitError)
 	}
 }
 
-type epHashKV struct {
-	Ep   string                   `json:"Endpoint"`
-	Resp *clientv3.HashKVResponse `json:"HashKV"`
+// endpointStatuses gets the status of each endpoint concurrently, reporting
+// the endpoints that fail on stderr. It returns the last error encountered,
+// if any.
+// endpointStatuses returns the statuses of the endpoints that answered. The
+// status of the endpoints that failed is fetched once more with a timeout of
+// retryTimeout, unless it is zero.
+func endpointStatuses(cmd *cobra.Command, eps []string, retryTimeout time.Duration) ([]epStatus, error) {
+	cfg := mustClientConfigFromCmd(cmd)
+	cfg.Endpoints = eps
+	cli, err := clientv3.New(*cfg)
+	if err != nil {
+		exitWithError(cobrautl.ExitBadConnection, err)
+	}
+	defer cli.Close()
+
+	ctx, cancel := commandCtx(cmd)
+	statuses, err := cli.StatusAll(ctx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	var retried []bool
+	if retryTimeout > 0 {
+		retried = retryFailedStatuses(statuses, func(ep string) (*clientv3.StatusResponse, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), retryTimeout)
+			defer cancel()
+			return cli.Status(ctx, ep)
+		})
+	}
+
+	var statusList []epStatus
+	for i, st := range statuses {
+		if st.Err != nil {
+			err = st.Err
+			fmt.Fprintf(os.Stderr, "Failed to get the status of endpoint %s (%v)\n", st.Endpoint, st.Err)
+			continue
+		}
+		statusList = append(statusList, epStatus{Ep: st.Endpoint, Resp: st.Status, Retried: retried != nil && retried[i]})
+	}
+	return statusList, err
+}
+
+// retryFailedStatuses fetches concurrently the status of the endpoints that
+// failed, and returns which of the statuses were retried.
+func retryFailedStatuses(statuses []clientv3.EndpointStatus, status func(ep string) (*clientv3.StatusResponse, error)) []bool {
+	retried := make([]bool, len(statuses))
+	var wg sync.WaitGroup
+	for i := range statuses {
+		st := &statuses[i]
+		if st.Err == nil {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Retrying to get the status of endpoint %s (%v)\n", st.Endpoint, st.Err)
+		retried[i] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			st.Status, st.Err = status(st.Endpoint)
+		}()
+	}
+	wg.Wait()
+	return retried
 }
 
 func epHashKVCommandFunc(cmd *cobra.Command, args []string) {
-	cfg := clientConfigFromCmd(cmd)
+	cfg := mustClientConfigFromCmd(cmd)
 
 	var hashList []epHashKV
 	var err error
 	for _, ep := range endpointsFromCluster(cmd) {
-		cfg.Endpoints = []string{ep}
-		c := mustClient(cfg)
 		ctx, cancel := commandCtx(cmd)
-		resp, serr := c.HashKV(ctx, ep, epHashKVRev)
+		hkv, serr := endpointops.GetHashKV(ctx, *cfg, ep, epHashKVRev)
 		cancel()
-		c.Close()
 		if serr != nil {
 			err = serr
 			fmt.Fprintf(os.Stderr, "Failed to get the hash of endpoint %s (%v)\n", ep, serr)
 			continue
 		}
-		hashList = append(hashList, epHashKV{Ep: ep, Resp: resp})
+		hashList = append(hashList, hkv)
 	}
 
 	display.EndpointHashKV(hashList)
 
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 }
 
@@ -247,7 +310,7 @@ func endpointsFromCluster(cmd *cobra.Command) []string {
 	if !epClusterEndpoints {
 		endpoints, err := cmd.Flags().GetStringSlice("endpoints")
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitError, err)
+			exitWithError(cobrautl.ExitError, err)
 		}
 		return endpoints
 	}
@@ -259,7 +322,7 @@ func endpointsFromCluster(cmd *cobra.Command) []string {
 	kat := keepAliveTimeoutFromCmd(cmd)
 	eps, err := endpointsFromCmd(cmd)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	// exclude auth for not asking needless password (MemberList() doesn't need authentication)
 	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
@@ -273,27 +336,13 @@ func endpointsFromCluster(cmd *cobra.Command) []string {
 		Auth:             au,
 	}, lg)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
-	}
-	c, err := clientv3.New(*cfg)
-	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
-
 	ctx, cancel := commandCtx(cmd)
-	defer func() {
-		c.Close()
-		cancel()
-	}()
-	membs, err := c.MemberList(ctx)
+	defer cancel()
+	ret, err := endpointops.ClusterEndpoints(ctx, *cfg)
 	if err != nil {
-		err = fmt.Errorf("failed to fetch endpoints from etcd cluster member list: %w", err)
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
-	}
-
-	var ret []string
-	for _, m := range membs.Members {
-		ret = append(ret, m.ClientURLs...)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return ret
 }
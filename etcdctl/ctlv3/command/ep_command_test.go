@@ -0,0 +1,103 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestStatusQuorum(t *testing.T) {
+	status := func(leader, term uint64) epStatus {
+		return epStatus{Resp: &clientv3.StatusResponse{Leader: leader, RaftTerm: term}}
+	}
+	tests := []struct {
+		name       string
+		statusList []epStatus
+		total      int
+		wantErr    string
+	}{
+		{
+			name:       "all endpoints agree",
+			statusList: []epStatus{status(1, 2), status(1, 2), status(1, 2)},
+			total:      3,
+		},
+		{
+			name:       "majority agrees while one endpoint failed",
+			statusList: []epStatus{status(1, 2), status(1, 2)},
+			total:      3,
+		},
+		{
+			name:       "majority agrees while one endpoint lags behind",
+			statusList: []epStatus{status(1, 2), status(1, 2), status(3, 1)},
+			total:      3,
+		},
+		{
+			name:       "half of the endpoints is not a majority",
+			statusList: []epStatus{status(1, 2), status(1, 2)},
+			total:      4,
+			wantErr:    "no quorum: 2 of 4 endpoints agree on leader 1 at raft term 2",
+		},
+		{
+			name:       "endpoints disagree on the raft term",
+			statusList: []epStatus{status(1, 2), status(1, 3), status(1, 3)},
+			total:      5,
+			wantErr:    "no quorum: 2 of 5 endpoints agree on leader 1 at raft term 3",
+		},
+		{
+			name:       "no endpoint reported a leader",
+			statusList: []epStatus{status(0, 2), status(0, 2)},
+			total:      3,
+			wantErr:    "no quorum: none of the 3 endpoints reported a leader",
+		},
+		{
+			name:    "no endpoint responded",
+			total:   1,
+			wantErr: "no quorum: none of the 1 endpoints reported a leader",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := statusQuorum(tc.statusList, tc.total)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryFailedStatuses(t *testing.T) {
+	statuses := []clientv3.EndpointStatus{
+		{Endpoint: "a", Status: &clientv3.StatusResponse{Leader: 1}},
+		{Endpoint: "b", Err: errors.New("context deadline exceeded")},
+		{Endpoint: "c", Err: errors.New("context deadline exceeded")},
+	}
+	retried := retryFailedStatuses(statuses, func(ep string) (*clientv3.StatusResponse, error) {
+		if ep == "c" {
+			return nil, errors.New("connection refused")
+		}
+		return &clientv3.StatusResponse{Leader: 1}, nil
+	})
+	assert.Equal(t, []bool{false, true, true}, retried)
+	assert.NoError(t, statuses[1].Err)
+	assert.NotNil(t, statuses[1].Status)
+	assert.EqualError(t, statuses[2].Err, "connection refused")
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// errorInfo describes how a well-known error is presented to the user.
+type errorInfo struct {
+	err error
+	// code is a stable machine-readable identifier of the error.
+	code string
+	// hint is a short remediation hint.
+	hint string
+}
+
+// knownErrors maps well-known errors to their code and remediation hint.
+// Errors not listed here are presented unchanged.
+var knownErrors = []errorInfo{
+	{
+		err:  rpctypes.ErrCompacted,
+		code: "compacted",
+		hint: "the requested revision has been compacted; use a newer --rev",
+	},
+	{
+		err:  rpctypes.ErrFutureRev,
+		code: "future_revision",
+		hint: "the requested revision has not been written yet; use an older --rev",
+	},
+	{
+		err:  rpctypes.ErrNoSpace,
+		code: "no_space",
+		hint: "the storage quota is exhausted; free space with 'etcdctl compaction' and 'etcdctl defrag', then clear the alarm with 'etcdctl alarm disarm'",
+	},
+	{
+		err:  rpctypes.ErrNoLeader,
+		code: "no_leader",
+		hint: "the cluster has no leader; check member connectivity with 'etcdctl endpoint status --cluster'",
+	},
+	{
+		err:  rpctypes.ErrTimeout,
+		code: "timeout",
+		hint: "the server did not finish the request in time; retry, and check cluster health with 'etcdctl endpoint health --cluster'",
+	},
+	{
+		err:  context.DeadlineExceeded,
+		code: "deadline_exceeded",
+		hint: "the request did not finish before --command-timeout; retry with a larger --command-timeout",
+	},
+	{
+		err:  rpctypes.ErrPermissionDenied,
+		code: "permission_denied",
+		hint: "the user is not allowed to perform this request; check its roles with 'etcdctl user get' and 'etcdctl role get'",
+	},
+	{
+		err:  rpctypes.ErrAuthFailed,
+		code: "auth_failed",
+		hint: "check the --user name and password",
+	},
+	{
+		err:  rpctypes.ErrInvalidAuthToken,
+		code: "invalid_auth_token",
+		hint: "the auth token has expired or is invalid; retry the request to get a new token",
+	},
+	{
+		err:  rpctypes.ErrUserEmpty,
+		code: "user_empty",
+		hint: "authentication is enabled; pass credentials with --user",
+	},
+	{
+		err:  rpctypes.ErrLeaseNotFound,
+		code: "lease_not_found",
+		hint: "the lease has expired or was revoked; grant a new one with 'etcdctl lease grant'",
+	},
+	{
+		err:  rpctypes.ErrRequestTooLarge,
+		code: "request_too_large",
+		hint: "the request exceeds the server's --max-request-bytes; split it into smaller requests",
+	},
+	{
+		err:  rpctypes.ErrTooManyOps,
+		code: "too_many_ops",
+		hint: "the transaction exceeds the server's --max-txn-ops; split it into smaller transactions",
+	},
+	{
+		err:  rpctypes.ErrUnhealthy,
+		code: "unhealthy_cluster",
+		hint: "the change would leave the cluster without quorum; check 'etcdctl endpoint health --cluster' first",
+	},
+	{
+		err:  rpctypes.ErrCorrupt,
+		code: "corrupt",
+		hint: "a member has detected data inconsistency; inspect 'etcdctl alarm list' and the member logs",
+	},
+}
+
+// lookupError returns the presentation of err, or false if err is not well-known.
+func lookupError(err error) (errorInfo, bool) {
+	for _, info := range knownErrors {
+		if errors.Is(err, info.err) {
+			return info, true
+		}
+	}
+	return errorInfo{}, false
+}
+
+// compactedError annotates a compacted error with the compact revision of
+// the cluster, so that the hint can point to a usable revision.
+type compactedError struct {
+	err             error
+	compactRevision int64
+}
+
+func (e *compactedError) Error() string { return e.err.Error() }
+
+func (e *compactedError) Unwrap() error { return e.err }
+
+// withCompactRevision annotates a compacted error with the compact revision
+// reported by the cluster. Other errors, or errors that occur while looking
+// up the revision, leave err unchanged.
+func withCompactRevision(ctx context.Context, c *clientv3.Client, err error) error {
+	if !errors.Is(err, rpctypes.ErrCompacted) || len(c.Endpoints()) == 0 {
+		return err
+	}
+	resp, serr := c.Status(ctx, c.Endpoints()[0])
+	if serr != nil || resp.CompactRevision <= 0 {
+		return err
+	}
+	return &compactedError{err: err, compactRevision: resp.CompactRevision}
+}
+
+// errorHint returns the remediation hint of err, or "" if there is none.
+func errorHint(err error) string {
+	info, ok := lookupError(err)
+	if !ok {
+		return ""
+	}
+	var cerr *compactedError
+	if errors.As(err, &cerr) {
+		return fmt.Sprintf("%s; current compact revision is %d", info.hint, cerr.compactRevision)
+	}
+	return info.hint
+}
+
+// errorCode returns the machine-readable code of err, or "" if there is none.
+func errorCode(err error) string {
+	info, _ := lookupError(err)
+	return info.code
+}
+
+// printError writes err to w, followed by its remediation hint if it is a
+// well-known error. In JSON output mode the error, its code and hint are
+// written as a single JSON object instead.
+func printError(w io.Writer, err error) {
+	if _, ok := display.(*jsonPrinter); ok {
+		b, merr := json.Marshal(struct {
+			Error string `json:"error"`
+			Code  string `json:"code,omitempty"`
+			Hint  string `json:"hint,omitempty"`
+		}{Error: err.Error(), Code: errorCode(err), Hint: errorHint(err)})
+		if merr == nil {
+			fmt.Fprintln(w, string(b))
+			return
+		}
+	}
+	fmt.Fprintln(w, "Error:", err)
+	if hint := errorHint(err); hint != "" {
+		fmt.Fprintln(w, "Hint:", hint)
+	}
+}
+
+// exitWithError prints err with printError and exits with the given code.
+func exitWithError(code int, err error) {
+	printError(os.Stderr, err)
+	os.Exit(code)
+}
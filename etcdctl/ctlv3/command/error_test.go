@@ -0,0 +1,127 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func TestKnownErrorsAreUnique(t *testing.T) {
+	codes := make(map[string]struct{})
+	for _, info := range knownErrors {
+		require.NotEmpty(t, info.code)
+		require.NotEmpty(t, info.hint)
+		_, dup := codes[info.code]
+		require.Falsef(t, dup, "duplicate error code %q", info.code)
+		codes[info.code] = struct{}{}
+	}
+}
+
+func TestErrorCodeAndHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+		hint string
+	}{
+		{
+			name: "compacted",
+			err:  rpctypes.ErrCompacted,
+			code: "compacted",
+			hint: "the requested revision has been compacted; use a newer --rev",
+		},
+		{
+			name: "compacted with known compact revision",
+			err:  &compactedError{err: rpctypes.ErrCompacted, compactRevision: 42},
+			code: "compacted",
+			hint: "the requested revision has been compacted; use a newer --rev; current compact revision is 42",
+		},
+		{
+			name: "wrapped no space",
+			err:  fmt.Errorf("put failed: %w", rpctypes.ErrNoSpace),
+			code: "no_space",
+			hint: "the storage quota is exhausted; free space with 'etcdctl compaction' and 'etcdctl defrag', then clear the alarm with 'etcdctl alarm disarm'",
+		},
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			code: "deadline_exceeded",
+			hint: "the request did not finish before --command-timeout; retry with a larger --command-timeout",
+		},
+		{
+			name: "unknown error",
+			err:  errors.New("something else"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, errorCode(tt.err))
+			assert.Equal(t, tt.hint, errorHint(tt.err))
+		})
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	defer func(p printer) { display = p }(display)
+
+	tests := []struct {
+		name    string
+		display printer
+		err     error
+		want    string
+	}{
+		{
+			name:    "simple known error",
+			display: &simplePrinter{},
+			err:     rpctypes.ErrNoLeader,
+			want:    "Error: etcdserver: no leader\nHint: the cluster has no leader; check member connectivity with 'etcdctl endpoint status --cluster'\n",
+		},
+		{
+			name:    "simple unknown error",
+			display: &simplePrinter{},
+			err:     errors.New("boom"),
+			want:    "Error: boom\n",
+		},
+		{
+			name:    "json known error",
+			display: newJSONPrinter(false),
+			err:     rpctypes.ErrLeaseNotFound,
+			want:    `{"error":"etcdserver: requested lease not found","code":"lease_not_found","hint":"the lease has expired or was revoked; grant a new one with 'etcdctl lease grant'"}` + "\n",
+		},
+		{
+			name:    "json unknown error",
+			display: newJSONPrinter(false),
+			err:     errors.New("boom"),
+			want:    `{"error":"boom"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			display = tt.display
+			var buf bytes.Buffer
+			printError(&buf, tt.err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -40,8 +40,24 @@ var (
 	getMinModRev    int64
 	getMaxModRev    int64
 	getStream       bool
+	getSummary      bool
 )
 
+// getSummaryPageSize is the number of keys fetched per range request
+// when computing a summary with --summary.
+const getSummaryPageSize = 1000
+
+// getSummaryResult holds the aggregate statistics printed by "get --summary".
+type getSummaryResult struct {
+	Revision        int64 `json:"revision"`
+	Count           int64 `json:"count"`
+	TotalKeyBytes   int64 `json:"total_key_bytes"`
+	TotalValueBytes int64 `json:"total_value_bytes"`
+	MinModRevision  int64 `json:"min_mod_revision"`
+	MaxModRevision  int64 `json:"max_mod_revision"`
+	Leases          int   `json:"leases"`
+}
+
 // NewGetCommand returns the cobra command for "get".
 func NewGetCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -66,6 +82,7 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().Int64Var(&getMinModRev, "min-mod-rev", 0, "Minimum modification revision")
 	cmd.Flags().Int64Var(&getMaxModRev, "max-mod-rev", 0, "Maximum modification revision")
 	cmd.Flags().BoolVar(&getStream, "stream", false, "Use the RangeStream RPC")
+	cmd.Flags().BoolVar(&getSummary, "summary", false, "Print a summary of key count, key/value sizes, mod revisions and leases after the keys (only the summary with --count-only)")
 
 	cmd.RegisterFlagCompletionFunc("consistency", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return []string{"l", "s"}, cobra.ShellCompDirectiveDefault
@@ -83,6 +100,10 @@ func NewGetCommand() *cobra.Command {
 // getCommandFunc executes the "get" command.
 func getCommandFunc(cmd *cobra.Command, args []string) {
 	key, opts := getGetOp(args)
+	if getSummary {
+		getSummaryFunc(cmd, key, opts)
+		return
+	}
 	ctx, cancel := commandCtx(cmd)
 	client := mustClientFromCmd(cmd)
 	var (
@@ -98,21 +119,24 @@ func getCommandFunc(cmd *cobra.Command, args []string) {
 	} else {
 		resp, err = client.Get(ctx, key, opts...)
 	}
+	if err != nil {
+		err = withCompactRevision(ctx, client, err)
+	}
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	if getCountOnly {
 		if _, fields := display.(*fieldsPrinter); !fields {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--count-only is only for `--write-out=fields`"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--count-only is only for `--write-out=fields`"))
 		}
 	}
 
 	if printValueOnly {
 		dp, simple := (display).(*simplePrinter)
 		if !simple {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("print-value-only is only for `--write-out=simple`"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("print-value-only is only for `--write-out=simple`"))
 		}
 		dp.valueOnly = true
 	}
@@ -121,15 +145,27 @@ func getCommandFunc(cmd *cobra.Command, args []string) {
 
 func getGetOp(args []string) (string, []clientv3.OpOption) {
 	if len(args) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("get command needs one argument as key and an optional argument as range_end"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("get command needs one argument as key and an optional argument as range_end"))
 	}
 
 	if getPrefix && getFromKey {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--prefix` and `--from-key` cannot be set at the same time, choose one"))
 	}
 
 	if getKeysOnly && getCountOnly {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--keys-only` and `--count-only` cannot be set at the same time, choose one"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--keys-only` and `--count-only` cannot be set at the same time, choose one"))
+	}
+
+	if getSummary {
+		if getLimit != 0 {
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--summary` and `--limit` cannot be set at the same time, the summary would only cover part of the range"))
+		}
+		if getSortOrder != "" || getSortTarget != "" {
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--summary` cannot be combined with `--order` or `--sort-by`"))
+		}
+		if getStream {
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--summary` and `--stream` cannot be set at the same time, choose one"))
+		}
 	}
 
 	var opts []clientv3.OpOption
@@ -140,7 +176,7 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 	key := args[0]
 	if len(args) > 1 {
 		if getPrefix || getFromKey {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("too many arguments, only accept one argument when `--prefix` or `--from-key` is set"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("too many arguments, only accept one argument when `--prefix` or `--from-key` is set"))
 		}
 		opts = append(opts, clientv3.WithRange(args[1]))
 	}
@@ -160,7 +196,7 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 	case sortOrder == "":
 		// nothing
 	default:
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("bad sort order %v", getSortOrder))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("bad sort order %v", getSortOrder))
 	}
 
 	sortByTarget := clientv3.SortByKey
@@ -179,7 +215,7 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 	case sortTarget == "":
 		// nothing
 	default:
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("bad sort target %v", getSortTarget))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("bad sort target %v", getSortTarget))
 	}
 
 	opts = append(opts, clientv3.WithSort(sortByTarget, sortByOrder))
@@ -200,11 +236,13 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 		opts = append(opts, clientv3.WithFromKey())
 	}
 
-	if getKeysOnly {
+	// --summary needs the values to account for their size, and it
+	// handles --keys-only and --count-only itself when printing.
+	if getKeysOnly && !getSummary {
 		opts = append(opts, clientv3.WithKeysOnly())
 	}
 
-	if getCountOnly {
+	if getCountOnly && !getSummary {
 		opts = append(opts, clientv3.WithCountOnly())
 	}
 
@@ -214,7 +252,7 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 
 	if getMaxCreateRev > 0 {
 		if getMinCreateRev > getMaxCreateRev {
-			cobrautl.ExitWithError(cobrautl.ExitBadFeature,
+			exitWithError(cobrautl.ExitBadFeature,
 				fmt.Errorf("getMinCreateRev(=%v) > getMaxCreateRev(=%v)", getMinCreateRev, getMaxCreateRev))
 		}
 		opts = append(opts, clientv3.WithMaxCreateRev(getMaxCreateRev))
@@ -226,7 +264,7 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 
 	if getMaxModRev > 0 {
 		if getMinModRev > getMaxModRev {
-			cobrautl.ExitWithError(cobrautl.ExitBadFeature,
+			exitWithError(cobrautl.ExitBadFeature,
 				fmt.Errorf("getMinModRev(=%v) > getMaxModRev(=%v)", getMinModRev, getMaxModRev))
 		}
 		opts = append(opts, clientv3.WithMaxModRev(getMaxModRev))
@@ -234,3 +272,72 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 
 	return key, opts
 }
+
+// getSummaryFunc pages through the requested range at a single revision,
+// printing the keys unless --count-only is set, and then prints the summary.
+func getSummaryFunc(cmd *cobra.Command, key string, opts []clientv3.OpOption) {
+	if printValueOnly {
+		dp, simple := (display).(*simplePrinter)
+		if !simple {
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("print-value-only is only for `--write-out=simple`"))
+		}
+		dp.valueOnly = true
+	}
+
+	// Resolve the range end once, since options like WithPrefix derive it
+	// from the key, which changes from page to page.
+	op := clientv3.OpGet(key, opts...)
+	opts = append(opts, clientv3.WithLimit(getSummaryPageSize), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if end := op.RangeBytes(); len(end) > 0 {
+		opts = append(opts, clientv3.WithRange(string(end)))
+	}
+
+	client := mustClientFromCmd(cmd)
+	leases := make(map[int64]struct{})
+	summary := getSummaryResult{Revision: getRev}
+	for {
+		pageOpts := opts
+		if summary.Revision > 0 {
+			pageOpts = append(pageOpts, clientv3.WithRev(summary.Revision))
+		}
+		ctx, cancel := commandCtx(cmd)
+		resp, err := client.Get(ctx, key, pageOpts...)
+		cancel()
+		if err != nil {
+			exitWithError(cobrautl.ExitError, err)
+		}
+		if summary.Revision == 0 {
+			summary.Revision = resp.Header.Revision
+		}
+
+		for _, kv := range resp.Kvs {
+			summary.Count++
+			summary.TotalKeyBytes += int64(len(kv.Key))
+			summary.TotalValueBytes += int64(len(kv.Value))
+			if summary.MinModRevision == 0 || kv.ModRevision < summary.MinModRevision {
+				summary.MinModRevision = kv.ModRevision
+			}
+			if kv.ModRevision > summary.MaxModRevision {
+				summary.MaxModRevision = kv.ModRevision
+			}
+			if kv.Lease != 0 {
+				leases[kv.Lease] = struct{}{}
+			}
+			if getKeysOnly {
+				kv.Value = nil
+			}
+		}
+
+		if !getCountOnly && len(resp.Kvs) > 0 {
+			display.Get(resp)
+		}
+
+		if !resp.More || len(resp.Kvs) == 0 {
+			break
+		}
+		key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+	}
+	summary.Leases = len(leases)
+
+	display.GetSummary(summary)
+}
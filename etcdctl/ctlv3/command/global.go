@@ -55,6 +55,7 @@ type GlobalFlags struct {
 
 	OutputFormat string
 	IsHex        bool
+	ShowHeader   bool
 
 	User     string
 	Password string
@@ -74,14 +75,22 @@ var display printer = &simplePrinter{}
 func initDisplayFromCmd(cmd *cobra.Command) {
 	isHex, err := cmd.Flags().GetBool("hex")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	outputType, err := cmd.Flags().GetString("write-out")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	if display = NewPrinter(outputType, isHex); display == nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, errors.New("unsupported output format"))
+		exitWithError(cobrautl.ExitBadFeature, errors.New("unsupported output format"))
+	}
+	showHeader, err := cmd.Flags().GetBool("show-header")
+	if err != nil {
+		exitWithError(cobrautl.ExitError, err)
+	}
+	// the other output formats always include the header
+	if sp, simple := display.(*simplePrinter); simple {
+		sp.showHeader = showHeader
 	}
 }
 
@@ -94,7 +103,7 @@ func (*discardValue) Type() string     { return "" }
 func clientConfigFromCmd(cmd *cobra.Command) *clientv3.ConfigSpec {
 	lg, err := logutil.CreateDefaultZapLogger(zap.InfoLevel)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	fs := cmd.InheritedFlags()
 	if strings.HasPrefix(cmd.Use, "watch") {
@@ -107,7 +116,7 @@ func clientConfigFromCmd(cmd *cobra.Command) *clientv3.ConfigSpec {
 
 	debug, err := cmd.Flags().GetBool("debug")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	if debug {
 		grpclog.SetLoggerV2(grpclog.NewLoggerV2WithVerbosity(os.Stderr, os.Stderr, os.Stderr, 4))
@@ -125,7 +134,7 @@ func clientConfigFromCmd(cmd *cobra.Command) *clientv3.ConfigSpec {
 	cfg := &clientv3.ConfigSpec{}
 	cfg.Endpoints, err = endpointsFromCmd(cmd)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	cfg.DialTimeout = dialTimeoutFromCmd(cmd)
@@ -146,7 +155,7 @@ func mustClientCfgFromCmd(cmd *cobra.Command) *clientv3.Config {
 	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
 	cfg, err := clientv3.NewClientConfig(cc, lg)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 	return cfg
 }
@@ -157,20 +166,29 @@ func mustClientFromCmd(cmd *cobra.Command) *clientv3.Client {
 }
 
 func mustClient(cc *clientv3.ConfigSpec) *clientv3.Client {
-	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
-	cfg, err := clientv3.NewClientConfig(cc, lg)
-	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
-	}
+	cfg := mustClientConfig(cc)
 
 	client, err := clientv3.New(*cfg)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadConnection, err)
+		exitWithError(cobrautl.ExitBadConnection, err)
 	}
 
 	return client
 }
 
+func mustClientConfigFromCmd(cmd *cobra.Command) *clientv3.Config {
+	return mustClientConfig(clientConfigFromCmd(cmd))
+}
+
+func mustClientConfig(cc *clientv3.ConfigSpec) *clientv3.Config {
+	lg, _ := logutil.CreateDefaultZapLogger(zap.InfoLevel)
+	cfg, err := clientv3.NewClientConfig(cc, lg)
+	if err != nil {
+		exitWithError(cobrautl.ExitBadArgs, err)
+	}
+	return cfg
+}
+
 func argOrStdin(args []string, stdin io.Reader, i int) (string, error) {
 	if i < len(args) {
 		return args[i], nil
@@ -185,7 +203,7 @@ func argOrStdin(args []string, stdin io.Reader, i int) (string, error) {
 func dialTimeoutFromCmd(cmd *cobra.Command) time.Duration {
 	dialTimeout, err := cmd.Flags().GetDuration("dial-timeout")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return dialTimeout
 }
@@ -193,7 +211,7 @@ func dialTimeoutFromCmd(cmd *cobra.Command) time.Duration {
 func keepAliveTimeFromCmd(cmd *cobra.Command) time.Duration {
 	keepAliveTime, err := cmd.Flags().GetDuration("keepalive-time")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return keepAliveTime
 }
@@ -201,7 +219,7 @@ func keepAliveTimeFromCmd(cmd *cobra.Command) time.Duration {
 func keepAliveTimeoutFromCmd(cmd *cobra.Command) time.Duration {
 	keepAliveTimeout, err := cmd.Flags().GetDuration("keepalive-timeout")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return keepAliveTimeout
 }
@@ -209,7 +227,7 @@ func keepAliveTimeoutFromCmd(cmd *cobra.Command) time.Duration {
 func maxCallSendMsgSizeFromCmd(cmd *cobra.Command) int {
 	maxRequestBytes, err := cmd.Flags().GetInt("max-request-bytes")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return maxRequestBytes
 }
@@ -217,7 +235,7 @@ func maxCallSendMsgSizeFromCmd(cmd *cobra.Command) int {
 func maxCallRecvMsgSizeFromCmd(cmd *cobra.Command) int {
 	maxReceiveBytes, err := cmd.Flags().GetInt("max-recv-bytes")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return maxReceiveBytes
 }
@@ -246,7 +264,7 @@ func secureCfgFromCmd(cmd *cobra.Command) *clientv3.SecureConfig {
 func insecureTransportFromCmd(cmd *cobra.Command) bool {
 	insecureTr, err := cmd.Flags().GetBool("insecure-transport")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return insecureTr
 }
@@ -254,7 +272,7 @@ func insecureTransportFromCmd(cmd *cobra.Command) bool {
 func insecureSkipVerifyFromCmd(cmd *cobra.Command) bool {
 	skipVerify, err := cmd.Flags().GetBool("insecure-skip-tls-verify")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return skipVerify
 }
@@ -262,21 +280,21 @@ func insecureSkipVerifyFromCmd(cmd *cobra.Command) bool {
 func keyAndCertFromCmd(cmd *cobra.Command) (cert, key, cacert string) {
 	var err error
 	if cert, err = cmd.Flags().GetString("cert"); err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	} else if cert == "" && cmd.Flags().Changed("cert") {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --cert option"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --cert option"))
 	}
 
 	if key, err = cmd.Flags().GetString("key"); err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	} else if key == "" && cmd.Flags().Changed("key") {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --key option"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --key option"))
 	}
 
 	if cacert, err = cmd.Flags().GetString("cacert"); err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	} else if cacert == "" && cmd.Flags().Changed("cacert") {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --cacert option"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("empty string is passed to --cacert option"))
 	}
 
 	return cert, key, cacert
@@ -285,15 +303,15 @@ func keyAndCertFromCmd(cmd *cobra.Command) (cert, key, cacert string) {
 func authCfgFromCmd(cmd *cobra.Command) *clientv3.AuthConfig {
 	userFlag, err := cmd.Flags().GetString("user")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 	passwordFlag, err := cmd.Flags().GetString("password")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 	tokenFlag, err := cmd.Flags().GetString("auth-jwt-token")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 
 	if userFlag == "" && tokenFlag == "" {
@@ -313,7 +331,7 @@ func authCfgFromCmd(cmd *cobra.Command) *clientv3.AuthConfig {
 			cfg.Username = userFlag
 			cfg.Password, err = speakeasy.Ask("Password: ")
 			if err != nil {
-				cobrautl.ExitWithError(cobrautl.ExitError, err)
+				exitWithError(cobrautl.ExitError, err)
 			}
 		} else {
 			cfg.Username = splitted[0]
@@ -330,7 +348,7 @@ func authCfgFromCmd(cmd *cobra.Command) *clientv3.AuthConfig {
 func insecureDiscoveryFromCmd(cmd *cobra.Command) bool {
 	discovery, err := cmd.Flags().GetBool("insecure-discovery")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return discovery
 }
@@ -338,7 +356,7 @@ func insecureDiscoveryFromCmd(cmd *cobra.Command) bool {
 func discoverySrvFromCmd(cmd *cobra.Command) string {
 	domainStr, err := cmd.Flags().GetString("discovery-srv")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 	return domainStr
 }
@@ -346,7 +364,7 @@ func discoverySrvFromCmd(cmd *cobra.Command) string {
 func discoveryDNSClusterServiceNameFromCmd(cmd *cobra.Command) string {
 	serviceNameStr, err := cmd.Flags().GetString("discovery-srv-name")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 	return serviceNameStr
 }
@@ -58,19 +58,19 @@ func NewLeaseGrantCommand() *cobra.Command {
 // leaseGrantCommandFunc executes the "lease grant" command.
 func leaseGrantCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease grant command needs TTL argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease grant command needs TTL argument"))
 	}
 
 	ttl, err := strconv.ParseInt(args[0], 10, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad TTL (%w)", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad TTL (%w)", err))
 	}
 
 	ctx, cancel := commandCtx(cmd)
 	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease (%w)", err))
+		exitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease (%w)", err))
 	}
 	display.Grant(resp)
 }
@@ -90,7 +90,7 @@ func NewLeaseRevokeCommand() *cobra.Command {
 // leaseRevokeCommandFunc executes the "lease grant" command.
 func leaseRevokeCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease revoke command needs 1 argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease revoke command needs 1 argument"))
 	}
 
 	id := leaseFromArgs(args[0])
@@ -98,7 +98,7 @@ func leaseRevokeCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := mustClientFromCmd(cmd).Revoke(ctx, id)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to revoke lease (%w)", err))
+		exitWithError(cobrautl.ExitError, fmt.Errorf("failed to revoke lease (%w)", err))
 	}
 	display.Revoke(id, resp)
 }
@@ -121,7 +121,7 @@ func NewLeaseTimeToLiveCommand() *cobra.Command {
 // leaseTimeToLiveCommandFunc executes the "lease timetolive" command.
 func leaseTimeToLiveCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease timetolive command needs lease ID as argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease timetolive command needs lease ID as argument"))
 	}
 	var opts []v3.LeaseOption
 	if timeToLiveKeys {
@@ -129,7 +129,7 @@ func leaseTimeToLiveCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	resp, rerr := mustClientFromCmd(cmd).TimeToLive(context.TODO(), leaseFromArgs(args[0]), opts...)
 	if rerr != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadConnection, rerr)
+		exitWithError(cobrautl.ExitBadConnection, rerr)
 	}
 	display.TimeToLive(resp, timeToLiveKeys)
 }
@@ -148,7 +148,7 @@ func NewLeaseListCommand() *cobra.Command {
 func leaseListCommandFunc(cmd *cobra.Command, args []string) {
 	resp, rerr := mustClientFromCmd(cmd).Leases(context.TODO())
 	if rerr != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadConnection, rerr)
+		exitWithError(cobrautl.ExitBadConnection, rerr)
 	}
 	display.Leases(resp)
 }
@@ -172,7 +172,7 @@ func NewLeaseKeepAliveCommand() *cobra.Command {
 // leaseKeepAliveCommandFunc executes the "lease keep-alive" command.
 func leaseKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease keep-alive command needs lease ID as argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease keep-alive command needs lease ID as argument"))
 	}
 
 	id := leaseFromArgs(args[0])
@@ -180,7 +180,7 @@ func leaseKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
 	if leaseKeepAliveOnce {
 		respc, kerr := mustClientFromCmd(cmd).KeepAliveOnce(context.TODO(), id)
 		if kerr != nil {
-			cobrautl.ExitWithError(cobrautl.ExitBadConnection, kerr)
+			exitWithError(cobrautl.ExitBadConnection, kerr)
 		}
 		display.KeepAlive(respc)
 		return
@@ -188,7 +188,7 @@ func leaseKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
 
 	respc, kerr := mustClientFromCmd(cmd).KeepAlive(context.TODO(), id)
 	if kerr != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadConnection, kerr)
+		exitWithError(cobrautl.ExitBadConnection, kerr)
 	}
 	for resp := range respc {
 		display.KeepAlive(resp)
@@ -202,7 +202,7 @@ func leaseKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
 func leaseFromArgs(arg string) v3.LeaseID {
 	id, err := strconv.ParseInt(arg, 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID arg (%w), expecting ID in Hex", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID arg (%w), expecting ID in Hex", err))
 	}
 	return v3.LeaseID(id)
 }
@@ -89,7 +89,7 @@ func authDestCfg() *clientv3.AuthConfig {
 			cfg.Username = mmuser
 			cfg.Password, err = speakeasy.Ask("Destination Password: ")
 			if err != nil {
-				cobrautl.ExitWithError(cobrautl.ExitError, err)
+				exitWithError(cobrautl.ExitError, err)
 			}
 		} else {
 			cfg.Username = splitted[0]
@@ -105,7 +105,7 @@ func authDestCfg() *clientv3.AuthConfig {
 
 func makeMirrorCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("make-mirror takes one destination argument"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("make-mirror takes one destination argument"))
 	}
 
 	dialTimeout := dialTimeoutFromCmd(cmd)
@@ -136,7 +136,7 @@ func makeMirrorCommandFunc(cmd *cobra.Command, args []string) {
 	c := mustClientFromCmd(cmd)
 
 	err := makeMirror(context.TODO(), c, dc)
-	cobrautl.ExitWithError(cobrautl.ExitError, err)
+	exitWithError(cobrautl.ExitError, err)
 }
 
 func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) error {
@@ -144,7 +144,7 @@ func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) er
 
 	// if destination prefix is specified and remove destination prefix is true return error
 	if mmnodestprefix && len(mmdestprefix) > 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("`--dest-prefix` and `--no-dest-prefix` cannot be set at the same time, choose one"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("`--dest-prefix` and `--no-dest-prefix` cannot be set at the same time, choose one"))
 	}
 
 	go func() {
@@ -159,21 +159,22 @@ func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) er
 		startRev = 0
 	}
 
-	s := mirror.NewSyncer(c, mmprefix, startRev)
+	var opts []mirror.SyncerOption
+	// the keys are mirrored to the same prefix unless a destination prefix is
+	// specified or the prefix is removed
+	if mmnodestprefix || len(mmdestprefix) > 0 {
+		opts = append(opts, mirror.WithDestPrefix(mmdestprefix))
+	}
+	s := mirror.NewSyncer(c, mmprefix, startRev, opts...)
 
 	// If a rev is provided, then do not sync the whole key space.
 	// Instead, just start watching the key space starting from the rev
 	if startRev == 0 {
 		rc, errc := s.SyncBase(ctx)
 
-		// if remove destination prefix is false and destination prefix is empty set the value of destination prefix same as prefix
-		if !mmnodestprefix && len(mmdestprefix) == 0 {
-			mmdestprefix = mmprefix
-		}
-
 		for r := range rc {
 			for _, kv := range r.Kvs {
-				_, err := dc.Put(ctx, modifyPrefix(string(kv.Key)), string(kv.Value))
+				_, err := dc.Put(ctx, string(kv.Key), string(kv.Value))
 				if err != nil {
 					return err
 				}
@@ -218,10 +219,10 @@ func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) er
 
 			switch ev.Type {
 			case mvccpb.Event_PUT:
-				ops = append(ops, clientv3.OpPut(modifyPrefix(string(ev.Kv.Key)), string(ev.Kv.Value)))
+				ops = append(ops, clientv3.OpPut(string(ev.Kv.Key), string(ev.Kv.Value)))
 				atomic.AddInt64(&total, 1)
 			case mvccpb.Event_DELETE:
-				ops = append(ops, clientv3.OpDelete(modifyPrefix(string(ev.Kv.Key))))
+				ops = append(ops, clientv3.OpDelete(string(ev.Kv.Key)))
 				atomic.AddInt64(&total, 1)
 			default:
 				panic("unexpected event type")
@@ -238,7 +239,3 @@ func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) er
 
 	return nil
 }
-
-func modifyPrefix(key string) string {
-	return strings.Replace(key, mmprefix, mmdestprefix, 1)
-}
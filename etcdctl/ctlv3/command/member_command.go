@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdctl/v3/pkg/clusterops"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
@@ -125,7 +126,7 @@ func NewMemberPromoteCommand() *cobra.Command {
 // memberAddCommandFunc executes the "member add" command.
 func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("member name not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("member name not provided"))
 	}
 	if len(args) > 1 {
 		ev := "too many arguments"
@@ -134,12 +135,12 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 				ev += fmt.Sprintf(`, did you mean --peer-urls=%s`, s)
 			}
 		}
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New(ev))
+		exitWithError(cobrautl.ExitBadArgs, errors.New(ev))
 	}
 	newMemberName := args[0]
 
 	if len(memberPeerURLs) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("member peer urls not provided"))
+		exitWithError(cobrautl.ExitBadArgs, errors.New("member peer urls not provided"))
 	}
 
 	urls := strings.Split(memberPeerURLs, ",")
@@ -156,7 +157,7 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	newID := resp.Member.ID
 
@@ -185,19 +186,19 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 // memberRemoveCommandFunc executes the "member remove" command.
 func memberRemoveCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
 	}
 
 	id, err := strconv.ParseUint(args[0], 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
 	ctx, cancel := commandCtx(cmd)
 	resp, err := mustClientFromCmd(cmd).MemberRemove(ctx, id)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.MemberRemove(id, resp)
 }
@@ -205,16 +206,16 @@ func memberRemoveCommandFunc(cmd *cobra.Command, args []string) {
 // memberUpdateCommandFunc executes the "member update" command.
 func memberUpdateCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
 	}
 
 	id, err := strconv.ParseUint(args[0], 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
 	if len(memberPeerURLs) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member peer urls not provided"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member peer urls not provided"))
 	}
 
 	urls := strings.Split(memberPeerURLs, ",")
@@ -223,7 +224,7 @@ func memberUpdateCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := mustClientFromCmd(cmd).MemberUpdate(ctx, id, urls)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.MemberUpdate(id, resp)
@@ -236,10 +237,10 @@ func memberListCommandFunc(cmd *cobra.Command, args []string) {
 		opts = append(opts, clientv3.WithSerializable())
 	}
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).MemberList(ctx, opts...)
+	resp, err := clusterops.MemberList(ctx, *mustClientConfigFromCmd(cmd), opts...)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.MemberList(resp)
@@ -248,19 +249,19 @@ func memberListCommandFunc(cmd *cobra.Command, args []string) {
 // memberPromoteCommandFunc executes the "member promote" command.
 func memberPromoteCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
 	}
 
 	id, err := strconv.ParseUint(args[0], 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
 	}
 
 	ctx, cancel := commandCtx(cmd)
 	resp, err := mustClientFromCmd(cmd).MemberPromote(ctx, id)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.MemberPromote(id, resp)
 }
@@ -38,11 +38,11 @@ func NewMoveLeaderCommand() *cobra.Command {
 // transferLeadershipCommandFunc executes the "compaction" command.
 func transferLeadershipCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("move-leader command needs 1 argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("move-leader command needs 1 argument"))
 	}
 	target, err := strconv.ParseUint(args[0], 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 
 	cfg := clientConfigFromCmd(cmd)
@@ -60,7 +60,7 @@ func transferLeadershipCommandFunc(cmd *cobra.Command, args []string) {
 		cli := mustClient(cfg)
 		resp, serr := cli.Status(ctx, ep)
 		if serr != nil {
-			cobrautl.ExitWithError(cobrautl.ExitError, serr)
+			exitWithError(cobrautl.ExitError, serr)
 		}
 
 		if resp.Header.GetMemberId() == resp.Leader {
@@ -71,14 +71,14 @@ func transferLeadershipCommandFunc(cmd *cobra.Command, args []string) {
 		cli.Close()
 	}
 	if leaderCli == nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("no leader endpoint given at %v", eps))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("no leader endpoint given at %v", eps))
 	}
 
 	var resp *clientv3.MoveLeaderResponse
 	resp, err = leaderCli.MoveLeader(ctx, target)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.MoveLeader(leaderID, target, resp)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 
@@ -30,9 +31,12 @@ import (
 type printer interface {
 	Del(*v3.DeleteResponse)
 	Get(*v3.GetResponse)
+	GetSummary(getSummaryResult)
 	Put(*v3.PutResponse)
 	Txn(*v3.TxnResponse)
+	Compact(rev int64, r *v3.CompactResponse)
 	Watch(*v3.WatchResponse)
+	WatchGap(watchGap)
 
 	Grant(r *v3.LeaseGrantResponse)
 	Revoke(id v3.LeaseID, r *v3.LeaseRevokeResponse)
@@ -49,6 +53,7 @@ type printer interface {
 	EndpointHealth([]epHealth)
 	EndpointStatus([]epStatus)
 	EndpointHashKV([]epHashKV)
+	EndpointDiskUsage([]epStatus)
 	MoveLeader(leader, target uint64, r *v3.MoveLeaderResponse)
 
 	DowngradeValidate(r *v3.DowngradeResponse)
@@ -87,6 +92,8 @@ func NewPrinter(printerType string, isHex bool) printer {
 		return newPBPrinter()
 	case "table":
 		return &tablePrinter{newPrinterUnsupported("table")}
+	case "binary":
+		return &binaryPrinter{newPrinterUnsupported("binary")}
 	}
 	return nil
 }
@@ -102,6 +109,10 @@ func (p *printerRPC) Put(r *v3.PutResponse)     { p.p((*pb.PutResponse)(r)) }
 func (p *printerRPC) Txn(r *v3.TxnResponse)     { p.p((*pb.TxnResponse)(r)) }
 func (p *printerRPC) Watch(r *v3.WatchResponse) { p.p(r) }
 
+func (p *printerRPC) Compact(rev int64, r *v3.CompactResponse) {
+	p.p((*pb.CompactionResponse)(r))
+}
+
 func (p *printerRPC) Grant(r *v3.LeaseGrantResponse)                      { p.p(r) }
 func (p *printerRPC) Revoke(id v3.LeaseID, r *v3.LeaseRevokeResponse)     { p.p(r) }
 func (p *printerRPC) KeepAlive(r *v3.LeaseKeepAliveResponse)              { p.p(r) }
@@ -168,14 +179,18 @@ type printerUnsupported struct{ printerRPC }
 
 func newPrinterUnsupported(n string) printer {
 	f := func(any) {
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, errors.New(n+" not supported as output format"))
+		exitWithError(cobrautl.ExitBadFeature, errors.New(n+" not supported as output format"))
 	}
 	return &printerUnsupported{printerRPC{nil, f}}
 }
 
-func (p *printerUnsupported) EndpointHealth([]epHealth) { p.p(nil) }
-func (p *printerUnsupported) EndpointStatus([]epStatus) { p.p(nil) }
-func (p *printerUnsupported) EndpointHashKV([]epHashKV) { p.p(nil) }
+func (p *printerUnsupported) GetSummary(getSummaryResult) { p.p(nil) }
+func (p *printerUnsupported) WatchGap(watchGap)           { p.p(nil) }
+
+func (p *printerUnsupported) EndpointHealth([]epHealth)    { p.p(nil) }
+func (p *printerUnsupported) EndpointStatus([]epStatus)    { p.p(nil) }
+func (p *printerUnsupported) EndpointHashKV([]epHashKV)    { p.p(nil) }
+func (p *printerUnsupported) EndpointDiskUsage([]epStatus) { p.p(nil) }
 
 func (p *printerUnsupported) MoveLeader(leader, target uint64, r *v3.MoveLeaderResponse) { p.p(nil) }
 func (p *printerUnsupported) DowngradeValidate(r *v3.DowngradeResponse)                  { p.p(nil) }
@@ -208,6 +223,20 @@ func makeMemberListTable(r *v3.MemberListResponse) (hdr []string, rows [][]strin
 	return hdr, rows
 }
 
+func makeGetSummaryTable(s getSummaryResult) (hdr []string, rows [][]string) {
+	hdr = []string{"revision", "count", "total key bytes", "total value bytes", "min mod revision", "max mod revision", "leases"}
+	rows = append(rows, []string{
+		fmt.Sprint(s.Revision),
+		fmt.Sprint(s.Count),
+		fmt.Sprint(s.TotalKeyBytes),
+		fmt.Sprint(s.TotalValueBytes),
+		fmt.Sprint(s.MinModRevision),
+		fmt.Sprint(s.MaxModRevision),
+		fmt.Sprint(s.Leases),
+	})
+	return hdr, rows
+}
+
 func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "health", "took", "error"}
 	for _, h := range healthList {
@@ -223,8 +252,9 @@ func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]stri
 
 func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]string) {
 	hdr = []string{
-		"endpoint", "ID", "version", "storage version", "db size", "in use", "percentage not in use", "quota", "is leader", "is learner", "raft term",
-		"raft index", "raft applied index", "errors", "downgrade target version", "downgrade enabled",
+		"endpoint", "ID", "version", "storage version", "go version", "git sha", "db size", "in use", "percentage not in use", "quota", "is leader", "is learner", "raft term",
+		"raft index", "raft applied index", "compact revision", "max clock skew", "value encoding", "errors", "downgrade target version", "downgrade enabled",
+		"retried",
 	}
 	for _, status := range statusList {
 		resp := (*pb.StatusResponse)(status.Resp)
@@ -233,6 +263,8 @@ func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]stri
 			fmt.Sprintf("%x", resp.GetHeader().GetMemberId()),
 			resp.GetVersion(),
 			resp.GetStorageVersion(),
+			resp.GetGoVersion(),
+			resp.GetGitSha(),
 			humanize.Bytes(uint64(resp.GetDbSize())),
 			humanize.Bytes(uint64(resp.GetDbSizeInUse())),
 			fmt.Sprintf("%d%%", int(float64(100-(resp.GetDbSizeInUse()*100/resp.GetDbSize())))),
@@ -242,14 +274,45 @@ func makeEndpointStatusTable(statusList []epStatus) (hdr []string, rows [][]stri
 			fmt.Sprint(resp.GetRaftTerm()),
 			fmt.Sprint(resp.GetRaftIndex()),
 			fmt.Sprint(resp.GetRaftAppliedIndex()),
+			fmt.Sprint(resp.GetCompactRevision()),
+			time.Duration(resp.GetMaxClockSkew()).String(),
+			formatValueEncoding(resp),
 			fmt.Sprint(strings.Join(resp.GetErrors(), ", ")),
 			resp.GetDowngradeInfo().GetTargetVersion(),
 			strconv.FormatBool(resp.GetDowngradeInfo().GetEnabled()),
+			strconv.FormatBool(status.Retried),
 		})
 	}
 	return hdr, rows
 }
 
+func makeEndpointDiskUsageTable(statusList []epStatus) (hdr []string, rows [][]string) {
+	hdr = []string{"endpoint", "ID", "wal files", "wal size", "oldest wal index", "snapshot files", "snapshot size"}
+	for _, status := range statusList {
+		resp := (*pb.StatusResponse)(status.Resp)
+		du := resp.GetDiskUsage()
+		rows = append(rows, []string{
+			status.Ep,
+			fmt.Sprintf("%x", resp.GetHeader().GetMemberId()),
+			fmt.Sprint(du.GetWalFileCount()),
+			humanize.Bytes(uint64(du.GetWalSize())),
+			fmt.Sprint(du.GetOldestWalIndex()),
+			fmt.Sprint(du.GetSnapshotFileCount()),
+			humanize.Bytes(uint64(du.GetSnapshotSize())),
+		})
+	}
+	return hdr, rows
+}
+
+// formatValueEncoding returns the value encoding of the member, followed by
+// the progress of migrating the stored values to it if that is not done.
+func formatValueEncoding(resp *pb.StatusResponse) string {
+	if resp.GetValueEncoding() == "" || resp.GetValueEncodingMigrationPercent() >= 100 {
+		return resp.GetValueEncoding()
+	}
+	return fmt.Sprintf("%s (%.1f%% migrated)", resp.GetValueEncoding(), resp.GetValueEncodingMigrationPercent())
+}
+
 func makeEndpointHashKVTable(hashList []epHashKV) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "hash", "hash_revision"}
 	for _, h := range hashList {
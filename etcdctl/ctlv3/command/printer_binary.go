@@ -0,0 +1,42 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// binaryPrinter prints compact tab separated lines meant to be sorted and
+// compared by scripts.
+type binaryPrinter struct{ printer }
+
+func (bp *binaryPrinter) EndpointHashKV(hashList []epHashKV) {
+	for _, line := range makeEndpointHashKVLines(hashList) {
+		fmt.Println(line)
+	}
+}
+
+// makeEndpointHashKVLines returns a line of the endpoint, hash, hash revision
+// and compact revision of each endpoint, separated by tabs.
+func makeEndpointHashKVLines(hashList []epHashKV) []string {
+	lines := make([]string, 0, len(hashList))
+	for _, h := range hashList {
+		resp := (*pb.HashKVResponse)(h.Resp)
+		lines = append(lines, fmt.Sprintf("%s\t%d\t%d\t%d", h.Ep, resp.GetHash(), resp.GetHashRevision(), resp.GetCompactRevision()))
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func TestMakeEndpointHashKVLines(t *testing.T) {
+	lines := makeEndpointHashKVLines([]epHashKV{
+		{Ep: "127.0.0.1:2379", Resp: &clientv3.HashKVResponse{Hash: 1084519789, HashRevision: 12, CompactRevision: 5}},
+		{Ep: "127.0.0.1:22379", Resp: &clientv3.HashKVResponse{Hash: 1084519789, HashRevision: 12, CompactRevision: -1}},
+	})
+	assert.Equal(t, []string{
+		"127.0.0.1:2379\t1084519789\t12\t5",
+		"127.0.0.1:22379\t1084519789\t12\t-1",
+	}, lines)
+}
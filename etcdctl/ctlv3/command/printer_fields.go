@@ -77,6 +77,16 @@ func (p *fieldsPrinter) Get(r *v3.GetResponse) {
 	fmt.Println(`"Count" :`, resp.GetCount())
 }
 
+func (p *fieldsPrinter) GetSummary(s getSummaryResult) {
+	fmt.Println(`"Revision" :`, s.Revision)
+	fmt.Println(`"Count" :`, s.Count)
+	fmt.Println(`"TotalKeyBytes" :`, s.TotalKeyBytes)
+	fmt.Println(`"TotalValueBytes" :`, s.TotalValueBytes)
+	fmt.Println(`"MinModRevision" :`, s.MinModRevision)
+	fmt.Println(`"MaxModRevision" :`, s.MaxModRevision)
+	fmt.Println(`"Leases" :`, s.Leases)
+}
+
 func (p *fieldsPrinter) Put(r *v3.PutResponse) {
 	resp := (*pb.PutResponse)(r)
 	p.hdr(resp.GetHeader())
@@ -103,6 +113,18 @@ func (p *fieldsPrinter) Txn(r *v3.TxnResponse) {
 	}
 }
 
+func (p *fieldsPrinter) WatchGap(gap watchGap) {
+	fmt.Println(`"GapLastRevision" :`, gap.LastRevision)
+	fmt.Println(`"GapNextRevision" :`, gap.NextRevision)
+	fmt.Println(`"GapResumed" :`, gap.Resumed)
+	fmt.Printf("\"GapReason\" : %q\n", gap.Reason)
+}
+
+func (p *fieldsPrinter) Compact(rev int64, r *v3.CompactResponse) {
+	p.hdr((*pb.CompactionResponse)(r).GetHeader())
+	fmt.Println(`"CompactRevision" :`, rev)
+}
+
 func (p *fieldsPrinter) Watch(resp *v3.WatchResponse) {
 	if resp == nil {
 		return
@@ -204,6 +226,8 @@ func (p *fieldsPrinter) EndpointStatus(eps []epStatus) {
 		p.hdr(resp.GetHeader())
 		fmt.Printf("\"Version\" : %q\n", resp.GetVersion())
 		fmt.Printf("\"StorageVersion\" : %q\n", resp.GetStorageVersion())
+		fmt.Printf("\"GoVersion\" : %q\n", resp.GetGoVersion())
+		fmt.Printf("\"GitSha\" : %q\n", resp.GetGitSha())
 		fmt.Println(`"DBSize" :`, resp.GetDbSize())
 		fmt.Println(`"DBSizeInUse" :`, resp.GetDbSizeInUse())
 		fmt.Println(`"DBSizeQuota" :`, resp.GetDbSizeQuota())
@@ -212,10 +236,15 @@ func (p *fieldsPrinter) EndpointStatus(eps []epStatus) {
 		fmt.Println(`"RaftIndex" :`, resp.GetRaftIndex())
 		fmt.Println(`"RaftTerm" :`, resp.GetRaftTerm())
 		fmt.Println(`"RaftAppliedIndex" :`, resp.GetRaftAppliedIndex())
+		fmt.Println(`"CompactRevision" :`, resp.GetCompactRevision())
+		fmt.Println(`"MaxClockSkew" :`, resp.GetMaxClockSkew())
+		fmt.Printf("\"ValueEncoding\" : %q\n", resp.GetValueEncoding())
+		fmt.Println(`"ValueEncodingMigrationPercent" :`, resp.GetValueEncodingMigrationPercent())
 		fmt.Println(`"Errors" :`, resp.GetErrors())
 		fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
 		fmt.Printf("\"DowngradeTargetVersion\" : %q\n", resp.GetDowngradeInfo().GetTargetVersion())
 		fmt.Println(`"DowngradeEnabled" :`, resp.GetDowngradeInfo().GetEnabled())
+		fmt.Println(`"Retried" :`, ep.Retried)
 		fmt.Println()
 	}
 }
@@ -231,6 +260,21 @@ func (p *fieldsPrinter) EndpointHashKV(hs []epHashKV) {
 	}
 }
 
+func (p *fieldsPrinter) EndpointDiskUsage(eps []epStatus) {
+	for _, ep := range eps {
+		resp := (*pb.StatusResponse)(ep.Resp)
+		du := resp.GetDiskUsage()
+		p.hdr(resp.GetHeader())
+		fmt.Printf("\"Endpoint\" : %q\n", ep.Ep)
+		fmt.Println(`"WALFileCount" :`, du.GetWalFileCount())
+		fmt.Println(`"WALSize" :`, du.GetWalSize())
+		fmt.Println(`"OldestWALIndex" :`, du.GetOldestWalIndex())
+		fmt.Println(`"SnapshotFileCount" :`, du.GetSnapshotFileCount())
+		fmt.Println(`"SnapshotSize" :`, du.GetSnapshotSize())
+		fmt.Println()
+	}
+}
+
 func (p *fieldsPrinter) Alarm(r *v3.AlarmResponse) {
 	resp := (*pb.AlarmResponse)(r)
 	p.hdr(resp.GetHeader())
@@ -73,9 +73,22 @@ func newJSONPrinter(isHex bool) printer {
 	}
 }
 
-func (p *jsonPrinter) EndpointHealth(r []epHealth) { printJSON(r) }
-func (p *jsonPrinter) EndpointStatus(r []epStatus) { printJSON(r) }
-func (p *jsonPrinter) EndpointHashKV(r []epHashKV) { printJSON(r) }
+func (p *jsonPrinter) GetSummary(s getSummaryResult) {
+	printJSON(struct {
+		Summary getSummaryResult `json:"summary"`
+	}{s})
+}
+
+func (p *jsonPrinter) WatchGap(gap watchGap) {
+	printJSON(struct {
+		Gap watchGap `json:"gap"`
+	}{gap})
+}
+
+func (p *jsonPrinter) EndpointHealth(r []epHealth)    { printJSON(r) }
+func (p *jsonPrinter) EndpointStatus(r []epStatus)    { printJSON(r) }
+func (p *jsonPrinter) EndpointHashKV(r []epHashKV)    { printJSON(r) }
+func (p *jsonPrinter) EndpointDiskUsage(r []epStatus) { printJSON(r) }
 
 func (p *jsonPrinter) MemberAdd(r *clientv3.MemberAddResponse)                   { p.printJSON(r) }
 func (p *jsonPrinter) MemberRemove(_ uint64, r *clientv3.MemberRemoveResponse)   { p.printJSON(r) }
@@ -87,6 +100,54 @@ func (p *jsonPrinter) Txn(r *clientv3.TxnResponse) {
 	p.printJSON(TxnResponseJSONFromProto((*pb.TxnResponse)(r)))
 }
 
+func (p *jsonPrinter) Compact(rev int64, r *clientv3.CompactResponse) {
+	p.printJSON(struct {
+		Header          any   `json:"header"`
+		CompactRevision int64 `json:"compact_revision"`
+	}{p.header(r.Header), rev})
+}
+
+// The lease responses embed their header, so its fields end up at the top
+// level of the JSON object; they are kept there for compatibility and the
+// header is added as a "header" object like in every other response.
+
+func (p *jsonPrinter) Grant(r *clientv3.LeaseGrantResponse) {
+	p.printJSON(struct {
+		Header any `json:"header"`
+		*clientv3.LeaseGrantResponse
+	}{p.header(r.ResponseHeader), r})
+}
+
+func (p *jsonPrinter) KeepAlive(r *clientv3.LeaseKeepAliveResponse) {
+	p.printJSON(struct {
+		Header any `json:"header"`
+		*clientv3.LeaseKeepAliveResponse
+	}{p.header(r.ResponseHeader), r})
+}
+
+func (p *jsonPrinter) TimeToLive(r *clientv3.LeaseTimeToLiveResponse, _ bool) {
+	p.printJSON(struct {
+		Header any `json:"header"`
+		*clientv3.LeaseTimeToLiveResponse
+	}{p.header(r.ResponseHeader), r})
+}
+
+func (p *jsonPrinter) Leases(r *clientv3.LeaseLeasesResponse) {
+	p.printJSON(struct {
+		Header any `json:"header"`
+		*clientv3.LeaseLeasesResponse
+	}{p.header(r.ResponseHeader), r})
+}
+
+// header returns the response header in the representation matching the
+// printer's --hex setting.
+func (p *jsonPrinter) header(h *pb.ResponseHeader) any {
+	if p.isHex {
+		return (*HexResponseHeader)(h)
+	}
+	return h
+}
+
 func printJSONTo(w io.Writer, v any) {
 	b, err := json.Marshal(v)
 	if err != nil {
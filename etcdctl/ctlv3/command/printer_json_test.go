@@ -373,3 +373,51 @@ func TestMemberList(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseHeaderIncluded(t *testing.T) {
+	tests := []testScenario{
+		{name: "decimal", isHex: false, cases: testCases},
+		{name: "hex", isHex: true, cases: testCases},
+	}
+
+	for _, testGroup := range tests {
+		t.Run(testGroup.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			p := &jsonPrinter{writer: &buffer, isHex: testGroup.isHex}
+
+			for _, tt := range testGroup.cases {
+				header := &pb.ResponseHeader{
+					ClusterId: tt.number,
+					MemberId:  tt.number,
+					Revision:  int64(tt.number),
+					RaftTerm:  tt.number,
+				}
+				commands := map[string]func(){
+					"compact":    func() { p.Compact(5, &clientv3.CompactResponse{Header: header}) },
+					"grant":      func() { p.Grant(&clientv3.LeaseGrantResponse{ResponseHeader: header}) },
+					"keepalive":  func() { p.KeepAlive(&clientv3.LeaseKeepAliveResponse{ResponseHeader: header}) },
+					"timetolive": func() { p.TimeToLive(&clientv3.LeaseTimeToLiveResponse{ResponseHeader: header}, false) },
+					"leases":     func() { p.Leases(&clientv3.LeaseLeasesResponse{ResponseHeader: header}) },
+				}
+				for name, command := range commands {
+					t.Run(fmt.Sprintf("%s/number=%d", name, tt.number), func(t *testing.T) {
+						buffer.Reset()
+						decoder := json.NewDecoder(&buffer)
+						decoder.UseNumber()
+
+						command()
+
+						var got map[string]any
+						err := decoder.Decode(&got)
+						require.NoErrorf(t, err, "failed to decode JSON")
+
+						assertHeader(t, &testGroup, &tt, got)
+						if name == "compact" {
+							assertNumericFieldEqual(t, got, "compact_revision", 5)
+						}
+					})
+				}
+			}
+		})
+	}
+}
@@ -47,6 +47,12 @@ func (p *pbPrinter) Watch(r *v3.WatchResponse) {
 	printPB(wr)
 }
 
+// WatchGap has no protobuf representation; the marker goes to stderr so
+// that stdout keeps carrying only WatchResponse messages.
+func (p *pbPrinter) WatchGap(gap watchGap) {
+	fmt.Fprintf(os.Stderr, "GAP last_revision=%d next_revision=%d resumed=%v reason=%q\n", gap.LastRevision, gap.NextRevision, gap.Resumed, gap.Reason)
+}
+
 func printPB(v any) {
 	var b []byte
 	var err error
@@ -54,7 +60,7 @@ func printPB(v any) {
 	case proto.Message:
 		b, err = proto.Marshal(m)
 	default:
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("marshal unsupported for type %T (%v)", v, v))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("marshal unsupported for type %T (%v)", v, v))
 	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -28,11 +28,29 @@ import (
 const rootRole = "root"
 
 type simplePrinter struct {
-	isHex     bool
-	valueOnly bool
+	isHex      bool
+	valueOnly  bool
+	showHeader bool
+}
+
+// header prints a one-line summary of the response header if --show-header is set.
+func (s *simplePrinter) header(h *pb.ResponseHeader) {
+	if !s.showHeader || h == nil {
+		return
+	}
+	if s.isHex {
+		fmt.Printf("cluster_id=%s member_id=%s revision=%d raft_term=%d\n", types.ID(h.GetClusterId()), types.ID(h.GetMemberId()), h.GetRevision(), h.GetRaftTerm())
+		return
+	}
+	fmt.Printf("cluster_id=%d member_id=%d revision=%d raft_term=%d\n", h.GetClusterId(), h.GetMemberId(), h.GetRevision(), h.GetRaftTerm())
 }
 
 func (s *simplePrinter) Del(resp *v3.DeleteResponse) {
+	s.header(resp.Header)
+	s.del(resp)
+}
+
+func (s *simplePrinter) del(resp *v3.DeleteResponse) {
 	r := (*pb.DeleteRangeResponse)(resp)
 	fmt.Println(r.GetDeleted())
 	for _, kv := range r.GetPrevKvs() {
@@ -41,13 +59,30 @@ func (s *simplePrinter) Del(resp *v3.DeleteResponse) {
 }
 
 func (s *simplePrinter) Get(resp *v3.GetResponse) {
+	s.header(resp.Header)
+	s.get(resp)
+}
+
+func (s *simplePrinter) get(resp *v3.GetResponse) {
 	r := (*pb.RangeResponse)(resp)
 	for _, kv := range r.GetKvs() {
 		printKV(s.isHex, s.valueOnly, kv)
 	}
 }
 
+func (s *simplePrinter) GetSummary(summary getSummaryResult) {
+	hdr, rows := makeGetSummaryTable(summary)
+	for i, field := range hdr {
+		fmt.Printf("%s: %s\n", field, rows[0][i])
+	}
+}
+
 func (s *simplePrinter) Put(r *v3.PutResponse) {
+	s.header(r.Header)
+	s.put(r)
+}
+
+func (s *simplePrinter) put(r *v3.PutResponse) {
 	resp := (*pb.PutResponse)(r)
 	fmt.Println("OK")
 	if resp.GetPrevKv() != nil {
@@ -57,6 +92,7 @@ func (s *simplePrinter) Put(r *v3.PutResponse) {
 
 func (s *simplePrinter) Txn(resp *v3.TxnResponse) {
 	r := (*pb.TxnResponse)(resp)
+	s.header(r.GetHeader())
 	if r.GetSucceeded() {
 		fmt.Println("SUCCESS")
 	} else {
@@ -67,17 +103,22 @@ func (s *simplePrinter) Txn(resp *v3.TxnResponse) {
 		fmt.Println("")
 		switch v := opResp.GetResponse().(type) {
 		case *pb.ResponseOp_ResponseDeleteRange:
-			s.Del((*v3.DeleteResponse)(v.ResponseDeleteRange))
+			s.del((*v3.DeleteResponse)(v.ResponseDeleteRange))
 		case *pb.ResponseOp_ResponsePut:
-			s.Put((*v3.PutResponse)(v.ResponsePut))
+			s.put((*v3.PutResponse)(v.ResponsePut))
 		case *pb.ResponseOp_ResponseRange:
-			s.Get((*v3.GetResponse)(v.ResponseRange))
+			s.get((*v3.GetResponse)(v.ResponseRange))
 		default:
 			fmt.Printf("unexpected response %+v\n", opResp)
 		}
 	}
 }
 
+func (s *simplePrinter) Compact(rev int64, r *v3.CompactResponse) {
+	s.header(r.Header)
+	fmt.Println("compacted revision", rev)
+}
+
 func (s *simplePrinter) Watch(resp *v3.WatchResponse) {
 	for _, event := range resp.Events {
 		fmt.Println(event.GetType())
@@ -88,19 +129,27 @@ func (s *simplePrinter) Watch(resp *v3.WatchResponse) {
 	}
 }
 
+func (s *simplePrinter) WatchGap(gap watchGap) {
+	fmt.Printf("GAP last_revision=%d next_revision=%d resumed=%v reason=%q\n", gap.LastRevision, gap.NextRevision, gap.Resumed, gap.Reason)
+}
+
 func (s *simplePrinter) Grant(resp *v3.LeaseGrantResponse) {
+	s.header(resp.ResponseHeader)
 	fmt.Printf("lease %016x granted with TTL(%ds)\n", resp.ID, resp.TTL)
 }
 
 func (s *simplePrinter) Revoke(id v3.LeaseID, r *v3.LeaseRevokeResponse) {
+	s.header(r.Header)
 	fmt.Printf("lease %016x revoked\n", id)
 }
 
 func (s *simplePrinter) KeepAlive(resp *v3.LeaseKeepAliveResponse) {
+	s.header(resp.ResponseHeader)
 	fmt.Printf("lease %016x keepalived with TTL(%d)\n", resp.ID, resp.TTL)
 }
 
 func (s *simplePrinter) TimeToLive(resp *v3.LeaseTimeToLiveResponse, keys bool) {
+	s.header(resp.ResponseHeader)
 	if resp.GrantedTTL == 0 && resp.TTL == -1 {
 		fmt.Printf("lease %016x already expired\n", resp.ID)
 		return
@@ -118,6 +167,7 @@ func (s *simplePrinter) TimeToLive(resp *v3.LeaseTimeToLiveResponse, keys bool)
 }
 
 func (s *simplePrinter) Leases(resp *v3.LeaseLeasesResponse) {
+	s.header(resp.ResponseHeader)
 	fmt.Printf("found %d leases\n", len(resp.Leases))
 	for _, item := range resp.Leases {
 		fmt.Printf("%016x\n", item.ID)
@@ -183,6 +233,13 @@ func (s *simplePrinter) EndpointHashKV(hashList []epHashKV) {
 	}
 }
 
+func (s *simplePrinter) EndpointDiskUsage(statusList []epStatus) {
+	_, rows := makeEndpointDiskUsageTable(statusList)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
 func (s *simplePrinter) MoveLeader(leader, target uint64, r *v3.MoveLeaderResponse) {
 	fmt.Printf("Leadership transferred from %s to %s\n", types.ID(leader), types.ID(target))
 }
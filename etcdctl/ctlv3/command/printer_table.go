@@ -36,6 +36,17 @@ func (tp *tablePrinter) MemberList(r *v3.MemberListResponse) {
 	table.Render()
 }
 
+func (tp *tablePrinter) GetSummary(s getSummaryResult) {
+	hdr, rows := makeGetSummaryTable(s)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
 func (tp *tablePrinter) EndpointHealth(r []epHealth) {
 	hdr, rows := makeEndpointHealthTable(r)
 	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
@@ -68,3 +79,14 @@ func (tp *tablePrinter) EndpointHashKV(r []epHashKV) {
 	}
 	table.Render()
 }
+
+func (tp *tablePrinter) EndpointDiskUsage(r []epStatus) {
+	hdr, rows := makeEndpointDiskUsageTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
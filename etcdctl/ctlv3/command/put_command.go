@@ -74,19 +74,19 @@ func putCommandFunc(cmd *cobra.Command, args []string) {
 	resp, err := mustClientFromCmd(cmd).Put(ctx, key, value, opts...)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.Put(resp)
 }
 
 func getPutOp(args []string) (string, string, []clientv3.OpOption) {
 	if len(args) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs 1 argument and input from stdin or 2 arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs 1 argument and input from stdin or 2 arguments"))
 	}
 
 	key := args[0]
 	if putIgnoreVal && len(args) > 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs only 1 argument when 'ignore-value' is set"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs only 1 argument when 'ignore-value' is set"))
 	}
 
 	var value string
@@ -94,13 +94,13 @@ func getPutOp(args []string) (string, string, []clientv3.OpOption) {
 	if !putIgnoreVal {
 		value, err = argOrStdin(args, os.Stdin, 1)
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs 1 argument and input from stdin or 2 arguments"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("put command needs 1 argument and input from stdin or 2 arguments"))
 		}
 	}
 
 	id, err := strconv.ParseInt(leaseStr, 16, 64)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err))
 	}
 
 	var opts []clientv3.OpOption
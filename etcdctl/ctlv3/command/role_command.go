@@ -109,12 +109,12 @@ func newRoleRevokePermissionCommand() *cobra.Command {
 // roleAddCommandFunc executes the "role add" command.
 func roleAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role add command requires role name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role add command requires role name as its argument"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.RoleAdd(context.TODO(), args[0])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.RoleAdd(args[0], resp)
@@ -123,12 +123,12 @@ func roleAddCommandFunc(cmd *cobra.Command, args []string) {
 // roleDeleteCommandFunc executes the "role delete" command.
 func roleDeleteCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role delete command requires role name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role delete command requires role name as its argument"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.RoleDelete(context.TODO(), args[0])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.RoleDelete(args[0], resp)
@@ -137,13 +137,13 @@ func roleDeleteCommandFunc(cmd *cobra.Command, args []string) {
 // roleGetCommandFunc executes the "role get" command.
 func roleGetCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role get command requires role name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role get command requires role name as its argument"))
 	}
 
 	name := args[0]
 	resp, err := mustClientFromCmd(cmd).Auth.RoleGet(context.TODO(), name)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.RoleGet(name, resp)
@@ -152,12 +152,12 @@ func roleGetCommandFunc(cmd *cobra.Command, args []string) {
 // roleListCommandFunc executes the "role list" command.
 func roleListCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role list command requires no arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role list command requires no arguments"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.RoleList(context.TODO())
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.RoleList(resp)
@@ -166,18 +166,18 @@ func roleListCommandFunc(cmd *cobra.Command, args []string) {
 // roleGrantPermissionCommandFunc executes the "role grant-permission" command.
 func roleGrantPermissionCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 3 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role grant command requires role name, permission type, and key [endkey] as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role grant command requires role name, permission type, and key [endkey] as its argument"))
 	}
 
 	perm, err := clientv3.StrToPermissionType(args[1])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 
 	key, rangeEnd := permRange(args[2:])
 	resp, err := mustClientFromCmd(cmd).Auth.RoleGrantPermission(context.TODO(), args[0], key, rangeEnd, perm)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.RoleGrantPermission(args[0], resp)
@@ -186,13 +186,13 @@ func roleGrantPermissionCommandFunc(cmd *cobra.Command, args []string) {
 // roleRevokePermissionCommandFunc executes the "role revoke-permission" command.
 func roleRevokePermissionCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role revoke-permission command requires role name and key [endkey] as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("role revoke-permission command requires role name and key [endkey] as its argument"))
 	}
 
 	key, rangeEnd := permRange(args[1:])
 	resp, err := mustClientFromCmd(cmd).Auth.RoleRevokePermission(context.TODO(), args[0], key, rangeEnd)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.RoleRevokePermission(args[0], args[1], rangeEnd, resp)
 }
@@ -202,7 +202,7 @@ func permRange(args []string) (string, string) {
 	var rangeEnd string
 	if len(key) == 0 {
 		if rolePermPrefix && rolePermFromKey {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--from-key and --prefix flags are mutually exclusive"))
+			exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("--from-key and --prefix flags are mutually exclusive"))
 		}
 
 		// Range permission is expressed as adt.BytesAffineInterval,
@@ -218,7 +218,7 @@ func permRange(args []string) (string, string) {
 		var err error
 		rangeEnd, err = rangeEndFromPermFlags(args[0:])
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+			exitWithError(cobrautl.ExitBadArgs, err)
 		}
 	}
 	return key, rangeEnd
@@ -68,7 +68,7 @@ Refer to https://github.com/etcd-io/etcd/blob/main/etcdctl/README.md#txn-options
 // txnCommandFunc executes the "txn" command.
 func txnCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("txn command does not accept argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("txn command does not accept argument"))
 	}
 
 	reader := bufio.NewReader(os.Stdin)
@@ -83,7 +83,7 @@ func txnCommandFunc(cmd *cobra.Command, args []string) {
 
 	resp, err := txn.Commit()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.Txn(resp)
@@ -99,7 +99,7 @@ func readCompares(r *bufio.Reader) (cmps []clientv3.Cmp) {
 	for {
 		line, err := r.ReadString('\n')
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+			exitWithError(cobrautl.ExitInvalidInput, err)
 		}
 
 		// remove space from the line
@@ -110,7 +110,7 @@ func readCompares(r *bufio.Reader) (cmps []clientv3.Cmp) {
 
 		cmp, err := ParseCompare(line)
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+			exitWithError(cobrautl.ExitInvalidInput, err)
 		}
 		cmps = append(cmps, *cmp)
 	}
@@ -122,7 +122,7 @@ func readOps(r *bufio.Reader) (ops []clientv3.Op) {
 	for {
 		line, err := r.ReadString('\n')
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+			exitWithError(cobrautl.ExitInvalidInput, err)
 		}
 
 		// remove space from the line
@@ -133,7 +133,7 @@ func readOps(r *bufio.Reader) (ops []clientv3.Op) {
 
 		op, err := parseRequestUnion(line)
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+			exitWithError(cobrautl.ExitInvalidInput, err)
 		}
 		ops = append(ops, *op)
 	}
@@ -127,7 +127,7 @@ func newUserRevokeRoleCommand() *cobra.Command {
 // userAddCommandFunc executes the "user add" command.
 func userAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user add command requires user name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user add command requires user name as its argument"))
 	}
 
 	var password string
@@ -154,7 +154,7 @@ func userAddCommandFunc(cmd *cobra.Command, args []string) {
 				user = splitted[0]
 				password = splitted[1]
 				if len(user) == 0 {
-					cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("empty user name is not allowed"))
+					exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("empty user name is not allowed"))
 				}
 			}
 		}
@@ -165,7 +165,7 @@ func userAddCommandFunc(cmd *cobra.Command, args []string) {
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserAddWithOptions(context.TODO(), user, password, options)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.UserAdd(user, resp)
@@ -174,12 +174,12 @@ func userAddCommandFunc(cmd *cobra.Command, args []string) {
 // userDeleteCommandFunc executes the "user delete" command.
 func userDeleteCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user delete command requires user name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user delete command requires user name as its argument"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserDelete(context.TODO(), args[0])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	display.UserDelete(args[0], resp)
 }
@@ -187,14 +187,14 @@ func userDeleteCommandFunc(cmd *cobra.Command, args []string) {
 // userGetCommandFunc executes the "user get" command.
 func userGetCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user get command requires user name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user get command requires user name as its argument"))
 	}
 
 	name := args[0]
 	client := mustClientFromCmd(cmd)
 	resp, err := client.Auth.UserGet(context.TODO(), name)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	if userShowDetail {
@@ -203,7 +203,7 @@ func userGetCommandFunc(cmd *cobra.Command, args []string) {
 			fmt.Print("\n")
 			roleResp, err := client.Auth.RoleGet(context.TODO(), role)
 			if err != nil {
-				cobrautl.ExitWithError(cobrautl.ExitError, err)
+				exitWithError(cobrautl.ExitError, err)
 			}
 			display.RoleGet(role, roleResp)
 		}
@@ -215,12 +215,12 @@ func userGetCommandFunc(cmd *cobra.Command, args []string) {
 // userListCommandFunc executes the "user list" command.
 func userListCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user list command requires no arguments"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user list command requires no arguments"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserList(context.TODO())
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.UserList(resp)
@@ -229,7 +229,7 @@ func userListCommandFunc(cmd *cobra.Command, args []string) {
 // userChangePasswordCommandFunc executes the "user passwd" command.
 func userChangePasswordCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user passwd command requires user name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user passwd command requires user name as its argument"))
 	}
 
 	var password string
@@ -242,7 +242,7 @@ func userChangePasswordCommandFunc(cmd *cobra.Command, args []string) {
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserChangePassword(context.TODO(), args[0], password)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.UserChangePassword(resp)
@@ -251,12 +251,12 @@ func userChangePasswordCommandFunc(cmd *cobra.Command, args []string) {
 // userGrantRoleCommandFunc executes the "user grant-role" command.
 func userGrantRoleCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user grant command requires user name and role name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user grant command requires user name and role name as its argument"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserGrantRole(context.TODO(), args[0], args[1])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.UserGrantRole(args[0], args[1], resp)
@@ -265,12 +265,12 @@ func userGrantRoleCommandFunc(cmd *cobra.Command, args []string) {
 // userRevokeRoleCommandFunc executes the "user revoke-role" command.
 func userRevokeRoleCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) != 2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user revoke-role requires user name and role name as its argument"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("user revoke-role requires user name and role name as its argument"))
 	}
 
 	resp, err := mustClientFromCmd(cmd).Auth.UserRevokeRole(context.TODO(), args[0], args[1])
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 
 	display.UserRevokeRole(args[0], args[1], resp)
@@ -280,21 +280,21 @@ func readPasswordInteractive(name string) string {
 	prompt1 := fmt.Sprintf("Password of %s: ", name)
 	password1, err1 := speakeasy.Ask(prompt1)
 	if err1 != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("failed to ask password: %w", err1))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("failed to ask password: %w", err1))
 	}
 
 	if len(password1) == 0 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("empty password"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("empty password"))
 	}
 
 	prompt2 := fmt.Sprintf("Type password of %s again for confirmation: ", name)
 	password2, err2 := speakeasy.Ask(prompt2)
 	if err2 != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("failed to ask password: %w", err2))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("failed to ask password: %w", err2))
 	}
 
 	if password1 != password2 {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("given passwords are different"))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("given passwords are different"))
 	}
 
 	return password1
@@ -70,7 +70,7 @@ func Argify(s string) []string {
 		} else if args[i][0] == '"' {
 			// "double quoted string"
 			if _, err := fmt.Sscanf(args[i], "%q", &args[i]); err != nil {
-				cobrautl.ExitWithError(cobrautl.ExitInvalidInput, err)
+				exitWithError(cobrautl.ExitInvalidInput, err)
 			}
 		}
 	}
@@ -80,7 +80,7 @@ func Argify(s string) []string {
 func commandCtx(cmd *cobra.Command) (context.Context, context.CancelFunc) {
 	timeOut, err := cmd.Flags().GetDuration("command-timeout")
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	return context.WithTimeout(context.Background(), timeOut)
 }
@@ -151,7 +151,7 @@ func compact(c *clientv3.Client, rev int64) {
 	_, err := c.Compact(ctx, rev, clientv3.WithCompactPhysical())
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	fmt.Printf("Compacted with revision %d\n", rev)
 }
@@ -163,7 +163,7 @@ func defrag(c *clientv3.Client, ep string) {
 	_, err := c.Defragment(ctx, ep)
 	cancel()
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitError, err)
+		exitWithError(cobrautl.ExitError, err)
 	}
 	fmt.Printf("Defragmented %q\n", ep)
 }
@@ -174,7 +174,7 @@ func IsSerializable(option string) bool {
 		return true
 	case "l":
 	default:
-		cobrautl.ExitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown consistency flag %q", getConsistency))
+		exitWithError(cobrautl.ExitBadFeature, fmt.Errorf("unknown consistency flag %q", getConsistency))
 	}
 	return false
 }
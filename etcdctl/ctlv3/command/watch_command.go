@@ -22,9 +22,11 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
@@ -42,8 +44,31 @@ var (
 	watchInteractive bool
 	watchPrevKey     bool
 	progressNotify   bool
+	watchResumeOnGap bool
 )
 
+// watchGap describes a point where the watch stream lost continuity, so
+// events between LastRevision and NextRevision may have been missed.
+type watchGap struct {
+	// LastRevision is the last revision known to have been fully delivered.
+	LastRevision int64 `json:"last_revision"`
+	// NextRevision is the revision the watch resumes, or would resume, from.
+	NextRevision int64  `json:"next_revision"`
+	Reason       string `json:"reason"`
+	Resumed      bool   `json:"resumed"`
+}
+
+// watchResult is how a single watch channel came to an end.
+type watchResult struct {
+	// gap is set if the watch ended because continuity was lost.
+	gap *watchGap
+	// reconnect is set if the watch can be recreated from lastRev+1 without
+	// losing continuity, e.g. after the member lost its leader.
+	reconnect bool
+	lastRev   int64
+	err       error
+}
+
 // NewWatchCommand returns the cobra command for "watch".
 func NewWatchCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -55,9 +80,10 @@ func NewWatchCommand() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&watchInteractive, "interactive", "i", false, "Interactive mode")
 	cmd.Flags().BoolVar(&watchPrefix, "prefix", false, "Watch on a prefix if prefix is set")
-	cmd.Flags().Int64Var(&watchRev, "rev", 0, "Revision to start watching")
+	cmd.Flags().Int64Var(&watchRev, "rev", 0, "Revision to start watching, which may be a future revision")
 	cmd.Flags().BoolVar(&watchPrevKey, "prev-kv", false, "get the previous key-value pair before the event happens")
 	cmd.Flags().BoolVar(&progressNotify, "progress-notify", false, "get periodic watch progress notification from server")
+	cmd.Flags().BoolVar(&watchResumeOnGap, "resume-on-gap", false, "print a gap marker and keep watching when continuity is lost (e.g. compaction) instead of exiting")
 
 	return cmd
 }
@@ -66,7 +92,7 @@ func NewWatchCommand() *cobra.Command {
 func watchCommandFunc(cmd *cobra.Command, args []string) {
 	envKey, envRange := os.Getenv("ETCDCTL_WATCH_KEY"), os.Getenv("ETCDCTL_WATCH_RANGE_END")
 	if envKey == "" && envRange != "" {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("ETCDCTL_WATCH_KEY is empty but got ETCDCTL_WATCH_RANGE_END=%q", envRange))
+		exitWithError(cobrautl.ExitBadArgs, fmt.Errorf("ETCDCTL_WATCH_KEY is empty but got ETCDCTL_WATCH_RANGE_END=%q", envRange))
 	}
 
 	if watchInteractive {
@@ -76,20 +102,43 @@ func watchCommandFunc(cmd *cobra.Command, args []string) {
 
 	watchArgs, execArgs, err := parseWatchArgs(os.Args, args, envKey, envRange, false)
 	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		exitWithError(cobrautl.ExitBadArgs, err)
 	}
 
 	c := mustClientFromCmd(cmd)
-	wc, err := getWatchChan(c, watchArgs)
-	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	printWatchFutureRev(cmd, c, watchArgs)
+	var res watchResult
+	for {
+		wc, err := getWatchChan(c, watchArgs)
+		if err != nil {
+			exitWithError(cobrautl.ExitBadArgs, err)
+		}
+
+		res = printWatchCh(c, wc, watchRev, execArgs)
+		if !watchResumeOnGap || (res.gap == nil && !res.reconnect) {
+			break
+		}
+		if res.gap != nil {
+			watchRev = res.gap.NextRevision
+		} else if res.lastRev > 0 {
+			watchRev = res.lastRev + 1
+		}
+		if res.reconnect {
+			// avoid spinning while the member has no leader
+			time.Sleep(time.Second)
+		}
 	}
 
-	printWatchCh(c, wc, execArgs)
-	if err = c.Close(); err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadConnection, err)
+	if err := c.Close(); err != nil {
+		exitWithError(cobrautl.ExitBadConnection, err)
+	}
+	switch {
+	case res.gap != nil:
+		exitWithError(cobrautl.ExitWatchGap, fmt.Errorf("watch lost continuity after revision %d (%s)", res.gap.LastRevision, res.gap.Reason))
+	case res.err != nil && !res.reconnect:
+		exitWithError(cobrautl.ExitError, fmt.Errorf("watch is canceled by the server (%w)", res.err))
 	}
-	cobrautl.ExitWithError(cobrautl.ExitInterrupted, fmt.Errorf("watch is canceled by the server"))
+	exitWithError(cobrautl.ExitInterrupted, fmt.Errorf("watch is canceled by the server"))
 }
 
 func watchInteractiveFunc(cmd *cobra.Command, osArgs []string, envKey, envRange string) {
@@ -100,7 +149,7 @@ func watchInteractiveFunc(cmd *cobra.Command, osArgs []string, envKey, envRange
 	for {
 		l, err := reader.ReadString('\n')
 		if err != nil {
-			cobrautl.ExitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("error reading watch request line: %w", err))
+			exitWithError(cobrautl.ExitInvalidInput, fmt.Errorf("error reading watch request line: %w", err))
 		}
 		l = strings.TrimSuffix(l, "\n")
 
@@ -117,7 +166,7 @@ func watchInteractiveFunc(cmd *cobra.Command, osArgs []string, envKey, envRange
 			}
 			watchArgs, execArgs, perr := parseWatchArgs(osArgs, args, envKey, envRange, true)
 			if perr != nil {
-				cobrautl.ExitWithError(cobrautl.ExitBadArgs, perr)
+				exitWithError(cobrautl.ExitBadArgs, perr)
 			}
 
 			ch, err := getWatchChan(c, watchArgs)
@@ -125,11 +174,12 @@ func watchInteractiveFunc(cmd *cobra.Command, osArgs []string, envKey, envRange
 				fmt.Fprintf(os.Stderr, "Invalid command %s (%v)\n", l, err)
 				continue
 			}
-			go printWatchCh(c, ch, execArgs)
+			printWatchFutureRev(cmd, c, watchArgs)
+			go printWatchCh(c, ch, watchRev, execArgs)
 		case "progress":
 			err := c.RequestProgress(clientv3.WithRequireLeader(context.Background()))
 			if err != nil {
-				cobrautl.ExitWithError(cobrautl.ExitError, err)
+				exitWithError(cobrautl.ExitError, err)
 			}
 		default:
 			fmt.Fprintf(os.Stderr, "Invalid command %s (only support watch)\n", l)
@@ -163,15 +213,63 @@ func getWatchChan(c *clientv3.Client, args []string) (clientv3.WatchChan, error)
 	return c.Watch(clientv3.WithRequireLeader(context.Background()), key, opts...), nil
 }
 
-func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string) {
+// printWatchFutureRev prints a line to stderr if the watch starts from a
+// revision the cluster has not reached yet, so that it does not look hung
+// until then.
+func printWatchFutureRev(cmd *cobra.Command, c *clientv3.Client, args []string) {
+	if watchRev <= 0 || len(args) < 1 {
+		return
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := c.Get(ctx, args[0], clientv3.WithCountOnly())
+	cancel()
+	if err != nil {
+		return
+	}
+	if rev := resp.Header.GetRevision(); watchRev > rev {
+		fmt.Fprintf(os.Stderr, "waiting for revision %d, the current revision is %d\n", watchRev, rev)
+	}
+}
+
+// printWatchCh prints the events received on ch until it is closed and
+// reports how the watch ended. If the watch lost continuity a gap marker
+// is printed as well. rev is the revision the watch was started from.
+func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, rev int64, execArgs []string) watchResult {
+	var res watchResult
+	if rev > 0 {
+		res.lastRev = rev - 1
+	}
 	for resp := range ch {
 		if resp.Canceled {
 			fmt.Fprintf(os.Stderr, "watch was canceled (%v)\n", resp.Err())
+			res.err = resp.Err()
+			switch {
+			case resp.CompactRevision != 0:
+				res.gap = &watchGap{LastRevision: res.lastRev, NextRevision: resp.CompactRevision, Reason: res.err.Error()}
+			case errors.Is(res.err, rpctypes.ErrCompacted):
+				res.gap = &watchGap{LastRevision: res.lastRev, NextRevision: resp.Header.GetRevision() + 1, Reason: res.err.Error()}
+			case errors.Is(res.err, rpctypes.ErrNoLeader):
+				res.reconnect = true
+			}
+			if res.gap != nil {
+				res.gap.Resumed = watchResumeOnGap
+			}
 		}
 		if resp.IsProgressNotify() {
 			fmt.Fprintf(os.Stdout, "progress notify: %d\n", resp.Header.GetRevision())
 		}
+		if resp.Created && rev == 0 {
+			res.lastRev = resp.Header.GetRevision()
+		}
 		display.Watch(&resp)
+		if resp.Canceled && res.gap != nil {
+			display.WatchGap(*res.gap)
+		}
+		if n := len(resp.Events); n > 0 {
+			res.lastRev = resp.Events[n-1].Kv.ModRevision
+		} else if resp.IsProgressNotify() {
+			res.lastRev = resp.Header.GetRevision()
+		}
 
 		if len(execArgs) > 0 {
 			for _, event := range resp.Events {
@@ -181,6 +279,9 @@ func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string)
 				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_EVENT_TYPE=%q", event.GetType()))
 				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_KEY=%q", event.GetKv().GetKey()))
 				cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_VALUE=%q", event.GetKv().GetValue()))
+				if event.PrevKv != nil {
+					cmd.Env = append(cmd.Env, fmt.Sprintf("ETCD_WATCH_PREV_VALUE=%q", event.PrevKv.GetValue()))
+				}
 				cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
 				if err := cmd.Run(); err != nil {
 					fmt.Fprintf(os.Stderr, "command %q error (%v)\n", execArgs, err)
@@ -189,6 +290,7 @@ func printWatchCh(c *clientv3.Client, ch clientv3.WatchChan, execArgs []string)
 			}
 		}
 	}
+	return res
 }
 
 // "commandArgs" is the command arguments after "spf13/cobra" parses
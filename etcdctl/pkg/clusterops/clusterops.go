@@ -0,0 +1,46 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusterops implements the cluster wide operations behind the
+// "etcdctl member" and "etcdctl alarm" commands, so that they can be used
+// without running etcdctl.
+package clusterops
+
+import (
+	"context"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// MemberList lists the members of the cluster using a client created from cfg.
+// Pass clientv3.WithSerializable to serve the list from the local member.
+func MemberList(ctx context.Context, cfg clientv3.Config, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	return cli.MemberList(ctx, opts...)
+}
+
+// AlarmList lists the active alarms of the cluster using a client created from
+// cfg.
+func AlarmList(ctx context.Context, cfg clientv3.Config) (*clientv3.AlarmResponse, error) {
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	return cli.AlarmList(ctx)
+}
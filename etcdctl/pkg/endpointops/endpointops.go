@@ -0,0 +1,243 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package endpointops implements the operations behind the "etcdctl endpoint"
+// commands, so that they can be used without running etcdctl.
+package endpointops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Health is the health of an endpoint as reported by "etcdctl endpoint health".
+type Health struct {
+	Ep     string `json:"endpoint"`
+	Health bool   `json:"health"`
+	Took   string `json:"took"`
+	Error  string `json:"error,omitempty"`
+	// Timings breaks down the time spent by each step of the check.
+	Timings HealthTimings `json:"timings"`
+}
+
+// HealthTimings are the durations of the steps of a health check, empty for
+// the steps that did not run.
+type HealthTimings struct {
+	// Dial is the time taken to connect to the endpoint.
+	Dial string `json:"dial,omitempty"`
+	// Get is the time taken by the linearizable read. Took is the sum of Dial
+	// and Get.
+	Get string `json:"get,omitempty"`
+	// AlarmList is the time taken to list the alarms.
+	AlarmList string `json:"alarm_list,omitempty"`
+	// Watch is the time taken to create the watch of WithWatchCheck.
+	Watch string `json:"watch,omitempty"`
+}
+
+// Status is the status of an endpoint as reported by "etcdctl endpoint status".
+type Status struct {
+	Ep   string                   `json:"Endpoint"`
+	Resp *clientv3.StatusResponse `json:"Status"`
+	// Retried is set if the status was fetched by a retry, after the first
+	// request failed.
+	Retried bool `json:"Retried,omitempty"`
+}
+
+// HashKV is the KV history hash of an endpoint as reported by
+// "etcdctl endpoint hashkv".
+type HashKV struct {
+	Ep   string                   `json:"Endpoint"`
+	Resp *clientv3.HashKVResponse `json:"HashKV"`
+}
+
+type healthOptions struct {
+	watchTimeout time.Duration
+}
+
+// HealthOption configures CheckHealth.
+type HealthOption func(*healthOptions)
+
+// WithWatchCheck makes CheckHealth also open a watch on the endpoint, which is
+// unhealthy unless the watch is created within timeout. Some failures block
+// the watch subsystem of a member while it still serves reads.
+func WithWatchCheck(timeout time.Duration) HealthOption {
+	return func(o *healthOptions) { o.watchTimeout = timeout }
+}
+
+// CheckHealth checks the health of the endpoint ep using a client created from
+// cfg. The endpoint is healthy if it serves a linearizable read and has no
+// active alarm. Failures are reported in the Error field of the returned
+// Health rather than as an error.
+func CheckHealth(ctx context.Context, cfg clientv3.Config, ep string, opts ...HealthOption) Health {
+	var o healthOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg.Endpoints = []string{ep}
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return Health{Ep: ep, Health: false, Error: err.Error()}
+	}
+	defer cli.Close()
+
+	var timings HealthTimings
+	start := time.Now()
+	waitConnected(ctx, cli.ActiveConnection())
+	timings.Dial = time.Since(start).String()
+
+	st := time.Now()
+	// get a random key. As long as we can get the response without an error, the
+	// endpoint is health.
+	_, err = cli.Get(ctx, "health")
+	timings.Get = time.Since(st).String()
+	eh := Health{Ep: ep, Health: false, Took: time.Since(start).String()}
+	// permission denied is OK since proposal goes through consensus to get it
+	if err == nil || errors.Is(err, rpctypes.ErrPermissionDenied) {
+		eh.Health = true
+	} else {
+		eh.Error = err.Error()
+	}
+
+	if eh.Health {
+		st = time.Now()
+		resp, err := cli.AlarmList(ctx)
+		timings.AlarmList = time.Since(st).String()
+		if err == nil && len(resp.Alarms) > 0 {
+			eh.Health = false
+			eh.Error = "Active Alarm(s): "
+			for _, v := range resp.Alarms {
+				switch v.Alarm {
+				case etcdserverpb.AlarmType_NOSPACE:
+					eh.Error = eh.Error + "NOSPACE "
+				case etcdserverpb.AlarmType_CORRUPT:
+					eh.Error = eh.Error + "CORRUPT "
+				default:
+					eh.Error = eh.Error + "UNKNOWN "
+				}
+			}
+		} else if err != nil {
+			eh.Health = false
+			eh.Error = "Unable to fetch the alarm list"
+		}
+	}
+
+	if eh.Health && o.watchTimeout > 0 {
+		st = time.Now()
+		err := checkWatch(ctx, cli, o.watchTimeout)
+		timings.Watch = time.Since(st).String()
+		if err != nil {
+			eh.Health = false
+			eh.Error = err.Error()
+		}
+	}
+	eh.Timings = timings
+	return eh
+}
+
+// waitConnected connects conn and waits for it to be ready, or for ctx to be
+// done, so that the time to connect is not counted in the first request.
+func waitConnected(ctx context.Context, conn *grpc.ClientConn) {
+	conn.Connect()
+	for state := conn.GetState(); state != connectivity.Ready; state = conn.GetState() {
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
+
+// checkWatch opens a watch with cli and waits for its created response. Like
+// for the read, a watch canceled by the server, for instance for lack of
+// permission, still shows that the watch subsystem works.
+func checkWatch(ctx context.Context, cli *clientv3.Client, timeout time.Duration) error {
+	wctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	wch := cli.Watch(clientv3.WithRequireLeader(wctx), "health", clientv3.WithCreatedNotify())
+	select {
+	case _, ok := <-wch:
+		if ok {
+			return nil
+		}
+	case <-wctx.Done():
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("watch was not created: %w", ctx.Err())
+	}
+	return fmt.Errorf("watch was not created within %v", timeout)
+}
+
+// GetStatus returns the status of the endpoint ep using a client created from
+// cfg.
+func GetStatus(ctx context.Context, cfg clientv3.Config, ep string) (Status, error) {
+	cfg.Endpoints = []string{ep}
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return Status{}, err
+	}
+	defer cli.Close()
+
+	resp, err := cli.Status(ctx, ep)
+	if err != nil {
+		return Status{}, err
+	}
+	return Status{Ep: ep, Resp: resp}, nil
+}
+
+// GetHashKV returns the hash of the KV history of the endpoint ep up to
+// revision rev using a client created from cfg. A zero rev hashes up to the
+// latest revision.
+func GetHashKV(ctx context.Context, cfg clientv3.Config, ep string, rev int64) (HashKV, error) {
+	cfg.Endpoints = []string{ep}
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return HashKV{}, err
+	}
+	defer cli.Close()
+
+	resp, err := cli.HashKV(ctx, ep, rev)
+	if err != nil {
+		return HashKV{}, err
+	}
+	return HashKV{Ep: ep, Resp: resp}, nil
+}
+
+// ClusterEndpoints returns the client URLs of all members in the member list
+// of the cluster reachable through cfg.
+func ClusterEndpoints(ctx context.Context, cfg clientv3.Config) ([]string, error) {
+	cli, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+
+	membs, err := cli.MemberList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch endpoints from etcd cluster member list: %w", err)
+	}
+
+	var ret []string
+	for _, m := range membs.Members {
+		ret = append(ret, m.ClientURLs...)
+	}
+	return ret, nil
+}
@@ -15,9 +15,12 @@
 package etcdutl
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"time"
 
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
@@ -46,6 +49,22 @@ func validateFilePath(filePath string) error {
 // FlockTimeout is the duration to wait to obtain a file lock on db file.
 var FlockTimeout time.Duration
 
+// openReadOnlyDB opens the db file at dbPath read-only, failing if it is
+// locked by a running etcd process for longer than FlockTimeout. The given
+// options may be nil.
+func openReadOnlyDB(dbPath string, opts *bolt.Options) (*bolt.DB, error) {
+	var o bolt.Options
+	if opts != nil {
+		o = *opts
+	}
+	o.ReadOnly, o.Timeout = true, FlockTimeout
+	db, err := bolt.Open(dbPath, 0o400, &o)
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("db file %q is locked, possibly by a running etcd process: %w", dbPath, err)
+	}
+	return db, err
+}
+
 func GetLogger() *zap.Logger {
 	config := logutil.DefaultZapLoggerConfig
 	config.Encoding = "console"
@@ -90,6 +109,8 @@ func (sl *SimpleLessor) Grant(id lease.LeaseID, ttl int64) (*lease.Lease, error)
 
 func (sl *SimpleLessor) Revoke(id lease.LeaseID) error { return nil }
 
+func (sl *SimpleLessor) Expire(id lease.LeaseID) error { return nil }
+
 func (sl *SimpleLessor) Checkpoint(id lease.LeaseID, remainingTTL int64) error { return nil }
 
 func (sl *SimpleLessor) Attach(id lease.LeaseID, items []lease.LeaseItem) error { return nil }
@@ -103,6 +124,10 @@ func (sl *SimpleLessor) Demote() {}
 
 func (sl *SimpleLessor) Renew(id lease.LeaseID) (int64, error) { return 10, nil }
 
+func (sl *SimpleLessor) RenewWithEpoch(id lease.LeaseID, epoch uint64) (int64, error) { return 10, nil }
+
+func (sl *SimpleLessor) Transfer(id lease.LeaseID) (*lease.Lease, error) { return nil, nil }
+
 func (sl *SimpleLessor) Lookup(id lease.LeaseID) *lease.Lease {
 	if _, ok := sl.LeaseSet[id]; ok {
 		return &lease.Lease{ID: id}
@@ -114,6 +139,8 @@ func (sl *SimpleLessor) Leases() []*lease.Lease { return nil }
 
 func (sl *SimpleLessor) ExpiredLeasesC() <-chan []*lease.Lease { return nil }
 
+func (sl *SimpleLessor) Watch(id lease.LeaseID) (<-chan lease.Event, func()) { return nil, func() {} }
+
 func (sl *SimpleLessor) Recover(b backend.Backend, rd lease.RangeDeleter) {}
 
 func (sl *SimpleLessor) Stop() {}
@@ -15,36 +15,137 @@
 package etcdutl
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	bolt "go.etcd.io/bbolt"
 
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/datadir"
 )
 
-var defragDataDir string
+var (
+	defragDataDirs []string
+	defragDryRun   bool
+)
 
 // NewDefragCommand returns the cobra command for "Defrag".
 func NewDefragCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "defrag",
+		Use:   "defrag [<data-dir>...] [--data-dir <data-dir>]...",
 		Short: "Defragments the storage of the etcd",
-		Run:   defragCommandFunc,
+		Long: `Defragments the given data directories one after another and reports the
+db size before and after, the bytes reclaimed and the duration for each of
+them. Data directories in use by a running etcd are refused.
+
+With --dry-run the data directories are not changed; the report shows the
+current db size and the space a defragmentation is estimated to reclaim.`,
+		Run: defragCommandFunc,
 	}
-	cmd.Flags().StringVar(&defragDataDir, "data-dir", "", "Required. Defragments a data directory not in use by etcd.")
-	cmd.MarkFlagRequired("data-dir")
+	cmd.Flags().StringArrayVar(&defragDataDirs, "data-dir", nil, "Defragments a data directory not in use by etcd. Can be repeated.")
+	cmd.Flags().BoolVar(&defragDryRun, "dry-run", false, "Only report the current db size and the estimated reclaimable space")
 	cmd.MarkFlagDirname("data-dir")
 	return cmd
 }
 
 func defragCommandFunc(cmd *cobra.Command, args []string) {
-	err := DefragData(defragDataDir)
-	if err != nil {
+	dataDirs := append(append([]string{}, defragDataDirs...), args...)
+	if len(dataDirs) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("at least one data directory must be given"))
+	}
+	printer := initPrinterFromCmd(cmd)
+
+	report := DefragReport{DryRun: defragDryRun}
+	for _, dataDir := range dataDirs {
+		r := defragDataDir(dataDir, defragDryRun)
+		if r.Error != "" {
+			report.Failed++
+		}
+		report.Reclaimed += r.Reclaimed
+		report.Results = append(report.Results, r)
+	}
+	printer.Defrag(report)
+	if report.Failed > 0 {
 		cobrautl.ExitWithError(cobrautl.ExitError,
-			fmt.Errorf("Failed to defragment etcd data[%s] (%w)", defragDataDir, err))
+			fmt.Errorf("Failed to defragment %d of %d etcd data directories", report.Failed, len(dataDirs)))
+	}
+}
+
+// DefragResult is the outcome of defragmenting a data directory.
+type DefragResult struct {
+	DataDir    string `json:"dataDir"`
+	SizeBefore int64  `json:"sizeBefore"`
+	// SizeAfter is the db size after defragmentation, or the estimated
+	// size in a dry run.
+	SizeAfter int64 `json:"sizeAfter"`
+	// Reclaimed is SizeBefore minus SizeAfter.
+	Reclaimed int64         `json:"reclaimed"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// DefragReport is the outcome of defragmenting data directories.
+type DefragReport struct {
+	Results   []DefragResult `json:"results"`
+	Failed    int            `json:"failed"`
+	Reclaimed int64          `json:"reclaimed"`
+	DryRun    bool           `json:"dryRun"`
+}
+
+func defragDataDir(dataDir string, dryRun bool) DefragResult {
+	r := DefragResult{DataDir: dataDir}
+	start := time.Now()
+	err := func() error {
+		if err := validateDataDir(dataDir); err != nil {
+			return err
+		}
+		// measuring the db also checks that it is not locked by a running
+		// etcd, as the backend panics if it cannot be opened
+		size, sizeInUse, err := dbSize(datadir.ToBackendFileName(dataDir))
+		if err != nil {
+			return err
+		}
+		r.SizeBefore = size
+		if dryRun {
+			r.SizeAfter = sizeInUse
+			return nil
+		}
+		if err = DefragData(dataDir); err != nil {
+			return err
+		}
+		r.SizeAfter, _, err = dbSize(datadir.ToBackendFileName(dataDir))
+		return err
+	}()
+	r.Duration = time.Since(start)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+	r.Reclaimed = r.SizeBefore - r.SizeAfter
+	return r
+}
+
+// dbSize returns the size of the db file at dbPath and the part of it that
+// is in use, i.e. neither on the free list nor preallocated.
+func dbSize(dbPath string) (size, sizeInUse int64, err error) {
+	db, err := openReadOnlyDB(dbPath, &bolt.Options{PreLoadFreelist: true})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer db.Close()
+	fi, err := os.Stat(dbPath)
+	if err != nil {
+		return 0, 0, err
 	}
+	err = db.View(func(tx *bolt.Tx) error {
+		sizeInUse = tx.Size() - int64(db.Stats().FreePageN)*int64(db.Info().PageSize)
+		return nil
+	})
+	return fi.Size(), sizeInUse, err
 }
 
 func DefragData(dataDir string) error {
@@ -0,0 +1,78 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+func TestDefragDataDir(t *testing.T) {
+	defer func(timeout time.Duration) { FlockTimeout = timeout }(FlockTimeout)
+	FlockTimeout = 100 * time.Millisecond
+
+	dataDir := t.TempDir()
+	dbPath := datadir.ToBackendFileName(dataDir)
+	require.NoError(t, os.MkdirAll(filepath.Dir(dbPath), 0o700))
+	b := backend.NewDefaultBackend(zap.NewNop(), dbPath)
+	st := mvcc.NewStore(zap.NewNop(), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	val := make([]byte, 4096)
+	for i := 0; i < 1000; i++ {
+		st.Put([]byte("foo"), val, lease.NoLease)
+	}
+	done, err := st.Compact(traceutil.TODO(), st.Rev())
+	require.NoError(t, err)
+	<-done
+	st.Close()
+	b.Close()
+	before, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+
+	r := defragDataDir(dataDir, true)
+	require.Empty(t, r.Error)
+	require.Equal(t, int64(len(before)), r.SizeBefore)
+	require.Less(t, r.SizeAfter, int64(len(before))/10)
+	after, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+
+	lb := backend.NewDefaultBackend(zap.NewNop(), dbPath)
+	r = defragDataDir(dataDir, false)
+	require.Contains(t, r.Error, "is locked")
+	lb.Close()
+
+	r = defragDataDir(dataDir, false)
+	require.Empty(t, r.Error)
+	require.Equal(t, int64(len(before)), r.SizeBefore)
+	require.Positive(t, r.Reclaimed)
+	require.Less(t, r.SizeAfter, int64(len(before))/10)
+	fi, err := os.Stat(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, fi.Size(), r.SizeAfter)
+
+	r = defragDataDir(filepath.Join(dataDir, "missing"), false)
+	require.NotEmpty(t, r.Error)
+}
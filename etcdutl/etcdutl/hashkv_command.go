@@ -15,38 +15,80 @@
 package etcdutl
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/spf13/cobra"
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
-var hashKVRevision int64
+var (
+	hashKVRevision int64
+	hashKVDataDir  string
+	hashKVDBFile   string
+)
 
 // NewHashKVCommand returns the cobra command for "hashkv".
 func NewHashKVCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "hashkv <filename>",
+		Use:   "hashkv [<filename> | --db <filename> | --data-dir <dir>]",
 		Short: "Prints the KV history hash of a given file",
-		Args:  cobra.ExactArgs(1),
-		Run:   hashKVCommandFunc,
+		Long: `Prints the KV history hash of a db file, which is the same hash the
+HashKV RPC and 'etcdctl endpoint hashkv' report for the given revision.
+
+The db file is opened read-only and the command fails if it is locked by a
+running etcd process, so it can be used on a member that does not start.`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  hashKVCommandFunc,
 	}
 	cmd.Flags().Int64Var(&hashKVRevision, "rev", 0, "maximum revision to hash (default: latest revision)")
+	cmd.Flags().StringVar(&hashKVDataDir, "data-dir", "", "Path to the etcd data directory to hash the db file of")
+	cmd.Flags().StringVar(&hashKVDBFile, "db", "", "Path to the db file to hash")
+	cmd.MarkFlagsMutuallyExclusive("data-dir", "db")
+	cmd.MarkFlagDirname("data-dir")
+	cmd.MarkFlagFilename("db")
 	return cmd
 }
 
 func hashKVCommandFunc(cmd *cobra.Command, args []string) {
 	printer := initPrinterFromCmd(cmd)
 
-	ds, err := calculateHashKV(args[0], hashKVRevision)
+	dbPath, err := hashKVDBPath(args)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	ds, err := calculateHashKV(dbPath, hashKVRevision)
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
 	printer.DBHashKV(ds)
 }
 
+func hashKVDBPath(args []string) (string, error) {
+	var paths []string
+	if len(args) == 1 {
+		paths = append(paths, args[0])
+	}
+	if hashKVDBFile != "" {
+		paths = append(paths, hashKVDBFile)
+	}
+	if hashKVDataDir != "" {
+		paths = append(paths, datadir.ToBackendFileName(hashKVDataDir))
+	}
+	if len(paths) != 1 {
+		return "", errors.New("exactly one of <filename>, --db or --data-dir must be given")
+	}
+	return paths[0], nil
+}
+
 type HashKV struct {
 	Hash            uint32 `json:"hash"`
 	HashRevision    int64  `json:"hashRevision"`
@@ -57,7 +99,19 @@ func calculateHashKV(dbPath string, rev int64) (HashKV, error) {
 	if err := validateFilePath(dbPath); err != nil {
 		return HashKV{}, err
 	}
-	b := backend.NewDefaultBackend(zap.NewNop(), dbPath, backend.WithTimeout(FlockTimeout))
+	// Opening a store writes to the backend, so hash a copy to leave the
+	// original untouched.
+	dir, err := os.MkdirTemp("", "etcdutl-hashkv")
+	if err != nil {
+		return HashKV{}, err
+	}
+	defer os.RemoveAll(dir)
+	copyPath := filepath.Join(dir, "db")
+	if err = copyDB(dbPath, copyPath); err != nil {
+		return HashKV{}, err
+	}
+
+	b := backend.NewDefaultBackend(zap.NewNop(), copyPath)
 	defer b.Close()
 	// Since `etcdutl hashkv` only hashes the keyspace and ignores leases, we use a simple lessor to simplify the implementation.
 	st := mvcc.NewStore(zap.NewNop(), b, &SimpleLessor{}, mvcc.StoreConfig{})
@@ -65,6 +119,13 @@ func calculateHashKV(dbPath string, rev int64) (HashKV, error) {
 	hst := mvcc.NewHashStorage(zap.NewNop(), st)
 
 	h, _, err := hst.HashByRev(rev)
+	if errors.Is(err, mvcc.ErrCompacted) {
+		tx := b.ReadTx()
+		tx.RLock()
+		compactRev, _ := mvcc.UnsafeReadScheduledCompact(tx)
+		tx.RUnlock()
+		return HashKV{}, fmt.Errorf("revision %d is below the compact revision %d of %q: %w", rev, compactRev, dbPath, err)
+	}
 	if err != nil {
 		return HashKV{}, err
 	}
@@ -74,3 +135,16 @@ func calculateHashKV(dbPath string, rev int64) (HashKV, error) {
 		CompactRevision: h.CompactRevision,
 	}, nil
 }
+
+// copyDB copies the db file at src to dst through a read-only transaction,
+// failing if the file is locked by a running etcd process.
+func copyDB(src, dst string) error {
+	db, err := openReadOnlyDB(src, nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(dst, 0o600)
+	})
+}
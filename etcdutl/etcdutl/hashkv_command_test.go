@@ -15,18 +15,27 @@
 package etcdutl
 
 import (
+	"bytes"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	bolt "go.etcd.io/bbolt"
 	"go.uber.org/zap"
 	"gotest.tools/v3/assert"
 
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
 func TestCalculateHashKV(t *testing.T) {
+	defer func(timeout time.Duration) { FlockTimeout = timeout }(FlockTimeout)
+	FlockTimeout = 100 * time.Millisecond
+
 	type testCase struct {
 		name            string
 		setupFunc       func(t *testing.T) (dbPath string, cleanup func())
@@ -110,6 +119,41 @@ func TestCalculateHashKV(t *testing.T) {
 			expectError:   true,
 			errorContains: "required revision is a future revision",
 		},
+		{
+			name: "compacted revision",
+			setupFunc: func(t *testing.T) (string, func()) {
+				dbPath := filepath.Join(t.TempDir(), "test_compacted.db")
+
+				b := backend.NewDefaultBackend(zap.NewNop(), dbPath)
+				st := mvcc.NewStore(zap.NewNop(), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+				for i := 0; i < 3; i++ {
+					st.Put([]byte("key"), []byte("value"), lease.NoLease)
+				}
+				done, err := st.Compact(traceutil.TODO(), 3)
+				assert.NilError(t, err)
+				<-done
+				st.Close()
+				b.Close()
+
+				return dbPath, func() {}
+			},
+			revision:      2,
+			expectError:   true,
+			errorContains: "revision 2 is below the compact revision 3",
+		},
+		{
+			name: "locked database",
+			setupFunc: func(t *testing.T) (string, func()) {
+				dbPath := filepath.Join(t.TempDir(), "test_locked.db")
+
+				db, err := bolt.Open(dbPath, 0o600, nil)
+				assert.NilError(t, err)
+
+				return dbPath, func() { db.Close() }
+			},
+			expectError:   true,
+			errorContains: "locked, possibly by a running etcd process",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -122,9 +166,13 @@ func TestCalculateHashKV(t *testing.T) {
 
 			dbPath, cleanup := tc.setupFunc(t)
 			defer cleanup()
+			before, _ := os.ReadFile(dbPath)
 
 			result, err := calculateHashKV(dbPath, tc.revision)
 
+			after, _ := os.ReadFile(dbPath)
+			assert.Assert(t, bytes.Equal(before, after), "db file was modified")
+
 			if tc.expectError {
 				assert.Assert(t, err != nil)
 				if tc.errorContains != "" {
@@ -143,3 +191,22 @@ func TestCalculateHashKV(t *testing.T) {
 		})
 	}
 }
+
+func TestHashKVDBPath(t *testing.T) {
+	defer func() { hashKVDataDir, hashKVDBFile = "", "" }()
+
+	_, err := hashKVDBPath(nil)
+	assert.ErrorContains(t, err, "exactly one of")
+
+	p, err := hashKVDBPath([]string{"file.db"})
+	assert.NilError(t, err)
+	assert.Equal(t, "file.db", p)
+
+	hashKVDataDir = "data"
+	p, err = hashKVDBPath(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, datadir.ToBackendFileName("data"), p)
+
+	_, err = hashKVDBPath([]string{"file.db"})
+	assert.ErrorContains(t, err, "exactly one of")
+}
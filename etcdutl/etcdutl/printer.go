@@ -17,6 +17,8 @@ package etcdutl
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
@@ -30,6 +32,16 @@ var OutputFormat string
 type printer interface {
 	DBStatus(snapshot.Status)
 	DBHashKV(HashKV)
+	RestoreBundle(RestoreBundleResult)
+	DBLeases(SnapshotLeases)
+	DBScanEntry(ScanEntry)
+	Defrag(DefragReport)
+}
+
+// SnapshotLeases is the result of "snapshot leases".
+type SnapshotLeases struct {
+	Leases   []snapshot.Lease `json:"leases"`
+	WithKeys bool             `json:"-"`
 }
 
 func NewPrinter(printerType string) printer {
@@ -65,6 +77,11 @@ func newPrinterUnsupported(n string) printer {
 func (p *printerUnsupported) DBStatus(snapshot.Status) { p.p(nil) }
 func (p *printerUnsupported) DBHashKV(HashKV)          { p.p(nil) }
 
+func (p *printerUnsupported) RestoreBundle(RestoreBundleResult) { p.p(nil) }
+func (p *printerUnsupported) DBLeases(SnapshotLeases)           { p.p(nil) }
+func (p *printerUnsupported) DBScanEntry(ScanEntry)             { p.p(nil) }
+func (p *printerUnsupported) Defrag(DefragReport)               { p.p(nil) }
+
 func makeDBStatusTable(ds snapshot.Status) (hdr []string, rows [][]string) {
 	hdr = []string{"hash", "revision", "total keys", "total size", "version"}
 	rows = append(rows, []string{
@@ -87,6 +104,72 @@ func makeDBHashKVTable(ds HashKV) (hdr []string, rows [][]string) {
 	return hdr, rows
 }
 
+func makeRestoreBundleTable(r RestoreBundleResult) (hdr []string, rows [][]string) {
+	hashCheck := "verified"
+	if !r.HashChecked {
+		hashCheck = "skipped"
+	}
+	hdr = []string{"name", "member id", "cluster id", "peer urls", "data dir", "hash check"}
+	for _, m := range r.Members {
+		rows = append(rows, []string{
+			m.Name,
+			m.ID,
+			r.ClusterID,
+			strings.Join(m.PeerURLs, ","),
+			m.DataDir,
+			hashCheck,
+		})
+	}
+	return hdr, rows
+}
+
+func makeDBLeasesTable(r SnapshotLeases) (hdr []string, rows [][]string) {
+	hdr = []string{"id", "ttl", "remaining ttl", "keys"}
+	if r.WithKeys {
+		hdr = append(hdr, "attached keys")
+	}
+	for _, l := range r.Leases {
+		row := []string{
+			fmt.Sprintf("%016x", l.ID),
+			fmt.Sprint(l.TTL),
+			fmt.Sprint(l.RemainingTTL),
+			fmt.Sprint(l.KeyCount),
+		}
+		if r.WithKeys {
+			row = append(row, strings.Join(l.Keys, ","))
+		}
+		rows = append(rows, row)
+	}
+	return hdr, rows
+}
+
+func makeDefragTable(r DefragReport) (hdr []string, rows [][]string) {
+	hdr = []string{"data dir", "size before", "size after", "reclaimed", "duration", "error"}
+	if r.DryRun {
+		hdr = []string{"data dir", "size", "size in use", "reclaimable", "duration", "error"}
+	}
+	for _, dr := range r.Results {
+		rows = append(rows, []string{
+			dr.DataDir,
+			humanize.Bytes(uint64(dr.SizeBefore)),
+			humanize.Bytes(uint64(dr.SizeAfter)),
+			humanize.Bytes(uint64(dr.Reclaimed)),
+			dr.Duration.Round(time.Millisecond).String(),
+			dr.Error,
+		})
+	}
+	return hdr, rows
+}
+
+func makeDefragSummary(r DefragReport) string {
+	verb := "reclaimed"
+	if r.DryRun {
+		verb = "reclaimable"
+	}
+	return fmt.Sprintf("%d of %d data directories succeeded, %s %s",
+		len(r.Results)-r.Failed, len(r.Results), humanize.Bytes(uint64(r.Reclaimed)), verb)
+}
+
 func initPrinterFromCmd(cmd *cobra.Command) (p printer) {
 	outputType, err := cmd.Flags().GetString("write-out")
 	if err != nil {
@@ -35,3 +35,22 @@ func (p *fieldsPrinter) DBHashKV(r HashKV) {
 	fmt.Println(`"Hash revision" :`, r.HashRevision)
 	fmt.Println(`"Compact revision" :`, r.CompactRevision)
 }
+
+func (p *fieldsPrinter) RestoreBundle(r RestoreBundleResult) {
+	fmt.Println(`"ClusterID" :`, r.ClusterID)
+	fmt.Printf("\"InitialCluster\" : %q\n", r.InitialCluster)
+	fmt.Printf("\"InitialClusterToken\" : %q\n", r.InitialClusterToken)
+	fmt.Println(`"HashChecked" :`, r.HashChecked)
+	for _, m := range r.Members {
+		fmt.Println(`"ID" :`, m.ID)
+		fmt.Printf("\"Name\" : %q\n", m.Name)
+		fmt.Print(`"PeerURLs" :`)
+		for _, u := range m.PeerURLs {
+			fmt.Printf(" %q", u)
+		}
+		fmt.Println()
+		fmt.Printf("\"DataDir\" : %q\n", m.DataDir)
+		fmt.Printf("\"WALDir\" : %q\n", m.WALDir)
+		fmt.Println()
+	}
+}
@@ -35,6 +35,10 @@ func newJSONPrinter() printer {
 func (p *jsonPrinter) DBStatus(r snapshot.Status) { printJSON(r) }
 func (p *jsonPrinter) DBHashKV(r HashKV)          { printJSON(r) }
 
+func (p *jsonPrinter) RestoreBundle(r RestoreBundleResult) { printJSON(r) }
+func (p *jsonPrinter) DBLeases(r SnapshotLeases)           { printJSON(r) }
+func (p *jsonPrinter) DBScanEntry(r ScanEntry)             { printJSON(r) }
+
 // !!! Share ??
 func printJSON(v any) {
 	b, err := json.Marshal(v)
@@ -36,3 +36,41 @@ func (s *simplePrinter) DBHashKV(ds HashKV) {
 		fmt.Println(strings.Join(row, ", "))
 	}
 }
+
+func (s *simplePrinter) RestoreBundle(r RestoreBundleResult) {
+	_, rows := makeRestoreBundleTable(r)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) DBLeases(r SnapshotLeases) {
+	_, rows := makeDBLeasesTable(r)
+	for _, row := range rows {
+		fmt.Println(strings.Join(row, ", "))
+	}
+}
+
+func (s *simplePrinter) DBScanEntry(e ScanEntry) {
+	row := []string{
+		e.Key,
+		fmt.Sprint(e.CreateRevision),
+		fmt.Sprint(e.ModRevision),
+		fmt.Sprint(e.Version),
+	}
+	if e.Value != "" {
+		row = append(row, e.Value)
+	}
+	fmt.Println(strings.Join(row, ", "))
+}
+
+func (s *simplePrinter) Defrag(r DefragReport) {
+	_, rows := makeDefragTable(r)
+	for _, row := range rows {
+		if row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		fmt.Println(strings.Join(row, ", "))
+	}
+	fmt.Println(makeDefragSummary(r))
+}
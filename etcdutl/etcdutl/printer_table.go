@@ -15,6 +15,7 @@
 package etcdutl
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -46,3 +47,37 @@ func (tp *tablePrinter) DBHashKV(r HashKV) {
 	}
 	table.Render()
 }
+
+func (tp *tablePrinter) RestoreBundle(r RestoreBundleResult) {
+	hdr, rows := makeRestoreBundleTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) DBLeases(r SnapshotLeases) {
+	hdr, rows := makeDBLeasesTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func (tp *tablePrinter) Defrag(r DefragReport) {
+	hdr, rows := makeDefragTable(r)
+	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
+	table.Header(hdr)
+	for _, row := range rows {
+		table.Append(row)
+	}
+	table.Render()
+	fmt.Println(makeDefragSummary(r))
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	bolt "go.etcd.io/bbolt"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	scanDataDir           string
+	scanPrefix            string
+	scanRevision          int64
+	scanKeysOnly          bool
+	scanLimit             int64
+	scanIncludeTombstones bool
+)
+
+// NewScanCommand returns the cobra command for "scan".
+func NewScanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan --data-dir <dir>",
+		Short: "Prints the key revisions stored in the db file of a data directory",
+		Long: `Prints the key revisions stored in the key bucket of the db file of a data
+directory in revision order, which is the history that has not been compacted
+yet. Each revision is printed with its create revision, mod revision and
+version. Keys and values that are not valid UTF-8 are base64 encoded.
+
+The db file is opened read-only and the command fails if it is locked by a
+running etcd process. With --write-out=json every revision is printed as one
+JSON object per line.`,
+		Args: cobra.NoArgs,
+		Run:  scanCommandFunc,
+	}
+	cmd.Flags().StringVar(&scanDataDir, "data-dir", "", "Path to the etcd data directory to scan the db file of")
+	cmd.Flags().StringVar(&scanPrefix, "prefix", "", "Only print keys with the given prefix")
+	cmd.Flags().Int64Var(&scanRevision, "rev", 0, "Only print revisions up to the given revision (default: latest revision)")
+	cmd.Flags().BoolVar(&scanKeysOnly, "keys-only", false, "Do not print values")
+	cmd.Flags().Int64Var(&scanLimit, "limit", 0, "Maximum number of revisions to print (default: no limit)")
+	cmd.Flags().BoolVar(&scanIncludeTombstones, "include-tombstones", false, "Print the tombstones of deleted keys")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagDirname("data-dir")
+	return cmd
+}
+
+func scanCommandFunc(cmd *cobra.Command, _ []string) {
+	if scanRevision < 0 || scanLimit < 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--rev and --limit must not be negative"))
+	}
+	printer := initPrinterFromCmd(cmd)
+
+	opts := scanOptions{
+		prefix:            []byte(scanPrefix),
+		rev:               scanRevision,
+		keysOnly:          scanKeysOnly,
+		limit:             scanLimit,
+		includeTombstones: scanIncludeTombstones,
+	}
+	err := scanDB(datadir.ToBackendFileName(scanDataDir), opts, func(e ScanEntry) error {
+		printer.DBScanEntry(e)
+		return nil
+	})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+}
+
+// ScanEntry is a key revision stored in the key bucket of a db file.
+type ScanEntry struct {
+	Key            string `json:"key"`
+	Value          string `json:"value,omitempty"`
+	CreateRevision int64  `json:"createRevision"`
+	ModRevision    int64  `json:"modRevision"`
+	Version        int64  `json:"version"`
+	Lease          int64  `json:"lease,omitempty"`
+	Tombstone      bool   `json:"tombstone,omitempty"`
+	// Base64 is true if the key and the value are base64 encoded, because
+	// either of them is not valid UTF-8.
+	Base64 bool `json:"base64,omitempty"`
+}
+
+type scanOptions struct {
+	prefix            []byte
+	rev               int64
+	keysOnly          bool
+	limit             int64
+	includeTombstones bool
+}
+
+var errScanLimit = errors.New("scan limit reached")
+
+// scanDB walks the key bucket of the db file at dbPath in a read-only
+// transaction and calls fn for every key revision matching opts.
+func scanDB(dbPath string, opts scanOptions, fn func(ScanEntry) error) error {
+	if err := validateFilePath(dbPath); err != nil {
+		return err
+	}
+	db, err := openReadOnlyDB(dbPath, nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var n int64
+	err = db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(schema.Key.Name())
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			rev, err := bytesToRev(k)
+			if err != nil {
+				return fmt.Errorf("cannot parse revision key: %q err: %w", k, err)
+			}
+			if opts.rev > 0 && rev.Main > opts.rev {
+				return nil
+			}
+			tombstone := mvcc.IsTombstone(k)
+			if tombstone && !opts.includeTombstones {
+				continue
+			}
+			var kv mvccpb.KeyValue
+			if err := mvcc.UnmarshalKeyValue(v, &kv); err != nil {
+				return fmt.Errorf("cannot unmarshal value, key: %q err: %w", k, err)
+			}
+			if !bytes.HasPrefix(kv.Key, opts.prefix) {
+				continue
+			}
+			if tombstone {
+				// tombstones only store the key, the deletion revision is in the bucket key
+				kv.ModRevision = rev.Main
+			}
+			if opts.keysOnly {
+				kv.Value = nil
+			}
+			if err := fn(newScanEntry(&kv, tombstone)); err != nil {
+				return err
+			}
+			if n++; opts.limit > 0 && n >= opts.limit {
+				return errScanLimit
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errScanLimit) {
+		return nil
+	}
+	return err
+}
+
+func newScanEntry(kv *mvccpb.KeyValue, tombstone bool) ScanEntry {
+	e := ScanEntry{
+		Key:            string(kv.Key),
+		Value:          string(kv.Value),
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Lease:          kv.Lease,
+		Tombstone:      tombstone,
+	}
+	if !utf8.Valid(kv.Key) || !utf8.Valid(kv.Value) {
+		e.Key = base64.StdEncoding.EncodeToString(kv.Key)
+		e.Value = base64.StdEncoding.EncodeToString(kv.Value)
+		e.Base64 = true
+	}
+	return e
+}
+
+func bytesToRev(b []byte) (rev mvcc.Revision, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s", r)
+		}
+	}()
+	return mvcc.BytesToRev(b), err
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+func TestScanDB(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	b := backend.NewDefaultBackend(zap.NewNop(), dbPath)
+	st := mvcc.NewStore(zap.NewNop(), b, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	st.Put([]byte("foo/a"), []byte("1"), lease.NoLease)
+	st.Put([]byte("bar"), []byte{0xff}, lease.NoLease)
+	st.Put([]byte("foo/a"), []byte("2"), lease.NoLease)
+	st.DeleteRange([]byte("foo/a"), nil)
+	st.Put([]byte("foo/b"), []byte("1"), lease.NoLease)
+	st.Close()
+	b.Close()
+	before, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+
+	scan := func(opts scanOptions) []ScanEntry {
+		var es []ScanEntry
+		require.NoError(t, scanDB(dbPath, opts, func(e ScanEntry) error {
+			es = append(es, e)
+			return nil
+		}))
+		return es
+	}
+
+	require.Equal(t, []ScanEntry{
+		{Key: "foo/a", Value: "1", CreateRevision: 2, ModRevision: 2, Version: 1},
+		{Key: "YmFy", Value: "/w==", CreateRevision: 3, ModRevision: 3, Version: 1, Base64: true},
+		{Key: "foo/a", Value: "2", CreateRevision: 2, ModRevision: 4, Version: 2},
+		{Key: "foo/b", Value: "1", CreateRevision: 6, ModRevision: 6, Version: 1},
+	}, scan(scanOptions{}))
+
+	require.Equal(t, []ScanEntry{
+		{Key: "foo/a", CreateRevision: 2, ModRevision: 2, Version: 1},
+		{Key: "foo/a", CreateRevision: 2, ModRevision: 4, Version: 2},
+		{Key: "foo/a", ModRevision: 5, Tombstone: true},
+	}, scan(scanOptions{prefix: []byte("foo/"), rev: 5, keysOnly: true, includeTombstones: true}))
+
+	require.Len(t, scan(scanOptions{limit: 2}), 2)
+
+	after, err := os.ReadFile(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+}
+
+func TestScanDBLocked(t *testing.T) {
+	defer func(timeout time.Duration) { FlockTimeout = timeout }(FlockTimeout)
+	FlockTimeout = 100 * time.Millisecond
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	b := backend.NewDefaultBackend(zap.NewNop(), dbPath)
+	defer b.Close()
+
+	err := scanDB(dbPath, scanOptions{}, func(ScanEntry) error { return nil })
+	require.ErrorContains(t, err, "is locked")
+}
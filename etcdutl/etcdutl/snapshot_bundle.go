@@ -0,0 +1,229 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+)
+
+// restoreBundle describes all members of the cluster a snapshot is restored
+// into, so that every data directory can be produced in one invocation.
+type restoreBundle struct {
+	// InitialClusterToken overrides --initial-cluster-token if set.
+	InitialClusterToken string                `json:"initial-cluster-token"`
+	Members             []restoreBundleMember `json:"members"`
+}
+
+type restoreBundleMember struct {
+	Name     string   `json:"name"`
+	PeerURLs []string `json:"peer-urls"`
+	DataDir  string   `json:"data-dir"`
+	// WALDir is optional and defaults to the WAL directory inside DataDir.
+	WALDir string `json:"wal-dir"`
+}
+
+// RestoreBundleResult is the outcome of restoring a snapshot from a bundle.
+type RestoreBundleResult struct {
+	ClusterID           string                      `json:"clusterID"`
+	InitialCluster      string                      `json:"initialCluster"`
+	InitialClusterToken string                      `json:"initialClusterToken"`
+	HashChecked         bool                        `json:"hashChecked"`
+	Members             []RestoreBundleMemberResult `json:"members"`
+}
+
+type RestoreBundleMemberResult struct {
+	Name     string   `json:"name"`
+	ID       string   `json:"ID"`
+	PeerURLs []string `json:"peerURLs"`
+	DataDir  string   `json:"dataDir"`
+	WALDir   string   `json:"walDir"`
+}
+
+func readRestoreBundle(path string) (*restoreBundle, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &restoreBundle{}
+	if err = yaml.UnmarshalStrict(b, bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle %q: %w", path, err)
+	}
+	return bundle, nil
+}
+
+// validate checks that the members can form a cluster and that restoring
+// them will not clobber each other or any existing data.
+func (b *restoreBundle) validate() error {
+	if len(b.Members) == 0 {
+		return errors.New("bundle has no members")
+	}
+	names := make(map[string]struct{})
+	peerURLs := make(map[string]string)
+	dirs := make(map[string]string)
+	for i, m := range b.Members {
+		if m.Name == "" {
+			return fmt.Errorf("member #%d has no name", i)
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("duplicate member name %q", m.Name)
+		}
+		names[m.Name] = struct{}{}
+
+		if len(m.PeerURLs) == 0 {
+			return fmt.Errorf("member %q has no peer URLs", m.Name)
+		}
+		urls, err := types.NewURLs(m.PeerURLs)
+		if err != nil {
+			return fmt.Errorf("member %q: %w", m.Name, err)
+		}
+		for _, u := range urls {
+			if err = validatePeerHost(u); err != nil {
+				return fmt.Errorf("member %q: %w", m.Name, err)
+			}
+			if other, ok := peerURLs[u.String()]; ok {
+				return fmt.Errorf("peer URL %q is used by both %q and %q", u.String(), other, m.Name)
+			}
+			peerURLs[u.String()] = m.Name
+		}
+
+		if m.DataDir == "" {
+			return fmt.Errorf("member %q has no data-dir", m.Name)
+		}
+		for _, dir := range []string{m.DataDir, m.WALDir} {
+			if dir == "" {
+				continue
+			}
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				return fmt.Errorf("member %q: %w", m.Name, err)
+			}
+			if other, ok := dirs[abs]; ok {
+				return fmt.Errorf("directory %q is used by both %q and %q", dir, other, m.Name)
+			}
+			dirs[abs] = m.Name
+		}
+		if fileutil.Exist(m.DataDir) && !fileutil.DirEmpty(m.DataDir) {
+			return fmt.Errorf("member %q: data-dir %q not empty or could not be read", m.Name, m.DataDir)
+		}
+		if m.WALDir != "" && fileutil.Exist(m.WALDir) {
+			return fmt.Errorf("member %q: wal-dir %q exists", m.Name, m.WALDir)
+		}
+	}
+	return nil
+}
+
+// validatePeerHost rejects peer URLs that other members could never dial,
+// without resolving them since the target hosts may not exist yet.
+func validatePeerHost(u url.URL) error {
+	if u.Scheme == "unix" || u.Scheme == "unixs" {
+		return nil
+	}
+	host := u.Hostname()
+	if host == "" {
+		return fmt.Errorf("peer URL %q has no host", u.String())
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsUnspecified() {
+			return fmt.Errorf("peer URL %q uses unspecified address", u.String())
+		}
+		return nil
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("peer URL %q has invalid host name", u.String())
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+				return fmt.Errorf("peer URL %q has invalid host name", u.String())
+			}
+		}
+	}
+	return nil
+}
+
+func (b *restoreBundle) initialCluster() string {
+	var ss []string
+	for _, m := range b.Members {
+		for _, u := range m.PeerURLs {
+			ss = append(ss, fmt.Sprintf("%s=%s", m.Name, u))
+		}
+	}
+	return strings.Join(ss, ",")
+}
+
+// restoreFromBundle restores the snapshot into the data directory of every
+// member in the bundle, using the same initial cluster and token for all of
+// them so that they agree on the cluster and member IDs.
+func restoreFromBundle(lg *zap.Logger, bundle *restoreBundle, cfg snapshot.RestoreConfig) (RestoreBundleResult, error) {
+	if err := bundle.validate(); err != nil {
+		return RestoreBundleResult{}, err
+	}
+	if bundle.InitialClusterToken != "" {
+		cfg.InitialClusterToken = bundle.InitialClusterToken
+	}
+	cfg.InitialCluster = bundle.initialCluster()
+
+	ics, err := types.NewURLsMap(cfg.InitialCluster)
+	if err != nil {
+		return RestoreBundleResult{}, err
+	}
+	cl, err := membership.NewClusterFromURLsMap(zap.NewNop(), cfg.InitialClusterToken, ics)
+	if err != nil {
+		return RestoreBundleResult{}, err
+	}
+
+	result := RestoreBundleResult{
+		ClusterID:           cl.ID().String(),
+		InitialCluster:      cfg.InitialCluster,
+		InitialClusterToken: cfg.InitialClusterToken,
+		HashChecked:         !cfg.SkipHashCheck,
+	}
+	for _, m := range bundle.Members {
+		mcfg := cfg
+		mcfg.Name = m.Name
+		mcfg.PeerURLs = m.PeerURLs
+		mcfg.OutputDataDir = m.DataDir
+		mcfg.OutputWALDir = m.WALDir
+		if mcfg.OutputWALDir == "" {
+			mcfg.OutputWALDir = datadir.ToWALDir(m.DataDir)
+		}
+		if err = snapshot.NewV3(lg).Restore(mcfg); err != nil {
+			return result, fmt.Errorf("failed to restore member %q: %w", m.Name, err)
+		}
+		result.Members = append(result.Members, RestoreBundleMemberResult{
+			Name:     m.Name,
+			ID:       cl.MemberByName(m.Name).ID.String(),
+			PeerURLs: m.PeerURLs,
+			DataDir:  mcfg.OutputDataDir,
+			WALDir:   mcfg.OutputWALDir,
+		})
+	}
+	return result, nil
+}
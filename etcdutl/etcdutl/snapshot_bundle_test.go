@@ -0,0 +1,148 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRestoreBundle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bundle.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+initial-cluster-token: dr
+members:
+- name: m1
+  peer-urls: ["http://10.0.0.1:2380"]
+  data-dir: /tmp/m1
+- name: m2
+  peer-urls: ["http://10.0.0.2:2380", "http://m2.example.com:2380"]
+  data-dir: /tmp/m2
+  wal-dir: /tmp/m2-wal
+`), 0o600))
+
+	bundle, err := readRestoreBundle(path)
+	require.NoError(t, err)
+	require.Equal(t, "dr", bundle.InitialClusterToken)
+	require.Len(t, bundle.Members, 2)
+	require.Equal(t, "/tmp/m2-wal", bundle.Members[1].WALDir)
+	require.Equal(t, "m1=http://10.0.0.1:2380,m2=http://10.0.0.2:2380,m2=http://m2.example.com:2380", bundle.initialCluster())
+
+	require.NoError(t, os.WriteFile(path, []byte("members:\n- name: m1\n  peer-url: http://10.0.0.1:2380\n"), 0o600))
+	_, err = readRestoreBundle(path)
+	require.ErrorContains(t, err, "peer-url")
+}
+
+func TestRestoreBundleValidate(t *testing.T) {
+	dir := t.TempDir()
+	nonEmptyDir := filepath.Join(dir, "non-empty")
+	require.NoError(t, os.MkdirAll(filepath.Join(nonEmptyDir, "member"), 0o700))
+
+	member := func(name, peerURL string) restoreBundleMember {
+		return restoreBundleMember{
+			Name:     name,
+			PeerURLs: []string{peerURL},
+			DataDir:  filepath.Join(dir, name),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		members       []restoreBundleMember
+		expectedError string
+	}{
+		{
+			name:    "valid",
+			members: []restoreBundleMember{member("m1", "http://10.0.0.1:2380"), member("m2", "https://m2.example.com:2380")},
+		},
+		{
+			name:          "no members",
+			expectedError: "bundle has no members",
+		},
+		{
+			name:          "missing name",
+			members:       []restoreBundleMember{member("", "http://10.0.0.1:2380")},
+			expectedError: "member #0 has no name",
+		},
+		{
+			name:          "duplicate name",
+			members:       []restoreBundleMember{member("m1", "http://10.0.0.1:2380"), member("m1", "http://10.0.0.2:2380")},
+			expectedError: `duplicate member name "m1"`,
+		},
+		{
+			name:          "no peer URLs",
+			members:       []restoreBundleMember{{Name: "m1", DataDir: filepath.Join(dir, "m1")}},
+			expectedError: `member "m1" has no peer URLs`,
+		},
+		{
+			name:          "duplicate peer URL",
+			members:       []restoreBundleMember{member("m1", "http://10.0.0.1:2380"), member("m2", "http://10.0.0.1:2380")},
+			expectedError: `peer URL "http://10.0.0.1:2380" is used by both "m1" and "m2"`,
+		},
+		{
+			name:          "peer URL without port",
+			members:       []restoreBundleMember{member("m1", "http://10.0.0.1")},
+			expectedError: "host:port",
+		},
+		{
+			name:          "unspecified peer address",
+			members:       []restoreBundleMember{member("m1", "http://0.0.0.0:2380")},
+			expectedError: "unspecified address",
+		},
+		{
+			name:          "invalid host name",
+			members:       []restoreBundleMember{member("m1", "http://m1_host:2380")},
+			expectedError: "invalid host name",
+		},
+		{
+			name:          "missing data dir",
+			members:       []restoreBundleMember{{Name: "m1", PeerURLs: []string{"http://10.0.0.1:2380"}}},
+			expectedError: `member "m1" has no data-dir`,
+		},
+		{
+			name: "shared data dir",
+			members: []restoreBundleMember{
+				member("m1", "http://10.0.0.1:2380"),
+				{Name: "m2", PeerURLs: []string{"http://10.0.0.2:2380"}, DataDir: filepath.Join(dir, "m1")},
+			},
+			expectedError: `is used by both "m1" and "m2"`,
+		},
+		{
+			name:          "data dir not empty",
+			members:       []restoreBundleMember{{Name: "m1", PeerURLs: []string{"http://10.0.0.1:2380"}, DataDir: nonEmptyDir}},
+			expectedError: "not empty",
+		},
+		{
+			name:          "wal dir exists",
+			members:       []restoreBundleMember{{Name: "m1", PeerURLs: []string{"http://10.0.0.1:2380"}, DataDir: filepath.Join(dir, "m1"), WALDir: nonEmptyDir}},
+			expectedError: "wal-dir",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&restoreBundle{Members: tc.members}).validate()
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
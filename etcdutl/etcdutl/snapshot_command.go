@@ -15,7 +15,9 @@
 package etcdutl
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -42,6 +44,12 @@ var (
 	initialMmapSize     = backend.InitialMmapSize
 	markCompacted       bool
 	revisionBump        uint64
+	restoreBundleFile   string
+	leasesFile          string
+	leasesWithKeys      bool
+	leasesKeysLimit     int
+	verifyIntegrity     bool
+	verifyMaxErrors     int
 )
 
 // NewSnapshotCommand returns the cobra command for "snapshot".
@@ -52,25 +60,73 @@ func NewSnapshotCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewSnapshotRestoreCommand())
 	cmd.AddCommand(newSnapshotStatusCommand())
+	cmd.AddCommand(newSnapshotLeasesCommand())
 	return cmd
 }
 
 func newSnapshotStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status <filename>",
 		Short: "Gets backend snapshot status of a given file",
 		Long: `When --write-out is set to simple, this command prints out comma-separated status lists for each endpoint.
 The items in the lists are hash, revision, total keys, total size.
+
+With --verify-integrity the snapshot file is checked before its status is
+printed: a full bbolt consistency check of the page references and the bucket
+structure is run and the revision encoding of every key revision is validated.
+The check can take a while on large snapshots, so its progress is printed to
+stderr. The command fails listing up to --max-errors problems if any is found.
 `,
 		Run: SnapshotStatusCommandFunc,
 	}
+	cmd.Flags().BoolVar(&verifyIntegrity, "verify-integrity", false, "Run a deep integrity check of the snapshot file before printing its status")
+	cmd.Flags().IntVar(&verifyMaxErrors, "max-errors", 10, "Maximum number of problems to print with --verify-integrity")
+	return cmd
+}
+
+func newSnapshotLeasesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "leases [<filename> | --file <filename>] [options]",
+		Short: "Lists the leases stored in a given snapshot file",
+		Long: `Lists the leases stored in a given snapshot file without restoring it.
+For each lease it prints the ID, the granted TTL, the remaining TTL as of the
+last checkpoint in the snapshot (the granted TTL if never checkpointed), and
+the number of attached keys.
+`,
+		Run: snapshotLeasesCommandFunc,
+	}
+	cmd.Flags().StringVar(&leasesFile, "file", "", "Path to the snapshot file, same as passing <filename>")
+	cmd.Flags().BoolVar(&leasesWithKeys, "with-keys", false, "List the keys attached to each lease")
+	cmd.Flags().IntVar(&leasesKeysLimit, "keys-limit", 100, "Maximum number of keys listed per lease with --with-keys")
+
+	cmd.MarkFlagFilename("file")
+
+	return cmd
 }
 
 func NewSnapshotRestoreCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore <filename> --data-dir {output dir} [options]",
 		Short: "Restores an etcd member snapshot to an etcd directory",
-		Run:   snapshotRestoreCommandFunc,
+		Long: `Restores an etcd member snapshot to an etcd directory.
+
+When --bundle is given, the snapshot is restored into the data directories of
+all members listed in the bundle file in one invocation, for example:
+
+  initial-cluster-token: etcd-cluster-dr
+  members:
+  - name: infra1
+    peer-urls: ["https://10.0.1.10:2380"]
+    data-dir: /var/lib/etcd-dr/infra1
+  - name: infra2
+    peer-urls: ["https://10.0.1.11:2380"]
+    data-dir: /var/lib/etcd-dr/infra2
+    wal-dir: /var/lib/etcd-dr-wal/infra2
+
+The initial cluster is derived from the members, so --name, --data-dir,
+--wal-dir, --initial-cluster and --initial-advertise-peer-urls must not be set.
+`,
+		Run: snapshotRestoreCommandFunc,
 	}
 	cmd.Flags().StringVar(&restoreDataDir, "data-dir", "", "Path to the output data directory")
 	cmd.Flags().StringVar(&restoreWALDir, "wal-dir", "", "Path to the WAL directory (use --data-dir if none given)")
@@ -82,9 +138,11 @@ func NewSnapshotRestoreCommand() *cobra.Command {
 	cmd.Flags().Uint64Var(&initialMmapSize, "initial-memory-map-size", initialMmapSize, "Initial memory map size of the database in bytes. It uses the default value if not defined or defined to 0")
 	cmd.Flags().Uint64Var(&revisionBump, "bump-revision", 0, "How much to increase the latest revision after restore")
 	cmd.Flags().BoolVar(&markCompacted, "mark-compacted", false, "Mark the latest revision after restore as the point of scheduled compaction (required if --bump-revision > 0, disallowed otherwise)")
+	cmd.Flags().StringVar(&restoreBundleFile, "bundle", "", "Path to a YAML file listing all members (name, peer-urls, data-dir, wal-dir) to restore the snapshot for")
 
 	cmd.MarkFlagDirname("data-dir")
 	cmd.MarkFlagDirname("wal-dir")
+	cmd.MarkFlagFilename("bundle", "yaml", "yml")
 
 	return cmd
 }
@@ -97,10 +155,18 @@ func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 	if err := validateFilePath(args[0]); err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
+	if verifyIntegrity && verifyMaxErrors <= 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--max-errors must be positive"))
+	}
 	printer := initPrinterFromCmd(cmd)
 
 	lg := GetLogger()
 	sp := snapshot.NewV3(lg)
+	if verifyIntegrity {
+		if err := verifySnapshotIntegrity(sp, args[0]); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+	}
 	ds, err := sp.Status(args[0])
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
@@ -108,7 +174,60 @@ func SnapshotStatusCommandFunc(cmd *cobra.Command, args []string) {
 	printer.DBStatus(ds)
 }
 
-func snapshotRestoreCommandFunc(_ *cobra.Command, args []string) {
+func verifySnapshotIntegrity(sp snapshot.Manager, dbPath string) error {
+	r, err := sp.VerifyIntegrity(dbPath, verifyMaxErrors, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if r.TotalErrors == 0 {
+		fmt.Fprintf(os.Stderr, "integrity check passed, %d key revisions checked\n", r.CheckedRevisions)
+		return nil
+	}
+	for _, e := range r.Errors {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	if r.TotalErrors > len(r.Errors) {
+		fmt.Fprintf(os.Stderr, "... %d more\n", r.TotalErrors-len(r.Errors))
+	}
+	return fmt.Errorf("snapshot file integrity check failed, %d errors found", r.TotalErrors)
+}
+
+func snapshotLeasesCommandFunc(cmd *cobra.Command, args []string) {
+	var dbPath string
+	switch {
+	case len(args) == 1 && leasesFile == "":
+		dbPath = args[0]
+	case len(args) == 0 && leasesFile != "":
+		dbPath = leasesFile
+	default:
+		err := errors.New("snapshot leases requires exactly one of <filename> or --file")
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	if leasesKeysLimit <= 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--keys-limit must be positive"))
+	}
+	if err := validateFilePath(dbPath); err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	printer := initPrinterFromCmd(cmd)
+
+	keysLimit := 0
+	if leasesWithKeys {
+		keysLimit = leasesKeysLimit
+	}
+	sp := snapshot.NewV3(GetLogger())
+	ls, err := sp.Leases(dbPath, keysLimit)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	printer.DBLeases(SnapshotLeases{Leases: ls, WithKeys: leasesWithKeys})
+}
+
+func snapshotRestoreCommandFunc(cmd *cobra.Command, args []string) {
+	if restoreBundleFile != "" {
+		snapshotRestoreBundleCommandFunc(cmd, args)
+		return
+	}
 	SnapshotRestoreCommandFunc(restoreCluster, restoreClusterToken, restoreDataDir, restoreWALDir,
 		restorePeerURLs, restoreName, skipHashCheck, initialMmapSize, revisionBump, markCompacted, args)
 }
@@ -165,6 +284,41 @@ func SnapshotRestoreCommandFunc(restoreCluster string,
 	}
 }
 
+func snapshotRestoreBundleCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		err := errors.New("snapshot restore requires exactly one argument")
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	for _, name := range []string{"name", "data-dir", "wal-dir", "initial-cluster", "initial-advertise-peer-urls"} {
+		if cmd.Flags().Changed(name) {
+			err := fmt.Errorf("--%s cannot be used with --bundle", name)
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+	}
+	if (revisionBump == 0 && markCompacted) || (revisionBump > 0 && !markCompacted) {
+		err := errors.New("--mark-compacted required if --revision-bump > 0")
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	printer := initPrinterFromCmd(cmd)
+
+	bundle, err := readRestoreBundle(restoreBundleFile)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+	}
+	res, err := restoreFromBundle(GetLogger(), bundle, snapshot.RestoreConfig{
+		SnapshotPath:        args[0],
+		InitialClusterToken: restoreClusterToken,
+		SkipHashCheck:       skipHashCheck,
+		InitialMmapSize:     initialMmapSize,
+		RevisionBump:        revisionBump,
+		MarkCompacted:       markCompacted,
+	})
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	printer.RestoreBundle(res)
+}
+
 func initialClusterFromName(name string) string {
 	n := name
 	if name == "" {
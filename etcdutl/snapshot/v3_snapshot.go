@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"go.uber.org/zap"
@@ -42,6 +43,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
 	"go.etcd.io/etcd/server/v3/etcdserver/cindex"
+	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -66,6 +68,14 @@ type Manager interface {
 	// Status returns the snapshot file information.
 	Status(dbPath string) (Status, error)
 
+	// Leases returns the leases stored in the snapshot file and the keys
+	// attached to them. At most keysLimit keys are listed per lease.
+	Leases(dbPath string, keysLimit int) ([]Lease, error)
+
+	// VerifyIntegrity runs a deep consistency check of the snapshot file.
+	// At most maxErrors problems are listed in the returned report.
+	VerifyIntegrity(dbPath string, maxErrors int, progress io.Writer) (IntegrityReport, error)
+
 	// Restore restores a new etcd data directory from given snapshot
 	// file. It returns an error if specified data directory already
 	// exists, to prevent unintended data directory overwrites.
@@ -173,7 +183,7 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 					ds.Revision = rev.Main
 
 					var kv mvccpb.KeyValue
-					err = proto.Unmarshal(v, &kv)
+					err = mvcc.UnmarshalKeyValue(v, &kv)
 					if err != nil {
 						return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
 					}
@@ -200,6 +210,205 @@ func (s *v3Manager) Status(dbPath string) (ds Status, err error) {
 	return ds, nil
 }
 
+// Lease is a lease stored in a snapshot file.
+type Lease struct {
+	ID  int64 `json:"id"`
+	TTL int64 `json:"ttl"`
+	// RemainingTTL is the remaining TTL as of the last checkpoint in the
+	// snapshot, or TTL if the lease has never been checkpointed.
+	RemainingTTL int64 `json:"remainingTTL"`
+	KeyCount     int   `json:"keyCount"`
+	// Keys lists up to the requested limit of the attached keys in order.
+	Keys []string `json:"keys,omitempty"`
+}
+
+// Leases returns the leases stored in the snapshot file and the keys
+// attached to them. The snapshot file is opened read-only.
+func (s *v3Manager) Leases(dbPath string, keysLimit int) ([]Lease, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(dbPath, 0o400, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	var ls []Lease
+	err = db.View(func(tx *bolt.Tx) error {
+		leaseIndex := make(map[int64]int)
+		if b := tx.Bucket(schema.Lease.Name()); b != nil {
+			if err := b.ForEach(func(k, v []byte) error {
+				var lpb leasepb.Lease
+				if err := proto.Unmarshal(v, &lpb); err != nil {
+					return fmt.Errorf("cannot unmarshal lease, key: %x err: %w", k, err)
+				}
+				remainingTTL := lpb.RemainingTTL
+				if remainingTTL <= 0 {
+					remainingTTL = lpb.TTL
+				}
+				leaseIndex[lpb.ID] = len(ls)
+				ls = append(ls, Lease{ID: lpb.ID, TTL: lpb.TTL, RemainingTTL: remainingTTL})
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		b := tx.Bucket(schema.Key.Name())
+		if b == nil {
+			return nil
+		}
+		// the key bucket is ordered by revision, so the last revision of a key
+		// decides which lease the key is attached to
+		keyLeases := make(map[string]int64)
+		if err := b.ForEach(func(k, v []byte) error {
+			if _, err := bytesToRev(k); err != nil {
+				return fmt.Errorf("cannot parse revision key: %q err: %w", k, err)
+			}
+			var kv mvccpb.KeyValue
+			if err := mvcc.UnmarshalKeyValue(v, &kv); err != nil {
+				return fmt.Errorf("cannot unmarshal value, key: %q value: %q err: %w", k, v, err)
+			}
+			if mvcc.IsTombstone(k) || kv.Lease == 0 {
+				delete(keyLeases, string(kv.Key))
+			} else {
+				keyLeases[string(kv.Key)] = kv.Lease
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for key, id := range keyLeases {
+			i, ok := leaseIndex[id]
+			if !ok {
+				continue
+			}
+			ls[i].KeyCount++
+			if keysLimit > 0 {
+				ls[i].Keys = append(ls[i].Keys, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range ls {
+		sort.Strings(ls[i].Keys)
+		if len(ls[i].Keys) > keysLimit {
+			ls[i].Keys = ls[i].Keys[:keysLimit]
+		}
+	}
+	return ls, nil
+}
+
+// IntegrityReport is the result of a deep consistency check of a snapshot file.
+type IntegrityReport struct {
+	// CheckedRevisions is the number of key revisions that were validated.
+	CheckedRevisions int `json:"checkedRevisions"`
+	// TotalErrors is the number of problems found.
+	TotalErrors int `json:"totalErrors"`
+	// Errors lists up to the requested limit of the problems found in the
+	// order they were found.
+	Errors []string `json:"errors,omitempty"`
+}
+
+func (r *IntegrityReport) addError(maxErrors int, err error) {
+	r.TotalErrors++
+	if len(r.Errors) < maxErrors {
+		r.Errors = append(r.Errors, err.Error())
+	}
+}
+
+// integrityProgressInterval is the number of key revisions validated between
+// two progress lines.
+const integrityProgressInterval = 10000
+
+// VerifyIntegrity runs a full bbolt consistency check of the snapshot file,
+// covering page references, the freelist and the bucket structure, and then
+// validates the revision encoding of every entry in the key bucket. The key
+// bucket is only walked if the bbolt check passes, since walking a broken
+// b-tree is not meaningful. Progress is written to progress if it is not nil.
+//
+// Problems found in the file are returned in the report; the returned error
+// is only set if the check could not run, e.g. because the file cannot be
+// opened as a bbolt database.
+func (s *v3Manager) VerifyIntegrity(dbPath string, maxErrors int, progress io.Writer) (IntegrityReport, error) {
+	var r IntegrityReport
+	if _, err := os.Stat(dbPath); err != nil {
+		return r, err
+	}
+	if progress == nil {
+		progress = io.Discard
+	}
+
+	db, err := bolt.Open(dbPath, 0o400, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return r, err
+	}
+	defer db.Close()
+
+	err = db.View(func(tx *bolt.Tx) error {
+		fmt.Fprintf(progress, "checking bbolt consistency of %d pages\n", tx.Size()/int64(db.Info().PageSize))
+		for err := range tx.Check() {
+			r.addError(maxErrors, err)
+		}
+		if r.TotalErrors > 0 {
+			return nil
+		}
+
+		b := tx.Bucket(schema.Key.Name())
+		if b == nil {
+			return nil
+		}
+		total := b.Stats().KeyN
+		fmt.Fprintf(progress, "checking %d key revisions\n", total)
+		return b.ForEach(func(k, v []byte) error {
+			if err := verifyKeyRevision(k, v); err != nil {
+				r.addError(maxErrors, err)
+			}
+			if r.CheckedRevisions++; r.CheckedRevisions%integrityProgressInterval == 0 {
+				fmt.Fprintf(progress, "checked %d/%d key revisions\n", r.CheckedRevisions, total)
+			}
+			return nil
+		})
+	})
+	return r, err
+}
+
+// verifyKeyRevision validates the entry k, v of the key bucket.
+func verifyKeyRevision(k, v []byte) error {
+	rev, err := bytesToRev(k)
+	if err != nil {
+		return fmt.Errorf("key bucket entry %x: cannot parse revision: %w", k, err)
+	}
+	tombstone := mvcc.IsTombstone(k)
+	if len(k) > len(mvcc.NewRevBytes()) && !tombstone {
+		return fmt.Errorf("key bucket entry %x: unknown revision mark %q", k, k[len(k)-1])
+	}
+	var kv mvccpb.KeyValue
+	if err := mvcc.UnmarshalKeyValue(v, &kv); err != nil {
+		return fmt.Errorf("key bucket entry %x: cannot unmarshal value: %w", k, err)
+	}
+	if len(kv.Key) == 0 {
+		return fmt.Errorf("key bucket entry %x: empty key", k)
+	}
+	if tombstone {
+		return nil
+	}
+	switch {
+	case kv.ModRevision != rev.Main:
+		return fmt.Errorf("key bucket entry %x: key %q has mod revision %d, expected %d", k, kv.Key, kv.ModRevision, rev.Main)
+	case kv.CreateRevision <= 0 || kv.CreateRevision > kv.ModRevision:
+		return fmt.Errorf("key bucket entry %x: key %q has create revision %d outside of (0, %d]", k, kv.Key, kv.CreateRevision, kv.ModRevision)
+	case kv.Version <= 0:
+		return fmt.Errorf("key bucket entry %x: key %q has non-positive version %d", k, kv.Key, kv.Version)
+	}
+	return nil
+}
+
 func bytesToRev(b []byte) (rev mvcc.Revision, err error) {
 	defer func() {
 		if r := recover(); r != nil {
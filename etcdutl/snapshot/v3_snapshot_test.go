@@ -15,7 +15,10 @@
 package snapshot
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 	"testing"
@@ -217,6 +220,120 @@ func TestSnapshotStatusTotalKey(t *testing.T) {
 	}
 }
 
+// TestSnapshotLeases tests if snapshot leases command reports the leases and
+// the keys currently attached to them.
+func TestSnapshotLeases(t *testing.T) {
+	dbpath := createDB(t, func(srv *etcdserver.EtcdServer) {
+		for _, id := range []int64{1, 2} {
+			_, err := srv.LeaseGrant(t.Context(), &etcdserverpb.LeaseGrantRequest{ID: id, TTL: 600})
+			require.NoError(t, err)
+		}
+		for _, key := range []string{"c", "e", "a", "b", "d"} {
+			_, err := srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte(key), Lease: 1})
+			require.NoError(t, err)
+		}
+		// "b" is detached from lease 1, "c" moves to lease 2 and "d" is deleted
+		_, err := srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("b")})
+		require.NoError(t, err)
+		_, err = srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("c"), Lease: 2})
+		require.NoError(t, err)
+		_, err = srv.DeleteRange(t.Context(), &etcdserverpb.DeleteRangeRequest{Key: []byte("d")})
+		require.NoError(t, err)
+	})
+
+	ls, err := NewV3(zap.NewNop()).Leases(dbpath, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []Lease{
+		{ID: 1, TTL: 600, RemainingTTL: 600, KeyCount: 2},
+		{ID: 2, TTL: 600, RemainingTTL: 600, KeyCount: 1},
+	}, ls)
+
+	ls, err = NewV3(zap.NewNop()).Leases(dbpath, 1)
+	require.NoError(t, err)
+	require.Len(t, ls, 2)
+	assert.Equal(t, []string{"a"}, ls[0].Keys)
+	assert.Equal(t, []string{"c"}, ls[1].Keys)
+}
+
+// TestSnapshotVerifyIntegrity tests that a deep integrity check passes on a
+// healthy snapshot and validates every key revision.
+func TestSnapshotVerifyIntegrity(t *testing.T) {
+	dbpath := createDB(t, insertKeys(t, 10, 100))
+
+	var progress bytes.Buffer
+	r, err := NewV3(zap.NewNop()).VerifyIntegrity(dbpath, 10, &progress)
+	require.NoError(t, err)
+	assert.Zero(t, r.TotalErrors)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, 10, r.CheckedRevisions)
+	assert.Contains(t, progress.String(), "checking 10 key revisions")
+}
+
+// TestSnapshotVerifyIntegrityCorruptValue tests that a corrupted byte in a
+// key revision, which bbolt cannot detect on its own, is reported.
+func TestSnapshotVerifyIntegrityCorruptValue(t *testing.T) {
+	dbpath := createDB(t, func(srv *etcdserver.EtcdServer) {
+		_, err := srv.Put(t.Context(), &etcdserverpb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+		require.NoError(t, err)
+	})
+
+	var value []byte
+	db, err := bbolt.Open(dbpath, 0o600, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		_, v := tx.Bucket(schema.Key.Name()).Cursor().Last()
+		value = bytes.Clone(v)
+		return nil
+	}))
+	require.NoError(t, db.Close())
+
+	// bump the mod revision, which follows the field tag 0x18 in the encoded
+	// key value. Stale copies of the value may remain in freed pages, so every
+	// copy is corrupted.
+	corrupted := bytes.Clone(value)
+	corrupted[bytes.IndexByte(value, 0x18)+1]++
+	data, err := os.ReadFile(dbpath)
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(data, value))
+	require.NoError(t, os.WriteFile(dbpath, bytes.ReplaceAll(data, value, corrupted), 0o600))
+
+	r, err := NewV3(zap.NewNop()).VerifyIntegrity(dbpath, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, r.TotalErrors)
+	assert.Contains(t, r.Errors[0], `key "foo" has mod revision 3, expected 2`)
+}
+
+// TestSnapshotVerifyIntegrityCorruptPage tests that a torn page is reported
+// by the bbolt consistency check and that the reported errors are limited.
+func TestSnapshotVerifyIntegrityCorruptPage(t *testing.T) {
+	dbpath := createDB(t, insertKeys(t, 1000, 100))
+
+	var root int64
+	var pageSize int
+	db, err := bbolt.Open(dbpath, 0o600, nil)
+	require.NoError(t, err)
+	pageSize = db.Info().PageSize
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		root = int64(tx.Bucket(schema.Key.Name()).Root())
+		return nil
+	}))
+	require.NoError(t, db.Close())
+
+	// the page flags follow the 8 byte page ID in the page header
+	f, err := os.OpenFile(dbpath, os.O_RDWR, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff}, root*int64(pageSize)+8)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	r, err := NewV3(zap.NewNop()).VerifyIntegrity(dbpath, 1, nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, r.TotalErrors, 1)
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0], fmt.Sprintf("page %d: ", root))
+	assert.Zero(t, r.CheckedRevisions)
+}
+
 // insertKeys insert `numKeys` number of keys of `valueSize` size into a running etcd server.
 func insertKeys(t *testing.T, numKeys, valueSize int) func(*etcdserver.EtcdServer) {
 	t.Helper()
@@ -19,21 +19,39 @@ import (
 	"os"
 )
 
+// Exit codes returned by etcdctl and etcdutl. See
+// http://tldp.org/LDP/abs/html/exitcodes.html for conventions.
 const (
-	// http://tldp.org/LDP/abs/html/exitcodes.html
+	// ExitSuccess is returned when the command succeeded.
 	ExitSuccess = iota
+	// ExitError is returned for failures without a more specific code,
+	// including most errors returned by the server.
 	ExitError
+	// ExitBadConnection is returned when no connection to the cluster could be established.
 	ExitBadConnection
-	ExitInvalidInput // for txn, watch command
-	ExitBadFeature   // provided a valid flag with an unsupported value
+	// ExitInvalidInput is returned when the input of the txn or watch
+	// command, or the state it depends on, is invalid.
+	ExitInvalidInput
+	// ExitBadFeature is returned when a valid flag is given an unsupported value.
+	ExitBadFeature
+	// ExitInterrupted is returned when the command was interrupted.
 	ExitInterrupted
+	// ExitIO is returned when reading or writing local files failed.
 	ExitIO
+	// ExitWatchGap is returned by the watch command when events may have been missed.
+	ExitWatchGap
+	// ExitBadArgs is returned when the command line arguments are invalid.
 	ExitBadArgs = 128
 
-	ExitServerError       = 4
+	// ExitServerError is reserved for server failures. It shares its value
+	// with ExitBadFeature.
+	ExitServerError = 4
+	// ExitClusterNotHealthy is reserved for an unhealthy cluster. It shares
+	// its value with ExitInterrupted.
 	ExitClusterNotHealthy = 5
 )
 
+// ExitWithError prints err to stderr and exits with the given code.
 func ExitWithError(code int, err error) {
 	fmt.Fprintln(os.Stderr, "Error:", err)
 	os.Exit(code)
@@ -30,6 +30,7 @@ import (
 	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/pkg/v3/featuregate"
 	"go.etcd.io/etcd/pkg/v3/netutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/storage/datadir"
 )
@@ -165,6 +166,9 @@ type ServerConfig struct {
 
 	EnableGRPCGateway bool
 
+	// EnableGRPCZstdCompression registers the zstd compressor on the gRPC server.
+	EnableGRPCZstdCompression bool
+
 	// EnableDistributedTracing enables distributed tracing using OpenTelemetry protocol.
 	EnableDistributedTracing bool
 	// TracerOptions are options for OpenTelemetry gRPC interceptor.
@@ -172,12 +176,37 @@ type ServerConfig struct {
 
 	WatchProgressNotifyInterval time.Duration
 
+	// WatchOldRevisionThreshold is the number of revisions a new watcher may
+	// have to catch up on before its creation is warned about, or rejected if
+	// WatchOldRevisionReject is set. 0 disables the check.
+	WatchOldRevisionThreshold int64
+	// WatchOldRevisionReject rejects the watchers beyond
+	// WatchOldRevisionThreshold instead of warning about them.
+	WatchOldRevisionReject bool
+	// WatchValueSelectorMaxCost is the maximum cost of the value selector of
+	// a watcher, when the WatchValueSelector feature is enabled.
+	WatchValueSelectorMaxCost int
+	// WatchSyncFairness is the ratio of the time left to the live delivery of
+	// watch events to the time spent catching up unsynced watchers.
+	WatchSyncFairness float64
+
 	// UnsafeNoFsync disables all uses of fsync.
 	// Setting this is unsafe and will cause data loss.
 	UnsafeNoFsync bool `json:"unsafe-no-fsync"`
 
 	DowngradeCheckTime time.Duration
 
+	// ClockSkewWarningThreshold is the maximum clock skew between members
+	// above which the leader logs a warning.
+	ClockSkewWarningThreshold time.Duration
+	// ClockOffset shifts the clock the member serves to its peers for clock
+	// skew estimation. Used only in tests, to simulate a skewed clock.
+	ClockOffset time.Duration
+
+	// AutoMaintenance configures the engine that compacts and defragments
+	// the member under quota pressure.
+	AutoMaintenance automaintenance.Config
+
 	// MemoryMlock enables mlocking of etcd owned memory pages.
 	// The setting improves etcd tail latency in environments were:
 	//   - memory pressure might lead to swapping pages to disk
@@ -47,11 +47,13 @@ import (
 	"go.etcd.io/etcd/pkg/v3/netutil"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
 const (
@@ -70,6 +72,7 @@ const (
 	DefaultGRPCKeepAliveInterval       = 2 * time.Hour
 	DefaultGRPCKeepAliveTimeout        = 20 * time.Second
 	DefaultDowngradeCheckTime          = 5 * time.Second
+	DefaultClockSkewWarningThreshold   = time.Second
 	DefaultAutoCompactionMode          = "periodic"
 	DefaultAutoCompactionRetention     = "0"
 	DefaultAuthToken                   = "simple"
@@ -372,6 +375,19 @@ type Config struct {
 	CompactionSleepInterval time.Duration `json:"compaction-sleep-interval"`
 	// WatchProgressNotifyInterval is the time duration of periodic watch progress notifications.
 	WatchProgressNotifyInterval time.Duration `json:"watch-progress-notify-interval"`
+	// WatchOldRevisionThreshold is the number of revisions a new watcher may
+	// have to catch up on before its creation is warned about, or rejected if
+	// WatchOldRevisionReject is set. 0 disables the check.
+	WatchOldRevisionThreshold int64 `json:"watch-old-revision-threshold"`
+	// WatchOldRevisionReject rejects the watchers beyond
+	// WatchOldRevisionThreshold instead of warning about them.
+	WatchOldRevisionReject bool `json:"watch-old-revision-reject"`
+	// WatchValueSelectorMaxCost is the maximum cost of the value selector of
+	// a watcher, when the WatchValueSelector feature is enabled.
+	WatchValueSelectorMaxCost int `json:"watch-value-selector-max-cost"`
+	// WatchSyncFairness is the ratio of the time left to the live delivery of
+	// watch events to the time spent catching up unsynced watchers.
+	WatchSyncFairness float64 `json:"watch-sync-fairness"`
 	// WarningApplyDuration is the time duration after which a warning is generated if applying request
 	WarningApplyDuration time.Duration `json:"warning-apply-duration"`
 	// BootstrapDefragThresholdMegabytes is the minimum number of megabytes needed to be freed for etcd server to
@@ -390,6 +406,10 @@ type Config struct {
 	ListenMetricsUrls     []url.URL
 	ListenMetricsUrlsJSON string `json:"listen-metrics-urls"`
 
+	// EnableWatchDebug enables the endpoint that raises the verbosity of the
+	// watch logs of a range hash at runtime.
+	EnableWatchDebug bool `json:"enable-watch-debug"`
+
 	// EnableDistributedTracing indicates if tracing using OpenTelemetry is enabled.
 	EnableDistributedTracing bool `json:"enable-distributed-tracing"`
 	// DistributedTracingAddress is the address of the OpenTelemetry Collector.
@@ -437,6 +457,11 @@ type Config struct {
 	// The gateway translates a RESTful HTTP API into gRPC.
 	EnableGRPCGateway bool `json:"enable-grpc-gateway"`
 
+	// EnableGRPCZstdCompression registers the zstd compressor on the gRPC
+	// server, so that clients can compress their calls with zstd. Responses
+	// are compressed with the codec of the request. gzip is always supported.
+	EnableGRPCZstdCompression bool `json:"enable-grpc-zstd-compression"`
+
 	// UnsafeNoFsync disables all uses of fsync.
 	// Setting this is unsafe and will cause data loss.
 	UnsafeNoFsync bool `json:"unsafe-no-fsync"`
@@ -444,6 +469,32 @@ type Config struct {
 	// DowngradeCheckTime is the duration between two downgrade status checks (in seconds).
 	DowngradeCheckTime time.Duration `json:"downgrade-check-time"`
 
+	// ClockSkewWarningThreshold is the maximum clock skew between members
+	// above which the leader logs a warning.
+	ClockSkewWarningThreshold time.Duration `json:"clock-skew-warning-threshold"`
+
+	// AutoMaintenancePolicy enables the engine that compacts and defragments
+	// the member under quota pressure, "conservative" or "aggressive". Empty
+	// disables it.
+	AutoMaintenancePolicy string `json:"auto-maintenance-policy"`
+	// AutoMaintenanceCompactionThreshold is the fraction of the backend quota
+	// above which the database size triggers a compaction, 0 for the default
+	// of the policy.
+	AutoMaintenanceCompactionThreshold float64 `json:"auto-maintenance-compaction-threshold"`
+	// AutoMaintenanceCompactionRetention is the number of revisions kept by a
+	// compaction, 0 for the default of the policy.
+	AutoMaintenanceCompactionRetention int64 `json:"auto-maintenance-compaction-retention"`
+	// AutoMaintenanceDefragThreshold is the fraction of the database that must
+	// be free space to trigger a defragmentation, 0 for the default of the
+	// policy.
+	AutoMaintenanceDefragThreshold float64 `json:"auto-maintenance-defrag-threshold"`
+	// AutoMaintenanceWindow is the cron-like spec of the window in which
+	// defragmentation may run, empty to allow it at any time.
+	AutoMaintenanceWindow string `json:"auto-maintenance-window"`
+	// AutoMaintenanceCheckInterval is the interval between two checks of the
+	// engine, 0 for the default of the policy.
+	AutoMaintenanceCheckInterval time.Duration `json:"auto-maintenance-check-interval"`
+
 	// MemoryMlock enables mlocking of etcd owned memory pages.
 	// The setting improves etcd tail latency in environments were:
 	//   - memory pressure might lead to swapping pages to disk
@@ -515,6 +566,9 @@ func NewConfig() *Config {
 		SnapshotCount:          etcdserver.DefaultSnapshotCount,
 		SnapshotCatchUpEntries: etcdserver.DefaultSnapshotCatchUpEntries,
 
+		WatchValueSelectorMaxCost: mvcc.DefaultValueSelectorMaxCost,
+		WatchSyncFairness:         mvcc.DefaultWatchSyncFairness,
+
 		MaxTxnOps:                   DefaultMaxTxnOps,
 		MaxRequestBytes:             DefaultMaxRequestBytes,
 		MaxConcurrentStreams:        DefaultMaxConcurrentStreams,
@@ -566,9 +620,10 @@ func NewConfig() *Config {
 		LogRotationConfigJSON: DefaultLogRotationConfig,
 		EnableGRPCGateway:     true,
 
-		DowngradeCheckTime: DefaultDowngradeCheckTime,
-		MemoryMlock:        false,
-		MaxLearners:        membership.DefaultMaxLearners,
+		DowngradeCheckTime:        DefaultDowngradeCheckTime,
+		ClockSkewWarningThreshold: DefaultClockSkewWarningThreshold,
+		MemoryMlock:               false,
+		MaxLearners:               membership.DefaultMaxLearners,
 
 		DistributedTracingAddress:     DefaultDistributedTracingAddress,
 		DistributedTracingServiceName: DefaultDistributedTracingServiceName,
@@ -732,6 +787,7 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 
 	// pprof profiler via HTTP
 	fs.BoolVar(&cfg.EnablePprof, "enable-pprof", false, "Enable runtime profiling data via HTTP server. Address is at client URL + \"/debug/pprof/\"")
+	fs.BoolVar(&cfg.EnableWatchDebug, "enable-watch-debug", false, "Enable raising the verbosity of the watch logs of a range hash at runtime via HTTP server. Address is at client URL + \"/debug/watch/ranges\"")
 
 	// additional metrics
 	fs.StringVar(&cfg.Metrics, "metrics", cfg.Metrics, "Set level of detail for exported metrics, specify 'extensive' to include server side grpc histogram metrics")
@@ -749,13 +805,25 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 
 	// gateway
 	fs.BoolVar(&cfg.EnableGRPCGateway, "enable-grpc-gateway", cfg.EnableGRPCGateway, "Enable GRPC gateway.")
+	fs.BoolVar(&cfg.EnableGRPCZstdCompression, "enable-grpc-zstd-compression", cfg.EnableGRPCZstdCompression, "Enable the zstd compression codec for client gRPC calls.")
 	fs.DurationVar(&cfg.CorruptCheckTime, "corrupt-check-time", cfg.CorruptCheckTime, "Duration of time between cluster corruption check passes.")
 	fs.DurationVar(&cfg.CompactHashCheckTime, "compact-hash-check-time", cfg.CompactHashCheckTime, "Duration of time between leader checks followers compaction hashes.")
 
 	fs.IntVar(&cfg.CompactionBatchLimit, "compaction-batch-limit", cfg.CompactionBatchLimit, "Sets the maximum revisions deleted in each compaction batch.")
 	fs.DurationVar(&cfg.CompactionSleepInterval, "compaction-sleep-interval", cfg.CompactionSleepInterval, "Sets the sleep interval between each compaction batch.")
 	fs.DurationVar(&cfg.WatchProgressNotifyInterval, "watch-progress-notify-interval", cfg.WatchProgressNotifyInterval, "Duration of periodic watch progress notifications.")
+	fs.Int64Var(&cfg.WatchOldRevisionThreshold, "watch-old-revision-threshold", cfg.WatchOldRevisionThreshold, "Number of revisions a new watcher may have to catch up on before a warning is returned with its creation (0 to disable).")
+	fs.BoolVar(&cfg.WatchOldRevisionReject, "watch-old-revision-reject", cfg.WatchOldRevisionReject, "Reject the creation of watchers beyond --watch-old-revision-threshold instead of warning about it.")
+	fs.IntVar(&cfg.WatchValueSelectorMaxCost, "watch-value-selector-max-cost", cfg.WatchValueSelectorMaxCost, "Maximum cost of the value selector of a watcher, counting its operators, path segments and comparisons. Requires the WatchValueSelector feature gate.")
+	fs.Float64Var(&cfg.WatchSyncFairness, "watch-sync-fairness", cfg.WatchSyncFairness, "Ratio of the time left to the live delivery of watch events to the time spent catching up unsynced watchers, while they are catching up. Higher values favor live watchers.")
 	fs.DurationVar(&cfg.DowngradeCheckTime, "downgrade-check-time", cfg.DowngradeCheckTime, "Duration of time between two downgrade status checks.")
+	fs.DurationVar(&cfg.ClockSkewWarningThreshold, "clock-skew-warning-threshold", cfg.ClockSkewWarningThreshold, "Maximum clock skew between members above which the leader logs a warning.")
+	fs.StringVar(&cfg.AutoMaintenancePolicy, "auto-maintenance-policy", cfg.AutoMaintenancePolicy, "Policy of the engine that compacts and defragments the member under quota pressure ('conservative' or 'aggressive'). Empty disables it.")
+	fs.Float64Var(&cfg.AutoMaintenanceCompactionThreshold, "auto-maintenance-compaction-threshold", cfg.AutoMaintenanceCompactionThreshold, "Fraction of the backend quota above which the database size triggers a compaction. 0 uses the default of the policy.")
+	fs.Int64Var(&cfg.AutoMaintenanceCompactionRetention, "auto-maintenance-compaction-retention", cfg.AutoMaintenanceCompactionRetention, "Number of revisions kept by an auto maintenance compaction. 0 uses the default of the policy.")
+	fs.Float64Var(&cfg.AutoMaintenanceDefragThreshold, "auto-maintenance-defrag-threshold", cfg.AutoMaintenanceDefragThreshold, "Fraction of the database that must be free space to trigger a defragmentation. 0 uses the default of the policy.")
+	fs.StringVar(&cfg.AutoMaintenanceWindow, "auto-maintenance-window", cfg.AutoMaintenanceWindow, "Cron-like spec ('minute hour day-of-month month day-of-week') of the window in which auto maintenance may defragment. Empty allows it at any time.")
+	fs.DurationVar(&cfg.AutoMaintenanceCheckInterval, "auto-maintenance-check-interval", cfg.AutoMaintenanceCheckInterval, "Duration between two checks of the auto maintenance engine. 0 uses the default of the policy.")
 	fs.DurationVar(&cfg.WarningApplyDuration, "warning-apply-duration", cfg.WarningApplyDuration, "Time duration after which a warning is generated if watch progress takes more time.")
 	fs.DurationVar(&cfg.WarningUnaryRequestDuration, "warning-unary-request-duration", cfg.WarningUnaryRequestDuration, "Time duration after which a warning is generated if a unary request takes more time.")
 	fs.BoolVar(&cfg.MemoryMlock, "memory-mlock", cfg.MemoryMlock, "Enable to enforce etcd pages (in particular bbolt) to stay in RAM.")
@@ -939,6 +1007,17 @@ func updateMinMaxVersions(info *transport.TLSInfo, min, max string) {
 }
 
 // Validate ensures that '*embed.Config' fields are properly configured.
+func (cfg *Config) autoMaintenanceConfig() automaintenance.Config {
+	return automaintenance.Config{
+		Policy:              cfg.AutoMaintenancePolicy,
+		CompactionThreshold: cfg.AutoMaintenanceCompactionThreshold,
+		CompactionRetention: cfg.AutoMaintenanceCompactionRetention,
+		DefragThreshold:     cfg.AutoMaintenanceDefragThreshold,
+		Window:              cfg.AutoMaintenanceWindow,
+		CheckInterval:       cfg.AutoMaintenanceCheckInterval,
+	}
+}
+
 func (cfg *Config) Validate() error {
 	if err := cfg.setupLogging(); err != nil {
 		return err
@@ -1020,6 +1099,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown auto-compaction-mode %q", cfg.AutoCompactionMode)
 	}
 
+	if err := cfg.autoMaintenanceConfig().Validate(); err != nil {
+		return err
+	}
+
 	// Validate distributed tracing configuration but only if enabled.
 	if cfg.EnableDistributedTracing {
 		if err := validateTracingConfig(cfg.DistributedTracingSamplingRatePerMillion); err != nil {
@@ -1039,6 +1122,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("--compact-hash-check-time must be >0 (set to %v)", cfg.CompactHashCheckTime)
 	}
 
+	if cfg.WatchSyncFairness <= 0 {
+		return fmt.Errorf("--watch-sync-fairness must be >0 (set to %v)", cfg.WatchSyncFairness)
+	}
+
 	// If `--name` isn't configured, then multiple members may have the same "default" name.
 	// When adding a new member with the "default" name as well, etcd may regards its peerURL
 	// as one additional peerURL of the existing member which has the same "default" name,
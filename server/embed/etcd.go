@@ -45,8 +45,10 @@ import (
 	runtimeutil "go.etcd.io/etcd/pkg/v3/runtime"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/storage"
 	"go.etcd.io/etcd/server/v3/verify"
@@ -220,12 +222,19 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		Logger:                            cfg.logger,
 		ForceNewCluster:                   cfg.ForceNewCluster,
 		EnableGRPCGateway:                 cfg.EnableGRPCGateway,
+		EnableGRPCZstdCompression:         cfg.EnableGRPCZstdCompression,
 		EnableDistributedTracing:          cfg.EnableDistributedTracing,
 		UnsafeNoFsync:                     cfg.UnsafeNoFsync,
 		CompactionBatchLimit:              cfg.CompactionBatchLimit,
 		CompactionSleepInterval:           cfg.CompactionSleepInterval,
 		WatchProgressNotifyInterval:       cfg.WatchProgressNotifyInterval,
+		WatchOldRevisionThreshold:         cfg.WatchOldRevisionThreshold,
+		WatchOldRevisionReject:            cfg.WatchOldRevisionReject,
+		WatchValueSelectorMaxCost:         cfg.WatchValueSelectorMaxCost,
+		WatchSyncFairness:                 cfg.WatchSyncFairness,
 		DowngradeCheckTime:                cfg.DowngradeCheckTime,
+		ClockSkewWarningThreshold:         cfg.ClockSkewWarningThreshold,
+		AutoMaintenance:                   cfg.autoMaintenanceConfig(),
 		WarningApplyDuration:              cfg.WarningApplyDuration,
 		WarningUnaryRequestDuration:       cfg.WarningUnaryRequestDuration,
 		MemoryMlock:                       cfg.MemoryMlock,
@@ -379,6 +388,8 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.String("discovery-user", sc.DiscoveryCfg.Auth.Username),
 
 		zap.String("downgrade-check-interval", sc.DowngradeCheckTime.String()),
+		zap.String("clock-skew-warning-threshold", sc.ClockSkewWarningThreshold.String()),
+		zap.String("auto-maintenance-policy", sc.AutoMaintenance.Policy),
 		zap.Int("max-learners", sc.MaxLearners),
 
 		zap.String("v2-deprecation", string(ec.V2Deprecation)),
@@ -438,6 +449,8 @@ func (e *Etcd) Close() {
 	timeout := 2 * time.Second
 	if e.Server != nil {
 		timeout = e.Server.Cfg.ReqTimeout()
+		// let the watch streams send their watchers a final response
+		e.Server.DrainClients()
 	}
 	for _, sctx := range e.sctxs {
 		for ss := range sctx.serversC {
@@ -772,6 +785,13 @@ func (e *Etcd) serveClients() {
 	etcdhttp.HandleVersion(mux, e.Server)
 	etcdhttp.HandleMetrics(mux)
 	etcdhttp.HandleHealth(e.cfg.logger, mux, e.Server)
+	if am := e.Server.AutoMaintenance(); am != nil {
+		automaintenance.HandleHistory(mux, am)
+	}
+	if e.cfg.EnableWatchDebug {
+		e.cfg.logger.Info("watch debug is enabled", zap.String("path", v3rpc.PathWatchDebugRanges))
+		v3rpc.HandleWatchDebug(mux)
+	}
 
 	var gopts []grpc.ServerOption
 	if e.cfg.GRPCKeepAliveMinTime > time.Duration(0) {
@@ -18,8 +18,10 @@ import (
 	errorspkg "errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -109,7 +111,7 @@ func startEtcdOrProxyV2(args []string) {
 		)
 		switch which {
 		case dirMember:
-			stopped, errc, err = startEtcd(&cfg.ec)
+			stopped, errc, err = startEtcd(&cfg.ec, cfg.configFile)
 		case dirProxy:
 			lg.Panic("v2 http proxy has already been deprecated in 3.6", zap.String("dir-type", string(which)))
 		default:
@@ -124,7 +126,7 @@ func startEtcdOrProxyV2(args []string) {
 			zap.String("data-dir", cfg.ec.Dir),
 			zap.String("dir-type", string(which)),
 		)
-		stopped, errc, err = startEtcd(&cfg.ec)
+		stopped, errc, err = startEtcd(&cfg.ec, cfg.configFile)
 	}
 
 	if err != nil {
@@ -177,12 +179,15 @@ func startEtcdOrProxyV2(args []string) {
 }
 
 // startEtcd runs StartEtcd in addition to hooks needed for standalone etcd.
-func startEtcd(cfg *embed.Config) (<-chan struct{}, <-chan error, error) {
+func startEtcd(cfg *embed.Config, configFile string) (<-chan struct{}, <-chan error, error) {
 	e, err := embed.StartEtcd(cfg)
 	if err != nil {
 		return nil, nil, err
 	}
 	osutil.RegisterInterruptHandler(e.Close)
+	if configFile != "" {
+		go reloadConfigOnSIGHUP(e, configFile)
+	}
 	select {
 	case <-e.Server.ReadyNotify(): // wait for e.Server to join the cluster
 	case <-e.Server.StopNotify(): // publish aborted from 'ErrStopped'
@@ -190,6 +195,30 @@ func startEtcd(cfg *embed.Config) (<-chan struct{}, <-chan error, error) {
 	return e.Server.StopNotify(), e.Err(), nil
 }
 
+// reloadConfigOnSIGHUP reads the configuration file again on SIGHUP, and
+// applies the settings that can change at runtime to the server, until it
+// stops. Only max-snapshots and max-wals can change at runtime.
+func reloadConfigOnSIGHUP(e *embed.Etcd, configFile string) {
+	lg := e.GetLogger()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGHUP)
+	defer signal.Stop(sigc)
+	for {
+		select {
+		case <-sigc:
+		case <-e.Server.StopNotify():
+			return
+		}
+		lg.Info("reloading configuration file", zap.String("path", configFile))
+		cfg, err := embed.ConfigFromFile(configFile)
+		if err != nil {
+			lg.Warn("failed to reload configuration file", zap.String("path", configFile), zap.Error(err))
+			continue
+		}
+		e.Server.SetFileRetention(cfg.MaxSnapFiles, cfg.MaxWalFiles)
+	}
+}
+
 // identifyDataDirOrDie returns the type of the data dir.
 // Dies if the datadir is invalid.
 func identifyDataDirOrDie(lg *zap.Logger, dir string) dirType {
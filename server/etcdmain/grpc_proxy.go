@@ -54,6 +54,7 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3election/v3electionpb"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
 	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy/cache"
 )
 
 var (
@@ -94,9 +95,26 @@ var (
 	grpcProxyAdvertiseClientURL string
 	grpcProxyResolverPrefix     string
 	grpcProxyResolverTTL        int
+	grpcProxyResolverWeight     uint32
 
-	grpcProxyNamespace string
-	grpcProxyLeasing   string
+	grpcProxyResolverBackoffBase time.Duration
+	grpcProxyResolverBackoffMax  time.Duration
+	grpcProxyResolverHealthCheck bool
+
+	grpcProxyCacheSize int
+	grpcProxyCacheTTL  time.Duration
+
+	grpcProxyMaxWatchersPerBroadcast int
+
+	grpcProxyRateLimit grpcproxy.RateLimitConfig
+
+	grpcProxyLeaseKeepAliveJitter   float64
+	grpcProxyLeaseKeepAliveFallback float64
+
+	grpcProxyNamespace                  string
+	grpcProxyNamespaceMapFile           string
+	grpcProxyNamespaceMapReloadInterval time.Duration
+	grpcProxyLeasing                    string
 
 	grpcProxyEnablePprof    bool
 	grpcProxyEnableOrdering bool
@@ -112,7 +130,13 @@ var (
 	maxConcurrentStreams uint32
 )
 
-const defaultGRPCMaxCallSendMsgSize = 1.5 * 1024 * 1024
+const (
+	defaultGRPCMaxCallSendMsgSize = 1.5 * 1024 * 1024
+
+	// upstreamHealthCheckInterval is the interval between the checks of the
+	// upstream endpoints reported on /proxy/upstream-health.
+	upstreamHealthCheckInterval = 5 * time.Second
+)
 
 func init() {
 	rootCmd.AddCommand(newGRPCProxyCommand())
@@ -149,7 +173,22 @@ func newGRPCProxyStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&grpcProxyAdvertiseClientURL, "advertise-client-url", "127.0.0.1:23790", "advertise address to register (must be reachable by client)")
 	cmd.Flags().StringVar(&grpcProxyResolverPrefix, "resolver-prefix", "", "prefix to use for registering proxy (must be shared with other grpc-proxy members)")
 	cmd.Flags().IntVar(&grpcProxyResolverTTL, "resolver-ttl", 0, "specify TTL, in seconds, when registering proxy endpoints")
+	cmd.Flags().Uint32Var(&grpcProxyResolverWeight, "resolver-weight", 0, "relative weight of this proxy for clients using weighted round-robin balancing (0 means default weight of 1)")
+	cmd.Flags().DurationVar(&grpcProxyResolverBackoffBase, "resolver-backoff-base", time.Second, "initial delay before retrying a failed registration of the proxy, doubled on every consecutive failure")
+	cmd.Flags().DurationVar(&grpcProxyResolverBackoffMax, "resolver-backoff-max", 30*time.Second, "maximum delay between two registration attempts of the proxy")
+	cmd.Flags().BoolVar(&grpcProxyResolverHealthCheck, "resolver-health-check", false, "only register the proxy while it can serve a serializable range from the etcd cluster")
+	cmd.Flags().IntVar(&grpcProxyCacheSize, "cache-size", cache.DefaultMaxEntries, "maximum number of serializable range responses cached by the proxy")
+	cmd.Flags().DurationVar(&grpcProxyCacheTTL, "cache-ttl", 0, "how long a cached range response may be served (0 to never expire); the cache can be cleared with a POST to "+grpcproxy.PathProxyCacheClear+" on the metrics-addr listener")
+	cmd.Flags().IntVar(&grpcProxyMaxWatchersPerBroadcast, "max-watchers-per-broadcast", 0, "maximum number of client watchers served by one upstream watch; once exceeded, new watchers on the same range get an upstream watch on a dedicated stream (0 for no limit)")
+	cmd.Flags().Float64Var(&grpcProxyRateLimit.QPS, "rate-limit-qps", 0, "KV, watch and lease requests per second allowed for each client, identified by TLS common name or IP address (0 to disable); the rate limits can be updated with a PUT to "+grpcproxy.PathProxyRateLimit+" on the metrics-addr listener")
+	cmd.Flags().IntVar(&grpcProxyRateLimit.Burst, "rate-limit-burst", 0, "KV, watch and lease requests each client may issue at once (0 defaults to rate-limit-qps)")
+	cmd.Flags().Float64Var(&grpcProxyRateLimit.GlobalQPS, "rate-limit-global-qps", 0, "KV, watch and lease requests per second allowed for all clients together (0 to disable)")
+	cmd.Flags().IntVar(&grpcProxyRateLimit.GlobalBurst, "rate-limit-global-burst", 0, "KV, watch and lease requests all clients together may issue at once (0 defaults to rate-limit-global-qps)")
+	cmd.Flags().Float64Var(&grpcProxyLeaseKeepAliveJitter, "lease-keepalive-jitter", grpcproxy.DefaultLeaseKeepAliveJitter, "fraction of the renewal interval by which the coalesced lease renewals are randomly moved, in [0, 1)")
+	cmd.Flags().Float64Var(&grpcProxyLeaseKeepAliveFallback, "lease-keepalive-fallback", grpcproxy.DefaultLeaseKeepAliveFallback, "fraction of its TTL below which a lease not renewed on the shared upstream stream is renewed directly, in [0, 1) (0 to disable)")
 	cmd.Flags().StringVar(&grpcProxyNamespace, "namespace", "", "string to prefix to all keys for namespacing requests")
+	cmd.Flags().StringVar(&grpcProxyNamespaceMapFile, "namespace-map-file", "", "JSON file mapping the TLS common name or URI SAN of each client to the key namespace its KV, watch and lease requests are confined to; clients not in the map are rejected unless it sets a default namespace")
+	cmd.Flags().DurationVar(&grpcProxyNamespaceMapReloadInterval, "namespace-map-reload-interval", 10*time.Second, "how often the namespace-map-file is checked for changes and reloaded")
 	cmd.Flags().BoolVar(&grpcProxyEnablePprof, "enable-pprof", false, `Enable runtime profiling data via HTTP server. Address is at client URL + "/debug/pprof/"`)
 	cmd.Flags().StringVar(&grpcProxyDataDir, "data-dir", "default.proxy", "Data directory for persistent data")
 	cmd.Flags().IntVar(&grpcMaxCallSendMsgSize, "max-send-bytes", defaultGRPCMaxCallSendMsgSize, "message send limits in bytes (default value is 1.5 MiB)")
@@ -249,8 +288,33 @@ func startGRPCProxy(cmd *cobra.Command, args []string) {
 		lg.Info("stop listening gRPC proxy client requests", zap.String("address", grpcProxyListenAddr))
 	}()
 
+	rl, err := grpcproxy.NewRateLimiter(grpcProxyRateLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if grpcProxyLeaseKeepAliveJitter < 0 || grpcProxyLeaseKeepAliveJitter >= 1 {
+		log.Fatalf("--lease-keepalive-jitter must be in [0, 1), got %v", grpcProxyLeaseKeepAliveJitter)
+	}
+	if grpcProxyLeaseKeepAliveFallback < 0 || grpcProxyLeaseKeepAliveFallback >= 1 {
+		log.Fatalf("--lease-keepalive-fallback must be in [0, 1), got %v", grpcProxyLeaseKeepAliveFallback)
+	}
+
+	var ns *grpcproxy.Namespacer
+	if grpcProxyNamespaceMapFile != "" {
+		nsCfg, nerr := grpcproxy.LoadNamespaceConfig(grpcProxyNamespaceMapFile)
+		if nerr != nil {
+			log.Fatal(nerr)
+		}
+		if ns, nerr = grpcproxy.NewNamespacer(nsCfg); nerr != nil {
+			log.Fatal(nerr)
+		}
+	}
+
 	client := mustNewClient(lg)
-	grpcServer := newGRPCProxyServer(lg, client)
+	grpcServer, kvp := newGRPCProxyServer(lg, client, rl, ns)
+	if ns != nil {
+		go ns.WatchConfigFile(client.Ctx(), lg, grpcProxyNamespaceMapFile, grpcProxyNamespaceMapReloadInterval)
+	}
 
 	errc := make(chan error, 3)
 
@@ -273,12 +337,15 @@ func startGRPCProxy(cmd *cobra.Command, args []string) {
 	// Create it after gRPC/cmux serving goroutines have started
 	proxyClient := newProxyHealthClient(lg, tlsInfo)
 
+	uc := grpcproxy.NewUpstreamHealthChecker(client)
+	go uc.Run(client.Ctx(), upstreamHealthCheckInterval)
+
 	httpClient := mustNewHTTPClient()
-	srvhttp := mustHTTPServer(lg, tlsInfo, httpClient, client, proxyClient)
+	srvhttp := mustHTTPServer(lg, tlsInfo, httpClient, client, proxyClient, uc)
 
 	startServe(errc, func() error { return srvhttp.Serve(httpl) })
 
-	maybeServeMetrics(lg, tlsInfo, httpClient, client, proxyClient)
+	maybeServeMetrics(lg, tlsInfo, httpClient, client, proxyClient, kvp, rl, uc)
 
 	lg.Info("started gRPC proxy", zap.String("address", grpcProxyListenAddr))
 
@@ -294,6 +361,10 @@ func checkArgs() {
 		fmt.Fprintln(os.Stderr, fmt.Errorf("invalid resolver-ttl %d", grpcProxyResolverTTL))
 		os.Exit(1)
 	}
+	if grpcProxyResolverPrefix == "" && grpcProxyResolverWeight > 0 {
+		fmt.Fprintln(os.Stderr, fmt.Errorf("invalid resolver-prefix %q", grpcProxyResolverPrefix))
+		os.Exit(1)
+	}
 	if grpcProxyResolverPrefix == "" && grpcProxyResolverTTL > 0 {
 		fmt.Fprintln(os.Stderr, fmt.Errorf("invalid resolver-prefix %q", grpcProxyResolverPrefix))
 		os.Exit(1)
@@ -464,10 +535,12 @@ func mustListenCMux(lg *zap.Logger, tlsinfo *transport.TLSInfo) cmux.CMux {
 	return cmux.New(l)
 }
 
-func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
+func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client, rl *grpcproxy.RateLimiter, ns *grpcproxy.Namespacer) (*grpc.Server, pb.KVServer) {
 	if grpcProxyEnableOrdering {
-		vf := ordering.NewOrderViolationSwitchEndpointClosure(client)
-		client.KV = ordering.NewKV(client.KV, vf)
+		client.KV = ordering.NewKVWithEndpointSwitch(client)
+		if err := ordering.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+			lg.Fatal("failed to register the ordering metrics", zap.Error(err))
+		}
 		lg.Info("waiting for linearized read from cluster to recover ordering")
 		for {
 			_, err := client.KV.Get(context.TODO(), "_", clientv3.WithKeysOnly())
@@ -489,13 +562,20 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 		client.KV, _, _ = leasing.NewKV(client, grpcProxyLeasing)
 	}
 
-	kvp, _ := grpcproxy.NewKvProxy(client)
-	watchp, _ := grpcproxy.NewWatchProxy(client.Ctx(), lg, client)
+	kvp, _ := grpcproxy.NewKvProxy(client, grpcproxy.WithCacheSize(grpcProxyCacheSize), grpcproxy.WithCacheTTL(grpcProxyCacheTTL))
+	watchp, _ := grpcproxy.NewWatchProxy(client.Ctx(), lg, client, grpcproxy.WithMaxBroadcastWatchers(grpcProxyMaxWatchersPerBroadcast))
 	if grpcProxyResolverPrefix != "" {
-		grpcproxy.Register(lg, client, grpcProxyResolverPrefix, grpcProxyAdvertiseClientURL, grpcProxyResolverTTL)
+		opts := []grpcproxy.RegisterOption{grpcproxy.WithRegisterBackoff(grpcProxyResolverBackoffBase, grpcProxyResolverBackoffMax)}
+		if grpcProxyResolverHealthCheck {
+			opts = append(opts, grpcproxy.WithRegisterHealthCheck(grpcproxy.RangeHealthCheck(client), 0))
+		}
+		grpcproxy.Register(lg, client, grpcProxyResolverPrefix, grpcProxyAdvertiseClientURL, grpcProxyResolverTTL, grpcProxyResolverWeight, opts...)
 	}
 	clusterp, _ := grpcproxy.NewClusterProxy(lg, client, grpcProxyAdvertiseClientURL, grpcProxyResolverPrefix)
-	leasep, _ := grpcproxy.NewLeaseProxy(client.Ctx(), client)
+	leasep, _ := grpcproxy.NewLeaseProxy(client.Ctx(), client,
+		grpcproxy.WithLeaseKeepAliveJitter(grpcProxyLeaseKeepAliveJitter),
+		grpcproxy.WithLeaseKeepAliveFallback(grpcProxyLeaseKeepAliveFallback),
+	)
 
 	mainp := grpcproxy.NewMaintenanceProxy(client)
 	authp := grpcproxy.NewAuthProxy(client)
@@ -504,12 +584,21 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 
 	serverMetrics := grpc_prometheus.NewServerMetrics()
 	prometheus.MustRegister(serverMetrics)
+	if err := clientv3.RegisterEndpointHealthMetrics(prometheus.DefaultRegisterer); err != nil {
+		lg.Fatal("failed to register the endpoint health metrics", zap.Error(err))
+	}
 
 	grpcChainStreamList := []grpc.StreamServerInterceptor{
 		serverMetrics.StreamServerInterceptor(),
+		rl.StreamServerInterceptor(),
 	}
 	grpcChainUnaryList := []grpc.UnaryServerInterceptor{
 		serverMetrics.UnaryServerInterceptor(),
+		rl.UnaryServerInterceptor(),
+	}
+	if ns != nil {
+		grpcChainStreamList = append(grpcChainStreamList, ns.StreamServerInterceptor())
+		grpcChainUnaryList = append(grpcChainUnaryList, ns.UnaryServerInterceptor())
 	}
 	if grpcProxyEnableLogging {
 		grpcChainStreamList = append(grpcChainStreamList,
@@ -550,7 +639,7 @@ func newGRPCProxyServer(lg *zap.Logger, client *clientv3.Client) *grpc.Server {
 	v3electionpb.RegisterElectionServer(server, electionp)
 	v3lockpb.RegisterLockServer(server, lockp)
 
-	return server
+	return server, kvp
 }
 
 func mustMatchHTTPListener(m cmux.CMux, tlsinfo *transport.TLSInfo) net.Listener {
@@ -560,13 +649,14 @@ func mustMatchHTTPListener(m cmux.CMux, tlsinfo *transport.TLSInfo) net.Listener
 	return m.Match(cmux.Any())
 }
 
-func mustHTTPServer(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *http.Client, c *clientv3.Client, proxyClient *clientv3.Client) *http.Server {
+func mustHTTPServer(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *http.Client, c *clientv3.Client, proxyClient *clientv3.Client, uc *grpcproxy.UpstreamHealthChecker) *http.Server {
 	httpmux := http.NewServeMux()
 	httpmux.HandleFunc("/", http.NotFound)
 	grpcproxy.HandleMetrics(httpmux, httpClient, c.Endpoints())
 	grpcproxy.HandleHealth(lg, httpmux, c)
 	grpcproxy.HandleProxyMetrics(httpmux)
 	grpcproxy.HandleProxyHealth(lg, httpmux, proxyClient)
+	grpcproxy.HandleUpstreamHealth(httpmux, uc)
 	if grpcProxyEnablePprof {
 		for p, h := range debugutil.PProfHandlers() {
 			httpmux.Handle(p, h)
@@ -595,7 +685,7 @@ func mustHTTPServer(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *http
 	return srvhttp
 }
 
-func maybeServeMetrics(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *http.Client, c *clientv3.Client, proxyClient *clientv3.Client) {
+func maybeServeMetrics(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *http.Client, c *clientv3.Client, proxyClient *clientv3.Client, kvp pb.KVServer, rl *grpcproxy.RateLimiter, uc *grpcproxy.UpstreamHealthChecker) {
 	if len(grpcProxyMetricsListenAddr) == 0 {
 		return
 	}
@@ -606,6 +696,9 @@ func maybeServeMetrics(lg *zap.Logger, tlsinfo *transport.TLSInfo, httpClient *h
 		grpcproxy.HandleHealth(lg, mux, c)
 		grpcproxy.HandleProxyMetrics(mux)
 		grpcproxy.HandleProxyHealth(lg, mux, proxyClient)
+		grpcproxy.HandleUpstreamHealth(mux, uc)
+		grpcproxy.HandleClearCache(lg, mux, kvp)
+		grpcproxy.HandleRateLimit(lg, mux, rl)
 		lg.Info("gRPC proxy server metrics URL serving")
 		herr := http.Serve(mhttpl, mux)
 		if herr != nil {
@@ -45,6 +45,8 @@ var (
     --watch-progress-notify-interval) must be specified as integer nanoseconds
     in the JSON/YAML file (e.g. 600000000000 for 10m); human-readable strings
     such as "10m" are only accepted on the command line.
+    On SIGHUP, the file is read again and its max-snapshots and max-wals are
+    applied to the running server, purging the files beyond the new limits.
 
   etcd gateway
     Run the stateless pass-through etcd TCP connection forwarding proxy.
@@ -106,6 +108,8 @@ Member:
     Enable to set socket option SO_REUSEADDR on listeners allowing binding to an address in TIME_WAIT state.
   --enable-grpc-gateway
     Enable GRPC gateway.
+  --enable-grpc-zstd-compression 'false'
+    Enable the zstd compression codec for client gRPC calls.
   --raft-read-timeout '` + rafthttp.DefaultConnReadTimeout.String() + `'
     Read timeout set on each rafthttp connection
   --raft-write-timeout '` + rafthttp.DefaultConnWriteTimeout.String() + `'
@@ -243,6 +247,8 @@ Auth:
 Profiling and Monitoring:
   --enable-pprof 'false'
     Enable runtime profiling data via HTTP server. Address is at client URL + "/debug/pprof/"
+  --enable-watch-debug 'false'
+    Enable raising the verbosity of the watch logs of a range hash at runtime via HTTP server. Address is at client URL + "/debug/watch/ranges"
   --metrics 'basic'
     Set level of detail for exported metrics, specify 'extensive' to include server side grpc histogram metrics.
   --listen-metrics-urls ''
@@ -287,6 +293,14 @@ Features:
     Skip verification of SAN field in client certificate for peer connections.
   --watch-progress-notify-interval '10m'
     Duration of periodical watch progress notification.
+  --watch-old-revision-threshold 0
+    Number of revisions a new watcher may have to catch up on before a warning is returned with its creation (0 to disable).
+  --watch-old-revision-reject 'false'
+    Reject the creation of watchers beyond --watch-old-revision-threshold instead of warning about it.
+  --watch-value-selector-max-cost 100
+    Maximum cost of the value selector of a watcher, counting its operators, path segments and comparisons. Requires the WatchValueSelector feature gate.
+  --watch-sync-fairness 1
+    Ratio of the time left to the live delivery of watch events to the time spent catching up unsynced watchers, while they are catching up. Higher values favor live watchers.
   --warning-apply-duration '100ms'
     Warning is generated if requests take more than this duration.
   --bootstrap-defrag-threshold-megabytes
@@ -297,6 +311,20 @@ Features:
     Sets the sleep interval between each compaction batch.
   --downgrade-check-time
     Duration of time between two downgrade status checks.
+  --clock-skew-warning-threshold '1s'
+    Maximum clock skew between members above which the leader logs a warning.
+  --auto-maintenance-policy ''
+    Policy of the engine that compacts and defragments the member under quota pressure ('conservative' or 'aggressive'). Empty disables it.
+  --auto-maintenance-compaction-threshold 0
+    Fraction of the backend quota above which the database size triggers a compaction. 0 uses the default of the policy.
+  --auto-maintenance-compaction-retention 0
+    Number of revisions kept by an auto maintenance compaction. 0 uses the default of the policy.
+  --auto-maintenance-defrag-threshold 0
+    Fraction of the database that must be free space to trigger a defragmentation. 0 uses the default of the policy.
+  --auto-maintenance-window ''
+    Cron-like spec ('minute hour day-of-month month day-of-week') of the window in which auto maintenance may defragment. Empty allows it at any time.
+  --auto-maintenance-check-interval 0
+    Duration between two checks of the auto maintenance engine. 0 uses the default of the policy.
   --snapshot-catchup-entries
     Number of entries for a slow follower to catch up after compacting the raft storage entries.
 
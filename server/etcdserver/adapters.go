@@ -16,14 +16,21 @@ package etcdserver
 
 import (
 	"context"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/membershippb"
 	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	serverversion "go.etcd.io/etcd/server/v3/etcdserver/version"
+	"go.etcd.io/etcd/server/v3/storage"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/raft/v3"
 )
 
 // serverVersionAdapter implements the interface Server defined in package
@@ -83,3 +90,43 @@ func (s *serverVersionAdapter) UpdateStorageVersion(target semver.Version) error
 	defer tx.Unlock()
 	return schema.UnsafeMigrate(s.lg, tx, s.r.storage, target)
 }
+
+// autoMaintenanceAdapter implements the interface Server defined in package
+// go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance.
+type autoMaintenanceAdapter struct {
+	*EtcdServer
+}
+
+func (s *autoMaintenanceAdapter) MaintenanceStatus() automaintenance.Status {
+	txn := s.KV().Read(mvcc.ConcurrentReadTxMode, traceutil.TODO())
+	rev, compactRev := txn.Rev(), txn.FirstRev()
+	txn.End()
+
+	quota := s.Cfg.QuotaBackendBytes
+	if quota == 0 {
+		quota = storage.DefaultQuotaBytes
+	}
+	var alarms []pb.AlarmType
+	for _, a := range s.Alarms() {
+		alarms = append(alarms, a.Alarm)
+	}
+	voting := s.cluster.VotingMembers()
+	ids := make([]types.ID, 0, len(voting))
+	for _, m := range voting {
+		ids = append(ids, m.ID)
+	}
+	be := s.Backend()
+	return automaintenance.Status{
+		MemberID:               s.MemberID(),
+		IsLeader:               s.isLeader(),
+		HasLeader:              s.Leader() != types.ID(raft.None),
+		VotingMemberIDs:        ids,
+		ConnectedVotingMembers: numConnectedSince(s.r.transport, time.Now().Add(-HealthInterval), s.MemberID(), voting),
+		Alarms:                 alarms,
+		DBSize:                 be.Size(),
+		DBSizeInUse:            be.SizeInUse(),
+		Quota:                  quota,
+		Revision:               rev,
+		CompactRevision:        compactRev,
+	}
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+const (
+	ActionCompact = "compact"
+	ActionDefrag  = "defrag"
+
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+	ResultSkipped   = "skipped"
+
+	// PathHistory is the path of the endpoint that reports the history of
+	// the engine.
+	PathHistory = "/auto-maintenance/history"
+
+	maxHistory = 100
+)
+
+// Status is the state of the member the engine decides on.
+type Status struct {
+	MemberID types.ID
+	IsLeader bool
+	// HasLeader is whether the member knows of a leader.
+	HasLeader bool
+	// VotingMemberIDs are the IDs of the voting members of the cluster.
+	VotingMemberIDs []types.ID
+	// ConnectedVotingMembers is the number of voting members, including the
+	// local one, the member has been connected to for a health interval.
+	ConnectedVotingMembers int
+	Alarms                 []pb.AlarmType
+
+	DBSize      int64
+	DBSizeInUse int64
+	// Quota is the backend quota in bytes, zero or negative if disabled.
+	Quota int64
+
+	Revision        int64
+	CompactRevision int64
+}
+
+// Server is the member the engine maintains.
+type Server interface {
+	MaintenanceStatus() Status
+	Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error)
+	Defragment() error
+}
+
+// Record is an entry of the history of the engine.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	Result string    `json:"result"`
+	// Reason is why the action was needed, and why it was skipped if it was.
+	Reason string `json:"reason"`
+	// Revision is the revision compacted to.
+	Revision     int64         `json:"revision,omitempty"`
+	DBSizeBefore int64         `json:"db_size_before"`
+	DBSizeAfter  int64         `json:"db_size_after,omitempty"`
+	Took         time.Duration `json:"took,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Engine compacts and defragments the storage of a member under quota
+// pressure.
+//
+// Only the leader compacts, once the database size crosses the compaction
+// threshold of the quota or the NOSPACE alarm is raised. Only members that
+// are not the leader defragment, inside the maintenance window and during
+// their turn. No action is taken while a CORRUPT alarm is raised or while
+// losing one more voting member would lose quorum; defragmenting also
+// requires a leader.
+type Engine struct {
+	lg     *zap.Logger
+	cfg    Config
+	window *Window
+	s      Server
+	clock  clockwork.Clock
+
+	mu      sync.RWMutex
+	history []Record
+	// lastSkip is the reason of the last skip of each action, so that an
+	// action that stays blocked is recorded once.
+	lastSkip map[string]string
+}
+
+// New returns an engine for the given config, which must enable it.
+func New(lg *zap.Logger, cfg Config, s Server) (*Engine, error) {
+	return newEngine(lg, cfg, s, clockwork.NewRealClock())
+}
+
+func newEngine(lg *zap.Logger, cfg Config, s Server, clock clockwork.Clock) (*Engine, error) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	cfg, err := cfg.complete()
+	if err != nil {
+		return nil, err
+	}
+	w, err := ParseWindow(cfg.Window)
+	if err != nil {
+		return nil, err
+	}
+	return &Engine{
+		lg:       lg,
+		cfg:      cfg,
+		window:   w,
+		s:        s,
+		clock:    clock,
+		lastSkip: make(map[string]string),
+	}, nil
+}
+
+// CheckInterval returns the interval at which Check should be called.
+func (e *Engine) CheckInterval() time.Duration { return e.cfg.CheckInterval }
+
+// Check decides on and runs the actions the member needs.
+func (e *Engine) Check(ctx context.Context) {
+	st := e.s.MaintenanceStatus()
+	e.checkCompaction(ctx, st)
+	e.checkDefrag(st)
+}
+
+func (e *Engine) checkCompaction(ctx context.Context, st Status) {
+	if !st.IsLeader {
+		return
+	}
+	var reason string
+	switch {
+	case slices.Contains(st.Alarms, pb.AlarmType_NOSPACE):
+		reason = "NOSPACE alarm is raised"
+	case st.Quota > 0 && float64(st.DBSize) >= e.cfg.CompactionThreshold*float64(st.Quota):
+		reason = fmt.Sprintf("database size %d is at least %v of quota %d", st.DBSize, e.cfg.CompactionThreshold, st.Quota)
+	default:
+		e.clearSkip(ActionCompact)
+		return
+	}
+	rev := st.Revision - e.cfg.CompactionRetention
+	if rev <= st.CompactRevision {
+		e.clearSkip(ActionCompact)
+		return
+	}
+	if blocker := unsafeReason(st); blocker != "" {
+		e.skip(ActionCompact, reason, blocker, st)
+		return
+	}
+	e.clearSkip(ActionCompact)
+
+	start := e.clock.Now()
+	_, err := e.s.Compact(ctx, &pb.CompactionRequest{Revision: rev})
+	e.record(Record{
+		Time:         start,
+		Action:       ActionCompact,
+		Reason:       reason,
+		Revision:     rev,
+		DBSizeBefore: st.DBSize,
+		Took:         e.clock.Since(start),
+	}, err)
+}
+
+func (e *Engine) checkDefrag(st Status) {
+	if st.DBSize <= 0 {
+		return
+	}
+	free := float64(st.DBSize-st.DBSizeInUse) / float64(st.DBSize)
+	if free < e.cfg.DefragThreshold {
+		e.clearSkip(ActionDefrag)
+		return
+	}
+	now := e.clock.Now()
+	if !e.window.Contains(now) || !e.isTurn(st, now) {
+		return
+	}
+	reason := fmt.Sprintf("%.0f%% of the database is free, at least %v", free*100, e.cfg.DefragThreshold)
+	blocker := unsafeReason(st)
+	switch {
+	case st.IsLeader:
+		blocker = "member is the leader"
+	case !st.HasLeader:
+		blocker = "cluster has no leader"
+	}
+	if blocker != "" {
+		e.skip(ActionDefrag, reason, blocker, st)
+		return
+	}
+	e.clearSkip(ActionDefrag)
+
+	err := e.s.Defragment()
+	e.record(Record{
+		Time:         now,
+		Action:       ActionDefrag,
+		Reason:       reason,
+		DBSizeBefore: st.DBSize,
+		DBSizeAfter:  e.s.MaintenanceStatus().DBSize,
+		Took:         e.clock.Since(now),
+	}, err)
+}
+
+// isTurn returns whether it is the turn of the member to defragment. Voting
+// members take turns of one check interval in the order of their IDs, so
+// that they never defragment at the same time. Learners do not count
+// towards quorum and may defragment at any time.
+func (e *Engine) isTurn(st Status, now time.Time) bool {
+	ids := slices.Clone(st.VotingMemberIDs)
+	slices.Sort(ids)
+	i := slices.Index(ids, st.MemberID)
+	if i < 0 {
+		return true
+	}
+	return int(now.UnixNano()/int64(e.cfg.CheckInterval)%int64(len(ids))) == i
+}
+
+// unsafeReason returns why the cluster is not healthy enough for the engine
+// to act, empty if it is.
+func unsafeReason(st Status) string {
+	if slices.Contains(st.Alarms, pb.AlarmType_CORRUPT) {
+		return "CORRUPT alarm is raised"
+	}
+	if n := len(st.VotingMemberIDs); n > 1 && st.ConnectedVotingMembers-1 < n/2+1 {
+		return fmt.Sprintf("quorum is at risk: %d of %d voting members are connected", st.ConnectedVotingMembers, n)
+	}
+	return ""
+}
+
+func (e *Engine) skip(action, reason, blocker string, st Status) {
+	e.mu.Lock()
+	if e.lastSkip[action] == blocker {
+		e.mu.Unlock()
+		return
+	}
+	e.lastSkip[action] = blocker
+	e.mu.Unlock()
+
+	e.lg.Warn(
+		"skipped auto maintenance action",
+		zap.String("action", action),
+		zap.String("reason", reason),
+		zap.String("blocked-by", blocker),
+	)
+	actionsTotal.WithLabelValues(action, ResultSkipped).Inc()
+	e.append(Record{
+		Time:         e.clock.Now(),
+		Action:       action,
+		Result:       ResultSkipped,
+		Reason:       reason + "; skipped: " + blocker,
+		DBSizeBefore: st.DBSize,
+	})
+}
+
+func (e *Engine) clearSkip(action string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.lastSkip, action)
+}
+
+func (e *Engine) record(r Record, err error) {
+	fields := []zap.Field{
+		zap.String("action", r.Action),
+		zap.String("reason", r.Reason),
+		zap.Int64("db-size-before", r.DBSizeBefore),
+		zap.Duration("took", r.Took),
+	}
+	if r.Action == ActionCompact {
+		fields = append(fields, zap.Int64("revision", r.Revision))
+	} else {
+		fields = append(fields, zap.Int64("db-size-after", r.DBSizeAfter))
+	}
+	if err != nil {
+		r.Result = ResultFailed
+		r.Error = err.Error()
+		e.lg.Warn("auto maintenance action failed", append(fields, zap.Error(err))...)
+	} else {
+		r.Result = ResultSucceeded
+		e.lg.Info("auto maintenance action succeeded", fields...)
+	}
+	actionsTotal.WithLabelValues(r.Action, r.Result).Inc()
+	e.append(r)
+}
+
+func (e *Engine) append(r Record) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.history) == maxHistory {
+		e.history = slices.Delete(e.history, 0, 1)
+	}
+	e.history = append(e.history, r)
+}
+
+// History returns the last actions of the engine, oldest first.
+func (e *Engine) History() []Record {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return slices.Clone(e.history)
+}
+
+// HandleHistory registers a handler on '/auto-maintenance/history' that
+// reports the history of the engine.
+func HandleHistory(mux *http.ServeMux, e *Engine) {
+	mux.HandleFunc(PathHistory, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(e.History())
+	})
+}
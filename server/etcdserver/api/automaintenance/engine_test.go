@@ -0,0 +1,381 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+type fakeServer struct {
+	st         Status
+	compacted  []int64
+	defrags    int
+	compactErr error
+}
+
+func (s *fakeServer) MaintenanceStatus() Status { return s.st }
+
+func (s *fakeServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error) {
+	if s.compactErr != nil {
+		return nil, s.compactErr
+	}
+	s.compacted = append(s.compacted, r.Revision)
+	s.st.CompactRevision = r.Revision
+	return &pb.CompactionResponse{}, nil
+}
+
+func (s *fakeServer) Defragment() error {
+	s.defrags++
+	s.st.DBSize = s.st.DBSizeInUse
+	return nil
+}
+
+// healthyStatus is the status of member 1 of a healthy 3 member cluster,
+// with a database far from the quota and without free space.
+func healthyStatus() Status {
+	return Status{
+		MemberID:               1,
+		HasLeader:              true,
+		VotingMemberIDs:        []types.ID{3, 1, 2},
+		ConnectedVotingMembers: 3,
+		DBSize:                 100,
+		DBSizeInUse:            100,
+		Quota:                  1000,
+		Revision:               5000,
+		CompactRevision:        1,
+	}
+}
+
+// leaderStatus is healthyStatus with member 1 as the leader.
+func leaderStatus() Status {
+	st := healthyStatus()
+	st.IsLeader = true
+	return st
+}
+
+// fragmentedStatus is healthyStatus with half of the database free.
+func fragmentedStatus() Status {
+	st := healthyStatus()
+	st.DBSizeInUse = 50
+	return st
+}
+
+func TestEngineCompaction(t *testing.T) {
+	tcs := []struct {
+		name    string
+		st      func(st *Status)
+		wantRev int64
+		want    string
+	}{
+		{
+			name: "below threshold",
+			st:   func(st *Status) {},
+		},
+		{
+			name:    "above threshold",
+			st:      func(st *Status) { st.DBSize = 800 },
+			wantRev: 4000,
+			want:    ResultSucceeded,
+		},
+		{
+			name:    "NOSPACE alarm",
+			st:      func(st *Status) { st.Alarms = []pb.AlarmType{pb.AlarmType_NOSPACE} },
+			wantRev: 4000,
+			want:    ResultSucceeded,
+		},
+		{
+			name: "disabled quota",
+			st:   func(st *Status) { st.DBSize, st.Quota = 800, 0 },
+		},
+		{
+			name: "not the leader",
+			st:   func(st *Status) { st.DBSize, st.IsLeader = 800, false },
+		},
+		{
+			name: "already compacted",
+			st:   func(st *Status) { st.DBSize, st.CompactRevision = 800, 4000 },
+		},
+		{
+			name: "quorum at risk",
+			st:   func(st *Status) { st.DBSize, st.ConnectedVotingMembers = 800, 2 },
+			want: ResultSkipped,
+		},
+		{
+			name: "CORRUPT alarm",
+			st: func(st *Status) {
+				st.DBSize = 800
+				st.Alarms = []pb.AlarmType{pb.AlarmType_CORRUPT}
+			},
+			want: ResultSkipped,
+		},
+		{
+			name: "single member",
+			st: func(st *Status) {
+				st.DBSize = 800
+				st.VotingMemberIDs, st.ConnectedVotingMembers = []types.ID{1}, 1
+			},
+			wantRev: 4000,
+			want:    ResultSucceeded,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &fakeServer{st: leaderStatus()}
+			tc.st(&s.st)
+			s.st.DBSizeInUse = s.st.DBSize
+			e, err := newEngine(zaptest.NewLogger(t), Config{Policy: PolicyAggressive}, s, clockwork.NewFakeClock())
+			require.NoError(t, err)
+
+			e.Check(t.Context())
+			h := e.History()
+			if tc.want == "" {
+				assert.Empty(t, h)
+				assert.Empty(t, s.compacted)
+				return
+			}
+			require.Len(t, h, 1)
+			assert.Equal(t, ActionCompact, h[0].Action)
+			assert.Equal(t, tc.want, h[0].Result)
+			if tc.wantRev != 0 {
+				assert.Equal(t, []int64{tc.wantRev}, s.compacted)
+				assert.Equal(t, tc.wantRev, h[0].Revision)
+			} else {
+				assert.Empty(t, s.compacted)
+			}
+		})
+	}
+}
+
+func TestEngineCompactionFailure(t *testing.T) {
+	s := &fakeServer{st: leaderStatus(), compactErr: errors.New("injected")}
+	s.st.DBSize, s.st.DBSizeInUse, s.st.Revision = 900, 900, 20000
+	e, err := newEngine(zaptest.NewLogger(t), Config{Policy: PolicyConservative}, s, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	e.Check(t.Context())
+	h := e.History()
+	require.Len(t, h, 1)
+	assert.Equal(t, ResultFailed, h[0].Result)
+	assert.Equal(t, "injected", h[0].Error)
+	assert.Equal(t, int64(10000), h[0].Revision)
+}
+
+func TestEngineDefrag(t *testing.T) {
+	// Member 1 is the first of the sorted voting members, so it is its turn
+	// during the first check interval of every three.
+	turn := time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		name   string
+		st     func(st *Status)
+		now    time.Time
+		window string
+		want   string
+	}{
+		{
+			name: "not fragmented",
+			st:   func(st *Status) { st.DBSizeInUse = 80 },
+			now:  turn,
+		},
+		{
+			name: "fragmented",
+			st:   func(st *Status) {},
+			now:  turn,
+			want: ResultSucceeded,
+		},
+		{
+			name: "not the turn of the member",
+			st:   func(st *Status) {},
+			now:  turn.Add(time.Minute),
+		},
+		{
+			name: "learner defragments in any turn",
+			st:   func(st *Status) { st.MemberID = 4 },
+			now:  turn.Add(time.Minute),
+			want: ResultSucceeded,
+		},
+		{
+			name:   "inside the window",
+			st:     func(st *Status) {},
+			now:    turn,
+			window: "* 3 * * *",
+			want:   ResultSucceeded,
+		},
+		{
+			name:   "outside the window",
+			st:     func(st *Status) {},
+			now:    turn,
+			window: "* 4 * * *",
+		},
+		{
+			name: "leader",
+			st:   func(st *Status) { st.IsLeader = true },
+			now:  turn,
+			want: ResultSkipped,
+		},
+		{
+			name: "no leader",
+			st:   func(st *Status) { st.HasLeader = false },
+			now:  turn,
+			want: ResultSkipped,
+		},
+		{
+			name: "quorum at risk",
+			st:   func(st *Status) { st.ConnectedVotingMembers = 2 },
+			now:  turn,
+			want: ResultSkipped,
+		},
+		{
+			name: "CORRUPT alarm",
+			st:   func(st *Status) { st.Alarms = []pb.AlarmType{pb.AlarmType_CORRUPT} },
+			now:  turn,
+			want: ResultSkipped,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &fakeServer{st: fragmentedStatus()}
+			tc.st(&s.st)
+			cfg := Config{Policy: PolicyAggressive, Window: tc.window}
+			e, err := newEngine(zaptest.NewLogger(t), cfg, s, clockwork.NewFakeClockAt(tc.now))
+			require.NoError(t, err)
+
+			e.Check(t.Context())
+			h := e.History()
+			if tc.want == "" {
+				assert.Empty(t, h)
+				assert.Zero(t, s.defrags)
+				return
+			}
+			require.Len(t, h, 1)
+			assert.Equal(t, ActionDefrag, h[0].Action)
+			assert.Equal(t, tc.want, h[0].Result)
+			if tc.want == ResultSucceeded {
+				assert.Equal(t, 1, s.defrags)
+				assert.Equal(t, int64(100), h[0].DBSizeBefore)
+				assert.Equal(t, int64(50), h[0].DBSizeAfter)
+			} else {
+				assert.Zero(t, s.defrags)
+			}
+		})
+	}
+}
+
+func TestEngineRecordsSkipOnce(t *testing.T) {
+	s := &fakeServer{st: leaderStatus()}
+	s.st.DBSize, s.st.DBSizeInUse, s.st.ConnectedVotingMembers = 800, 800, 2
+	e, err := newEngine(zaptest.NewLogger(t), Config{Policy: PolicyAggressive}, s, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	e.Check(t.Context())
+	e.Check(t.Context())
+	require.Len(t, e.History(), 1)
+
+	// Once the member reconnects, the compaction runs and a later blocker
+	// is recorded again.
+	s.st.ConnectedVotingMembers = 3
+	e.Check(t.Context())
+	s.st.Revision = 6000
+	s.st.ConnectedVotingMembers = 2
+	e.Check(t.Context())
+
+	h := e.History()
+	require.Len(t, h, 3)
+	assert.Equal(t, []string{ResultSkipped, ResultSucceeded, ResultSkipped}, []string{h[0].Result, h[1].Result, h[2].Result})
+}
+
+func TestEngineHistoryIsBounded(t *testing.T) {
+	s := &fakeServer{st: leaderStatus()}
+	s.st.DBSize, s.st.DBSizeInUse = 800, 800
+	e, err := newEngine(zaptest.NewLogger(t), Config{Policy: PolicyAggressive}, s, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	for i := 0; i < maxHistory+10; i++ {
+		s.st.Revision += 10
+		e.Check(t.Context())
+	}
+	h := e.History()
+	require.Len(t, h, maxHistory)
+	assert.Equal(t, int64(5000+11*10-1000), h[0].Revision)
+}
+
+func TestHandleHistory(t *testing.T) {
+	s := &fakeServer{st: leaderStatus()}
+	s.st.DBSize, s.st.DBSizeInUse = 800, 800
+	e, err := newEngine(zaptest.NewLogger(t), Config{Policy: PolicyAggressive}, s, clockwork.NewFakeClock())
+	require.NoError(t, err)
+	e.Check(t.Context())
+
+	mux := http.NewServeMux()
+	HandleHistory(mux, e)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + PathHistory)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var h []Record
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&h))
+	require.Len(t, h, 1)
+	assert.Equal(t, ActionCompact, h[0].Action)
+	assert.Equal(t, int64(4000), h[0].Revision)
+
+	resp, err = http.Post(srv.URL+PathHistory, "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tcs := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "disabled", cfg: Config{}},
+		{name: "conservative", cfg: Config{Policy: PolicyConservative}},
+		{name: "aggressive with overrides", cfg: Config{Policy: PolicyAggressive, CompactionThreshold: 0.9, DefragThreshold: 0.1, Window: "0 2 * * *"}},
+		{name: "unknown policy", cfg: Config{Policy: "lazy"}, wantErr: true},
+		{name: "compaction threshold above 1", cfg: Config{Policy: PolicyAggressive, CompactionThreshold: 1.5}, wantErr: true},
+		{name: "negative defrag threshold", cfg: Config{Policy: PolicyAggressive, DefragThreshold: -0.1}, wantErr: true},
+		{name: "negative retention", cfg: Config{Policy: PolicyAggressive, CompactionRetention: -1}, wantErr: true},
+		{name: "invalid window", cfg: Config{Policy: PolicyAggressive, Window: "* * *"}, wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
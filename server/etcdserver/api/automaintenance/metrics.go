@@ -0,0 +1,30 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var actionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "etcd",
+	Subsystem: "server",
+	Name:      "auto_maintenance_actions_total",
+	Help:      "The total number of actions of the auto maintenance engine by action and result.",
+}, []string{"action", "result"})
+
+func init() {
+	prometheus.MustRegister(actionsTotal)
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	PolicyConservative = "conservative"
+	PolicyAggressive   = "aggressive"
+)
+
+// Config configures the engine. Zero values of the thresholds, the
+// retention and the check interval are taken from the policy.
+type Config struct {
+	// Policy is the name of the policy, empty if the engine is disabled.
+	Policy string
+	// CompactionThreshold is the fraction of the backend quota above which
+	// the database size triggers a compaction.
+	CompactionThreshold float64
+	// CompactionRetention is the number of revisions kept by a compaction.
+	CompactionRetention int64
+	// DefragThreshold is the fraction of the database that must be free
+	// space to trigger a defragmentation.
+	DefragThreshold float64
+	// Window is the cron-like spec of the window in which defragmentation
+	// may run, empty to allow it at any time. See ParseWindow.
+	Window string
+	// CheckInterval is the interval between two checks of the engine. It is
+	// also the length of the turn each voting member gets to defragment, so
+	// that voting members never defragment at the same time.
+	CheckInterval time.Duration
+}
+
+var policies = map[string]Config{
+	PolicyConservative: {
+		Policy:              PolicyConservative,
+		CompactionThreshold: 0.8,
+		CompactionRetention: 10000,
+		DefragThreshold:     0.5,
+		CheckInterval:       5 * time.Minute,
+	},
+	PolicyAggressive: {
+		Policy:              PolicyAggressive,
+		CompactionThreshold: 0.5,
+		CompactionRetention: 1000,
+		DefragThreshold:     0.3,
+		CheckInterval:       time.Minute,
+	},
+}
+
+// Enabled returns whether the config enables the engine.
+func (c Config) Enabled() bool { return c.Policy != "" }
+
+// complete returns the config with its zero values taken from the policy.
+func (c Config) complete() (Config, error) {
+	p, ok := policies[c.Policy]
+	if !ok {
+		return c, fmt.Errorf("unknown auto maintenance policy %q (expected %q or %q)", c.Policy, PolicyConservative, PolicyAggressive)
+	}
+	if c.CompactionThreshold == 0 {
+		c.CompactionThreshold = p.CompactionThreshold
+	}
+	if c.CompactionRetention == 0 {
+		c.CompactionRetention = p.CompactionRetention
+	}
+	if c.DefragThreshold == 0 {
+		c.DefragThreshold = p.DefragThreshold
+	}
+	if c.CheckInterval == 0 {
+		c.CheckInterval = p.CheckInterval
+	}
+	return c, nil
+}
+
+// Validate returns an error if the config of an enabled engine is invalid.
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	c, err := c.complete()
+	if err != nil {
+		return err
+	}
+	if c.CompactionThreshold <= 0 || c.CompactionThreshold > 1 {
+		return fmt.Errorf("auto maintenance compaction threshold must be in (0, 1], got %v", c.CompactionThreshold)
+	}
+	if c.CompactionRetention < 0 {
+		return fmt.Errorf("auto maintenance compaction retention must not be negative, got %d", c.CompactionRetention)
+	}
+	if c.DefragThreshold <= 0 || c.DefragThreshold >= 1 {
+		return fmt.Errorf("auto maintenance defrag threshold must be in (0, 1), got %v", c.DefragThreshold)
+	}
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("auto maintenance check interval must not be negative, got %v", c.CheckInterval)
+	}
+	_, err = ParseWindow(c.Window)
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Window is a maintenance window given by a cron-like spec of five
+// space-separated fields: minute, hour, day of month, month and day of week.
+// Each field is "*" or a comma-separated list of values, ranges ("a-b") and
+// steps ("*/s", "a-b/s"). Day of week 0 and 7 are both Sunday. As in cron,
+// when both the day of month and the day of week are restricted, a time
+// matches if either of them does.
+//
+// For example, "* 2-4 * * 6,0" is open from 02:00 to 04:59 on weekends.
+type Window struct {
+	spec string
+
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+var windowFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseWindow parses a window spec. An empty spec returns a nil Window,
+// which is always open.
+func ParseWindow(spec string) (*Window, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(windowFields) {
+		return nil, fmt.Errorf("invalid maintenance window %q: expected %d fields, got %d", spec, len(windowFields), len(fields))
+	}
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := parseWindowField(f, windowFields[i].min, windowFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %q: %s: %w", spec, windowFields[i].name, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be given as either 0 or 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+	return &Window{
+		spec:   spec,
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+func parseWindowField(f string, lo, hi int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(f, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], s
+		}
+		from, to := lo, hi
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err1, err2 error
+			from, err1 = strconv.Atoi(a)
+			to, err2 = strconv.Atoi(b)
+			if err1 != nil || err2 != nil || from > to {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			v, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rng)
+			}
+			from, to = v, v
+			if step != 1 {
+				to = hi
+			}
+		}
+		if from < lo || to > hi {
+			return 0, fmt.Errorf("%q is out of range [%d, %d]", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// Contains returns whether the window is open at the given time, in the
+// location of the time.
+func (w *Window) Contains(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	if w.minute&(1<<uint(t.Minute())) == 0 ||
+		w.hour&(1<<uint(t.Hour())) == 0 ||
+		w.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domOK := w.dom&(1<<uint(t.Day())) != 0
+	dowOK := w.dow&(1<<uint(t.Weekday())) != 0
+	if w.domAny || w.dowAny {
+		return domOK && dowOK
+	}
+	return domOK || dowOK
+}
+
+func (w *Window) String() string {
+	if w == nil {
+		return ""
+	}
+	return w.spec
+}
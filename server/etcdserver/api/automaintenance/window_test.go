@@ -0,0 +1,114 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automaintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindowContains(t *testing.T) {
+	// 2026-03-07 is a Saturday.
+	sat := func(hour, minute int) time.Time { return time.Date(2026, 3, 7, hour, minute, 0, 0, time.UTC) }
+	mon := func(hour, minute int) time.Time { return time.Date(2026, 3, 9, hour, minute, 0, 0, time.UTC) }
+
+	tcs := []struct {
+		name string
+		spec string
+		in   []time.Time
+		out  []time.Time
+	}{
+		{
+			name: "empty spec is always open",
+			spec: "",
+			in:   []time.Time{sat(0, 0), mon(13, 37)},
+		},
+		{
+			name: "every minute",
+			spec: "* * * * *",
+			in:   []time.Time{sat(0, 0), mon(23, 59)},
+		},
+		{
+			name: "hour range on weekends",
+			spec: "* 2-4 * * 6,0",
+			in:   []time.Time{sat(2, 0), sat(4, 59)},
+			out:  []time.Time{sat(1, 59), sat(5, 0), mon(3, 0)},
+		},
+		{
+			name: "sunday as 7",
+			spec: "* * * * 7",
+			in:   []time.Time{time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)},
+			out:  []time.Time{sat(12, 0)},
+		},
+		{
+			name: "steps",
+			spec: "*/15 */6 * * *",
+			in:   []time.Time{sat(0, 0), sat(6, 45), mon(18, 30)},
+			out:  []time.Time{sat(0, 1), sat(7, 0)},
+		},
+		{
+			name: "value with step",
+			spec: "30/10 * * * *",
+			in:   []time.Time{sat(0, 30), sat(0, 50)},
+			out:  []time.Time{sat(0, 0), sat(0, 35)},
+		},
+		{
+			name: "day of month or day of week",
+			spec: "* * 9 * 6",
+			in:   []time.Time{sat(10, 0), mon(10, 0)},
+			out:  []time.Time{time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "day of month and any day of week",
+			spec: "* * 9 3 *",
+			in:   []time.Time{mon(10, 0)},
+			out:  []time.Time{sat(10, 0), time.Date(2026, 4, 9, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			w, err := ParseWindow(tc.spec)
+			require.NoError(t, err)
+			for _, tm := range tc.in {
+				assert.Truef(t, w.Contains(tm), "expected %v to be in window %q", tm, tc.spec)
+			}
+			for _, tm := range tc.out {
+				assert.Falsef(t, w.Contains(tm), "expected %v not to be in window %q", tm, tc.spec)
+			}
+		})
+	}
+}
+
+func TestParseWindowInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1,,2 * * * *",
+	} {
+		_, err := ParseWindow(spec)
+		assert.Errorf(t, err, "expected an error for %q", spec)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+var (
+	// clockSampleInterval is the interval between two samples of the clock
+	// of a peer.
+	clockSampleInterval = 5 * time.Second
+	// clockSampleTimeout bounds the round trip of a clock sample.
+	clockSampleTimeout = 5 * time.Second
+)
+
+// clockSampleWindow is the number of recent samples of the clock of a peer
+// its skew is estimated from.
+const clockSampleWindow = 10
+
+// clockHandler serves the current time of the local member, in nanoseconds
+// since the Unix epoch, for peers to estimate its clock skew.
+type clockHandler struct {
+	clock func() time.Time
+}
+
+func newClockHandler(clock func() time.Time) http.Handler {
+	if clock == nil {
+		clock = time.Now
+	}
+	return &clockHandler{clock: clock}
+}
+
+func (h *clockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	io.WriteString(w, strconv.FormatInt(h.clock().UnixNano(), 10))
+}
+
+// clockSkews periodically samples the clocks of the peers, and estimates
+// the offset of each of them from the local clock. A nil *clockSkews
+// samples nothing.
+type clockSkews struct {
+	lg    *zap.Logger
+	rt    http.RoundTripper
+	clock func() time.Time
+
+	mu    sync.Mutex
+	peers map[types.ID]*peerClock
+}
+
+// peerClock holds the recent clock offset samples of a peer.
+type peerClock struct {
+	stopc chan struct{}
+	// samples is a ring buffer of the last clockSampleWindow offsets
+	samples []time.Duration
+	next    int
+}
+
+func newClockSkews(lg *zap.Logger, rt http.RoundTripper, clock func() time.Time) *clockSkews {
+	return &clockSkews{
+		lg:    lg,
+		rt:    rt,
+		clock: clock,
+		peers: make(map[types.ID]*peerClock),
+	}
+}
+
+// add starts sampling the clock of the peer with the given id, replacing its
+// previous samples if any.
+func (c *clockSkews) add(id types.ID, us []string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(id)
+	pc := &peerClock{stopc: make(chan struct{})}
+	c.peers[id] = pc
+	go c.run(id, pc, us)
+}
+
+func (c *clockSkews) remove(id types.ID) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(id)
+}
+
+func (c *clockSkews) removeAll() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.peers {
+		c.removeLocked(id)
+	}
+}
+
+func (c *clockSkews) removeLocked(id types.ID) {
+	if pc, ok := c.peers[id]; ok {
+		close(pc.stopc)
+		delete(c.peers, id)
+	}
+}
+
+func (c *clockSkews) run(id types.ID, pc *peerClock, us []string) {
+	ticker := time.NewTicker(clockSampleInterval)
+	defer ticker.Stop()
+	for {
+		offset, err := c.sample(pc.stopc, us)
+		if err == nil {
+			c.mu.Lock()
+			if len(pc.samples) < clockSampleWindow {
+				pc.samples = append(pc.samples, offset)
+			} else {
+				pc.samples[pc.next] = offset
+			}
+			pc.next = (pc.next + 1) % clockSampleWindow
+			c.mu.Unlock()
+		} else if c.lg != nil {
+			c.lg.Debug("failed to sample the clock of peer", zap.String("remote-peer-id", id.String()), zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-pc.stopc:
+			return
+		}
+	}
+}
+
+// sample returns the offset of the clock of the peer from the local clock.
+// The time of the peer is assumed to be read halfway through the round trip.
+func (c *clockSkews) sample(stopc <-chan struct{}, us []string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clockSampleTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopc:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var lastErr error
+	for _, u := range us {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+ClockPrefix, nil)
+		if err != nil {
+			return 0, err
+		}
+		start := c.clock()
+		resp, err := c.rt.RoundTrip(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		b, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+		resp.Body.Close()
+		end := c.clock()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			lastErr = fmt.Errorf("unexpected http status %s", http.StatusText(resp.StatusCode))
+			continue
+		}
+		ns, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		midpoint := start.Add(end.Sub(start) / 2)
+		return time.Unix(0, ns).Sub(midpoint), nil
+	}
+	return 0, lastErr
+}
+
+// offsets returns the estimated offset of the clock of each peer from the
+// local clock, which is the median of its recent samples. Peers whose clock
+// has not been sampled yet are omitted.
+func (c *clockSkews) offsets() map[types.ID]time.Duration {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	offsets := make(map[types.ID]time.Duration, len(c.peers))
+	for id, pc := range c.peers {
+		if len(pc.samples) == 0 {
+			continue
+		}
+		offsets[id] = medianDuration(pc.samples)
+	}
+	return offsets
+}
+
+// medianDuration returns the median of the given non-empty durations.
+func medianDuration(ds []time.Duration) time.Duration {
+	sorted := slices.Clone(ds)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return sorted[mid-1] + (sorted[mid]-sorted[mid-1])/2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+// clockRoundTripper serves the given remote time to clock sample requests.
+type clockRoundTripper struct {
+	remote time.Time
+}
+
+func (t *clockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := strconv.FormatInt(t.remote.UnixNano(), 10)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestClockHandler(t *testing.T) {
+	now := time.Unix(1000, 500)
+	h := newClockHandler(func() time.Time { return now })
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, ClockPrefix, nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("code = %d, want %d", rw.Code, http.StatusOK)
+	}
+	if got, want := rw.Body.String(), strconv.FormatInt(now.UnixNano(), 10); got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, ClockPrefix, nil))
+	if rw.Code != http.StatusMethodNotAllowed {
+		t.Errorf("code = %d, want %d", rw.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// TestClockSkewsSampleMidpoint ensures the time of the peer is compared to
+// the midpoint of the round trip.
+func TestClockSkewsSampleMidpoint(t *testing.T) {
+	base := time.Unix(1000, 0)
+	// the round trip takes 2s on the local clock, and the peer reads its
+	// clock 3s ahead of the local one halfway through
+	clocks := []time.Time{base, base.Add(2 * time.Second)}
+	clock := func() time.Time {
+		now := clocks[0]
+		clocks = clocks[1:]
+		return now
+	}
+	rt := &clockRoundTripper{remote: base.Add(4 * time.Second)}
+	c := newClockSkews(zaptest.NewLogger(t), rt, clock)
+
+	offset, err := c.sample(make(chan struct{}), []string{"http://localhost:2380"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset != 3*time.Second {
+		t.Errorf("offset = %v, want %v", offset, 3*time.Second)
+	}
+}
+
+func TestClockSkewsOffsets(t *testing.T) {
+	c := newClockSkews(zaptest.NewLogger(t), nil, time.Now)
+	c.peers[types.ID(1)] = &peerClock{samples: []time.Duration{
+		time.Second, time.Second, 10 * time.Second, time.Second, -5 * time.Second,
+	}}
+	c.peers[types.ID(2)] = &peerClock{samples: []time.Duration{time.Second, 3 * time.Second}}
+	c.peers[types.ID(3)] = &peerClock{}
+
+	got := c.offsets()
+	want := map[types.ID]time.Duration{
+		// outliers do not affect the median
+		types.ID(1): time.Second,
+		types.ID(2): 2 * time.Second,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("offsets = %v, want %v", got, want)
+	}
+	for id, offset := range want {
+		if got[id] != offset {
+			t.Errorf("offset of %s = %v, want %v", id, got[id], offset)
+		}
+	}
+}
+
+func TestClockSkewsAddRemove(t *testing.T) {
+	rt := &clockRoundTripper{}
+	now := time.Unix(1000, 0)
+	c := newClockSkews(zaptest.NewLogger(t), rt, func() time.Time { return now })
+	rt.remote = now.Add(time.Second)
+
+	c.add(types.ID(1), []string{"http://localhost:2380"})
+	defer c.removeAll()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if offset, ok := c.offsets()[types.ID(1)]; ok {
+			if offset != time.Second {
+				t.Fatalf("offset = %v, want %v", offset, time.Second)
+			}
+			c.remove(types.ID(1))
+			if _, ok := c.offsets()[types.ID(1)]; ok {
+				t.Fatalf("offset of removed peer is still reported")
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("clock of peer is not sampled")
+}
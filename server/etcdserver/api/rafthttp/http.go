@@ -60,6 +60,7 @@ var (
 	ProbingPrefix      = path.Join(RaftPrefix, "probing")
 	RaftStreamPrefix   = path.Join(RaftPrefix, "stream")
 	RaftSnapshotPrefix = path.Join(RaftPrefix, "snapshot")
+	ClockPrefix        = path.Join(RaftPrefix, "clock")
 
 	errIncompatibleVersion = errors.New("incompatible version")
 	ErrClusterIDMismatch   = errors.New("cluster ID mismatch")
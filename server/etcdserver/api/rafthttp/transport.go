@@ -85,6 +85,10 @@ type Transporter interface {
 	ActiveSince(id types.ID) time.Time
 	// ActivePeers returns the number of active peers.
 	ActivePeers() int
+	// ClockSkews returns the estimated offset of the clock of each peer
+	// from the local clock. Peers whose clock has not been sampled yet
+	// are omitted.
+	ClockSkews() map[types.ID]time.Duration
 	// Stop closes the connections and stops the transporter.
 	Stop()
 }
@@ -119,6 +123,10 @@ type Transport struct {
 	// When an error is received from ErrorC, user should stop raft state
 	// machine and thus stop the Transport.
 	ErrorC chan error
+	// Clock returns the local time served to peers sampling the clock of
+	// the local member, and used to sample the clocks of peers.
+	// If nil, time.Now is used.
+	Clock func() time.Time
 
 	streamRt   http.RoundTripper // roundTripper used by streams
 	pipelineRt http.RoundTripper // roundTripper used by pipelines
@@ -129,6 +137,8 @@ type Transport struct {
 
 	pipelineProber probing.Prober
 	streamProber   probing.Prober
+
+	clockSkews *clockSkews
 }
 
 func (t *Transport) Start() error {
@@ -145,6 +155,10 @@ func (t *Transport) Start() error {
 	t.peers = make(map[types.ID]Peer)
 	t.pipelineProber = probing.NewProber(t.pipelineRt)
 	t.streamProber = probing.NewProber(t.streamRt)
+	if t.Clock == nil {
+		t.Clock = time.Now
+	}
+	t.clockSkews = newClockSkews(t.Logger, t.pipelineRt, t.Clock)
 
 	// If client didn't provide dial retry frequency, use the default
 	// (100ms backoff between attempts to create a new stream),
@@ -164,6 +178,7 @@ func (t *Transport) Handler() http.Handler {
 	mux.Handle(RaftStreamPrefix+"/", streamHandler)
 	mux.Handle(RaftSnapshotPrefix, snapHandler)
 	mux.Handle(ProbingPrefix, probing.NewHandler())
+	mux.Handle(ClockPrefix, newClockHandler(t.Clock))
 	return mux
 }
 
@@ -220,6 +235,7 @@ func (t *Transport) Stop() {
 	}
 	t.pipelineProber.RemoveAll()
 	t.streamProber.RemoveAll()
+	t.clockSkews.removeAll()
 	if tr, ok := t.streamRt.(*http.Transport); ok {
 		tr.CloseIdleConnections()
 	}
@@ -313,6 +329,7 @@ func (t *Transport) AddPeer(id types.ID, us []string) {
 	t.peers[id] = startPeer(t, urls, id, fs)
 	addPeerToProber(t.Logger, t.pipelineProber, id.String(), us, RoundTripperNameSnapshot, rttSec)
 	addPeerToProber(t.Logger, t.streamProber, id.String(), us, RoundTripperNameRaftMessage, rttSec)
+	t.clockSkews.add(id, us)
 
 	if t.Logger != nil {
 		t.Logger.Info(
@@ -348,6 +365,7 @@ func (t *Transport) removePeer(id types.ID) {
 		delete(t.LeaderStats.Followers, id.String())
 		t.pipelineProber.Remove(id.String())
 		t.streamProber.Remove(id.String())
+		t.clockSkews.remove(id)
 	}
 
 	if t.Logger != nil {
@@ -386,6 +404,7 @@ func (t *Transport) UpdatePeer(id types.ID, us []string) {
 	addPeerToProber(t.Logger, t.pipelineProber, id.String(), us, RoundTripperNameSnapshot, rttSec)
 	t.streamProber.Remove(id.String())
 	addPeerToProber(t.Logger, t.streamProber, id.String(), us, RoundTripperNameRaftMessage, rttSec)
+	t.clockSkews.add(id, us)
 
 	if t.Logger != nil {
 		t.Logger.Info(
@@ -452,3 +471,7 @@ func (t *Transport) ActivePeers() (cnt int) {
 	}
 	return cnt
 }
+
+func (t *Transport) ClockSkews() map[types.ID]time.Duration {
+	return t.clockSkews.offsets()
+}
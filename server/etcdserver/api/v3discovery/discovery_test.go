@@ -744,6 +744,10 @@ func (fkv *fakeBaseKV) Put(ctx context.Context, key string, val string, opts ...
 	return nil, nil
 }
 
+func (fkv *fakeBaseKV) PutWithConfirm(ctx context.Context, key string, val string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	return nil, nil
+}
+
 func (fkv *fakeBaseKV) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
 	return nil, nil
 }
@@ -779,6 +783,10 @@ func (fw *fakeBaseWatcher) RequestProgress(ctx context.Context) error {
 	return nil
 }
 
+func (fw *fakeBaseWatcher) CancelAll(ctx context.Context) error {
+	return nil
+}
+
 func (fw *fakeBaseWatcher) Close() error {
 	return nil
 }
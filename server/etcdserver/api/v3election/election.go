@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	epb "go.etcd.io/etcd/server/v3/etcdserver/api/v3election/v3electionpb"
@@ -127,7 +128,13 @@ func (es *electionServer) session(ctx context.Context, lease int64) (*concurrenc
 		es.c,
 		concurrency.WithLease(clientv3.LeaseID(lease)),
 		concurrency.WithContext(ctx),
+		// Observe and Leader do not use the lease of their session
+		concurrency.WithLeaseValidation(lease != -1),
 	)
+	if errors.Is(err, concurrency.ErrLeaseNotFound) {
+		// keep the error the server returns for requests on a missing lease
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -16,7 +16,9 @@ package v3lock
 
 import (
 	"context"
+	"errors"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
@@ -38,6 +40,10 @@ func (ls *lockServer) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3l
 		concurrency.WithLease(clientv3.LeaseID(req.Lease)),
 		concurrency.WithContext(ctx),
 	)
+	if errors.Is(err, concurrency.ErrLeaseNotFound) {
+		// keep the error the server returns for requests on a missing lease
+		return nil, rpctypes.ErrGRPCLeaseNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
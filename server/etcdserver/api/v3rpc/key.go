@@ -163,6 +163,10 @@ func checkRangeRequest(r *pb.RangeRequest) error {
 		return rpctypes.ErrGRPCInvalidSortOption
 	}
 
+	if len(r.Cursor) != 0 && !txn.IsDefaultOrdering(r.SortTarget, r.SortOrder) {
+		return rpctypes.ErrGRPCInvalidRangeCursor
+	}
+
 	return nil
 }
 
@@ -176,6 +180,9 @@ func checkRangeStreamRequest(r *pb.RangeRequest) error {
 	if txn.HasRevisionFilters(r) {
 		return status.Errorf(codes.Unimplemented, "RangeStream does not support revision filters")
 	}
+	if len(r.Cursor) != 0 {
+		return status.Errorf(codes.Unimplemented, "RangeStream does not support cursors")
+	}
 	return nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/lease"
 )
@@ -59,6 +60,15 @@ func (ls *LeaseServer) LeaseRevoke(ctx context.Context, rr *pb.LeaseRevokeReques
 	return resp, nil
 }
 
+func (ls *LeaseServer) LeaseTransfer(ctx context.Context, tr *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	resp, err := ls.le.LeaseTransfer(ctx, tr)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ls.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 func (ls *LeaseServer) LeaseTimeToLive(ctx context.Context, rr *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
 	resp, err := ls.le.LeaseTimeToLive(ctx, rr)
 	if err != nil && !errors.Is(err, lease.ErrLeaseNotFound) {
@@ -90,6 +100,69 @@ func (ls *LeaseServer) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesReques
 	return resp, nil
 }
 
+// maxLeaseWatchEvents is the maximum number of events sent in a lease watch response.
+const maxLeaseWatchEvents = 100
+
+func (ls *LeaseServer) LeaseWatch(r *pb.LeaseWatchRequest, stream pb.Lease_LeaseWatchServer) error {
+	evc, cancel, err := ls.le.LeaseWatch(stream.Context(), r)
+	if err != nil {
+		return togRPCError(err)
+	}
+	defer cancel()
+
+	// the first response has no event, and tells the client the watch is created
+	if err := ls.sendLeaseWatchResponse(stream, &pb.LeaseWatchResponse{}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case ev, ok := <-evc:
+			if !ok {
+				// the watcher fell behind and was dropped by the lessor
+				return rpctypes.ErrGRPCLeaseWatchCanceled
+			}
+			resp := &pb.LeaseWatchResponse{Events: []*pb.LeaseEvent{toLeaseEvent(ev)}}
+			// batch the events that are already pending
+			for len(resp.Events) < maxLeaseWatchEvents && len(evc) > 0 {
+				resp.Events = append(resp.Events, toLeaseEvent(<-evc))
+			}
+			if err := ls.sendLeaseWatchResponse(stream, resp); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (ls *LeaseServer) sendLeaseWatchResponse(stream pb.Lease_LeaseWatchServer, resp *pb.LeaseWatchResponse) error {
+	resp.Header = &pb.ResponseHeader{}
+	ls.hdr.fill(resp.Header)
+	err := stream.Send(resp)
+	if err != nil {
+		if isClientCtxErr(stream.Context().Err(), err) {
+			ls.lg.Debug("failed to send lease watch response to gRPC stream", zap.Error(err))
+		} else {
+			ls.lg.Warn("failed to send lease watch response to gRPC stream", zap.Error(err))
+			streamFailures.WithLabelValues("send", "lease-watch").Inc()
+		}
+	}
+	return err
+}
+
+func toLeaseEvent(ev lease.Event) *pb.LeaseEvent {
+	e := &pb.LeaseEvent{ID: int64(ev.ID), TTL: ev.TTL}
+	switch ev.Type {
+	case lease.EventGranted:
+		e.Type = pb.LeaseEvent_GRANTED
+	case lease.EventRevoked:
+		e.Type = pb.LeaseEvent_REVOKED
+	case lease.EventExpired:
+		e.Type = pb.LeaseEvent_EXPIRED
+	}
+	return e
+}
+
 func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err error) {
 	errc := make(chan error, 1)
 	go func() {
@@ -133,8 +206,9 @@ func (ls *LeaseServer) leaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) erro
 		resp := &pb.LeaseKeepAliveResponse{ID: req.ID, Header: &pb.ResponseHeader{}}
 		ls.hdr.fill(resp.Header)
 
-		ttl, err := ls.le.LeaseRenew(stream.Context(), lease.LeaseID(req.ID))
-		if errors.Is(err, lease.ErrLeaseNotFound) {
+		ttl, err := ls.le.LeaseRenew(stream.Context(), lease.LeaseID(req.ID), req.Epoch)
+		// A lease transferred to another holder looks expired to the old one.
+		if errors.Is(err, lease.ErrLeaseNotFound) || errors.Is(err, lease.ErrLeaseTransferred) {
 			err = nil
 			ttl = 0
 		}
@@ -19,6 +19,7 @@ import (
 	"crypto/sha256"
 	errorspkg "errors"
 	"io"
+	"runtime"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -68,16 +69,27 @@ type LeaderTransferrer interface {
 
 type ClusterStatusGetter interface {
 	IsLearner() bool
+	MaxClockSkew() time.Duration
 }
 
 type ConfigGetter interface {
 	Config() config.ServerConfig
 }
 
+type ValueEncodingGetter interface {
+	ValueEncodingStatus() mvcc.ValueEncodingStatus
+}
+
+type DiskUsageGetter interface {
+	DiskUsage() (etcdserver.DiskUsage, error)
+}
+
 type maintenanceServer struct {
 	lg     *zap.Logger
 	rg     apply.RaftStatusGetter
 	hasher mvcc.HashStorage
+	rv     mvcc.ReadView
+	ve     ValueEncodingGetter
 	bg     BackendGetter
 	defrag Defrager
 	a      Alarmer
@@ -87,6 +99,7 @@ type maintenanceServer struct {
 	d      Downgrader
 	vs     serverversion.Server
 	cg     ConfigGetter
+	du     DiskUsageGetter
 
 	healthNotifier notifier
 
@@ -99,6 +112,8 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		lg:             s.Cfg.Logger,
 		rg:             s,
 		hasher:         s.KV().HashStorage(),
+		rv:             s.KV(),
+		ve:             s.KV(),
 		bg:             s,
 		defrag:         s,
 		a:              s,
@@ -109,6 +124,7 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		vs:             etcdserver.NewServerVersionAdapter(s),
 		healthNotifier: healthNotifier,
 		cg:             s,
+		du:             s,
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
@@ -264,6 +280,8 @@ func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (
 	resp := &pb.StatusResponse{
 		Header:           hdr,
 		Version:          version.Version,
+		GoVersion:        runtime.Version(),
+		GitSha:           version.GitSHA,
 		Leader:           uint64(ms.rg.Leader()),
 		RaftIndex:        ms.rg.CommittedIndex(),
 		RaftAppliedIndex: ms.rg.AppliedIndex(),
@@ -273,7 +291,13 @@ func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (
 		IsLearner:        ms.cs.IsLearner(),
 		DbSizeQuota:      ms.cg.Config().QuotaBackendBytes,
 		DowngradeInfo:    &pb.DowngradeInfo{Enabled: false},
+		// the compact revision is -1 before the first compaction
+		CompactRevision: max(ms.rv.FirstRev(), 0),
+		MaxClockSkew:    int64(ms.cs.MaxClockSkew()),
 	}
+	ves := ms.ve.ValueEncodingStatus()
+	resp.ValueEncoding = ves.Encoding.String()
+	resp.ValueEncodingMigrationPercent = ves.MigrationPercent
 	if resp.DbSizeQuota == 0 {
 		resp.DbSizeQuota = storage.DefaultQuotaBytes
 	}
@@ -292,6 +316,20 @@ func (ms *maintenanceServer) Status(ctx context.Context, ar *pb.StatusRequest) (
 	for _, a := range ms.a.Alarms() {
 		resp.Errors = append(resp.Errors, a.String())
 	}
+	if ms.du != nil {
+		du, err := ms.du.DiskUsage()
+		if err != nil {
+			ms.lg.Warn("failed to get disk usage", zap.Error(err))
+		} else {
+			resp.DiskUsage = &pb.DiskUsage{
+				WalFileCount:      du.WAL.Files,
+				WalSize:           du.WAL.Bytes,
+				OldestWalIndex:    du.WAL.OldestIndex,
+				SnapshotFileCount: du.SnapshotFiles,
+				SnapshotSize:      du.SnapshotBytes,
+			}
+		}
+	}
 	return resp, nil
 }
 
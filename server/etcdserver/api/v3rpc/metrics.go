@@ -15,6 +15,8 @@
 package v3rpc
 
 import (
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -100,8 +102,53 @@ var (
 			Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
 		},
 	)
+
+	watchOldRevisionTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "server",
+			Name:      "watch_old_revision_total",
+			Help:      "The total number of watch create requests with a start revision older than the watch old revision threshold, by action (warn or reject).",
+		},
+		[]string{"action"},
+	)
+
+	watchSubstreams = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watch_substreams",
+			Help:      "The number of watchers on the watch streams of each client connection, by peer address.",
+		},
+		[]string{"peer"},
+	)
+)
+
+var (
+	// watchSubstreamsByPeer counts the watchers of each peer, so that the
+	// series of a peer is deleted once it has no watcher left.
+	watchSubstreamsByPeer   = make(map[string]int)
+	watchSubstreamsByPeerMu sync.Mutex
 )
 
+// addWatchSubstreams adds n, which may be negative, to the number of
+// watchers of peer.
+func addWatchSubstreams(peer string, n int) {
+	if n == 0 {
+		return
+	}
+	watchSubstreamsByPeerMu.Lock()
+	defer watchSubstreamsByPeerMu.Unlock()
+	count := watchSubstreamsByPeer[peer] + n
+	if count <= 0 {
+		delete(watchSubstreamsByPeer, peer)
+		watchSubstreams.DeleteLabelValues(peer)
+		return
+	}
+	watchSubstreamsByPeer[peer] = count
+	watchSubstreams.WithLabelValues(peer).Set(float64(count))
+}
+
 func init() {
 	prometheus.MustRegister(sentBytes)
 	prometheus.MustRegister(receivedBytes)
@@ -111,4 +158,6 @@ func init() {
 	prometheus.MustRegister(watchSendLoopWatchStreamDurationPerEvent)
 	prometheus.MustRegister(watchSendLoopControlStreamDuration)
 	prometheus.MustRegister(watchSendLoopProgressDuration)
+	prometheus.MustRegister(watchOldRevisionTotal)
+	prometheus.MustRegister(watchSubstreams)
 }
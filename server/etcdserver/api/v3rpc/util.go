@@ -61,6 +61,8 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 	errors.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
 	errors.ErrBadLeaderTransferee:        rpctypes.ErrGRPCBadLeaderTransferee,
+	errors.ErrLeaseTransferNotSupported:  rpctypes.ErrGRPCLeaseTransferNotSupported,
+	errors.ErrInvalidRangeCursor:         rpctypes.ErrGRPCInvalidRangeCursor,
 
 	errors.ErrClusterVersionUnavailable:      rpctypes.ErrGRPCClusterVersionUnavailable,
 	errors.ErrWrongDowngradeVersionFormat:    rpctypes.ErrGRPCWrongDowngradeVersionFormat,
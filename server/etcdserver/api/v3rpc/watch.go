@@ -15,16 +15,21 @@
 package v3rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"math/rand"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/protobuf/proto"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -36,11 +41,36 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+	"go.etcd.io/etcd/server/v3/etcdserver/txn"
+	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
 const minWatchProgressInterval = 100 * time.Millisecond
 
+var errWatchValueSelectorDisabled = errors.New("etcdserver: watch value selectors are disabled, see the WatchValueSelector feature gate")
+
+var (
+	errWatchCompareDisabled         = errors.New("etcdserver: watch compares are disabled, see the WatchCompare feature gate")
+	errWatchCompareKey              = errors.New("etcdserver: watch compare must be on the single key of the watcher")
+	errWatchCompareSnapshotFallback = errors.New("etcdserver: watch compare cannot be combined with snapshot fallback")
+)
+
+var (
+	// watchDrainTimeout bounds how long a watch stream waits for its watchers
+	// to catch up when the server shuts down.
+	watchDrainTimeout = time.Second
+	// watchDrainRetryInterval is the interval at which the progress of the
+	// watchers not yet drained is requested again.
+	watchDrainRetryInterval = 50 * time.Millisecond
+)
+
+// ReadNotifier waits for the member to apply all the entries committed by the
+// cluster.
+type ReadNotifier interface {
+	LinearizableReadNotify(ctx context.Context) error
+}
+
 type watchServer struct {
 	lg *zap.Logger
 
@@ -49,9 +79,32 @@ type watchServer struct {
 
 	maxRequestBytes uint
 
+	// oldRevisionThreshold is the number of revisions a new watcher may have
+	// to catch up on before it is warned about, or rejected if
+	// oldRevisionReject is set.
+	oldRevisionThreshold int64
+	oldRevisionReject    bool
+
+	// valueSelectorMaxCost is the maximum cost of the value selector of a
+	// watcher, or 0 if value selectors are disabled.
+	valueSelectorMaxCost int
+	// compareEnabled is set if watchers may be created with a compare.
+	compareEnabled bool
+
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
+	rn        ReadNotifier
+
+	// readTimeout bounds the linearizable read of the watchers created with
+	// linearizable_start.
+	readTimeout time.Duration
+
+	// drainc is closed when the server starts shutting down.
+	drainc <-chan struct{}
+
+	// lastStreamID is the ID of the last stream, identifying streams in logs.
+	lastStreamID atomic.Uint64
 
 	// we want compile errors if new methods are added
 	pb.UnsafeWatchServer
@@ -67,13 +120,25 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 
 		maxRequestBytes: s.Cfg.MaxRequestBytesWithOverhead(),
 
+		oldRevisionThreshold: s.Cfg.WatchOldRevisionThreshold,
+		oldRevisionReject:    s.Cfg.WatchOldRevisionReject,
+
 		sg:        s,
 		watchable: s.Watchable(),
 		ag:        s,
+		rn:        s,
+
+		readTimeout: s.Cfg.ReqTimeout(),
+
+		drainc: s.DrainNotify(),
 	}
 	if srv.lg == nil {
 		srv.lg = zap.NewNop()
 	}
+	if s.FeatureEnabled(features.WatchValueSelector) {
+		srv.valueSelectorMaxCost = s.Cfg.WatchValueSelectorMaxCost
+	}
+	srv.compareEnabled = s.FeatureEnabled(features.WatchCompare)
 	if s.Cfg.WatchProgressNotifyInterval > 0 {
 		if s.Cfg.WatchProgressNotifyInterval < minWatchProgressInterval {
 			srv.lg.Warn(
@@ -129,22 +194,40 @@ const ctrlStreamBufLen = 16
 // It also forwards control message like watch created and canceled.
 type serverWatchStream struct {
 	lg *zap.Logger
+	// log logs the lifecycle of the watchers of the stream.
+	log *watchLog
 
 	clusterID int64
 	memberID  int64
 
 	maxRequestBytes uint
 
+	oldRevisionThreshold int64
+	oldRevisionReject    bool
+
+	valueSelectorMaxCost int
+	compareEnabled       bool
+
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
 	ag        AuthGetter
+	rn        ReadNotifier
+
+	readTimeout time.Duration
 
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
 
-	// mu protects progress, prevKV, fragment
+	// peer is the address of the client of the stream.
+	peer string
+
+	// mu protects watchers, progress, prevKV, fragment, maxEvents, keysOnly,
+	// versionOnly, snapshotFallback, keepAlive
 	mu sync.RWMutex
+	// records the key ranges of the watch IDs created and not canceled yet,
+	// counted in the substreams of the peer
+	watchers map[mvcc.WatchID]watchRange
 	// tracks the watchID that stream might need to send progress to
 	// TODO: combine progress and prevKV into a single struct?
 	progress map[mvcc.WatchID]bool
@@ -152,38 +235,80 @@ type serverWatchStream struct {
 	prevKV map[mvcc.WatchID]bool
 	// records fragmented watch IDs
 	fragment map[mvcc.WatchID]bool
+	// records the maximum number of events per response of watch IDs
+	maxEvents map[mvcc.WatchID]int64
+	// record watch IDs that only need keys, without values
+	keysOnly map[mvcc.WatchID]bool
+	// record watch IDs that only need the versions of keys
+	versionOnly map[mvcc.WatchID]bool
+	// records the create requests of watch IDs that fall back to a
+	// snapshot of their range when compacted
+	snapshotFallback map[mvcc.WatchID]*pb.WatchCreateRequest
+	// records the keep alive interval of watch IDs that need heartbeats
+	keepAlive map[mvcc.WatchID]time.Duration
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
 
+	// drainc is closed when the server starts shutting down.
+	drainc <-chan struct{}
+	// drainedc is closed by the send loop once the watchers have been sent
+	// their final response, or the drain timed out.
+	drainedc chan struct{}
+
 	// wg waits for the send loop to complete
 	wg sync.WaitGroup
 }
 
 func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 	sws := serverWatchStream{
-		lg: ws.lg,
+		lg:  ws.lg,
+		log: newWatchLog(ws.lg, ws.lastStreamID.Add(1)),
 
 		clusterID: ws.clusterID,
 		memberID:  ws.memberID,
 
 		maxRequestBytes: ws.maxRequestBytes,
 
+		oldRevisionThreshold: ws.oldRevisionThreshold,
+		oldRevisionReject:    ws.oldRevisionReject,
+
+		valueSelectorMaxCost: ws.valueSelectorMaxCost,
+		compareEnabled:       ws.compareEnabled,
+
 		sg:        ws.sg,
 		watchable: ws.watchable,
 		ag:        ws.ag,
+		rn:        ws.rn,
+
+		readTimeout: ws.readTimeout,
 
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
 		// chan for sending control response like watcher created and canceled.
 		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
 
+		peer: ws.streamPeer(stream),
+
+		watchers: make(map[mvcc.WatchID]watchRange),
 		progress: make(map[mvcc.WatchID]bool),
 		prevKV:   make(map[mvcc.WatchID]bool),
 		fragment: make(map[mvcc.WatchID]bool),
+		keysOnly: make(map[mvcc.WatchID]bool),
+
+		versionOnly: make(map[mvcc.WatchID]bool),
+		keepAlive:   make(map[mvcc.WatchID]time.Duration),
+
+		maxEvents: make(map[mvcc.WatchID]int64),
+
+		snapshotFallback: make(map[mvcc.WatchID]*pb.WatchCreateRequest),
 
 		closec: make(chan struct{}),
+
+		drainc:   ws.drainc,
+		drainedc: make(chan struct{}),
 	}
+	sws.log.established(sws.peer, ws.streamUser(stream))
 
 	sws.wg.Add(1)
 	go func() {
@@ -228,12 +353,31 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 		if errors.Is(err, context.Canceled) {
 			err = rpctypes.ErrGRPCWatchCanceled
 		}
+	case <-sws.drainedc:
+		err = rpctypes.ErrGRPCServerClosing
 	}
 
 	sws.close()
 	return err
 }
 
+// streamPeer returns the address of the peer of the stream, if known.
+func (ws *watchServer) streamPeer(stream pb.Watch_WatchServer) string {
+	if p, ok := peer.FromContext(stream.Context()); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// streamUser returns the authenticated user of the stream, if any.
+func (ws *watchServer) streamUser(stream pb.Watch_WatchServer) string {
+	authInfo, err := ws.ag.AuthInfoFromCtx(stream.Context())
+	if err != nil || authInfo == nil {
+		return ""
+	}
+	return authInfo.Username
+}
+
 func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) error {
 	authInfo, err := sws.ag.AuthInfoFromCtx(sws.gRPCStream.Context())
 	if err != nil {
@@ -246,6 +390,82 @@ func (sws *serverWatchStream) isWatchPermitted(wcr *pb.WatchCreateRequest) error
 	return sws.ag.AuthStore().IsRangePermitted(authInfo, wcr.Key, wcr.RangeEnd)
 }
 
+// waitLinearizableStart waits for a linearizable read, so that the store of
+// the member has applied all the revisions committed by the cluster when the
+// watcher is created from its current revision. It blocks the requests of the
+// stream until then, which keeps the responses in order.
+func (sws *serverWatchStream) waitLinearizableStart() error {
+	ctx, cancel := context.WithTimeout(sws.gRPCStream.Context(), sws.readTimeout)
+	defer cancel()
+	return sws.rn.LinearizableReadNotify(ctx)
+}
+
+// startCompare checks the compare of the watcher created by creq, and returns
+// the start revision of the watcher and the result of the compare before it.
+// A watcher starting at the current revision starts right after the revision
+// the compare is evaluated at, so that no flip is missed in between.
+func (sws *serverWatchStream) startCompare(creq *pb.WatchCreateRequest) (int64, bool, error) {
+	cmp := creq.Compare
+	switch {
+	case !sws.compareEnabled:
+		return 0, false, errWatchCompareDisabled
+	case len(creq.RangeEnd) != 0 || len(cmp.RangeEnd) != 0 || !bytes.Equal(creq.Key, cmp.Key):
+		return 0, false, errWatchCompareKey
+	case creq.SnapshotFallback:
+		return 0, false, errWatchCompareSnapshotFallback
+	case creq.StartRevision == 1:
+		// the key does not exist before the first revision
+		return 1, txn.CompareKeyValue(cmp, nil), nil
+	}
+	var rev int64
+	if creq.StartRevision > 1 {
+		rev = creq.StartRevision - 1
+	}
+	r, err := sws.watchable.Range(context.TODO(), creq.Key, nil, mvcc.RangeOptions{Rev: rev})
+	if err != nil {
+		return 0, false, err
+	}
+	var kv *mvccpb.KeyValue
+	if len(r.KVs) != 0 {
+		kv = r.KVs[0]
+	}
+	startRev := creq.StartRevision
+	if startRev <= 0 {
+		startRev = r.Rev + 1
+	}
+	return startRev, txn.CompareKeyValue(cmp, kv), nil
+}
+
+// checkOldRevision returns a warning if the watcher created by creq has more
+// than oldRevisionThreshold revisions to catch up on, or an error if such
+// watchers are rejected.
+func (sws *serverWatchStream) checkOldRevision(creq *pb.WatchCreateRequest) (string, error) {
+	// a compacted start revision cancels the watcher without any catch up
+	if sws.oldRevisionThreshold <= 0 || creq.StartRevision <= 0 || creq.StartRevision < sws.watchable.FirstRev() {
+		return "", nil
+	}
+	rev := sws.watchStream.Rev()
+	catchUp := rev - creq.StartRevision + 1
+	if catchUp <= sws.oldRevisionThreshold {
+		return "", nil
+	}
+	fields := []zap.Field{
+		zap.String("key", string(creq.Key)),
+		zap.Int64("start-revision", creq.StartRevision),
+		zap.Int64("current-revision", rev),
+		zap.Int64("catch-up-revisions", catchUp),
+		zap.Int64("threshold", sws.oldRevisionThreshold),
+	}
+	if sws.oldRevisionReject {
+		watchOldRevisionTotal.WithLabelValues("reject").Inc()
+		sws.lg.Warn("rejected watch with an old start revision", fields...)
+		return "", rpctypes.ErrGRPCWatchStartRevisionTooOld
+	}
+	watchOldRevisionTotal.WithLabelValues("warn").Inc()
+	sws.lg.Warn("created watch with an old start revision", fields...)
+	return fmt.Sprintf("watch start revision %d is %d revisions behind the current revision %d; consider getting the range and watching from its revision instead", creq.StartRevision, catchUp, rev), nil
+}
+
 func (sws *serverWatchStream) recvLoop() error {
 	for {
 		req, err := sws.gRPCStream.Recv()
@@ -262,26 +482,18 @@ func (sws *serverWatchStream) recvLoop() error {
 				break
 			}
 
+			// gofail: var beforeCreateWatch struct{}
 			creq := uv.CreateRequest
+			rangeHash := WatchRangeHash(creq.Key, creq.RangeEnd)
 			if len(creq.Key) == 0 {
 				// \x00 is the smallest key
 				creq.Key = []byte{0}
 			}
 			if creq.StartRevision < 0 {
-				wr := &pb.WatchResponse{
-					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
-					WatchId:      clientv3.InvalidWatchID,
-					Canceled:     true,
-					Created:      true,
-					CancelReason: rpctypes.ErrCompacted.Error(),
-				}
-
-				select {
-				case sws.ctrlStream <- wr:
-					continue
-				case <-sws.closec:
+				if !sws.rejectCreate(rpctypes.ErrCompacted.Error()) {
 					return nil
 				}
+				continue
 			}
 
 			err := sws.isWatchPermitted(creq)
@@ -301,20 +513,47 @@ func (sws *serverWatchStream) recvLoop() error {
 					cancelReason = rpctypes.ErrGRPCPermissionDenied.Error()
 				}
 
-				wr := &pb.WatchResponse{
-					Header:       sws.newResponseHeader(sws.watchStream.Rev()),
-					WatchId:      clientv3.InvalidWatchID,
-					Canceled:     true,
-					Created:      true,
-					CancelReason: cancelReason,
+				if !sws.rejectCreate(cancelReason) {
+					return nil
 				}
+				continue
+			}
 
-				select {
-				case sws.ctrlStream <- wr:
-					continue
-				case <-sws.closec:
+			warning, err := sws.checkOldRevision(creq)
+			if err != nil {
+				if !sws.rejectCreate(rpctypes.ErrorDesc(err)) {
+					return nil
+				}
+				continue
+			}
+
+			filters, err := FiltersFromRequest(creq, sws.valueSelectorMaxCost)
+			if err != nil {
+				if !sws.rejectCreate(err.Error()) {
 					return nil
 				}
+				continue
+			}
+
+			if creq.LinearizableStart && creq.StartRevision <= 0 {
+				if err = sws.waitLinearizableStart(); err != nil {
+					if !sws.rejectCreate(rpctypes.ErrorDesc(togRPCError(err))) {
+						return nil
+					}
+					continue
+				}
+			}
+
+			var condition func(kv *mvccpb.KeyValue) bool
+			var conditionResult bool
+			if cmp := creq.Compare; cmp != nil {
+				if creq.StartRevision, conditionResult, err = sws.startCompare(creq); err != nil {
+					if !sws.rejectCreate(rpctypes.ErrorDesc(togRPCError(err))) {
+						return nil
+					}
+					continue
+				}
+				condition = func(kv *mvccpb.KeyValue) bool { return txn.CompareKeyValue(cmp, kv) }
 			}
 
 			if len(creq.RangeEnd) == 0 {
@@ -327,7 +566,6 @@ func (sws *serverWatchStream) recvLoop() error {
 				creq.RangeEnd = []byte{}
 			}
 
-			filters := FiltersFromRequest(creq)
 			ctx, _ := traceutil.Tracer.Start(sws.gRPCStream.Context(), "watch", trace.WithAttributes(
 				attribute.String("key", string(creq.Key)),
 				attribute.String("range_end", string(creq.RangeEnd)),
@@ -335,10 +573,36 @@ func (sws *serverWatchStream) recvLoop() error {
 				attribute.Bool("progress_notify", creq.ProgressNotify),
 				attribute.Bool("prev_kv", creq.PrevKv),
 				attribute.Bool("fragment", creq.Fragment),
+				attribute.Bool("keys_only", creq.KeysOnly),
+				attribute.Bool("version_only", creq.VersionOnly),
+				attribute.Bool("snapshot_fallback", creq.SnapshotFallback),
+				attribute.Bool("latest_per_key", creq.LatestPerKey),
+				attribute.Bool("value_prefix", len(creq.ValuePrefix) != 0),
+				attribute.Bool("value_selector", creq.ValueSelector != ""),
+				attribute.String("filter_order", creq.FilterOrder.String()),
+				attribute.Int64("sample_every_n", creq.SampleEveryN),
+				attribute.Int64("max_events_per_response", creq.MaxEventsPerResponse),
+				attribute.Int64("compact_warning_margin", creq.CompactWarningMargin),
+				attribute.Int64("keep_alive_interval", creq.KeepAliveInterval),
+				attribute.Bool("linearizable_start", creq.LinearizableStart),
+				attribute.Int64("ack_window", creq.AckWindow),
+				attribute.Bool("collapse_deletes", creq.CollapseDeletes),
+				attribute.Bool("compare", creq.Compare != nil),
 			))
 
-			id, err := sws.watchStream.Watch(ctx, mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, creq.StartRevision, filters...)
+			opts := mvcc.WatchOptions{
+				LatestPerKey:         creq.LatestPerKey,
+				SampleEveryN:         creq.SampleEveryN,
+				CompactWarningMargin: creq.CompactWarningMargin,
+				AckWindow:            creq.AckWindow,
+				CollapseDeletes:      creq.CollapseDeletes,
+				Condition:            condition,
+				ConditionResult:      conditionResult,
+			}
+			id, err := sws.watchStream.WatchWithOptions(ctx, mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, creq.StartRevision, opts, filters...)
 			if err == nil {
+				sws.log.created(id, rangeHash, creq.StartRevision, sws.watchStream.Rev())
+				sws.addWatcher(id, creq.Key, watchRangeEnd(creq.RangeEnd))
 				sws.mu.Lock()
 				if creq.ProgressNotify {
 					sws.progress[id] = true
@@ -349,6 +613,21 @@ func (sws *serverWatchStream) recvLoop() error {
 				if creq.Fragment {
 					sws.fragment[id] = true
 				}
+				if creq.MaxEventsPerResponse > 0 {
+					sws.maxEvents[id] = creq.MaxEventsPerResponse
+				}
+				if creq.KeysOnly {
+					sws.keysOnly[id] = true
+				}
+				if creq.VersionOnly {
+					sws.versionOnly[id] = true
+				}
+				if creq.SnapshotFallback {
+					sws.snapshotFallback[id] = creq
+				}
+				if creq.KeepAliveInterval > 0 {
+					sws.keepAlive[id] = max(time.Duration(creq.KeepAliveInterval)*time.Millisecond, minWatchProgressInterval)
+				}
 				sws.mu.Unlock()
 			} else {
 				id = clientv3.InvalidWatchID
@@ -362,6 +641,9 @@ func (sws *serverWatchStream) recvLoop() error {
 			}
 			if err != nil {
 				wr.CancelReason = err.Error()
+			} else {
+				wr.Warning = warning
+				wr.CompareResult = conditionResult
 			}
 			select {
 			case sws.ctrlStream <- wr:
@@ -371,25 +653,16 @@ func (sws *serverWatchStream) recvLoop() error {
 
 		case *pb.WatchRequest_CancelRequest:
 			if uv.CancelRequest != nil {
-				id := uv.CancelRequest.WatchId
-				err := sws.watchStream.Cancel(mvcc.WatchID(id))
-				if err == nil {
-					wr := &pb.WatchResponse{
-						Header:   sws.newResponseHeader(sws.watchStream.Rev()),
-						WatchId:  id,
-						Canceled: true,
-					}
-					select {
-					case sws.ctrlStream <- wr:
-					case <-sws.closec:
+				if !sws.cancelWatch(mvcc.WatchID(uv.CancelRequest.WatchId)) {
+					return nil
+				}
+			}
+		case *pb.WatchRequest_CancelRangeRequest:
+			if uv.CancelRangeRequest != nil {
+				for _, id := range sws.watchersWithin(uv.CancelRangeRequest.Key, uv.CancelRangeRequest.RangeEnd) {
+					if !sws.cancelWatch(id) {
 						return nil
 					}
-
-					sws.mu.Lock()
-					delete(sws.progress, mvcc.WatchID(id))
-					delete(sws.prevKV, mvcc.WatchID(id))
-					delete(sws.fragment, mvcc.WatchID(id))
-					sws.mu.Unlock()
 				}
 			}
 		case *pb.WatchRequest_ProgressRequest:
@@ -398,6 +671,11 @@ func (sws *serverWatchStream) recvLoop() error {
 				sws.watchStream.RequestProgressAll()
 				sws.mu.Unlock()
 			}
+		case *pb.WatchRequest_AckRequest:
+			if uv.AckRequest != nil {
+				// the watcher may have been canceled since the ack was sent
+				sws.watchStream.Ack(mvcc.WatchID(uv.AckRequest.WatchId), uv.AckRequest.Revision)
+			}
 		default:
 			// we probably should not shutdown the entire stream when
 			// receive an invalid command.
@@ -408,6 +686,24 @@ func (sws *serverWatchStream) recvLoop() error {
 	}
 }
 
+// rejectCreate sends the response canceling a create request before any
+// watcher is created for it. It returns false if the stream is closed.
+func (sws *serverWatchStream) rejectCreate(reason string) bool {
+	wr := &pb.WatchResponse{
+		Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:      clientv3.InvalidWatchID,
+		Canceled:     true,
+		Created:      true,
+		CancelReason: reason,
+	}
+	select {
+	case sws.ctrlStream <- wr:
+		return true
+	case <-sws.closec:
+		return false
+	}
+}
+
 func (sws *serverWatchStream) sendLoop() {
 	// watch ids that are currently active
 	ids := make(map[mvcc.WatchID]struct{})
@@ -417,8 +713,24 @@ func (sws *serverWatchStream) sendLoop() {
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
 
+	// once the server starts shutting down, the progress of every watcher is
+	// requested until it is synced, and the watcher is then canceled with the
+	// revision it has received all the events up to
+	drainc := sws.drainc
+	draining := false
+	var drainTickc, drainTimeoutc <-chan time.Time
+
+	// a heartbeat is sent whenever nothing was sent on the stream for the
+	// smallest keep alive interval of its watchers
+	var keepAliveInterval time.Duration
+	keepAliveTimer := time.NewTimer(time.Hour)
+	keepAliveTimer.Stop()
+	var keepAliveTimerc <-chan time.Time
+	lastSent := time.Now()
+
 	defer func() {
 		progressTicker.Stop()
+		keepAliveTimer.Stop()
 		// drain the chan to clean up pending events
 		for ws := range sws.watchStream.Chan() {
 			mvcc.ReportEventReceived(len(ws.Events))
@@ -437,41 +749,80 @@ func (sws *serverWatchStream) sendLoop() {
 				return
 			}
 
+			if draining && isProgressResponse(wresp) {
+				if _, okID := ids[wresp.WatchID]; okID {
+					if !sws.sendDrainResponse(wresp) {
+						return
+					}
+					delete(ids, wresp.WatchID)
+					if len(ids) == 0 {
+						close(sws.drainedc)
+						return
+					}
+					continue
+				}
+			}
+
 			start := time.Now()
 
-			// TODO(fuweid): do we still need copy here?
-			evs := wresp.Events
-			events := make([]*mvccpb.Event, len(evs))
 			sws.mu.RLock()
 			needPrevKV := sws.prevKV[wresp.WatchID]
+			keysOnly := sws.keysOnly[wresp.WatchID]
+			versionOnly := sws.versionOnly[wresp.WatchID]
+			fallback := sws.snapshotFallback[wresp.WatchID]
+			maxEvents := sws.maxEvents[wresp.WatchID]
 			sws.mu.RUnlock()
+
+			snapshot := false
+			if wresp.CompactRevision != 0 && fallback != nil {
+				if sresp, ok := sws.snapshotResponse(wresp.WatchID, fallback); ok {
+					mvcc.ReportEventReceived(len(wresp.Events))
+					wresp, snapshot = sresp, true
+				}
+			}
+
+			// TODO(fuweid): do we still need copy here?
+			evs := wresp.Events
+			events := make([]*mvccpb.Event, len(evs))
 			for i := range evs {
 				events[i] = evs[i]
-				if needPrevKV && !IsCreateEvent(evs[i]) {
+				// the previous key-value pairs of a collapsed range
+				// deletion are not sent
+				if needPrevKV && !versionOnly && !snapshot && !IsCreateEvent(evs[i]) && evs[i].Type != mvccpb.Event_DELETE_RANGE {
 					opt := mvcc.RangeOptions{Rev: evs[i].Kv.ModRevision - 1}
 					r, err := sws.watchable.Range(context.TODO(), evs[i].Kv.Key, nil, opt)
 					if err == nil && len(r.KVs) != 0 {
 						events[i].PrevKv = r.KVs[0]
 					}
 				}
+				switch {
+				case versionOnly:
+					events[i] = VersionOnlyEvent(events[i])
+				case keysOnly:
+					events[i] = KeysOnlyEvent(events[i])
+				}
 			}
 
 			canceled := wresp.CompactRevision != 0
 			wr := &pb.WatchResponse{
-				Header:          sws.newResponseHeader(wresp.Revision),
-				WatchId:         int64(wresp.WatchID),
-				Events:          events,
-				CompactRevision: wresp.CompactRevision,
-				Canceled:        canceled,
+				Header:                 sws.newResponseHeader(wresp.Revision),
+				WatchId:                int64(wresp.WatchID),
+				Events:                 events,
+				CompactRevision:        wresp.CompactRevision,
+				Canceled:               canceled,
+				Snapshot:               snapshot,
+				CatchUp:                wresp.CatchUp,
+				CompactWarningRevision: wresp.CompactWarningRevision,
+				CompareResult:          wresp.ConditionResult,
 			}
+			wrs := splitEvents(wr, int(maxEvents))
 
 			// Progress notifications can have WatchID -1
 			// if they announce on behalf of multiple watchers
 			if wresp.WatchID != clientv3.InvalidWatchID {
 				if _, okID := ids[wresp.WatchID]; !okID {
 					// buffer if id not yet announced
-					wrs := append(pending[wresp.WatchID], wr)
-					pending[wresp.WatchID] = wrs
+					pending[wresp.WatchID] = append(pending[wresp.WatchID], wrs...)
 					continue
 				}
 			}
@@ -484,10 +835,16 @@ func (sws *serverWatchStream) sendLoop() {
 
 			var serr error
 			// gofail: var beforeSendWatchResponse struct{}
-			if !fragmented && !ok {
-				serr = sws.gRPCStream.Send(wr)
-			} else {
-				serr = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+			for _, wr := range wrs {
+				if !fragmented && !ok {
+					serr = sws.gRPCStream.Send(wr)
+				} else {
+					serr = sendFragments(wr, sws.maxRequestBytes, sws.gRPCStream.Send)
+				}
+				if serr != nil {
+					break
+				}
+				sws.log.sent(wr)
 			}
 
 			if serr != nil {
@@ -499,6 +856,7 @@ func (sws *serverWatchStream) sendLoop() {
 				}
 				return
 			}
+			lastSent = time.Now()
 
 			sws.mu.Lock()
 			if len(evs) > 0 && sws.progress[wresp.WatchID] {
@@ -525,6 +883,17 @@ func (sws *serverWatchStream) sendLoop() {
 				}
 				return
 			}
+			lastSent = time.Now()
+
+			// the watchers of the stream changed
+			if interval := sws.keepAliveInterval(); interval != keepAliveInterval {
+				keepAliveInterval, keepAliveTimerc = interval, nil
+				keepAliveTimer.Stop()
+				if interval > 0 {
+					keepAliveTimer.Reset(interval)
+					keepAliveTimerc = keepAliveTimer.C
+				}
+			}
 
 			// track id creation
 			wid := mvcc.WatchID(c.WatchId)
@@ -533,6 +902,11 @@ func (sws *serverWatchStream) sendLoop() {
 
 			if c.Canceled && wid != clientv3.InvalidWatchID {
 				delete(ids, wid)
+				reason := c.CancelReason
+				if reason == "" {
+					reason = "canceled by client"
+				}
+				sws.log.canceled(wid, reason)
 				continue
 			}
 			if c.Created {
@@ -549,6 +923,7 @@ func (sws *serverWatchStream) sendLoop() {
 						}
 						return
 					}
+					sws.log.sent(v)
 				}
 				delete(pending, wid)
 			}
@@ -568,12 +943,98 @@ func (sws *serverWatchStream) sendLoop() {
 			sws.mu.Unlock()
 			watchSendLoopProgressDuration.Observe(time.Since(start).Seconds())
 
+		case <-keepAliveTimerc:
+			if idle := time.Since(lastSent); idle < keepAliveInterval {
+				keepAliveTimer.Reset(keepAliveInterval - idle)
+				continue
+			}
+			// the heartbeat has no revision, so that it is not mistaken for
+			// a progress notification
+			hb := &pb.WatchResponse{
+				Header:    sws.newResponseHeader(0),
+				WatchId:   clientv3.InvalidWatchID,
+				Heartbeat: true,
+			}
+			if err := sws.gRPCStream.Send(hb); err != nil {
+				if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+					sws.lg.Debug("failed to send watch heartbeat to gRPC stream", zap.Error(err))
+				} else {
+					sws.lg.Warn("failed to send watch heartbeat to gRPC stream", zap.Error(err))
+					streamFailures.WithLabelValues("send", "watch").Inc()
+				}
+				return
+			}
+			lastSent = time.Now()
+			keepAliveTimer.Reset(keepAliveInterval)
+
+		case <-drainc:
+			drainc, draining = nil, true
+			if len(ids) == 0 {
+				close(sws.drainedc)
+				return
+			}
+			drainTicker := time.NewTicker(watchDrainRetryInterval)
+			defer drainTicker.Stop()
+			drainTimer := time.NewTimer(watchDrainTimeout)
+			defer drainTimer.Stop()
+			drainTickc, drainTimeoutc = drainTicker.C, drainTimer.C
+			sws.requestDrainProgress(ids)
+
+		case <-drainTickc:
+			sws.requestDrainProgress(ids)
+
+		case <-drainTimeoutc:
+			sws.lg.Warn("timed out draining watchers", zap.Int("watchers", len(ids)))
+			close(sws.drainedc)
+			return
+
 		case <-sws.closec:
 			return
 		}
 	}
 }
 
+// isProgressResponse returns true if the response is a progress notification
+// of a single watcher. A compaction warning is sent to unsynced watchers, so
+// it is not one.
+func isProgressResponse(wresp mvcc.WatchResponse) bool {
+	return len(wresp.Events) == 0 && wresp.CompactRevision == 0 && wresp.CompactWarningRevision == 0 && wresp.WatchID != clientv3.InvalidWatchID
+}
+
+// requestDrainProgress requests the progress of the given watchers, which is
+// only sent once they are synced.
+func (sws *serverWatchStream) requestDrainProgress(ids map[mvcc.WatchID]struct{}) {
+	for id := range ids {
+		sws.watchStream.RequestProgress(id)
+	}
+}
+
+// sendDrainResponse cancels the watcher of the given progress response, and
+// sends it a final response with the revision it has received all the events
+// up to, so that the client can resume watching from the next revision on
+// another member.
+func (sws *serverWatchStream) sendDrainResponse(wresp mvcc.WatchResponse) bool {
+	sws.watchStream.Cancel(wresp.WatchID)
+	sws.removeWatcher(wresp.WatchID)
+	wr := &pb.WatchResponse{
+		Header:       sws.newResponseHeader(wresp.Revision),
+		WatchId:      int64(wresp.WatchID),
+		Canceled:     true,
+		CancelReason: rpctypes.ErrServerClosing.Error(),
+	}
+	if err := sws.gRPCStream.Send(wr); err != nil {
+		if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+			sws.lg.Debug("failed to send watch drain response to gRPC stream", zap.Error(err))
+		} else {
+			sws.lg.Warn("failed to send watch drain response to gRPC stream", zap.Error(err))
+			streamFailures.WithLabelValues("send", "watch").Inc()
+		}
+		return false
+	}
+	sws.log.canceled(wresp.WatchID, wr.CancelReason)
+	return true
+}
+
 func IsCreateEvent(e *mvccpb.Event) bool {
 	return e.Type == mvccpb.Event_PUT && e.Kv.CreateRevision == e.Kv.ModRevision
 }
@@ -602,14 +1063,20 @@ func sendFragments(
 		//
 		// REF: https://github.com/grpc/grpc-go/issues/5857
 		cur := &pb.WatchResponse{
-			Header:          wr.Header,
-			WatchId:         wr.WatchId,
-			Created:         wr.Created,
-			Canceled:        wr.Canceled,
-			CompactRevision: wr.CompactRevision,
-			CancelReason:    wr.CancelReason,
-			Fragment:        true,
-			Events:          make([]*mvccpb.Event, 0),
+			Header:                 wr.Header,
+			WatchId:                wr.WatchId,
+			Created:                wr.Created,
+			Canceled:               wr.Canceled,
+			CompactRevision:        wr.CompactRevision,
+			CancelReason:           wr.CancelReason,
+			Snapshot:               wr.Snapshot,
+			Warning:                wr.Warning,
+			CatchUp:                wr.CatchUp,
+			CompactWarningRevision: wr.CompactWarningRevision,
+			Heartbeat:              wr.Heartbeat,
+			CompareResult:          wr.CompareResult,
+			Fragment:               true,
+			Events:                 make([]*mvccpb.Event, 0),
 		}
 
 		for _, ev := range wr.Events[idx:] {
@@ -634,10 +1101,216 @@ func sendFragments(
 	return nil
 }
 
+// splitEvents splits the events of the response into responses of at most
+// maxEvents events each. The events of a revision are never split, so a
+// response holds more events when a single revision has more than maxEvents.
+// Snapshot responses are not split, since the receiver replaces its state
+// with the events of each of them.
+func splitEvents(wr *pb.WatchResponse, maxEvents int) []*pb.WatchResponse {
+	if maxEvents <= 0 || len(wr.Events) <= maxEvents || wr.Snapshot {
+		return []*pb.WatchResponse{wr}
+	}
+
+	var wrs []*pb.WatchResponse
+	for evs := wr.Events; len(evs) > 0; {
+		n := min(maxEvents, len(evs))
+		if n < len(evs) {
+			// end the response before the revision the limit falls in,
+			// or after it if that revision is the first one
+			rev := evs[n].Kv.ModRevision
+			i := n
+			for i > 0 && evs[i-1].Kv.ModRevision == rev {
+				i--
+			}
+			if i > 0 {
+				n = i
+			} else {
+				for n < len(evs) && evs[n].Kv.ModRevision == rev {
+					n++
+				}
+			}
+		}
+
+		// Keep this explicit field copy in sync with pb.WatchResponse.
+		// TestWatchResponseProtoFieldCount guards against missing new fields.
+		wrs = append(wrs, &pb.WatchResponse{
+			Header:                 wr.Header,
+			WatchId:                wr.WatchId,
+			Created:                wr.Created,
+			Canceled:               wr.Canceled,
+			CompactRevision:        wr.CompactRevision,
+			CancelReason:           wr.CancelReason,
+			Fragment:               wr.Fragment,
+			Snapshot:               wr.Snapshot,
+			Warning:                wr.Warning,
+			CatchUp:                wr.CatchUp,
+			CompactWarningRevision: wr.CompactWarningRevision,
+			Heartbeat:              wr.Heartbeat,
+			CompareResult:          wr.CompareResult,
+			Events:                 evs[:n:n],
+		})
+		evs = evs[n:]
+	}
+	return wrs
+}
+
+// snapshotResponse resumes the compacted watcher of the given ID right after
+// the current revision, and returns a response carrying the state of the
+// watched range at that revision as PUT events. It returns false if the
+// watcher was canceled in the meantime or the snapshot could not be taken,
+// in which case the compaction is reported instead.
+func (sws *serverWatchStream) snapshotResponse(id mvcc.WatchID, creq *pb.WatchCreateRequest) (mvcc.WatchResponse, bool) {
+	r, err := sws.watchable.Range(context.TODO(), creq.Key, creq.RangeEnd, mvcc.RangeOptions{})
+	if err == nil {
+		err = sws.watchStream.Rewatch(id, r.Rev+1)
+	}
+	if err != nil {
+		if !errors.Is(err, mvcc.ErrWatcherNotExist) && !errors.Is(err, mvcc.ErrWatcherNotCompacted) {
+			sws.lg.Warn("failed to fall back to snapshot for compacted watcher", zap.Int64("watch-id", int64(id)), zap.Error(err))
+		}
+		return mvcc.WatchResponse{}, false
+	}
+
+	// the filters were checked when the watcher was created
+	filters, _ := FiltersFromRequest(creq, sws.valueSelectorMaxCost)
+	evs := make([]*mvccpb.Event, 0, len(r.KVs))
+	for _, kv := range r.KVs {
+		ev := &mvccpb.Event{Type: mvccpb.Event_PUT, Kv: kv}
+		if !slices.ContainsFunc(filters, func(filter mvcc.FilterFunc) bool { return filter(ev) }) {
+			evs = append(evs, ev)
+		}
+	}
+	return mvcc.WatchResponse{WatchID: id, Events: evs, Revision: r.Rev, CatchUp: true}, true
+}
+
+// keepAliveInterval returns the smallest keep alive interval of the watchers
+// of the stream, or 0 if none of them needs heartbeats.
+func (sws *serverWatchStream) keepAliveInterval() time.Duration {
+	sws.mu.RLock()
+	defer sws.mu.RUnlock()
+	var interval time.Duration
+	for _, d := range sws.keepAlive {
+		if interval == 0 || d < interval {
+			interval = d
+		}
+	}
+	return interval
+}
+
 func (sws *serverWatchStream) close() {
 	sws.watchStream.Close()
 	close(sws.closec)
 	sws.wg.Wait()
+	sws.log.closed()
+
+	sws.mu.Lock()
+	n := len(sws.watchers)
+	clear(sws.watchers)
+	sws.mu.Unlock()
+	addWatchSubstreams(sws.peer, -n)
+}
+
+// watchRange is the key range of a watcher, as set in its create request.
+type watchRange struct {
+	key, end []byte
+}
+
+// watchRangeEnd returns the range end of a create request as it was sent,
+// before an end of "\x00" was turned into an empty end.
+func watchRangeEnd(end []byte) []byte {
+	if end != nil && len(end) == 0 {
+		return []byte{0}
+	}
+	return end
+}
+
+// WatchRangeWithin returns whether all the keys of the watch range
+// [wkey, wend) are in the range [key, end). Both ranges follow the conventions
+// of the watch create requests: an empty end stands for the single key, and an
+// end of "\x00" for all the keys from the key on.
+func WatchRangeWithin(wkey, wend, key, end []byte) bool {
+	allFrom := []byte{0}
+	switch {
+	case bytes.Compare(wkey, key) < 0:
+		return false
+	case len(end) == 0:
+		return len(wend) == 0 && bytes.Equal(wkey, key)
+	case bytes.Equal(end, allFrom):
+		return true
+	case len(wend) == 0:
+		return bytes.Compare(wkey, end) < 0
+	case bytes.Equal(wend, allFrom):
+		return false
+	}
+	return bytes.Compare(wend, end) <= 0
+}
+
+// cancelWatch cancels the watcher id on behalf of the client, and sends it
+// a canceled response if it existed. It returns false if the stream is
+// closed.
+func (sws *serverWatchStream) cancelWatch(id mvcc.WatchID) bool {
+	if err := sws.watchStream.Cancel(id); err != nil {
+		return true
+	}
+	wr := &pb.WatchResponse{
+		Header:   sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:  int64(id),
+		Canceled: true,
+	}
+	select {
+	case sws.ctrlStream <- wr:
+	case <-sws.closec:
+		return false
+	}
+
+	sws.removeWatcher(id)
+	sws.mu.Lock()
+	delete(sws.progress, id)
+	delete(sws.prevKV, id)
+	delete(sws.fragment, id)
+	delete(sws.maxEvents, id)
+	delete(sws.keysOnly, id)
+	delete(sws.versionOnly, id)
+	delete(sws.keepAlive, id)
+	delete(sws.snapshotFallback, id)
+	sws.mu.Unlock()
+	return true
+}
+
+// watchersWithin returns the IDs of the watchers whose keys are all in the
+// range [key, end), in increasing order.
+func (sws *serverWatchStream) watchersWithin(key, end []byte) []mvcc.WatchID {
+	sws.mu.RLock()
+	var ids []mvcc.WatchID
+	for id, wr := range sws.watchers {
+		if WatchRangeWithin(wr.key, wr.end, key, end) {
+			ids = append(ids, id)
+		}
+	}
+	sws.mu.RUnlock()
+	slices.Sort(ids)
+	return ids
+}
+
+// addWatcher records the key range of a created watcher, and counts it in the
+// substreams of the peer.
+func (sws *serverWatchStream) addWatcher(id mvcc.WatchID, key, end []byte) {
+	sws.mu.Lock()
+	sws.watchers[id] = watchRange{key: key, end: end}
+	sws.mu.Unlock()
+	addWatchSubstreams(sws.peer, 1)
+}
+
+// removeWatcher stops counting a canceled watcher in the substreams of the
+// peer.
+func (sws *serverWatchStream) removeWatcher(id mvcc.WatchID) {
+	sws.mu.Lock()
+	_, ok := sws.watchers[id]
+	delete(sws.watchers, id)
+	sws.mu.Unlock()
+	if ok {
+		addWatchSubstreams(sws.peer, -1)
+	}
 }
 
 func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
@@ -650,24 +1323,100 @@ func (sws *serverWatchStream) newResponseHeader(rev int64) *pb.ResponseHeader {
 }
 
 func filterNoDelete(e *mvccpb.Event) bool {
-	return e.Type == mvccpb.Event_DELETE
+	return e.Type == mvccpb.Event_DELETE || e.Type == mvccpb.Event_DELETE_RANGE
 }
 
 func filterNoPut(e *mvccpb.Event) bool {
 	return e.Type == mvccpb.Event_PUT
 }
 
+// KeysOnlyEvent returns a copy of the given event with the values of
+// its key-value pairs omitted. The original event is left untouched
+// since it may be shared with other watchers.
+func KeysOnlyEvent(ev *mvccpb.Event) *mvccpb.Event {
+	return &mvccpb.Event{
+		Type:     ev.Type,
+		Kv:       keyOnly(ev.Kv),
+		PrevKv:   keyOnly(ev.PrevKv),
+		RangeEnd: ev.RangeEnd,
+		Deleted:  ev.Deleted,
+	}
+}
+
+func keyOnly(kv *mvccpb.KeyValue) *mvccpb.KeyValue {
+	if kv == nil {
+		return nil
+	}
+	return &mvccpb.KeyValue{
+		Key:            kv.Key,
+		CreateRevision: kv.CreateRevision,
+		ModRevision:    kv.ModRevision,
+		Version:        kv.Version,
+		Lease:          kv.Lease,
+	}
+}
+
+// VersionOnlyEvent returns a copy of the given event that only carries
+// its type, the key, mod revision and version of its key-value pair, and the
+// range of a DELETE_RANGE event.
+// The original event is left untouched since it may be shared with other
+// watchers.
+func VersionOnlyEvent(ev *mvccpb.Event) *mvccpb.Event {
+	return &mvccpb.Event{
+		Type: ev.Type,
+		Kv: &mvccpb.KeyValue{
+			Key:         ev.Kv.Key,
+			ModRevision: ev.Kv.ModRevision,
+			Version:     ev.Kv.Version,
+		},
+		RangeEnd: ev.RangeEnd,
+		Deleted:  ev.Deleted,
+	}
+}
+
+func filterValuePrefix(prefix []byte) mvcc.FilterFunc {
+	return func(e *mvccpb.Event) bool {
+		return e.Type == mvccpb.Event_PUT && !bytes.HasPrefix(e.Kv.Value, prefix)
+	}
+}
+
 // FiltersFromRequest returns "mvcc.FilterFunc" from a given watch create request.
-func FiltersFromRequest(creq *pb.WatchCreateRequest) []mvcc.FilterFunc {
-	filters := make([]mvcc.FilterFunc, 0, len(creq.Filters))
+// The filters are combined into a single pipeline evaluated in the requested
+// filter order. It returns an error if the value selector of the request is
+// invalid or costs more than valueSelectorMaxCost, or if valueSelectorMaxCost
+// is 0, which disables value selectors.
+func FiltersFromRequest(creq *pb.WatchCreateRequest, valueSelectorMaxCost int) ([]mvcc.FilterFunc, error) {
+	filters := make([]mvcc.Filter, 0, len(creq.Filters)+1)
 	for _, ft := range creq.Filters {
 		switch ft {
 		case pb.WatchCreateRequest_NOPUT:
-			filters = append(filters, filterNoPut)
+			filters = append(filters, mvcc.Filter{Stage: mvcc.FilterStageType, Func: filterNoPut, Name: "noput"})
 		case pb.WatchCreateRequest_NODELETE:
-			filters = append(filters, filterNoDelete)
+			filters = append(filters, mvcc.Filter{Stage: mvcc.FilterStageType, Func: filterNoDelete, Name: "nodelete"})
 		default:
 		}
 	}
-	return filters
+	if len(creq.ValuePrefix) != 0 {
+		filters = append(filters, mvcc.Filter{Stage: mvcc.FilterStageValue, Func: filterValuePrefix(creq.ValuePrefix), Name: "value_prefix"})
+	}
+	if creq.ValueSelector != "" {
+		if valueSelectorMaxCost <= 0 {
+			return nil, errWatchValueSelectorDisabled
+		}
+		fn, err := mvcc.NewValueSelectorFilter(creq.ValueSelector, valueSelectorMaxCost)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, mvcc.Filter{Stage: mvcc.FilterStageValue, Func: fn, Name: "value_selector"})
+	}
+
+	order := mvcc.TypeFiltersFirst
+	if creq.FilterOrder == pb.WatchCreateRequest_VALUE_FIRST {
+		order = mvcc.ValueFiltersFirst
+	}
+	pipeline := mvcc.NewFilterPipeline(order, filters...)
+	if pipeline == nil {
+		return nil, nil
+	}
+	return []mvcc.FilterFunc{pipeline}, nil
 }
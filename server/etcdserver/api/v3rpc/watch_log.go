@@ -0,0 +1,263 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+const (
+	// PathWatchDebugRanges is the path of the endpoint that lists, adds and
+	// removes the range hashes whose watchers are logged verbosely.
+	PathWatchDebugRanges = "/debug/watch/ranges"
+
+	// watchLogInterval and watchLogBurst rate limit the lifecycle logs of
+	// the watchers of a stream that are not logged verbosely.
+	watchLogInterval = time.Second
+	watchLogBurst    = 10
+)
+
+// WatchRangeHash returns the hash identifying the range of a watcher in the
+// server logs. It is the first 16 hex digits of the SHA-256 of the key, a
+// zero byte, and the range end, as they are set in the watch create request.
+// The range end is empty when watching a single key. For instance, the hash
+// of a watcher on the prefix "foo" is given by:
+//
+//	printf 'foo\0fop' | sha256sum | cut -c1-16
+func WatchRangeHash(key, rangeEnd []byte) string {
+	h := sha256.New()
+	h.Write(key)
+	h.Write([]byte{0})
+	h.Write(rangeEnd)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+var (
+	// verboseWatchRanges are the range hashes whose watchers are logged
+	// without rate limit, along with every response they are sent.
+	verboseWatchRanges   = make(map[string]struct{})
+	verboseWatchRangesMu sync.RWMutex
+)
+
+// SetWatchRangeVerbose sets whether the watchers of the given range hash are
+// logged verbosely.
+func SetWatchRangeVerbose(rangeHash string, verbose bool) {
+	verboseWatchRangesMu.Lock()
+	defer verboseWatchRangesMu.Unlock()
+	if verbose {
+		verboseWatchRanges[rangeHash] = struct{}{}
+	} else {
+		delete(verboseWatchRanges, rangeHash)
+	}
+}
+
+// VerboseWatchRanges returns the sorted range hashes whose watchers are
+// logged verbosely.
+func VerboseWatchRanges() []string {
+	verboseWatchRangesMu.RLock()
+	defer verboseWatchRangesMu.RUnlock()
+	hashes := make([]string, 0, len(verboseWatchRanges))
+	for h := range verboseWatchRanges {
+		hashes = append(hashes, h)
+	}
+	slices.Sort(hashes)
+	return hashes
+}
+
+func isWatchRangeVerbose(rangeHash string) bool {
+	verboseWatchRangesMu.RLock()
+	defer verboseWatchRangesMu.RUnlock()
+	_, ok := verboseWatchRanges[rangeHash]
+	return ok
+}
+
+// HandleWatchDebug registers a handler on '/debug/watch/ranges' that lists
+// the range hashes logged verbosely on GET, and adds or removes the range
+// hash given by the 'hash' query parameter on PUT or DELETE.
+func HandleWatchDebug(mux *http.ServeMux) {
+	mux.HandleFunc(PathWatchDebugRanges, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodDelete:
+			h := r.URL.Query().Get("hash")
+			if b, err := hex.DecodeString(h); err != nil || len(b) != 8 {
+				http.Error(w, fmt.Sprintf("invalid range hash %q, expected 16 hex digits", h), http.StatusBadRequest)
+				return
+			}
+			SetWatchRangeVerbose(h, r.Method == http.MethodPut)
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(VerboseWatchRanges())
+	})
+}
+
+// watchLog logs the lifecycle of the watchers of a stream, so that a client
+// reporting a missed event can be matched to the watcher that served it.
+// The logs of a stream are rate limited, unless the range hash of the
+// watcher is logged verbosely.
+type watchLog struct {
+	lg      *zap.Logger
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+	// suppressed is the number of logs dropped since the last one.
+	suppressed int
+	watchers   map[mvcc.WatchID]*loggedWatcher
+}
+
+type loggedWatcher struct {
+	rangeHash string
+	synced    bool
+	// lastSentRevision is the header revision of the last response sent.
+	lastSentRevision int64
+}
+
+func newWatchLog(lg *zap.Logger, streamID uint64) *watchLog {
+	return &watchLog{
+		lg:       lg.With(zap.Uint64("watch-stream-id", streamID)),
+		limiter:  rate.NewLimiter(rate.Every(watchLogInterval), watchLogBurst),
+		watchers: make(map[mvcc.WatchID]*loggedWatcher),
+	}
+}
+
+// established logs a new stream of the given peer and authenticated user.
+func (l *watchLog) established(peer, user string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.log(false, "watch stream established", zap.String("peer", peer), zap.String("user", user))
+}
+
+// created logs a new watcher. A watcher starting at a revision that is not
+// in the future has to catch up on the history before being synced.
+func (l *watchLog) created(id mvcc.WatchID, rangeHash string, startRev, rev int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w := &loggedWatcher{rangeHash: rangeHash, synced: startRev <= 0 || startRev > rev}
+	l.watchers[id] = w
+	verbose := isWatchRangeVerbose(rangeHash)
+	l.log(verbose, "watcher created",
+		zap.Int64("watch-id", int64(id)),
+		zap.String("range-hash", rangeHash),
+		zap.Int64("start-revision", startRev),
+		zap.Int64("current-revision", rev),
+	)
+	if w.synced {
+		l.log(verbose, "watcher synced", zap.Int64("watch-id", int64(id)), zap.String("range-hash", rangeHash), zap.Int64("revision", rev))
+	}
+}
+
+// sent records a response sent to a watcher. A watcher is synced once it is
+// sent a response that is not replayed from the history, is warned by a
+// compaction warning, and is canceled by a compaction response.
+func (l *watchLog) sent(wr *pb.WatchResponse) {
+	id := mvcc.WatchID(wr.WatchId)
+	if id == clientv3.InvalidWatchID || wr.Created {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	w, ok := l.watchers[id]
+	if !ok {
+		return
+	}
+	w.lastSentRevision = wr.Header.Revision
+	verbose := isWatchRangeVerbose(w.rangeHash)
+	if verbose {
+		l.lg.Info("sent watch response",
+			zap.Int64("watch-id", int64(id)),
+			zap.String("range-hash", w.rangeHash),
+			zap.Int64("revision", wr.Header.Revision),
+			zap.Int("events", len(wr.Events)),
+			zap.Bool("catch-up", wr.CatchUp),
+			zap.Bool("fragment", wr.Fragment),
+		)
+	}
+	if wr.CompactWarningRevision != 0 {
+		l.log(verbose, "watcher warned of compaction",
+			zap.Int64("watch-id", int64(id)),
+			zap.String("range-hash", w.rangeHash),
+			zap.Int64("compact-revision", wr.CompactWarningRevision),
+			zap.Int64("revision", wr.Header.Revision),
+		)
+	}
+	// a response with only a compaction warning is sent to a watcher that
+	// is still catching up
+	warningOnly := len(wr.Events) == 0 && wr.CompactWarningRevision != 0
+	if !w.synced && !wr.CatchUp && !wr.Canceled && !warningOnly {
+		w.synced = true
+		l.log(verbose, "watcher synced", zap.Int64("watch-id", int64(id)), zap.String("range-hash", w.rangeHash), zap.Int64("revision", wr.Header.Revision))
+	}
+	if wr.Canceled && wr.CompactRevision != 0 {
+		l.cancel(id, w, fmt.Sprintf("compacted at revision %d", wr.CompactRevision))
+	}
+}
+
+// canceled logs the cancellation of a watcher for the given reason.
+func (l *watchLog) canceled(id mvcc.WatchID, reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w, ok := l.watchers[id]; ok {
+		l.cancel(id, w, reason)
+	}
+}
+
+// closed logs the cancellation of the remaining watchers of a closed stream.
+func (l *watchLog) closed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, w := range l.watchers {
+		l.cancel(id, w, "stream closed")
+	}
+}
+
+func (l *watchLog) cancel(id mvcc.WatchID, w *loggedWatcher, reason string) {
+	delete(l.watchers, id)
+	l.log(isWatchRangeVerbose(w.rangeHash), "watcher canceled",
+		zap.Int64("watch-id", int64(id)),
+		zap.String("range-hash", w.rangeHash),
+		zap.String("reason", reason),
+		zap.Int64("last-sent-revision", w.lastSentRevision),
+	)
+}
+
+func (l *watchLog) log(verbose bool, msg string, fields ...zap.Field) {
+	if !verbose && !l.limiter.Allow() {
+		l.suppressed++
+		return
+	}
+	if l.suppressed > 0 {
+		fields = append(fields, zap.Int("suppressed", l.suppressed))
+		l.suppressed = 0
+	}
+	l.lg.Info(msg, fields...)
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestWatchRangeHash(t *testing.T) {
+	// The hashes are documented to be computed with sha256sum, and must not
+	// change across releases.
+	assert.Equal(t, "446e60c841fc0800", WatchRangeHash([]byte("foo"), nil))
+	assert.Equal(t, "e04b61f74e14f175", WatchRangeHash([]byte("foo"), []byte("fop")))
+}
+
+func newObservedWatchLog() (*watchLog, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	return newWatchLog(zap.New(core), 7), logs
+}
+
+func watchResponse(id, rev int64, catchUp bool) *pb.WatchResponse {
+	return &pb.WatchResponse{
+		Header:  &pb.ResponseHeader{Revision: rev},
+		WatchId: id,
+		Events:  []*mvccpb.Event{{Kv: &mvccpb.KeyValue{Key: []byte("foo"), ModRevision: rev}}},
+		CatchUp: catchUp,
+	}
+}
+
+func TestWatchLogLifecycle(t *testing.T) {
+	l, logs := newObservedWatchLog()
+	hash := WatchRangeHash([]byte("foo"), nil)
+
+	l.established("127.0.0.1:1234", "root")
+	l.created(1, hash, 5, 10)
+	l.sent(watchResponse(1, 10, true))
+	l.sent(watchResponse(1, 11, false))
+	l.sent(watchResponse(1, 12, false))
+	l.canceled(1, "canceled by client")
+
+	entries := logs.AllUntimed()
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+		assert.Equal(t, uint64(7), e.ContextMap()["watch-stream-id"])
+	}
+	require.Equal(t, []string{"watch stream established", "watcher created", "watcher synced", "watcher canceled"}, msgs)
+
+	assert.Equal(t, "127.0.0.1:1234", entries[0].ContextMap()["peer"])
+	assert.Equal(t, "root", entries[0].ContextMap()["user"])
+
+	created := entries[1].ContextMap()
+	assert.Equal(t, int64(1), created["watch-id"])
+	assert.Equal(t, hash, created["range-hash"])
+	assert.Equal(t, int64(5), created["start-revision"])
+
+	assert.Equal(t, int64(11), entries[2].ContextMap()["revision"])
+
+	canceled := entries[3].ContextMap()
+	assert.Equal(t, "canceled by client", canceled["reason"])
+	assert.Equal(t, int64(12), canceled["last-sent-revision"])
+}
+
+func TestWatchLogSyncedAtCreation(t *testing.T) {
+	l, logs := newObservedWatchLog()
+	l.created(1, "h", 0, 10)
+	l.created(2, "h", 11, 10)
+	l.sent(watchResponse(1, 11, false))
+	assert.Equal(t, 2, logs.FilterMessage("watcher synced").Len())
+}
+
+func TestWatchLogCanceledOnCompactionAndClose(t *testing.T) {
+	l, logs := newObservedWatchLog()
+	l.created(1, "h", 0, 10)
+	l.created(2, "h", 0, 10)
+	l.sent(&pb.WatchResponse{Header: &pb.ResponseHeader{Revision: 20}, WatchId: 1, Canceled: true, CompactRevision: 15})
+	l.closed()
+	l.closed()
+
+	canceled := logs.FilterMessage("watcher canceled").AllUntimed()
+	require.Len(t, canceled, 2)
+	assert.Equal(t, "compacted at revision 15", canceled[0].ContextMap()["reason"])
+	assert.Equal(t, int64(20), canceled[0].ContextMap()["last-sent-revision"])
+	assert.Equal(t, int64(2), canceled[1].ContextMap()["watch-id"])
+	assert.Equal(t, "stream closed", canceled[1].ContextMap()["reason"])
+}
+
+func TestWatchLogCompactWarning(t *testing.T) {
+	l, logs := newObservedWatchLog()
+	l.created(1, "h", 5, 10)
+	l.sent(&pb.WatchResponse{Header: &pb.ResponseHeader{Revision: 4}, WatchId: 1, CompactWarningRevision: 3})
+
+	warned := logs.FilterMessage("watcher warned of compaction").AllUntimed()
+	require.Len(t, warned, 1)
+	assert.Equal(t, int64(3), warned[0].ContextMap()["compact-revision"])
+	assert.Equal(t, int64(4), warned[0].ContextMap()["revision"])
+	// the watcher is still catching up
+	assert.Zero(t, logs.FilterMessage("watcher synced").Len())
+}
+
+func TestWatchLogRateLimitAndVerbose(t *testing.T) {
+	l, logs := newObservedWatchLog()
+	for i := 0; i < 2*watchLogBurst; i++ {
+		l.created(1, "quiet", 5, 10)
+	}
+	assert.Equal(t, watchLogBurst, logs.Len())
+
+	SetWatchRangeVerbose("loud", true)
+	defer SetWatchRangeVerbose("loud", false)
+	logs.TakeAll()
+	l.created(2, "loud", 5, 10)
+	l.sent(watchResponse(2, 10, true))
+	l.canceled(2, "canceled by client")
+
+	var msgs []string
+	for _, e := range logs.AllUntimed() {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"watcher created", "sent watch response", "watcher canceled"}, msgs)
+	// the first log after dropped ones reports how many were dropped
+	assert.Equal(t, int64(watchLogBurst), logs.AllUntimed()[0].ContextMap()["suppressed"])
+}
+
+func TestHandleWatchDebug(t *testing.T) {
+	mux := http.NewServeMux()
+	HandleWatchDebug(mux)
+	do := func(method, query string) (int, []string) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, PathWatchDebugRanges+query, nil))
+		var hashes []string
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &hashes))
+		}
+		return rec.Code, hashes
+	}
+
+	code, hashes := do(http.MethodPut, "?hash=e04b61f74e14f175")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"e04b61f74e14f175"}, hashes)
+	assert.True(t, isWatchRangeVerbose("e04b61f74e14f175"))
+
+	code, hashes = do(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"e04b61f74e14f175"}, hashes)
+
+	code, _ = do(http.MethodPut, "?hash=foo")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = do(http.MethodPost, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, hashes = do(http.MethodDelete, "?hash=e04b61f74e14f175")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, hashes)
+	assert.False(t, isWatchRangeVerbose("e04b61f74e14f175"))
+}
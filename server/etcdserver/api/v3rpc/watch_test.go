@@ -21,6 +21,8 @@ import (
 	"reflect"
 	"testing"
 
+	"google.golang.org/protobuf/proto"
+
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 )
@@ -84,8 +86,141 @@ func TestSendFragment(t *testing.T) {
 	}
 }
 
+func TestSplitEvents(t *testing.T) {
+	tt := []struct {
+		revs      []int64
+		maxEvents int
+		snapshot  bool
+		sizes     []int
+	}{
+		{ // no limit, expect no split
+			revs:  []int64{1, 2, 3},
+			sizes: []int{3},
+		},
+		{ // under the limit, expect no split
+			revs:      []int64{1, 2, 3},
+			maxEvents: 3,
+			sizes:     []int{3},
+		},
+		{ // one event per revision
+			revs:      []int64{1, 2, 3, 4, 5},
+			maxEvents: 2,
+			sizes:     []int{2, 2, 1},
+		},
+		{ // limit falls in a revision, expect the revision in the next response
+			revs:      []int64{1, 2, 2, 3},
+			maxEvents: 2,
+			sizes:     []int{1, 2, 1},
+		},
+		{ // first revision exceeds the limit, expect it in a single response
+			revs:      []int64{1, 1, 1, 2, 3},
+			maxEvents: 2,
+			sizes:     []int{3, 2},
+		},
+		{ // snapshot responses are not split
+			revs:      []int64{1, 2, 3},
+			maxEvents: 1,
+			snapshot:  true,
+			sizes:     []int{3},
+		},
+	}
+
+	for i, tc := range tt {
+		wr := &pb.WatchResponse{WatchId: 7, CatchUp: true, Snapshot: tc.snapshot}
+		for _, rev := range tc.revs {
+			wr.Events = append(wr.Events, &mvccpb.Event{Kv: &mvccpb.KeyValue{ModRevision: rev}})
+		}
+
+		wrs := splitEvents(wr, tc.maxEvents)
+		sizes := make([]int, len(wrs))
+		var revs []int64
+		for j, cur := range wrs {
+			sizes[j] = len(cur.Events)
+			for _, ev := range cur.Events {
+				revs = append(revs, ev.Kv.ModRevision)
+			}
+			if cur.WatchId != wr.WatchId || cur.CatchUp != wr.CatchUp {
+				t.Errorf("#%d: expected the fields of the response to be copied, got %+v", i, cur)
+			}
+		}
+		if !reflect.DeepEqual(sizes, tc.sizes) {
+			t.Errorf("#%d: expected responses of %v events, got %v", i, tc.sizes, sizes)
+		}
+		if !reflect.DeepEqual(revs, tc.revs) {
+			t.Errorf("#%d: expected events of revisions %v, got %v", i, tc.revs, revs)
+		}
+	}
+}
+
+func TestVersionOnlyEvent(t *testing.T) {
+	kv := &mvccpb.KeyValue{
+		Key:            []byte("/config/service/feature-flags"),
+		CreateRevision: 1000,
+		ModRevision:    2000,
+		Version:        42,
+		Value:          bytes.Repeat([]byte("v"), 256),
+		Lease:          7587,
+	}
+	prevKV := &mvccpb.KeyValue{Key: kv.Key, CreateRevision: 1000, ModRevision: 1999, Version: 41, Value: kv.Value, Lease: 7587}
+	ev := &mvccpb.Event{Type: mvccpb.PUT, Kv: kv, PrevKv: prevKV}
+
+	vev := VersionOnlyEvent(ev)
+	want := &mvccpb.Event{Type: mvccpb.PUT, Kv: &mvccpb.KeyValue{Key: kv.Key, ModRevision: 2000, Version: 42}}
+	if !reflect.DeepEqual(vev, want) {
+		t.Fatalf("expected %+v, got %+v", want, vev)
+	}
+	if len(ev.Kv.Value) == 0 || ev.PrevKv == nil {
+		t.Fatalf("expected the original event to be left untouched, got %+v", ev)
+	}
+
+	// a response of a wide fan-out notification is smaller than with keys_only
+	resp := func(ev *mvccpb.Event) int {
+		wr := &pb.WatchResponse{Header: &pb.ResponseHeader{Revision: 2000}, WatchId: 1}
+		for i := 0; i < 100; i++ {
+			wr.Events = append(wr.Events, ev)
+		}
+		return proto.Size(wr)
+	}
+	full, keysOnly, versionOnly := resp(ev), resp(KeysOnlyEvent(ev)), resp(vev)
+	if versionOnly >= keysOnly || keysOnly >= full {
+		t.Fatalf("expected version only (%d) < keys only (%d) < full (%d) response sizes", versionOnly, keysOnly, full)
+	}
+	// key (31) + mod_revision (3) + version (2) + type and framing of the event
+	if perEvent := versionOnly / 100; perEvent > 45 {
+		t.Errorf("expected version only events of at most 45 bytes, got %d", perEvent)
+	}
+}
+
+func TestWatchRangeWithin(t *testing.T) {
+	tests := []struct {
+		wkey, wend string
+		key, end   string
+		want       bool
+	}{
+		{"foo", "", "foo", "", true},
+		{"foo", "", "fop", "", false},
+		{"foo/", "foo0", "foo", "", false},
+		{"foo", "", "foo", "fop", true},
+		{"fop", "", "foo", "fop", false},
+		{"foo/", "foo0", "foo", "fop", true},
+		{"foo/", "foo0", "foo/", "foo0", true},
+		{"foo/", "fop", "foo/", "foo0", false},
+		{"fo", "foo0", "foo", "fop", false},
+		{"foo/", "\x00", "foo", "fop", false},
+		{"foo/", "\x00", "foo", "\x00", true},
+		{"foo/", "foo0", "\x00", "\x00", true},
+		{"a", "", "b", "\x00", false},
+	}
+	for _, tt := range tests {
+		got := WatchRangeWithin([]byte(tt.wkey), []byte(tt.wend), []byte(tt.key), []byte(tt.end))
+		if got != tt.want {
+			t.Errorf("WatchRangeWithin([%q, %q), [%q, %q)) = %v, want %v", tt.wkey, tt.wend, tt.key, tt.end, got, tt.want)
+		}
+	}
+}
+
 func TestWatchResponseProtoFieldCount(t *testing.T) {
-	const expectedWatchResponseProtoFields = 8
+	const expectedWatchResponseProtoFields = 14
 
 	fields := 0
 	typ := reflect.TypeOf(pb.WatchResponse{})
@@ -97,8 +232,8 @@ func TestWatchResponseProtoFieldCount(t *testing.T) {
 
 	// NOTE:
 	//
-	// We do manually value-copy in sendFragments. If there is new
-	// protobuf field added to WatchResponse, we need to update sendFragments.
+	// We do manually value-copy in sendFragments and splitEvents. If there
+	// is new protobuf field added to WatchResponse, we need to update them.
 	if fields != expectedWatchResponseProtoFields {
 		t.Fatalf("unexpected pb.WatchResponse protobuf field count, got=%d expected=%d", fields, expectedWatchResponseProtoFields)
 	}
@@ -195,6 +195,39 @@ func (aa *authApplierV3) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevoke
 	return aa.applierV3.LeaseRevoke(lc)
 }
 
+func (aa *authApplierV3) LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	if err := checkLeasePuts(aa.as, &aa.authInfo, aa.lessor, lease.LeaseID(lc.ID)); err != nil {
+		return nil, err
+	}
+	return aa.applierV3.LeaseExpire(lc)
+}
+
+func (aa *authApplierV3) LeaseTransfer(lc *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	if err := checkLeaseTransfer(aa.as, &aa.authInfo, aa.lessor, lease.LeaseID(lc.ID)); err != nil {
+		return nil, err
+	}
+	return aa.applierV3.LeaseTransfer(lc)
+}
+
+// checkLeaseTransfer permits taking over the lease to admins, and to users
+// who could have put every key attached to it. A lease without keys grants
+// no write permission to check, so only admins may take it over.
+func checkLeaseTransfer(as auth.AuthStore, ai *auth.AuthInfo, lessor lease.Lessor, leaseID lease.LeaseID) error {
+	err := as.IsAdminPermitted(ai)
+	if err == nil {
+		return nil
+	}
+
+	l := lessor.Lookup(leaseID)
+	if l == nil {
+		return nil
+	}
+	if len(l.Keys()) == 0 {
+		return err
+	}
+	return checkLeasePutsKeys(as, ai, l)
+}
+
 func checkLeasePuts(as auth.AuthStore, ai *auth.AuthInfo, lessor lease.Lessor, leaseID lease.LeaseID) error {
 	l := lessor.Lookup(leaseID)
 	if l != nil {
@@ -267,6 +267,11 @@ func TestAuthApplierV3_AdminPermission(t *testing.T) {
 			request:               &InternalRaftRequestWrapper{InternalRaftRequest: &pb.InternalRaftRequest{LeaseRevoke: &pb.LeaseRevokeRequest{}}},
 			adminPermissionNeeded: false,
 		},
+		{
+			name:                  "LeaseTransfer does not need admin permission",
+			request:               &InternalRaftRequestWrapper{InternalRaftRequest: &pb.InternalRaftRequest{LeaseTransfer: &pb.LeaseTransferRequest{}}},
+			adminPermissionNeeded: false,
+		},
 		{
 			name:                  "Alarm does not need admin permission",
 			request:               &InternalRaftRequestWrapper{InternalRaftRequest: &pb.InternalRaftRequest{Alarm: &pb.AlarmRequest{}}},
@@ -701,6 +706,57 @@ func TestAuthApplierV3_LeaseRevoke(t *testing.T) {
 	require.Equal(t, err, auth.ErrPermissionDenied)
 }
 
+// TestAuthApplierV3_LeaseTransfer verifies user cannot take over a lease if the lease is attached
+// with a key out of range by someone else
+func TestAuthApplierV3_LeaseTransfer(t *testing.T) {
+	authApplier := defaultAuthApplierV3(t)
+	mustCreateRolesAndEnableAuth(t, authApplier)
+
+	_, err := authApplier.LeaseGrant(&pb.LeaseGrantRequest{
+		TTL: lease.MaxLeaseTTL,
+		ID:  leaseID,
+	})
+	require.NoError(t, err)
+
+	// The user should not be able to transfer the lease without keys
+	setAuthInfo(authApplier, userWriteOnly)
+	_, err = authApplier.LeaseTransfer(&pb.LeaseTransferRequest{
+		ID: leaseID,
+	})
+	require.Equal(t, err, auth.ErrPermissionDenied)
+
+	// Put a key under the lease inside user's key range
+	_, _, err = authApplier.Put(&pb.PutRequest{
+		Key:   []byte(key),
+		Value: []byte("1"),
+		Lease: leaseID,
+	})
+	require.NoError(t, err)
+
+	// The user should be able to transfer the lease
+	resp, err := authApplier.LeaseTransfer(&pb.LeaseTransferRequest{
+		ID: leaseID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), resp.Epoch)
+
+	// Put a key under the lease outside user's key range
+	setAuthInfo(authApplier, userRoot)
+	_, _, err = authApplier.Put(&pb.PutRequest{
+		Key:   []byte(keyOutsideRange),
+		Value: []byte("1"),
+		Lease: leaseID,
+	})
+	require.NoError(t, err)
+
+	// The user should not be able to transfer the lease anymore
+	setAuthInfo(authApplier, userWriteOnly)
+	_, err = authApplier.LeaseTransfer(&pb.LeaseTransferRequest{
+		ID: leaseID,
+	})
+	require.Equal(t, err, auth.ErrPermissionDenied)
+}
+
 // TestAuthApplierV3_UserGet verifies UserGet can only be performed by the user itself or the root
 func TestAuthApplierV3_UserGet(t *testing.T) {
 	tcs := []struct {
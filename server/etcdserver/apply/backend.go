@@ -86,6 +86,7 @@ func (a *applierV3backend) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantR
 	if err == nil {
 		resp.ID = int64(l.ID)
 		resp.TTL = l.TTL()
+		resp.Epoch = l.Epoch()
 		resp.Header = a.newHeader()
 	}
 	return resp, err
@@ -96,6 +97,23 @@ func (a *applierV3backend) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevo
 	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
 }
 
+func (a *applierV3backend) LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	err := a.options.Lessor.Expire(lease.LeaseID(lc.ID))
+	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
+}
+
+func (a *applierV3backend) LeaseTransfer(lc *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	l, err := a.options.Lessor.Transfer(lease.LeaseID(lc.ID))
+	resp := &pb.LeaseTransferResponse{}
+	if err == nil {
+		resp.ID = int64(l.ID)
+		resp.TTL = l.TTL()
+		resp.Epoch = l.Epoch()
+		resp.Header = a.newHeader()
+	}
+	return resp, err
+}
+
 func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error) {
 	for _, c := range lc.Checkpoints {
 		err := a.options.Lessor.Checkpoint(lease.LeaseID(c.ID), c.Remaining_TTL)
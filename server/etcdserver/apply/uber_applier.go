@@ -149,6 +149,12 @@ func (a *uberApplier) dispatch(r *InternalRaftRequestWrapper, shouldApplyV3 memb
 	case r.LeaseRevoke != nil:
 		op = "LeaseRevoke"
 		ar.Resp, ar.Err = a.applyV3.LeaseRevoke(r.LeaseRevoke)
+	case r.LeaseExpire != nil:
+		op = "LeaseExpire"
+		ar.Resp, ar.Err = a.applyV3.LeaseExpire(r.LeaseExpire)
+	case r.LeaseTransfer != nil:
+		op = "LeaseTransfer"
+		ar.Resp, ar.Err = a.applyV3.LeaseTransfer(r.LeaseTransfer)
 	case r.LeaseCheckpoint != nil:
 		op = "LeaseCheckpoint"
 		ar.Resp, ar.Err = a.applyV3.LeaseCheckpoint(r.LeaseCheckpoint)
@@ -43,6 +43,8 @@ var (
 	ErrClusterVersionUnavailable   = errors.New("etcdserver: cluster version not found during downgrade")
 	ErrWrongDowngradeVersionFormat = errors.New("etcdserver: wrong downgrade target version format")
 	ErrKeyNotFound                 = errors.New("etcdserver: key not found")
+	ErrLeaseTransferNotSupported   = errors.New("etcdserver: lease transfer requires cluster version 3.8 or later")
+	ErrInvalidRangeCursor          = errors.New("etcdserver: invalid range cursor")
 )
 
 type DiscoveryError struct {
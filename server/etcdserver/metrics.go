@@ -148,6 +148,12 @@ var (
 		},
 		[]string{"name", "stage"},
 	)
+	maxClockSkew = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "max_clock_skew_seconds",
+		Help:      "The maximum estimated clock skew between any two members of the cluster.",
+	})
 	fdUsed = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "os",
 		Subsystem: "fd",
@@ -180,6 +186,7 @@ func init() {
 	prometheus.MustRegister(serverFeatureEnabled)
 	prometheus.MustRegister(learnerPromoteSucceed)
 	prometheus.MustRegister(learnerPromoteFailed)
+	prometheus.MustRegister(maxClockSkew)
 	prometheus.MustRegister(fdUsed)
 	prometheus.MustRegister(fdLimit)
 
@@ -22,7 +22,10 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -50,6 +53,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/etcdserver/api"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	httptypes "go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp/types"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
@@ -70,6 +74,7 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/storage/schema"
+	"go.etcd.io/etcd/server/v3/storage/wal"
 	"go.etcd.io/raft/v3"
 	"go.etcd.io/raft/v3/raftpb"
 )
@@ -93,6 +98,10 @@ const (
 
 	purgeFileInterval = 30 * time.Second
 
+	// clockSkewCheckInterval is the interval between two updates of the
+	// estimated clock skew between members.
+	clockSkewCheckInterval = 5 * time.Second
+
 	// max number of in-flight snapshot messages etcdserver allows to have
 	// This number is more than enough for most clusters with 5 machines.
 	maxInFlightMsgSnap = 16
@@ -213,6 +222,14 @@ type EtcdServer struct {
 	term              atomic.Uint64
 	lead              atomic.Uint64
 
+	// maxSnapFiles and maxWALFiles are the numbers of snapshot and WAL files
+	// to retain. They start from the configuration, and are changed at runtime
+	// by SetFileRetention.
+	maxSnapFiles atomic.Uint64
+	maxWALFiles  atomic.Uint64
+	// purgec triggers a purge of the files exceeding the retention limits.
+	purgec chan struct{}
+
 	consistIndex cindex.ConsistentIndexer // consistIndex is used to get/set/save consistentIndex
 	r            raftNode                 // uses 64-bit atomics; keep 64-bit aligned.
 
@@ -232,6 +249,9 @@ type EtcdServer struct {
 	stopping chan struct{}
 	// done is closed when all goroutines from start() complete.
 	done chan struct{}
+	// drainc is closed when the client servers start shutting down.
+	drainc    chan struct{}
+	drainOnce sync.Once
 	// leaderChanged is used to notify the linearizable read loop to drop the old read requests.
 	leaderChanged *notify.Notifier
 
@@ -260,6 +280,9 @@ type EtcdServer struct {
 
 	// compactor is used to auto-compact the KV.
 	compactor v3compactor.Compactor
+	// autoMaintenance compacts and defragments the KV under quota pressure,
+	// nil if disabled.
+	autoMaintenance *automaintenance.Engine
 
 	// peerRt used to send requests (version, lease) to peers.
 	peerRt   http.RoundTripper
@@ -316,6 +339,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		lgMu:                  new(sync.RWMutex),
 		lg:                    cfg.Logger,
 		errorc:                make(chan error, 1),
+		drainc:                make(chan struct{}),
 		snapshotter:           b.ss,
 		r:                     *b.raft.newRaftNode(b.ss, b.storage.wal.w, b.cluster.cl),
 		memberID:              b.cluster.nodeID,
@@ -329,7 +353,10 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		consistIndex:          b.storage.backend.ci,
 		firstCommitInTerm:     notify.NewNotifier(),
 		clusterVersionChanged: notify.NewNotifier(),
+		purgec:                make(chan struct{}, 1),
 	}
+	srv.maxSnapFiles.Store(uint64(cfg.MaxSnapFiles))
+	srv.maxWALFiles.Store(uint64(cfg.MaxWALFiles))
 
 	addFeatureGateMetrics(cfg.ServerFeatureGate, serverFeatureEnabled)
 	serverID.With(prometheus.Labels{"server_id": b.cluster.nodeID.String()}).Set(1)
@@ -362,6 +389,10 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 	mvccStoreConfig := mvcc.StoreConfig{
 		CompactionBatchLimit:    cfg.CompactionBatchLimit,
 		CompactionSleepInterval: cfg.CompactionSleepInterval,
+		WatchSyncFairness:       cfg.WatchSyncFairness,
+	}
+	if cfg.ServerFeatureGate.Enabled(features.ValueChecksum) {
+		mvccStoreConfig.ValueEncoding = mvcc.ValueEncodingChecksum
 	}
 	srv.kv = mvcc.New(srv.Logger(), srv.be, srv.lessor, mvccStoreConfig)
 	srv.corruptionChecker = newCorruptionChecker(cfg.Logger, srv, srv.kv.HashStorage())
@@ -383,6 +414,12 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		}
 		srv.compactor.Run()
 	}
+	if cfg.AutoMaintenance.Enabled() {
+		srv.autoMaintenance, err = automaintenance.New(cfg.Logger, cfg.AutoMaintenance, &autoMaintenanceAdapter{EtcdServer: srv})
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if err = srv.restoreAlarms(); err != nil {
 		return nil, err
@@ -421,6 +458,9 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		LeaderStats: lstats,
 		ErrorC:      srv.errorc,
 	}
+	if offset := cfg.ClockOffset; offset != 0 {
+		tr.Clock = func() time.Time { return time.Now().Add(offset) }
+	}
 	if err = tr.Start(); err != nil {
 		return nil, err
 	}
@@ -538,6 +578,8 @@ func (s *EtcdServer) Start() {
 	s.GoAttach(s.monitorKVHash)
 	s.GoAttach(s.monitorCompactHash)
 	s.GoAttach(s.monitorDowngrade)
+	s.GoAttach(s.monitorClockSkew)
+	s.GoAttach(s.monitorAutoMaintenance)
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -596,35 +638,112 @@ func (s *EtcdServer) start() {
 
 func (s *EtcdServer) purgeFile() {
 	lg := s.Logger()
-	var dberrc, serrc, werrc <-chan error
-	var dbdonec, sdonec, wdonec <-chan struct{}
-	if s.Cfg.MaxSnapFiles > 0 {
-		dbdonec, dberrc = fileutil.PurgeFileWithoutFlock(lg, s.Cfg.SnapDir(), "snap.db", s.Cfg.MaxSnapFiles, purgeFileInterval, s.stopping)
-		sdonec, serrc = fileutil.PurgeFileWithoutFlock(lg, s.Cfg.SnapDir(), "snap", s.Cfg.MaxSnapFiles, purgeFileInterval, s.stopping)
+	lg.Info("started to purge files",
+		zap.String("snap-dir", s.Cfg.SnapDir()),
+		zap.String("wal-dir", s.Cfg.WALDir()),
+		zap.Uint64("max-snapshots", s.maxSnapFiles.Load()),
+		zap.Uint64("max-wals", s.maxWALFiles.Load()),
+		zap.Duration("interval", purgeFileInterval))
+	triggered := false
+	for {
+		purged, err := s.purgeFiles()
+		if err != nil {
+			lg.Fatal("failed to purge files", zap.Error(err))
+		}
+		if triggered {
+			lg.Info("purged files exceeding the retention limits", zap.Strings("removed", purged))
+		}
+		select {
+		case <-time.After(purgeFileInterval):
+			triggered = false
+		case <-s.purgec:
+			triggered = true
+		case <-s.stopping:
+			return
+		}
 	}
-	if s.Cfg.MaxWALFiles > 0 {
-		wdonec, werrc = fileutil.PurgeFileWithDoneNotify(lg, s.Cfg.WALDir(), "wal", s.Cfg.MaxWALFiles, purgeFileInterval, s.stopping)
+}
+
+// purgeFiles removes the oldest snapshot and WAL files exceeding the retention
+// limits, and returns their paths. WAL files still locked by the WAL are kept.
+func (s *EtcdServer) purgeFiles() ([]string, error) {
+	lg := s.Logger()
+	var removed []string
+	for _, p := range []struct {
+		dir    string
+		suffix string
+		max    uint64
+		flock  bool
+	}{
+		{s.Cfg.SnapDir(), "snap.db", s.maxSnapFiles.Load(), false},
+		{s.Cfg.SnapDir(), "snap", s.maxSnapFiles.Load(), false},
+		{s.Cfg.WALDir(), "wal", s.maxWALFiles.Load(), true},
+	} {
+		if p.max == 0 {
+			continue
+		}
+		purged, err := fileutil.PurgeFileOnce(lg, p.dir, p.suffix, uint(p.max), p.flock)
+		if err != nil {
+			return removed, fmt.Errorf("failed to purge %s files: %w", p.suffix, err)
+		}
+		for _, name := range purged {
+			removed = append(removed, filepath.Join(p.dir, name))
+		}
 	}
+	return removed, nil
+}
 
+// SetFileRetention changes the numbers of snapshot and WAL files to retain,
+// 0 meaning unlimited, and purges the files exceeding the new limits right
+// away.
+func (s *EtcdServer) SetFileRetention(maxSnapFiles, maxWALFiles uint) {
+	oldSnap := s.maxSnapFiles.Swap(uint64(maxSnapFiles))
+	oldWAL := s.maxWALFiles.Swap(uint64(maxWALFiles))
+	s.Logger().Info("updated file retention",
+		zap.Uint64("previous-max-snapshots", oldSnap),
+		zap.Uint("max-snapshots", maxSnapFiles),
+		zap.Uint64("previous-max-wals", oldWAL),
+		zap.Uint("max-wals", maxWALFiles))
 	select {
-	case e := <-dberrc:
-		lg.Fatal("failed to purge snap db file", zap.Error(e))
-	case e := <-serrc:
-		lg.Fatal("failed to purge snap file", zap.Error(e))
-	case e := <-werrc:
-		lg.Fatal("failed to purge wal file", zap.Error(e))
-	case <-s.stopping:
-		if dbdonec != nil {
-			<-dbdonec
-		}
-		if sdonec != nil {
-			<-sdonec
+	case s.purgec <- struct{}{}:
+	default:
+	}
+}
+
+// DiskUsage is the disk usage of the WAL and snapshot files of a member.
+type DiskUsage struct {
+	WAL           wal.Usage
+	SnapshotFiles int64
+	SnapshotBytes int64
+}
+
+// DiskUsage returns the disk usage of the WAL and snapshot files.
+func (s *EtcdServer) DiskUsage() (DiskUsage, error) {
+	var du DiskUsage
+	var err error
+	if du.WAL, err = wal.DirUsage(s.Logger(), s.Cfg.WALDir()); err != nil {
+		return DiskUsage{}, err
+	}
+	names, err := fileutil.ReadDir(s.Cfg.SnapDir())
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".snap") && !strings.HasSuffix(name, ".snap.db") {
+			continue
 		}
-		if wdonec != nil {
-			<-wdonec
+		fi, err := os.Stat(filepath.Join(s.Cfg.SnapDir(), name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// purged in the meantime
+				continue
+			}
+			return DiskUsage{}, err
 		}
-		return
+		du.SnapshotFiles++
+		du.SnapshotBytes += fi.Size()
 	}
+	return du, nil
 }
 
 func (s *EtcdServer) Cluster() api.Cluster { return s.cluster }
@@ -882,7 +1001,7 @@ func (s *EtcdServer) revokeExpiredLeases(leases []*lease.Lease) {
 			f := func(lid int64) {
 				s.GoAttach(func() {
 					ctx := s.authStore.WithRoot(s.ctx)
-					_, lerr := s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: lid})
+					lerr := s.leaseExpire(ctx, lid)
 					if lerr == nil {
 						leaseExpired.Inc()
 					} else {
@@ -1346,6 +1465,16 @@ func (s *EtcdServer) StopNotify() <-chan struct{} { return s.done }
 // when the server is being stopped.
 func (s *EtcdServer) StoppingNotify() <-chan struct{} { return s.stopping }
 
+// DrainClients notifies the client-facing services that the servers serving
+// them are about to shut down, so that long-lived streams can end gracefully.
+// It should be called before the gRPC servers are stopped.
+func (s *EtcdServer) DrainClients() {
+	s.drainOnce.Do(func() { close(s.drainc) })
+}
+
+// DrainNotify returns a channel that is closed when DrainClients is called.
+func (s *EtcdServer) DrainNotify() <-chan struct{} { return s.drainc }
+
 func (s *EtcdServer) checkMembershipOperationPermission(ctx context.Context) error {
 	if s.authStore == nil {
 		// In the context of ordinary etcd process, s.authStore will never be nil.
@@ -2345,6 +2474,64 @@ func (s *EtcdServer) monitorDowngrade() {
 	}
 }
 
+// MaxClockSkew returns the maximum estimated clock skew between any two
+// members of the cluster, this member included, based on the samples of
+// the clocks of its peers.
+func (s *EtcdServer) MaxClockSkew() time.Duration {
+	var lo, hi time.Duration
+	for _, offset := range s.r.transport.ClockSkews() {
+		lo = min(lo, offset)
+		hi = max(hi, offset)
+	}
+	return hi - lo
+}
+
+func (s *EtcdServer) monitorClockSkew() {
+	lg := s.Logger()
+	for {
+		select {
+		case <-time.After(clockSkewCheckInterval):
+		case <-s.stopping:
+			return
+		}
+
+		skew := s.MaxClockSkew()
+		maxClockSkew.Set(skew.Seconds())
+		if !s.isLeader() || s.Cfg.ClockSkewWarningThreshold <= 0 || skew <= s.Cfg.ClockSkewWarningThreshold {
+			continue
+		}
+		fields := []zap.Field{
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Duration("max-clock-skew", skew),
+			zap.Duration("clock-skew-warning-threshold", s.Cfg.ClockSkewWarningThreshold),
+		}
+		for id, offset := range s.r.transport.ClockSkews() {
+			fields = append(fields, zap.Duration("clock-offset-"+id.String(), offset))
+		}
+		lg.Warn("clock skew between members is above the threshold", fields...)
+	}
+}
+
+// AutoMaintenance returns the auto maintenance engine of the server, nil if
+// it is disabled.
+func (s *EtcdServer) AutoMaintenance() *automaintenance.Engine { return s.autoMaintenance }
+
+func (s *EtcdServer) monitorAutoMaintenance() {
+	if s.autoMaintenance == nil {
+		return
+	}
+	for {
+		select {
+		case <-time.After(s.autoMaintenance.CheckInterval()):
+		case <-s.stopping:
+			return
+		}
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		s.autoMaintenance.Check(ctx)
+		cancel()
+	}
+}
+
 func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 	switch {
 	case errorspkg.Is(err, context.Canceled):
@@ -1417,20 +1417,21 @@ func newNopTransporter() rafthttp.Transporter {
 	return &nopTransporter{}
 }
 
-func (s *nopTransporter) Start() error                        { return nil }
-func (s *nopTransporter) Handler() http.Handler               { return nil }
-func (s *nopTransporter) Send(m []*raftpb.Message)            {}
-func (s *nopTransporter) SendSnapshot(m *snap.Message)        {}
-func (s *nopTransporter) AddRemote(id types.ID, us []string)  {}
-func (s *nopTransporter) AddPeer(id types.ID, us []string)    {}
-func (s *nopTransporter) RemovePeer(id types.ID)              {}
-func (s *nopTransporter) RemoveAllPeers()                     {}
-func (s *nopTransporter) UpdatePeer(id types.ID, us []string) {}
-func (s *nopTransporter) ActiveSince(id types.ID) time.Time   { return time.Time{} }
-func (s *nopTransporter) ActivePeers() int                    { return 0 }
-func (s *nopTransporter) Stop()                               {}
-func (s *nopTransporter) Pause()                              {}
-func (s *nopTransporter) Resume()                             {}
+func (s *nopTransporter) Start() error                           { return nil }
+func (s *nopTransporter) Handler() http.Handler                  { return nil }
+func (s *nopTransporter) Send(m []*raftpb.Message)               {}
+func (s *nopTransporter) SendSnapshot(m *snap.Message)           {}
+func (s *nopTransporter) AddRemote(id types.ID, us []string)     {}
+func (s *nopTransporter) AddPeer(id types.ID, us []string)       {}
+func (s *nopTransporter) RemovePeer(id types.ID)                 {}
+func (s *nopTransporter) RemoveAllPeers()                        {}
+func (s *nopTransporter) UpdatePeer(id types.ID, us []string)    {}
+func (s *nopTransporter) ActiveSince(id types.ID) time.Time      { return time.Time{} }
+func (s *nopTransporter) ActivePeers() int                       { return 0 }
+func (s *nopTransporter) ClockSkews() map[types.ID]time.Duration { return nil }
+func (s *nopTransporter) Stop()                                  {}
+func (s *nopTransporter) Pause()                                 {}
+func (s *nopTransporter) Resume()                                {}
 
 type snapTransporter struct {
 	nopTransporter
@@ -1596,3 +1597,91 @@ func TestAddFeatureGateMetrics(t *testing.T) {
 	err := ptestutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected), "etcd_server_feature_enabled")
 	require.NoErrorf(t, err, "unexpected metric collection result: \n%s", err)
 }
+
+type clockSkewTransporter struct {
+	nopTransporter
+	skews map[types.ID]time.Duration
+}
+
+func (s *clockSkewTransporter) ClockSkews() map[types.ID]time.Duration { return s.skews }
+
+func TestMaxClockSkew(t *testing.T) {
+	tests := []struct {
+		name  string
+		skews map[types.ID]time.Duration
+		want  time.Duration
+	}{
+		{
+			name: "no samples",
+			want: 0,
+		},
+		{
+			name:  "peers ahead",
+			skews: map[types.ID]time.Duration{1: time.Second, 2: 3 * time.Second},
+			want:  3 * time.Second,
+		},
+		{
+			name:  "peers behind",
+			skews: map[types.ID]time.Duration{1: -time.Second, 2: -2 * time.Second},
+			want:  2 * time.Second,
+		},
+		{
+			name:  "peers ahead and behind",
+			skews: map[types.ID]time.Duration{1: time.Second, 2: -2 * time.Second},
+			want:  3 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &EtcdServer{
+				r: *newRaftNode(raftNodeConfig{
+					lg:        zaptest.NewLogger(t),
+					transport: &clockSkewTransporter{skews: tt.skews},
+				}),
+			}
+			if got := s.MaxClockSkew(); got != tt.want {
+				t.Errorf("MaxClockSkew() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFileRetention(t *testing.T) {
+	dataDir := t.TempDir()
+	s := &EtcdServer{
+		lgMu:   new(sync.RWMutex),
+		lg:     zaptest.NewLogger(t),
+		Cfg:    config.ServerConfig{DataDir: dataDir},
+		purgec: make(chan struct{}, 1),
+	}
+	require.NoError(t, os.MkdirAll(s.Cfg.SnapDir(), 0o700))
+	require.NoError(t, os.MkdirAll(s.Cfg.WALDir(), 0o700))
+	for i := 1; i <= 5; i++ {
+		name := fmt.Sprintf("%016x-%016x", 1, i)
+		require.NoError(t, os.WriteFile(filepath.Join(s.Cfg.SnapDir(), name+".snap"), []byte("snap"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(s.Cfg.WALDir(), fmt.Sprintf("%016x-%016x.wal", i, i*10)), []byte("wal"), 0o600))
+	}
+
+	s.SetFileRetention(0, 0)
+	purged, err := s.purgeFiles()
+	require.NoError(t, err)
+	require.Empty(t, purged)
+
+	s.SetFileRetention(2, 3)
+	select {
+	case <-s.purgec:
+	default:
+		t.Fatal("expected a purge to be triggered")
+	}
+	purged, err = s.purgeFiles()
+	require.NoError(t, err)
+	require.Len(t, purged, 5)
+
+	du, err := s.DiskUsage()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), du.SnapshotFiles)
+	assert.Equal(t, int64(8), du.SnapshotBytes)
+	assert.Equal(t, int64(3), du.WAL.Files)
+	assert.Equal(t, int64(9), du.WAL.Bytes)
+	assert.Equal(t, uint64(30), du.WAL.OldestIndex)
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// rangeCursorVersion is the version of the encoding of range cursors, so
+// that members can tell the cursors they do not understand.
+const rangeCursorVersion = 1
+
+const rangeCursorDowngraded = 1 << 0
+
+// rangeCursor is the position of a paginated range. It is encoded into the
+// opaque cursor of range requests and responses as the version, the flags,
+// the varint revision and the key.
+type rangeCursor struct {
+	// rev is the revision the range is read at.
+	rev int64
+	// key is the first key of the next page.
+	key []byte
+	// downgraded is set once the range had to move past a compaction of the
+	// revision it was pinned to.
+	downgraded bool
+}
+
+func (c rangeCursor) encode() []byte {
+	var flags byte
+	if c.downgraded {
+		flags |= rangeCursorDowngraded
+	}
+	b := make([]byte, 0, 2+binary.MaxVarintLen64+len(c.key))
+	b = append(b, rangeCursorVersion, flags)
+	b = binary.AppendVarint(b, c.rev)
+	return append(b, c.key...)
+}
+
+func decodeRangeCursor(b []byte) (rangeCursor, error) {
+	if len(b) < 2 || b[0] != rangeCursorVersion {
+		return rangeCursor{}, errors.ErrInvalidRangeCursor
+	}
+	c := rangeCursor{downgraded: b[1]&rangeCursorDowngraded != 0}
+	rev, n := binary.Varint(b[2:])
+	if n <= 0 || rev <= 0 {
+		return rangeCursor{}, errors.ErrInvalidRangeCursor
+	}
+	c.rev = rev
+	c.key = b[2+n:]
+	return c, nil
+}
+
+// resolveRangeCursor returns the position to continue the range of r from.
+// If the revision of the cursor has been compacted, the range continues at
+// the first revision left and the cursor is marked as downgraded.
+func resolveRangeCursor(rv mvcc.ReadView, r *pb.RangeRequest) (rangeCursor, error) {
+	c, err := decodeRangeCursor(r.Cursor)
+	if err != nil {
+		return rangeCursor{}, err
+	}
+	if r.Revision != 0 && r.Revision != c.rev {
+		return rangeCursor{}, errors.ErrInvalidRangeCursor
+	}
+	if bytes.Compare(c.key, r.Key) < 0 {
+		c.key = r.Key
+	}
+	switch {
+	case c.rev > rv.Rev():
+		return rangeCursor{}, mvcc.ErrFutureRev
+	case c.rev < rv.FirstRev():
+		c.rev, c.downgraded = rv.FirstRev(), true
+	}
+	return c, nil
+}
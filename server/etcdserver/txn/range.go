@@ -59,16 +59,26 @@ func Range(ctx context.Context, lg *zap.Logger, kv mvcc.KV, r *pb.RangeRequest,
 func executeRange(ctx context.Context, lg *zap.Logger, txnRead mvcc.TxnRead, r *pb.RangeRequest, withTotalCount bool) (*pb.RangeResponse, error) {
 	trace := traceutil.Get(ctx)
 
+	key, rev := r.Key, r.Revision
+	var cursor rangeCursor
+	if len(r.Cursor) != 0 {
+		var err error
+		if cursor, err = resolveRangeCursor(txnRead, r); err != nil {
+			return nil, err
+		}
+		key, rev = cursor.key, cursor.rev
+	}
+
 	limit := rangeLimit(r)
 	ro := mvcc.RangeOptions{
 		Limit:          limit,
-		Rev:            r.Revision,
+		Rev:            rev,
 		CountOnly:      r.CountOnly,
 		FastKeysOnly:   r.KeysOnly && r.SortTarget != pb.RangeRequest_VALUE,
 		WithTotalCount: withTotalCount,
 	}
 
-	rr, err := txnRead.Range(ctx, r.Key, mkGteRange(r.RangeEnd), ro)
+	rr, err := txnRead.Range(ctx, key, mkGteRange(r.RangeEnd), ro)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +88,16 @@ func executeRange(ctx context.Context, lg *zap.Logger, txnRead mvcc.TxnRead, r *
 	trace.Step("filter and sort the key-value pairs")
 
 	resp := asembleRangeResponse(rr, r)
+	resp.ConsistencyDowngraded = cursor.downgraded
+	if resp.More && len(resp.Kvs) != 0 && IsDefaultOrdering(r.SortTarget, r.SortOrder) {
+		if rev <= 0 {
+			rev = rr.Rev
+		}
+		next := cursor
+		next.rev = rev
+		next.key = append(bytes.Clone(resp.Kvs[len(resp.Kvs)-1].Key), '\x00')
+		resp.NextCursor = next.encode()
+	}
 	trace.Step("assemble the response")
 
 	return resp, nil
@@ -172,6 +192,10 @@ func asembleRangeResponse(rr *mvcc.RangeResult, r *pb.RangeRequest) *pb.RangeRes
 }
 
 func checkRange(rv mvcc.ReadView, req *pb.RangeRequest) error {
+	if len(req.Cursor) != 0 {
+		_, err := resolveRangeCursor(rv, req)
+		return err
+	}
 	switch {
 	case req.Revision == 0:
 		return nil
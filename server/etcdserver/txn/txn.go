@@ -276,12 +276,7 @@ func applyCompare(rv mvcc.ReadView, c *pb.Compare) bool {
 		return false
 	}
 	if len(rr.KVs) == 0 {
-		if c.Target == pb.Compare_VALUE {
-			// Always fail if comparing a value on a key/keys that doesn't exist;
-			// nil == empty string in grpc; no way to represent missing value
-			return false
-		}
-		return compareKV(c, &mvccpb.KeyValue{})
+		return CompareKeyValue(c, nil)
 	}
 	for _, kv := range rr.KVs {
 		if !compareKV(c, kv) {
@@ -291,6 +286,20 @@ func applyCompare(rv mvcc.ReadView, c *pb.Compare) bool {
 	return true
 }
 
+// CompareKeyValue applies the compare request to the key-value pair kv of a
+// single key, which is nil if the key does not exist.
+func CompareKeyValue(c *pb.Compare, kv *mvccpb.KeyValue) bool {
+	if kv == nil {
+		if c.Target == pb.Compare_VALUE {
+			// Always fail if comparing a value on a key/keys that doesn't exist;
+			// nil == empty string in grpc; no way to represent missing value
+			return false
+		}
+		kv = &mvccpb.KeyValue{}
+	}
+	return compareKV(c, kv)
+}
+
 func compareKV(c *pb.Compare, ckv *mvccpb.KeyValue) bool {
 	var result int
 	rev := int64(0)
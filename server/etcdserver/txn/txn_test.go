@@ -28,6 +28,7 @@ import (
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
 	"go.etcd.io/etcd/server/v3/lease"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
@@ -276,6 +277,67 @@ func TestCheckRange(t *testing.T) {
 	}
 }
 
+func TestRangeCursor(t *testing.T) {
+	s, _ := setup(t, testSetup{})
+	lg := zaptest.NewLogger(t)
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		s.Put([]byte(k), []byte("v"), 0)
+	}
+	pinned := s.Rev()
+	keys := func(resp *pb.RangeResponse) (ks []string) {
+		for _, kv := range resp.Kvs {
+			ks = append(ks, string(kv.Key))
+		}
+		return ks
+	}
+	req := &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("z"), Limit: 3}
+
+	resp, _, err := Range(t.Context(), lg, s, req, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b", "c"}, keys(resp))
+	require.True(t, resp.More)
+	require.NotEmpty(t, resp.NextCursor)
+
+	// changes after the first page are not seen by the following ones
+	s.Put([]byte("bb"), []byte("v"), 0)
+	s.DeleteRange([]byte("d"), nil)
+	req.Cursor = resp.NextCursor
+	resp, _, err = Range(t.Context(), lg, s, req, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "e", "f"}, keys(resp))
+	require.False(t, resp.ConsistencyDowngraded)
+
+	// once the pinned revision is compacted, the range continues at the
+	// compacted revision and reports the downgraded consistency
+	s.Put([]byte("ga"), []byte("v"), 0)
+	done, err := s.Compact(traceutil.TODO(), s.Rev())
+	require.NoError(t, err)
+	<-done
+	require.Greater(t, s.Rev(), pinned)
+	req.Cursor = resp.NextCursor
+	resp, _, err = Range(t.Context(), lg, s, req, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"g", "ga", "h"}, keys(resp))
+	require.True(t, resp.ConsistencyDowngraded)
+	require.True(t, resp.More)
+
+	req.Cursor = resp.NextCursor
+	resp, _, err = Range(t.Context(), lg, s, req, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"i", "j"}, keys(resp))
+	require.True(t, resp.ConsistencyDowngraded)
+	require.False(t, resp.More)
+	require.Empty(t, resp.NextCursor)
+
+	// the cursor cannot be used with another revision
+	req.Revision = pinned
+	_, _, err = Range(t.Context(), lg, s, req, false)
+	require.ErrorIs(t, err, errors.ErrInvalidRangeCursor)
+
+	_, _, err = Range(t.Context(), lg, s, &pb.RangeRequest{Key: []byte("a"), RangeEnd: []byte("z"), Cursor: []byte("foo")}, false)
+	require.ErrorIs(t, err, errors.ErrInvalidRangeCursor)
+}
+
 func setup(t *testing.T, setup testSetup) (mvcc.KV, lease.Lessor) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
 	t.Cleanup(func() {
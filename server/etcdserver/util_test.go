@@ -77,21 +77,22 @@ func newNopTransporterWithActiveTime(memberIDs []types.ID) rafthttp.Transporter
 	return &nopTransporterWithActiveTime{activeMap: am}
 }
 
-func (s *nopTransporterWithActiveTime) Start() error                        { return nil }
-func (s *nopTransporterWithActiveTime) Handler() http.Handler               { return nil }
-func (s *nopTransporterWithActiveTime) Send(m []*raftpb.Message)            {}
-func (s *nopTransporterWithActiveTime) SendSnapshot(m *snap.Message)        {}
-func (s *nopTransporterWithActiveTime) AddRemote(id types.ID, us []string)  {}
-func (s *nopTransporterWithActiveTime) AddPeer(id types.ID, us []string)    {}
-func (s *nopTransporterWithActiveTime) RemovePeer(id types.ID)              {}
-func (s *nopTransporterWithActiveTime) RemoveAllPeers()                     {}
-func (s *nopTransporterWithActiveTime) UpdatePeer(id types.ID, us []string) {}
-func (s *nopTransporterWithActiveTime) ActiveSince(id types.ID) time.Time   { return s.activeMap[id] }
-func (s *nopTransporterWithActiveTime) ActivePeers() int                    { return 0 }
-func (s *nopTransporterWithActiveTime) Stop()                               {}
-func (s *nopTransporterWithActiveTime) Pause()                              {}
-func (s *nopTransporterWithActiveTime) Resume()                             {}
-func (s *nopTransporterWithActiveTime) reset(am map[types.ID]time.Time)     { s.activeMap = am }
+func (s *nopTransporterWithActiveTime) Start() error                           { return nil }
+func (s *nopTransporterWithActiveTime) Handler() http.Handler                  { return nil }
+func (s *nopTransporterWithActiveTime) Send(m []*raftpb.Message)               {}
+func (s *nopTransporterWithActiveTime) SendSnapshot(m *snap.Message)           {}
+func (s *nopTransporterWithActiveTime) AddRemote(id types.ID, us []string)     {}
+func (s *nopTransporterWithActiveTime) AddPeer(id types.ID, us []string)       {}
+func (s *nopTransporterWithActiveTime) RemovePeer(id types.ID)                 {}
+func (s *nopTransporterWithActiveTime) RemoveAllPeers()                        {}
+func (s *nopTransporterWithActiveTime) UpdatePeer(id types.ID, us []string)    {}
+func (s *nopTransporterWithActiveTime) ActiveSince(id types.ID) time.Time      { return s.activeMap[id] }
+func (s *nopTransporterWithActiveTime) ActivePeers() int                       { return 0 }
+func (s *nopTransporterWithActiveTime) ClockSkews() map[types.ID]time.Duration { return nil }
+func (s *nopTransporterWithActiveTime) Stop()                                  {}
+func (s *nopTransporterWithActiveTime) Pause()                                 {}
+func (s *nopTransporterWithActiveTime) Resume()                                {}
+func (s *nopTransporterWithActiveTime) reset(am map[types.ID]time.Time)        { s.activeMap = am }
 
 func TestExceedsRequestLimit(t *testing.T) {
 	tests := []struct {
@@ -139,6 +140,14 @@ func TestExceedsRequestLimit(t *testing.T) {
 			name:           "Test critical request and gap is larger than maxGapBetweenApplyAndCommitIndex without priority check",
 			enablePriority: false,
 		},
+		{
+			ci:             1 + maxGapBetweenApplyAndCommitIndex + 1,
+			ai:             1,
+			expectedResult: false,
+			req:            &pb.InternalRaftRequest{LeaseExpire: &pb.LeaseRevokeRequest{}},
+			name:           "Test lease expiry and gap is larger than maxGapBetweenApplyAndCommitIndex with priority check",
+			enablePriority: true,
+		},
 		{
 			ci:             1 + 2*maxGapBetweenApplyAndCommitIndex + 1,
 			ai:             1,
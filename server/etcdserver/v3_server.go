@@ -72,15 +72,23 @@ type Lessor interface {
 	// LeaseRevoke sends LeaseRevoke request to raft and toApply it after committed.
 	LeaseRevoke(ctx context.Context, r *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
 
+	// LeaseTransfer sends LeaseTransfer request to raft and toApply it after committed.
+	LeaseTransfer(ctx context.Context, r *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error)
+
 	// LeaseRenew renews the lease with given ID. The renewed TTL is returned. Or an error
-	// is returned.
-	LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error)
+	// is returned. A non-zero epoch must match the lease's current epoch, otherwise
+	// lease.ErrLeaseTransferred is returned.
+	LeaseRenew(ctx context.Context, id lease.LeaseID, epoch uint64) (int64, error)
 
 	// LeaseTimeToLive retrieves lease information.
 	LeaseTimeToLive(ctx context.Context, r *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error)
 
 	// LeaseLeases lists all leases.
 	LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error)
+
+	// LeaseWatch watches the lifecycle events of the lease with the given ID, or of all
+	// leases if the ID is 0. The returned function stops the watch.
+	LeaseWatch(ctx context.Context, r *pb.LeaseWatchRequest) (<-chan lease.Event, func(), error)
 }
 
 type Authenticator interface {
@@ -252,6 +260,7 @@ func (s *EtcdServer) rangeStream(ctx context.Context, r *pb.RangeRequest, rs pb.
 			out.More = resp.More
 			out.Count = count
 			if resp.More {
+				out.NextCursor = resp.NextCursor
 				remaining, cerr := txn.Count(ctx, s.Logger(), s.KV(), nextKey, r.RangeEnd, r.Revision)
 				if cerr != nil {
 					return cerr
@@ -485,7 +494,44 @@ func (s *EtcdServer) LeaseRevoke(ctx context.Context, r *pb.LeaseRevokeRequest)
 	return resp.(*pb.LeaseRevokeResponse), nil
 }
 
-func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, error) {
+// leaseExpire revokes an expired lease. Members older than 3.8 do not apply
+// LeaseExpire requests, so the lease is revoked like by a client until the
+// cluster version reaches 3.8.
+func (s *EtcdServer) leaseExpire(ctx context.Context, id int64) error {
+	r := &pb.LeaseRevokeRequest{ID: id}
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_8) {
+		_, err := s.LeaseRevoke(ctx, r)
+		return err
+	}
+	_, err := s.raftRequest(ctx, &pb.InternalRaftRequest{LeaseExpire: r})
+	return err
+}
+
+func (s *EtcdServer) LeaseTransfer(ctx context.Context, r *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	var span trace.Span
+	ctx, span = traceutil.Tracer.Start(ctx, "lease_transfer", trace.WithAttributes(
+		attribute.Int64("id", r.GetID()),
+	))
+	defer span.End()
+
+	// Members older than 3.8 do not track lease epochs, so applying the
+	// transfer on them would silently diverge from the rest of the cluster.
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_8) {
+		return nil, errors.ErrLeaseTransferNotSupported
+	}
+
+	if err := s.requireAuthInfo(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.raftRequest(ctx, &pb.InternalRaftRequest{LeaseTransfer: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseTransferResponse), nil
+}
+
+func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID, epoch uint64) (int64, error) {
 	var span trace.Span
 	ctx, span = traceutil.Tracer.Start(ctx, "lease_renew", trace.WithAttributes(
 		attribute.Int64("id", int64(id)),
@@ -525,7 +571,7 @@ func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, e
 			return 0, err
 		}
 
-		ttl, err := s.lessor.Renew(id)
+		ttl, err := s.lessor.RenewWithEpoch(id, epoch)
 		if err == nil { // already requested to primary lessor(leader)
 			return ttl, nil
 		}
@@ -550,8 +596,8 @@ func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, e
 
 		for _, url := range leader.PeerURLs {
 			lurl := url + leasehttp.LeasePrefix
-			ttl, err := leasehttp.RenewHTTP(cctx, id, lurl, s.peerRt)
-			if err == nil || errorspkg.Is(err, lease.ErrLeaseNotFound) {
+			ttl, err := leasehttp.RenewHTTP(cctx, id, epoch, lurl, s.peerRt)
+			if err == nil || errorspkg.Is(err, lease.ErrLeaseNotFound) || errorspkg.Is(err, lease.ErrLeaseTransferred) {
 				return ttl, err
 			}
 		}
@@ -747,6 +793,24 @@ func (s *EtcdServer) LeaseLeases(ctx context.Context, _ *pb.LeaseLeasesRequest)
 	return &pb.LeaseLeasesResponse{Header: s.newHeader(), Leases: lss}, nil
 }
 
+func (s *EtcdServer) LeaseWatch(ctx context.Context, r *pb.LeaseWatchRequest) (<-chan lease.Event, func(), error) {
+	id := lease.LeaseID(r.ID)
+	// watch before checking the permissions, so that no event is missed
+	// between the check and the watch
+	evc, cancel := s.lessor.Watch(id)
+	var err error
+	if id == lease.NoLease {
+		err = s.checkLeaseLeases(ctx, s.lessor.Leases())
+	} else {
+		_, err = s.checkLeaseTimeToLive(ctx, id)
+	}
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return evc, cancel, nil
+}
+
 func (s *EtcdServer) checkLeaseLeases(ctx context.Context, leases []*lease.Lease) error {
 	rev := s.AuthStore().Revision()
 
@@ -1148,6 +1212,10 @@ func getRequestType(r *pb.InternalRaftRequest) string {
 		return "LeaseGrant"
 	case r.LeaseRevoke != nil:
 		return "LeaseRevoke"
+	case r.LeaseExpire != nil:
+		return "LeaseExpire"
+	case r.LeaseTransfer != nil:
+		return "LeaseTransfer"
 	case r.LeaseCheckpoint != nil:
 		return "LeaseCheckpoint"
 	case r.Alarm != nil:
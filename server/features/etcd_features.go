@@ -84,6 +84,18 @@ const (
 	// alpha: v3.7
 	// main PR: https://github.com/etcd-io/etcd/pull/20492
 	PriorityRequest featuregate.Feature = "PriorityRequest"
+	// ValueChecksum enables storing every value with its checksum, which is verified whenever the value is read.
+	// Existing values are migrated in the background, and migrated back when the feature is disabled.
+	// alpha: v3.8
+	ValueChecksum featuregate.Feature = "ValueChecksum"
+	// WatchValueSelector enables watchers filtering the events on the fields of
+	// their JSON values with a value selector.
+	// alpha: v3.8
+	WatchValueSelector featuregate.Feature = "WatchValueSelector"
+	// WatchCompare enables watchers only receiving the events that flip the
+	// result of a comparison on their key.
+	// alpha: v3.8
+	WatchCompare featuregate.Feature = "WatchCompare"
 )
 
 var DefaultEtcdServerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -96,6 +108,9 @@ var DefaultEtcdServerFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	SetMemberLocalAddr:           {Default: false, PreRelease: featuregate.Alpha},
 	FastLeaseKeepAlive:           {Default: true, PreRelease: featuregate.Beta},
 	PriorityRequest:              {Default: false, PreRelease: featuregate.Alpha},
+	ValueChecksum:                {Default: false, PreRelease: featuregate.Alpha},
+	WatchValueSelector:           {Default: false, PreRelease: featuregate.Alpha},
+	WatchCompare:                 {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func NewDefaultServerFeatureGate(name string, lg *zap.Logger) featuregate.FeatureGate {
@@ -17,6 +17,7 @@ package lease
 import (
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
@@ -28,6 +29,8 @@ type Lease struct {
 	ID           LeaseID
 	ttl          int64 // time to live of the lease in seconds
 	remainingTTL int64 // remaining time to live in seconds, if zero valued it is considered unset and the full ttl should be used
+	// epoch is incremented every time the lease is transferred to a new holder
+	epoch atomic.Uint64
 	// expiryMu protects concurrent accesses to expiry
 	expiryMu sync.RWMutex
 	// expiry is time when lease should expire. no expiration when expiry.IsZero() is true
@@ -40,12 +43,14 @@ type Lease struct {
 }
 
 func NewLease(id LeaseID, ttl int64) *Lease {
-	return &Lease{
+	l := &Lease{
 		ID:      id,
 		ttl:     ttl,
 		itemSet: make(map[LeaseItem]struct{}),
 		revokec: make(chan struct{}),
 	}
+	l.epoch.Store(1)
+	return l
 }
 
 func (l *Lease) expired() bool {
@@ -54,6 +59,11 @@ func (l *Lease) expired() bool {
 
 func (l *Lease) persistTo(b backend.Backend) {
 	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL}
+	// leases which were never transferred are persisted without epoch, as
+	// by members not supporting transfers
+	if epoch := l.Epoch(); epoch > 1 {
+		lpb.Epoch = epoch
+	}
 	tx := b.BatchTx()
 	tx.LockInsideApply()
 	defer tx.Unlock()
@@ -65,6 +75,12 @@ func (l *Lease) TTL() int64 {
 	return l.ttl
 }
 
+// Epoch returns the epoch of the Lease, which starts at 1 and is incremented
+// every time the lease is transferred.
+func (l *Lease) Epoch() uint64 {
+	return l.epoch.Load()
+}
+
 // SetLeaseItem sets the given lease item, this func is thread-safe
 func (l *Lease) SetLeaseItem(item LeaseItem) {
 	l.mu.Lock()
@@ -0,0 +1,98 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import "sync"
+
+// EventType is the type of a lease lifecycle event.
+type EventType int
+
+const (
+	// EventGranted is sent when a lease is granted.
+	EventGranted EventType = iota
+	// EventRevoked is sent when a lease is revoked by a client.
+	EventRevoked
+	// EventExpired is sent when a lease is revoked because its TTL elapsed.
+	EventExpired
+)
+
+// Event is a lifecycle event of a lease. Events are sent as the requests
+// granting and revoking leases are applied, so every member sends the same
+// events in the same order.
+type Event struct {
+	Type EventType
+	ID   LeaseID
+	// TTL is the granted TTL of the lease in seconds.
+	TTL int64
+}
+
+// leaseWatchBufLen is the number of events buffered for a lease watcher;
+// a watcher that falls further behind is dropped, as the lessor must not
+// block on it while applying requests.
+// non-const so modifiable by tests
+var leaseWatchBufLen = 128
+
+type leaseWatcher struct {
+	id LeaseID
+	ch chan Event
+}
+
+type leaseWatchers struct {
+	mu sync.Mutex
+	ws map[*leaseWatcher]struct{}
+}
+
+func (le *lessor) Watch(id LeaseID) (<-chan Event, func()) {
+	return le.watchers.add(id)
+}
+
+func (lw *leaseWatchers) add(id LeaseID) (<-chan Event, func()) {
+	w := &leaseWatcher{id: id, ch: make(chan Event, leaseWatchBufLen)}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if lw.ws == nil {
+		lw.ws = make(map[*leaseWatcher]struct{})
+	}
+	lw.ws[w] = struct{}{}
+	return w.ch, func() {
+		lw.mu.Lock()
+		defer lw.mu.Unlock()
+		lw.remove(w)
+	}
+}
+
+// remove closes the chan of a watcher, unless it is already removed.
+func (lw *leaseWatchers) remove(w *leaseWatcher) {
+	if _, ok := lw.ws[w]; ok {
+		delete(lw.ws, w)
+		close(w.ch)
+	}
+}
+
+func (lw *leaseWatchers) notify(ev Event) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	for w := range lw.ws {
+		if w.id != NoLease && w.id != ev.ID {
+			continue
+		}
+		select {
+		case w.ch <- ev:
+		default:
+			leaseWatchersDropped.Inc()
+			lw.remove(w)
+		}
+	}
+}
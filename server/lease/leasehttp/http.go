@@ -88,12 +88,16 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		// gofail: var beforeServeHTTPLeaseRenew struct{}
-		ttl, rerr := h.l.Renew(lease.LeaseID(lreq.ID))
+		ttl, rerr := h.l.RenewWithEpoch(lease.LeaseID(lreq.ID), lreq.Epoch)
 		if rerr != nil {
 			if errors.Is(rerr, lease.ErrLeaseNotFound) {
 				http.Error(w, rerr.Error(), http.StatusNotFound)
 				return
 			}
+			if errors.Is(rerr, lease.ErrLeaseTransferred) {
+				http.Error(w, rerr.Error(), http.StatusPreconditionFailed)
+				return
+			}
 
 			http.Error(w, rerr.Error(), http.StatusBadRequest)
 			return
@@ -167,11 +171,12 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(v)
 }
 
-// RenewHTTP renews a lease at a given primary server.
+// RenewHTTP renews a lease at a given primary server. A zero epoch renews
+// the lease regardless of its current epoch.
 // TODO: Batch request in future?
-func RenewHTTP(ctx context.Context, id lease.LeaseID, url string, rt http.RoundTripper) (int64, error) {
+func RenewHTTP(ctx context.Context, id lease.LeaseID, epoch uint64, url string, rt http.RoundTripper) (int64, error) {
 	// will post lreq protobuf to leader
-	lreq, err := proto.Marshal(&pb.LeaseKeepAliveRequest{ID: int64(id)})
+	lreq, err := proto.Marshal(&pb.LeaseKeepAliveRequest{ID: int64(id), Epoch: epoch})
 	if err != nil {
 		return -1, err
 	}
@@ -206,6 +211,10 @@ func RenewHTTP(ctx context.Context, id lease.LeaseID, url string, rt http.RoundT
 		return -1, lease.ErrLeaseNotFound
 	}
 
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return -1, lease.ErrLeaseTransferred
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return -1, fmt.Errorf("lease: unknown error(%s)", b)
 	}
@@ -15,6 +15,7 @@
 package leasehttp
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -41,7 +42,7 @@ func TestRenewHTTP(t *testing.T) {
 	ts := httptest.NewServer(NewHandler(le, waitReady))
 	defer ts.Close()
 
-	ttl, err := RenewHTTP(t.Context(), l.ID, ts.URL+LeasePrefix, http.DefaultTransport)
+	ttl, err := RenewHTTP(t.Context(), l.ID, 0, ts.URL+LeasePrefix, http.DefaultTransport)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -50,6 +51,32 @@ func TestRenewHTTP(t *testing.T) {
 	}
 }
 
+func TestRenewHTTPTransferred(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, be)
+
+	le := lease.NewLessor(lg, be, nil, lease.LessorConfig{MinLeaseTTL: int64(5)})
+	le.Promote(time.Second)
+	l, err := le.Grant(1, int64(5))
+	if err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+	if _, err = le.Transfer(l.ID); err != nil {
+		t.Fatalf("failed to transfer lease: %v", err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le, waitReady))
+	defer ts.Close()
+
+	if _, err = RenewHTTP(t.Context(), l.ID, 1, ts.URL+LeasePrefix, http.DefaultTransport); !errors.Is(err, lease.ErrLeaseTransferred) {
+		t.Fatalf("expected %v, got %v", lease.ErrLeaseTransferred, err)
+	}
+	if _, err = RenewHTTP(t.Context(), l.ID, 2, ts.URL+LeasePrefix, http.DefaultTransport); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestTimeToLiveHTTP(t *testing.T) {
 	lg := zaptest.NewLogger(t)
 	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
@@ -79,7 +106,7 @@ func TestTimeToLiveHTTP(t *testing.T) {
 
 func TestRenewHTTPTimeout(t *testing.T) {
 	testApplyTimeout(t, func(l *lease.Lease, serverURL string) error {
-		_, err := RenewHTTP(t.Context(), l.ID, serverURL+LeasePrefix, http.DefaultTransport)
+		_, err := RenewHTTP(t.Context(), l.ID, 0, serverURL+LeasePrefix, http.DefaultTransport)
 		return err
 	})
 }
@@ -24,10 +24,12 @@ const (
 )
 
 type Lease struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            int64                  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	TTL           int64                  `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	RemainingTTL  int64                  `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ID           int64                  `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TTL          int64                  `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	RemainingTTL int64                  `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	// Epoch is the epoch of a lease which was transferred, and 0 otherwise.
+	Epoch         uint64 `protobuf:"varint,4,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +85,13 @@ func (x *Lease) GetRemainingTTL() int64 {
 	return 0
 }
 
+func (x *Lease) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type LeaseInternalRequest struct {
 	state                  protoimpl.MessageState               `protogen:"open.v1"`
 	LeaseTimeToLiveRequest *etcdserverpb.LeaseTimeToLiveRequest `protobuf:"bytes,1,opt,name=LeaseTimeToLiveRequest,proto3" json:"LeaseTimeToLiveRequest,omitempty"`
@@ -175,11 +184,12 @@ var File_lease_proto protoreflect.FileDescriptor
 
 const file_lease_proto_rawDesc = "" +
 	"\n" +
-	"\vlease.proto\x12\aleasepb\x1a\x1fetcd/api/etcdserverpb/rpc.proto\"M\n" +
+	"\vlease.proto\x12\aleasepb\x1a\x1fetcd/api/etcdserverpb/rpc.proto\"c\n" +
 	"\x05Lease\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\x03R\x02ID\x12\x10\n" +
 	"\x03TTL\x18\x02 \x01(\x03R\x03TTL\x12\"\n" +
-	"\fRemainingTTL\x18\x03 \x01(\x03R\fRemainingTTL\"t\n" +
+	"\fRemainingTTL\x18\x03 \x01(\x03R\fRemainingTTL\x12\x14\n" +
+	"\x05Epoch\x18\x04 \x01(\x04R\x05Epoch\"t\n" +
 	"\x14LeaseInternalRequest\x12\\\n" +
 	"\x16LeaseTimeToLiveRequest\x18\x01 \x01(\v2$.etcdserverpb.LeaseTimeToLiveRequestR\x16LeaseTimeToLiveRequest\"x\n" +
 	"\x15LeaseInternalResponse\x12_\n" +
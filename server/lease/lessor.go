@@ -61,6 +61,7 @@ var (
 	ErrLeaseNotFound    = errors.New("lease not found")
 	ErrLeaseExists      = errors.New("lease already exists")
 	ErrLeaseTTLTooLarge = errors.New("too large lease TTL")
+	ErrLeaseTransferred = errors.New("lease transferred")
 )
 
 // TxnDelete is a TxnWrite that only permits deletes. Defined here
@@ -94,6 +95,9 @@ type Lessor interface {
 	// given lease will be removed. If the ID does not exist, an error
 	// will be returned.
 	Revoke(id LeaseID) error
+	// Expire revokes an expired lease with given ID. It only differs from Revoke
+	// in the event it sends to the lease watchers.
+	Expire(id LeaseID) error
 
 	// Checkpoint applies the remainingTTL of a lease. The remainingTTL is used in Promote to set
 	// the expiry of leases to less than the full TTL when possible.
@@ -123,6 +127,14 @@ type Lessor interface {
 	// an error will be returned.
 	Renew(id LeaseID) (int64, error)
 
+	// RenewWithEpoch renews a lease with given ID like Renew, but only if the lease is still at
+	// the given epoch. Otherwise, ErrLeaseTransferred is returned. An epoch of 0 matches any epoch.
+	RenewWithEpoch(id LeaseID, epoch uint64) (int64, error)
+
+	// Transfer transfers the lease with given ID to a new holder, by incrementing its epoch
+	// and resetting its TTL. If the ID does not exist, an error will be returned.
+	Transfer(id LeaseID) (*Lease, error)
+
 	// Lookup gives the lease at a given lease id, if any
 	Lookup(id LeaseID) *Lease
 
@@ -132,6 +144,11 @@ type Lessor interface {
 	// ExpiredLeasesC returns a chan that is used to receive expired leases.
 	ExpiredLeasesC() <-chan []*Lease
 
+	// Watch returns a chan that receives the events of the lease with given ID, or of
+	// all leases if the ID is NoLease, and a function to stop watching. The chan is
+	// closed once the watch is stopped, or if the watcher falls too far behind.
+	Watch(id LeaseID) (<-chan Event, func())
+
 	// Recover recovers the lessor state from the given backend and RangeDeleter.
 	Recover(b backend.Backend, rd RangeDeleter)
 
@@ -189,6 +206,8 @@ type lessor struct {
 	checkpointPersist bool
 	// cluster is used to adapt lessor logic based on cluster version
 	cluster cluster
+
+	watchers leaseWatchers
 }
 
 type cluster interface {
@@ -320,10 +339,19 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 		le.scheduleCheckpointIfNeeded(l)
 	}
 
+	le.watchers.notify(Event{Type: EventGranted, ID: l.ID, TTL: l.ttl})
 	return l, nil
 }
 
 func (le *lessor) Revoke(id LeaseID) error {
+	return le.revoke(id, EventRevoked)
+}
+
+func (le *lessor) Expire(id LeaseID) error {
+	return le.revoke(id, EventExpired)
+}
+
+func (le *lessor) revoke(id LeaseID, evType EventType) error {
 	le.mu.Lock()
 
 	l := le.leaseMap[id]
@@ -361,6 +389,7 @@ func (le *lessor) Revoke(id LeaseID) error {
 	txn.End()
 
 	leaseRevoked.Inc()
+	le.watchers.notify(Event{Type: evType, ID: l.ID, TTL: l.TTL()})
 	return nil
 }
 
@@ -394,6 +423,12 @@ func greaterOrEqual(first, second semver.Version) bool {
 // Renew renews an existing lease. If the given lease does not exist or
 // has expired, an error will be returned.
 func (le *lessor) Renew(id LeaseID) (int64, error) {
+	return le.RenewWithEpoch(id, 0)
+}
+
+// RenewWithEpoch renews an existing lease if it was not transferred since
+// the given epoch.
+func (le *lessor) RenewWithEpoch(id LeaseID, epoch uint64) (int64, error) {
 	le.mu.RLock()
 	if !le.isPrimary() {
 		// forward renew request to primary instead of returning error.
@@ -408,6 +443,10 @@ func (le *lessor) Renew(id LeaseID) (int64, error) {
 		le.mu.RUnlock()
 		return -1, ErrLeaseNotFound
 	}
+	if epoch != 0 && l.Epoch() != epoch {
+		le.mu.RUnlock()
+		return -1, ErrLeaseTransferred
+	}
 	// Clear remaining TTL when we renew if it is set
 	clearRemainingTTL := le.cp != nil && l.remainingTTL > 0
 
@@ -446,6 +485,10 @@ func (le *lessor) Renew(id LeaseID) (int64, error) {
 		le.mu.Unlock()
 		return -1, ErrLeaseNotFound
 	}
+	if epoch != 0 && l.Epoch() != epoch {
+		le.mu.Unlock()
+		return -1, ErrLeaseTransferred
+	}
 	l.refresh(0)
 	item := &LeaseWithTime{id: l.ID, time: l.expiry}
 	le.leaseExpiredNotifier.RegisterOrUpdate(item)
@@ -455,6 +498,30 @@ func (le *lessor) Renew(id LeaseID) (int64, error) {
 	return l.ttl, nil
 }
 
+// Transfer increments the epoch of an existing lease, so that renewals at
+// the previous epoch are rejected, and gives it its full TTL again.
+func (le *lessor) Transfer(id LeaseID) (*Lease, error) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	l := le.leaseMap[id]
+	if l == nil {
+		return nil, ErrLeaseNotFound
+	}
+	l.epoch.Add(1)
+	l.remainingTTL = 0
+	l.persistTo(le.b)
+
+	if le.isPrimary() {
+		l.refresh(0)
+		item := &LeaseWithTime{id: l.ID, time: l.expiry}
+		le.leaseExpiredNotifier.RegisterOrUpdate(item)
+	}
+
+	leaseTransferred.Inc()
+	return l, nil
+}
+
 func (le *lessor) Lookup(id LeaseID) *Lease {
 	le.mu.RLock()
 	defer le.mu.RUnlock()
@@ -817,7 +884,7 @@ func (le *lessor) initAndRecover() {
 		if lpb.TTL < le.minLeaseTTL {
 			lpb.TTL = le.minLeaseTTL
 		}
-		le.leaseMap[ID] = &Lease{
+		l := &Lease{
 			ID:  ID,
 			ttl: lpb.TTL,
 			// itemSet will be filled in when recover key-value pairs
@@ -827,6 +894,8 @@ func (le *lessor) initAndRecover() {
 			revokec:      make(chan struct{}),
 			remainingTTL: lpb.RemainingTTL,
 		}
+		l.epoch.Store(max(lpb.Epoch, 1))
+		le.leaseMap[ID] = l
 	}
 	le.leaseExpiredNotifier.Init()
 	heap.Init(&le.leaseCheckpointHeap)
@@ -851,6 +920,8 @@ func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
+func (fl *FakeLessor) Expire(id LeaseID) error { return nil }
+
 func (fl *FakeLessor) Checkpoint(id LeaseID, remainingTTL int64) error { return nil }
 
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
@@ -864,6 +935,10 @@ func (fl *FakeLessor) Demote() {}
 
 func (fl *FakeLessor) Renew(id LeaseID) (int64, error) { return 10, nil }
 
+func (fl *FakeLessor) RenewWithEpoch(id LeaseID, epoch uint64) (int64, error) { return 10, nil }
+
+func (fl *FakeLessor) Transfer(id LeaseID) (*Lease, error) { return nil, nil }
+
 func (fl *FakeLessor) Lookup(id LeaseID) *Lease {
 	if _, ok := fl.LeaseSet[id]; ok {
 		return &Lease{ID: id}
@@ -875,6 +950,8 @@ func (fl *FakeLessor) Leases() []*Lease { return nil }
 
 func (fl *FakeLessor) ExpiredLeasesC() <-chan []*Lease { return nil }
 
+func (fl *FakeLessor) Watch(id LeaseID) (<-chan Event, func()) { return nil, func() {} }
+
 func (fl *FakeLessor) Recover(b backend.Backend, rd RangeDeleter) {}
 
 func (fl *FakeLessor) Stop() {}
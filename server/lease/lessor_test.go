@@ -263,6 +263,135 @@ func TestLessorRenew(t *testing.T) {
 	}
 }
 
+func TestLessorTransfer(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer be.Close()
+	defer os.RemoveAll(dir)
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.Promote(0)
+
+	l, err := le.Grant(1, minLeaseTTL)
+	if err != nil {
+		t.Fatalf("failed to grant lease (%v)", err)
+	}
+	if l.Epoch() != 1 {
+		t.Fatalf("epoch = %d, want 1", l.Epoch())
+	}
+
+	tl, err := le.Transfer(l.ID)
+	if err != nil {
+		t.Fatalf("failed to transfer lease (%v)", err)
+	}
+	if tl.Epoch() != 2 {
+		t.Errorf("epoch = %d, want 2", tl.Epoch())
+	}
+	if tl.Remaining() < time.Duration(minLeaseTTL-1)*time.Second {
+		t.Errorf("failed to reset the lease TTL on transfer")
+	}
+
+	if _, err = le.RenewWithEpoch(l.ID, 1); !errors.Is(err, ErrLeaseTransferred) {
+		t.Errorf("err = %v, want %v", err, ErrLeaseTransferred)
+	}
+	if _, err = le.RenewWithEpoch(l.ID, 2); err != nil {
+		t.Errorf("failed to renew with current epoch (%v)", err)
+	}
+	// keepalives without an epoch keep the pre-transfer semantics
+	if _, err = le.RenewWithEpoch(l.ID, 0); err != nil {
+		t.Errorf("failed to renew without epoch (%v)", err)
+	}
+
+	if _, err = le.Transfer(LeaseID(2)); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("err = %v, want %v", err, ErrLeaseNotFound)
+	}
+
+	// the epoch survives a restart
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	if nl := nle.Lookup(l.ID); nl == nil || nl.Epoch() != 2 {
+		t.Errorf("recovered lease = %v, want epoch 2", nl)
+	}
+}
+
+func TestLessorWatch(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer be.Close()
+	defer os.RemoveAll(dir)
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
+
+	all, cancelAll := le.Watch(NoLease)
+	defer cancelAll()
+	one, cancelOne := le.Watch(2)
+
+	for id := LeaseID(1); id <= 2; id++ {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatalf("failed to grant lease (%v)", err)
+		}
+	}
+	if err := le.Revoke(1); err != nil {
+		t.Fatalf("failed to revoke lease (%v)", err)
+	}
+	if err := le.Expire(2); err != nil {
+		t.Fatalf("failed to expire lease (%v)", err)
+	}
+
+	wevs := []Event{
+		{Type: EventGranted, ID: 1, TTL: 100},
+		{Type: EventGranted, ID: 2, TTL: 100},
+		{Type: EventRevoked, ID: 1, TTL: 100},
+		{Type: EventExpired, ID: 2, TTL: 100},
+	}
+	for i, wev := range wevs {
+		if ev := <-all; ev != wev {
+			t.Errorf("#%d: event = %+v, want %+v", i, ev, wev)
+		}
+	}
+	for _, wev := range []Event{wevs[1], wevs[3]} {
+		if ev := <-one; ev != wev {
+			t.Errorf("event = %+v, want %+v", ev, wev)
+		}
+	}
+
+	cancelOne()
+	if _, ok := <-one; ok {
+		t.Error("expected the chan of a canceled watcher to be closed")
+	}
+}
+
+func TestLessorWatchSlowWatcher(t *testing.T) {
+	defer func(n int) { leaseWatchBufLen = n }(leaseWatchBufLen)
+	leaseWatchBufLen = 1
+
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer be.Close()
+	defer os.RemoveAll(dir)
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+
+	evc, cancel := le.Watch(NoLease)
+	defer cancel()
+	for id := LeaseID(1); id <= 2; id++ {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatalf("failed to grant lease (%v)", err)
+		}
+	}
+
+	if ev := <-evc; ev.ID != 1 {
+		t.Errorf("event = %+v, want the grant of lease 1", ev)
+	}
+	if _, ok := <-evc; ok {
+		t.Error("expected the chan of a slow watcher to be closed")
+	}
+}
+
 func TestLessorRenewWithCheckpointer(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
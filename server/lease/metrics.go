@@ -40,6 +40,20 @@ var (
 		Help:      "The number of renewed leases seen by the leader.",
 	})
 
+	leaseTransferred = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "transferred_total",
+		Help:      "The total number of transferred leases.",
+	})
+
+	leaseWatchersDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "watchers_dropped_total",
+		Help:      "The total number of lease watchers dropped for falling behind.",
+	})
+
 	leaseTotalTTLs = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -56,5 +70,7 @@ func init() {
 	prometheus.MustRegister(leaseGranted)
 	prometheus.MustRegister(leaseRevoked)
 	prometheus.MustRegister(leaseRenewed)
+	prometheus.MustRegister(leaseTransferred)
+	prometheus.MustRegister(leaseWatchersDropped)
 	prometheus.MustRegister(leaseTotalTTLs)
 }
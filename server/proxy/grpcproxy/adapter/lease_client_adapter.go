@@ -38,6 +38,10 @@ func (c *ls2lc) LeaseRevoke(ctx context.Context, in *pb.LeaseRevokeRequest, opts
 	return c.leaseServer.LeaseRevoke(ctx, in)
 }
 
+func (c *ls2lc) LeaseTransfer(ctx context.Context, in *pb.LeaseTransferRequest, opts ...grpc.CallOption) (*pb.LeaseTransferResponse, error) {
+	return c.leaseServer.LeaseTransfer(ctx, in)
+}
+
 func (c *ls2lc) LeaseKeepAlive(ctx context.Context, opts ...grpc.CallOption) (pb.Lease_LeaseKeepAliveClient, error) {
 	cs := newPipeStream(ctx, func(ss chanServerStream) error {
 		return c.leaseServer.LeaseKeepAlive(&ls2lcServerStream{ss})
@@ -53,6 +57,13 @@ func (c *ls2lc) LeaseLeases(ctx context.Context, in *pb.LeaseLeasesRequest, opts
 	return c.leaseServer.LeaseLeases(ctx, in)
 }
 
+func (c *ls2lc) LeaseWatch(ctx context.Context, in *pb.LeaseWatchRequest, opts ...grpc.CallOption) (pb.Lease_LeaseWatchClient, error) {
+	cs := newPipeStream(ctx, func(ss chanServerStream) error {
+		return c.leaseServer.LeaseWatch(in, &lw2lwcServerStream{ss})
+	})
+	return &lw2lwcClientStream{cs}, nil
+}
+
 // ls2lcClientStream implements Lease_LeaseKeepAliveClient
 type ls2lcClientStream struct{ chanClientStream }
 
@@ -82,3 +93,21 @@ func (s *ls2lcServerStream) Recv() (*pb.LeaseKeepAliveRequest, error) {
 	}
 	return v.(*pb.LeaseKeepAliveRequest), nil
 }
+
+// lw2lwcClientStream implements Lease_LeaseWatchClient
+type lw2lwcClientStream struct{ chanClientStream }
+
+// lw2lwcServerStream implements Lease_LeaseWatchServer
+type lw2lwcServerStream struct{ chanServerStream }
+
+func (s *lw2lwcClientStream) Recv() (*pb.LeaseWatchResponse, error) {
+	var v any
+	if err := s.RecvMsg(&v); err != nil { //nolint:staticcheck // TODO: remove for a supported version
+		return nil, err
+	}
+	return v.(*pb.LeaseWatchResponse), nil
+}
+
+func (s *lw2lwcServerStream) Send(rr *pb.LeaseWatchResponse) error {
+	return s.SendMsg(rr) //nolint:staticcheck // TODO: remove for a supported version
+}
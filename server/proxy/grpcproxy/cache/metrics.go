@@ -0,0 +1,46 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	evictionReasonCapacity = "capacity"
+	evictionReasonExpired  = "expired"
+
+	invalidationReasonWrite      = "write"
+	invalidationReasonCompaction = "compaction"
+	invalidationReasonClear      = "clear"
+)
+
+var (
+	evictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "cache_evictions_total",
+		Help:      "Total number of cached responses evicted, by reason (capacity or expired).",
+	}, []string{"reason"})
+	invalidations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "cache_invalidations_total",
+		Help:      "Total number of cached responses invalidated, by reason (write, compaction or clear).",
+	}, []string{"reason"})
+)
+
+func init() {
+	prometheus.MustRegister(evictions)
+	prometheus.MustRegister(invalidations)
+}
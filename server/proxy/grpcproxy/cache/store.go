@@ -19,6 +19,7 @@ package cache
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 	"k8s.io/utils/lru"
@@ -38,6 +39,8 @@ type Cache interface {
 	Get(req *pb.RangeRequest) (*pb.RangeResponse, error)
 	Compact(revision int64)
 	Invalidate(key []byte, endkey []byte)
+	// Clear removes all entries and returns how many were removed.
+	Clear() int
 	Size() int
 	Close()
 }
@@ -52,20 +55,43 @@ func keyFunc(req *pb.RangeRequest) string {
 	return string(b)
 }
 
-func NewCache(maxCacheEntries int) Cache {
-	return &cache{
+// Option configures a Cache.
+type Option func(*cache)
+
+// WithTTL makes cached responses expire after the given duration. Responses
+// never expire if ttl is not positive, which is the default.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *cache) { c.ttl = ttl }
+}
+
+// NewCache returns a Cache holding at most maxCacheEntries responses. If
+// maxCacheEntries is not positive, DefaultMaxEntries is used.
+func NewCache(maxCacheEntries int, opts ...Option) Cache {
+	if maxCacheEntries <= 0 {
+		maxCacheEntries = DefaultMaxEntries
+	}
+	c := &cache{
 		lru:          lru.New(maxCacheEntries),
+		maxEntries:   maxCacheEntries,
 		cachedRanges: adt.NewIntervalTree(),
 		compactedRev: -1,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *cache) Close() {}
 
 // cache implements Cache
 type cache struct {
-	mu  sync.RWMutex
-	lru *lru.Cache
+	mu         sync.RWMutex
+	lru        *lru.Cache
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
 
 	// a reverse index for cache invalidation
 	cachedRanges adt.IntervalTree
@@ -73,6 +99,12 @@ type cache struct {
 	compactedRev int64
 }
 
+// entry is a cached response and the time it expires at, if the cache has a TTL.
+type entry struct {
+	resp    *pb.RangeResponse
+	expires time.Time
+}
+
 // Add adds the response of a request to the cache if its revision is larger than the compacted revision of the cache.
 func (c *cache) Add(req *pb.RangeRequest, resp *pb.RangeResponse) {
 	key := keyFunc(req)
@@ -81,7 +113,17 @@ func (c *cache) Add(req *pb.RangeRequest, resp *pb.RangeResponse) {
 	defer c.mu.Unlock()
 
 	if req.Revision > c.compactedRev {
-		c.lru.Add(key, resp)
+		if c.lru.Len() >= c.maxEntries {
+			if _, ok := c.lru.Get(key); !ok {
+				// the least recently used entry makes room for this one
+				evictions.WithLabelValues(evictionReasonCapacity).Inc()
+			}
+		}
+		e := &entry{resp: resp}
+		if c.ttl > 0 {
+			e.expires = c.now().Add(c.ttl)
+		}
+		c.lru.Add(key, e)
 	}
 	// we do not need to invalidate a request with a revision specified.
 	// so we do not need to add it into the reverse index.
@@ -120,12 +162,20 @@ func (c *cache) Get(req *pb.RangeRequest) (*pb.RangeResponse, error) {
 	defer c.mu.Unlock()
 
 	if req.Revision > 0 && req.Revision < c.compactedRev {
-		c.lru.Remove(key)
+		if _, ok := c.lru.Get(key); ok {
+			c.lru.Remove(key)
+			invalidations.WithLabelValues(invalidationReasonCompaction).Inc()
+		}
 		return nil, ErrCompacted
 	}
 
-	if resp, ok := c.lru.Get(key); ok {
-		return resp.(*pb.RangeResponse), nil
+	if v, ok := c.lru.Get(key); ok {
+		e := v.(*entry)
+		if e.expires.IsZero() || c.now().Before(e.expires) {
+			return e.resp, nil
+		}
+		c.lru.Remove(key)
+		evictions.WithLabelValues(evictionReasonExpired).Inc()
 	}
 	return nil, errors.New("not exist")
 }
@@ -149,7 +199,10 @@ func (c *cache) Invalidate(key, endkey []byte) {
 	for _, iv := range ivs {
 		keys := iv.Val.(map[string]struct{})
 		for key := range keys {
-			c.lru.Remove(key)
+			if _, ok := c.lru.Get(key); ok {
+				c.lru.Remove(key)
+				invalidations.WithLabelValues(invalidationReasonWrite).Inc()
+			}
 		}
 	}
 	// delete after removing all keys since it is destructive to 'ivs'
@@ -167,6 +220,17 @@ func (c *cache) Compact(revision int64) {
 	}
 }
 
+func (c *cache) Clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.lru.Len()
+	c.lru.Clear()
+	c.cachedRanges = adt.NewIntervalTree()
+	invalidations.WithLabelValues(invalidationReasonClear).Add(float64(n))
+	return n
+}
+
 func (c *cache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -0,0 +1,147 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func rangeReq(key string) *pb.RangeRequest {
+	return &pb.RangeRequest{Key: []byte(key), Serializable: true}
+}
+
+func rangeResp(rev int64) *pb.RangeResponse {
+	return &pb.RangeResponse{Header: &pb.ResponseHeader{Revision: rev}}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	before := testutil.ToFloat64(evictions.WithLabelValues(evictionReasonCapacity))
+
+	c := NewCache(2)
+	c.Add(rangeReq("a"), rangeResp(1))
+	c.Add(rangeReq("b"), rangeResp(2))
+	// touch "a" so that "b" becomes the least recently used entry
+	_, err := c.Get(rangeReq("a"))
+	require.NoError(t, err)
+	// replacing an existing entry does not evict anything
+	c.Add(rangeReq("a"), rangeResp(3))
+	require.InDelta(t, before, testutil.ToFloat64(evictions.WithLabelValues(evictionReasonCapacity)), 0)
+
+	c.Add(rangeReq("c"), rangeResp(4))
+	require.Equal(t, 2, c.Size())
+	require.InDelta(t, before+1, testutil.ToFloat64(evictions.WithLabelValues(evictionReasonCapacity)), 0)
+
+	_, err = c.Get(rangeReq("b"))
+	require.Error(t, err)
+	resp, err := c.Get(rangeReq("a"))
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.Header.Revision)
+	_, err = c.Get(rangeReq("c"))
+	require.NoError(t, err)
+}
+
+func TestCacheDefaultSize(t *testing.T) {
+	c := NewCache(0).(*cache)
+	require.Equal(t, DefaultMaxEntries, c.maxEntries)
+}
+
+func TestCacheTTL(t *testing.T) {
+	before := testutil.ToFloat64(evictions.WithLabelValues(evictionReasonExpired))
+
+	now := time.Unix(0, 0)
+	c := NewCache(10, WithTTL(time.Second)).(*cache)
+	c.now = func() time.Time { return now }
+
+	c.Add(rangeReq("a"), rangeResp(1))
+	now = now.Add(500 * time.Millisecond)
+	_, err := c.Get(rangeReq("a"))
+	require.NoError(t, err)
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = c.Get(rangeReq("a"))
+	require.Error(t, err)
+	require.Equal(t, 0, c.Size())
+	require.InDelta(t, before+1, testutil.ToFloat64(evictions.WithLabelValues(evictionReasonExpired)), 0)
+
+	// re-adding the response restarts its TTL
+	c.Add(rangeReq("a"), rangeResp(2))
+	now = now.Add(500 * time.Millisecond)
+	resp, err := c.Get(rangeReq("a"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Header.Revision)
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	before := testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonWrite))
+
+	c := NewCache(10)
+	c.Add(rangeReq("a"), rangeResp(1))
+	c.Add(&pb.RangeRequest{Key: []byte("b"), RangeEnd: []byte("d"), Serializable: true}, rangeResp(1))
+	c.Add(rangeReq("x"), rangeResp(1))
+
+	c.Invalidate([]byte("c"), nil)
+	_, err := c.Get(&pb.RangeRequest{Key: []byte("b"), RangeEnd: []byte("d"), Serializable: true})
+	require.Error(t, err)
+	_, err = c.Get(rangeReq("a"))
+	require.NoError(t, err)
+
+	c.Invalidate([]byte("a"), []byte("y"))
+	require.Equal(t, 0, c.Size())
+	require.InDelta(t, before+3, testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonWrite)), 0)
+}
+
+func TestCacheCompact(t *testing.T) {
+	before := testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonCompaction))
+
+	c := NewCache(10)
+	old := &pb.RangeRequest{Key: []byte("a"), Revision: 5, Serializable: true}
+	c.Add(old, rangeResp(5))
+	c.Add(rangeReq("a"), rangeResp(12))
+
+	c.Compact(10)
+	_, err := c.Get(old)
+	require.ErrorIs(t, err, ErrCompacted)
+	require.InDelta(t, before+1, testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonCompaction)), 0)
+
+	// requests for the latest revision are unaffected by compaction
+	_, err = c.Get(rangeReq("a"))
+	require.NoError(t, err)
+
+	// responses at compacted revisions are not cached again
+	c.Add(old, rangeResp(5))
+	require.Equal(t, 1, c.Size())
+}
+
+func TestCacheClear(t *testing.T) {
+	before := testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonClear))
+
+	c := NewCache(10)
+	c.Add(rangeReq("a"), rangeResp(1))
+	c.Add(rangeReq("b"), rangeResp(1))
+	require.Equal(t, 2, c.Clear())
+	require.Equal(t, 0, c.Size())
+	require.InDelta(t, before+2, testutil.ToFloat64(invalidations.WithLabelValues(invalidationReasonClear)), 0)
+
+	// the reverse index is reset along with the entries
+	c.Add(rangeReq("a"), rangeResp(2))
+	c.Invalidate([]byte("a"), nil)
+	require.Equal(t, 0, c.Size())
+}
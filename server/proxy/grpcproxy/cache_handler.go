@@ -0,0 +1,53 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+// PathProxyCacheClear is the path of the endpoint that clears the range cache of the proxy.
+const PathProxyCacheClear = "/proxy/cache/clear"
+
+// HandleClearCache registers a handler on '/proxy/cache/clear' that drops all
+// range responses cached by the given KV proxy. It only accepts POST requests
+// and replies with the number of dropped responses.
+func HandleClearCache(lg *zap.Logger, mux *http.ServeMux, kv pb.KVServer) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	p, ok := kv.(*kvProxy)
+	if !ok {
+		return
+	}
+	mux.HandleFunc(PathProxyCacheClear, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		n := p.ClearCache()
+		lg.Info("cleared gRPC proxy range cache", zap.Int("entries", n))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Cleared int `json:"cleared"`
+		}{Cleared: n})
+	})
+}
@@ -17,7 +17,6 @@ package grpcproxy
 import (
 	"context"
 	"errors"
-	"fmt"
 	"os"
 	"sync"
 
@@ -118,7 +117,7 @@ func (cp *clusterProxy) monitor(wa endpoints.WatchChannel) {
 			cp.umu.Lock()
 			for _, up := range updates {
 				switch up.Op {
-				case endpoints.Add:
+				case endpoints.Add, endpoints.Modify:
 					cp.umap[up.Key] = up.Endpoint
 				case endpoints.Delete:
 					delete(cp.umap, up.Key)
@@ -146,7 +145,7 @@ func (cp *clusterProxy) membersFromUpdates() ([]*pb.Member, error) {
 	defer cp.umu.RUnlock()
 	mbs := make([]*pb.Member, 0, len(cp.umap))
 	for _, upt := range cp.umap {
-		m, err := decodeMeta(fmt.Sprint(upt.Metadata)) //nolint:staticcheck // TODO: remove for a supported version
+		m, err := decodeMeta(upt.Metadata) //nolint:staticcheck // TODO: remove for a supported version
 		if err != nil {
 			return nil, err
 		}
@@ -80,3 +80,16 @@ func checkProxyHealth(c *clientv3.Client) etcdhttp.Health {
 	cancel()
 	return h
 }
+
+// RangeHealthCheck returns a health check for WithRegisterHealthCheck which
+// succeeds while the proxy can serve a serializable range through its client
+// connection.
+func RangeHealthCheck(c *clientv3.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := c.Get(ctx, "a", clientv3.WithSerializable())
+		if errors.Is(err, rpctypes.ErrPermissionDenied) {
+			return nil
+		}
+		return err
+	}
+}
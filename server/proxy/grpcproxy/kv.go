@@ -17,6 +17,7 @@ package grpcproxy
 import (
 	"context"
 	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -34,16 +35,47 @@ type kvProxy struct {
 	pb.UnsafeKVServer
 }
 
-func NewKvProxy(c *clientv3.Client) (pb.KVServer, <-chan struct{}) {
+type kvProxyOptions struct {
+	cacheSize int
+	cacheTTL  time.Duration
+}
+
+// KvProxyOption configures the KV proxy.
+type KvProxyOption func(*kvProxyOptions)
+
+// WithCacheSize sets the maximum number of serializable range responses
+// cached by the proxy. The default is cache.DefaultMaxEntries.
+func WithCacheSize(size int) KvProxyOption {
+	return func(o *kvProxyOptions) { o.cacheSize = size }
+}
+
+// WithCacheTTL sets how long a cached range response may be served. Cached
+// responses do not expire by default.
+func WithCacheTTL(ttl time.Duration) KvProxyOption {
+	return func(o *kvProxyOptions) { o.cacheTTL = ttl }
+}
+
+func NewKvProxy(c *clientv3.Client, opts ...KvProxyOption) (pb.KVServer, <-chan struct{}) {
+	o := kvProxyOptions{cacheSize: cache.DefaultMaxEntries}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	kv := &kvProxy{
 		kv:    c.KV,
-		cache: cache.NewCache(cache.DefaultMaxEntries),
+		cache: cache.NewCache(o.cacheSize, cache.WithTTL(o.cacheTTL)),
 	}
 	donec := make(chan struct{})
 	close(donec)
 	return kv, donec
 }
 
+// ClearCache drops all cached range responses and returns how many were dropped.
+func (p *kvProxy) ClearCache() int {
+	n := p.cache.Clear()
+	cacheKeys.Set(float64(p.cache.Size()))
+	return n
+}
+
 func (p *kvProxy) Range(ctx context.Context, r *pb.RangeRequest) (*pb.RangeResponse, error) {
 	if r.Serializable {
 		resp, err := p.cache.Get(r)
@@ -197,6 +229,9 @@ func RangeRequestToOp(r *pb.RangeRequest) clientv3.Op {
 	if r.Serializable {
 		opts = append(opts, clientv3.WithSerializable())
 	}
+	if len(r.Cursor) != 0 {
+		opts = append(opts, clientv3.WithCursor(r.Cursor))
+	}
 
 	return clientv3.OpGet(string(r.Key), opts...)
 }
@@ -38,6 +38,9 @@ type leaseProxy struct {
 
 	lessor clientv3.Lease
 
+	// keepAliver renews the leases kept alive by all the client streams.
+	keepAliver *leaseKeepAliver
+
 	ctx context.Context
 
 	leader *leader
@@ -52,7 +55,14 @@ type leaseProxy struct {
 	pb.LeaseServer
 }
 
-func NewLeaseProxy(ctx context.Context, c *clientv3.Client) (pb.LeaseServer, <-chan struct{}) {
+func NewLeaseProxy(ctx context.Context, c *clientv3.Client, opts ...LeaseProxyOption) (pb.LeaseServer, <-chan struct{}) {
+	o := leaseProxyOptions{
+		keepAliveJitter:   DefaultLeaseKeepAliveJitter,
+		keepAliveFallback: DefaultLeaseKeepAliveFallback,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 	cctx, cancel := context.WithCancel(ctx)
 	lp := &leaseProxy{
 		leaseClient: pb.NewLeaseClient(c.ActiveConnection()),
@@ -60,6 +70,7 @@ func NewLeaseProxy(ctx context.Context, c *clientv3.Client) (pb.LeaseServer, <-c
 		ctx:         cctx,
 		leader:      newLeader(cctx, c.Watcher),
 	}
+	lp.keepAliver = newLeaseKeepAliver(cctx, lp.leaseClient, lp.lessor, o)
 	ch := make(chan struct{})
 	go func() {
 		defer close(ch)
@@ -95,6 +106,15 @@ func (lp *leaseProxy) LeaseRevoke(ctx context.Context, rr *pb.LeaseRevokeRequest
 	return (*pb.LeaseRevokeResponse)(r), nil
 }
 
+func (lp *leaseProxy) LeaseTransfer(ctx context.Context, tr *pb.LeaseTransferRequest) (*pb.LeaseTransferResponse, error) {
+	rp, err := lp.leaseClient.LeaseTransfer(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
 func (lp *leaseProxy) LeaseTimeToLive(ctx context.Context, rr *pb.LeaseTimeToLiveRequest) (*pb.LeaseTimeToLiveResponse, error) {
 	var (
 		r   *clientv3.LeaseTimeToLiveResponse
@@ -134,6 +154,31 @@ func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest
 	return rp, err
 }
 
+func (lp *leaseProxy) LeaseWatch(rr *pb.LeaseWatchRequest, stream pb.Lease_LeaseWatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	ctx = withClientAuthToken(ctx, stream.Context())
+
+	wc, err := lp.leaseClient.LeaseWatch(ctx, rr)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := wc.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err = stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
 func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
 	lp.mu.Lock()
 	select {
@@ -149,6 +194,7 @@ func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error
 	lps := leaseProxyStream{
 		stream:          stream,
 		lessor:          lp.lessor,
+		keepAliver:      lp.keepAliver,
 		keepAliveLeases: make(map[int64]*atomicCounter),
 		respc:           make(chan *pb.LeaseKeepAliveResponse),
 		ctx:             ctx,
@@ -232,7 +278,8 @@ func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error
 type leaseProxyStream struct {
 	stream pb.Lease_LeaseKeepAliveServer
 
-	lessor clientv3.Lease
+	lessor     clientv3.Lease
+	keepAliver *leaseKeepAliver
 	// wg tracks keepAliveLoop goroutines
 	wg sync.WaitGroup
 	// mu protects keepAliveLeases
@@ -276,10 +323,7 @@ func (lps *leaseProxyStream) recvLoop() error {
 func (lps *leaseProxyStream) keepAliveLoop(leaseID int64, neededResps *atomicCounter) error {
 	cctx, ccancel := context.WithCancel(lps.ctx)
 	defer ccancel()
-	respc, err := lps.lessor.KeepAlive(cctx, clientv3.LeaseID(leaseID))
-	if err != nil {
-		return err
-	}
+	respc := lps.keepAliver.KeepAlive(cctx, leaseID)
 	// ticker expires when loop hasn't received keepalive within TTL
 	var ticker <-chan time.Time
 	for {
@@ -327,12 +371,7 @@ func (lps *leaseProxyStream) keepAliveLoop(leaseID int64, neededResps *atomicCou
 				continue
 			}
 			ticker = time.After(time.Duration(rp.TTL) * time.Second)
-			r := &pb.LeaseKeepAliveResponse{
-				Header: rp.ResponseHeader,
-				ID:     int64(rp.ID),
-				TTL:    rp.TTL,
-			}
-			lps.replyToClient(r, neededResps)
+			lps.replyToClient(rp, neededResps)
 		}
 	}
 }
@@ -0,0 +1,383 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// DefaultLeaseKeepAliveJitter is the default fraction of the renewal
+	// interval by which coalesced renewals are randomly moved.
+	DefaultLeaseKeepAliveJitter = 0.1
+	// DefaultLeaseKeepAliveFallback is the default fraction of the TTL of a
+	// lease below which it is renewed directly.
+	DefaultLeaseKeepAliveFallback = 0.5
+
+	// leaseKeepAliveRetryInterval is the delay before re-establishing the
+	// upstream stream, or retrying a failed direct renewal.
+	leaseKeepAliveRetryInterval = 500 * time.Millisecond
+)
+
+type leaseProxyOptions struct {
+	keepAliveJitter   float64
+	keepAliveFallback float64
+}
+
+// LeaseProxyOption configures the lease proxy.
+type LeaseProxyOption func(*leaseProxyOptions)
+
+// WithLeaseKeepAliveJitter sets the fraction of the renewal interval, a
+// third of the TTL, by which each coalesced renewal of a lease is randomly
+// moved, so that renewals do not arrive upstream in synchronized bursts.
+// The default is DefaultLeaseKeepAliveJitter.
+func WithLeaseKeepAliveJitter(jitter float64) LeaseProxyOption {
+	return func(o *leaseProxyOptions) { o.keepAliveJitter = jitter }
+}
+
+// WithLeaseKeepAliveFallback sets the fraction of the TTL of a lease below
+// which its remaining TTL may not fall without a renewal. A lease that was
+// not renewed on the shared upstream stream in time is renewed with a direct
+// KeepAliveOnce instead. Zero disables the fallback. The default is
+// DefaultLeaseKeepAliveFallback.
+func WithLeaseKeepAliveFallback(fraction float64) LeaseProxyOption {
+	return func(o *leaseProxyOptions) { o.keepAliveFallback = fraction }
+}
+
+// coalescedLease is a lease kept alive by one or more client streams.
+type coalescedLease struct {
+	// subs receive the renewals of the lease.
+	subs map[chan *pb.LeaseKeepAliveResponse]struct{}
+	// ttl is the TTL granted by the last renewal, zero until the first one.
+	ttl time.Duration
+	// deadline is when the lease expires unless it is renewed.
+	deadline time.Time
+	// next is when the lease is next renewed on the upstream stream.
+	next time.Time
+	// fallback is set while a direct renewal is in flight.
+	fallback bool
+	// fallbackRetry is when a failed direct renewal may be retried.
+	fallbackRetry time.Time
+}
+
+// leaseKeepAliver renews the leases kept alive through the proxy on a
+// single upstream LeaseKeepAlive stream. Renewals are jittered, and after
+// the stream is re-established the overdue renewals are staggered. A lease
+// that is about to get within the fallback fraction of its TTL without a
+// renewal is renewed directly.
+type leaseKeepAliver struct {
+	ctx         context.Context
+	leaseClient pb.LeaseClient
+	lessor      clientv3.Lease
+	opts        leaseProxyOptions
+
+	mu     sync.Mutex
+	leases map[int64]*coalescedLease
+
+	// sendc and fallbackc wake up the send and fallback loops.
+	sendc     chan struct{}
+	fallbackc chan struct{}
+}
+
+func newLeaseKeepAliver(ctx context.Context, lc pb.LeaseClient, lessor clientv3.Lease, opts leaseProxyOptions) *leaseKeepAliver {
+	ka := &leaseKeepAliver{
+		ctx:         ctx,
+		leaseClient: lc,
+		lessor:      lessor,
+		opts:        opts,
+		leases:      make(map[int64]*coalescedLease),
+		sendc:       make(chan struct{}, 1),
+		fallbackc:   make(chan struct{}, 1),
+	}
+	go ka.run()
+	go ka.fallbackLoop()
+	return ka
+}
+
+// KeepAlive keeps the lease alive until ctx is done, and returns the channel
+// its renewals are sent to. The channel is closed when the lease expires or
+// ctx is done.
+func (ka *leaseKeepAliver) KeepAlive(ctx context.Context, id int64) <-chan *pb.LeaseKeepAliveResponse {
+	ch := make(chan *pb.LeaseKeepAliveResponse, 1)
+	ka.mu.Lock()
+	l, ok := ka.leases[id]
+	if !ok {
+		// a new lease is renewed right away
+		l = &coalescedLease{
+			subs: make(map[chan *pb.LeaseKeepAliveResponse]struct{}),
+			next: time.Now(),
+		}
+		ka.leases[id] = l
+	}
+	l.subs[ch] = struct{}{}
+	ka.mu.Unlock()
+	wake(ka.sendc)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-ka.ctx.Done():
+		}
+		ka.unsubscribe(id, ch)
+	}()
+	return ch
+}
+
+func (ka *leaseKeepAliver) unsubscribe(id int64, ch chan *pb.LeaseKeepAliveResponse) {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	l, ok := ka.leases[id]
+	if !ok {
+		return
+	}
+	if _, ok = l.subs[ch]; !ok {
+		return
+	}
+	delete(l.subs, ch)
+	close(ch)
+	if len(l.subs) == 0 {
+		delete(ka.leases, id)
+	}
+}
+
+// renewed records a renewal of the lease and forwards it to the subscribers.
+func (ka *leaseKeepAliver) renewed(resp *pb.LeaseKeepAliveResponse, typ string) {
+	leaseRenewals.WithLabelValues(typ).Inc()
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	l, ok := ka.leases[resp.ID]
+	if !ok {
+		return
+	}
+	if resp.TTL <= 0 {
+		ka.unsafeExpire(resp.ID, l)
+		return
+	}
+	now := time.Now()
+	l.ttl = time.Duration(resp.TTL) * time.Second
+	l.deadline = now.Add(l.ttl)
+	l.next = now.Add(ka.renewInterval(l.ttl))
+	for ch := range l.subs {
+		select {
+		case ch <- resp:
+		default:
+		}
+	}
+	wake(ka.fallbackc)
+}
+
+func (ka *leaseKeepAliver) unsafeExpire(id int64, l *coalescedLease) {
+	for ch := range l.subs {
+		close(ch)
+	}
+	delete(ka.leases, id)
+}
+
+// renewInterval returns the jittered interval between two renewals of a
+// lease with the given TTL.
+func (ka *leaseKeepAliver) renewInterval(ttl time.Duration) time.Duration {
+	d := ttl / 3
+	if j := ka.opts.keepAliveJitter; j > 0 {
+		d = time.Duration(float64(d) * (1 + j*(rand.Float64()*2-1)))
+	}
+	return d
+}
+
+// fallbackAt returns when the lease is renewed directly, or the zero time
+// if it is not.
+func (ka *leaseKeepAliver) fallbackAt(l *coalescedLease) time.Time {
+	if ka.opts.keepAliveFallback <= 0 || l.ttl == 0 || l.fallback {
+		return time.Time{}
+	}
+	at := l.deadline.Add(-time.Duration(float64(l.ttl) * ka.opts.keepAliveFallback))
+	if at.Before(l.fallbackRetry) {
+		at = l.fallbackRetry
+	}
+	return at
+}
+
+// run maintains the upstream stream, re-establishing it when it fails.
+func (ka *leaseKeepAliver) run() {
+	reconnect := false
+	for {
+		if err := ka.serveStream(reconnect); err != nil && ka.ctx.Err() == nil {
+			reconnect = true
+		}
+		select {
+		case <-time.After(leaseKeepAliveRetryInterval):
+		case <-ka.ctx.Done():
+			return
+		}
+	}
+}
+
+func (ka *leaseKeepAliver) serveStream(reconnect bool) error {
+	ctx, cancel := context.WithCancel(ka.ctx)
+	defer cancel()
+	stream, err := ka.leaseClient.LeaseKeepAlive(ctx)
+	if err != nil {
+		return err
+	}
+	if reconnect {
+		ka.stagger(time.Now())
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			resp, rerr := stream.Recv()
+			if rerr != nil {
+				errc <- rerr
+				return
+			}
+			ka.renewed(resp, "coalesced")
+		}
+	}()
+
+	for {
+		ids, wait := ka.dueRenewals(time.Now())
+		for _, id := range ids {
+			if err = stream.Send(&pb.LeaseKeepAliveRequest{ID: id}); err != nil {
+				return err
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ka.sendc:
+			timer.Stop()
+		case err = <-errc:
+			timer.Stop()
+			return err
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// dueRenewals returns the leases to renew now, and how long to wait for the
+// next renewal.
+func (ka *leaseKeepAliver) dueRenewals(now time.Time) (ids []int64, wait time.Duration) {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	wait = time.Hour
+	for id, l := range ka.leases {
+		if !l.next.After(now) {
+			ids = append(ids, id)
+			// renew again if no response arrives
+			l.next = now.Add(max(ka.renewInterval(l.ttl), leaseKeepAliveRetryInterval))
+		}
+		wait = min(wait, l.next.Sub(now))
+	}
+	return ids, wait
+}
+
+// stagger spreads the overdue renewals over the renewal interval of their
+// lease, but not past the point where they would be renewed directly, so
+// that they are not all sent at once on a re-established stream.
+func (ka *leaseKeepAliver) stagger(now time.Time) {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	for _, l := range ka.leases {
+		if l.next.After(now) || l.ttl == 0 {
+			continue
+		}
+		window := l.ttl / 3
+		if at := ka.fallbackAt(l); !at.IsZero() {
+			window = min(window, at.Sub(now))
+		}
+		if window > 0 {
+			l.next = now.Add(time.Duration(rand.Int63n(int64(window) + 1)))
+		}
+	}
+}
+
+// fallbackLoop renews directly the leases that were not renewed on the
+// upstream stream in time.
+func (ka *leaseKeepAliver) fallbackLoop() {
+	for {
+		timer := time.NewTimer(ka.startFallbacks(time.Now()))
+		select {
+		case <-timer.C:
+		case <-ka.fallbackc:
+			timer.Stop()
+		case <-ka.ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// startFallbacks starts the direct renewals that are due, and returns how
+// long to wait for the next one.
+func (ka *leaseKeepAliver) startFallbacks(now time.Time) time.Duration {
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	wait := time.Hour
+	for id, l := range ka.leases {
+		at := ka.fallbackAt(l)
+		if at.IsZero() {
+			continue
+		}
+		if at.After(now) {
+			wait = min(wait, at.Sub(now))
+			continue
+		}
+		l.fallback = true
+		go ka.renewDirectly(id)
+	}
+	return wait
+}
+
+func (ka *leaseKeepAliver) renewDirectly(id int64) {
+	resp, err := ka.lessor.KeepAliveOnce(ka.ctx, clientv3.LeaseID(id))
+	if err == nil {
+		ka.mu.Lock()
+		if l, ok := ka.leases[id]; ok {
+			l.fallback = false
+		}
+		ka.mu.Unlock()
+		ka.renewed(&pb.LeaseKeepAliveResponse{Header: resp.ResponseHeader, ID: int64(resp.ID), TTL: resp.TTL}, "fallback")
+		return
+	}
+
+	ka.mu.Lock()
+	if l, ok := ka.leases[id]; ok {
+		l.fallback = false
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			ka.unsafeExpire(id, l)
+		} else {
+			l.fallbackRetry = time.Now().Add(leaseKeepAliveRetryInterval)
+		}
+	}
+	ka.mu.Unlock()
+	wake(ka.fallbackc)
+}
+
+func wake(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
@@ -17,13 +17,20 @@ package grpcproxy
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 type maintenanceProxy struct {
+	client            *clientv3.Client
 	maintenanceClient pb.MaintenanceClient
 	// we want compile errors if new methods are added
 	pb.UnsafeMaintenanceServer
@@ -31,10 +38,63 @@ type maintenanceProxy struct {
 
 func NewMaintenanceProxy(c *clientv3.Client) pb.MaintenanceServer {
 	return &maintenanceProxy{
+		client:            c,
 		maintenanceClient: pb.NewMaintenanceClient(c.ActiveConnection()),
 	}
 }
 
+// targetMember returns the member named by the target member metadata of the
+// request, if any. The outgoing metadata is checked as well, since the
+// in-process adapters pass the context of the client as is.
+func targetMember(ctx context.Context) (string, bool) {
+	for _, fromContext := range []func(context.Context) (metadata.MD, bool){metadata.FromIncomingContext, metadata.FromOutgoingContext} {
+		md, ok := fromContext(ctx)
+		if !ok {
+			continue
+		}
+		if ts := md.Get(rpctypes.MetadataTargetMemberKey); len(ts) > 0 && ts[0] != "" {
+			return ts[0], true
+		}
+	}
+	return "", false
+}
+
+// targetMaintenanceClient returns a maintenance client connected to the
+// upstream endpoint of the given member, identified by name or hexadecimal ID,
+// and a function to close it.
+func (mp *maintenanceProxy) targetMaintenanceClient(ctx context.Context, target string) (pb.MaintenanceClient, func(), error) {
+	resp, err := pb.NewClusterClient(mp.client.ActiveConnection()).MemberList(ctx, &pb.MemberListRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	var id uint64
+	for _, m := range resp.Members {
+		if m.Name == target || fmt.Sprintf("%x", m.ID) == target {
+			id = m.ID
+			break
+		}
+	}
+	if id == 0 {
+		return nil, nil, rpctypes.ErrGRPCMemberNotFound
+	}
+
+	// the advertised client URLs of the member may not be reachable from the
+	// proxy, so look for the member among the endpoints of the proxy instead
+	for _, ep := range mp.client.Endpoints() {
+		conn, err := mp.client.Dial(ep)
+		if err != nil {
+			continue
+		}
+		mc := pb.NewMaintenanceClient(conn)
+		sresp, err := mc.Status(ctx, &pb.StatusRequest{})
+		if err == nil && sresp.Header.MemberId == id {
+			return mc, func() { conn.Close() }, nil
+		}
+		conn.Close()
+	}
+	return nil, nil, status.Errorf(codes.Unavailable, "member %q is not reachable through the endpoints of the proxy", target)
+}
+
 func (mp *maintenanceProxy) Defragment(ctx context.Context, dr *pb.DefragmentRequest) (*pb.DefragmentResponse, error) {
 	return mp.maintenanceClient.Defragment(ctx, dr)
 }
@@ -70,6 +130,15 @@ func (mp *maintenanceProxy) Hash(ctx context.Context, r *pb.HashRequest) (*pb.Ha
 }
 
 func (mp *maintenanceProxy) HashKV(ctx context.Context, r *pb.HashKVRequest) (*pb.HashKVResponse, error) {
+	if target, ok := targetMember(ctx); ok {
+		ctx = withClientAuthToken(ctx, ctx)
+		mc, closeFn, err := mp.targetMaintenanceClient(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		defer closeFn()
+		return mc.HashKV(ctx, r)
+	}
 	return mp.maintenanceClient.HashKV(ctx, r)
 }
 
@@ -78,6 +147,15 @@ func (mp *maintenanceProxy) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.
 }
 
 func (mp *maintenanceProxy) Status(ctx context.Context, r *pb.StatusRequest) (*pb.StatusResponse, error) {
+	if target, ok := targetMember(ctx); ok {
+		ctx = withClientAuthToken(ctx, ctx)
+		mc, closeFn, err := mp.targetMaintenanceClient(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		defer closeFn()
+		return mc.Status(ctx, r)
+	}
 	return mp.maintenanceClient.Status(ctx, r)
 }
 
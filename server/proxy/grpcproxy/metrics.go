@@ -41,32 +41,87 @@ var (
 		Name:      "events_coalescing_total",
 		Help:      "Total number of events coalescing",
 	})
+	watchBroadcastReceivers = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "watch_broadcast_receivers",
+		Help:      "Number of client watchers each response of an upstream watch is broadcast to.",
+		// lowest bucket start of upper bound 1 with factor 2
+		// highest bucket start of 1 * 2^13 == 8192
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	})
+	watchEventsFannedOut = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "watch_events_fanned_out_total",
+		Help:      "Total number of events sent to client watchers by upstream watch broadcasts.",
+	})
+	watchBroadcastDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "watch_broadcast_duration_seconds",
+		Help:      "Time spent broadcasting a response of an upstream watch to its client watchers.",
+		// lowest bucket start of upper bound 0.0001 sec (0.1 ms) with factor 2
+		// highest bucket start of 0.0001 sec * 2^15 == 3.2768 sec
+		Buckets: prometheus.ExponentialBuckets(0.0001, 2, 16),
+	})
 	cacheKeys = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "etcd",
 		Subsystem: "grpc_proxy",
 		Name:      "cache_keys_total",
 		Help:      "Total number of keys/ranges cached",
 	})
-	cacheHits = prometheus.NewGauge(prometheus.GaugeOpts{
+	cacheHits = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "grpc_proxy",
 		Name:      "cache_hits_total",
 		Help:      "Total number of cache hits",
 	})
-	cachedMisses = prometheus.NewGauge(prometheus.GaugeOpts{
+	cachedMisses = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "grpc_proxy",
 		Name:      "cache_misses_total",
 		Help:      "Total number of cache misses",
 	})
+	registered = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "registered",
+		Help:      "Whether or not the proxy is registered under the resolver prefix. 1 if it is, 0 otherwise.",
+	})
+	registerAttempts = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "register_attempts_total",
+		Help:      "Total number of attempts to register the proxy under the resolver prefix.",
+	})
+	throttledRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "throttled_requests_total",
+		Help:      "Total number of requests rejected by the rate limiter, by client identity and the exceeded limit (client or global).",
+	}, []string{"identity", "limit"})
+	leaseRenewals = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "grpc_proxy",
+		Name:      "lease_renewals_total",
+		Help:      "Total number of lease renewals by the proxy, by type (coalesced on the shared upstream stream, or fallback to a direct keepalive).",
+	}, []string{"type"})
 )
 
 func init() {
 	prometheus.MustRegister(watchersCoalescing)
 	prometheus.MustRegister(eventsCoalescing)
+	prometheus.MustRegister(watchBroadcastReceivers)
+	prometheus.MustRegister(watchEventsFannedOut)
+	prometheus.MustRegister(watchBroadcastDuration)
 	prometheus.MustRegister(cacheKeys)
 	prometheus.MustRegister(cacheHits)
 	prometheus.MustRegister(cachedMisses)
+	prometheus.MustRegister(registered)
+	prometheus.MustRegister(registerAttempts)
+	prometheus.MustRegister(throttledRequests)
+	prometheus.MustRegister(leaseRenewals)
 }
 
 // HandleMetrics performs a GET request against etcd endpoint and returns '/metrics'.
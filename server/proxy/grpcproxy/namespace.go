@@ -0,0 +1,427 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// namespacedServices are the services whose requests are confined to the
+// namespace of the client.
+var namespacedServices = []string{
+	"/" + pb.KV_ServiceDesc.ServiceName + "/",
+	"/" + pb.Watch_ServiceDesc.ServiceName + "/",
+	"/" + pb.Lease_ServiceDesc.ServiceName + "/",
+}
+
+// namespaceExemptMethods are the methods outside of namespacedServices that
+// namespaced clients may call, since they do not expose any key.
+var namespaceExemptMethods = map[string]struct{}{
+	"/etcdserverpb.Cluster/MemberList": {},
+	"/etcdserverpb.Maintenance/Status": {},
+}
+
+// NamespaceConfig maps the identities of the clients of the proxy to the key
+// namespaces they are confined to.
+type NamespaceConfig struct {
+	// Namespaces maps a client identity to its namespace, the prefix of all
+	// of its keys. The identity of a client is a URI SAN or the common name
+	// of its verified TLS certificate, the URI SANs taking precedence.
+	Namespaces map[string]string `json:"namespaces"`
+	// Default is the namespace of the clients whose identity is not in
+	// Namespaces. If empty, their requests are rejected.
+	Default string `json:"default"`
+}
+
+func (cfg NamespaceConfig) validate() error {
+	for identity, ns := range cfg.Namespaces {
+		if ns == "" {
+			return fmt.Errorf("grpcproxy: namespace of %q must not be empty", identity)
+		}
+	}
+	return nil
+}
+
+// LoadNamespaceConfig reads the JSON encoded NamespaceConfig in the file at
+// the given path.
+func LoadNamespaceConfig(path string) (NamespaceConfig, error) {
+	var cfg NamespaceConfig
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("grpcproxy: failed to decode namespace config %q: %w", path, err)
+	}
+	return cfg, cfg.validate()
+}
+
+// Namespacer confines the KV, Watch and Lease requests of each client of the
+// proxy to the namespace of its identity, the same way as the clientv3
+// namespace package: the keys of the requests are prefixed with the
+// namespace, and the prefix is removed from the keys of the responses.
+// Requests whose keys would escape the namespace, requests from clients
+// without a namespace, and requests to the other services fail with
+// PermissionDenied.
+type Namespacer struct {
+	mu  sync.RWMutex
+	cfg NamespaceConfig
+}
+
+// NewNamespacer returns a Namespacer with the given configuration.
+func NewNamespacer(cfg NamespaceConfig) (*Namespacer, error) {
+	n := &Namespacer{}
+	if err := n.SetConfig(cfg); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// Config returns the current configuration.
+func (n *Namespacer) Config() NamespaceConfig {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.cfg
+}
+
+// SetConfig replaces the configuration. It applies to the requests received
+// from then on; the watches already created keep their namespace.
+func (n *Namespacer) SetConfig(cfg NamespaceConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.cfg = cfg
+	return nil
+}
+
+// WatchConfigFile reloads the configuration from the file at the given path
+// whenever it changes, checking every interval until ctx is done. An invalid
+// file is logged and the current configuration is kept.
+func (n *Namespacer) WatchConfigFile(ctx context.Context, lg *zap.Logger, path string, interval time.Duration) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	var lastMod time.Time
+	if fi, err := os.Stat(path); err == nil {
+		lastMod = fi.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			lg.Warn("failed to stat namespace config", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		if fi.ModTime().Equal(lastMod) {
+			continue
+		}
+		lastMod = fi.ModTime()
+		cfg, err := LoadNamespaceConfig(path)
+		if err == nil {
+			err = n.SetConfig(cfg)
+		}
+		if err != nil {
+			lg.Warn("failed to reload namespace config", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		lg.Info("reloaded namespace config", zap.String("path", path), zap.Int("namespaces", len(cfg.Namespaces)))
+	}
+}
+
+// namespace returns the namespace of the client of the given context.
+func (n *Namespacer) namespace(ctx context.Context) (string, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for _, identity := range certIdentities(ctx) {
+		if ns, ok := n.cfg.Namespaces[identity]; ok {
+			return ns, nil
+		}
+	}
+	if n.cfg.Default != "" {
+		return n.cfg.Default, nil
+	}
+	return "", rpctypes.ErrGRPCPermissionDenied
+}
+
+// UnaryServerInterceptor returns a unary interceptor that confines the
+// requests to the namespace of the client.
+func (n *Namespacer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		pfx, err := n.namespace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !inServices(info.FullMethod, namespacedServices) {
+			if _, ok := namespaceExemptMethods[info.FullMethod]; ok {
+				return handler(ctx, req)
+			}
+			return nil, rpctypes.ErrGRPCPermissionDenied
+		}
+		if err = prefixRequest(pfx, req); err != nil {
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		return unprefixResponse(pfx, resp), nil
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor that confines the
+// messages of the streams to the namespace of the client.
+func (n *Namespacer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		pfx, err := n.namespace(ss.Context())
+		if err != nil {
+			return err
+		}
+		if !inServices(info.FullMethod, namespacedServices) {
+			return rpctypes.ErrGRPCPermissionDenied
+		}
+		return handler(srv, &namespacedStream{ServerStream: ss, pfx: pfx})
+	}
+}
+
+type namespacedStream struct {
+	grpc.ServerStream
+	pfx string
+}
+
+func (s *namespacedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return prefixRequest(s.pfx, m)
+}
+
+func (s *namespacedStream) SendMsg(m any) error {
+	return s.ServerStream.SendMsg(unprefixResponse(s.pfx, m))
+}
+
+// prefixRequest prefixes the keys of the given request with pfx in place.
+func prefixRequest(pfx string, req any) error {
+	switch r := req.(type) {
+	case *pb.RangeRequest:
+		return prefixRange(pfx, &r.Key, &r.RangeEnd)
+	case *pb.PutRequest:
+		return prefixRange(pfx, &r.Key, nil)
+	case *pb.DeleteRangeRequest:
+		return prefixRange(pfx, &r.Key, &r.RangeEnd)
+	case *pb.TxnRequest:
+		return prefixTxnRequest(pfx, r)
+	case *pb.WatchRequest:
+		if cr := r.GetCreateRequest(); cr != nil {
+			return prefixRange(pfx, &cr.Key, &cr.RangeEnd)
+		}
+	}
+	return nil
+}
+
+func prefixTxnRequest(pfx string, r *pb.TxnRequest) error {
+	for _, cmp := range r.Compare {
+		if err := prefixRange(pfx, &cmp.Key, &cmp.RangeEnd); err != nil {
+			return err
+		}
+	}
+	for _, ops := range [][]*pb.RequestOp{r.Success, r.Failure} {
+		for _, op := range ops {
+			var err error
+			switch tv := op.Request.(type) {
+			case *pb.RequestOp_RequestRange:
+				err = prefixRequest(pfx, tv.RequestRange)
+			case *pb.RequestOp_RequestPut:
+				err = prefixRequest(pfx, tv.RequestPut)
+			case *pb.RequestOp_RequestDeleteRange:
+				err = prefixRequest(pfx, tv.RequestDeleteRange)
+			case *pb.RequestOp_RequestTxn:
+				err = prefixRequest(pfx, tv.RequestTxn)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// prefixRange prefixes the key and the range end, if any, with pfx. A range
+// end of "\x00", which stands for the end of the keyspace, is mapped to the
+// end of the namespace.
+func prefixRange(pfx string, key, end *[]byte) error {
+	if len(*key) == 0 {
+		return rpctypes.ErrGRPCEmptyKey
+	}
+	*key = append([]byte(pfx), *key...)
+	if end == nil || len(*end) == 0 {
+		return nil
+	}
+	if len(*end) == 1 && (*end)[0] == 0 {
+		pfxEnd := prefixEnd(pfx)
+		if pfxEnd == nil {
+			// a namespace of 0xff bytes has no end within the keyspace
+			return rpctypes.ErrGRPCPermissionDenied
+		}
+		*end = pfxEnd
+		return nil
+	}
+	*end = append([]byte(pfx), *end...)
+	return nil
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the
+// given prefix, or nil if there is none.
+func prefixEnd(pfx string) []byte {
+	end := []byte(pfx)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// unprefixResponse returns the given response with pfx removed from its keys.
+// The response is copied before being modified, since the proxy may share it
+// with other requests through its cache and its coalesced watchers.
+func unprefixResponse(pfx string, resp any) any {
+	switch r := resp.(type) {
+	case *pb.RangeResponse:
+		r = proto.Clone(r).(*pb.RangeResponse)
+		unprefixKvs(pfx, r.Kvs...)
+		return r
+	case *pb.RangeStreamResponse:
+		r = proto.Clone(r).(*pb.RangeStreamResponse)
+		if r.RangeResponse != nil {
+			unprefixKvs(pfx, r.RangeResponse.Kvs...)
+		}
+		return r
+	case *pb.PutResponse:
+		r = proto.Clone(r).(*pb.PutResponse)
+		if r.PrevKv != nil {
+			unprefixKvs(pfx, r.PrevKv)
+		}
+		return r
+	case *pb.DeleteRangeResponse:
+		r = proto.Clone(r).(*pb.DeleteRangeResponse)
+		unprefixKvs(pfx, r.PrevKvs...)
+		return r
+	case *pb.TxnResponse:
+		r = proto.Clone(r).(*pb.TxnResponse)
+		unprefixTxnResponse(pfx, r)
+		return r
+	case *pb.WatchResponse:
+		r = proto.Clone(r).(*pb.WatchResponse)
+		for _, ev := range r.Events {
+			unprefixKvs(pfx, ev.Kv)
+			if ev.PrevKv != nil {
+				unprefixKvs(pfx, ev.PrevKv)
+			}
+		}
+		return r
+	case *pb.LeaseTimeToLiveResponse:
+		r = proto.Clone(r).(*pb.LeaseTimeToLiveResponse)
+		keys := r.Keys[:0]
+		for _, k := range r.Keys {
+			if bytes.HasPrefix(k, []byte(pfx)) {
+				keys = append(keys, k[len(pfx):])
+			}
+		}
+		r.Keys = keys
+		return r
+	}
+	return resp
+}
+
+// unprefixTxnResponse removes pfx from the keys of the given response in place.
+func unprefixTxnResponse(pfx string, r *pb.TxnResponse) {
+	for _, op := range r.Responses {
+		switch tv := op.Response.(type) {
+		case *pb.ResponseOp_ResponseRange:
+			unprefixKvs(pfx, tv.ResponseRange.GetKvs()...)
+		case *pb.ResponseOp_ResponsePut:
+			if prevKv := tv.ResponsePut.GetPrevKv(); prevKv != nil {
+				unprefixKvs(pfx, prevKv)
+			}
+		case *pb.ResponseOp_ResponseDeleteRange:
+			unprefixKvs(pfx, tv.ResponseDeleteRange.GetPrevKvs()...)
+		case *pb.ResponseOp_ResponseTxn:
+			if tv.ResponseTxn != nil {
+				unprefixTxnResponse(pfx, tv.ResponseTxn)
+			}
+		}
+	}
+}
+
+func unprefixKvs(pfx string, kvs ...*mvccpb.KeyValue) {
+	for _, kv := range kvs {
+		if kv != nil && bytes.HasPrefix(kv.Key, []byte(pfx)) {
+			kv.Key = kv.Key[len(pfx):]
+		}
+	}
+}
+
+// certIdentities returns the URI SANs and the common name of the verified TLS
+// certificate of the client, in this order.
+func certIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	var identities []string
+	for _, chains := range tlsInfo.State.VerifiedChains {
+		if len(chains) == 0 {
+			continue
+		}
+		for _, uri := range chains[0].URIs {
+			identities = append(identities, uri.String())
+		}
+		if cn := chains[0].Subject.CommonName; cn != "" {
+			identities = append(identities, cn)
+		}
+	}
+	return identities
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+func TestPrefixRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		pfx     string
+		key     string
+		end     string
+		wantKey string
+		wantEnd string
+		wantErr error
+	}{
+		{name: "single key", pfx: "a/", key: "foo", wantKey: "a/foo"},
+		{name: "range", pfx: "a/", key: "foo", end: "fop", wantKey: "a/foo", wantEnd: "a/fop"},
+		{name: "from key", pfx: "a/", key: "foo", end: "\x00", wantKey: "a/foo", wantEnd: "a0"},
+		{name: "end beyond keyspace", pfx: "a\xff", key: "foo", end: "\xff\xff", wantKey: "a\xfffoo", wantEnd: "a\xff\xff\xff"},
+		{name: "from key of unbounded namespace", pfx: "\xff\xff", key: "foo", end: "\x00", wantErr: rpctypes.ErrGRPCPermissionDenied},
+		{name: "empty key", pfx: "a/", wantErr: rpctypes.ErrGRPCEmptyKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, end := []byte(tt.key), []byte(tt.end)
+			err := prefixRange(tt.pfx, &key, &end)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKey, string(key))
+			assert.Equal(t, tt.wantEnd, string(end))
+		})
+	}
+}
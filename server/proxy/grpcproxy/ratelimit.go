@@ -0,0 +1,241 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+)
+
+// rateLimitIdleTimeout is how long the limiter of a client identity is kept
+// after its last request.
+const rateLimitIdleTimeout = 5 * time.Minute
+
+// rateLimitedServices are the services whose requests are rate limited.
+var rateLimitedServices = []string{
+	"/" + pb.KV_ServiceDesc.ServiceName + "/",
+	"/" + pb.Watch_ServiceDesc.ServiceName + "/",
+	"/" + pb.Lease_ServiceDesc.ServiceName + "/",
+}
+
+// RateLimitConfig is the configuration of a RateLimiter. A zero QPS disables
+// the corresponding limit. A zero burst defaults to the QPS, rounded up.
+type RateLimitConfig struct {
+	// QPS is the number of requests per second allowed for each client identity.
+	QPS float64 `json:"qps"`
+	// Burst is the number of requests a client identity may issue at once.
+	Burst int `json:"burst"`
+	// GlobalQPS is the number of requests per second allowed for all clients together.
+	GlobalQPS float64 `json:"global-qps"`
+	// GlobalBurst is the number of requests all clients together may issue at once.
+	GlobalBurst int `json:"global-burst"`
+}
+
+func (cfg RateLimitConfig) validate() error {
+	if cfg.QPS < 0 || cfg.GlobalQPS < 0 {
+		return errors.New("grpcproxy: rate limit qps must not be negative")
+	}
+	if cfg.Burst < 0 || cfg.GlobalBurst < 0 {
+		return errors.New("grpcproxy: rate limit burst must not be negative")
+	}
+	return nil
+}
+
+func newLimiter(qps float64, burst int) *rate.Limiter {
+	if qps == 0 {
+		return nil
+	}
+	if burst == 0 {
+		burst = int(math.Ceil(qps))
+	}
+	return rate.NewLimiter(rate.Limit(qps), burst)
+}
+
+type clientLimiter struct {
+	*rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiter limits the rate of the KV, Watch and Lease requests served by
+// the proxy, per client identity and for all clients together. The identity
+// of a client is the common name of its verified TLS certificate, or its IP
+// address if it has none. A rejected request fails with ResourceExhausted.
+// Every message received on a stream counts as a request.
+type RateLimiter struct {
+	mu      sync.Mutex
+	cfg     RateLimitConfig
+	global  *rate.Limiter
+	clients map[string]*clientLimiter
+	lastGC  time.Time
+}
+
+// NewRateLimiter returns a RateLimiter with the given configuration.
+func NewRateLimiter(cfg RateLimitConfig) (*RateLimiter, error) {
+	rl := &RateLimiter{}
+	if err := rl.SetConfig(cfg); err != nil {
+		return nil, err
+	}
+	return rl, nil
+}
+
+// Config returns the current configuration.
+func (rl *RateLimiter) Config() RateLimitConfig {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.cfg
+}
+
+// SetConfig replaces the configuration. It takes effect immediately; the
+// requests already admitted are forgotten.
+func (rl *RateLimiter) SetConfig(cfg RateLimitConfig) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.cfg = cfg
+	rl.global = newLimiter(cfg.GlobalQPS, cfg.GlobalBurst)
+	rl.clients = make(map[string]*clientLimiter)
+	return nil
+}
+
+// allow admits a request of the given client identity, or returns the error
+// to reject it with.
+func (rl *RateLimiter) allow(identity string) error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.gc(now)
+	if rl.cfg.QPS > 0 {
+		cl, ok := rl.clients[identity]
+		if !ok {
+			cl = &clientLimiter{Limiter: newLimiter(rl.cfg.QPS, rl.cfg.Burst)}
+			rl.clients[identity] = cl
+		}
+		cl.lastSeen = now
+		if !cl.AllowN(now, 1) {
+			throttledRequests.WithLabelValues(identity, "client").Inc()
+			return rpctypes.ErrGRPCRequestTooManyRequests
+		}
+	}
+	if rl.global != nil && !rl.global.AllowN(now, 1) {
+		throttledRequests.WithLabelValues(identity, "global").Inc()
+		return rpctypes.ErrGRPCRequestTooManyRequests
+	}
+	return nil
+}
+
+// gc drops the limiters of the client identities that have been idle for
+// rateLimitIdleTimeout.
+func (rl *RateLimiter) gc(now time.Time) {
+	if now.Sub(rl.lastGC) < rateLimitIdleTimeout {
+		return
+	}
+	rl.lastGC = now
+	for identity, cl := range rl.clients {
+		if now.Sub(cl.lastSeen) >= rateLimitIdleTimeout {
+			delete(rl.clients, identity)
+		}
+	}
+}
+
+// UnaryServerInterceptor returns a unary interceptor that rate limits the
+// requests of the KV, Watch and Lease services.
+func (rl *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isRateLimited(info.FullMethod) {
+			if err := rl.allow(clientIdentity(ctx)); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor that rate limits the
+// messages received on the streams of the KV, Watch and Lease services.
+func (rl *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !isRateLimited(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		return handler(srv, &rateLimitedStream{ServerStream: ss, rl: rl, identity: clientIdentity(ss.Context())})
+	}
+}
+
+type rateLimitedStream struct {
+	grpc.ServerStream
+	rl       *RateLimiter
+	identity string
+}
+
+func (s *rateLimitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.rl.allow(s.identity)
+}
+
+func isRateLimited(fullMethod string) bool {
+	return inServices(fullMethod, rateLimitedServices)
+}
+
+// inServices returns true if the given method belongs to one of the services.
+func inServices(fullMethod string, services []string) bool {
+	for _, prefix := range services {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIdentity returns the common name of the verified TLS certificate of
+// the client, or its IP address if there is none.
+func clientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p == nil {
+		return ""
+	}
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		for _, chains := range tlsInfo.State.VerifiedChains {
+			if len(chains) > 0 && chains[0].Subject.CommonName != "" {
+				return chains[0].Subject.CommonName
+			}
+		}
+	}
+	if p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
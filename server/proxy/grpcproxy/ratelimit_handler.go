@@ -0,0 +1,64 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// PathProxyRateLimit is the path of the endpoint that reports and updates the
+// rate limits of the proxy.
+const PathProxyRateLimit = "/proxy/ratelimit"
+
+// HandleRateLimit registers a handler on '/proxy/ratelimit' that reports the
+// configuration of the given rate limiter on GET, and replaces it with the
+// JSON encoded RateLimitConfig in the request body on PUT.
+func HandleRateLimit(lg *zap.Logger, mux *http.ServeMux, rl *RateLimiter) {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	mux.HandleFunc(PathProxyRateLimit, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var cfg RateLimitConfig
+			dec := json.NewDecoder(r.Body)
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&cfg); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := rl.SetConfig(cfg); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lg.Info("updated gRPC proxy rate limits",
+				zap.Float64("qps", cfg.QPS),
+				zap.Int("burst", cfg.Burst),
+				zap.Float64("global-qps", cfg.GlobalQPS),
+				zap.Int("global-burst", cfg.GlobalBurst),
+			)
+		default:
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(rl.Config())
+	})
+}
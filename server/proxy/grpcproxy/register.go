@@ -15,77 +15,288 @@
 package grpcproxy
 
 import (
+	"context"
 	"encoding/json"
+	"math/rand"
 	"os"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
-	"golang.org/x/time/rate"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/client/v3/naming/endpoints"
 )
 
-// allow maximum 1 retry per second
-const registerRetryRate = 1
+const (
+	// defaultRegisterBackoffBase is the default delay before retrying a
+	// failed registration, which doubles with every consecutive failure.
+	defaultRegisterBackoffBase = time.Second
+	// defaultRegisterBackoffMax is the default maximum delay between two
+	// registration attempts.
+	defaultRegisterBackoffMax = 30 * time.Second
+	// defaultRegisterHealthCheckInterval is the default interval between
+	// two health checks of a registered proxy.
+	defaultRegisterHealthCheckInterval = 5 * time.Second
+)
 
 // Register registers itself as a grpc-proxy server by writing prefixed-key
-// with session of specified TTL (in seconds). The returned channel is closed
-// when the client's context is canceled.
-func Register(lg *zap.Logger, c *clientv3.Client, prefix string, addr string, ttl int) <-chan struct{} {
-	rm := rate.NewLimiter(rate.Limit(registerRetryRate), registerRetryRate)
-
-	donec := make(chan struct{})
-	go func() {
-		defer close(donec)
-
-		for rm.Wait(c.Ctx()) == nil {
-			ss, err := registerSession(lg, c, prefix, addr, ttl)
-			if err != nil {
-				lg.Warn("failed to create a session", zap.Error(err))
-				continue
-			}
+// with session of specified TTL (in seconds). The weight is the relative share
+// of traffic the proxy should get from clients dialing with the weighted
+// round-robin balancer, and 0 means the default weight. The returned channel
+// is closed when the client's context is canceled.
+func Register(lg *zap.Logger, c *clientv3.Client, prefix string, addr string, ttl int, weight uint32, opts ...RegisterOption) <-chan struct{} {
+	return RegisterEndpoint(lg, c, prefix, endpoints.Endpoint{Addr: addr, Weight: weight}, ttl, opts...).Done()
+}
+
+type registerOptions struct {
+	backoffBase         time.Duration
+	backoffMax          time.Duration
+	healthCheck         func(ctx context.Context) error
+	healthCheckInterval time.Duration
+}
+
+// RegisterOption configures how a grpc-proxy endpoint is kept registered.
+type RegisterOption func(*registerOptions)
+
+// WithRegisterBackoff sets the delay before retrying a failed registration,
+// which doubles with every consecutive failure up to max. Delays are
+// jittered so that proxies do not retry in lockstep.
+func WithRegisterBackoff(base, max time.Duration) RegisterOption {
+	return func(op *registerOptions) {
+		if base > 0 {
+			op.backoffBase = base
+		}
+		if max > 0 {
+			op.backoffMax = max
+		}
+	}
+}
+
+// WithRegisterHealthCheck only keeps the endpoint registered while the given
+// health check succeeds. The health check is run before every registration
+// attempt, and every interval while registered; the endpoint is deregistered
+// as soon as it fails.
+func WithRegisterHealthCheck(check func(ctx context.Context) error, interval time.Duration) RegisterOption {
+	return func(op *registerOptions) {
+		op.healthCheck = check
+		if interval > 0 {
+			op.healthCheckInterval = interval
+		}
+	}
+}
+
+// Registration is the registration of a grpc-proxy endpoint made by
+// RegisterEndpoint.
+type Registration struct {
+	lg     *zap.Logger
+	c      *clientv3.Client
+	prefix string
+	key    string
+	opts   registerOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	donec  chan struct{}
+
+	mu sync.Mutex
+	// ss is the session of the registered endpoint key
+	ss *concurrency.Session
+}
+
+// RegisterEndpoint registers the given endpoint as a grpc-proxy server under
+// the prefix with a session of the specified TTL (in seconds), and keeps it
+// registered until the client's context is canceled or Deregister is called.
+// The metadata of the endpoint, for example its zone, is stored with it for
+// clients to select endpoints. If the metadata is nil, the hostname is
+// registered as the name of the proxy. Otherwise, it should be a JSON object
+// whose "name" field is the name reported by the member list of the proxies.
+func RegisterEndpoint(lg *zap.Logger, c *clientv3.Client, prefix string, ep endpoints.Endpoint, ttl int, opts ...RegisterOption) *Registration {
+	if ep.Metadata == nil {
+		ep.Metadata = getMeta()
+	}
+	op := registerOptions{
+		backoffBase:         defaultRegisterBackoffBase,
+		backoffMax:          defaultRegisterBackoffMax,
+		healthCheckInterval: defaultRegisterHealthCheckInterval,
+	}
+	for _, opt := range opts {
+		opt(&op)
+	}
+	ctx, cancel := context.WithCancel(c.Ctx())
+	r := &Registration{
+		lg:     lg,
+		c:      c,
+		prefix: prefix,
+		key:    prefix + "/" + ep.Addr,
+		opts:   op,
+		ctx:    ctx,
+		cancel: cancel,
+		donec:  make(chan struct{}),
+	}
+	go r.run(ep, ttl)
+	return r
+}
+
+func (r *Registration) run(ep endpoints.Endpoint, ttl int) {
+	defer close(r.donec)
+
+	var healthc <-chan time.Time
+	if r.opts.healthCheck != nil {
+		ticker := time.NewTicker(r.opts.healthCheckInterval)
+		defer ticker.Stop()
+		healthc = ticker.C
+	}
+
+	failures := 0
+	for first := true; ; first = false {
+		if !first {
 			select {
-			case <-c.Ctx().Done():
-				ss.Close()
+			case <-time.After(r.backoff(failures)):
+			case <-r.ctx.Done():
 				return
+			}
+		}
 
-			case <-ss.Done():
-				lg.Warn("session expired; possible network partition or server restart")
-				lg.Warn("creating a new session to rejoin")
-				continue
+		registerAttempts.Inc()
+		if err := r.checkHealth(); err != nil {
+			r.lg.Warn("proxy is unhealthy; skipped registration", zap.Error(err))
+			failures++
+			continue
+		}
+		ss, err := r.registerSession(ep, ttl)
+		if err != nil {
+			r.lg.Warn("failed to create a session", zap.Error(err))
+			failures++
+			continue
+		}
+		failures = 0
+		r.mu.Lock()
+		r.ss = ss
+		r.mu.Unlock()
+		registered.Set(1)
+
+		if !r.keepRegistered(ss, healthc) {
+			return
+		}
+		r.mu.Lock()
+		r.ss = nil
+		r.mu.Unlock()
+		registered.Set(0)
+	}
+}
+
+// keepRegistered waits until the registration of the session is lost, and
+// returns whether the endpoint should be registered again.
+func (r *Registration) keepRegistered(ss *concurrency.Session, healthc <-chan time.Time) bool {
+	for {
+		select {
+		case <-r.ctx.Done():
+			registered.Set(0)
+			if r.c.Ctx().Err() != nil {
+				ss.Close()
+			}
+			// otherwise Deregister deletes the key and closes the session
+			return false
+
+		case <-ss.Done():
+			r.lg.Warn("session expired; possible network partition or server restart")
+			r.lg.Warn("creating a new session to rejoin")
+			return true
+
+		case <-healthc:
+			if err := r.checkHealth(); err != nil {
+				r.lg.Warn("proxy is unhealthy; deregistering", zap.Error(err))
+				// revoking the lease of the session deletes the endpoint key
+				ss.Close()
+				return true
 			}
 		}
-	}()
+	}
+}
 
-	return donec
+func (r *Registration) checkHealth() error {
+	if r.opts.healthCheck == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, r.opts.healthCheckInterval)
+	defer cancel()
+	return r.opts.healthCheck(ctx)
 }
 
-func registerSession(lg *zap.Logger, c *clientv3.Client, prefix string, addr string, ttl int) (*concurrency.Session, error) {
-	ss, err := concurrency.NewSession(c, concurrency.WithTTL(ttl))
+// backoff returns the jittered delay before the next registration attempt
+// after the given number of consecutive failures.
+func (r *Registration) backoff(failures int) time.Duration {
+	d := r.opts.backoffBase
+	for i := 0; i < failures && d < r.opts.backoffMax; i++ {
+		d *= 2
+	}
+	d = min(d, r.opts.backoffMax)
+	// wait between half and all of the delay
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func (r *Registration) registerSession(ep endpoints.Endpoint, ttl int) (*concurrency.Session, error) {
+	ss, err := concurrency.NewSession(r.c, concurrency.WithTTL(ttl))
 	if err != nil {
 		return nil, err
 	}
 
-	em, err := endpoints.NewManager(c, prefix)
+	em, err := endpoints.NewManager(r.c, r.prefix)
 	if err != nil {
 		ss.Close()
 		return nil, err
 	}
-	endpoint := endpoints.Endpoint{Addr: addr, Metadata: getMeta()}
-	if err = em.AddEndpoint(c.Ctx(), prefix+"/"+addr, endpoint, clientv3.WithLease(ss.Lease())); err != nil {
+	if err = em.AddEndpoint(r.ctx, r.key, ep, clientv3.WithLease(ss.Lease())); err != nil {
 		ss.Close()
 		return nil, err
 	}
 
-	lg.Info(
+	r.lg.Info(
 		"registered session with lease",
-		zap.String("addr", addr),
+		zap.String("addr", ep.Addr),
 		zap.Int("lease-ttl", ttl),
+		zap.Uint32("weight", ep.Weight),
 	)
 	return ss, nil
 }
 
+// Done returns a channel that is closed when the endpoint stops being kept
+// registered, because the client's context is canceled or Deregister is called.
+func (r *Registration) Done() <-chan struct{} {
+	return r.donec
+}
+
+// Deregister stops keeping the endpoint registered, deletes its key so that
+// watchers of the prefix observe its removal right away instead of after the
+// session TTL, and closes the session. It is safe to call more than once.
+func (r *Registration) Deregister(ctx context.Context) error {
+	r.cancel()
+	<-r.donec
+
+	r.mu.Lock()
+	ss := r.ss
+	r.ss = nil
+	r.mu.Unlock()
+	if ss == nil {
+		return nil
+	}
+
+	em, err := endpoints.NewManager(r.c, r.prefix)
+	if err == nil {
+		err = em.DeleteEndpoint(ctx, r.key)
+	}
+	if cerr := ss.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	r.lg.Info("deregistered endpoint", zap.String("key", r.key))
+	return nil
+}
+
 // meta represents metadata of proxy register.
 type meta struct {
 	Name string `json:"name"`
@@ -97,8 +308,19 @@ func getMeta() string {
 	return string(bts)
 }
 
-func decodeMeta(s string) (meta, error) {
+// decodeMeta decodes the metadata of a registered endpoint, which is either
+// the JSON encoded meta registered by default or a JSON object given to
+// RegisterEndpoint.
+func decodeMeta(md any) (meta, error) {
 	m := meta{}
-	err := json.Unmarshal([]byte(s), &m)
+	bts, ok := md.(string)
+	if !ok {
+		b, err := json.Marshal(md)
+		if err != nil {
+			return m, err
+		}
+		bts = string(b)
+	}
+	err := json.Unmarshal([]byte(bts), &m)
 	return m, err
 }
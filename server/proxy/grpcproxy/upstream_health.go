@@ -0,0 +1,127 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// PathProxyUpstreamHealth is the path of the endpoint that reports the health
+// of the upstream endpoints of the proxy.
+const PathProxyUpstreamHealth = "/proxy/upstream-health"
+
+// UpstreamHealth is the last known health of an upstream endpoint.
+type UpstreamHealth struct {
+	Endpoint string `json:"endpoint"`
+	// MemberID is the hexadecimal ID of the member serving the endpoint, as
+	// of the last successful check.
+	MemberID string `json:"member_id,omitempty"`
+	// LastConnect is the time of the last successful check.
+	LastConnect time.Time `json:"last_connect,omitzero"`
+	// LastError is the error of the last check, empty if it succeeded.
+	LastError string `json:"last_error,omitempty"`
+}
+
+// UpstreamHealthChecker periodically checks the status of each upstream
+// endpoint of a client.
+type UpstreamHealthChecker struct {
+	c *clientv3.Client
+
+	mu     sync.RWMutex
+	health map[string]UpstreamHealth
+}
+
+func NewUpstreamHealthChecker(c *clientv3.Client) *UpstreamHealthChecker {
+	return &UpstreamHealthChecker{c: c, health: make(map[string]UpstreamHealth)}
+}
+
+// Run checks the upstream endpoints every interval until the context is done.
+func (uc *UpstreamHealthChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		uc.check(ctx, interval)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (uc *UpstreamHealthChecker) check(ctx context.Context, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, ep := range uc.c.Endpoints() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			resp, err := uc.c.Status(cctx, ep)
+			cancel()
+
+			uc.mu.Lock()
+			defer uc.mu.Unlock()
+			h := uc.health[ep]
+			h.Endpoint = ep
+			if err != nil {
+				h.LastError = err.Error()
+			} else {
+				h.MemberID = fmt.Sprintf("%x", resp.Header.MemberId)
+				h.LastConnect = time.Now()
+				h.LastError = ""
+			}
+			uc.health[ep] = h
+		}()
+	}
+	wg.Wait()
+}
+
+// Health returns the last known health of each upstream endpoint, in the
+// order of the endpoints of the client.
+func (uc *UpstreamHealthChecker) Health() []UpstreamHealth {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	eps := uc.c.Endpoints()
+	hs := make([]UpstreamHealth, 0, len(eps))
+	for _, ep := range eps {
+		h, ok := uc.health[ep]
+		if !ok {
+			h = UpstreamHealth{Endpoint: ep, LastError: "not checked yet"}
+		}
+		hs = append(hs, h)
+	}
+	return hs
+}
+
+// HandleUpstreamHealth registers a handler on '/proxy/upstream-health' that
+// reports the last known health of each upstream endpoint.
+func HandleUpstreamHealth(mux *http.ServeMux, uc *UpstreamHealthChecker) {
+	mux.HandleFunc(PathProxyUpstreamHealth, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(uc.Health())
+	})
+}
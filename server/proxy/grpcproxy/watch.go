@@ -16,7 +16,10 @@ package grpcproxy
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -27,8 +30,11 @@ import (
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
+var errWatchCompareUnsupported = errors.New("grpcproxy: watch compares are not supported by the proxy")
+
 type watchProxy struct {
 	cw  clientv3.Watcher
 	ctx context.Context
@@ -47,11 +53,37 @@ type watchProxy struct {
 	kv clientv3.KV
 	lg *zap.Logger
 
+	// maxBroadcastWatchers caps the client watchers served by one upstream
+	// watch; 0 means no cap.
+	maxBroadcastWatchers int
+	// dedicatedStreams numbers the dedicated upstream watch streams.
+	dedicatedStreams atomic.Uint64
+
 	// we want compile errors if new methods are added
 	pb.UnsafeWatchServer
 }
 
-func NewWatchProxy(ctx context.Context, lg *zap.Logger, c *clientv3.Client) (pb.WatchServer, <-chan struct{}) {
+type watchProxyOptions struct {
+	maxBroadcastWatchers int
+}
+
+// WatchProxyOption configures the watch proxy.
+type WatchProxyOption func(*watchProxyOptions)
+
+// WithMaxBroadcastWatchers caps the number of client watchers served by a
+// single upstream watch. Once every upstream watch of a range is full, new
+// watchers are served by an upstream watch on a dedicated stream, so a slow
+// watcher holds up a bounded number of others. Watchers are not capped by
+// default.
+func WithMaxBroadcastWatchers(n int) WatchProxyOption {
+	return func(o *watchProxyOptions) { o.maxBroadcastWatchers = n }
+}
+
+func NewWatchProxy(ctx context.Context, lg *zap.Logger, c *clientv3.Client, opts ...WatchProxyOption) (pb.WatchServer, <-chan struct{}) {
+	var o watchProxyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	cctx, cancel := context.WithCancel(ctx)
 	wp := &watchProxy{
 		cw:     c.Watcher,
@@ -60,6 +92,8 @@ func NewWatchProxy(ctx context.Context, lg *zap.Logger, c *clientv3.Client) (pb.
 
 		kv: c.KV, // for permission checking
 		lg: lg,
+
+		maxBroadcastWatchers: o.maxBroadcastWatchers,
 	}
 	wp.ranges = newWatchRanges(wp)
 	ch := make(chan struct{})
@@ -260,16 +294,45 @@ func (wps *watchProxyStream) recvLoop() error {
 				continue
 			}
 
+			if cr.Compare != nil {
+				// the watchers of the proxy share the events of the server,
+				// which does not evaluate their compares
+				wps.watchCh <- &pb.WatchResponse{
+					Header:       &pb.ResponseHeader{},
+					WatchId:      clientv3.InvalidWatchID,
+					Created:      true,
+					Canceled:     true,
+					CancelReason: errWatchCompareUnsupported.Error(),
+				}
+				continue
+			}
+
+			// the proxy filters the events itself, so it allows value
+			// selectors up to the default cost
+			filters, err := v3rpc.FiltersFromRequest(cr, mvcc.DefaultValueSelectorMaxCost)
+			if err != nil {
+				wps.watchCh <- &pb.WatchResponse{
+					Header:       &pb.ResponseHeader{},
+					WatchId:      clientv3.InvalidWatchID,
+					Created:      true,
+					Canceled:     true,
+					CancelReason: err.Error(),
+				}
+				continue
+			}
+
 			wps.mu.Lock()
 			w := &watcher{
 				wr:  watchRange{string(cr.Key), string(cr.RangeEnd)},
 				id:  wps.nextWatcherID,
 				wps: wps,
 
-				nextrev:  cr.StartRevision,
-				progress: cr.ProgressNotify,
-				prevKV:   cr.PrevKv,
-				filters:  v3rpc.FiltersFromRequest(cr),
+				nextrev:     cr.StartRevision,
+				progress:    cr.ProgressNotify,
+				prevKV:      cr.PrevKv,
+				keysOnly:    cr.KeysOnly,
+				versionOnly: cr.VersionOnly,
+				filters:     filters,
 			}
 			if !w.wr.valid() {
 				w.post(&pb.WatchResponse{
@@ -290,6 +353,15 @@ func (wps *watchProxyStream) recvLoop() error {
 		case *pb.WatchRequest_CancelRequest:
 			wps.delete(uv.CancelRequest.WatchId)
 			wps.lg.Debug("cancel watcher", zap.Int64("watcherId", uv.CancelRequest.WatchId))
+		case *pb.WatchRequest_CancelRangeRequest:
+			cr := uv.CancelRangeRequest
+			for _, id := range wps.watchersWithin(cr.GetKey(), cr.GetRangeEnd()) {
+				wps.delete(id)
+			}
+			wps.lg.Debug("cancel watchers in range", zap.ByteString("key", cr.GetKey()), zap.ByteString("end", cr.GetRangeEnd()))
+		case *pb.WatchRequest_AckRequest:
+			// the watchers of the proxy are shared by its clients, so the
+			// events are not held back for the acks of a single client
 		default:
 			// Panic or Fatalf would allow to network clients to crash the serve remotely.
 			wps.lg.Error("not supported request type by gRPC proxy", zap.Stringer("request", req))
@@ -313,6 +385,21 @@ func (wps *watchProxyStream) sendLoop() {
 	}
 }
 
+// watchersWithin returns the IDs of the watchers whose keys are all in the
+// range [key, end), in increasing order.
+func (wps *watchProxyStream) watchersWithin(key, end []byte) []int64 {
+	wps.mu.Lock()
+	defer wps.mu.Unlock()
+	var ids []int64
+	for id, w := range wps.watchers {
+		if v3rpc.WatchRangeWithin([]byte(w.wr.key), []byte(w.wr.end), key, end) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 func (wps *watchProxyStream) delete(id int64) {
 	wps.mu.Lock()
 	defer wps.mu.Unlock()
@@ -16,15 +16,22 @@ package grpcproxy
 
 import (
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
+// watchStreamKey is the metadata key that sets apart the contexts of upstream
+// watches on dedicated streams, since the client shares one stream between
+// all the watches with the same metadata.
+const watchStreamKey = "proxy-watch-stream"
+
 // watchBroadcast broadcasts a server watcher to many client watchers.
 type watchBroadcast struct {
 	// cancel stops the underlying etcd server watcher and closes ch.
@@ -39,17 +46,26 @@ type watchBroadcast struct {
 	receivers map[*watcher]struct{}
 	// responses counts the number of responses
 	responses int
-	lg        *zap.Logger
+	// maxReceivers caps the number of receivers; 0 means no cap.
+	maxReceivers int
+	lg           *zap.Logger
 }
 
-func newWatchBroadcast(lg *zap.Logger, wp *watchProxy, w *watcher, update func(*watchBroadcast)) *watchBroadcast {
+// newWatchBroadcast starts an upstream watch for w. If dedicated is set, the
+// upstream watch is opened on its own stream instead of the shared one.
+func newWatchBroadcast(lg *zap.Logger, wp *watchProxy, w *watcher, update func(*watchBroadcast), dedicated bool) *watchBroadcast {
 	cctx, cancel := context.WithCancel(wp.ctx)
+	if dedicated {
+		id := wp.dedicatedStreams.Add(1)
+		cctx = metadata.AppendToOutgoingContext(cctx, watchStreamKey, strconv.FormatUint(id, 10))
+	}
 	wb := &watchBroadcast{
-		cancel:    cancel,
-		nextrev:   w.nextrev,
-		receivers: make(map[*watcher]struct{}),
-		donec:     make(chan struct{}),
-		lg:        lg,
+		cancel:       cancel,
+		nextrev:      w.nextrev,
+		receivers:    make(map[*watcher]struct{}),
+		donec:        make(chan struct{}),
+		maxReceivers: wp.maxBroadcastWatchers,
+		lg:           lg,
 	}
 	wb.add(w)
 	go func() {
@@ -84,14 +100,24 @@ func (wb *watchBroadcast) bcast(wr clientv3.WatchResponse) {
 		wb.nextrev = wr.Header.Revision + 1
 	}
 	wb.responses++
+	start := time.Now()
 	for r := range wb.receivers {
 		r.send(wr)
 	}
 	if len(wb.receivers) > 0 {
 		eventsCoalescing.Add(float64(len(wb.receivers) - 1))
+		watchBroadcastReceivers.Observe(float64(len(wb.receivers)))
+		watchBroadcastDuration.Observe(time.Since(start).Seconds())
+		watchEventsFannedOut.Add(float64(len(wr.Events) * len(wb.receivers)))
 	}
 }
 
+// full returns true if the broadcast cannot take any more receivers.
+// Expects wb.mu to be held.
+func (wb *watchBroadcast) full() bool {
+	return wb.maxReceivers > 0 && len(wb.receivers) >= wb.maxReceivers
+}
+
 // add puts a watcher into receiving a broadcast if its revision at least
 // meets the broadcast revision. Returns true if added.
 func (wb *watchBroadcast) add(w *watcher) bool {
@@ -102,6 +128,9 @@ func (wb *watchBroadcast) add(w *watcher) bool {
 		// or wb is being established with a current watcher
 		return false
 	}
+	if wb.full() {
+		return false
+	}
 	if wb.responses == 0 {
 		// Newly created; create event will be sent by etcd.
 		wb.receivers[w] = struct{}{}
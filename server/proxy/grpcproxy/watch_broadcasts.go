@@ -64,7 +64,9 @@ func (wbs *watchBroadcasts) coalesce(wb *watchBroadcast) {
 		// 1. check if wbswb is behind wb so it won't skip any events in wb
 		// 2. ensure wbswb started; nextrev == 0 may mean wbswb is waiting
 		// for a current watcher and expects a create event from the server.
-		if wb.nextrev >= wbswb.nextrev && wbswb.responses > 0 {
+		// 3. ensure the merged receivers do not exceed the cap of wbswb.
+		fits := wbswb.maxReceivers == 0 || len(wbswb.receivers)+len(wb.receivers) <= wbswb.maxReceivers
+		if wb.nextrev >= wbswb.nextrev && wbswb.responses > 0 && fits {
 			for w := range wb.receivers {
 				wbswb.receivers[w] = struct{}{}
 				wbs.watchers[w] = wbswb
@@ -86,14 +88,18 @@ func (wbs *watchBroadcasts) add(w *watcher) {
 	wbs.mu.Lock()
 	defer wbs.mu.Unlock()
 	// find fitting bcast
+	full := false
 	for wb := range wbs.bcasts {
 		if wb.add(w) {
 			wbs.watchers[w] = wb
 			return
 		}
+		wb.mu.RLock()
+		full = full || wb.full()
+		wb.mu.RUnlock()
 	}
-	// no fit; create a bcast
-	wb := newWatchBroadcast(wbs.wp.lg, wbs.wp, w, wbs.update)
+	// no fit; create a bcast, on its own stream if the others are capped
+	wb := newWatchBroadcast(wbs.wp.lg, wbs.wp, w, wbs.update, full)
 	wbs.watchers[w] = wb
 	wbs.bcasts[wb] = struct{}{}
 }
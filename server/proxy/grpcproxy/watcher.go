@@ -20,6 +20,7 @@ import (
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -34,10 +35,12 @@ func (wr *watchRange) valid() bool {
 type watcher struct {
 	// user configuration
 
-	wr       watchRange
-	filters  []mvcc.FilterFunc
-	progress bool
-	prevKV   bool
+	wr          watchRange
+	filters     []mvcc.FilterFunc
+	progress    bool
+	prevKV      bool
+	keysOnly    bool
+	versionOnly bool
 
 	// id is the id returned to the client on its watch stream.
 	id int64
@@ -96,6 +99,12 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 			}
 			ev = evCopy
 		}
+		switch {
+		case w.versionOnly:
+			ev = v3rpc.VersionOnlyEvent(ev)
+		case w.keysOnly:
+			ev = v3rpc.KeysOnlyEvent(ev)
+		}
 		events = append(events, ev)
 	}
 
@@ -116,6 +125,7 @@ func (w *watcher) send(wr clientv3.WatchResponse) {
 		Canceled:        wr.Canceled,
 		WatchId:         w.id,
 		Events:          events,
+		CatchUp:         wr.IsCatchUp(),
 	})
 }
 
@@ -86,7 +86,9 @@ func (h *kvHasher) WriteKeyValue(k, v []byte) {
 	}
 
 	h.hash.Write(k)
-	h.hash.Write(v)
+	// hash the canonical form, so that members agree on the hash however far
+	// they are in migrating their values to another encoding.
+	h.hash.Write(canonicalValue(v))
 }
 
 func (h *kvHasher) Hash() KeyValueHash {
@@ -124,6 +124,10 @@ type KV interface {
 	// HashStorage returns HashStorage interface for KV storage.
 	HashStorage() HashStorage
 
+	// ValueEncodingStatus returns the encoding of the stored values and the
+	// progress of migrating them to it.
+	ValueEncodingStatus() ValueEncodingStatus
+
 	// Compact frees all superseded keys with revisions less than rev.
 	Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
 
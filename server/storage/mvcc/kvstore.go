@@ -23,7 +23,6 @@ import (
 	"time"
 
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
@@ -43,11 +42,27 @@ var (
 	restoreChunkKeys               = 10000 // non-const for testing
 	defaultCompactionBatchLimit    = 1000
 	defaultCompactionSleepInterval = 10 * time.Millisecond
+
+	defaultValueMigrationBatchLimit    = 1000
+	defaultValueMigrationSleepInterval = 100 * time.Millisecond
 )
 
 type StoreConfig struct {
 	CompactionBatchLimit    int
 	CompactionSleepInterval time.Duration
+	// ValueEncoding is the encoding new values are written with. Values
+	// written with another encoding are migrated to it in the background.
+	ValueEncoding ValueEncoding
+	// ValueMigrationBatchLimit is the number of values migrated per backend
+	// transaction.
+	ValueMigrationBatchLimit int
+	// ValueMigrationSleepInterval is the pause between two batches of the
+	// value migration.
+	ValueMigrationSleepInterval time.Duration
+	// WatchSyncFairness is the ratio of the time left to the live delivery of
+	// the synced watchers to the time spent catching up unsynced watchers,
+	// while they are catching up. 0 means DefaultWatchSyncFairness.
+	WatchSyncFairness float64
 }
 
 type store struct {
@@ -76,6 +91,14 @@ type store struct {
 	fifoSched schedule.Scheduler
 
 	stopc chan struct{}
+	// wg tracks the value encoding migration.
+	wg sync.WaitGroup
+
+	// migrationMu protects migration.
+	migrationMu sync.RWMutex
+	// migration is the progress of the value encoding migration, or nil
+	// if there is none.
+	migration *valueMigration
 
 	lg     *zap.Logger
 	hashes HashStorage
@@ -94,6 +117,12 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 	if cfg.CompactionSleepInterval == 0 {
 		cfg.CompactionSleepInterval = defaultCompactionSleepInterval
 	}
+	if cfg.ValueMigrationBatchLimit == 0 {
+		cfg.ValueMigrationBatchLimit = defaultValueMigrationBatchLimit
+	}
+	if cfg.ValueMigrationSleepInterval == 0 {
+		cfg.ValueMigrationSleepInterval = defaultValueMigrationSleepInterval
+	}
 	s := &store{
 		cfg:     cfg,
 		b:       b,
@@ -408,6 +437,10 @@ func (s *store) restore() error {
 
 	s.lg.Info("kvstore restored", zap.Int64("current-rev", s.currentRev))
 
+	if m := s.initValueMigration(); m != nil {
+		s.startValueMigration(m)
+	}
+
 	if scheduledCompact != 0 {
 		if _, err := s.compactLockfree(scheduledCompact); err != nil {
 			s.lg.Warn("compaction encountered error",
@@ -495,7 +528,7 @@ func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, k
 		rkv := revKeyValue{key: key}
 
 		kv := &mvccpb.KeyValue{}
-		if err := proto.Unmarshal(vals[i], kv); err != nil {
+		if err := UnmarshalKeyValue(vals[i], kv); err != nil {
 			lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
 		}
 		rkv.kv = kv
@@ -515,6 +548,7 @@ func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, k
 func (s *store) Close() error {
 	close(s.stopc)
 	s.fifoSched.Stop()
+	s.wg.Wait()
 	return nil
 }
 
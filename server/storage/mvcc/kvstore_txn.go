@@ -139,7 +139,7 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 			)
 		}
 		kv := &mvccpb.KeyValue{}
-		if err := proto.Unmarshal(vs[0], kv); err != nil {
+		if err := UnmarshalKeyValue(vs[0], kv); err != nil {
 			tr.s.lg.Fatal(
 				"failed to unmarshal mvccpb.KeyValue",
 				zap.Error(err),
@@ -256,7 +256,7 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 	}
 
 	tw.trace.Step("marshal mvccpb.KeyValue")
-	tw.tx.UnsafeSeqPut(schema.Key, ibytes, d)
+	tw.tx.UnsafeSeqPut(schema.Key, ibytes, encodeValue(tw.s.cfg.ValueEncoding, d))
 	tw.s.kvindex.Put(key, idxRev)
 	tw.changes = append(tw.changes, kv)
 	tw.trace.Step("store kv pair into bolt db")
@@ -320,7 +320,7 @@ func (tw *storeTxnWrite) delete(key []byte) {
 		)
 	}
 
-	tw.tx.UnsafeSeqPut(schema.Key, ibytes, d)
+	tw.tx.UnsafeSeqPut(schema.Key, ibytes, encodeValue(tw.s.cfg.ValueEncoding, d))
 	err = tw.s.kvindex.Tombstone(key, idxRev.Revision)
 	if err != nil {
 		tw.storeTxnCommon.s.lg.Fatal(
@@ -84,6 +84,24 @@ var (
 		},
 	)
 
+	syncedWatcherGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watcher_synced_total",
+			Help:      "Total number of watchers in the synced watcher group.",
+		},
+	)
+
+	unsyncedWatcherGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watcher_unsynced_total",
+			Help:      "Total number of watchers in the unsynced watcher group.",
+		},
+	)
+
 	slowWatcherGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "etcd_debugging",
@@ -93,6 +111,15 @@ var (
 		},
 	)
 
+	pausedWatcherGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "paused_watcher_total",
+			Help:      "Total number of watchers paused until their client acknowledges more revisions.",
+		},
+	)
+
 	totalEventsCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "etcd_debugging",
@@ -102,6 +129,26 @@ var (
 		},
 	)
 
+	watchEventsFilteredCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watch_events_filtered_total",
+			Help:      "Total number of events filtered out by the filters of the watchers of this member, by filter type. Compare with events_total for the share of events saved by filters.",
+		},
+		[]string{"type"},
+	)
+
+	watchSyncPhaseSec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd_debugging",
+			Subsystem: "mvcc",
+			Name:      "watch_sync_phase_seconds_total",
+			Help:      "Total time spent delivering events to watchers, by phase: catching up unsynced watchers, or live delivery to synced watchers. See --watch-sync-fairness.",
+		},
+		[]string{"phase"},
+	)
+
 	pendingEventsGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "etcd_debugging",
@@ -284,6 +331,14 @@ var (
 			Name:      "total_put_size_in_bytes",
 			Help:      "The total size of put kv pairs seen by this member.",
 		})
+
+	valueEncodingMigrationPercent = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "etcd",
+			Subsystem: "mvcc",
+			Name:      "value_encoding_migration_percent",
+			Help:      "The percentage of the stored values migrated to the configured value encoding.",
+		})
 )
 
 func init() {
@@ -294,8 +349,13 @@ func init() {
 	prometheus.MustRegister(keysGauge)
 	prometheus.MustRegister(watchStreamGauge)
 	prometheus.MustRegister(watcherGauge)
+	prometheus.MustRegister(syncedWatcherGauge)
+	prometheus.MustRegister(unsyncedWatcherGauge)
 	prometheus.MustRegister(slowWatcherGauge)
+	prometheus.MustRegister(pausedWatcherGauge)
 	prometheus.MustRegister(totalEventsCounter)
+	prometheus.MustRegister(watchEventsFilteredCounter)
+	prometheus.MustRegister(watchSyncPhaseSec)
 	prometheus.MustRegister(pendingEventsGauge)
 	prometheus.MustRegister(indexCompactionPauseMs)
 	prometheus.MustRegister(dbCompactionPauseMs)
@@ -310,6 +370,7 @@ func init() {
 	prometheus.MustRegister(currentRev)
 	prometheus.MustRegister(compactRev)
 	prometheus.MustRegister(totalPutSizeGauge)
+	prometheus.MustRegister(valueEncodingMigrationPercent)
 }
 
 // ReportEventReceived reports that an event is received.
@@ -0,0 +1,126 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+var ErrValueChecksumMismatch = errors.New("mvcc: value checksum mismatch")
+
+// ValueEncoding is the encoding of the values of the key bucket.
+//
+// A plain value is a marshaled mvccpb.KeyValue. Any other encoding starts
+// with a zero byte, which a marshaled protobuf message never starts with,
+// followed by the encoding, so that values of different encodings can be
+// told apart and coexist in the same bucket.
+type ValueEncoding uint8
+
+const (
+	// ValueEncodingPlain stores the marshaled mvccpb.KeyValue as is.
+	ValueEncodingPlain ValueEncoding = iota
+	// ValueEncodingChecksum prefixes the marshaled mvccpb.KeyValue with its
+	// CRC-32C checksum, which is verified whenever the value is read.
+	ValueEncodingChecksum
+)
+
+const (
+	valueEncodingMarker = 0x00
+	// valueHeaderLen is the length of the marker and the encoding.
+	valueHeaderLen   = 2
+	valueChecksumLen = 4
+)
+
+var valueChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+func (e ValueEncoding) String() string {
+	switch e {
+	case ValueEncodingPlain:
+		return "plain"
+	case ValueEncodingChecksum:
+		return "checksum"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(e))
+	}
+}
+
+// valueEncodingOf returns the encoding of the given value of the key bucket.
+func valueEncodingOf(v []byte) ValueEncoding {
+	if len(v) < valueHeaderLen || v[0] != valueEncodingMarker {
+		return ValueEncodingPlain
+	}
+	return ValueEncoding(v[1])
+}
+
+// encodeValue encodes the marshaled mvccpb.KeyValue d with the given encoding.
+func encodeValue(e ValueEncoding, d []byte) []byte {
+	switch e {
+	case ValueEncodingChecksum:
+		v := make([]byte, valueHeaderLen+valueChecksumLen+len(d))
+		v[0], v[1] = valueEncodingMarker, byte(e)
+		binary.BigEndian.PutUint32(v[valueHeaderLen:], crc32.Checksum(d, valueChecksumTable))
+		copy(v[valueHeaderLen+valueChecksumLen:], d)
+		return v
+	default:
+		return d
+	}
+}
+
+// decodeValue returns the marshaled mvccpb.KeyValue of the given value of
+// the key bucket, verifying its checksum if it has one.
+func decodeValue(v []byte) ([]byte, error) {
+	switch e := valueEncodingOf(v); e {
+	case ValueEncodingPlain:
+		return v, nil
+	case ValueEncodingChecksum:
+		if len(v) < valueHeaderLen+valueChecksumLen {
+			return nil, fmt.Errorf("mvcc: value too short for encoding %s", e)
+		}
+		d := v[valueHeaderLen+valueChecksumLen:]
+		if binary.BigEndian.Uint32(v[valueHeaderLen:]) != crc32.Checksum(d, valueChecksumTable) {
+			return nil, ErrValueChecksumMismatch
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("mvcc: unknown value encoding %s", e)
+	}
+}
+
+// canonicalValue returns the marshaled mvccpb.KeyValue of the given value
+// without verifying it, so that the same key-value has the same canonical
+// form whatever its encoding. Values it cannot decode are returned as is.
+func canonicalValue(v []byte) []byte {
+	if valueEncodingOf(v) == ValueEncodingChecksum && len(v) >= valueHeaderLen+valueChecksumLen {
+		return v[valueHeaderLen+valueChecksumLen:]
+	}
+	return v
+}
+
+// UnmarshalKeyValue decodes the given value of the key bucket, whatever its
+// encoding, into kv.
+func UnmarshalKeyValue(v []byte, kv *mvccpb.KeyValue) error {
+	d, err := decodeValue(v)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(d, kv)
+}
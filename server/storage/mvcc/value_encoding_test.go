@@ -0,0 +1,73 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestValueEncodingRoundTrip(t *testing.T) {
+	d, err := proto.Marshal(&mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar"), ModRevision: 2})
+	require.NoError(t, err)
+
+	for _, e := range []ValueEncoding{ValueEncodingPlain, ValueEncodingChecksum} {
+		t.Run(e.String(), func(t *testing.T) {
+			v := encodeValue(e, d)
+			assert.Equal(t, e, valueEncodingOf(v))
+			assert.Equal(t, d, canonicalValue(v))
+
+			got, err := decodeValue(v)
+			require.NoError(t, err)
+			assert.Equal(t, d, got)
+
+			var kv mvccpb.KeyValue
+			require.NoError(t, UnmarshalKeyValue(v, &kv))
+			assert.Equal(t, "bar", string(kv.Value))
+		})
+	}
+}
+
+func TestValueEncodingEmpty(t *testing.T) {
+	// the empty message marshals to an empty value, which is plain
+	assert.Equal(t, ValueEncodingPlain, valueEncodingOf(nil))
+	v := encodeValue(ValueEncodingChecksum, nil)
+	assert.Equal(t, ValueEncodingChecksum, valueEncodingOf(v))
+	d, err := decodeValue(v)
+	require.NoError(t, err)
+	assert.Empty(t, d)
+}
+
+func TestValueEncodingChecksumMismatch(t *testing.T) {
+	d, err := proto.Marshal(&mvccpb.KeyValue{Key: []byte("foo"), Value: []byte("bar")})
+	require.NoError(t, err)
+	v := encodeValue(ValueEncodingChecksum, d)
+	v[len(v)-1] ^= 0xff
+
+	_, err = decodeValue(v)
+	require.ErrorIs(t, err, ErrValueChecksumMismatch)
+	assert.True(t, bytes.HasSuffix(v, canonicalValue(v)))
+}
+
+func TestValueEncodingUnknown(t *testing.T) {
+	_, err := decodeValue([]byte{valueEncodingMarker, 0xff, 1, 2, 3, 4})
+	require.ErrorContains(t, err, "unknown value encoding")
+}
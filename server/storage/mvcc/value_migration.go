@@ -0,0 +1,266 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// ValueEncodingStatus reports the encoding of the stored values.
+type ValueEncodingStatus struct {
+	// Encoding is the encoding new values are written with.
+	Encoding ValueEncoding
+	// MigrationPercent is the percentage of the values written before the
+	// encoding was configured that have been migrated to it.
+	MigrationPercent float64
+}
+
+// valueMigration is the progress of migrating the values of the key bucket
+// to another encoding. It is persisted in the meta bucket, so that the
+// migration resumes where it stopped when the member restarts.
+type valueMigration struct {
+	// target is the encoding the values are migrated to.
+	target ValueEncoding
+	// startRev and endRev are the first and the last main revision to
+	// migrate. Later revisions are written with the target encoding.
+	startRev, endRev int64
+	// next is the next revision to migrate.
+	next Revision
+}
+
+const valueMigrationLen = 1 + 4*8
+
+func (m *valueMigration) done() bool {
+	return m.next.Main > m.endRev
+}
+
+func (m *valueMigration) percent() float64 {
+	if m.done() {
+		return 100
+	}
+	return float64(m.next.Main-m.startRev) * 100 / float64(m.endRev-m.startRev+1)
+}
+
+func (m *valueMigration) marshal() []byte {
+	b := make([]byte, valueMigrationLen)
+	b[0] = byte(m.target)
+	binary.BigEndian.PutUint64(b[1:], uint64(m.startRev))
+	binary.BigEndian.PutUint64(b[9:], uint64(m.endRev))
+	binary.BigEndian.PutUint64(b[17:], uint64(m.next.Main))
+	binary.BigEndian.PutUint64(b[25:], uint64(m.next.Sub))
+	return b
+}
+
+func unmarshalValueMigration(b []byte) (*valueMigration, error) {
+	if len(b) != valueMigrationLen {
+		return nil, fmt.Errorf("mvcc: invalid value encoding migration length %d", len(b))
+	}
+	return &valueMigration{
+		target:   ValueEncoding(b[0]),
+		startRev: int64(binary.BigEndian.Uint64(b[1:])),
+		endRev:   int64(binary.BigEndian.Uint64(b[9:])),
+		next: Revision{
+			Main: int64(binary.BigEndian.Uint64(b[17:])),
+			Sub:  int64(binary.BigEndian.Uint64(b[25:])),
+		},
+	}, nil
+}
+
+func unsafeReadValueMigration(tx backend.UnsafeReader) (*valueMigration, bool, error) {
+	_, vs := tx.UnsafeRange(schema.Meta, schema.MetaValueEncodingMigrationName, nil, 0)
+	if len(vs) == 0 {
+		return nil, false, nil
+	}
+	m, err := unmarshalValueMigration(vs[0])
+	return m, true, err
+}
+
+func unsafeSetValueMigration(tx backend.UnsafeWriter, m *valueMigration) {
+	tx.UnsafePut(schema.Meta, schema.MetaValueEncodingMigrationName, m.marshal())
+}
+
+// ValueEncodingStatus returns the encoding of the stored values and the
+// progress of migrating them to it.
+func (s *store) ValueEncodingStatus() ValueEncodingStatus {
+	s.migrationMu.RLock()
+	defer s.migrationMu.RUnlock()
+	st := ValueEncodingStatus{Encoding: s.cfg.ValueEncoding, MigrationPercent: 100}
+	if s.migration != nil {
+		st.MigrationPercent = s.migration.percent()
+	}
+	return st
+}
+
+func (s *store) setValueMigration(m *valueMigration) {
+	s.migrationMu.Lock()
+	s.migration = m
+	s.migrationMu.Unlock()
+	if m == nil {
+		valueEncodingMigrationPercent.Set(100)
+	} else {
+		valueEncodingMigrationPercent.Set(m.percent())
+	}
+}
+
+// initValueMigration loads the progress of the value encoding migration
+// from the backend, or starts a new migration if the values were last
+// migrated to another encoding than the configured one. It returns the
+// migration if it is not done yet.
+func (s *store) initValueMigration() *valueMigration {
+	target := s.cfg.ValueEncoding
+
+	tx := s.b.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+
+	m, found, err := unsafeReadValueMigration(tx)
+	if err != nil {
+		s.lg.Warn("failed to read value encoding migration, restarting it", zap.Error(err))
+		found = false
+	}
+	switch {
+	case !found && target == ValueEncodingPlain:
+		// without a migration, all values are plain
+		s.setValueMigration(nil)
+		return nil
+	case found && m.target == target:
+		s.setValueMigration(m)
+		if m.done() {
+			return nil
+		}
+		s.lg.Info(
+			"resuming value encoding migration",
+			zap.Stringer("value-encoding", target),
+			zap.Int64("next-revision", m.next.Main),
+			zap.Int64("end-revision", m.endRev),
+		)
+		return m
+	}
+
+	// The values written from now on use the target encoding, so only the
+	// revisions up to the current one are migrated. The migration is
+	// persisted before any of those values are written, so that a member
+	// that restarts with another encoding knows what to migrate back.
+	m = &valueMigration{
+		target:   target,
+		startRev: max(s.compactMainRev, 1),
+		endRev:   s.currentRev,
+	}
+	m.next = Revision{Main: m.startRev}
+	unsafeSetValueMigration(tx, m)
+	s.setValueMigration(m)
+	s.lg.Info(
+		"starting value encoding migration",
+		zap.Stringer("value-encoding", target),
+		zap.Int64("start-revision", m.startRev),
+		zap.Int64("end-revision", m.endRev),
+	)
+	return m
+}
+
+// startValueMigration migrates the values in the background, pausing
+// between batches, until the migration is done or the store is closed or
+// restored.
+func (s *store) startValueMigration(m *valueMigration) {
+	stopc := s.stopc
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		start := time.Now()
+		for {
+			if m = s.migrateValueBatch(m, stopc); m == nil {
+				return
+			}
+			if m.done() {
+				s.lg.Info(
+					"finished value encoding migration",
+					zap.Stringer("value-encoding", m.target),
+					zap.Duration("took", time.Since(start)),
+				)
+				return
+			}
+			select {
+			case <-time.After(s.cfg.ValueMigrationSleepInterval):
+			case <-stopc:
+				return
+			}
+		}
+	}()
+}
+
+// migrateValueBatch rewrites the values of the next batch of revisions with
+// the target encoding, in place and without changing their revisions, and
+// persists the progress in the same transaction. It returns the updated
+// progress, or nil if the migration was stopped.
+func (s *store) migrateValueBatch(m *valueMigration, stopc <-chan struct{}) *valueMigration {
+	// Rewriting a key that is already in the read buffer would make range
+	// reads return it twice until the next commit, so reads and syncing
+	// watchers are blocked until the batch is committed.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-stopc:
+		// the store is closed, or restored from another backend
+		return nil
+	default:
+	}
+
+	start, end := NewRevBytes(), NewRevBytes()
+	start = RevToBytes(m.next, start)
+	end = RevToBytes(Revision{Main: m.endRev + 1}, end)
+	limit := s.cfg.ValueMigrationBatchLimit
+
+	tx := s.b.BatchTx()
+	tx.LockOutsideApply()
+	// gofail: var valueMigrationAfterAcquiredBatchTxLock struct{}
+	keys, vals := tx.UnsafeRange(schema.Key, start, end, int64(limit))
+	next := *m
+	for i := range keys {
+		if valueEncodingOf(vals[i]) != m.target {
+			d, err := decodeValue(vals[i])
+			if err != nil {
+				s.lg.Fatal(
+					"failed to decode value",
+					zap.Int64("revision-main", BytesToRev(keys[i]).Main),
+					zap.Int64("revision-sub", BytesToRev(keys[i]).Sub),
+					zap.Error(err),
+				)
+			}
+			tx.UnsafePut(schema.Key, bytes.Clone(keys[i]), encodeValue(m.target, bytes.Clone(d)))
+		}
+		next.next = BytesToRev(keys[i])
+		next.next.Sub++
+	}
+	if len(keys) < limit {
+		next.next = Revision{Main: m.endRev + 1}
+	}
+	unsafeSetValueMigration(tx, &next)
+	s.revMu.Lock()
+	tx.Unlock()
+	s.b.ForceCommit()
+	s.revMu.Unlock()
+	// gofail: var valueMigrationAfterCommitBatch struct{}
+
+	s.setValueMigration(&next)
+	return &next
+}
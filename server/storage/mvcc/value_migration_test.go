@@ -0,0 +1,153 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func TestValueMigrationKillAndResume(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer b.Close()
+	lg := zaptest.NewLogger(t)
+
+	s0 := NewStore(lg, b, &lease.FakeLessor{}, StoreConfig{})
+	for i := 0; i < 10; i++ {
+		s0.Put([]byte(fmt.Sprintf("foo%d", i)), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+	s0.DeleteRange([]byte("foo0"), nil)
+	rev := s0.Rev()
+	plainHash, _, err := s0.HashStorage().HashByRev(rev)
+	require.NoError(t, err)
+	assert.Equal(t, ValueEncodingStatus{Encoding: ValueEncodingPlain, MigrationPercent: 100}, s0.ValueEncodingStatus())
+	require.NoError(t, s0.Close())
+
+	// the member is killed after the first batch of the migration
+	cfg := StoreConfig{
+		ValueEncoding:               ValueEncodingChecksum,
+		ValueMigrationBatchLimit:    4,
+		ValueMigrationSleepInterval: time.Hour,
+	}
+	s1 := NewStore(lg, b, &lease.FakeLessor{}, cfg)
+	require.Eventually(t, func() bool {
+		return s1.ValueEncodingStatus().MigrationPercent > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	s1.Put([]byte("foo10"), []byte("bar10"), lease.NoLease)
+	hash, _, err := s1.HashStorage().HashByRev(rev)
+	require.NoError(t, err)
+	assert.Equal(t, plainHash, hash, "hash of partially migrated values")
+	require.NoError(t, s1.Close())
+
+	m := readValueMigration(t, b)
+	assert.Equal(t, ValueEncodingChecksum, m.target)
+	assert.Equal(t, Revision{Main: 5, Sub: 1}, m.next)
+	assert.Less(t, m.percent(), float64(100))
+	encodings := countValueEncodings(b)
+	// the first batch and the put after the migration started
+	assert.Equal(t, map[ValueEncoding]int{ValueEncodingPlain: 7, ValueEncodingChecksum: 5}, encodings)
+
+	// the restarted member resumes the migration where it stopped
+	cfg.ValueMigrationSleepInterval = time.Millisecond
+	s2 := NewStore(lg, b, &lease.FakeLessor{}, cfg)
+	require.Eventually(t, func() bool {
+		return s2.ValueEncodingStatus().MigrationPercent == 100
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[ValueEncoding]int{ValueEncodingChecksum: 12}, countValueEncodings(b))
+	assert.Equal(t, rev+1, s2.Rev())
+	r, err := s2.Range(t.Context(), []byte("foo"), []byte("fop"), RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 10)
+	for _, kv := range r.KVs {
+		assert.Equal(t, "bar"+string(kv.Key[len("foo"):]), string(kv.Value))
+	}
+	hash, _, err = s2.HashStorage().HashByRev(rev)
+	require.NoError(t, err)
+	assert.Equal(t, plainHash, hash, "hash of migrated values")
+	require.NoError(t, s2.Close())
+
+	// the values are migrated back when the encoding is reverted
+	s3 := NewStore(lg, b, &lease.FakeLessor{}, StoreConfig{ValueMigrationSleepInterval: time.Millisecond})
+	require.Eventually(t, func() bool {
+		return s3.ValueEncodingStatus().MigrationPercent == 100
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[ValueEncoding]int{ValueEncodingPlain: 12}, countValueEncodings(b))
+	hash, _, err = s3.HashStorage().HashByRev(rev)
+	require.NoError(t, err)
+	assert.Equal(t, plainHash, hash, "hash of values migrated back")
+	require.NoError(t, s3.Close())
+}
+
+func TestValueMigrationAfterCompaction(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	defer b.Close()
+	lg := zaptest.NewLogger(t)
+
+	s0 := NewStore(lg, b, &lease.FakeLessor{}, StoreConfig{})
+	for i := 0; i < 10; i++ {
+		s0.Put([]byte("foo"), []byte(fmt.Sprintf("bar%d", i)), lease.NoLease)
+	}
+	done, err := s0.Compact(traceutil.TODO(), 6)
+	require.NoError(t, err)
+	<-done
+	require.NoError(t, s0.Close())
+
+	s1 := NewStore(lg, b, &lease.FakeLessor{}, StoreConfig{
+		ValueEncoding:               ValueEncodingChecksum,
+		ValueMigrationSleepInterval: time.Millisecond,
+	})
+	defer s1.Close()
+	require.Eventually(t, func() bool {
+		return s1.ValueEncodingStatus().MigrationPercent == 100
+	}, 10*time.Second, 10*time.Millisecond)
+	m := readValueMigration(t, b)
+	assert.Equal(t, int64(6), m.startRev)
+	assert.Equal(t, int64(11), m.endRev)
+	// the compaction keeps the revision 6 of the key
+	assert.Equal(t, map[ValueEncoding]int{ValueEncodingChecksum: 6}, countValueEncodings(b))
+}
+
+func readValueMigration(t *testing.T, b backend.Backend) *valueMigration {
+	tx := b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	m, found, err := unsafeReadValueMigration(tx)
+	require.NoError(t, err)
+	require.True(t, found)
+	return m
+}
+
+func countValueEncodings(b backend.Backend) map[ValueEncoding]int {
+	encodings := make(map[ValueEncoding]int)
+	tx := b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+		encodings[valueEncodingOf(v)]++
+		return nil
+	})
+	return encodings
+}
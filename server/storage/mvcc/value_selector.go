@@ -0,0 +1,393 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+// DefaultValueSelectorMaxCost is the default maximum cost of a value selector.
+const DefaultValueSelectorMaxCost = 100
+
+var (
+	ErrInvalidValueSelector   = errors.New("mvcc: invalid value selector")
+	ErrValueSelectorTooCostly = errors.New("mvcc: value selector is too costly")
+)
+
+// NewValueSelectorFilter returns a FilterFunc that filters out the PUT events
+// whose value is not a JSON document matching the value selector expr. DELETE
+// events are not filtered out. The syntax of a selector is JSONPath-like:
+//
+//	selector   := and ("||" and)*
+//	and        := unary ("&&" unary)*
+//	unary      := "!" unary | "(" selector ")" | path [comparison literal]
+//	path       := "$" ("." name | "[" index "]" | "[" string "]")*
+//	comparison := "==" | "!=" | "<" | "<=" | ">" | ">="
+//	literal    := string | number | "true" | "false" | "null"
+//
+// where names are made of letters, digits and underscores, and strings and
+// numbers are JSON ones. A path alone is true if the value has it. A
+// comparison is false if the value does not have its path, and an ordering
+// comparison is false unless the value at the path and the literal are both
+// numbers or both strings. Values that are not JSON match no selector.
+//
+// Each operator, path segment and comparison of the selector costs 1. It
+// returns ErrValueSelectorTooCostly if the selector costs more than maxCost,
+// which bounds the work to evaluate it on an event besides decoding the
+// value, or ErrInvalidValueSelector if it is malformed.
+func NewValueSelectorFilter(expr string, maxCost int) (FilterFunc, error) {
+	p := &selectorParser{expr: expr, maxCost: maxCost}
+	sel, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos != len(p.expr) {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos])
+	}
+	return func(e *mvccpb.Event) bool {
+		if e.Type != mvccpb.Event_PUT {
+			return false
+		}
+		var v any
+		if err := json.Unmarshal(e.Kv.Value, &v); err != nil {
+			return true
+		}
+		return !sel.match(v)
+	}, nil
+}
+
+type selector interface {
+	match(v any) bool
+}
+
+type orSelector []selector
+
+func (s orSelector) match(v any) bool {
+	for _, sel := range s {
+		if sel.match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+type andSelector []selector
+
+func (s andSelector) match(v any) bool {
+	for _, sel := range s {
+		if !sel.match(v) {
+			return false
+		}
+	}
+	return true
+}
+
+type notSelector struct{ sel selector }
+
+func (s notSelector) match(v any) bool { return !s.sel.match(v) }
+
+// pathSelector matches the values having its path, whose value at the path
+// compares to lit with op, if set.
+type pathSelector struct {
+	// path holds the names of the object members and the indexes of the
+	// array elements to get to the compared value
+	path []any
+	op   string
+	lit  any
+}
+
+func (s *pathSelector) match(v any) bool {
+	for _, seg := range s.path {
+		switch seg := seg.(type) {
+		case string:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return false
+			}
+			if v, ok = obj[seg]; !ok {
+				return false
+			}
+		case int:
+			arr, ok := v.([]any)
+			if !ok || seg >= len(arr) {
+				return false
+			}
+			v = arr[seg]
+		}
+	}
+
+	// objects and arrays are never equal to a literal, so comparing them
+	// does not panic
+	switch s.op {
+	case "":
+		return true
+	case "==":
+		return v == s.lit
+	case "!=":
+		return v != s.lit
+	}
+	var c int
+	switch v := v.(type) {
+	case float64:
+		lit, ok := s.lit.(float64)
+		if !ok {
+			return false
+		}
+		c = cmp.Compare(v, lit)
+	case string:
+		lit, ok := s.lit.(string)
+		if !ok {
+			return false
+		}
+		c = strings.Compare(v, lit)
+	default:
+		return false
+	}
+	switch s.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// selectorParser parses a value selector by recursive descent. It counts the
+// cost of the selector as it goes, so that the depth of the recursion is
+// bounded by the maximum cost.
+type selectorParser struct {
+	expr    string
+	pos     int
+	cost    int
+	maxCost int
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidValueSelector, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *selectorParser) addCost() error {
+	if p.cost++; p.cost > p.maxCost {
+		return fmt.Errorf("%w: it costs more than %d", ErrValueSelectorTooCostly, p.maxCost)
+	}
+	return nil
+}
+
+func (p *selectorParser) skipSpace() {
+	for p.pos < len(p.expr) && strings.IndexByte(" \t\r\n", p.expr[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume skips tok if the selector continues with it, after spaces.
+func (p *selectorParser) consume(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.expr[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *selectorParser) parseOr() (selector, error) {
+	sel, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := orSelector{sel}
+	for p.consume("||") {
+		if err = p.addCost(); err != nil {
+			return nil, err
+		}
+		if sel, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		or = append(or, sel)
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *selectorParser) parseAnd() (selector, error) {
+	sel, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	and := andSelector{sel}
+	for p.consume("&&") {
+		if err = p.addCost(); err != nil {
+			return nil, err
+		}
+		if sel, err = p.parseUnary(); err != nil {
+			return nil, err
+		}
+		and = append(and, sel)
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *selectorParser) parseUnary() (selector, error) {
+	if err := p.addCost(); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.consume("!"):
+		sel, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notSelector{sel}, nil
+	case p.consume("("):
+		sel, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected ')'")
+		}
+		return sel, nil
+	}
+	return p.parsePath()
+}
+
+func (p *selectorParser) parsePath() (selector, error) {
+	if !p.consume("$") {
+		return nil, p.errorf("expected '$'")
+	}
+	sel := &pathSelector{}
+	for {
+		switch {
+		case p.consume("."):
+			start := p.pos
+			for p.pos < len(p.expr) && isNameByte(p.expr[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, p.errorf("expected a name")
+			}
+			sel.path = append(sel.path, p.expr[start:p.pos])
+		case p.consume("["):
+			if p.skipSpace(); strings.HasPrefix(p.expr[p.pos:], `"`) {
+				name, err := p.parseString()
+				if err != nil {
+					return nil, err
+				}
+				sel.path = append(sel.path, name)
+			} else {
+				start := p.pos
+				for p.pos < len(p.expr) && p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' {
+					p.pos++
+				}
+				idx, err := strconv.Atoi(p.expr[start:p.pos])
+				if err != nil {
+					return nil, p.errorf("expected an index or a string")
+				}
+				sel.path = append(sel.path, idx)
+			}
+			if !p.consume("]") {
+				return nil, p.errorf("expected ']'")
+			}
+		default:
+			if err := p.parseComparison(sel); err != nil {
+				return nil, err
+			}
+			return sel, nil
+		}
+		if err := p.addCost(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseComparison parses the comparison of sel, if any.
+func (p *selectorParser) parseComparison(sel *pathSelector) error {
+	// the operators starting with another one come first
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			sel.op = op
+			break
+		}
+	}
+	if sel.op == "" {
+		return nil
+	}
+	if err := p.addCost(); err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	rest := p.expr[p.pos:]
+	var err error
+	switch {
+	case strings.HasPrefix(rest, `"`):
+		sel.lit, err = p.parseString()
+	case strings.HasPrefix(rest, "true"):
+		sel.lit, p.pos = true, p.pos+len("true")
+	case strings.HasPrefix(rest, "false"):
+		sel.lit, p.pos = false, p.pos+len("false")
+	case strings.HasPrefix(rest, "null"):
+		sel.lit, p.pos = nil, p.pos+len("null")
+	default:
+		start := p.pos
+		for p.pos < len(p.expr) && strings.IndexByte("+-.0123456789eE", p.expr[p.pos]) >= 0 {
+			p.pos++
+		}
+		var n float64
+		if n, err = strconv.ParseFloat(p.expr[start:p.pos], 64); err != nil {
+			p.pos = start
+			return p.errorf("expected a literal")
+		}
+		sel.lit = n
+	}
+	return err
+}
+
+// parseString parses the JSON string the selector continues with.
+func (p *selectorParser) parseString() (string, error) {
+	end := p.pos + 1
+	for ; end < len(p.expr) && p.expr[end] != '"'; end++ {
+		if p.expr[end] == '\\' {
+			end++
+		}
+	}
+	if end >= len(p.expr) {
+		return "", p.errorf("unterminated string")
+	}
+	var s string
+	if err := json.Unmarshal([]byte(p.expr[p.pos:end+1]), &s); err != nil {
+		return "", p.errorf("invalid string: %v", err)
+	}
+	p.pos = end + 1
+	return s, nil
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
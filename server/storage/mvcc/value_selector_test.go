@@ -0,0 +1,111 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
+
+func TestValueSelectorFilter(t *testing.T) {
+	value := `{"kind": "Pod", "spec": {"replicas": 3, "paused": false, "nodes": ["a", "b"]}, "labels": {"app.kubernetes.io/name": "web"}, "owner": null}`
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{`$.kind == "Pod"`, true},
+		{`$.kind != "Pod"`, false},
+		{`$.kind`, true},
+		{`$.missing`, false},
+		{`$.missing == null`, false},
+		{`$.missing != "x"`, false},
+		{`$.owner == null`, true},
+		{`$.spec.replicas == 3`, true},
+		{`$.spec.replicas >= 3 && $.spec.replicas < 4.5`, true},
+		{`$.spec.replicas > 3`, false},
+		{`$.spec.replicas > "3"`, false},
+		{`$.spec.paused == false`, true},
+		{`$.spec.nodes[1] == "b"`, true},
+		{`$.spec.nodes[2]`, false},
+		{`$.spec.nodes == "a"`, false},
+		{`$.spec.nodes[0] < "b"`, true},
+		{`$["labels"]["app.kubernetes.io/name"] == "web"`, true},
+		{`$.kind == "Node" || $.spec.replicas == 3`, true},
+		{`!($.kind == "Node" || $.spec.replicas == 3)`, false},
+		{`!$.missing && ($.kind == "Pod")`, true},
+		{`$`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := NewValueSelectorFilter(tt.expr, DefaultValueSelectorMaxCost)
+			require.NoError(t, err)
+			ev := &mvccpb.Event{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Value: []byte(value)}}
+			assert.Equal(t, !tt.match, filter(ev))
+		})
+	}
+}
+
+func TestValueSelectorFilterEvents(t *testing.T) {
+	filter, err := NewValueSelectorFilter(`$.a == 1`, DefaultValueSelectorMaxCost)
+	require.NoError(t, err)
+
+	assert.True(t, filter(&mvccpb.Event{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Value: []byte(`not json`)}}), "values that are not JSON match no selector")
+	assert.True(t, filter(&mvccpb.Event{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Value: []byte(`{"a": 2}`)}}))
+	assert.False(t, filter(&mvccpb.Event{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Value: []byte(`{"a": 1}`)}}))
+	assert.False(t, filter(&mvccpb.Event{Type: mvccpb.Event_DELETE, Kv: &mvccpb.KeyValue{}}), "DELETE events are not filtered out")
+}
+
+func TestValueSelectorFilterInvalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		maxCost int
+		wantErr error
+	}{
+		{``, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`kind == "Pod"`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a ==`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a == Pod`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a == "Pod`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a[-1]`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a[0`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`($.a`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a & $.b`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a $.b`, DefaultValueSelectorMaxCost, ErrInvalidValueSelector},
+		{`$.a.b.c == 1`, 4, ErrValueSelectorTooCostly},
+		{`$.a || $.b || $.c`, 4, ErrValueSelectorTooCostly},
+		// the recursion stops at the maximum cost
+		{strings.Repeat("!", 1<<20) + "$", DefaultValueSelectorMaxCost, ErrValueSelectorTooCostly},
+		{strings.Repeat("(", 1<<20) + "$", DefaultValueSelectorMaxCost, ErrValueSelectorTooCostly},
+	}
+	for _, tt := range tests {
+		name := tt.expr
+		if len(name) > 20 {
+			name = name[:20]
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := NewValueSelectorFilter(tt.expr, tt.maxCost)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := NewValueSelectorFilter(`$.a.b.c == 1`, 5)
+	require.NoError(t, err)
+}
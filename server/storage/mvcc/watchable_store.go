@@ -15,11 +15,12 @@
 package mvcc
 
 import (
+	"bytes"
+	"math"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/client/pkg/v3/verify"
@@ -42,12 +43,22 @@ var (
 
 	// maxResyncPeriod is the period of executing resync.
 	watchResyncPeriod = 100 * time.Millisecond
+
+	// watcherKeyShards is the number of shards single key watchers are
+	// spread over, so that watchers on different keys rarely contend.
+	watcherKeyShards = 16
 )
 
+// DefaultWatchSyncFairness leaves as much time to the live delivery of the
+// synced watchers as is spent catching up unsynced watchers.
+const DefaultWatchSyncFairness = 1.0
+
 func ChanBufLen() int { return chanBufLen }
 
 type watchable interface {
-	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc)
+	watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, opts WatchOptions, fcs ...FilterFunc) (*watcher, cancelFunc)
+	rewatch(w *watcher, startRev int64) error
+	ack(w *watcher, rev int64)
 	progress(w *watcher)
 	progressAll(watchers map[WatchID]*watcher) bool
 	rev() int64
@@ -56,20 +67,31 @@ type watchable interface {
 type watchableStore struct {
 	*store
 
-	// mu protects watcher groups and batches. It should never be locked
-	// before locking store.mu to avoid deadlock.
+	// mu protects watcher groups. It should never be locked before locking
+	// store.mu to avoid deadlock. Adding, canceling and notifying watchers
+	// hold it for reading and lock only the shards involved with shardMu;
+	// anything touching watchers of all shards holds it for writing.
 	mu sync.RWMutex
+	// shardMu locks the shards of the synced and unsynced watcher groups
+	// by shard index. Multiple shards must be locked in ascending order.
+	shardMu []sync.Mutex
 
+	// victimMu protects victims.
+	victimMu sync.Mutex
 	// victims are watcher batches that were blocked on the watch channel
 	victims []watcherBatch
 	victimc chan struct{}
 
 	// contains all unsynced watchers that needs to sync with events that have happened
-	unsynced watcherGroup
+	unsynced shardedWatcherGroup
 
 	// contains all synced watchers that are in sync with the progress of the store.
 	// The key of the map is the key that the watcher watches on.
-	synced watcherGroup
+	synced shardedWatcherGroup
+
+	// syncFairness is the ratio of the time left to live delivery to the
+	// time spent catching up, see StoreConfig.WatchSyncFairness.
+	syncFairness float64
 
 	stopc chan struct{}
 	wg    sync.WaitGroup
@@ -95,10 +117,16 @@ func newWatchableStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg S
 	}
 	s := &watchableStore{
 		store:    NewStore(lg, b, le, cfg),
+		shardMu:  make([]sync.Mutex, watcherKeyShards+1),
 		victimc:  make(chan struct{}, 1),
-		unsynced: newWatcherGroup(),
-		synced:   newWatcherGroup(),
+		unsynced: newShardedWatcherGroup(watcherKeyShards),
+		synced:   newShardedWatcherGroup(watcherKeyShards),
 		stopc:    make(chan struct{}),
+
+		syncFairness: cfg.WatchSyncFairness,
+	}
+	if s.syncFairness <= 0 {
+		s.syncFairness = DefaultWatchSyncFairness
 	}
 	s.store.ReadView = &readView{s}
 	s.store.WriteView = &writeView{s}
@@ -125,47 +153,105 @@ func (s *watchableStore) NewWatchStream() WatchStream {
 	}
 }
 
-func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, fcs ...FilterFunc) (*watcher, cancelFunc) {
+func (s *watchableStore) watch(key, end []byte, startRev int64, id WatchID, ch chan<- WatchResponse, opts WatchOptions, fcs ...FilterFunc) (*watcher, cancelFunc) {
 	wa := &watcher{
-		key:      key,
-		end:      end,
-		startRev: startRev,
-		minRev:   startRev,
-		id:       id,
-		ch:       ch,
-		fcs:      fcs,
+		key:                  key,
+		end:                  end,
+		startRev:             startRev,
+		minRev:               startRev,
+		id:                   id,
+		ch:                   ch,
+		fcs:                  fcs,
+		latestPerKey:         opts.LatestPerKey,
+		sampleEveryN:         opts.SampleEveryN,
+		compactWarningMargin: opts.CompactWarningMargin,
+		ackWindow:            opts.AckWindow,
+		collapseDeletes:      opts.CollapseDeletes,
+		condition:            opts.Condition,
+		conditionResult:      opts.ConditionResult,
 	}
 
-	s.mu.Lock()
+	s.mu.RLock()
+	shard := s.synced.shard(wa)
+	s.shardMu[shard].Lock()
+	s.unsafeAddWatcher(shard, wa)
+	s.shardMu[shard].Unlock()
+	s.mu.RUnlock()
+
+	watcherGauge.Inc()
+
+	return wa, func() { s.cancelWatcher(wa) }
+}
+
+// unsafeAddWatcher adds the watcher to the synced or unsynced group
+// depending on its startRev. The shard of the watcher must be locked.
+func (s *watchableStore) unsafeAddWatcher(shard int, wa *watcher) {
 	s.revMu.RLock()
-	synced := startRev > s.store.currentRev || startRev == 0
+	defer s.revMu.RUnlock()
+	synced := wa.startRev > s.store.currentRev || wa.startRev == 0
 	if synced {
 		wa.minRev = s.store.currentRev + 1
-		if startRev > wa.minRev {
-			wa.minRev = startRev
+		if wa.startRev > wa.minRev {
+			wa.minRev = wa.startRev
 		}
-		s.synced.add(wa)
+		s.synced[shard].add(wa)
 	} else {
 		slowWatcherGauge.Inc()
-		s.unsynced.add(wa)
+		s.unsynced[shard].add(wa)
 	}
-	s.revMu.RUnlock()
-	s.mu.Unlock()
+	// the revisions before the start of the watcher count as acknowledged
+	wa.ackedRev = max(wa.ackedRev, wa.minRev-1)
+}
 
-	watcherGauge.Inc()
+// ack acknowledges the revisions of the watcher up to rev, and resumes it
+// from the history if it was paused by its ack window.
+func (s *watchableStore) ack(wa *watcher, rev int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	shard := s.synced.shard(wa)
+	s.shardMu[shard].Lock()
+	defer s.shardMu[shard].Unlock()
+	wa.ackedRev = max(wa.ackedRev, rev)
+	// a canceled watcher has no channel
+	if !wa.paused || wa.ch == nil || wa.minRev > wa.ackLimit() {
+		return
+	}
+	wa.paused = false
+	pausedWatcherGauge.Dec()
+	slowWatcherGauge.Inc()
+	s.unsynced[shard].add(wa)
+}
 
-	return wa, func() { s.cancelWatcher(wa) }
+// rewatch resumes a watcher that was removed because of compaction from
+// startRev, keeping its ID, range and filters.
+func (s *watchableStore) rewatch(wa *watcher, startRev int64) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	shard := s.synced.shard(wa)
+	s.shardMu[shard].Lock()
+	defer s.shardMu[shard].Unlock()
+	// a canceled watcher has no channel
+	if !wa.compacted || wa.ch == nil {
+		return ErrWatcherNotCompacted
+	}
+	wa.compacted = false
+	wa.startRev, wa.minRev = startRev, startRev
+	s.unsafeAddWatcher(shard, wa)
+	return nil
 }
 
 // cancelWatcher removes references of the watcher from the watchableStore
 func (s *watchableStore) cancelWatcher(wa *watcher) {
+	var shard int
 	for {
-		s.mu.Lock()
-		if s.unsynced.delete(wa) {
+		s.mu.RLock()
+		shard = s.synced.shard(wa)
+		s.shardMu[shard].Lock()
+		if s.unsynced[shard].delete(wa) {
 			slowWatcherGauge.Dec()
 			watcherGauge.Dec()
 			break
-		} else if s.synced.delete(wa) {
+		} else if s.synced[shard].delete(wa) {
 			watcherGauge.Dec()
 			break
 		} else if wa.ch == nil {
@@ -174,13 +260,19 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 		} else if wa.compacted {
 			watcherGauge.Dec()
 			break
+		} else if wa.paused {
+			pausedWatcherGauge.Dec()
+			watcherGauge.Dec()
+			break
 		}
 
 		if !wa.victim {
-			s.mu.Unlock()
+			s.shardMu[shard].Unlock()
+			s.mu.RUnlock()
 			panic("watcher not victim but not in watch groups")
 		}
 
+		s.victimMu.Lock()
 		var victimBatch watcherBatch
 		for _, wb := range s.victims {
 			if wb[wa] != nil {
@@ -192,16 +284,33 @@ func (s *watchableStore) cancelWatcher(wa *watcher) {
 			slowWatcherGauge.Dec()
 			watcherGauge.Dec()
 			delete(victimBatch, wa)
+			s.victimMu.Unlock()
 			break
 		}
+		s.victimMu.Unlock()
 
 		// victim being processed so not accessible; retry
-		s.mu.Unlock()
+		s.shardMu[shard].Unlock()
+		s.mu.RUnlock()
 		time.Sleep(time.Millisecond)
 	}
 
 	wa.ch = nil
-	s.mu.Unlock()
+	s.shardMu[shard].Unlock()
+	s.mu.RUnlock()
+}
+
+// lockShards locks the given shards, which must be in ascending order.
+func (s *watchableStore) lockShards(shards []int) {
+	for _, i := range shards {
+		s.shardMu[i].Lock()
+	}
+}
+
+func (s *watchableStore) unlockShards(shards []int) {
+	for j := len(shards) - 1; j >= 0; j-- {
+		s.shardMu[shards[j]].Unlock()
+	}
 }
 
 func (s *watchableStore) Restore(b backend.Backend) error {
@@ -212,14 +321,61 @@ func (s *watchableStore) Restore(b backend.Backend) error {
 		return err
 	}
 
-	for wa := range s.synced.watchers {
-		wa.restore = true
-		s.unsynced.add(wa)
+	for i := range s.synced {
+		for wa := range s.synced[i].watchers {
+			wa.restore = true
+			s.unsynced[i].add(wa)
+		}
 	}
-	s.synced = newWatcherGroup()
+	s.synced = newShardedWatcherGroup(len(s.synced) - 1)
 	return nil
 }
 
+// Compact compacts the store up to rev, and warns the watchers catching up on
+// the history that the compaction got within their warning margin.
+func (s *watchableStore) Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error) {
+	ch, err := s.store.Compact(trace, rev)
+	if err == nil {
+		s.warnCompaction(rev)
+	}
+	return ch, err
+}
+
+// warnCompaction warns the unsynced and victim watchers whose next revision
+// to receive is at most their warning margin past the compaction revision.
+// The watchers behind the compaction are canceled by syncWatchers instead.
+func (s *watchableStore) warnCompaction(compactRev int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.unsynced {
+		for w := range s.unsynced[i].watchers {
+			if !w.compactWarningDue(w.minRev, compactRev) {
+				continue
+			}
+			w.compactWarningRev = compactRev
+			// the warning is sent with the next response if the channel
+			// is full
+			w.send(WatchResponse{WatchID: w.id, Revision: w.minRev - 1})
+		}
+	}
+
+	// victims are sent their pending events first, so the warning is sent
+	// along with them by moveVictims
+	s.victimMu.Lock()
+	defer s.victimMu.Unlock()
+	for _, wb := range s.victims {
+		for w, eb := range wb {
+			next := w.minRev
+			if len(eb.evs) != 0 {
+				next = eb.evs[0].Kv.ModRevision
+			}
+			if w.compactWarningDue(next, compactRev) {
+				w.compactWarningRev = compactRev
+			}
+		}
+	}
+}
+
 // syncWatchersLoop syncs the watcher in the unsynced map every 100ms.
 func (s *watchableStore) syncWatchersLoop() {
 	defer s.wg.Done()
@@ -228,22 +384,28 @@ func (s *watchableStore) syncWatchersLoop() {
 	defer delayTicker.Stop()
 
 	for {
-		s.mu.RLock()
+		s.mu.Lock()
 		st := time.Now()
 		lastUnsyncedWatchers := s.unsynced.size()
-		s.mu.RUnlock()
+		s.reportWatcherGroupSizes()
+		s.mu.Unlock()
 
 		unsyncedWatchers := 0
 		if lastUnsyncedWatchers > 0 {
 			unsyncedWatchers = s.syncWatchers()
 		}
 		syncDuration := time.Since(st)
+		if lastUnsyncedWatchers > 0 {
+			watchSyncPhaseSec.WithLabelValues("catch_up").Add(syncDuration.Seconds())
+		}
 
 		delayTicker.Reset(watchResyncPeriod)
 		// more work pending?
 		if unsyncedWatchers != 0 && lastUnsyncedWatchers > unsyncedWatchers {
-			// be fair to other store operations by yielding time taken
-			delayTicker.Reset(syncDuration)
+			// be fair to the live delivery of the synced watchers, which
+			// waits for the lock held while catching up, and to other store
+			// operations by yielding time in proportion to the time taken
+			delayTicker.Reset(s.syncYield(syncDuration))
 		}
 
 		select {
@@ -254,6 +416,13 @@ func (s *watchableStore) syncWatchersLoop() {
 	}
 }
 
+// syncYield returns how long the catch up of unsynced watchers yields to live
+// delivery after taking syncDuration.
+func (s *watchableStore) syncYield(syncDuration time.Duration) time.Duration {
+	// a ticker can not be reset to 0
+	return max(time.Duration(float64(syncDuration)*s.syncFairness), time.Nanosecond)
+}
+
 // syncVictimsLoop tries to write precomputed watcher responses to
 // watchers that had a blocked watcher channel
 func (s *watchableStore) syncVictimsLoop() {
@@ -263,9 +432,9 @@ func (s *watchableStore) syncVictimsLoop() {
 		for s.moveVictims() != 0 {
 			// try to update all victim watchers
 		}
-		s.mu.RLock()
+		s.victimMu.Lock()
 		isEmpty := len(s.victims) == 0
-		s.mu.RUnlock()
+		s.victimMu.Unlock()
 
 		var tickc <-chan time.Time
 		if !isEmpty {
@@ -284,10 +453,10 @@ func (s *watchableStore) syncVictimsLoop() {
 // moveVictims tries to update watches with already pending event data
 func (s *watchableStore) moveVictims() (moved int) {
 	// gofail: var beforeMoveVictims struct{}
-	s.mu.Lock()
+	s.victimMu.Lock()
 	victims := s.victims
 	s.victims = nil
-	s.mu.Unlock()
+	s.victimMu.Unlock()
 
 	var newVictim watcherBatch
 	for _, wb := range victims {
@@ -295,7 +464,7 @@ func (s *watchableStore) moveVictims() (moved int) {
 		for w, eb := range wb {
 			// watcher has observed the store up to, but not including, w.minRev
 			rev := w.minRev - 1
-			if !w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: rev}) {
+			if !w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: rev, CatchUp: eb.catchUp}) {
 				if newVictim == nil {
 					newVictim = make(watcherBatch)
 				}
@@ -331,9 +500,9 @@ func (s *watchableStore) moveVictims() (moved int) {
 	}
 
 	if len(newVictim) > 0 {
-		s.mu.Lock()
+		s.victimMu.Lock()
 		s.victims = append(s.victims, newVictim)
-		s.mu.Unlock()
+		s.victimMu.Unlock()
 	}
 
 	return moved
@@ -366,18 +535,32 @@ func (s *watchableStore) syncWatchers() int {
 	evs := rangeEvents(s.store.lg, s.store.b, minRev, curRev+1, wg)
 
 	victims := make(watcherBatch)
-	wb := newWatcherBatch(wg, evs)
+	wb := newWatcherBatch(wg, evs, true)
 	for w := range wg.watchers {
 		if w.minRev < compactionRev {
 			// Skip the watcher that failed to send compacted watch response due to w.ch is full.
 			// Next retry of syncWatchers would try to resend the compacted watch response to w.ch
 			continue
 		}
-		w.minRev = max(curRev+1, w.minRev)
+		// a watcher with an ack window is only sent the revisions up to its
+		// limit, and is paused once it reaches it
+		upTo := min(curRev, w.ackLimit())
+		w.minRev = max(upTo+1, w.minRev)
 
 		eb, ok := wb[w]
+		if ok && upTo < curRev {
+			eb.limit(upTo)
+			ok = len(eb.evs) != 0
+		}
+		if !ok && upTo < curRev {
+			s.unsynced.delete(w)
+			s.pause(w)
+			continue
+		}
 		if !ok {
-			// bring un-notified watcher to synced
+			// bring un-notified watcher to synced, which no longer needs
+			// a pending compaction warning
+			w.compactWarningRev = 0
 			s.synced.add(w)
 			s.unsynced.delete(w)
 			continue
@@ -386,8 +569,9 @@ func (s *watchableStore) syncWatchers() int {
 		if eb.moreRev != 0 {
 			w.minRev = eb.moreRev
 		}
+		eb.catchUp = true
 
-		if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: curRev}) {
+		if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: upTo, CatchUp: true}) {
 			pendingEventsGauge.Add(float64(len(eb.evs)))
 		} else {
 			w.victim = true
@@ -396,10 +580,15 @@ func (s *watchableStore) syncWatchers() int {
 		if w.victim {
 			victims[w] = eb
 		} else {
-			if eb.moreRev != 0 {
+			if eb.moreRev != 0 && eb.moreRev <= upTo {
 				// stay unsynced; more to read
 				continue
 			}
+			if upTo < curRev {
+				s.unsynced.delete(w)
+				s.pause(w)
+				continue
+			}
 			s.synced.add(w)
 		}
 		s.unsynced.delete(w)
@@ -407,14 +596,24 @@ func (s *watchableStore) syncWatchers() int {
 	s.addVictim(victims)
 
 	vsz := 0
+	s.victimMu.Lock()
 	for _, v := range s.victims {
 		vsz += len(v)
 	}
+	s.victimMu.Unlock()
 	slowWatcherGauge.Set(float64(s.unsynced.size() + vsz))
+	s.reportWatcherGroupSizes()
 
 	return s.unsynced.size()
 }
 
+// reportWatcherGroupSizes sets the synced and unsynced watcher gauges.
+// s.mu must be held.
+func (s *watchableStore) reportWatcherGroupSizes() {
+	syncedWatcherGauge.Set(float64(s.synced.size()))
+	unsyncedWatcherGauge.Set(float64(s.unsynced.size()))
+}
+
 // rangeEvents returns events in range [minRev, maxRev).
 func rangeEvents(lg *zap.Logger, b backend.Backend, minRev, maxRev int64, c contains) []*mvccpb.Event {
 	if minRev < 0 {
@@ -446,7 +645,7 @@ type contains interface {
 func kvsToEvents(lg *zap.Logger, c contains, revs, vals [][]byte) (evs []*mvccpb.Event) {
 	for i, v := range vals {
 		kv := &mvccpb.KeyValue{}
-		if err := proto.Unmarshal(v, kv); err != nil {
+		if err := UnmarshalKeyValue(v, kv); err != nil {
 			lg.Panic("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
 		}
 
@@ -466,38 +665,80 @@ func kvsToEvents(lg *zap.Logger, c contains, revs, vals [][]byte) (evs []*mvccpb
 }
 
 // notify notifies the fact that given event at the given rev just happened to
-// watchers that watch on the key of the event.
-func (s *watchableStore) notify(rev int64, evs []*mvccpb.Event) {
+// watchers that watch on the key of the event. The events are grouped by
+// shard with eventsByShard, and the caller must hold the given shards locked.
+func (s *watchableStore) notify(rev int64, shards []int, shardEvs [][]*mvccpb.Event, changes []*mvccpb.KeyValue, ranges []deletedRange) {
+	if len(shards) == 0 {
+		return
+	}
+	st := time.Now()
+	defer func() { watchSyncPhaseSec.WithLabelValues("live").Add(time.Since(st).Seconds()) }()
+
+	// deletes maps the changes of the range deletions, which the events of
+	// the watchers share, to their range, for the watchers that collapse
+	// them. It is only built when needed.
+	var deletes map[*mvccpb.KeyValue]*deletedRange
 	victim := make(watcherBatch)
-	for w, eb := range newWatcherBatch(&s.synced, evs) {
-		if eb.revs != 1 {
-			s.store.lg.Panic(
-				"unexpected multiple revisions in watch notification",
-				zap.Int("number-of-revisions", eb.revs),
-			)
-		}
-		if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: rev}) {
-			pendingEventsGauge.Add(float64(len(eb.evs)))
-		} else {
-			// move slow watcher to victims
-			w.victim = true
-			victim[w] = eb
-			s.synced.delete(w)
-			slowWatcherGauge.Inc()
+	for _, i := range shards {
+		for w, eb := range newWatcherBatch(&s.synced[i], shardEvs[i], false) {
+			if eb.revs != 1 {
+				s.store.lg.Panic(
+					"unexpected multiple revisions in watch notification",
+					zap.Int("number-of-revisions", eb.revs),
+				)
+			}
+			if w.collapseDeletes && w.end != nil && len(ranges) != 0 {
+				if deletes == nil {
+					deletes = make(map[*mvccpb.KeyValue]*deletedRange)
+					for j, dr := range ranges {
+						for _, kv := range changes[dr.first : dr.first+dr.n] {
+							deletes[kv] = &ranges[j]
+						}
+					}
+				}
+				eb.evs = w.collapse(eb.evs, deletes)
+			}
+			if rev > w.ackLimit() {
+				// hold the events back until the client acknowledges more
+				// revisions; they are read from the history on resume
+				s.synced[i].delete(w)
+				w.minRev = rev
+				s.pause(w)
+				continue
+			}
+			if w.send(WatchResponse{WatchID: w.id, Events: eb.evs, Revision: rev}) {
+				pendingEventsGauge.Add(float64(len(eb.evs)))
+			} else {
+				// move slow watcher to victims
+				w.victim = true
+				victim[w] = eb
+				s.synced[i].delete(w)
+				slowWatcherGauge.Inc()
+			}
+			// always update minRev
+			// in case 'send' returns true and watcher stays synced, this is needed for Restore when all watchers become unsynced
+			// in case 'send' returns false, this is needed for syncWatchers
+			w.minRev = rev + 1
 		}
-		// always update minRev
-		// in case 'send' returns true and watcher stays synced, this is needed for Restore when all watchers become unsynced
-		// in case 'send' returns false, this is needed for syncWatchers
-		w.minRev = rev + 1
 	}
 	s.addVictim(victim)
 }
 
+// pause takes the watcher out of the watcher groups until the client
+// acknowledges the revisions past its ack window. The watcher must not be in
+// any group anymore.
+func (s *watchableStore) pause(w *watcher) {
+	w.paused = true
+	pausedWatcherGauge.Inc()
+}
+
 func (s *watchableStore) addVictim(victim watcherBatch) {
 	if len(victim) == 0 {
 		return
 	}
+	s.victimMu.Lock()
 	s.victims = append(s.victims, victim)
+	s.victimMu.Unlock()
 	select {
 	case s.victimc <- struct{}{}:
 	default:
@@ -518,11 +759,16 @@ func (s *watchableStore) progressIfSync(watchers map[WatchID]*watcher, responseW
 	// gofail: var beforeProgressIfSync struct{}
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	// Lock the shards of the watchers so that no notification to them
+	// is in flight while the revision is read.
+	shards := s.synced.watcherShards(watchers)
+	s.lockShards(shards)
+	defer s.unlockShards(shards)
 
 	rev := s.rev()
 	// Any watcher unsynced?
 	for _, w := range watchers {
-		if _, ok := s.synced.watchers[w]; !ok {
+		if !s.synced.has(w) {
 			return false
 		}
 		if rev < w.startRev {
@@ -555,6 +801,10 @@ type watcher struct {
 	// compacted is set when the watcher is removed because of compaction
 	compacted bool
 
+	// paused is set when the watcher is removed from the watcher groups
+	// because it reached the limit of its ack window
+	paused bool
+
 	// restore is true when the watcher is being restored from leader snapshot
 	// which means that this watcher has just been moved from "synced" to "unsynced"
 	// watcher group, possibly with a future revision when it was first added
@@ -569,13 +819,60 @@ type watcher struct {
 	id     WatchID
 
 	fcs []FilterFunc
+	// latestPerKey is set when only the latest event of each key is sent to
+	// the watcher while it is unsynced.
+	latestPerKey bool
+	// sampleEveryN is greater than 1 when only every Nth PUT event of each
+	// key is sent to the watcher.
+	sampleEveryN int64
+	// sampleCounts counts the PUT events of each key since its last DELETE.
+	// The keys with no such event are absent.
+	sampleCounts map[string]int64
+	// compactWarningMargin is greater than 0 when the watcher is warned of
+	// the compactions that get within that many revisions of its next
+	// revision to receive.
+	compactWarningMargin int64
+	// compactWarningRev is the revision of a compaction the watcher has not
+	// been warned of yet. The warning is sent with the next response.
+	compactWarningRev int64
+	// ackWindow is greater than 0 when the watcher is only sent the
+	// revisions up to ackWindow past ackedRev.
+	ackWindow int64
+	// ackedRev is the highest revision the client acknowledged.
+	ackedRev int64
+	// collapseDeletes is set when the DELETE events of a range deletion are
+	// sent to the synced watcher as a single DELETE_RANGE event.
+	collapseDeletes bool
+	// condition, if set, is evaluated on the events of the watcher, which
+	// only receives those that flip its result.
+	condition func(kv *mvccpb.KeyValue) bool
+	// conditionResult is the result of the condition after the last event
+	// sent to the watcher.
+	conditionResult bool
 	// a chan to send out the watch response.
 	// The chan might be shared with other watchers.
 	ch chan<- WatchResponse
 }
 
+// ackLimit returns the highest revision the watcher can be sent.
+func (w *watcher) ackLimit() int64 {
+	if w.ackWindow <= 0 {
+		return math.MaxInt64
+	}
+	return w.ackedRev + w.ackWindow
+}
+
 func (w *watcher) send(wr WatchResponse) bool {
 	progressEvent := len(wr.Events) == 0
+	wr.CompactWarningRevision = w.compactWarningRev
+
+	conditionResult := w.conditionResult
+	if w.condition != nil {
+		wr.Events, conditionResult = w.flips(wr.Events)
+		if wr.WatchID == w.id {
+			wr.ConditionResult = conditionResult
+		}
+	}
 
 	if len(w.fcs) != 0 {
 		ne := make([]*mvccpb.Event, 0, len(wr.Events))
@@ -594,6 +891,11 @@ func (w *watcher) send(wr WatchResponse) bool {
 		wr.Events = ne
 	}
 
+	var sampleCounts map[string]int64
+	if w.sampleEveryN > 1 {
+		wr.Events, sampleCounts = w.sample(wr.Events)
+	}
+
 	verify.Verify("Event.ModRevision is less than the w.startRev for watchID", func() (bool, map[string]any) {
 		if w.startRev > 0 {
 			for _, ev := range wr.Events {
@@ -611,12 +913,134 @@ func (w *watcher) send(wr WatchResponse) bool {
 
 	// if all events are filtered out, we should send nothing.
 	if !progressEvent && len(wr.Events) == 0 {
+		w.updateSampleCounts(sampleCounts)
+		w.conditionResult = conditionResult
 		return true
 	}
 	select {
 	case w.ch <- wr:
+		w.updateSampleCounts(sampleCounts)
+		w.conditionResult = conditionResult
+		w.compactWarningRev = 0
 		return true
 	default:
 		return false
 	}
 }
+
+// compactWarningDue returns whether a compaction at compactRev is within the
+// warning margin of the watcher, whose next revision to receive is next.
+func (w *watcher) compactWarningDue(next, compactRev int64) bool {
+	return w.compactWarningMargin > 0 && next >= compactRev && next-compactRev <= w.compactWarningMargin
+}
+
+// collapse returns evs with the DELETE events of each range deletion replaced
+// by a single DELETE_RANGE event, in place of the first one. The event holds
+// the part of the deleted range within the range of the watcher, and the
+// number of keys deleted in it.
+func (w *watcher) collapse(evs []*mvccpb.Event, deletes map[*mvccpb.KeyValue]*deletedRange) []*mvccpb.Event {
+	ne := make([]*mvccpb.Event, 0, len(evs))
+	collapsed := make(map[*deletedRange]*mvccpb.Event)
+	for _, ev := range evs {
+		dr := deletes[ev.Kv]
+		if dr == nil {
+			ne = append(ne, ev)
+			continue
+		}
+		if cev := collapsed[dr]; cev != nil {
+			cev.Deleted++
+			continue
+		}
+		key := dr.key
+		if bytes.Compare(w.key, key) > 0 {
+			key = w.key
+		}
+		end := dr.end
+		if len(end) == 0 || (len(w.end) != 0 && bytes.Compare(w.end, end) < 0) {
+			end = w.end
+		}
+		if len(end) == 0 {
+			// \x00 is the range end of all the keys from the key on
+			end = []byte{0}
+		}
+		cev := &mvccpb.Event{
+			Type:     mvccpb.Event_DELETE_RANGE,
+			Kv:       &mvccpb.KeyValue{Key: key, ModRevision: ev.Kv.ModRevision},
+			RangeEnd: end,
+			Deleted:  1,
+		}
+		collapsed[dr] = cev
+		ne = append(ne, cev)
+	}
+	return ne
+}
+
+// flips returns the events of evs that flip the result of the condition of
+// the watcher, and its result after them. The result of the watcher is not
+// updated, so that a response that fails to send is evaluated the same way
+// when it is sent again.
+func (w *watcher) flips(evs []*mvccpb.Event) ([]*mvccpb.Event, bool) {
+	result := w.conditionResult
+	ne := make([]*mvccpb.Event, 0, len(evs))
+	for _, ev := range evs {
+		kv := ev.Kv
+		if ev.Type != mvccpb.Event_PUT {
+			kv = nil
+		}
+		if r := w.condition(kv); r != result {
+			result = r
+			ne = append(ne, ev)
+		}
+	}
+	return ne, result
+}
+
+// sample returns the events to send out of evs, and the updated counts of
+// their keys. The counts of the watcher are not updated, so that a response
+// that fails to send is sampled the same way when it is sent again.
+func (w *watcher) sample(evs []*mvccpb.Event) ([]*mvccpb.Event, map[string]int64) {
+	counts := make(map[string]int64)
+	ne := make([]*mvccpb.Event, 0, len(evs))
+	for _, ev := range evs {
+		key := string(ev.Kv.Key)
+		if ev.Type == mvccpb.Event_DELETE {
+			counts[key] = 0
+			ne = append(ne, ev)
+			continue
+		}
+		if ev.Type == mvccpb.Event_DELETE_RANGE {
+			// restart the count of every deleted key of the range
+			for _, keys := range []map[string]int64{w.sampleCounts, counts} {
+				for k := range keys {
+					if k >= key && (string(ev.RangeEnd) == "\x00" || k < string(ev.RangeEnd)) {
+						counts[k] = 0
+					}
+				}
+			}
+			ne = append(ne, ev)
+			continue
+		}
+		n, ok := counts[key]
+		if !ok {
+			n = w.sampleCounts[key]
+		}
+		if n%w.sampleEveryN == 0 {
+			ne = append(ne, ev)
+		}
+		counts[key] = n + 1
+	}
+	return ne, counts
+}
+
+func (w *watcher) updateSampleCounts(counts map[string]int64) {
+	for key, n := range counts {
+		if n == 0 {
+			delete(w.sampleCounts, key)
+			continue
+		}
+		if w.sampleCounts == nil {
+			w.sampleCounts = make(map[string]int64)
+		}
+		w.sampleCounts[key] = n
+	}
+}
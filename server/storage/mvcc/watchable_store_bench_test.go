@@ -15,7 +15,10 @@
 package mvcc
 
 import (
+	"fmt"
 	"math/rand"
+	"runtime/metrics"
+	"sync"
 	"testing"
 
 	"go.uber.org/zap/zaptest"
@@ -198,3 +201,72 @@ func BenchmarkWatchableStoreSyncedCancel(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkWatchableStoreConcurrentWatchCancel benchmarks creating and
+// canceling watchers on distinct keys from many goroutines while puts are
+// notifying watchers, and reports the time spent waiting on mutexes.
+func BenchmarkWatchableStoreConcurrentWatchCancel(b *testing.B) {
+	be, _ := betesting.NewDefaultTmpBackend(b)
+	s := New(zaptest.NewLogger(b), be, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, be)
+
+	const keyN = 1024
+	keys := make([][]byte, keyN)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key-%d", i))
+	}
+
+	stopc := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stopc:
+				return
+			default:
+			}
+			s.Put(keys[i%keyN], []byte("bar"), lease.NoLease)
+		}
+	}()
+
+	var next sync.Mutex
+	nextKey := 0
+	sample := []metrics.Sample{{Name: "/sync/mutex/wait/total:seconds"}}
+	metrics.Read(sample)
+	waitBefore := sample[0].Value.Float64()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		next.Lock()
+		key := keys[nextKey%keyN]
+		nextKey++
+		next.Unlock()
+
+		w := s.NewWatchStream()
+		defer w.Close()
+		go func() {
+			for range w.Chan() {
+			}
+		}()
+		for pb.Next() {
+			id, err := w.Watch(b.Context(), 0, key, nil, 0)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if err = w.Cancel(id); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+	b.StopTimer()
+
+	metrics.Read(sample)
+	b.ReportMetric((sample[0].Value.Float64()-waitBefore)*1e9/float64(b.N), "lock-wait-ns/op")
+	close(stopc)
+	wg.Wait()
+}
@@ -15,7 +15,10 @@
 package mvcc
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -340,6 +343,31 @@ func TestSyncWatchers(t *testing.T) {
 	}
 }
 
+// TestWatchCatchUp ensures that the responses replayed from the history to
+// an unsynced watcher are marked as catch-up, and the live ones are not.
+func TestWatchCatchUp(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey := []byte("foo")
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(t.Context(), 0, testKey, nil, 1)
+	require.NoError(t, err)
+
+	s.syncWatchers()
+	resp := <-w.Chan()
+	require.Len(t, resp.Events, 1)
+	assert.True(t, resp.CatchUp)
+
+	s.Put(testKey, []byte("baz"), lease.NoLease)
+	resp = <-w.Chan()
+	require.Len(t, resp.Events, 1)
+	assert.False(t, resp.CatchUp)
+}
+
 func TestRangeEvents(t *testing.T) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
 	lg := zaptest.NewLogger(t)
@@ -450,6 +478,76 @@ func (f fakeContains) contains(string) bool {
 }
 
 // TestWatchCompacted tests a watcher that watches on a compacted revision.
+// TestSyncWatchersGroupGauges tests that syncWatchers reports the sizes of the
+// synced and unsynced watcher groups.
+func TestSyncWatchersGroupGauges(t *testing.T) {
+	oldMaxWatchersPerSync := maxWatchersPerSync
+	defer func() {
+		maxWatchersPerSync = oldMaxWatchersPerSync
+	}()
+	maxWatchersPerSync = 2
+
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey := []byte("foo")
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(t.Context(), 0, testKey, nil, 0)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = w.Watch(t.Context(), 0, testKey, nil, 1)
+		require.NoError(t, err)
+	}
+
+	s.syncWatchers()
+	assert.InDelta(t, 3, testutil.ToFloat64(syncedWatcherGauge), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(unsyncedWatcherGauge), 0)
+
+	s.syncWatchers()
+	assert.InDelta(t, 4, testutil.ToFloat64(syncedWatcherGauge), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(unsyncedWatcherGauge), 0)
+}
+
+func TestWatchSyncFairness(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+	assert.Equal(t, 10*time.Millisecond, s.syncYield(10*time.Millisecond))
+	assert.Equal(t, time.Nanosecond, s.syncYield(0))
+
+	b2, _ := betesting.NewDefaultTmpBackend(t)
+	s2 := newWatchableStore(zaptest.NewLogger(t), b2, &lease.FakeLessor{}, StoreConfig{WatchSyncFairness: 2.5})
+	defer cleanup(s2, b2)
+	assert.Equal(t, 25*time.Millisecond, s2.syncYield(10*time.Millisecond))
+}
+
+func TestWatchSyncPhaseMetric(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	catchUp := testutil.ToFloat64(watchSyncPhaseSec.WithLabelValues("catch_up"))
+	live := testutil.ToFloat64(watchSyncPhaseSec.WithLabelValues("live"))
+
+	testKey := []byte("foo")
+	s.Put(testKey, []byte("bar"), lease.NoLease)
+	w := s.NewWatchStream()
+	defer w.Close()
+	// the watcher from the first revision catches up, then gets the next
+	// event live
+	_, err := w.Watch(t.Context(), 0, testKey, nil, 1)
+	require.NoError(t, err)
+	<-w.Chan()
+	s.Put(testKey, []byte("baz"), lease.NoLease)
+	<-w.Chan()
+
+	assert.Greater(t, testutil.ToFloat64(watchSyncPhaseSec.WithLabelValues("catch_up")), catchUp)
+	assert.Greater(t, testutil.ToFloat64(watchSyncPhaseSec.WithLabelValues("live")), live)
+}
+
 func TestWatchCompacted(t *testing.T) {
 	b, _ := betesting.NewDefaultTmpBackend(t)
 	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
@@ -485,6 +583,197 @@ func TestWatchCompacted(t *testing.T) {
 	}
 }
 
+// TestWatchCompactWarning tests that the unsynced watchers are warned of the
+// compactions within their margin, with the next response if their channel
+// is full.
+func TestWatchCompactWarning(t *testing.T) {
+	oldChanBufLen := chanBufLen
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	// no sync loop, so that the watchers stay unsynced
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer func() {
+		cleanup(s, b)
+		chanBufLen = oldChanBufLen
+	}()
+
+	testKey, testValue := []byte("foo"), []byte("bar")
+	for i := 0; i < 10; i++ {
+		s.Put(testKey, testValue, lease.NoLease)
+	}
+
+	chanBufLen = 1
+	warned := s.NewWatchStream()
+	defer warned.Close()
+	chanBufLen = oldChanBufLen
+	others := s.NewWatchStream()
+	defer others.Close()
+
+	wid, err := warned.WatchWithOptions(t.Context(), 0, testKey, nil, 5, WatchOptions{CompactWarningMargin: 3})
+	require.NoError(t, err)
+	// out of the margin, and without margin
+	_, err = others.WatchWithOptions(t.Context(), 0, testKey, nil, 9, WatchOptions{CompactWarningMargin: 3})
+	require.NoError(t, err)
+	_, err = others.Watch(t.Context(), 0, testKey, nil, 5)
+	require.NoError(t, err)
+
+	_, err = s.Compact(traceutil.TODO(), 3)
+	require.NoError(t, err)
+	// the channel is full, so the warning is sent with the next response
+	_, err = s.Compact(traceutil.TODO(), 4)
+	require.NoError(t, err)
+
+	resp := <-warned.Chan()
+	assert.Equal(t, WatchResponse{WatchID: wid, Revision: 4, CompactWarningRevision: 3}, resp)
+
+	s.syncWatchers()
+	resp = <-warned.Chan()
+	assert.Len(t, resp.Events, 7)
+	assert.Equal(t, int64(4), resp.CompactWarningRevision)
+	for i := 0; i < 2; i++ {
+		resp = <-others.Chan()
+		assert.NotEmpty(t, resp.Events)
+		assert.Zero(t, resp.CompactWarningRevision)
+	}
+
+	s.Put(testKey, testValue, lease.NoLease)
+	resp = <-warned.Chan()
+	assert.Len(t, resp.Events, 1)
+	assert.Zero(t, resp.CompactWarningRevision)
+}
+
+// TestWatchRewatchCompacted tests that a watcher canceled by compaction can be
+// resumed with its ID, while other watchers cannot.
+func TestWatchAckWindow(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	// no sync loop, so that the watchers are only synced by the test
+	s := newWatchableStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey, testValue := []byte("foo"), []byte("bar")
+	revs := func(resp WatchResponse) []int64 {
+		var rs []int64
+		for _, ev := range resp.Events {
+			rs = append(rs, ev.Kv.ModRevision)
+		}
+		return rs
+	}
+	recv := func(ws WatchStream) WatchResponse {
+		t.Helper()
+		select {
+		case resp := <-ws.Chan():
+			return resp
+		case <-time.After(5 * time.Second):
+			t.Fatal("failed to receive watch response")
+		}
+		return WatchResponse{}
+	}
+	requireNoResponse := func(ws WatchStream) {
+		t.Helper()
+		select {
+		case resp := <-ws.Chan():
+			t.Fatalf("unexpected watch response %+v", resp)
+		default:
+		}
+	}
+
+	// a synced watcher is paused once it is sent the revisions of its window
+	synced := s.NewWatchStream()
+	defer synced.Close()
+	sid, err := synced.WatchWithOptions(t.Context(), 0, testKey, nil, 0, WatchOptions{AckWindow: 2})
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		s.Put(testKey, testValue, lease.NoLease)
+	}
+	assert.Equal(t, []int64{2}, revs(recv(synced)))
+	assert.Equal(t, []int64{3}, revs(recv(synced)))
+	s.syncWatchers()
+	requireNoResponse(synced)
+
+	// the ack resumes it from the history
+	require.NoError(t, synced.Ack(sid, 3))
+	s.syncWatchers()
+	resp := recv(synced)
+	assert.Equal(t, []int64{4, 5}, revs(resp))
+	assert.Equal(t, int64(5), resp.Revision)
+
+	// an unsynced watcher is only sent the revisions of its window
+	unsynced := s.NewWatchStream()
+	defer unsynced.Close()
+	uid, err := unsynced.WatchWithOptions(t.Context(), 0, testKey, nil, 2, WatchOptions{AckWindow: 1})
+	require.NoError(t, err)
+	s.syncWatchers()
+	resp = recv(unsynced)
+	assert.Equal(t, []int64{2}, revs(resp))
+	assert.Equal(t, int64(2), resp.Revision)
+	s.syncWatchers()
+	requireNoResponse(unsynced)
+
+	require.NoError(t, unsynced.Ack(uid, 4))
+	s.syncWatchers()
+	resp = recv(unsynced)
+	assert.Equal(t, []int64{3, 4, 5}, revs(resp))
+	assert.Equal(t, int64(5), resp.Revision)
+
+	// both watchers are synced again, but only the one within its window is
+	// notified
+	require.NoError(t, synced.Ack(sid, 5))
+	s.Put(testKey, testValue, lease.NoLease)
+	assert.Equal(t, []int64{6}, revs(recv(synced)))
+	requireNoResponse(unsynced)
+
+	require.NoError(t, unsynced.Ack(uid, 5))
+	s.syncWatchers()
+	assert.Equal(t, []int64{6}, revs(recv(unsynced)))
+	requireNoResponse(synced)
+
+	require.ErrorIs(t, synced.Ack(uid+100, 1), ErrWatcherNotExist)
+}
+
+func TestWatchRewatchCompacted(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	testKey := []byte("foo")
+	testValue := []byte("bar")
+	for i := 0; i < 10; i++ {
+		s.Put(testKey, testValue, lease.NoLease)
+	}
+	_, err := s.Compact(traceutil.TODO(), 5)
+	require.NoError(t, err)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	synced, _ := w.Watch(t.Context(), 0, testKey, nil, 0)
+	require.ErrorIs(t, w.Rewatch(synced, 1), ErrWatcherNotCompacted)
+	require.ErrorIs(t, w.Rewatch(100, 1), ErrWatcherNotExist)
+
+	wt, _ := w.Watch(t.Context(), 0, testKey, nil, 4)
+	select {
+	case resp := <-w.Chan():
+		require.Equal(t, wt, resp.WatchID)
+		require.Equal(t, int64(5), resp.CompactRevision)
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive compaction response (timeout)")
+	}
+
+	rev := s.Rev()
+	require.NoError(t, w.Rewatch(wt, rev))
+	require.ErrorIs(t, w.Rewatch(wt, rev), ErrWatcherNotCompacted)
+	select {
+	case resp := <-w.Chan():
+		require.Equal(t, wt, resp.WatchID)
+		require.Len(t, resp.Events, 1)
+		require.Equal(t, rev, resp.Events[0].Kv.ModRevision)
+	case <-time.After(time.Second):
+		t.Fatalf("failed to receive event after rewatch (timeout)")
+	}
+
+	require.NoError(t, w.Cancel(wt))
+	require.ErrorIs(t, w.Rewatch(wt, 10), ErrWatcherNotExist)
+}
+
 func TestWatchNoEventLossOnCompact(t *testing.T) {
 	oldChanBufLen, oldMaxWatchersPerSync := chanBufLen, maxWatchersPerSync
 
@@ -770,6 +1059,60 @@ func TestWatchBatchUnsynced(t *testing.T) {
 	}
 }
 
+// TestWatchLatestPerKeyUnsynced ensures that an unsynced watcher created with
+// the LatestPerKey option only receives the latest event of each key,
+// regardless of the batch size, and then receives every event once synced.
+func TestWatchLatestPerKeyUnsynced(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	oldMaxRevs := watchBatchMaxRevs
+	defer func() {
+		watchBatchMaxRevs = oldMaxRevs
+		cleanup(s, b)
+	}()
+	watchBatchMaxRevs = 2
+
+	for _, k := range []string{"a", "b", "a", "b", "c", "a"} {
+		s.Put([]byte(k), []byte(k), lease.NoLease)
+	}
+	s.DeleteRange([]byte("b"), nil)
+	// out of the watched range
+	s.Put([]byte("d"), []byte("d"), lease.NoLease)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.WatchWithOptions(t.Context(), 0, []byte("a"), []byte("d"), 1, WatchOptions{LatestPerKey: true})
+	require.NoError(t, err)
+
+	type event struct {
+		typ mvccpb.Event_EventType
+		key string
+		rev int64
+	}
+	recv := func() []event {
+		var evs []event
+		select {
+		case resp := <-w.Chan():
+			for _, ev := range resp.Events {
+				evs = append(evs, event{typ: ev.Type, key: string(ev.Kv.Key), rev: ev.Kv.ModRevision})
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("failed to receive watch response")
+		}
+		return evs
+	}
+	assert.Equal(t, []event{
+		{typ: mvccpb.Event_PUT, key: "c", rev: 6},
+		{typ: mvccpb.Event_PUT, key: "a", rev: 7},
+		{typ: mvccpb.Event_DELETE, key: "b", rev: 8},
+	}, recv())
+
+	s.Put([]byte("a"), []byte("a"), lease.NoLease)
+	s.Put([]byte("a"), []byte("a"), lease.NoLease)
+	assert.Equal(t, []event{{typ: mvccpb.Event_PUT, key: "a", rev: 10}}, recv())
+	assert.Equal(t, []event{{typ: mvccpb.Event_PUT, key: "a", rev: 11}}, recv())
+}
+
 func TestNewMapwatcherToEventMap(t *testing.T) {
 	k0, k1, k2 := []byte("foo0"), []byte("foo1"), []byte("foo2")
 	v0, v1, v2 := []byte("bar0"), []byte("bar1"), []byte("bar2")
@@ -851,7 +1194,7 @@ func TestNewMapwatcherToEventMap(t *testing.T) {
 			wg.add(w)
 		}
 
-		gwe := newWatcherBatch(&wg, tt.evs)
+		gwe := newWatcherBatch(&wg, tt.evs, false)
 		if len(gwe) != len(tt.wwe) {
 			t.Errorf("#%d: len(gwe) got = %d, want = %d", i, len(gwe), len(tt.wwe))
 		}
@@ -987,3 +1330,130 @@ func TestStressWatchCancelClose(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestShardedWatcherGroup(t *testing.T) {
+	sg := newShardedWatcherGroup(4)
+	foo := &watcher{key: []byte("foo"), id: 1}
+	bar := &watcher{key: []byte("bar"), id: 2}
+	rng := &watcher{key: []byte("a"), end: []byte("z"), id: 3}
+	for _, w := range []*watcher{foo, bar, rng} {
+		sg.add(w)
+	}
+
+	assert.Equal(t, 3, sg.size())
+	assert.Equal(t, sg.rangeShard(), sg.shard(rng))
+	assert.Equal(t, sg.keyShard("foo"), sg.shard(foo))
+	assert.True(t, sg.has(foo))
+	assert.True(t, sg.contains("foo"))
+	assert.True(t, sg.contains("baz"), "range watcher should cover baz")
+	assert.False(t, sg.contains("zoo"))
+	assert.Equal(t, watcherSet{foo: {}, rng: {}}, sg.watcherSetByKey("foo"))
+	assert.Equal(t, watcherSet{rng: {}}, sg.watcherSetByKey("baz"))
+
+	evs := []*mvccpb.Event{
+		{Kv: &mvccpb.KeyValue{Key: []byte("foo")}},
+		{Kv: &mvccpb.KeyValue{Key: []byte("zoo")}},
+	}
+	shards, shardEvs := sg.eventsByShard(evs)
+	assert.True(t, sort.IntsAreSorted(shards))
+	assert.Equal(t, sg.rangeShard(), shards[len(shards)-1])
+	assert.Equal(t, evs, shardEvs[sg.rangeShard()])
+	assert.Contains(t, shardEvs[sg.keyShard("foo")], evs[0])
+	assert.Equal(t, []int{sg.shard(foo), sg.rangeShard()}, sg.watcherShards(map[WatchID]*watcher{1: foo, 3: rng}))
+
+	foo.ch = make(chan WatchResponse, 1)
+	foo.minRev, bar.minRev, rng.minRev = 1, 5, 10
+	wg, minRev := sg.choose(math.MaxInt32, 10, 2)
+	assert.Equal(t, int64(5), minRev)
+	assert.Equal(t, 2, wg.size())
+	assert.True(t, foo.compacted)
+	assert.False(t, sg.has(foo), "compacted watcher should be removed")
+	assert.Equal(t, 2, sg.size())
+
+	assert.True(t, sg.delete(rng))
+	assert.False(t, sg.delete(rng))
+	assert.False(t, sg.contains("baz"))
+}
+
+// TestWatchConcurrentShards tests that watchers on different keys created,
+// canceled and notified concurrently still observe every event on their
+// keys exactly once and in order.
+func TestWatchConcurrentShards(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	const (
+		keyN = 8
+		putN = 50
+	)
+	rangeStream := s.NewWatchStream()
+	defer rangeStream.Close()
+	_, err := rangeStream.Watch(t.Context(), 0, []byte("key"), []byte("kez"), 0)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	errc := make(chan error, keyN)
+	for i := 0; i < keyN; i++ {
+		wg.Add(1)
+		go func(key []byte) {
+			defer wg.Done()
+			w := s.NewWatchStream()
+			defer w.Close()
+			keyID, err := w.Watch(t.Context(), 0, key, nil, 0)
+			if err != nil {
+				errc <- err
+				return
+			}
+			var lastRev int64
+			for j := 0; j < putN; j++ {
+				// churn watchers on the same key while putting to it
+				id, _ := w.Watch(t.Context(), 0, key, nil, 0)
+				rev := s.Put(key, []byte(fmt.Sprint(j)), lease.NoLease)
+				w.Cancel(id)
+				// drain until the watch on key sees the put, skipping
+				// responses to the churned watchers
+				for lastRev != rev {
+					select {
+					case resp := <-w.Chan():
+						if resp.WatchID != keyID {
+							continue
+						}
+						for _, ev := range resp.Events {
+							if !bytes.Equal(ev.Kv.Key, key) || ev.Kv.ModRevision <= lastRev {
+								errc <- fmt.Errorf("unexpected event %v after revision %d", ev.Kv, lastRev)
+								return
+							}
+							lastRev = ev.Kv.ModRevision
+						}
+					case <-time.After(5 * time.Second):
+						errc <- fmt.Errorf("timed out waiting for revision %d on %q", rev, key)
+						return
+					}
+				}
+			}
+		}([]byte(fmt.Sprintf("key%d", i)))
+	}
+
+	lastRev := s.Rev()
+	for n := 0; n < keyN*putN; {
+		select {
+		case resp := <-rangeStream.Chan():
+			for _, ev := range resp.Events {
+				require.Equalf(t, lastRev+1, ev.Kv.ModRevision, "range watcher missed an event")
+				lastRev = ev.Kv.ModRevision
+				n++
+			}
+		case err := <-errc:
+			t.Fatal(err)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out after %d events on range watcher", n)
+		}
+	}
+	wg.Wait()
+	close(errc)
+	for err := range errc {
+		t.Fatal(err)
+	}
+}
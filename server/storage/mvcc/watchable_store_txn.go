@@ -38,19 +38,43 @@ func (tw *watchableStoreTxnWrite) End() {
 		}
 	}
 
-	// end write txn under watchable store lock so the updates are visible
-	// when asynchronous event posting checks the current store revision
-	tw.s.mu.Lock()
-	tw.s.notify(rev, evs)
+	// end write txn under the locks of the notified watcher shards so the
+	// updates are visible when asynchronous event posting checks the
+	// current store revision
+	tw.s.mu.RLock()
+	shards, shardEvs := tw.s.synced.eventsByShard(evs)
+	tw.s.lockShards(shards)
+	tw.s.notify(rev, shards, shardEvs, changes, tw.ranges)
 	tw.TxnWrite.End()
-	tw.s.mu.Unlock()
+	tw.s.unlockShards(shards)
+	tw.s.mu.RUnlock()
+}
+
+func (tw *watchableStoreTxnWrite) DeleteRange(key, end []byte) (n, rev int64) {
+	first := len(tw.Changes())
+	n, rev = tw.TxnWrite.DeleteRange(key, end)
+	if n != 0 && end != nil {
+		tw.ranges = append(tw.ranges, deletedRange{key: key, end: end, first: first, n: int(n)})
+	}
+	return n, rev
 }
 
 type watchableStoreTxnWrite struct {
 	TxnWrite
 	s *watchableStore
+	// ranges are the range deletions of the txn, in order.
+	ranges []deletedRange
+}
+
+// deletedRange is a deletion of the keys in [key, end), with the conventions
+// of TxnWrite.DeleteRange, whose DELETE events may be collapsed into one.
+type deletedRange struct {
+	key, end []byte
+	// first is the index of the change of the first deleted key, and n the
+	// number of deleted keys.
+	first, n int
 }
 
 func (s *watchableStore) Write(trace *traceutil.Trace) TxnWrite {
-	return &watchableStoreTxnWrite{s.store.Write(trace), s}
+	return &watchableStoreTxnWrite{TxnWrite: s.store.Write(trace), s: s}
 }
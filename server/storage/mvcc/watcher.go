@@ -27,9 +27,10 @@ import (
 )
 
 var (
-	ErrWatcherNotExist    = errors.New("mvcc: watcher does not exist")
-	ErrEmptyWatcherRange  = errors.New("mvcc: watcher range is empty")
-	ErrWatcherDuplicateID = errors.New("mvcc: duplicate watch ID provided on the WatchStream")
+	ErrWatcherNotExist     = errors.New("mvcc: watcher does not exist")
+	ErrEmptyWatcherRange   = errors.New("mvcc: watcher range is empty")
+	ErrWatcherDuplicateID  = errors.New("mvcc: duplicate watch ID provided on the WatchStream")
+	ErrWatcherNotCompacted = errors.New("mvcc: watcher is not canceled by compaction")
 )
 
 type WatchID int64
@@ -37,6 +38,126 @@ type WatchID int64
 // FilterFunc returns true if the given event should be filtered out.
 type FilterFunc func(e *mvccpb.Event) bool
 
+// FilterStage classifies a filter by what it inspects, which bounds the cost
+// of evaluating it.
+type FilterStage int
+
+const (
+	// FilterStageType filters inspect the event type only.
+	FilterStageType FilterStage = iota
+	// FilterStageValue filters inspect the key-value of the event.
+	FilterStageValue
+
+	numFilterStages
+)
+
+// FilterOrder is the order in which the stages of a filter pipeline run.
+type FilterOrder int
+
+const (
+	// TypeFiltersFirst runs the type filters before the value filters. It is
+	// the default.
+	TypeFiltersFirst FilterOrder = iota
+	// ValueFiltersFirst runs the value filters before the type filters.
+	ValueFiltersFirst
+)
+
+// Filter is a FilterFunc together with its stage.
+type Filter struct {
+	Stage FilterStage
+	Func  FilterFunc
+	// Name labels the events filtered out by Func in the
+	// etcd_debugging_mvcc_watch_events_filtered_total metric. It defaults to
+	// "other".
+	Name string
+}
+
+// NewFilterPipeline returns a FilterFunc that runs the given filters stage
+// by stage in the given order, and within a stage in the given sequence.
+// An event is filtered out by the first filter that matches it, so the
+// filters after it are not evaluated, and it is counted under the name of
+// that filter. It returns nil if there are no filters.
+func NewFilterPipeline(order FilterOrder, filters ...Filter) FilterFunc {
+	var stages [numFilterStages][]FilterFunc
+	for _, f := range filters {
+		stages[f.Stage] = append(stages[f.Stage], countFiltered(f))
+	}
+	if order == ValueFiltersFirst {
+		stages[FilterStageType], stages[FilterStageValue] = stages[FilterStageValue], stages[FilterStageType]
+	}
+	var fcs []FilterFunc
+	for _, stage := range stages {
+		fcs = append(fcs, stage...)
+	}
+	if len(fcs) == 0 {
+		return nil
+	}
+	return func(e *mvccpb.Event) bool {
+		for _, fc := range fcs {
+			if fc(e) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// countFiltered returns the FilterFunc of f, counting the events it filters out.
+func countFiltered(f Filter) FilterFunc {
+	name := f.Name
+	if name == "" {
+		name = "other"
+	}
+	filtered := watchEventsFilteredCounter.WithLabelValues(name)
+	return func(e *mvccpb.Event) bool {
+		if f.Func(e) {
+			filtered.Inc()
+			return true
+		}
+		return false
+	}
+}
+
+// WatchOptions configures how events are delivered to a watcher.
+type WatchOptions struct {
+	// LatestPerKey makes the watcher receive only the latest event of each
+	// key while it catches up on the history from its start revision.
+	LatestPerKey bool
+	// SampleEveryN, if greater than 1, makes the watcher receive only every
+	// Nth PUT event of each key, starting with the first one, out of the
+	// events that pass its filters. DELETE events are always received and
+	// restart the count of their key. The other events are dropped, so
+	// sampling is lossy.
+	SampleEveryN int64
+	// CompactWarningMargin, if greater than 0, makes the watcher receive a
+	// response with CompactWarningRevision set when a compaction gets within
+	// that many revisions of the next revision it has to receive, while it
+	// catches up on the history.
+	CompactWarningMargin int64
+	// AckWindow, if greater than 0, makes the watcher receive only the events
+	// of the revisions up to AckWindow past the last revision acknowledged
+	// with Ack. The revisions before the start of the watcher count as
+	// acknowledged. The watcher is paused, without buffering the later
+	// events, until more revisions are acknowledged.
+	AckWindow int64
+	// CollapseDeletes makes the watcher receive a single DELETE_RANGE event
+	// in place of the DELETE events of the keys deleted by a DeleteRange
+	// with a range end, holding the part of the range within the range of
+	// the watcher and the number of keys deleted in it. Only the events
+	// sent while the watcher is synced are collapsed; the events read from
+	// the history, and the deletes of single keys such as those of expired
+	// leases, are sent one key at a time. It does not apply to the watchers
+	// of a single key.
+	CollapseDeletes bool
+	// Condition, if set, makes the watcher receive only the events that flip
+	// the result of Condition on their key-value pair, which is nil for a
+	// DELETE event. ConditionResult is its result before the start revision
+	// of the watcher. It applies to the watchers of a single key, before the
+	// filters of the watcher.
+	Condition       func(kv *mvccpb.KeyValue) bool
+	ConditionResult bool
+}
+
 type WatchStream interface {
 	// Watch creates a watcher. The watcher watches the events happening or
 	// happened on the given key or range [key, end) from the given startRev.
@@ -50,6 +171,10 @@ type WatchStream interface {
 	// an auto-generated watch ID is returned.
 	Watch(ctx context.Context, id WatchID, key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error)
 
+	// WatchWithOptions creates a watcher like Watch, which receives events
+	// as configured by opts.
+	WatchWithOptions(ctx context.Context, id WatchID, key, end []byte, startRev int64, opts WatchOptions, fcs ...FilterFunc) (WatchID, error)
+
 	// Chan returns a chan. All watch response will be sent to the returned chan.
 	Chan() <-chan WatchResponse
 
@@ -72,6 +197,18 @@ type WatchStream interface {
 	// returned.
 	Cancel(id WatchID) error
 
+	// Rewatch resumes the watcher with given ID from startRev after it has
+	// been canceled by compaction, which is reported with CompactRevision.
+	// If the watcher does not exist or is not canceled by compaction, an
+	// error will be returned.
+	Rewatch(id WatchID, startRev int64) error
+
+	// Ack acknowledges that the client has processed the events of the
+	// watcher with the given ID up to rev, which resumes the watcher if it
+	// was paused by its ack window. If the watcher does not exist, an error
+	// will be returned.
+	Ack(id WatchID, rev int64) error
+
 	// Close closes Chan and release all related resources.
 	Close()
 
@@ -95,6 +232,19 @@ type WatchResponse struct {
 
 	// CompactRevision is set when the watcher is cancelled due to compaction.
 	CompactRevision int64
+
+	// CompactWarningRevision is set to the revision of a compaction that got
+	// within the compaction warning margin of the watcher. The watcher is not
+	// canceled.
+	CompactWarningRevision int64
+
+	// CatchUp is set when the events are replayed from the history to an
+	// unsynced watcher, rather than notified as they happen.
+	CatchUp bool
+
+	// ConditionResult is the result of the condition of the watcher after
+	// the events, for the watchers created with a condition.
+	ConditionResult bool
 }
 
 // watchStream contains a collection of watchers that share
@@ -113,6 +263,12 @@ type watchStream struct {
 
 // Watch creates a new watcher in the stream and returns its WatchID.
 func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, startRev int64, fcs ...FilterFunc) (WatchID, error) {
+	return ws.WatchWithOptions(ctx, id, key, end, startRev, WatchOptions{}, fcs...)
+}
+
+// WatchWithOptions creates a new watcher in the stream, which receives
+// events as configured by opts, and returns its WatchID.
+func (ws *watchStream) WatchWithOptions(ctx context.Context, id WatchID, key, end []byte, startRev int64, opts WatchOptions, fcs ...FilterFunc) (WatchID, error) {
 	// prevent wrong range where key >= end lexicographically
 	// watch request with 'WithFromKey' has empty-byte range end
 	if len(end) != 0 && bytes.Compare(key, end) != -1 {
@@ -135,7 +291,7 @@ func (ws *watchStream) Watch(ctx context.Context, id WatchID, key, end []byte, s
 		return -1, ErrWatcherDuplicateID
 	}
 
-	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, fcs...)
+	w, c := ws.watchable.watch(key, end, startRev, id, ws.ch, opts, fcs...)
 
 	span := trace.SpanFromContext(ctx)
 	ws.cancels[id] = func() {
@@ -175,6 +331,31 @@ func (ws *watchStream) Cancel(id WatchID) error {
 	return nil
 }
 
+func (ws *watchStream) Rewatch(id WatchID, startRev int64) error {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	ok = ok && !ws.closed
+	ws.mu.Unlock()
+
+	if !ok {
+		return ErrWatcherNotExist
+	}
+	return ws.watchable.rewatch(w, startRev)
+}
+
+func (ws *watchStream) Ack(id WatchID, rev int64) error {
+	ws.mu.Lock()
+	w, ok := ws.watchers[id]
+	ok = ok && !ws.closed
+	ws.mu.Unlock()
+
+	if !ok {
+		return ErrWatcherNotExist
+	}
+	ws.watchable.ack(w, rev)
+	return nil
+}
+
 func (ws *watchStream) Close() {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
@@ -34,6 +34,9 @@ type eventBatch struct {
 	revs int
 	// moreRev is first revision with more events following this batch
 	moreRev int64
+	// catchUp is set when the batch is read from the history for an unsynced
+	// watcher
+	catchUp bool
 }
 
 func (eb *eventBatch) add(ev *mvccpb.Event) {
@@ -63,6 +66,18 @@ func (eb *eventBatch) add(ev *mvccpb.Event) {
 	eb.evs = append(eb.evs, ev)
 }
 
+// limit drops the events after rev from the batch, so that the next batch
+// starts with them.
+func (eb *eventBatch) limit(rev int64) {
+	for i, ev := range eb.evs {
+		if ev.Kv.ModRevision > rev {
+			eb.moreRev = ev.Kv.ModRevision
+			eb.evs = eb.evs[:i]
+			return
+		}
+	}
+}
+
 type watcherBatch map[*watcher]*eventBatch
 
 func (wb watcherBatch) add(w *watcher, ev *mvccpb.Event) {
@@ -75,28 +90,65 @@ func (wb watcherBatch) add(w *watcher, ev *mvccpb.Event) {
 }
 
 // newWatcherBatch maps watchers to their matched events. It enables quick
-// events look up by watcher.
-func newWatcherBatch(wg *watcherGroup, evs []*mvccpb.Event) watcherBatch {
+// events look up by watcher. If unsynced is set, the events of watchers
+// asking for the latest event per key are coalesced.
+func newWatcherBatch(wg *watcherGroup, evs []*mvccpb.Event, unsynced bool) watcherBatch {
 	if len(wg.watchers) == 0 {
 		return nil
 	}
 
 	wb := make(watcherBatch)
+	var latest map[*watcher][]*mvccpb.Event
 	for _, ev := range evs {
 		for w := range wg.watcherSetByKey(string(ev.Kv.Key)) {
 			if ev.Kv.ModRevision >= w.minRev {
 				// don't double notify
-				wb.add(w, &mvccpb.Event{
+				nev := &mvccpb.Event{
 					Type:   ev.Type,
 					Kv:     ev.Kv,
 					PrevKv: ev.PrevKv,
-				})
+				}
+				if unsynced && w.latestPerKey {
+					if latest == nil {
+						latest = make(map[*watcher][]*mvccpb.Event)
+					}
+					latest[w] = append(latest[w], nev)
+					continue
+				}
+				wb.add(w, nev)
 			}
 		}
 	}
+	for w, wevs := range latest {
+		wb[w] = newLatestPerKeyBatch(wevs)
+	}
 	return wb
 }
 
+// newLatestPerKeyBatch returns a batch of the latest event of each key in the
+// revision-ordered evs, in revision order. The batch holds at most one event
+// per key, so it is not limited to watchBatchMaxRevs revisions.
+func newLatestPerKeyBatch(evs []*mvccpb.Event) *eventBatch {
+	seen := make(map[string]struct{}, len(evs))
+	n := len(evs)
+	for i := len(evs) - 1; i >= 0; i-- {
+		key := string(evs[i].Kv.Key)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		n--
+		evs[n] = evs[i]
+	}
+	eb := &eventBatch{evs: evs[n:]}
+	for i, ev := range eb.evs {
+		if i == 0 || ev.Kv.ModRevision > eb.evs[i-1].Kv.ModRevision {
+			eb.revs++
+		}
+	}
+	return eb
+}
+
 type watcherSet map[*watcher]struct{}
 
 func (w watcherSet) add(wa *watcher) {
@@ -293,3 +345,133 @@ func (wg *watcherGroup) watcherSetByKey(key string) watcherSet {
 	}
 	return ret
 }
+
+// shardedWatcherGroup spreads watchers over watcher groups so that watchers
+// in different shards can be added, removed and notified independently.
+// Single key watchers are sharded by key hash; range watchers may cover keys
+// of any shard, so they are all kept in the last shard.
+type shardedWatcherGroup []watcherGroup
+
+func newShardedWatcherGroup(keyShards int) shardedWatcherGroup {
+	sg := make(shardedWatcherGroup, keyShards+1)
+	for i := range sg {
+		sg[i] = newWatcherGroup()
+	}
+	return sg
+}
+
+// rangeShard returns the index of the shard holding the range watchers.
+func (sg shardedWatcherGroup) rangeShard() int { return len(sg) - 1 }
+
+// keyShard returns the index of the shard holding the watchers on key.
+func (sg shardedWatcherGroup) keyShard(key string) int {
+	// FNV-1a
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return int(h % uint32(len(sg)-1))
+}
+
+// shard returns the index of the shard holding the given watcher.
+func (sg shardedWatcherGroup) shard(wa *watcher) int {
+	if wa.end != nil {
+		return sg.rangeShard()
+	}
+	return sg.keyShard(string(wa.key))
+}
+
+func (sg shardedWatcherGroup) add(wa *watcher) { sg[sg.shard(wa)].add(wa) }
+
+func (sg shardedWatcherGroup) delete(wa *watcher) bool { return sg[sg.shard(wa)].delete(wa) }
+
+func (sg shardedWatcherGroup) has(wa *watcher) bool {
+	_, ok := sg[sg.shard(wa)].watchers[wa]
+	return ok
+}
+
+func (sg shardedWatcherGroup) contains(key string) bool {
+	return sg[sg.keyShard(key)].contains(key) || sg[sg.rangeShard()].contains(key)
+}
+
+func (sg shardedWatcherGroup) size() int {
+	n := 0
+	for i := range sg {
+		n += sg[i].size()
+	}
+	return n
+}
+
+func (sg shardedWatcherGroup) watcherSetByKey(key string) watcherSet {
+	wkeys := sg[sg.keyShard(key)].watcherSetByKey(key)
+	wranges := sg[sg.rangeShard()].watcherSetByKey(key)
+	switch {
+	case len(wranges) == 0:
+		return wkeys
+	case len(wkeys) == 0:
+		return wranges
+	}
+	ret := make(watcherSet)
+	ret.union(wkeys)
+	ret.union(wranges)
+	return ret
+}
+
+// choose selects up to maxWatchers watchers from all shards into a new
+// watcher group, and returns it along with the minimum revision to sync
+// them from. Compacted watchers are removed from their shards.
+func (sg shardedWatcherGroup) choose(maxWatchers int, curRev, compactRev int64) (*watcherGroup, int64) {
+	ret := newWatcherGroup()
+	var chosen []*watcher
+	for i := range sg {
+		for w := range sg[i].watchers {
+			if maxWatchers <= 0 {
+				break
+			}
+			maxWatchers--
+			ret.add(w)
+			chosen = append(chosen, w)
+		}
+	}
+	minRev := ret.chooseAll(curRev, compactRev)
+	for _, w := range chosen {
+		if w.compacted {
+			sg.delete(w)
+		}
+	}
+	return &ret, minRev
+}
+
+// eventsByShard groups events by the shards whose watchers may observe
+// them, and returns the indexes of those shards in ascending order.
+func (sg shardedWatcherGroup) eventsByShard(evs []*mvccpb.Event) (shards []int, shardEvs [][]*mvccpb.Event) {
+	shardEvs = make([][]*mvccpb.Event, len(sg))
+	for _, ev := range evs {
+		i := sg.keyShard(string(ev.Kv.Key))
+		shardEvs[i] = append(shardEvs[i], ev)
+	}
+	shardEvs[sg.rangeShard()] = evs
+	for i := range shardEvs {
+		if len(shardEvs[i]) != 0 {
+			shards = append(shards, i)
+		}
+	}
+	return shards, shardEvs
+}
+
+// watcherShards returns the indexes of the shards holding the given
+// watchers in ascending order.
+func (sg shardedWatcherGroup) watcherShards(watchers map[WatchID]*watcher) []int {
+	marked := make([]bool, len(sg))
+	for _, w := range watchers {
+		marked[sg.shard(w)] = true
+	}
+	var shards []int
+	for i := range marked {
+		if marked[i] {
+			shards = append(shards, i)
+		}
+	}
+	return shards
+}
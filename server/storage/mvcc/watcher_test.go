@@ -24,11 +24,13 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap/zaptest"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
 	"go.etcd.io/etcd/server/v3/lease"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 )
@@ -508,3 +510,378 @@ func TestWatcherWatchWithFilter(t *testing.T) {
 		t.Fatal("failed to receive delete request")
 	}
 }
+
+// TestNewFilterPipeline ensures that the filter pipeline evaluates the stages
+// in the requested order and stops at the first filter that matches.
+func TestNewFilterPipeline(t *testing.T) {
+	var calls []string
+	filter := func(name string, stage FilterStage, match bool) Filter {
+		return Filter{Stage: stage, Func: func(*mvccpb.Event) bool {
+			calls = append(calls, name)
+			return match
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		order   FilterOrder
+		filters []Filter
+
+		wantFiltered bool
+		wantCalls    []string
+	}{
+		{
+			name:    "no filters",
+			order:   TypeFiltersFirst,
+			filters: nil,
+		},
+		{
+			name:  "type filters first",
+			order: TypeFiltersFirst,
+			filters: []Filter{
+				filter("value", FilterStageValue, false),
+				filter("type1", FilterStageType, false),
+				filter("type2", FilterStageType, false),
+			},
+			wantCalls: []string{"type1", "type2", "value"},
+		},
+		{
+			name:  "value filters first",
+			order: ValueFiltersFirst,
+			filters: []Filter{
+				filter("type1", FilterStageType, false),
+				filter("value", FilterStageValue, false),
+				filter("type2", FilterStageType, false),
+			},
+			wantCalls: []string{"value", "type1", "type2"},
+		},
+		{
+			name:  "type filter match skips value filters",
+			order: TypeFiltersFirst,
+			filters: []Filter{
+				filter("value", FilterStageValue, true),
+				filter("type", FilterStageType, true),
+			},
+			wantFiltered: true,
+			wantCalls:    []string{"type"},
+		},
+		{
+			name:  "value filter match skips type filters",
+			order: ValueFiltersFirst,
+			filters: []Filter{
+				filter("value", FilterStageValue, true),
+				filter("type", FilterStageType, true),
+			},
+			wantFiltered: true,
+			wantCalls:    []string{"value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			fc := NewFilterPipeline(tt.order, tt.filters...)
+			if len(tt.filters) == 0 {
+				if fc != nil {
+					t.Fatal("expected nil pipeline without filters")
+				}
+				return
+			}
+			if got := fc(&mvccpb.Event{}); got != tt.wantFiltered {
+				t.Errorf("filtered = %v, want %v", got, tt.wantFiltered)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// TestFilterPipelineFilteredMetric ensures that the events filtered out by a
+// pipeline are counted under the name of the filter that matched them.
+func TestFilterPipelineFilteredMetric(t *testing.T) {
+	isPut := func(e *mvccpb.Event) bool { return e.Type == mvccpb.Event_PUT }
+	isDelete := func(e *mvccpb.Event) bool { return e.Type == mvccpb.Event_DELETE }
+	fc := NewFilterPipeline(TypeFiltersFirst,
+		Filter{Stage: FilterStageType, Func: isPut, Name: "noput"},
+		Filter{Stage: FilterStageValue, Func: isDelete},
+	)
+
+	noput := testutil.ToFloat64(watchEventsFilteredCounter.WithLabelValues("noput"))
+	other := testutil.ToFloat64(watchEventsFilteredCounter.WithLabelValues("other"))
+
+	for _, typ := range []mvccpb.Event_EventType{mvccpb.Event_PUT, mvccpb.Event_PUT, mvccpb.Event_DELETE} {
+		if !fc(&mvccpb.Event{Type: typ}) {
+			t.Fatalf("expected %v event to be filtered out", typ)
+		}
+	}
+
+	if got := testutil.ToFloat64(watchEventsFilteredCounter.WithLabelValues("noput")); got != noput+2 {
+		t.Errorf("noput filtered = %v, want %v", got, noput+2)
+	}
+	if got := testutil.ToFloat64(watchEventsFilteredCounter.WithLabelValues("other")); got != other+1 {
+		t.Errorf("other filtered = %v, want %v", got, other+1)
+	}
+}
+
+// TestWatcherWatchSampleEveryN ensures that a sampled watcher receives every
+// Nth PUT event of each key, and every DELETE event, which restarts the count
+// of its key.
+func TestWatcherWatchSampleEveryN(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	_, err := w.WatchWithOptions(t.Context(), 0, []byte("a"), []byte("c"), 0, WatchOptions{SampleEveryN: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		s.Put([]byte("a"), []byte(fmt.Sprintf("a%d", i)), lease.NoLease)
+		s.Put([]byte("b"), []byte(fmt.Sprintf("b%d", i)), lease.NoLease)
+	}
+	s.DeleteRange([]byte("a"), nil)
+	s.Put([]byte("a"), []byte("a5"), lease.NoLease)
+	s.Put([]byte("a"), []byte("a6"), lease.NoLease)
+
+	type event struct {
+		typ   mvccpb.Event_EventType
+		key   string
+		value string
+	}
+	want := []event{
+		{typ: mvccpb.Event_PUT, key: "a", value: "a1"},
+		{typ: mvccpb.Event_PUT, key: "b", value: "b1"},
+		{typ: mvccpb.Event_PUT, key: "a", value: "a4"},
+		{typ: mvccpb.Event_PUT, key: "b", value: "b4"},
+		{typ: mvccpb.Event_DELETE, key: "a"},
+		{typ: mvccpb.Event_PUT, key: "a", value: "a5"},
+	}
+	var got []event
+	for len(got) < len(want) {
+		select {
+		case resp := <-w.Chan():
+			for _, ev := range resp.Events {
+				got = append(got, event{typ: ev.Type, key: string(ev.Kv.Key), value: string(ev.Kv.Value)})
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("failed to receive events, got %v", got)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected response %v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestWatcherSampleFailedSend ensures that a response that fails to send
+// does not advance the sample counts, so that it is sampled the same way
+// when it is sent again.
+func TestWatcherSampleFailedSend(t *testing.T) {
+	ch := make(chan WatchResponse)
+	w := &watcher{ch: ch, sampleEveryN: 2}
+	evs := []*mvccpb.Event{
+		{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 2}},
+		{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 3}},
+		{Type: mvccpb.Event_PUT, Kv: &mvccpb.KeyValue{Key: []byte("a"), ModRevision: 4}},
+	}
+	if w.send(WatchResponse{Events: evs, Revision: 4}) {
+		t.Fatal("expected send to fail on a blocked channel")
+	}
+	if len(w.sampleCounts) != 0 {
+		t.Fatalf("sample counts = %v, want none", w.sampleCounts)
+	}
+
+	ch2 := make(chan WatchResponse, 1)
+	w.ch = ch2
+	if !w.send(WatchResponse{Events: evs, Revision: 4}) {
+		t.Fatal("failed to send")
+	}
+	resp := <-ch2
+	var revs []int64
+	for _, ev := range resp.Events {
+		revs = append(revs, ev.Kv.ModRevision)
+	}
+	if !reflect.DeepEqual(revs, []int64{2, 4}) {
+		t.Errorf("revisions = %v, want [2 4]", revs)
+	}
+	if w.sampleCounts["a"] != 3 {
+		t.Errorf("sample count = %d, want 3", w.sampleCounts["a"])
+	}
+}
+
+// TestWatcherWatchCollapseDeletes ensures that a synced watcher collapsing
+// deletes receives a single DELETE_RANGE event for each range deletion,
+// clipped to its range, while the deletes of single keys are not collapsed.
+func TestWatcherWatchCollapseDeletes(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	type event struct {
+		typ      mvccpb.Event_EventType
+		key      string
+		rangeEnd string
+		deleted  int64
+		rev      int64
+	}
+	watch := func(key, end []byte, opts WatchOptions) WatchStream {
+		w := s.NewWatchStream()
+		if _, err := w.WatchWithOptions(t.Context(), 0, key, end, 0, opts, func(ev *mvccpb.Event) bool {
+			return ev.Type == mvccpb.Event_PUT
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+	recv := func(w WatchStream, n int) []event {
+		var got []event
+		for len(got) < n {
+			select {
+			case resp := <-w.Chan():
+				for _, ev := range resp.Events {
+					got = append(got, event{ev.Type, string(ev.Kv.Key), string(ev.RangeEnd), ev.Deleted, ev.Kv.ModRevision})
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("failed to receive events, got %v", got)
+			}
+		}
+		return got
+	}
+
+	collapsed := watch([]byte("b"), []byte("d"), WatchOptions{CollapseDeletes: true})
+	defer collapsed.Close()
+	fromKey := watch([]byte("c"), []byte{}, WatchOptions{CollapseDeletes: true})
+	defer fromKey.Close()
+	single := watch([]byte("b"), nil, WatchOptions{CollapseDeletes: true})
+	defer single.Close()
+	plain := watch([]byte("b"), []byte("d"), WatchOptions{})
+	defer plain.Close()
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		s.Put([]byte(k), nil, lease.NoLease)
+	}
+	// revision 6
+	s.DeleteRange([]byte("a"), []byte("e"))
+	for _, k := range []string{"b", "c"} {
+		s.Put([]byte(k), nil, lease.NoLease)
+	}
+	// revision 9
+	txn := s.Write(traceutil.TODO())
+	txn.DeleteRange([]byte("b"), nil)
+	txn.DeleteRange([]byte("c"), []byte{})
+	txn.End()
+
+	tests := []struct {
+		name string
+		w    WatchStream
+		want []event
+	}{
+		{
+			name: "range",
+			w:    collapsed,
+			want: []event{
+				{typ: mvccpb.Event_DELETE_RANGE, key: "b", rangeEnd: "d", deleted: 2, rev: 6},
+				{typ: mvccpb.Event_DELETE, key: "b", rev: 9},
+				{typ: mvccpb.Event_DELETE_RANGE, key: "c", rangeEnd: "d", deleted: 1, rev: 9},
+			},
+		},
+		{
+			name: "from key",
+			w:    fromKey,
+			want: []event{
+				{typ: mvccpb.Event_DELETE_RANGE, key: "c", rangeEnd: "e", deleted: 2, rev: 6},
+				{typ: mvccpb.Event_DELETE_RANGE, key: "c", rangeEnd: "\x00", deleted: 1, rev: 9},
+			},
+		},
+		{
+			name: "single key",
+			w:    single,
+			want: []event{
+				{typ: mvccpb.Event_DELETE, key: "b", rev: 6},
+				{typ: mvccpb.Event_DELETE, key: "b", rev: 9},
+			},
+		},
+		{
+			name: "not collapsed",
+			w:    plain,
+			want: []event{
+				{typ: mvccpb.Event_DELETE, key: "b", rev: 6},
+				{typ: mvccpb.Event_DELETE, key: "c", rev: 6},
+				{typ: mvccpb.Event_DELETE, key: "b", rev: 9},
+				{typ: mvccpb.Event_DELETE, key: "c", rev: 9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recv(tt.w, len(tt.want)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWatcherWatchCondition ensures that a watcher with a condition only
+// receives the events that flip its result, along with the result.
+func TestWatcherWatchCondition(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := New(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	w := s.NewWatchStream()
+	defer w.Close()
+
+	isMe := func(kv *mvccpb.KeyValue) bool { return kv != nil && string(kv.Value) == "me" }
+	_, err := w.WatchWithOptions(t.Context(), 0, []byte("leader"), nil, 0, WatchOptions{Condition: isMe})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []string{"other", "me", "me", "other"} {
+		s.Put([]byte("leader"), []byte(v), lease.NoLease)
+	}
+	s.DeleteRange([]byte("leader"), nil)
+	s.Put([]byte("leader"), []byte("me"), lease.NoLease)
+	s.DeleteRange([]byte("leader"), nil)
+
+	type event struct {
+		typ    mvccpb.Event_EventType
+		value  string
+		result bool
+	}
+	want := []event{
+		{typ: mvccpb.Event_PUT, value: "me", result: true},
+		{typ: mvccpb.Event_PUT, value: "other", result: false},
+		{typ: mvccpb.Event_PUT, value: "me", result: true},
+		{typ: mvccpb.Event_DELETE, result: false},
+	}
+	var got []event
+	for len(got) < len(want) {
+		select {
+		case resp := <-w.Chan():
+			if len(resp.Events) != 1 {
+				t.Fatalf("len(events) = %d, want 1", len(resp.Events))
+			}
+			ev := resp.Events[0]
+			got = append(got, event{typ: ev.Type, value: string(ev.Kv.Value), result: resp.ConditionResult})
+		case <-time.After(5 * time.Second):
+			t.Fatalf("failed to receive events, got %v", got)
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	select {
+	case resp := <-w.Chan():
+		t.Fatalf("unexpected response %v", resp)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
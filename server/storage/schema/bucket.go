@@ -83,6 +83,8 @@ var (
 	ClusterDowngradeKeyName      = []byte("downgrade")
 	// Since v3.6
 	MetaStorageVersionName = []byte("storageVersion")
+	// Since v3.8
+	MetaValueEncodingMigrationName = []byte("valueEncodingMigration")
 	// Before adding new meta key please update server/etcdserver/version
 )
 
@@ -91,8 +93,10 @@ func DefaultIgnores(bucket, key []byte) bool {
 	// consistent index & term might be changed due to v2 internal sync, which
 	// is not controllable by the user.
 	// storage version might change after wal snapshot and is not controller by user.
+	// value encoding migration progresses independently on each member.
 	return bytes.Equal(bucket, Meta.Name()) &&
-		(bytes.Equal(key, MetaTermKeyName) || bytes.Equal(key, MetaConsistentIndexKeyName) || bytes.Equal(key, MetaStorageVersionName))
+		(bytes.Equal(key, MetaTermKeyName) || bytes.Equal(key, MetaConsistentIndexKeyName) || bytes.Equal(key, MetaStorageVersionName) ||
+			bytes.Equal(key, MetaValueEncodingMigrationName))
 }
 
 func BackendMemberKey(id types.ID) []byte {
@@ -17,6 +17,8 @@ package wal
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"go.uber.org/zap"
@@ -110,3 +112,39 @@ func parseWALName(str string) (seq, index uint64, err error) {
 func walName(seq, index uint64) string {
 	return fmt.Sprintf("%016x-%016x.wal", seq, index)
 }
+
+// Usage is the disk usage of the WAL files of a directory.
+type Usage struct {
+	Files int64
+	Bytes int64
+	// OldestIndex is the raft index of the first entry of the oldest file.
+	OldestIndex uint64
+}
+
+// DirUsage returns the disk usage of the WAL files in the given directory.
+func DirUsage(lg *zap.Logger, dirpath string) (Usage, error) {
+	names, err := readWALNames(lg, dirpath)
+	if err != nil {
+		if errors.Is(err, ErrFileNotFound) {
+			return Usage{}, nil
+		}
+		return Usage{}, err
+	}
+	var u Usage
+	for _, name := range names {
+		fi, err := os.Stat(filepath.Join(dirpath, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// purged in the meantime
+				continue
+			}
+			return Usage{}, err
+		}
+		u.Files++
+		u.Bytes += fi.Size()
+		if u.Files == 1 {
+			_, u.OldestIndex, _ = parseWALName(name)
+		}
+	}
+	return u, nil
+}
@@ -513,6 +513,22 @@ func TestRecover(t *testing.T) {
 	}
 }
 
+func TestDirUsage(t *testing.T) {
+	p := t.TempDir()
+	u, err := DirUsage(zaptest.NewLogger(t), p)
+	require.NoError(t, err)
+	require.Equal(t, Usage{}, u)
+
+	for i, size := range []int{10, 20, 30} {
+		require.NoError(t, os.WriteFile(filepath.Join(p, walName(uint64(i), uint64(i+1)*0x1000)), make([]byte, size), 0o600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(p, "foo.tmp"), make([]byte, 100), 0o600))
+
+	u, err = DirUsage(zaptest.NewLogger(t), p)
+	require.NoError(t, err)
+	require.Equal(t, Usage{Files: 3, Bytes: 60, OldestIndex: 0x1000}, u)
+}
+
 func TestSearchIndex(t *testing.T) {
 	tests := []struct {
 		names []string
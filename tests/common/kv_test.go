@@ -182,6 +182,8 @@ func testKVGet(t *testing.T, stream bool) {
 								(*etcdserverpb.RangeResponse)(tt.wantResponse),
 								(*etcdserverpb.RangeResponse)(resp),
 								protocmp.Transform(),
+								// the cursor of the next page is opaque
+								protocmp.IgnoreFields(&etcdserverpb.RangeResponse{}, "next_cursor"),
 							),
 							"-want, +got")
 					})
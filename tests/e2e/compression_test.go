@@ -0,0 +1,91 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+// TestCompressionZstdFallback ensures that a client compressing with zstd
+// works against servers with and without zstd support, falling back to
+// uncompressed calls on the first unary or stream call failing because of the
+// codec.
+func TestCompressionZstdFallback(t *testing.T) {
+	e2e.BeforeTest(t)
+
+	for _, tc := range []struct {
+		name         string
+		serverZstd   bool
+		wantFallback bool
+	}{
+		{name: "ServerWithZstd", serverZstd: true},
+		{name: "ServerWithoutZstd", wantFallback: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			epc, err := e2e.NewEtcdProcessCluster(t.Context(), t,
+				e2e.WithClusterSize(1),
+				e2e.WithGRPCZstdCompression(tc.serverZstd),
+			)
+			require.NoError(t, err)
+			defer epc.Close()
+
+			newClient := func() (*clientv3.Client, *observer.ObservedLogs) {
+				core, logs := observer.New(zap.WarnLevel)
+				cli, err := clientv3.New(clientv3.Config{
+					Endpoints:        epc.EndpointsGRPC(),
+					DialTimeout:      5 * time.Second,
+					CompressionCodec: clientv3.CompressionZstd,
+					Logger:           zap.New(core),
+				})
+				require.NoError(t, err)
+				t.Cleanup(func() { cli.Close() })
+				return cli, logs
+			}
+			val := strings.Repeat("compressible ", 10*1024)
+
+			// the first call is a stream
+			cli, logs := newClient()
+			wch := cli.Watch(t.Context(), "foo")
+			_, err = cli.Put(t.Context(), "foo", val)
+			require.NoError(t, err)
+			select {
+			case wresp := <-wch:
+				require.NoError(t, wresp.Err())
+				require.Len(t, wresp.Events, 1)
+				require.Equal(t, val, string(wresp.Events[0].Kv.Value))
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for watch event")
+			}
+			require.Equal(t, tc.wantFallback, logs.FilterMessageSnippet("falling back to uncompressed").Len() == 1)
+
+			// the first call is unary
+			cli, logs = newClient()
+			resp, err := cli.Get(t.Context(), "foo")
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			require.Equal(t, val, string(resp.Kvs[0].Value))
+			require.Equal(t, tc.wantFallback, logs.FilterMessageSnippet("falling back to uncompressed").Len() == 1)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"testing"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3CheckWatch(t *testing.T) { testCtl(t, checkWatchTest) }
+
+func checkWatchTest(cx ctlCtx) {
+	cmdArgs := append(cx.PrefixArgs(), "check", "watch", "--keys", "3", "--writers", "4", "--duration", "2s")
+	lines := []expect.ExpectedResponse{
+		{Value: "Start watch check for 2s [3 keys, 4 concurrent writers]."},
+		{Value: "PASS: Observed all"},
+	}
+	if err := e2e.SpawnWithExpects(cmdArgs, cx.envMap, lines...); err != nil {
+		cx.t.Fatalf("checkWatchTest error (%v)", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/e2e"
+)
+
+func TestCtlV3EndpointHealthTimings(t *testing.T) { testCtl(t, endpointHealthTimingsTest) }
+
+func endpointHealthTimingsTest(cx ctlCtx) {
+	cmdArgs := append(cx.PrefixArgs(), "endpoint", "health", "--check-watch", "--write-out=json")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{
+		Value:         `"health":true,"took":"[^"]+","timings":\{"dial":"[^"]+","get":"[^"]+","alarm_list":"[^"]+","watch":"[^"]+"\}`,
+		IsRegularExpr: true,
+	}))
+
+	// the human output is unchanged
+	cmdArgs = append(cx.PrefixArgs(), "endpoint", "health")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "is healthy: successfully committed proposal: took = "}))
+}
@@ -42,6 +42,9 @@ func TestCtlV3GetRev(t *testing.T)                { testCtl(t, getRevTest) }
 func TestCtlV3GetMinMaxCreateModRev(t *testing.T) { testCtl(t, getMinMaxCreateModRevTest) }
 func TestCtlV3GetKeysOnly(t *testing.T)           { testCtl(t, getKeysOnlyTest) }
 func TestCtlV3GetCountOnly(t *testing.T)          { testCtl(t, getCountOnlyTest) }
+func TestCtlV3GetSummary(t *testing.T)            { testCtl(t, getSummaryTest) }
+func TestCtlV3GetShowHeader(t *testing.T)         { testCtl(t, getShowHeaderTest) }
+func TestCtlV3GetCompactedHint(t *testing.T)      { testCtl(t, getCompactedHintTest) }
 
 func TestCtlV3DelTimeout(t *testing.T) { testCtl(t, delTest, withDefaultDialTimeout()) }
 
@@ -285,6 +288,50 @@ func getCountOnlyTest(cx ctlCtx) {
 	require.NotContains(cx.t, lines, "\"Count\" : 3")
 }
 
+func getSummaryTest(cx ctlCtx) {
+	leaseID, err := ctlV3LeaseGrant(cx, 100)
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, ctlV3Put(cx, "key1", "val1", leaseID))
+	require.NoError(cx.t, ctlV3Put(cx, "key2", "value2", leaseID))
+	require.NoError(cx.t, ctlV3Put(cx, "key3", "v3", ""))
+	require.NoError(cx.t, ctlV3Put(cx, "other", "val", ""))
+
+	cmdArgs := append(cx.PrefixArgs(), "get", "key", "--prefix", "--summary")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: "key1"},
+		expect.ExpectedResponse{Value: "val1"},
+		expect.ExpectedResponse{Value: "key3"},
+		expect.ExpectedResponse{Value: "count: 3"},
+		expect.ExpectedResponse{Value: "total key bytes: 12"},
+		expect.ExpectedResponse{Value: "total value bytes: 12"},
+		expect.ExpectedResponse{Value: "leases: 1"},
+	))
+
+	cmdArgs = append(cx.PrefixArgs(), "get", "key", "--prefix", "--summary", "--count-only", "--write-out=json")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: `"count":3,"total_key_bytes":12,"total_value_bytes":12`},
+	))
+
+	cmdArgs = append(cx.PrefixArgs(), "get", "key", "--prefix", "--summary", "--limit", "1")
+	require.ErrorContains(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap), "`--summary` and `--limit` cannot be set at the same time")
+}
+
+func getShowHeaderTest(cx ctlCtx) {
+	require.NoError(cx.t, ctlV3Put(cx, "key", "val", ""))
+	cmdArgs := append(cx.PrefixArgs(), "get", "key", "--show-header")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: "revision=2 raft_term="},
+		expect.ExpectedResponse{Value: "key"},
+		expect.ExpectedResponse{Value: "val"},
+	))
+
+	cmdArgs = append(cx.PrefixArgs(), "compaction", "2", "--write-out=json")
+	require.NoError(cx.t, e2e.SpawnWithExpects(cmdArgs, cx.envMap,
+		expect.ExpectedResponse{Value: `"revision":2,"raft_term":`},
+		expect.ExpectedResponse{Value: `"compact_revision":2}`},
+	))
+}
+
 func delTest(cx ctlCtx) {
 	tests := []struct {
 		puts []kv
@@ -366,6 +413,36 @@ type kv struct {
 	key, val string
 }
 
+func getCompactedHintTest(cx ctlCtx) {
+	for i := 0; i < 3; i++ {
+		require.NoError(cx.t, ctlV3Put(cx, "key", fmt.Sprint(i), ""))
+	}
+	// revisions 2 to 4 hold the puts above
+	require.NoError(cx.t, e2e.SpawnWithExpects(append(cx.PrefixArgs(), "compaction", "4"), cx.envMap, expect.ExpectedResponse{Value: "compacted revision 4"}))
+
+	cmdArgs := append(cx.PrefixArgs(), "get", "key", "--rev", "2")
+	ctlV3ExpectError(cx, cmdArgs,
+		"Error: etcdserver: mvcc: required revision has been compacted",
+		"Hint: the requested revision has been compacted; use a newer --rev; current compact revision is 4",
+	)
+
+	cmdArgs = append(cx.PrefixArgs(), "get", "key", "--rev", "2", "-w", "json")
+	ctlV3ExpectError(cx, cmdArgs, `"code":"compacted"`)
+}
+
+// ctlV3ExpectError runs a command that is expected to fail with
+// cobrautl.ExitError after printing the given lines.
+func ctlV3ExpectError(cx ctlCtx, args []string, lines ...string) {
+	proc, err := e2e.SpawnCmd(args, cx.envMap)
+	require.NoError(cx.t, err)
+	defer proc.Close()
+	for _, l := range lines {
+		_, err = proc.Expect(l)
+		require.NoError(cx.t, err)
+	}
+	require.ErrorContains(cx.t, proc.Close(), "unexpected exit code [1]")
+}
+
 func ctlV3Get(cx ctlCtx, args []string, kvs ...kv) error {
 	cmdArgs := append(cx.PrefixArgs(), "get")
 	cmdArgs = append(cmdArgs, args...)
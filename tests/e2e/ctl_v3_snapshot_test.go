@@ -16,6 +16,7 @@ package e2e
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -70,6 +71,60 @@ func snapshotTest(cx ctlCtx) {
 	}
 }
 
+func TestCtlV3SnapshotRateLimit(t *testing.T) { testCtl(t, snapshotRateLimitTest) }
+
+func snapshotRateLimitTest(cx ctlCtx) {
+	maintenanceInitKeys(cx)
+
+	fpath := filepath.Join(cx.t.TempDir(), "snapshot")
+	cmdArgs := append(cx.PrefixArgs(), "snapshot", "save", "--rate-limit", "1000000", fpath)
+	require.NoError(cx.t, e2e.SpawnWithExpectWithEnv(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: fmt.Sprintf("Snapshot saved at %s", fpath)}))
+
+	st, err := getSnapshotStatus(cx, fpath)
+	require.NoError(cx.t, err)
+	assert.Equal(cx.t, int64(4), st.Revision)
+
+	serr := e2e.SpawnWithExpectWithEnv(append(cx.PrefixArgs(), "snapshot", "save", "--rate-limit", "-1", fpath), cx.envMap,
+		expect.ExpectedResponse{Value: "invalid --rate-limit -1"})
+	require.ErrorContains(cx.t, serr, "invalid --rate-limit -1")
+}
+
+func TestCtlV3SnapshotSaveToStdout(t *testing.T) { testCtl(t, snapshotSaveToStdoutTest) }
+
+func snapshotSaveToStdoutTest(cx ctlCtx) {
+	maintenanceInitKeys(cx)
+
+	// stdout of the spawned process is a terminal, which must be refused.
+	serr := e2e.SpawnWithExpectWithEnv(append(cx.PrefixArgs(), "snapshot", "save", "-"), cx.envMap,
+		expect.ExpectedResponse{Value: "refusing to write snapshot to a terminal"})
+	require.ErrorContains(cx.t, serr, "refusing to write snapshot to a terminal")
+
+	fpath := filepath.Join(cx.t.TempDir(), "snapshot")
+	require.NoError(cx.t, ctlV3SnapshotSave(cx, fpath))
+
+	// Every snapshot request forces a backend commit, so the raw bytes of
+	// two snapshots differ; compare what was piped through sha256sum with
+	// what was teed to disk, and the teed snapshot with the file-based one.
+	spath := filepath.Join(cx.t.TempDir(), "snapshot-stdout")
+	pipeline := strings.Join(append(cx.PrefixArgs(), "snapshot", "save", "-"), " ") + " | tee " + spath + " | sha256sum"
+	lines, err := e2e.SpawnWithExpectLines(context.TODO(), []string{"sh", "-c", pipeline}, cx.envMap,
+		expect.ExpectedResponse{Value: "Snapshot streamed to stdout, sha256 verified"},
+		expect.ExpectedResponse{Value: "  -"},
+	)
+	require.NoError(cx.t, err)
+	data, err := os.ReadFile(spath)
+	require.NoError(cx.t, err)
+	assert.Equal(cx.t, fmt.Sprintf("%x  -", sha256.Sum256(data)), strings.TrimSpace(lines[len(lines)-1]))
+
+	want, err := getSnapshotStatus(cx, fpath)
+	require.NoError(cx.t, err)
+	got, err := getSnapshotStatus(cx, spath)
+	require.NoError(cx.t, err)
+	assert.Equal(cx.t, want.Hash, got.Hash)
+	assert.Equal(cx.t, want.Revision, got.Revision)
+	assert.Equal(cx.t, want.TotalKey, got.TotalKey)
+}
+
 func TestCtlV3SnapshotCorrupt(t *testing.T) { testCtl(t, snapshotCorruptTest) }
 
 func snapshotCorruptTest(cx ctlCtx) {
@@ -412,6 +467,85 @@ func TestRestoreCompactionRevBump(t *testing.T) {
 	require.Equal(t, []testutils.KV{{Key: "foo4", Val: "val4"}}, watchRes)
 }
 
+// TestRestoreBundle ensures that a snapshot restored with --bundle into the
+// data directories of all members yields a cluster that can be started.
+func TestRestoreBundle(t *testing.T) {
+	e2e.BeforeTest(t)
+
+	epc, err := e2e.NewEtcdProcessCluster(t.Context(), t,
+		e2e.WithClusterSize(3),
+		e2e.WithKeepDataDir(true),
+	)
+	require.NoErrorf(t, err, "could not start etcd process cluster")
+	defer func() {
+		if errC := epc.Close(); errC != nil {
+			t.Fatalf("error closing etcd processes (%v)", errC)
+		}
+	}()
+
+	ctl := epc.Etcdctl()
+	kvs := []testutils.KV{{Key: "foo1", Val: "val1"}, {Key: "foo2", Val: "val2"}, {Key: "foo3", Val: "val3"}}
+	for i := range kvs {
+		_, err = ctl.Put(t.Context(), kvs[i].Key, kvs[i].Val, config.PutOptions{})
+		require.NoError(t, err)
+	}
+
+	fpath := filepath.Join(t.TempDir(), "test.snapshot")
+	t.Log("etcdctl saving snapshot...")
+	cmdPrefix := []string{e2e.BinPath.Etcdctl, "--endpoints", epc.EndpointsGRPC()[0]}
+	require.NoError(t, e2e.SpawnWithExpects(append(cmdPrefix, "snapshot", "save", fpath), nil, expect.ExpectedResponse{Value: fmt.Sprintf("Snapshot saved at %s", fpath)}))
+
+	t.Log("Stopping the original cluster...")
+	require.NoError(t, epc.Stop())
+
+	restoreDir := t.TempDir()
+	var bundle strings.Builder
+	bundle.WriteString("initial-cluster-token: etcd-cluster-restored\nmembers:\n")
+	var expected []expect.ExpectedResponse
+	for i, proc := range epc.Procs {
+		dataDir := filepath.Join(restoreDir, fmt.Sprintf("member%d", i))
+		fmt.Fprintf(&bundle, "- name: %s\n  peer-urls: [%q]\n  data-dir: %s\n", proc.Config().Name, proc.Config().PeerURL.String(), dataDir)
+		proc.Config().DataDirPath = dataDir
+		require.NoError(t, e2e.PatchArgs(proc.Config().Args, "data-dir", dataDir))
+		expected = append(expected, expect.ExpectedResponse{Value: fmt.Sprintf("%s, ", proc.Config().Name)})
+	}
+	bundlePath := filepath.Join(restoreDir, "bundle.yaml")
+	require.NoError(t, os.WriteFile(bundlePath, []byte(bundle.String()), 0o600))
+
+	t.Log("etcdutl restoring the snapshot from bundle...")
+	lines, err := e2e.SpawnWithExpectLines(t.Context(), []string{
+		e2e.BinPath.Etcdutl, "snapshot", "restore", fpath, "--bundle", bundlePath,
+	}, nil, expected...)
+	require.NoError(t, err)
+	restoredIDs := make(map[string]bool)
+	for _, line := range lines {
+		fields := strings.Split(strings.TrimSpace(line), ", ")
+		require.Lenf(t, fields, 6, "unexpected restore output %q", line)
+		require.Equal(t, "verified", fields[5])
+		restoredIDs[fields[1]] = true
+	}
+
+	t.Log("Restarting the cluster using the restored data directories...")
+	require.NoError(t, epc.Restart(t.Context()))
+
+	members, err := ctl.MemberList(t.Context(), false)
+	require.NoError(t, err)
+	require.Len(t, members.Members, len(epc.Procs))
+	for _, m := range members.Members {
+		require.Truef(t, restoredIDs[fmt.Sprintf("%x", m.ID)], "member %x was not restored from bundle, restored %v", m.ID, restoredIDs)
+	}
+
+	t.Log("Ensuring every restored member has the correct data...")
+	for _, proc := range epc.Procs {
+		for i := range kvs {
+			v, gerr := proc.Etcdctl().Get(t.Context(), kvs[i].Key, config.GetOptions{Serializable: true})
+			require.NoError(t, gerr)
+			require.Len(t, v.Kvs, 1)
+			require.Equal(t, kvs[i].Val, string(v.Kvs[0].Value))
+		}
+	}
+}
+
 func hasKVs(t *testing.T, ctl *e2e.EtcdctlV3, kvs []testutils.KV, currentRev int, baseRev int) {
 	for i := range kvs {
 		v, err := ctl.Get(t.Context(), kvs[i].Key, config.GetOptions{})
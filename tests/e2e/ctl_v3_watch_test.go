@@ -15,10 +15,16 @@
 package e2e
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/pkg/v3/expect"
+	"go.etcd.io/etcd/tests/v3/framework/config"
 	"go.etcd.io/etcd/tests/v3/framework/e2e"
 )
 
@@ -169,6 +175,14 @@ func watchTest(cx ctlCtx) {
 			args: []string{"sample", "--rev", "1", "samplx", "--", "echo", "watch event received"},
 			wkv:  []kvExec{{key: "sample", val: "value", execOutput: "watch event received"}},
 		},
+		{ // watch 1 key with --prev-kv and ${ETCD_WATCH_PREV_VALUE}
+			puts: []kv{{"prevkey", "v1"}, {"prevkey", "v2"}},
+			args: []string{"prevkey", "--rev", "1", "--prev-kv", "--", "env"},
+			wkv: []kvExec{
+				{key: "prevkey", val: "v1"},
+				{key: "prevkey", val: "v2", execOutput: `ETCD_WATCH_PREV_VALUE="v1"`},
+			},
+		},
 		{ // watch 3 keys by prefix, with env
 			puts:   []kv{{"key1", "val1"}, {"key2", "val2"}, {"key3", "val3"}},
 			envKey: "key",
@@ -221,3 +235,48 @@ func watchTest(cx ctlCtx) {
 		<-donec
 	}
 }
+
+func TestCtlV3WatchGap(t *testing.T) { testCtl(t, watchGapTest) }
+
+func watchGapTest(cx ctlCtx) {
+	for i := 0; i < 3; i++ {
+		require.NoError(cx.t, ctlV3Put(cx, "gapkey", fmt.Sprintf("v%d", i), ""))
+	}
+	_, err := cx.epc.Etcdctl().Compact(context.TODO(), 4, config.CompactOption{})
+	require.NoError(cx.t, err)
+
+	cmdArgs := append(cx.PrefixArgs(), "watch", "gapkey", "--rev", "2")
+	err = e2e.SpawnWithExpects(cmdArgs, cx.envMap, expect.ExpectedResponse{Value: "GAP last_revision=1 next_revision=4 resumed=false"})
+	require.ErrorContains(cx.t, err, "unexpected exit code [7]")
+
+	cmdArgs = append(cx.PrefixArgs(), "watch", "gapkey", "--rev", "2", "--resume-on-gap")
+	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
+	require.NoError(cx.t, err)
+	_, err = proc.Expect("GAP last_revision=1 next_revision=4 resumed=true")
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, ctlV3Put(cx, "gapkey", "after-gap", ""))
+	_, err = proc.Expect("after-gap")
+	require.NoError(cx.t, err)
+	require.NoError(cx.t, proc.Stop())
+}
+
+func TestCtlV3WatchFutureRev(t *testing.T) { testCtl(t, watchFutureRevTest) }
+
+func watchFutureRevTest(cx ctlCtx) {
+	require.NoError(cx.t, ctlV3Put(cx, "futurekey", "v1", ""))
+
+	// the put above is at revision 2
+	cmdArgs := append(cx.PrefixArgs(), "watch", "futurekey", "--rev", "4")
+	proc, err := e2e.SpawnCmd(cmdArgs, cx.envMap)
+	require.NoError(cx.t, err)
+	defer proc.Stop()
+	_, err = proc.Expect("waiting for revision 4, the current revision is 2")
+	require.NoError(cx.t, err)
+
+	// the event before the start revision is not sent
+	require.NoError(cx.t, ctlV3Put(cx, "futurekey", "before", ""))
+	require.NoError(cx.t, ctlV3Put(cx, "futurekey", "after", ""))
+	_, err = proc.Expect("after")
+	require.NoError(cx.t, err)
+	require.NotContains(cx.t, strings.Join(proc.Lines(), "\n"), "before")
+}
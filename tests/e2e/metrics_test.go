@@ -158,7 +158,9 @@ func TestNoMetricsMissing(t *testing.T) {
 			"etcd_debugging_mvcc_slow_watcher_total",
 			"etcd_debugging_mvcc_total_put_size_in_bytes",
 			"etcd_debugging_mvcc_watch_stream_total",
+			"etcd_debugging_mvcc_watcher_synced_total",
 			"etcd_debugging_mvcc_watcher_total",
+			"etcd_debugging_mvcc_watcher_unsynced_total",
 			"etcd_debugging_server_lease_expired_total",
 			"etcd_debugging_server_watch_send_loop_watch_stream_duration_seconds",
 			"etcd_debugging_server_watch_send_loop_watch_stream_duration_per_event_seconds",
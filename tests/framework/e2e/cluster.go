@@ -390,6 +390,10 @@ func WithExtensiveMetrics() EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.Metrics = "extensive" }
 }
 
+func WithGRPCZstdCompression(enabled bool) EPClusterOption {
+	return func(c *EtcdProcessClusterConfig) { c.ServerConfig.EnableGRPCZstdCompression = enabled }
+}
+
 func WithEnableDistributedTracing(addr string) EPClusterOption {
 	return func(c *EtcdProcessClusterConfig) {
 		c.ServerConfig.EnableDistributedTracing = true
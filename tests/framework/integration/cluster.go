@@ -54,6 +54,7 @@ import (
 	"go.etcd.io/etcd/server/v3/config"
 	"go.etcd.io/etcd/server/v3/embed"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/etcdhttp"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
@@ -64,6 +65,7 @@ import (
 	lockpb "go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	"go.etcd.io/etcd/server/v3/verify"
 	framecfg "go.etcd.io/etcd/tests/v3/framework/config"
 	"go.etcd.io/etcd/tests/v3/framework/testutils"
@@ -170,10 +172,22 @@ type ClusterConfig struct {
 	LeaseCheckpointPersist  bool
 
 	WatchProgressNotifyInterval time.Duration
+	WatchOldRevisionThreshold   int64
+	WatchOldRevisionReject      bool
+	EnableWatchValueSelector    bool
+	EnableWatchCompare          bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+	EnableGRPCZstdCompression   bool
+	AutoCompactionMode          string
+	AutoCompactionRetention     time.Duration
+	AutoMaintenance             automaintenance.Config
+	// EnableGRPCProxy creates for each member a client whose KV, Lease and
+	// Watcher go through an in-process gRPC proxy, returned by
+	// Cluster.ProxyClient, alongside the usual client.
+	EnableGRPCProxy bool
 }
 
 type Cluster struct {
@@ -283,10 +297,19 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			LeaseCheckpointInterval:     c.Cfg.LeaseCheckpointInterval,
 			LeaseCheckpointPersist:      c.Cfg.LeaseCheckpointPersist,
 			WatchProgressNotifyInterval: c.Cfg.WatchProgressNotifyInterval,
+			WatchOldRevisionThreshold:   c.Cfg.WatchOldRevisionThreshold,
+			WatchOldRevisionReject:      c.Cfg.WatchOldRevisionReject,
+			EnableWatchValueSelector:    c.Cfg.EnableWatchValueSelector,
+			EnableWatchCompare:          c.Cfg.EnableWatchCompare,
 			MaxLearners:                 c.Cfg.MaxLearners,
 			DisableStrictReconfigCheck:  c.Cfg.DisableStrictReconfigCheck,
 			CorruptCheckTime:            c.Cfg.CorruptCheckTime,
 			Metrics:                     c.Cfg.Metrics,
+			EnableGRPCZstdCompression:   c.Cfg.EnableGRPCZstdCompression,
+			AutoCompactionMode:          c.Cfg.AutoCompactionMode,
+			AutoCompactionRetention:     c.Cfg.AutoCompactionRetention,
+			AutoMaintenance:             c.Cfg.AutoMaintenance,
+			EnableGRPCProxy:             c.Cfg.EnableGRPCProxy,
 		})
 	return m
 }
@@ -358,6 +381,9 @@ func (c *Cluster) RemoveMember(t testutil.TB, cc *clientv3.Client, id uint64) er
 			newMembers = append(newMembers, m)
 		} else {
 			m.Client.Close()
+			if m.ProxyClient != nil {
+				m.ProxyClient.Close()
+			}
 			select {
 			case <-m.Server.StopNotify():
 				m.Terminate(t)
@@ -571,6 +597,9 @@ type Member struct {
 	ServerClient *clientv3.Client
 	// Client is a clientv3 that communicates via socket, either UNIX or TCP.
 	Client *clientv3.Client
+	// ProxyClient is a clientv3 whose KV, Lease and Watcher go through an
+	// in-process gRPC proxy to the member, if EnableGRPCProxy is set.
+	ProxyClient *clientv3.Client
 
 	KeepDataDirTerminate     bool
 	ClientMaxCallSendMsgSize int
@@ -578,6 +607,7 @@ type Member struct {
 	UseIP                    bool
 	UseBridge                bool
 	UseTCP                   bool
+	EnableGRPCProxy          bool
 
 	IsLearner bool
 	Closed    bool
@@ -613,10 +643,19 @@ type MemberConfig struct {
 	LeaseCheckpointInterval     time.Duration
 	LeaseCheckpointPersist      bool
 	WatchProgressNotifyInterval time.Duration
+	WatchOldRevisionThreshold   int64
+	WatchOldRevisionReject      bool
+	EnableWatchValueSelector    bool
+	EnableWatchCompare          bool
 	MaxLearners                 int
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+	EnableGRPCZstdCompression   bool
+	AutoCompactionMode          string
+	AutoCompactionRetention     time.Duration
+	AutoMaintenance             automaintenance.Config
+	EnableGRPCProxy             bool
 }
 
 // MustNewMember return an inited member with the given name. If peerTLS is
@@ -715,9 +754,13 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	m.UseIP = mcfg.UseIP
 	m.UseBridge = mcfg.UseBridge
 	m.UseTCP = mcfg.UseTCP
+	m.EnableGRPCProxy = mcfg.EnableGRPCProxy
 	m.LeaseCheckpointInterval = mcfg.LeaseCheckpointInterval
 
 	m.WatchProgressNotifyInterval = mcfg.WatchProgressNotifyInterval
+	m.WatchOldRevisionThreshold = mcfg.WatchOldRevisionThreshold
+	m.WatchOldRevisionReject = mcfg.WatchOldRevisionReject
+	m.WatchValueSelectorMaxCost = mvcc.DefaultValueSelectorMaxCost
 
 	m.InitialCorruptCheck = true
 	if mcfg.CorruptCheckTime > time.Duration(0) {
@@ -730,17 +773,21 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 		m.MaxLearners = mcfg.MaxLearners
 	}
 	m.Metrics = mcfg.Metrics
+	m.AutoCompactionMode = mcfg.AutoCompactionMode
+	m.AutoCompactionRetention = mcfg.AutoCompactionRetention
+	m.AutoMaintenance = mcfg.AutoMaintenance
 	m.V2Deprecation = config.V2DeprDefault
 	m.GRPCServerRecorder = &grpctesting.GRPCRecorder{}
 
 	m.Logger, m.LogObserver = memberLogger(t, mcfg.Name)
 	m.ServerFeatureGate = features.NewDefaultServerFeatureGate(m.Name, m.Logger)
-	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist)
+	featureGates := fmt.Sprintf("LeaseCheckpoint=%v,LeaseCheckpointPersist=%v,WatchValueSelector=%v,WatchCompare=%v", mcfg.EnableLeaseCheckpoint, mcfg.LeaseCheckpointPersist, mcfg.EnableWatchValueSelector, mcfg.EnableWatchCompare)
 	if err := m.ServerFeatureGate.(featuregate.MutableFeatureGate).Set(featureGates); err != nil {
 		t.Fatalf("Set FeatureGate FAILED: %v", err)
 	}
 
 	m.StrictReconfigCheck = !mcfg.DisableStrictReconfigCheck
+	m.EnableGRPCZstdCompression = mcfg.EnableGRPCZstdCompression
 	if err := m.listenGRPC(); err != nil {
 		t.Fatalf("listenGRPC FAILED: %v", err)
 	}
@@ -894,8 +941,26 @@ func (m *Member) ID() types.ID { return m.Server.MemberID() }
 
 // NewClientV3 creates a new grpc client connection to the member
 func NewClientV3(m *Member) (*clientv3.Client, error) {
+	cfg, err := m.clientV3Config()
+	if err != nil {
+		return nil, err
+	}
+	return newClientV3(cfg)
+}
+
+// NewProxyClientV3 creates a new grpc client connection to the member, whose
+// KV, Lease and Watcher go through an in-process gRPC proxy.
+func NewProxyClientV3(m *Member) (*clientv3.Client, error) {
+	cfg, err := m.clientV3Config()
+	if err != nil {
+		return nil, err
+	}
+	return newProxiedClientV3(cfg)
+}
+
+func (m *Member) clientV3Config() (clientv3.Config, error) {
 	if m.GRPCURL == "" {
-		return nil, fmt.Errorf("member not configured for grpc")
+		return clientv3.Config{}, fmt.Errorf("member not configured for grpc")
 	}
 
 	cfg := clientv3.Config{
@@ -909,14 +974,14 @@ func NewClientV3(m *Member) (*clientv3.Client, error) {
 	if m.ClientTLSInfo != nil {
 		tls, err := m.ClientTLSInfo.ClientConfig()
 		if err != nil {
-			return nil, err
+			return clientv3.Config{}, err
 		}
 		cfg.TLS = tls
 	}
 	if m.DialOptions != nil {
 		cfg.DialOptions = append(cfg.DialOptions, m.DialOptions...)
 	}
-	return newClientV3(cfg)
+	return cfg, nil
 }
 
 // Clone returns a member with the same server configuration. The returned
@@ -1105,6 +1170,12 @@ func (m *Member) Launch() error {
 			return err
 		}
 	}
+	if m.GRPCURL != "" && m.EnableGRPCProxy && m.ProxyClient == nil {
+		m.ProxyClient, err = NewProxyClientV3(m)
+		if err != nil {
+			return err
+		}
+	}
 
 	m.Logger.Info(
 		"launched a member",
@@ -1184,6 +1255,9 @@ func (m *Member) Close() {
 		m.ServerClient = nil
 	}
 	if m.GRPCServer != nil {
+		if m.Server != nil {
+			m.Server.DrainClients()
+		}
 		ch := make(chan struct{})
 		go func() {
 			defer close(ch)
@@ -1420,6 +1494,9 @@ func (c *Cluster) Terminate(t testutil.TB) {
 		if m.Client != nil {
 			m.Client.Close()
 		}
+		if m.ProxyClient != nil {
+			m.ProxyClient.Close()
+		}
 	}
 	var wg sync.WaitGroup
 	wg.Add(len(c.Members))
@@ -1443,6 +1520,12 @@ func (c *Cluster) Client(i int) *clientv3.Client {
 	return c.Members[i].Client
 }
 
+// ProxyClient returns the client of the i-th member going through a gRPC
+// proxy. The cluster must be configured with EnableGRPCProxy.
+func (c *Cluster) ProxyClient(i int) *clientv3.Client {
+	return c.Members[i].ProxyClient
+}
+
 func (c *Cluster) Endpoints() []string {
 	var endpoints []string
 	for _, m := range c.Members {
@@ -17,13 +17,10 @@
 package integration
 
 import (
-	"context"
 	"sync"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/namespace"
-	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
-	"go.etcd.io/etcd/server/v3/proxy/grpcproxy/adapter"
 )
 
 const ThroughProxy = true
@@ -35,26 +32,10 @@ var (
 
 const proxyNamespace = "proxy-namespace"
 
-type grpcClientProxy struct {
-	ctx       context.Context
-	ctxCancel func()
-	grpc      GRPCAPI
-	wdonec    <-chan struct{}
-	kvdonec   <-chan struct{}
-	lpdonec   <-chan struct{}
-}
-
 func ToGRPC(c *clientv3.Client) GRPCAPI {
 	pmu.Lock()
 	defer pmu.Unlock()
 
-	// dedicated context bound to 'grpc-proxy' lifetype
-	// (so in practice lifetime of the client connection to the proxy).
-	// TODO: Refactor to a separate clientv3.Client instance instead of the context alone.
-	ctx, ctxCancel := context.WithCancel(context.WithValue(context.TODO(), "_name", "grpcProxyContext"))
-
-	lg := c.GetLogger()
-
 	if v, ok := proxies[c]; ok {
 		return v.grpc
 	}
@@ -63,47 +44,9 @@ func ToGRPC(c *clientv3.Client) GRPCAPI {
 	c.KV = namespace.NewKV(c.KV, proxyNamespace)
 	c.Watcher = namespace.NewWatcher(c.Watcher, proxyNamespace)
 	c.Lease = namespace.NewLease(c.Lease, proxyNamespace)
-	// test coalescing/caching proxy
-	kvp, kvpch := grpcproxy.NewKvProxy(c)
-	wp, wpch := grpcproxy.NewWatchProxy(ctx, lg, c)
-	lp, lpch := grpcproxy.NewLeaseProxy(ctx, c)
-	mp := grpcproxy.NewMaintenanceProxy(c)
-	clp, _ := grpcproxy.NewClusterProxy(lg, c, "", "") // without registering proxy URLs
-	authp := grpcproxy.NewAuthProxy(c)
-	lockp := grpcproxy.NewLockProxy(c)
-	electp := grpcproxy.NewElectionProxy(c)
-
-	grpc := GRPCAPI{
-		adapter.ClusterServerToClusterClient(clp),
-		adapter.KvServerToKvClient(kvp),
-		adapter.LeaseServerToLeaseClient(lp),
-		adapter.WatchServerToWatchClient(wp),
-		adapter.MaintenanceServerToMaintenanceClient(mp),
-		adapter.AuthServerToAuthClient(authp),
-		adapter.LockServerToLockClient(lockp),
-		adapter.ElectionServerToElectionClient(electp),
-	}
-	proxies[c] = grpcClientProxy{ctx: ctx, ctxCancel: ctxCancel, grpc: grpc, wdonec: wpch, kvdonec: kvpch, lpdonec: lpch}
-	return grpc
-}
-
-type proxyCloser struct {
-	clientv3.Watcher
-	proxyCtxCancel func()
-	wdonec         <-chan struct{}
-	kvdonec        <-chan struct{}
-	lclose         func()
-	lpdonec        <-chan struct{}
-}
-
-func (pc *proxyCloser) Close() error {
-	pc.proxyCtxCancel()
-	<-pc.kvdonec
-	err := pc.Watcher.Close()
-	<-pc.wdonec
-	pc.lclose()
-	<-pc.lpdonec
-	return err
+	p := newGRPCClientProxy(c)
+	proxies[c] = p
+	return p.grpc
 }
 
 func newClientV3(cfg clientv3.Config) (*clientv3.Client, error) {
@@ -111,19 +54,9 @@ func newClientV3(cfg clientv3.Config) (*clientv3.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	rpc := ToGRPC(c)
-	c.KV = clientv3.NewKVFromKVClient(rpc.KV, c)
+	ToGRPC(c)
 	pmu.Lock()
-	lc := c.Lease
-	c.Lease = clientv3.NewLeaseFromLeaseClient(rpc.Lease, c, cfg.DialTimeout)
-	c.Watcher = &proxyCloser{
-		Watcher:        clientv3.NewWatchFromWatchClient(rpc.Watch, c),
-		wdonec:         proxies[c].wdonec,
-		kvdonec:        proxies[c].kvdonec,
-		lclose:         func() { lc.Close() },
-		lpdonec:        proxies[c].lpdonec,
-		proxyCtxCancel: proxies[c].ctxCancel,
-	}
+	proxies[c].proxyClient(c, cfg.DialTimeout)
 	pmu.Unlock()
 	return c, nil
 }
@@ -0,0 +1,112 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy/adapter"
+)
+
+// grpcClientProxy is an in-process gRPC proxy whose upstream is a client.
+type grpcClientProxy struct {
+	ctxCancel func()
+	grpc      GRPCAPI
+	wdonec    <-chan struct{}
+	kvdonec   <-chan struct{}
+	lpdonec   <-chan struct{}
+}
+
+// newGRPCClientProxy starts the proxy servers over the given client, and
+// returns their gRPC API.
+func newGRPCClientProxy(c *clientv3.Client) grpcClientProxy {
+	// dedicated context bound to 'grpc-proxy' lifetype
+	// (so in practice lifetime of the client connection to the proxy).
+	// TODO: Refactor to a separate clientv3.Client instance instead of the context alone.
+	ctx, ctxCancel := context.WithCancel(context.WithValue(context.TODO(), "_name", "grpcProxyContext"))
+
+	lg := c.GetLogger()
+
+	// test coalescing/caching proxy
+	kvp, kvpch := grpcproxy.NewKvProxy(c)
+	wp, wpch := grpcproxy.NewWatchProxy(ctx, lg, c)
+	lp, lpch := grpcproxy.NewLeaseProxy(ctx, c)
+	mp := grpcproxy.NewMaintenanceProxy(c)
+	clp, _ := grpcproxy.NewClusterProxy(lg, c, "", "") // without registering proxy URLs
+	authp := grpcproxy.NewAuthProxy(c)
+	lockp := grpcproxy.NewLockProxy(c)
+	electp := grpcproxy.NewElectionProxy(c)
+
+	grpc := GRPCAPI{
+		adapter.ClusterServerToClusterClient(clp),
+		adapter.KvServerToKvClient(kvp),
+		adapter.LeaseServerToLeaseClient(lp),
+		adapter.WatchServerToWatchClient(wp),
+		adapter.MaintenanceServerToMaintenanceClient(mp),
+		adapter.AuthServerToAuthClient(authp),
+		adapter.LockServerToLockClient(lockp),
+		adapter.ElectionServerToElectionClient(electp),
+	}
+	return grpcClientProxy{ctxCancel: ctxCancel, grpc: grpc, wdonec: wpch, kvdonec: kvpch, lpdonec: lpch}
+}
+
+// proxyClient makes the KV, Lease and Watcher of the client go through the
+// proxy. Closing the client stops the proxy.
+func (p grpcClientProxy) proxyClient(c *clientv3.Client, dialTimeout time.Duration) {
+	c.KV = clientv3.NewKVFromKVClient(p.grpc.KV, c)
+	lc := c.Lease
+	c.Lease = clientv3.NewLeaseFromLeaseClient(p.grpc.Lease, c, dialTimeout)
+	c.Watcher = &proxyCloser{
+		Watcher:        clientv3.NewWatchFromWatchClient(p.grpc.Watch, c),
+		wdonec:         p.wdonec,
+		kvdonec:        p.kvdonec,
+		lclose:         func() { lc.Close() },
+		lpdonec:        p.lpdonec,
+		proxyCtxCancel: p.ctxCancel,
+	}
+}
+
+type proxyCloser struct {
+	clientv3.Watcher
+	proxyCtxCancel func()
+	wdonec         <-chan struct{}
+	kvdonec        <-chan struct{}
+	lclose         func()
+	lpdonec        <-chan struct{}
+}
+
+func (pc *proxyCloser) Close() error {
+	pc.proxyCtxCancel()
+	<-pc.kvdonec
+	err := pc.Watcher.Close()
+	<-pc.wdonec
+	pc.lclose()
+	<-pc.lpdonec
+	return err
+}
+
+// newProxiedClientV3 creates a client whose KV, Lease and Watcher go through
+// an in-process gRPC proxy over its own connection, whatever the build tags.
+func newProxiedClientV3(cfg clientv3.Config) (*clientv3.Client, error) {
+	c, err := clientv3.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	newGRPCClientProxy(c).proxyClient(c, cfg.DialTimeout)
+	return c, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestBatchPut(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, MaxTxnOps: 16})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	kvs := batchKeyValues(1000, 0)
+	resp, err := clientv3.BatchPut(t.Context(), cli, kvs, clientv3.WithMaxOpsPerTxn(16))
+	require.NoError(t, err)
+	require.Len(t, resp.Revisions, 63)
+	seen := make(map[int64]bool)
+	for _, rev := range resp.Revisions {
+		require.NotZero(t, rev)
+		require.False(t, seen[rev])
+		seen[rev] = true
+	}
+
+	gresp, err := cli.Get(t.Context(), "key", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), gresp.Count)
+
+	// batches larger than --max-txn-ops are rejected
+	_, err = clientv3.BatchPut(t.Context(), cli, kvs, clientv3.WithMaxOpsPerTxn(17))
+	var berr *clientv3.BatchError
+	require.ErrorAs(t, err, &berr)
+	require.ErrorIs(t, err, rpctypes.ErrTooManyOps)
+}
+
+func TestBatchPutFailure(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, MaxTxnOps: 16})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	// the 6th batch fails on a lease that does not exist
+	kvs := batchKeyValues(1000, 0)
+	kvs[5*16+3].Lease = 12345
+
+	resp, err := clientv3.BatchPut(t.Context(), cli, kvs, clientv3.WithMaxOpsPerTxn(16), clientv3.WithBatchConcurrency(1))
+	var berr *clientv3.BatchError
+	require.ErrorAs(t, err, &berr)
+	require.Equal(t, 5, berr.Batch)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+	for i, rev := range resp.Revisions {
+		require.Equalf(t, i < 5, rev != 0, "unexpected revision %d of batch %d", rev, i)
+	}
+
+	// resume from the failed batch
+	kvs[5*16+3].Lease = clientv3.NoLease
+	_, err = clientv3.BatchPut(t.Context(), cli, kvs[berr.Batch*16:], clientv3.WithMaxOpsPerTxn(16))
+	require.NoError(t, err)
+	gresp, err := cli.Get(t.Context(), "key", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), gresp.Count)
+
+	// the other batches are still written when continuing on errors
+	kvs = batchKeyValues(1000, 1000)
+	kvs[5*16+3].Lease = 12345
+	kvs[7*16].Lease = 12345
+	resp, err = clientv3.BatchPut(t.Context(), cli, kvs, clientv3.WithMaxOpsPerTxn(16), clientv3.WithContinueOnError())
+	require.ErrorAs(t, err, &berr)
+	require.Equal(t, 5, berr.Batch)
+	for i, rev := range resp.Revisions {
+		require.Equalf(t, i != 5 && i != 7, rev != 0, "unexpected revision %d of batch %d", rev, i)
+	}
+	gresp, err = cli.Get(t.Context(), "key", clientv3.WithPrefix(), clientv3.WithCountOnly())
+	require.NoError(t, err)
+	require.Equal(t, int64(2000-2*16), gresp.Count)
+}
+
+func batchKeyValues(n, from int) []clientv3.KeyValue {
+	kvs := make([]clientv3.KeyValue, n)
+	for i := range kvs {
+		kvs[i] = clientv3.KeyValue{Key: fmt.Sprintf("key%05d", from+i), Value: "v"}
+	}
+	return kvs
+}
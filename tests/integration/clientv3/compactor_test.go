@@ -0,0 +1,64 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/compactor"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestPeriodicCompactor ensures that the compact revision advances with
+// periodic compactors running on several clients.
+func TestPeriodicCompactor(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	for i := range 2 {
+		pc := compactor.NewPeriodic(clus.Client(i), time.Second)
+		pc.Start()
+		defer pc.Stop()
+	}
+
+	cli := clus.Client(2)
+	compacted := func(rev int64) bool {
+		_, err := cli.Get(t.Context(), "foo", clientv3.WithRev(rev))
+		return errors.Is(err, rpctypes.ErrCompacted)
+	}
+	var lastRev int64
+	for i := range 3 {
+		var rev int64
+		require.Eventually(t, func() bool {
+			resp, err := cli.Put(t.Context(), "foo", fmt.Sprint(i))
+			require.NoError(t, err)
+			if rev == 0 {
+				rev = resp.Header.Revision
+			}
+			return compacted(rev)
+		}, 10*time.Second, 50*time.Millisecond)
+		require.Greater(t, rev, lastRev)
+		lastRev = rev
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime/metrics"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+var compressionCodecs = []string{clientv3.CompressionNone, clientv3.CompressionGzip, clientv3.CompressionZstd}
+
+// compressibleValue returns a value of about size bytes that compresses like
+// typical JSON objects.
+func compressibleValue(size int) string {
+	r := rand.New(rand.NewSource(int64(size)))
+	var sb strings.Builder
+	for sb.Len() < size {
+		fmt.Fprintf(&sb, `{"name":"pod-%d","namespace":"ns-%d","uid":"%08x","phase":"Running"},`, r.Intn(1000), r.Intn(10), r.Uint32())
+	}
+	return sb.String()[:size]
+}
+
+func newCompressionClient(tb testing.TB, clus *integration.Cluster, codec string) *clientv3.Client {
+	cli, err := integration.NewClient(tb, clientv3.Config{
+		Endpoints:        []string{clus.Members[0].GRPCURL},
+		DialTimeout:      5 * time.Second,
+		CompressionCodec: codec,
+	})
+	require.NoError(tb, err)
+	tb.Cleanup(func() { cli.Close() })
+	return cli
+}
+
+// TestCompression ensures that unary and watch calls work with every
+// compression codec.
+func TestCompression(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, EnableGRPCZstdCompression: true})
+	defer clus.Terminate(t)
+
+	val := compressibleValue(100 * 1024)
+	for _, codec := range compressionCodecs {
+		t.Run(codec, func(t *testing.T) {
+			cli := newCompressionClient(t, clus, codec)
+			key := "compression/" + codec
+
+			wch := cli.Watch(t.Context(), key)
+			_, err := cli.Put(t.Context(), key, val)
+			require.NoError(t, err)
+
+			resp, err := cli.Get(t.Context(), key)
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			require.Equal(t, val, string(resp.Kvs[0].Value))
+
+			select {
+			case wresp := <-wch:
+				require.NoError(t, wresp.Err())
+				require.Len(t, wresp.Events, 1)
+				require.Equal(t, val, string(wresp.Events[0].Kv.Value))
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for watch event")
+			}
+		})
+	}
+
+	_, err := clientv3.New(clientv3.Config{Endpoints: clus.Endpoints(), CompressionCodec: "snappy"})
+	require.ErrorContains(t, err, `unknown compression codec "snappy"`)
+}
+
+// cpuSeconds returns the CPU time used by the process so far, as estimated by
+// the Go runtime.
+func cpuSeconds() float64 {
+	samples := []metrics.Sample{
+		{Name: "/cpu/classes/total:cpu-seconds"},
+		{Name: "/cpu/classes/idle:cpu-seconds"},
+	}
+	metrics.Read(samples)
+	return samples[0].Value.Float64() - samples[1].Value.Float64()
+}
+
+func reportCPU(b *testing.B, start float64) {
+	b.ReportMetric((cpuSeconds()-start)*1e9/float64(b.N), "cpu-ns/op")
+}
+
+// BenchmarkCompressionPutGet compares the throughput and CPU cost of putting
+// and getting 100KB values with each compression codec.
+func BenchmarkCompressionPutGet(b *testing.B) {
+	integration.BeforeTest(b, integration.WithoutGoLeakDetection())
+
+	clus := integration.NewCluster(b, &integration.ClusterConfig{Size: 1, EnableGRPCZstdCompression: true})
+	defer clus.Terminate(b)
+
+	val := compressibleValue(100 * 1024)
+	for _, codec := range compressionCodecs {
+		b.Run(codec, func(b *testing.B) {
+			cli := newCompressionClient(b, clus, codec)
+			b.SetBytes(int64(2 * len(val)))
+			b.ResetTimer()
+			start := cpuSeconds()
+			for i := 0; i < b.N; i++ {
+				key := fmt.Sprintf("bench/%d", i%100)
+				if _, err := cli.Put(b.Context(), key, val); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := cli.Get(b.Context(), key); err != nil {
+					b.Fatal(err)
+				}
+			}
+			reportCPU(b, start)
+		})
+	}
+}
+
+// BenchmarkCompressionWatch compares the throughput and CPU cost of watching
+// 100KB values with each compression codec, with the puts going uncompressed
+// through a separate client.
+func BenchmarkCompressionWatch(b *testing.B) {
+	integration.BeforeTest(b, integration.WithoutGoLeakDetection())
+
+	clus := integration.NewCluster(b, &integration.ClusterConfig{Size: 1, EnableGRPCZstdCompression: true})
+	defer clus.Terminate(b)
+
+	val := compressibleValue(100 * 1024)
+	putCli := newCompressionClient(b, clus, clientv3.CompressionNone)
+	for _, codec := range compressionCodecs {
+		b.Run(codec, func(b *testing.B) {
+			cli := newCompressionClient(b, clus, codec)
+			prefix := "bench/watch/" + codec + "/"
+			wch := cli.Watch(b.Context(), prefix, clientv3.WithPrefix())
+			b.SetBytes(int64(len(val)))
+			b.ResetTimer()
+			start := cpuSeconds()
+			go func() {
+				for i := 0; i < b.N; i++ {
+					if _, err := putCli.Put(b.Context(), prefix+fmt.Sprint(i%100), val); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			}()
+			for n := 0; n < b.N; {
+				wresp, ok := <-wch
+				if !ok {
+					b.Fatal("watch channel closed")
+				}
+				n += len(wresp.Events)
+			}
+			reportCPU(b, start)
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
@@ -103,3 +104,88 @@ func TestResumeElection(t *testing.T) {
 		t.Errorf("expected new leader to be 'candidate1' got %q", string(kv.Value))
 	}
 }
+
+func TestElectionObserveWithHistory(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	s1, s2 := newRWMutexSession(t, cli), newRWMutexSession(t, cli)
+	e1 := concurrency.NewElection(s1, "/election-history")
+	e2 := concurrency.NewElection(s2, "/election-history")
+
+	require.NoError(t, e1.Campaign(ctx, "a"))
+	key1, rev1 := e1.Key(), e1.Rev()
+	require.NoError(t, e1.Proclaim(ctx, "a2"))
+	elected := make(chan error, 1)
+	go func() { elected <- e2.Campaign(ctx, "b") }()
+	waitRWMutexKeys(t, cli, "/election-history/", 2)
+	require.NoError(t, e1.Resign(ctx))
+	require.NoError(t, <-elected)
+	key2, rev2 := e2.Key(), e2.Rev()
+	require.NoError(t, e2.Resign(ctx))
+
+	obs := e1.ObserveWithHistory(ctx, rev1)
+	expected := []concurrency.LeaderRecord{
+		{Key: key1, Value: []byte("a"), CreateRevision: rev1, Lease: s1.Lease()},
+		{Key: key1, Value: []byte("a2"), CreateRevision: rev1, Lease: s1.Lease()},
+		{Key: key2, Value: []byte("b"), CreateRevision: rev2, Lease: s2.Lease()},
+		{},
+	}
+	var lastRev int64
+	for _, exp := range expected {
+		rec := <-obs
+		require.NoError(t, rec.Err)
+		require.Greater(t, rec.Revision, lastRev)
+		lastRev = rec.Revision
+		rec.Revision = 0
+		require.Equal(t, exp, rec)
+	}
+
+	// the live changes follow the history
+	require.NoError(t, e1.Campaign(ctx, "c"))
+	defer e1.Resign(t.Context())
+	rec := <-obs
+	require.NoError(t, rec.Err)
+	require.Greater(t, rec.Revision, lastRev)
+	require.Equal(t, "c", string(rec.Value))
+	require.Equal(t, e1.Rev(), rec.CreateRevision)
+}
+
+func TestElectionObserveWithHistoryCompacted(t *testing.T) {
+	// the compaction needs a cluster of its own, next to the one of the
+	// examples whose goroutines would be reported as leaked
+	integration.BeforeTest(t, integration.WithoutGoLeakDetection())
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	s := newRWMutexSession(t, cli)
+	e := concurrency.NewElection(s, "/election-compacted")
+	require.NoError(t, e.Campaign(ctx, "a"))
+	require.NoError(t, e.Proclaim(ctx, "b"))
+	compactRev := e.Header().Revision
+	_, err := cli.Compact(ctx, compactRev)
+	require.NoError(t, err)
+
+	obs := e.ObserveWithHistory(ctx, e.Rev())
+	rec := <-obs
+	var cerr *concurrency.ElectionCompactedError
+	require.ErrorAs(t, rec.Err, &cerr)
+	require.Equal(t, compactRev, cerr.CompactRevision)
+	require.ErrorIs(t, rec.Err, rpctypes.ErrCompacted)
+	_, ok := <-obs
+	require.False(t, ok)
+
+	// the current leader can still be observed
+	rec = <-e.ObserveWithHistory(ctx, 0)
+	require.NoError(t, rec.Err)
+	require.Equal(t, "b", string(rec.Value))
+	require.Equal(t, e.Rev(), rec.CreateRevision)
+}
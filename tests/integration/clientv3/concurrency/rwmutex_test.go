@@ -0,0 +1,186 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// newRWMutexSession returns a session closed once the test is done. The
+// client has to be closed with t.Cleanup as well, so that the session revokes
+// its lease before the client is closed, otherwise its keys outlive the test.
+func newRWMutexSession(t *testing.T, cli *clientv3.Client) *concurrency.Session {
+	s, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestRWMutexReadersShareLock(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	r1 := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-shared")
+	r2 := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-shared")
+	w := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-shared")
+
+	require.NoError(t, r1.RLock(t.Context()))
+	require.NoError(t, r2.TryRLock(t.Context()))
+	require.ErrorIs(t, w.TryLock(t.Context()), concurrency.ErrLocked)
+
+	// the lock can guard a txn of a reader
+	resp, err := cli.Txn(t.Context()).If(r2.IsOwner()).Then(clientv3.OpPut("/rw-shared-data", "v")).Commit()
+	require.NoError(t, err)
+	require.True(t, resp.Succeeded)
+
+	wLocked := make(chan error, 1)
+	go func() { wLocked <- w.Lock(t.Context()) }()
+
+	require.NoError(t, r1.RUnlock(t.Context()))
+	select {
+	case err = <-wLocked:
+		t.Fatalf("writer acquired the lock held by a reader (err: %v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.NoError(t, r2.RUnlock(t.Context()))
+	select {
+	case err = <-wLocked:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("writer did not acquire the lock released by the readers")
+	}
+	require.NoError(t, w.Unlock(t.Context()))
+
+	require.ErrorIs(t, w.Unlock(t.Context()), concurrency.ErrLockReleased)
+}
+
+func TestRWMutexWaitingWriterBlocksReaders(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	r1 := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-fair")
+	w := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-fair")
+	r2 := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-fair")
+
+	require.NoError(t, r1.RLock(t.Context()))
+
+	wLocked := make(chan error, 1)
+	go func() { wLocked <- w.Lock(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/rw-fair/write/", 1)
+
+	// a reader coming after the waiting writer must not get the lock
+	require.ErrorIs(t, r2.TryRLock(t.Context()), concurrency.ErrLocked)
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	err = r2.RLock(ctx)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// the canceled reader cleans up its key
+	waitRWMutexKeys(t, cli, "/rw-fair/read/", 1)
+
+	r2Locked := make(chan error, 1)
+	go func() { r2Locked <- r2.RLock(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/rw-fair/read/", 2)
+
+	require.NoError(t, r1.RUnlock(t.Context()))
+	select {
+	case err = <-wLocked:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("writer did not acquire the lock released by the reader")
+	}
+	select {
+	case err = <-r2Locked:
+		t.Fatalf("reader acquired the lock held by a writer (err: %v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, w.Unlock(t.Context()))
+	select {
+	case err = <-r2Locked:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reader did not acquire the lock released by the writer")
+	}
+	require.NoError(t, r2.RUnlock(t.Context()))
+}
+
+func TestRWMutexSessionExpiryReleasesLock(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	ws := newRWMutexSession(t, cli)
+	w := concurrency.NewRWMutex(ws, "/rw-expiry")
+	rs := newRWMutexSession(t, cli)
+	r := concurrency.NewRWMutex(rs, "/rw-expiry")
+	w2 := concurrency.NewRWMutex(newRWMutexSession(t, cli), "/rw-expiry")
+
+	require.NoError(t, w.Lock(t.Context()))
+
+	rLocked := make(chan error, 1)
+	go func() { rLocked <- r.RLock(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/rw-expiry/read/", 1)
+
+	// expire the session of the writer holding the lock
+	_, err = cli.Revoke(t.Context(), ws.Lease())
+	require.NoError(t, err)
+	select {
+	case err = <-rLocked:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reader did not acquire the lock released by the expired session")
+	}
+	resp, err := cli.Txn(t.Context()).If(w.IsOwner()).Commit()
+	require.NoError(t, err)
+	require.False(t, resp.Succeeded, "expired session still owns the lock")
+
+	w2Locked := make(chan error, 1)
+	go func() { w2Locked <- w2.Lock(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/rw-expiry/write/", 1)
+
+	// expire the session of the reader holding the lock
+	_, err = cli.Revoke(t.Context(), rs.Lease())
+	require.NoError(t, err)
+	select {
+	case err = <-w2Locked:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("writer did not acquire the lock released by the expired session")
+	}
+	require.NoError(t, w2.Unlock(t.Context()))
+}
+
+func waitRWMutexKeys(t *testing.T, cli *clientv3.Client, pfx string, n int64) {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		resp, err := cli.Get(t.Context(), pfx, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		require.NoError(t, err)
+		if resp.Count == n {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d lock keys under %q", n, pfx)
+}
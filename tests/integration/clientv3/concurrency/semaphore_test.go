@@ -0,0 +1,141 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestSemaphoreContention(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	var holders, maxHolders atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		sm := concurrency.NewSemaphore(newRWMutexSession(t, cli), "/sem-contention", 3)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sm.Acquire(t.Context()); err != nil {
+				t.Error(err)
+				return
+			}
+			n := holders.Add(1)
+			for {
+				m := maxHolders.Load()
+				if n <= m || maxHolders.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(100 * time.Millisecond)
+			holders.Add(-1)
+			if err := sm.Release(t.Context()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, maxHolders.Load(), int32(3), "more holders than slots")
+	require.Positive(t, maxHolders.Load())
+}
+
+func TestSemaphoreSessionKillFreesSlot(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	var sessions []*concurrency.Session
+	for range 3 {
+		s := newRWMutexSession(t, cli)
+		sessions = append(sessions, s)
+		require.NoError(t, concurrency.NewSemaphore(s, "/sem-kill", 3).Acquire(t.Context()))
+	}
+
+	sm := concurrency.NewSemaphore(newRWMutexSession(t, cli), "/sem-kill", 3)
+	require.ErrorIs(t, sm.TryAcquire(t.Context()), concurrency.ErrNoSlot)
+	ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+	err = sm.Acquire(ctx)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	// the canceled waiter cleans up its key
+	waitRWMutexKeys(t, cli, "/sem-kill/holders/", 3)
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- sm.Acquire(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/sem-kill/holders/", 4)
+
+	// kill the session of a holder
+	_, err = cli.Revoke(t.Context(), sessions[1].Lease())
+	require.NoError(t, err)
+	select {
+	case err = <-acquired:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiter did not acquire the slot freed by the killed session")
+	}
+	resp, err := cli.Txn(t.Context()).If(sm.IsOwner()).Commit()
+	require.NoError(t, err)
+	require.True(t, resp.Succeeded)
+	require.NoError(t, sm.Release(t.Context()))
+	require.ErrorIs(t, sm.Release(t.Context()), concurrency.ErrSlotReleased)
+}
+
+func TestSemaphoreSetCapacity(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+
+	// the capacity is stored for the next runs of the test otherwise
+	defer cli.Delete(t.Context(), "/sem-resize/capacity")
+
+	sm1 := concurrency.NewSemaphore(newRWMutexSession(t, cli), "/sem-resize", 1)
+	sm2 := concurrency.NewSemaphore(newRWMutexSession(t, cli), "/sem-resize", 1)
+	require.NoError(t, sm1.Acquire(t.Context()))
+
+	acquired := make(chan error, 1)
+	go func() { acquired <- sm2.Acquire(t.Context()) }()
+	waitRWMutexKeys(t, cli, "/sem-resize/holders/", 2)
+	select {
+	case err = <-acquired:
+		t.Fatalf("waiter acquired a slot beyond the capacity (err: %v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	require.NoError(t, sm1.SetCapacity(t.Context(), 2))
+	select {
+	case err = <-acquired:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiter did not acquire the slot added by the larger capacity")
+	}
+
+	// the stored capacity overrides the one of the semaphore
+	sm3 := concurrency.NewSemaphore(newRWMutexSession(t, cli), "/sem-resize", 5)
+	require.ErrorIs(t, sm3.TryAcquire(t.Context()), concurrency.ErrNoSlot)
+	require.NoError(t, sm1.Release(t.Context()))
+	require.NoError(t, sm3.TryAcquire(t.Context()))
+}
@@ -46,6 +46,106 @@ func TestSessionOptions(t *testing.T) {
 	}
 }
 
+func TestSessionAdoptLease(t *testing.T) {
+	blue, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer blue.Close()
+	green, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer green.Close()
+
+	bs, err := concurrency.NewSession(blue, concurrency.WithTTL(5))
+	require.NoError(t, err)
+	defer bs.Orphan()
+
+	gs, err := concurrency.NewSession(green, concurrency.WithAdoptLease(bs.Lease()))
+	require.NoError(t, err)
+	defer gs.Close()
+	assert.Equal(t, bs.Lease(), gs.Lease())
+
+	select {
+	case <-bs.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("previous session did not end after its lease was adopted")
+	}
+	select {
+	case <-gs.Done():
+		t.Fatal("adopting session ended unexpectedly")
+	default:
+	}
+}
+
+func TestSessionWithLeaseValidation(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	t.Run("live lease", func(t *testing.T) {
+		lease, err := cli.Grant(t.Context(), 100)
+		require.NoError(t, err)
+		s, err := concurrency.NewSession(cli, concurrency.WithLease(lease.ID), concurrency.WithMinLeaseTTL(10))
+		require.NoError(t, err)
+		defer s.Close()
+
+		ttl, err := s.RemainingTTL(t.Context())
+		require.NoError(t, err)
+		assert.Greater(t, ttl, int64(90))
+	})
+
+	t.Run("revoked lease", func(t *testing.T) {
+		lease, err := cli.Grant(t.Context(), 100)
+		require.NoError(t, err)
+		_, err = cli.Revoke(t.Context(), lease.ID)
+		require.NoError(t, err)
+		_, err = concurrency.NewSession(cli, concurrency.WithLease(lease.ID))
+		require.ErrorIs(t, err, concurrency.ErrLeaseNotFound)
+	})
+
+	t.Run("nearly expired lease", func(t *testing.T) {
+		lease, err := cli.Grant(t.Context(), 5)
+		require.NoError(t, err)
+		_, err = concurrency.NewSession(cli, concurrency.WithLease(lease.ID), concurrency.WithMinLeaseTTL(10))
+		var expiring *concurrency.LeaseExpiringError
+		require.ErrorAs(t, err, &expiring)
+		assert.LessOrEqual(t, expiring.RemainingTTL, int64(5))
+	})
+}
+
+func TestSessionWithoutKeepAlive(t *testing.T) {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	owner, err := concurrency.NewSession(cli, concurrency.WithTTL(2))
+	require.NoError(t, err)
+	defer owner.Orphan()
+
+	s, err := concurrency.NewSession(cli, concurrency.WithLease(owner.Lease()), concurrency.WithAdoptKeepAlive(false))
+	require.NoError(t, err)
+	// the session lives as long as the owner keeps the lease alive
+	select {
+	case <-s.Done():
+		t.Fatal("session ended while its lease is kept alive")
+	case <-time.After(3 * time.Second):
+	}
+	// and closing it leaves the lease to its owner
+	require.NoError(t, s.Close())
+	_, err = owner.RemainingTTL(t.Context())
+	require.NoError(t, err)
+
+	s, err = concurrency.NewSession(cli, concurrency.WithLease(owner.Lease()), concurrency.WithAdoptKeepAlive(false))
+	require.NoError(t, err)
+	defer s.Close()
+	owner.Orphan()
+	select {
+	case <-s.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("session did not end after its lease expired")
+	}
+	_, err = s.RemainingTTL(t.Context())
+	require.ErrorIs(t, err, concurrency.ErrLeaseNotFound)
+}
+
 func TestSessionTTLOptions(t *testing.T) {
 	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
 	require.NoError(t, err)
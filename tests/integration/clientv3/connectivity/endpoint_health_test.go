@@ -0,0 +1,91 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package connectivity_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestEndpointHealthCheckBlackhole ensures that with EnableEndpointHealthCheck,
+// unary requests stop going to a blackholed member after a few timeouts, and
+// go to it again once it is back.
+func TestEndpointHealthCheckBlackhole(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, UseBridge: true})
+	defer clus.Terminate(t)
+
+	eps := []string{clus.Members[0].GRPCURL, clus.Members[1].GRPCURL, clus.Members[2].GRPCURL}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:                 eps,
+		DialTimeout:               5 * time.Second,
+		EnableEndpointHealthCheck: true,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	blackholed := uint64(clus.Members[0].ID())
+	// get returns the ID of the member that served a serializable get, or 0
+	// if the get timed out.
+	get := func() uint64 {
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		resp, err := cli.Get(ctx, "foo", clientv3.WithSerializable())
+		if err != nil {
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+			return 0
+		}
+		return resp.Header.MemberId
+	}
+
+	// wait for the client to connect to every member
+	served := make(map[uint64]bool)
+	for deadline := time.Now().Add(5 * time.Second); len(served) < len(eps); {
+		require.Truef(t, time.Now().Before(deadline), "requests only went to %v", served)
+		if id := get(); id != 0 {
+			served[id] = true
+		}
+	}
+
+	clus.Members[0].Bridge().Blackhole()
+	timeouts := 0
+	for i := range 30 {
+		id := get()
+		if id == 0 {
+			timeouts++
+		}
+		// the member is ejected after 3 consecutive timeouts, and a request
+		// already on its way may still go through the bridge
+		if i >= 15 {
+			require.NotZero(t, id, "request %d timed out", i)
+			require.NotEqual(t, blackholed, id)
+		}
+	}
+	require.LessOrEqual(t, timeouts, 3)
+
+	clus.Members[0].Bridge().Unblackhole()
+	for deadline := time.Now().Add(10 * time.Second); get() != blackholed; {
+		require.Truef(t, time.Now().Before(deadline), "requests did not go to the member once it was back")
+	}
+}
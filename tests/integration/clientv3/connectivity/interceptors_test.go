@@ -0,0 +1,134 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package connectivity_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestUnaryInterceptorsSeeAttempts ensures that the interceptors of the
+// config are called for every attempt of a request, below the retries, while
+// the request is traced once.
+func TestUnaryInterceptorsSeeAttempts(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	var attempts, failures atomic.Int64
+	counting := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		attempts.Add(1)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	// the first member is flaky: it serves reads but they fail on the way back
+	flakyID := uint64(clus.Members[0].ID())
+	flaky := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if resp, ok := reply.(*pb.RangeResponse); ok && err == nil && resp.Header.MemberId == flakyID {
+			failures.Add(1)
+			return status.Error(codes.Unavailable, "flaky member")
+		}
+		return err
+	}
+	recorder := tracetest.NewSpanRecorder()
+	eps := []string{clus.Members[0].GRPCURL, clus.Members[1].GRPCURL, clus.Members[2].GRPCURL}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:         eps,
+		DialTimeout:       5 * time.Second,
+		UnaryInterceptors: []grpc.UnaryClientInterceptor{counting, flaky},
+		TracerProvider:    sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	putResp, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	attempts.Store(0)
+
+	const gets = 30
+	for range gets {
+		resp, err := cli.Get(t.Context(), "foo", clientv3.WithSerializable())
+		require.NoError(t, err)
+		require.NotEqual(t, flakyID, resp.Header.MemberId)
+	}
+	require.NotZero(t, failures.Load(), "no request went to the flaky member")
+	require.Equal(t, gets+failures.Load(), attempts.Load())
+
+	var spans int
+	for _, span := range recorder.Ended() {
+		if span.Name() != "etcdserverpb.KV/Range" {
+			continue
+		}
+		spans++
+		attrs := attribute.NewSet(span.Attributes()...)
+		method, _ := attrs.Value(clientv3.TraceAttrMethod)
+		require.Equal(t, "Range", method.AsString())
+		key, _ := attrs.Value(clientv3.TraceAttrKey)
+		require.Equal(t, "foo", key.AsString())
+		rev, _ := attrs.Value(clientv3.TraceAttrRevision)
+		require.Equal(t, putResp.Header.Revision, rev.AsInt64())
+	}
+	require.Equal(t, gets, spans)
+}
+
+// TestTracingRedactKeys ensures that the keys are hashed in the spans with
+// RedactKeys.
+func TestTracingRedactKeys(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:      []string{clus.Members[0].GRPCURL},
+		DialTimeout:    5 * time.Second,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		RedactKeys:     true,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	_, err = cli.Put(t.Context(), "secret", "bar")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "etcdserverpb.KV/Put", spans[0].Name())
+	sum := sha256.Sum256([]byte("secret"))
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	key, ok := attrs.Value(clientv3.TraceAttrKey)
+	require.True(t, ok)
+	require.Equal(t, hex.EncodeToString(sum[:]), key.AsString())
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/diagnostics"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// droppingWatcher drops the watch events matching drop.
+type droppingWatcher struct {
+	clientv3.Watcher
+	drop func(*clientv3.Event) bool
+	// watching is closed on the first call to Watch.
+	watching chan struct{}
+	once     sync.Once
+}
+
+func (w *droppingWatcher) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	wch := w.Watcher.Watch(ctx, key, opts...)
+	w.once.Do(func() { close(w.watching) })
+	ch := make(chan clientv3.WatchResponse)
+	go func() {
+		defer close(ch)
+		for wresp := range wch {
+			var evs []*clientv3.Event
+			for _, ev := range wresp.Events {
+				if !w.drop(ev) {
+					evs = append(evs, ev)
+				}
+			}
+			wresp.Events = evs
+			select {
+			case ch <- wresp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// runVerifiedWatcher runs a VerifiedWatcher of "/" on a client dropping the
+// events matching drop, and returns the discrepancies it reported once the
+// given writes are done and two more checks ran.
+func runVerifiedWatcher(t *testing.T, drop func(*clientv3.Event) bool, writes func(cli *clientv3.Client)) []diagnostics.Discrepancy {
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+
+	const checkInterval = 100 * time.Millisecond
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	// a heartbeat key moves the watch revision forward, as the grpc proxy
+	// does not answer progress requests
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			cli.Put(ctx, "/heartbeat", "")
+			time.Sleep(checkInterval / 5)
+		}
+	}()
+
+	dw := &droppingWatcher{Watcher: cli.Watcher, drop: drop, watching: make(chan struct{})}
+	cli.Watcher = dw
+	vw := diagnostics.NewVerifiedWatcher(cli, "/", checkInterval)
+	var (
+		mu            sync.Mutex
+		discrepancies []diagnostics.Discrepancy
+	)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- vw.Run(ctx, func(d diagnostics.Discrepancy) {
+			t.Log(d)
+			mu.Lock()
+			defer mu.Unlock()
+			discrepancies = append(discrepancies, d)
+		})
+	}()
+
+	// the writes must not be read by the initial list
+	select {
+	case <-dw.watching:
+	case err := <-errc:
+		t.Fatalf("unexpected error %v", err)
+	}
+	writes(cli)
+	time.Sleep(4 * checkInterval)
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return discrepancies
+}
+
+func TestVerifiedWatcher(t *testing.T) {
+	integration.BeforeTest(t)
+
+	discrepancies := runVerifiedWatcher(t, func(*clientv3.Event) bool { return false }, func(cli *clientv3.Client) {
+		for i := 0; i < 50; i++ {
+			_, err := cli.Put(t.Context(), "/foo", "bar")
+			require.NoError(t, err)
+			_, err = cli.Delete(t.Context(), "/foo")
+			require.NoError(t, err)
+		}
+	})
+	assert.Empty(t, discrepancies)
+}
+
+func TestVerifiedWatcherDroppedEvents(t *testing.T) {
+	integration.BeforeTest(t)
+
+	dropped := map[string]bool{"/missing": true, "/stale": true, "/phantom": true}
+	drop := func(ev *clientv3.Event) bool {
+		key := string(ev.Kv.Key)
+		switch {
+		case key == "/missing":
+			return true
+		case key == "/stale":
+			return ev.Kv.Version == 2
+		case key == "/phantom":
+			return ev.Type == clientv3.EventTypeDelete
+		}
+		return false
+	}
+	discrepancies := runVerifiedWatcher(t, drop, func(cli *clientv3.Client) {
+		for _, key := range []string{"/missing", "/stale", "/stale", "/phantom"} {
+			_, err := cli.Put(t.Context(), key, "bar")
+			require.NoError(t, err)
+		}
+		_, err := cli.Delete(t.Context(), "/phantom")
+		require.NoError(t, err)
+	})
+
+	kinds := map[string]diagnostics.DiscrepancyKind{}
+	for _, d := range discrepancies {
+		require.True(t, dropped[string(d.Key)], "unexpected discrepancy %v", d)
+		require.NotContains(t, kinds, string(d.Key), "discrepancy reported twice")
+		kinds[string(d.Key)] = d.Kind
+		require.GreaterOrEqual(t, d.WatchRevision, d.ListRevision)
+	}
+	assert.Equal(t, map[string]diagnostics.DiscrepancyKind{
+		"/missing": diagnostics.MissingKey,
+		"/stale":   diagnostics.StaleValue,
+		"/phantom": diagnostics.PhantomKey,
+	}, kinds)
+}
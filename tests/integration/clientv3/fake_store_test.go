@@ -0,0 +1,337 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	fake "go.etcd.io/etcd/client/v3/clientv3test"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// fakeStoreScenarios are run against the in-memory store of clientv3test and
+// against a cluster, which must give the same results. Each scenario uses its
+// own prefix, and the revisions are recorded relative to the one the scenario
+// starts at.
+var fakeStoreScenarios = []struct {
+	name string
+	run  func(r *fakeStoreRecorder)
+}{
+	{"PutGet", func(r *fakeStoreRecorder) {
+		r.put("a", "1")
+		r.put("b", "2")
+		r.put("c", "3")
+		r.put("a", "4", clientv3.WithPrevKV())
+		r.get("", clientv3.WithPrefix())
+		r.get("", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByModRevision, clientv3.SortDescend), clientv3.WithLimit(2))
+		r.get("", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByValue, clientv3.SortNone))
+		r.get("", clientv3.WithPrefix(), clientv3.WithKeysOnly(), clientv3.WithMinModRev(r.base+3))
+		r.get("", clientv3.WithPrefix(), clientv3.WithCountOnly())
+		r.get("b", clientv3.WithFromKey(), clientv3.WithLimit(1))
+		r.get("a", clientv3.WithRev(r.base+1))
+		r.get("a", clientv3.WithRev(r.base+100))
+		r.put("b", "", clientv3.WithIgnoreValue())
+		r.put("d", "", clientv3.WithIgnoreValue())
+		r.put("d", "5", clientv3.WithLease(1))
+		r.del("b", clientv3.WithRange(r.key("d")), clientv3.WithPrevKV())
+		r.get("", clientv3.WithPrefix())
+	}},
+	{"Txn", func(r *fakeStoreRecorder) {
+		r.put("a", "1")
+		r.txn(
+			[]clientv3.Cmp{clientv3.Compare(clientv3.Value(r.key("missing")), "=", "")},
+			[]clientv3.Op{clientv3.OpPut(r.key("b"), "then")},
+			[]clientv3.Op{clientv3.OpPut(r.key("b"), "else")},
+		)
+		r.txn(
+			[]clientv3.Cmp{
+				clientv3.Compare(clientv3.Version(r.key("missing")), "=", 0),
+				clientv3.Compare(clientv3.ModRevision(r.key("a")), "<", r.base+2),
+			},
+			[]clientv3.Op{
+				clientv3.OpPut(r.key("a"), "2"),
+				clientv3.OpGet(r.key("a")),
+				clientv3.OpDelete(r.key("b"), clientv3.WithPrevKV()),
+				clientv3.OpTxn(
+					[]clientv3.Cmp{clientv3.Compare(clientv3.Value(r.key("a")), "=", "1")},
+					[]clientv3.Op{clientv3.OpPut(r.key("c"), "nested"), clientv3.OpGet(r.key(""), clientv3.WithPrefix())},
+					nil,
+				),
+			},
+			nil,
+		)
+		r.txn(nil, []clientv3.Op{clientv3.OpPut(r.key("a"), "3"), clientv3.OpPut(r.key("a"), "4")}, nil)
+		r.txn(nil, []clientv3.Op{clientv3.OpDelete(r.key(""), clientv3.WithPrefix()), clientv3.OpPut(r.key("a"), "4")}, nil)
+		r.txn(nil, []clientv3.Op{clientv3.OpGet(r.key("a"), clientv3.WithRev(r.base+100))}, nil)
+		r.txn(nil, nil, nil)
+		r.get("", clientv3.WithPrefix())
+	}},
+	{"Watch", func(r *fakeStoreRecorder) {
+		r.put("a", "1")
+		r.put("b", "2")
+		r.put("a", "3")
+		r.del("a")
+		r.watch(4, "", clientv3.WithPrefix(), clientv3.WithRev(r.base+1), clientv3.WithPrevKV())
+		r.watch(1, "", clientv3.WithPrefix(), clientv3.WithRev(r.base+1), clientv3.WithFilterPut())
+		r.watch(2, "a", clientv3.WithRev(r.base+1), clientv3.WithKeysOnly())
+		r.watch(1, "b", clientv3.WithRange(r.key("a")))
+	}},
+	{"Lease", func(r *fakeStoreRecorder) {
+		id := r.grant(60)
+		r.put("a", "1", clientv3.WithLease(id))
+		r.put("b", "2", clientv3.WithLease(id))
+		r.put("c", "2", clientv3.WithLease(id+1000))
+		r.put("b", "3", clientv3.WithIgnoreLease())
+		r.put("c", "4", clientv3.WithIgnoreLease())
+		r.timeToLive(id)
+		r.revoke(id)
+		r.revoke(id)
+		r.timeToLive(id)
+		r.get("", clientv3.WithPrefix())
+	}},
+	// compaction affects all the keys, so it goes last
+	{"Compact", func(r *fakeStoreRecorder) {
+		r.put("a", "1")
+		r.put("a", "2")
+		r.del("a")
+		r.put("a", "3")
+		r.compact(r.base + 3)
+		r.compact(r.base + 3)
+		r.compact(r.base + 100)
+		r.get("a", clientv3.WithRev(r.base+2))
+		r.get("a", clientv3.WithRev(r.base+3))
+		r.get("a", clientv3.WithRev(r.base+4))
+		r.watch(0, "a", clientv3.WithRev(r.base+2))
+		r.watch(1, "a", clientv3.WithRev(r.base+3))
+	}},
+}
+
+// TestFakeStoreConformance checks that the in-memory store of clientv3test
+// behaves like a cluster.
+func TestFakeStoreConformance(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("the store behaves like a member, and the grpc proxy drops the reason of the rejected watches")
+	}
+	integration.BeforeTest(t)
+	s := fake.New(nil)
+	defer s.Close()
+	fakeResults := runFakeStoreScenarios(t, s.Client(), s.Client().Compact)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	clusResults := runFakeStoreScenarios(t, cli, cli.Compact)
+
+	for i, sc := range fakeStoreScenarios {
+		require.Equalf(t, clusResults[i], fakeResults[i], "scenario %q", sc.name)
+	}
+}
+
+func runFakeStoreScenarios(t *testing.T, cli *clientv3.Client, compact func(context.Context, int64, ...clientv3.CompactOption) (*clientv3.CompactResponse, error)) [][]string {
+	var results [][]string
+	for _, sc := range fakeStoreScenarios {
+		resp, err := cli.Get(t.Context(), "rev")
+		require.NoError(t, err)
+		r := &fakeStoreRecorder{
+			t:         t,
+			cli:       cli,
+			compactFn: compact,
+			prefix:    sc.name + "/",
+			base:      resp.Header.Revision,
+		}
+		sc.run(r)
+		results = append(results, r.results)
+	}
+	return results
+}
+
+// fakeStoreRecorder runs the requests of a scenario, and records their
+// results with the revisions relative to base and the lease IDs replaced by
+// their order of grant.
+type fakeStoreRecorder struct {
+	t         *testing.T
+	cli       *clientv3.Client
+	compactFn func(context.Context, int64, ...clientv3.CompactOption) (*clientv3.CompactResponse, error)
+	prefix    string
+	base      int64
+	leases    []clientv3.LeaseID
+
+	results []string
+}
+
+func (r *fakeStoreRecorder) key(k string) string { return r.prefix + k }
+
+func (r *fakeStoreRecorder) record(format string, args ...any) {
+	r.results = append(r.results, fmt.Sprintf(format, args...))
+}
+
+func (r *fakeStoreRecorder) rev(rev int64) int64 {
+	if rev == 0 {
+		return 0
+	}
+	return rev - r.base
+}
+
+func (r *fakeStoreRecorder) lease(id int64) string {
+	for i, l := range r.leases {
+		if int64(l) == id {
+			return fmt.Sprintf("lease%d", i)
+		}
+	}
+	return fmt.Sprint(id)
+}
+
+func (r *fakeStoreRecorder) kv(kv *mvccpb.KeyValue) string {
+	if kv == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%s=%q create=%d mod=%d version=%d lease=%s",
+		strings.TrimPrefix(string(kv.Key), r.prefix), kv.Value,
+		r.rev(kv.CreateRevision), r.rev(kv.ModRevision), kv.Version, r.lease(kv.Lease))
+}
+
+func (r *fakeStoreRecorder) kvs(kvs []*mvccpb.KeyValue) string {
+	var s []string
+	for _, kv := range kvs {
+		s = append(s, r.kv(kv))
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+func (r *fakeStoreRecorder) err(op string, err error) {
+	r.record("%s: error %v", op, err)
+}
+
+func (r *fakeStoreRecorder) put(key, val string, opts ...clientv3.OpOption) {
+	resp, err := r.cli.Put(r.t.Context(), r.key(key), val, opts...)
+	if err != nil {
+		r.err("put "+key, err)
+		return
+	}
+	r.record("put %s: rev=%d prev=%s", key, r.rev(resp.Header.Revision), r.kv(resp.PrevKv))
+}
+
+func (r *fakeStoreRecorder) get(key string, opts ...clientv3.OpOption) {
+	resp, err := r.cli.Get(r.t.Context(), r.key(key), opts...)
+	if err != nil {
+		r.err("get "+key, err)
+		return
+	}
+	r.record("get %s: rev=%d count=%d more=%t kvs=%s", key, r.rev(resp.Header.Revision), resp.Count, resp.More, r.kvs(resp.Kvs))
+}
+
+func (r *fakeStoreRecorder) del(key string, opts ...clientv3.OpOption) {
+	resp, err := r.cli.Delete(r.t.Context(), r.key(key), opts...)
+	if err != nil {
+		r.err("delete "+key, err)
+		return
+	}
+	r.record("delete %s: rev=%d deleted=%d prev=%s", key, r.rev(resp.Header.Revision), resp.Deleted, r.kvs(resp.PrevKvs))
+}
+
+func (r *fakeStoreRecorder) txn(cmps []clientv3.Cmp, thenOps, elseOps []clientv3.Op) {
+	resp, err := r.cli.Txn(r.t.Context()).If(cmps...).Then(thenOps...).Else(elseOps...).Commit()
+	if err != nil {
+		r.err("txn", err)
+		return
+	}
+	r.record("txn: rev=%d %s", r.rev(resp.Header.Revision), r.txnResponse((*pb.TxnResponse)(resp)))
+}
+
+func (r *fakeStoreRecorder) txnResponse(resp *pb.TxnResponse) string {
+	s := []string{fmt.Sprintf("succeeded=%t", resp.Succeeded)}
+	for _, op := range resp.Responses {
+		switch tv := op.Response.(type) {
+		case *pb.ResponseOp_ResponseRange:
+			s = append(s, fmt.Sprintf("range(count=%d kvs=%s)", tv.ResponseRange.Count, r.kvs(tv.ResponseRange.Kvs)))
+		case *pb.ResponseOp_ResponsePut:
+			s = append(s, fmt.Sprintf("put(prev=%s)", r.kv(tv.ResponsePut.PrevKv)))
+		case *pb.ResponseOp_ResponseDeleteRange:
+			s = append(s, fmt.Sprintf("delete(deleted=%d prev=%s)", tv.ResponseDeleteRange.Deleted, r.kvs(tv.ResponseDeleteRange.PrevKvs)))
+		case *pb.ResponseOp_ResponseTxn:
+			s = append(s, "txn("+r.txnResponse(tv.ResponseTxn)+")")
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func (r *fakeStoreRecorder) compact(rev int64) {
+	_, err := r.compactFn(r.t.Context(), rev)
+	if err != nil {
+		r.err(fmt.Sprintf("compact %d", r.rev(rev)), err)
+		return
+	}
+	r.record("compact %d", r.rev(rev))
+}
+
+// watch records the first n events of a watch, or its error.
+func (r *fakeStoreRecorder) watch(n int, key string, opts ...clientv3.OpOption) {
+	ctx, cancel := context.WithTimeout(r.t.Context(), 5*time.Second)
+	defer cancel()
+	var evs []string
+	for wresp := range r.cli.Watch(ctx, r.key(key), opts...) {
+		if err := wresp.Err(); err != nil {
+			r.record("watch %s: error %v compact=%d", key, err, r.rev(wresp.CompactRevision))
+			return
+		}
+		for _, ev := range wresp.Events {
+			evs = append(evs, fmt.Sprintf("%s %s prev=%s", ev.Type, r.kv(ev.Kv), r.kv(ev.PrevKv)))
+		}
+		if len(evs) >= n {
+			break
+		}
+	}
+	r.record("watch %s: %s", key, strings.Join(evs, ", "))
+}
+
+func (r *fakeStoreRecorder) grant(ttl int64) clientv3.LeaseID {
+	resp, err := r.cli.Grant(r.t.Context(), ttl)
+	require.NoError(r.t, err)
+	r.leases = append(r.leases, resp.ID)
+	r.record("grant %s: ttl=%d", r.lease(int64(resp.ID)), resp.TTL)
+	return resp.ID
+}
+
+func (r *fakeStoreRecorder) revoke(id clientv3.LeaseID) {
+	resp, err := r.cli.Revoke(r.t.Context(), id)
+	if err != nil {
+		r.err("revoke "+r.lease(int64(id)), err)
+		return
+	}
+	r.record("revoke %s: rev=%d", r.lease(int64(id)), r.rev(resp.Header.Revision))
+}
+
+func (r *fakeStoreRecorder) timeToLive(id clientv3.LeaseID) {
+	resp, err := r.cli.TimeToLive(r.t.Context(), id, clientv3.WithAttachedKeys())
+	if err != nil {
+		r.err("time to live "+r.lease(int64(id)), err)
+		return
+	}
+	var keys []string
+	for _, k := range resp.Keys {
+		keys = append(keys, strings.TrimPrefix(string(k), r.prefix))
+	}
+	// the remaining TTL depends on the time the lease was granted
+	r.record("time to live %s: granted=%d alive=%t keys=%v", r.lease(int64(id)), resp.GrantedTTL, resp.TTL > 0, keys)
+}
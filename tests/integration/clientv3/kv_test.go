@@ -24,10 +24,12 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
@@ -735,6 +737,70 @@ func TestKVPutAtMostOnce(t *testing.T) {
 	}
 }
 
+// TestKVPutWithConfirm ensures that PutWithConfirm applies a Put exactly once
+// when its response is lost, and retries it when the request is lost.
+func TestKVPutWithConfirm(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	var dropRequests, dropResponses atomic.Int32
+	lostPut := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method != "/etcdserverpb.KV/Put" {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if dropRequests.Add(-1) >= 0 {
+			return status.Error(codes.Unavailable, "request dropped")
+		}
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return err
+		}
+		if dropResponses.Add(-1) >= 0 {
+			return status.Error(codes.Unavailable, "response dropped")
+		}
+		return nil
+	}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   []string{clus.Members[0].GRPCURL},
+		DialOptions: []grpc.DialOption{grpc.WithUnaryInterceptor(lostPut)},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	dropResponses.Store(1)
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.ErrorContains(t, err, "response dropped")
+
+	dropResponses.Store(1)
+	resp, err := cli.PutWithConfirm(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, "baz", string(gresp.Kvs[0].Value))
+	require.Equal(t, int64(2), gresp.Kvs[0].Version)
+	require.Equal(t, gresp.Kvs[0].ModRevision, resp.Header.Revision)
+
+	dropRequests.Store(2)
+	resp, err = cli.PutWithConfirm(t.Context(), "foo", "qux")
+	require.NoError(t, err)
+	gresp, err = cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, "qux", string(gresp.Kvs[0].Value))
+	require.Equal(t, int64(3), gresp.Kvs[0].Version)
+	require.Equal(t, gresp.Kvs[0].ModRevision, resp.Header.Revision)
+
+	// errors that are known to not apply the Put are not retried
+	_, err = cli.PutWithConfirm(t.Context(), "foo", "bar", clientv3.WithLease(clientv3.LeaseID(1)))
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	// the Put is given up after a bounded number of attempts, even without a
+	// deadline
+	dropRequests.Store(100)
+	_, err = cli.PutWithConfirm(context.Background(), "foo", "bar")
+	require.ErrorContains(t, err, "request dropped")
+	require.Equal(t, int32(95), dropRequests.Load())
+}
+
 // TestKVLargeRequests tests various client/server side request limits.
 func TestKVLargeRequests(t *testing.T) {
 	integration.BeforeTest(t)
@@ -292,6 +292,106 @@ func TestLeaseKeepAliveNotFound(t *testing.T) {
 	}
 }
 
+// TestLeaseTransfer ensures that after a lease is transferred, keepalives from
+// the previous holder are rejected while the new holder keeps the lease alive.
+func TestLeaseTransfer(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	blue, green := clus.Client(1), clus.Client(2)
+
+	gresp, err := blue.Grant(t.Context(), 5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), gresp.Epoch)
+	_, err = blue.Put(t.Context(), "foo", "bar", clientv3.WithLease(gresp.ID))
+	require.NoError(t, err)
+	bch, err := blue.KeepAlive(t.Context(), gresp.ID)
+	require.NoError(t, err)
+	<-bch
+
+	tresp, err := green.Transfer(t.Context(), gresp.ID)
+	require.NoError(t, err)
+	require.Equal(t, gresp.ID, tresp.ID)
+	require.Equal(t, int64(5), tresp.TTL)
+	require.Equal(t, uint64(2), tresp.Epoch)
+	gch, err := green.KeepAlive(t.Context(), gresp.ID)
+	require.NoError(t, err)
+
+	_, err = blue.KeepAliveOnce(t.Context(), gresp.ID)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseNotFound)
+
+	timer := time.After(10 * time.Second)
+	for closed := false; !closed; {
+		select {
+		case _, ok := <-bch:
+			closed = !ok
+		case <-timer:
+			t.Fatal("expected the previous holder's keepalive to close")
+		}
+	}
+
+	select {
+	case resp, ok := <-gch:
+		require.True(t, ok, "expected the new holder's keepalive to stay open")
+		require.Positive(t, resp.TTL)
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected a keepalive response for the new holder")
+	}
+
+	// keys attached to the lease survive the transfer
+	resp, err := green.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	require.Equal(t, int64(gresp.ID), resp.Kvs[0].Lease)
+}
+
+func TestLeaseWatch(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	recv := func(wch clientv3.LeaseWatchChan) clientv3.LeaseWatchResponse {
+		select {
+		case wresp, ok := <-wch:
+			require.True(t, ok, "expected the lease watch to stay open")
+			require.NoError(t, wresp.Err())
+			return wresp
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for a lease watch response")
+		}
+		return clientv3.LeaseWatchResponse{}
+	}
+	// events are sent by every member, whichever is the leader
+	all := clus.Client(1).WatchLeases(t.Context(), clientv3.NoLease)
+	require.Empty(t, recv(all).Events)
+
+	revoked, err := clus.Client(0).Grant(t.Context(), 10)
+	require.NoError(t, err)
+	expired, err := clus.Client(0).Grant(t.Context(), 1)
+	require.NoError(t, err)
+	one := clus.Client(2).WatchLeases(t.Context(), expired.ID)
+	require.Empty(t, recv(one).Events)
+	_, err = clus.Client(0).Revoke(t.Context(), revoked.ID)
+	require.NoError(t, err)
+
+	var events []clientv3.LeaseEvent
+	for len(events) < 4 {
+		events = append(events, recv(all).Events...)
+	}
+	require.Equal(t, []clientv3.LeaseEvent{
+		{Type: clientv3.LeaseEventGranted, ID: revoked.ID, TTL: 10},
+		{Type: clientv3.LeaseEventGranted, ID: expired.ID, TTL: 1},
+		{Type: clientv3.LeaseEventRevoked, ID: revoked.ID, TTL: 10},
+		{Type: clientv3.LeaseEventExpired, ID: expired.ID, TTL: 1},
+	}, events)
+	require.Equal(t, []clientv3.LeaseEvent{
+		{Type: clientv3.LeaseEventExpired, ID: expired.ID, TTL: 1},
+	}, recv(one).Events)
+}
+
 func TestLeaseGrantErrConnClosed(t *testing.T) {
 	integration.BeforeTest(t)
 
@@ -508,6 +608,68 @@ func TestLeaseKeepAliveTTLTimeout(t *testing.T) {
 	clus.Members[0].Restart(t)
 }
 
+// TestLeaseKeepAliveStallHandler ensures the stall handler is called before
+// the keep alive channel closes if keep alive requests get no response.
+func TestLeaseKeepAliveStallHandler(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	type stall struct {
+		id           clientv3.LeaseID
+		lastResponse time.Time
+	}
+	stallc := make(chan stall, 1)
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints: clus.Client(0).Endpoints(),
+		LeaseStallHandler: func(id clientv3.LeaseID, lastResponse time.Time) {
+			stallc <- stall{id, lastResponse}
+		},
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	resp, err := cli.Grant(t.Context(), 5)
+	require.NoError(t, err)
+	rc, kerr := cli.KeepAlive(t.Context(), resp.ID)
+	require.NoError(t, kerr)
+	kresp := <-rc
+	require.Equalf(t, kresp.ID, resp.ID, "ID = %x, want %x", kresp.ID, resp.ID)
+	select {
+	case st := <-stallc:
+		t.Fatalf("unexpected stall of lease %x", st.id)
+	case <-time.After(2 * time.Second):
+	}
+
+	// keep client disconnected
+	stopped := time.Now()
+	clus.Members[0].Stop(t)
+	timer := time.After(10 * time.Second)
+	for stalled := false; !stalled; {
+		select {
+		case st := <-stallc:
+			require.Equal(t, resp.ID, st.id)
+			require.True(t, st.lastResponse.Before(stopped), "last response %v after the member stopped at %v", st.lastResponse, stopped)
+			stalled = true
+		case _, ok := <-rc:
+			// responses received before the member stopped may be queued
+			require.Truef(t, ok, "keepalive channel closed before the stall handler was called")
+		case <-timer:
+			t.Fatalf("stall handler was not called")
+		}
+	}
+	for kresp != nil {
+		select {
+		case kresp = <-rc:
+		case <-timer:
+			t.Fatalf("keepalive channel did not close")
+		}
+	}
+
+	clus.Members[0].Restart(t)
+}
+
 func TestLeaseTimeToLive(t *testing.T) {
 	integration.BeforeTest(t)
 
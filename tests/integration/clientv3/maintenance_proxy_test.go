@@ -0,0 +1,57 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build cluster_proxy
+
+package clientv3test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestMaintenanceProxyTargetMember ensures that the proxy forwards the Status
+// and HashKV requests with a target member to that member.
+func TestMaintenanceProxyTargetMember(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	// the proxy reaches the members through the endpoints of its client
+	cli, err := clus.ClusterClient(t)
+	require.NoError(t, err)
+	mc := integration.ToGRPC(cli).Maintenance
+	for _, m := range clus.Members {
+		for _, target := range []string{m.Name, m.ID().String()} {
+			ctx := clientv3.WithTargetMember(t.Context(), target)
+			sresp, err := mc.Status(ctx, &pb.StatusRequest{})
+			require.NoError(t, err)
+			require.Equal(t, uint64(m.ID()), sresp.Header.MemberId)
+
+			hresp, err := mc.HashKV(ctx, &pb.HashKVRequest{})
+			require.NoError(t, err)
+			require.Equal(t, uint64(m.ID()), hresp.Header.MemberId)
+		}
+	}
+
+	_, err = mc.Status(clientv3.WithTargetMember(t.Context(), "unknown"), &pb.StatusRequest{})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCMemberNotFound)
+}
@@ -24,6 +24,9 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -214,6 +217,18 @@ func TestMaintenanceSnapshotWithVersionTimeout(t *testing.T) {
 	})
 }
 
+// TestMaintenanceSnapshotWithProgressTimeout ensures that SnapshotWithProgress function
+// returns corresponding context errors when context timeout happened before snapshot reading
+func TestMaintenanceSnapshotWithProgressTimeout(t *testing.T) {
+	testMaintenanceSnapshotTimeout(t, func(ctx context.Context, client *clientv3.Client) (io.ReadCloser, error) {
+		resp, _, err := client.SnapshotWithProgress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Snapshot, nil
+	})
+}
+
 // TestMaintenanceSnapshotTimeout ensures that Snapshot function
 // returns corresponding context errors when context timeout happened before snapshot reading
 func TestMaintenanceSnapshotTimeout(t *testing.T) {
@@ -280,6 +295,18 @@ func TestMaintenanceSnapshotWithVersionErrorInflight(t *testing.T) {
 	})
 }
 
+// TestMaintenanceSnapshotWithProgressErrorInflight ensures that ReaderCloser returned by SnapshotWithProgress function
+// will fail to read with corresponding context errors on inflight context cancel timeout.
+func TestMaintenanceSnapshotWithProgressErrorInflight(t *testing.T) {
+	testMaintenanceSnapshotErrorInflight(t, func(ctx context.Context, client *clientv3.Client) (io.ReadCloser, error) {
+		resp, _, err := client.SnapshotWithProgress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Snapshot, nil
+	})
+}
+
 // TestMaintenanceSnapshotErrorInflight ensures that ReaderCloser returned by Snapshot function
 // will fail to read with corresponding context errors on inflight context cancel timeout.
 func TestMaintenanceSnapshotErrorInflight(t *testing.T) {
@@ -411,6 +438,39 @@ func TestMaintenanceSnapshotContentDigest(t *testing.T) {
 	require.Equal(t, checksumInBytes, actualChecksum)
 }
 
+// TestMaintenanceSnapshotWithProgress ensures that SnapshotWithProgress
+// verifies the digest of the snapshot and reports the progress of the read.
+func TestMaintenanceSnapshotWithProgress(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	populateDataIntoCluster(t, clus, 3, 1024*1024)
+
+	resp, progressc, err := clus.RandClient().SnapshotWithProgress(t.Context())
+	require.NoError(t, err)
+
+	var last clientv3.SnapshotProgress
+	progressDone := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		for p := range progressc {
+			last = p
+		}
+	}()
+
+	snapSize, err := io.Copy(io.Discard, resp.Snapshot)
+	require.NoError(t, err)
+	require.NoError(t, resp.Snapshot.Close())
+
+	<-progressDone
+	assert.Equal(t, snapSize, last.BytesRead)
+	assert.Equal(t, snapSize, last.TotalHint)
+	// the digest is still returned
+	assert.Equal(t, int64(sha256.Size), snapSize%512)
+}
+
 func TestMaintenanceStatus(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -461,6 +521,8 @@ func TestMaintenanceStatus(t *testing.T) {
 				require.NoError(t, err)
 				t.Logf("Response from %v: %v", i, resp)
 				require.Equal(t, tc.expectedQuota, resp.DbSizeQuota)
+				require.Equal(t, runtime.Version(), resp.GoVersion)
+				require.Equal(t, version.GitSHA, resp.GitSha)
 				if prevID == 0 {
 					prevID, leaderFound = resp.Header.MemberId, resp.Header.MemberId == resp.Leader
 					continue
@@ -481,3 +543,150 @@ func TestMaintenanceStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestMaintenanceStatusAll(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	clus.WaitLeader(t)
+
+	eps := make([]string, 3)
+	for i := 0; i < 3; i++ {
+		eps[i] = clus.Members[i].GRPCURL
+	}
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: eps})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	statuses, err := cli.StatusAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for i, st := range statuses {
+		require.NoError(t, st.Err)
+		assert.Equal(t, eps[i], st.Endpoint)
+		assert.Equal(t, uint64(clus.Members[i].ID()), st.Status.Header.MemberId)
+	}
+
+	// an endpoint that fails does not fail the others
+	clus.Members[2].Stop(t)
+	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Second)
+	defer cancel()
+	statuses, err = cli.StatusAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	for _, st := range statuses[:2] {
+		require.NoError(t, st.Err)
+	}
+	require.Error(t, statuses[2].Err)
+	assert.Nil(t, statuses[2].Status)
+	assert.Equal(t, eps[2], statuses[2].Endpoint)
+}
+
+func TestMaintenanceDefragmentCluster(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	lead := clus.WaitLeader(t)
+
+	eps := make([]string, 3)
+	for i := 0; i < 3; i++ {
+		eps[i] = clus.Members[i].GRPCURL
+	}
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: eps})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	// fragment the backend of every member
+	val := strings.Repeat("a", 10*1024)
+	for i := 0; i < 100; i++ {
+		_, err = cli.Put(t.Context(), fmt.Sprintf("foo%d", i), val)
+		require.NoError(t, err)
+	}
+	resp, err := cli.Delete(t.Context(), "foo", clientv3.WithPrefix())
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), resp.Header.Revision, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	// the cluster keeps serving writes while it is defragmented
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			if _, err := cli.Put(ctx, "bar", "baz"); err != nil && ctx.Err() == nil {
+				t.Errorf("failed to put while defragmenting: %v", err)
+				return
+			}
+		}
+	}()
+
+	dctx, dcancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer dcancel()
+	reports, err := cli.DefragmentCluster(dctx)
+	cancel()
+	wg.Wait()
+	require.NoError(t, err)
+
+	require.Len(t, reports, 3)
+	for _, r := range reports {
+		require.NoError(t, r.Err)
+		assert.False(t, r.Skipped)
+		assert.Less(t, r.DbSizeAfter, r.DbSizeBefore)
+		assert.NotZero(t, r.Duration)
+	}
+	// the leader is defragmented last
+	assert.Equal(t, uint64(clus.Members[lead].ID()), reports[2].MemberID)
+	assert.True(t, reports[2].IsLeader)
+	assert.Equal(t, eps[lead], reports[2].Endpoint)
+}
+
+func TestMaintenanceStatusCompactRevision(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ep := clus.Members[0].GRPCURL
+
+	resp, err := cli.Status(t.Context(), ep)
+	require.NoError(t, err)
+	require.Zero(t, resp.CompactRevision)
+
+	for i := 0; i < 3; i++ {
+		_, err = cli.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+	}
+	_, err = cli.Compact(t.Context(), 3, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	resp, err = cli.Status(t.Context(), ep)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.CompactRevision)
+}
+
+func TestMaintenanceStatusDiskUsage(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, SnapshotCount: 10})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ep := clus.Members[0].GRPCURL
+
+	for i := 0; i < 20; i++ {
+		_, err := cli.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+	}
+
+	resp, err := cli.Status(t.Context(), ep)
+	require.NoError(t, err)
+	du := resp.DiskUsage
+	require.NotNil(t, du)
+	require.Positive(t, du.GetWalFileCount())
+	require.Positive(t, du.GetWalSize())
+	require.Positive(t, du.GetSnapshotFileCount())
+	require.Positive(t, du.GetSnapshotSize())
+}
@@ -21,9 +21,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/mirror"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
@@ -121,3 +123,93 @@ func TestMirrorSyncBase(t *testing.T) {
 		t.Errorf("unexpected kv count: %d", count)
 	}
 }
+
+func TestMirrorSyncDestPrefix(t *testing.T) {
+	integration.BeforeTest(t)
+
+	src := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer src.Terminate(t)
+	// listen on TCP, as the unix socket names of the members of both clusters
+	// would collide
+	dst := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseTCP: true})
+	defer dst.Terminate(t)
+
+	sc, dc := src.Client(0), dst.Client(0)
+	ctx := t.Context()
+
+	binaryKey := "/prod/\x00\xff\xfe/prod/"
+	for _, key := range []string{"/prod/", "/prod/a", binaryKey, "/prodx", "/other"} {
+		_, err := sc.Put(ctx, key, "v"+key)
+		require.NoError(t, err)
+	}
+
+	var progress []int64
+	syncer := mirror.NewSyncer(sc, "/prod/", 0,
+		mirror.WithDestPrefix("/staging-restore/"),
+		mirror.WithProgress(func(keysSynced int64, currentRev int64) {
+			progress = append(progress, keysSynced)
+			assert.Equal(t, int64(6), currentRev)
+		}),
+	)
+	gch, ech := syncer.SyncBase(ctx)
+	for g := range gch {
+		for _, kv := range g.Kvs {
+			_, err := dc.Put(ctx, string(kv.Key), string(kv.Value))
+			require.NoError(t, err)
+		}
+	}
+	for e := range ech {
+		t.Fatalf("unexpected error %v", e)
+	}
+	require.Equal(t, []int64{3}, progress)
+	require.Equal(t, map[string]string{
+		"/staging-restore/":                   "v/prod/",
+		"/staging-restore/a":                  "v/prod/a",
+		"/staging-restore/\x00\xff\xfe/prod/": "v" + binaryKey,
+	}, mirroredKVs(t, dc))
+
+	wch := syncer.SyncUpdates(ctx)
+	_, err := sc.Put(ctx, "/prod/b", "v/prod/b")
+	require.NoError(t, err)
+	_, err = sc.Delete(ctx, binaryKey)
+	require.NoError(t, err)
+	_, err = sc.Delete(ctx, "/prod/")
+	require.NoError(t, err)
+
+	for n := 0; n < 3; {
+		select {
+		case wr := <-wch:
+			for _, ev := range wr.Events {
+				switch ev.Type {
+				case mvccpb.Event_PUT:
+					_, err = dc.Put(ctx, string(ev.Kv.Key), string(ev.Kv.Value))
+				case mvccpb.Event_DELETE:
+					_, err = dc.Delete(ctx, string(ev.Kv.Key))
+				}
+				require.NoError(t, err)
+				n++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("failed to receive updates in five seconds")
+		}
+	}
+
+	require.Equal(t, map[string]string{
+		"/staging-restore/a": "v/prod/a",
+		"/staging-restore/b": "v/prod/b",
+	}, mirroredKVs(t, dc))
+
+	resp, err := sc.Get(ctx, "/prod/a")
+	require.NoError(t, err)
+	require.Equal(t, "/prod/a", string(resp.Kvs[0].Key), "the source keys must not be rewritten")
+}
+
+func mirroredKVs(t *testing.T, c *clientv3.Client) map[string]string {
+	resp, err := c.Get(t.Context(), "\x00", clientv3.WithFromKey())
+	require.NoError(t, err)
+	kvs := make(map[string]string)
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = string(kv.Value)
+	}
+	return kvs
+}
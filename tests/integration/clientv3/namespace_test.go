@@ -16,9 +16,12 @@ package clientv3test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -78,3 +81,108 @@ func TestNamespaceWatch(t *testing.T) {
 	// let client close teardown namespace watch
 	c.Watcher = nsWatcher
 }
+
+// TestNamespaceResponsesUnprefixed ensures that a namespaced client does not
+// see the prefix in the keys of any response.
+func TestNamespaceResponsesUnprefixed(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsKV := namespace.NewKV(c.KV, "foo/")
+	nsWatcher := namespace.NewWatcher(c.Watcher, "foo/")
+	nsLease := namespace.NewLease(c.Lease, "foo/")
+	defer nsWatcher.Close()
+
+	requireUnprefixed := func(kvs ...*mvccpb.KeyValue) {
+		t.Helper()
+		for _, kv := range kvs {
+			require.False(t, strings.HasPrefix(string(kv.Key), "foo/"), "key %q is prefixed", kv.Key)
+		}
+	}
+
+	lresp, err := nsLease.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	wch := nsWatcher.Watch(t.Context(), "", clientv3.WithPrefix(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	<-wch
+
+	_, err = nsKV.Put(t.Context(), "a", "1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	// a key outside of the namespace attached to the same lease
+	_, err = c.Put(t.Context(), "bar/a", "1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+
+	presp, err := nsKV.Put(t.Context(), "a", "2", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	requireUnprefixed(presp.PrevKv)
+	gresp, err := nsKV.Get(t.Context(), "", clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Len(t, gresp.Kvs, 1)
+	requireUnprefixed(gresp.Kvs...)
+	_, err = nsKV.Put(t.Context(), "b", "1")
+	require.NoError(t, err)
+	_, err = nsKV.Put(t.Context(), "c", "1")
+	require.NoError(t, err)
+	tresp, err := nsKV.Txn(t.Context()).Then(
+		clientv3.OpGet("a"),
+		clientv3.OpTxn(nil, []clientv3.Op{clientv3.OpPut("b", "2", clientv3.WithPrevKV())}, nil),
+		clientv3.OpDelete("c", clientv3.WithPrevKV()),
+	).Commit()
+	require.NoError(t, err)
+	requireUnprefixed(tresp.Responses[0].GetResponseRange().Kvs...)
+	requireUnprefixed(tresp.Responses[1].GetResponseTxn().Responses[0].GetResponsePut().PrevKv)
+	requireUnprefixed(tresp.Responses[2].GetResponseDeleteRange().PrevKvs...)
+	dresp, err := nsKV.Delete(t.Context(), "a", clientv3.WithPrevKV())
+	require.NoError(t, err)
+	requireUnprefixed(dresp.PrevKvs...)
+
+	for events := 0; events < 7; {
+		wresp := <-wch
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			requireUnprefixed(ev.Kv)
+			if ev.PrevKv != nil {
+				requireUnprefixed(ev.PrevKv)
+			}
+			events++
+		}
+	}
+
+	_, err = nsKV.Put(t.Context(), "c", "1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	ttl, err := nsLease.TimeToLive(t.Context(), lresp.ID, clientv3.WithAttachedKeys())
+	require.NoError(t, err)
+	// the key outside of the namespace is not returned
+	require.Equal(t, [][]byte{[]byte("c")}, ttl.Keys)
+}
+
+// TestNamespaceMaintenance ensures that a namespaced maintenance client does
+// not return snapshots, which hold the keys of every namespace, and passes
+// the other requests through.
+func TestNamespaceMaintenance(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	c := clus.Client(0)
+	nsMaintenance := namespace.NewMaintenance(c.Maintenance, "foo/")
+
+	_, err := nsMaintenance.Snapshot(t.Context())
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = nsMaintenance.SnapshotWithVersion(t.Context())
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, _, err = nsMaintenance.SnapshotWithProgress(t.Context())
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	ep := clus.Members[0].GRPCURL
+	sresp, err := nsMaintenance.Status(t.Context(), ep)
+	require.NoError(t, err)
+	require.NotZero(t, sresp.Header.Revision)
+	_, err = nsMaintenance.Defragment(t.Context(), ep)
+	require.NoError(t, err)
+	_, err = nsMaintenance.HashKV(t.Context(), ep, 0)
+	require.NoError(t, err)
+}
@@ -79,6 +79,35 @@ func TestEndpointManager(t *testing.T) {
 	require.Truef(t, reflect.DeepEqual(us[0], wu), "up = %#v, want %#v", us[0], wu)
 }
 
+// TestEndpointManagerModify ensures that overwriting an endpoint, for example
+// to change its metadata, is watched as a modification.
+func TestEndpointManagerModify(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	em, err := endpoints.NewManager(clus.RandClient(), "foo")
+	require.NoError(t, err)
+	e1 := endpoints.Endpoint{Addr: "127.0.0.1", Metadata: "metadata"}
+	require.NoError(t, em.AddEndpoint(t.Context(), "foo/a1", e1))
+
+	ctx, watchCancel := context.WithCancel(t.Context())
+	defer watchCancel()
+	w, err := em.NewWatchChannel(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Add, Key: "foo/a1", Endpoint: e1}}, <-w)
+
+	e1.Metadata = "new metadata"
+	require.NoError(t, em.AddEndpoint(t.Context(), "foo/a1", e1))
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Modify, Key: "foo/a1", Endpoint: e1}}, <-w)
+
+	require.NoError(t, em.DeleteEndpoint(t.Context(), "foo/a1"))
+	require.Equal(t, endpoints.Delete, (<-w)[0].Op)
+	require.NoError(t, em.AddEndpoint(t.Context(), "foo/a1", e1))
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Add, Key: "foo/a1", Endpoint: e1}}, <-w)
+}
+
 // TestEndpointManagerAtomicity ensures the resolver will initialize
 // correctly with multiple hosts and correctly receive multiple
 // updates in a single revision.
@@ -0,0 +1,124 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package naming_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	etcd "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/naming/endpoints"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestEndpointManagerRegister(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+
+	em, err := endpoints.NewManager(cli, "foo")
+	require.NoError(t, err)
+	w, err := em.NewWatchChannel(t.Context())
+	require.NoError(t, err)
+
+	_, err = em.Register(t.Context(), "bar/a1", endpoints.Endpoint{Addr: "127.0.0.1"}, 60)
+	require.Error(t, err)
+
+	e1 := endpoints.Endpoint{Addr: "127.0.0.1", Metadata: "metadata"}
+	// the TTL is long enough for the test to fail if the key is only removed
+	// when the lease expires
+	r, err := em.Register(t.Context(), "foo/a1", e1, 60)
+	require.NoError(t, err)
+
+	ups := recvUpdates(t, w)
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Add, Key: "foo/a1", Endpoint: e1}}, ups)
+	leaseID := mustGetLease(t, cli)
+
+	// the key is put again while the session is alive
+	_, err = cli.Delete(t.Context(), "foo/a1")
+	require.NoError(t, err)
+	require.Equal(t, endpoints.Delete, recvUpdates(t, w)[0].Op)
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Add, Key: "foo/a1", Endpoint: e1}}, recvUpdates(t, w))
+	require.Equal(t, leaseID, mustGetLease(t, cli))
+
+	// killing the session registers the endpoint with a new one
+	_, err = cli.Revoke(t.Context(), leaseID)
+	require.NoError(t, err)
+	require.Equal(t, endpoints.Delete, recvUpdates(t, w)[0].Op)
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Add, Key: "foo/a1", Endpoint: e1}}, recvUpdates(t, w))
+	require.NotEqual(t, leaseID, mustGetLease(t, cli))
+
+	require.NoError(t, r.Deregister(t.Context()))
+	ups = recvUpdates(t, w)
+	require.Equal(t, []*endpoints.Update{{Op: endpoints.Delete, Key: "foo/a1"}}, ups)
+	select {
+	case <-r.Done():
+	default:
+		t.Fatal("Done is not closed after Deregister")
+	}
+	require.NoError(t, r.Deregister(t.Context()))
+
+	resp, err := cli.Get(t.Context(), "foo", etcd.WithPrefix())
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+	leases, err := cli.Leases(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, leases.Leases)
+}
+
+func TestEndpointManagerRegisterClientClose(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+
+	em, err := endpoints.NewManager(cli, "foo")
+	require.NoError(t, err)
+	r, err := em.Register(t.Context(), "foo/a1", endpoints.Endpoint{Addr: "127.0.0.1"}, 5)
+	require.NoError(t, err)
+
+	cli.Close()
+	clus.TakeClient(0)
+	select {
+	case <-r.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Done is not closed after the client is closed")
+	}
+}
+
+func recvUpdates(t *testing.T, w endpoints.WatchChannel) []*endpoints.Update {
+	t.Helper()
+	select {
+	case ups, ok := <-w:
+		require.True(t, ok, "watch channel closed")
+		return ups
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for endpoint updates")
+	}
+	return nil
+}
+
+func mustGetLease(t *testing.T, cli *etcd.Client) etcd.LeaseID {
+	t.Helper()
+	resp, err := cli.Get(t.Context(), "foo/a1")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	return etcd.LeaseID(resp.Kvs[0].Lease)
+}
@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/peer"
 
 	"go.etcd.io/etcd/client/v3/naming/endpoints"
 	"go.etcd.io/etcd/client/v3/naming/resolver"
@@ -140,6 +141,61 @@ func TestEtcdGrpcResolverRoundRobin(t *testing.T) {
 	testEtcdGRPCResolver(t, "round_robin")
 }
 
+// TestEtcdGrpcResolverWeightedRoundRobin tests that endpoints registered
+// with a weight receive a proportional share of requests.
+func TestEtcdGrpcResolverWeightedRoundRobin(t *testing.T) {
+	integration.BeforeTest(t)
+
+	s1 := grpctesting.NewDummyStubServer([]byte{'1'})
+	require.NoError(t, s1.Start(nil))
+	defer s1.Stop()
+	s2 := grpctesting.NewDummyStubServer([]byte{'2'})
+	require.NoError(t, s2.Start(nil))
+	defer s2.Stop()
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	em, err := endpoints.NewManager(clus.Client(0), "foo")
+	require.NoError(t, err)
+	require.NoError(t, em.AddEndpoint(t.Context(), "foo/e1", endpoints.Endpoint{Addr: s1.Addr(), Weight: 3}))
+	require.NoError(t, em.AddEndpoint(t.Context(), "foo/e2", endpoints.Endpoint{Addr: s2.Addr()}))
+
+	epts, err := em.List(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), epts["foo/e1"].Weight)
+	assert.Equal(t, uint32(0), epts["foo/e2"].Weight)
+
+	b, err := resolver.NewBuilder(clus.Client(0))
+	require.NoError(t, err)
+	conn, err := grpc.NewClient("etcd:///foo", grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithResolvers(b),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, resolver.WeightedRoundRobin)))
+	require.NoError(t, err)
+	defer conn.Close()
+	c := testpb.NewTestServiceClient(conn)
+
+	call := func() string {
+		var p peer.Peer
+		_, err := c.UnaryCall(t.Context(), &testpb.SimpleRequest{}, grpc.WaitForReady(true), grpc.Peer(&p))
+		require.NoError(t, err)
+		return p.Addr.String()
+	}
+
+	// wait until both endpoints are ready and picked
+	seen := make(map[string]bool)
+	for i := 0; len(seen) < 2; i++ {
+		require.Lessf(t, i, 1000, "only reached %v", seen)
+		seen[call()] = true
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		counts[call()]++
+	}
+	assert.InEpsilonf(t, 300, counts[s1.Addr()], 0.1, "unexpected distribution %v", counts)
+	assert.InEpsilonf(t, 100, counts[s2.Addr()], 0.1, "unexpected distribution %v", counts)
+}
+
 func TestEtcdEndpointManager(t *testing.T) {
 	integration.BeforeTest(t)
 
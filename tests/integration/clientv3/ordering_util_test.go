@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -139,3 +140,88 @@ func TestUnresolvableOrderViolation(t *testing.T) {
 	_, err = OrderingKv.Get(ctx, "foo", clientv3.WithSerializable())
 	require.ErrorIsf(t, err, ordering.ErrNoGreaterRev, "expected %v, got %v", ordering.ErrNoGreaterRev, err)
 }
+
+// TestEndpointSwitchSuspectsStaleEndpoint ensures that the KV of
+// NewKVWithEndpointSwitch sends the reads served stale by a partitioned member
+// to the other members, and returns ErrNoGreaterRev once only the partitioned
+// member is left.
+func TestEndpointSwitchSuspectsStaleEndpoint(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	stale := (lead + 1) % 3
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[lead].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+	ctx := t.Context()
+
+	_, err = cli.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	// ensure that the stale member has caught up with the first put
+	_, err = clus.Client(stale).Get(ctx, "foo")
+	require.NoError(t, err)
+
+	var others []*integration.Member
+	for i, m := range clus.Members {
+		if i != stale {
+			others = append(others, m)
+		}
+	}
+	clus.Members[stale].InjectPartition(t, others...)
+	time.Sleep(1 * time.Second) // give enough time for the operation
+
+	// the update to "foo" is not replicated to the partitioned member
+	_, err = cli.Put(ctx, "foo", "buzz")
+	require.NoError(t, err)
+
+	orderingKv := ordering.NewKVWithEndpointSwitch(cli)
+	// set prevRev to the revision of the leader
+	resp, err := orderingKv.Get(ctx, "foo")
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+
+	cli.SetEndpoints(clus.Members[0].GRPCURL, clus.Members[1].GRPCURL, clus.Members[2].GRPCURL)
+	time.Sleep(1 * time.Second) // give enough time for the operation
+	violations := gatherOrderingViolations(t)
+	for range 10 {
+		resp, err = orderingKv.Get(ctx, "foo", clientv3.WithSerializable())
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, resp.Header.Revision, rev)
+		require.Equal(t, "buzz", string(resp.Kvs[0].Value))
+	}
+	var stales []string
+	for ep, v := range gatherOrderingViolations(t) {
+		if v > violations[ep] {
+			// the partitioned member is suspected after its first stale
+			// response
+			require.InDelta(t, 1, v-violations[ep], 0)
+			stales = append(stales, ep)
+		}
+	}
+	require.Len(t, stales, 1, "expected a violation from the partitioned member only")
+
+	t.Logf("Reconfigure client to speak only to the 'partitioned' member")
+	cli.SetEndpoints(clus.Members[stale].GRPCURL)
+	time.Sleep(1 * time.Second) // give enough time for the operation
+	_, err = orderingKv.Get(ctx, "foo", clientv3.WithSerializable())
+	require.ErrorIs(t, err, ordering.ErrNoGreaterRev)
+}
+
+// gatherOrderingViolations returns the ordering violations of the clients by
+// endpoint.
+func gatherOrderingViolations(t *testing.T) map[string]float64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	violations := make(map[string]float64)
+	for _, mf := range mfs {
+		if mf.GetName() != "etcd_client_ordering_violations_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			violations[m.GetLabel()[0].GetValue()] = m.GetCounter().GetValue()
+		}
+	}
+	return violations
+}
@@ -0,0 +1,390 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestPagerPinsRevision(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys(t, cli, 20)
+	p := clientv3.NewPager(cli, "key", 5, clientv3.WithPrefix())
+	resp, err := p.Next(t.Context())
+	require.NoError(t, err)
+	require.True(t, resp.More)
+	require.NotEmpty(t, resp.NextCursor)
+
+	// writes after the first page are not seen by the following ones
+	_, err = cli.Put(t.Context(), "key010a", "v")
+	require.NoError(t, err)
+	_, err = cli.Delete(t.Context(), "key015")
+	require.NoError(t, err)
+
+	keys := pageKeys(t, p, resp)
+	require.Equal(t, keyNames(20), keys)
+	require.False(t, p.ConsistencyDowngraded())
+
+	// a cursor requires the default key ordering
+	_, err = cli.Get(t.Context(), "key", clientv3.WithPrefix(), clientv3.WithCursor(resp.NextCursor), clientv3.WithSort(clientv3.SortByValue, clientv3.SortDescend))
+	require.ErrorIs(t, err, rpctypes.ErrInvalidRangeCursor)
+}
+
+func TestPagerAutoCompaction(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{
+		Size:                    1,
+		AutoCompactionMode:      v3compactor.ModePeriodic,
+		AutoCompactionRetention: time.Second,
+	})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys(t, cli, 50)
+	p := clientv3.NewPager(cli, "key", 5, clientv3.WithPrefix())
+	resp, err := p.Next(t.Context())
+	require.NoError(t, err)
+	pinned := resp.Header.Revision
+
+	// keep writing until the auto compaction removes the pinned revision
+	require.Eventually(t, func() bool {
+		_, perr := cli.Put(t.Context(), "other", "v")
+		require.NoError(t, perr)
+		_, gerr := cli.Get(t.Context(), "key", clientv3.WithPrefix(), clientv3.WithRev(pinned))
+		return errors.Is(gerr, rpctypes.ErrCompacted)
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the iteration continues past the compaction at the same key boundary
+	keys := pageKeys(t, p, resp)
+	require.Equal(t, keyNames(50), keys)
+	require.True(t, p.ConsistencyDowngraded())
+}
+
+func TestPagedGet(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys(t, cli, 25)
+	_, err := cli.Put(t.Context(), "other", "v")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		key   string
+		opts  []clientv3.OpOption
+		pages int
+		want  []string
+	}{
+		{
+			name:  "prefix",
+			key:   "key",
+			opts:  []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithLimit(10)},
+			pages: 3,
+			want:  keyNames(25),
+		},
+		{
+			name:  "prefix ending on a page boundary",
+			key:   "key",
+			opts:  []clientv3.OpOption{clientv3.WithPrefix(), clientv3.WithLimit(5)},
+			pages: 5,
+			want:  keyNames(25),
+		},
+		{
+			name:  "range",
+			key:   "key005",
+			opts:  []clientv3.OpOption{clientv3.WithRange("key012"), clientv3.WithLimit(3)},
+			pages: 3,
+			want:  keyNames(12)[5:],
+		},
+		{
+			name:  "from key",
+			key:   "key020",
+			opts:  []clientv3.OpOption{clientv3.WithFromKey(), clientv3.WithLimit(4)},
+			pages: 2,
+			want:  append(keyNames(25)[20:], "other"),
+		},
+		{
+			name:  "default page size",
+			key:   "key",
+			opts:  []clientv3.OpOption{clientv3.WithPrefix()},
+			pages: 1,
+			want:  keyNames(25),
+		},
+		{
+			name:  "single key",
+			key:   "key007",
+			pages: 1,
+			want:  []string{"key007"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			it := cli.NewPagedGet(tc.key, tc.opts...)
+			keys, pages := pagedKeys(t, it)
+			require.Equal(t, tc.want, keys)
+			require.Equal(t, tc.pages, pages)
+		})
+	}
+
+	_, err = cli.NewPagedGet("key", clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend)).Next(t.Context())
+	require.Error(t, err)
+}
+
+func TestPagedGetConcurrentWrites(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys(t, cli, 100)
+	it := cli.NewPagedGet("key", clientv3.WithPrefix(), clientv3.WithLimit(7))
+	first, err := it.Next(t.Context())
+	require.NoError(t, err)
+	rev := it.Revision()
+
+	// keep adding and deleting keys of the range during the iteration
+	ctx, cancel := context.WithCancel(t.Context())
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for i := 0; ctx.Err() == nil; i++ {
+			cli.Put(ctx, fmt.Sprintf("key%03da", i%100), "v")
+			cli.Delete(ctx, fmt.Sprintf("key%03d", (i*7)%100))
+		}
+	}()
+
+	keys := []string{}
+	for _, kv := range first.Kvs {
+		keys = append(keys, string(kv.Key))
+	}
+	more, _ := pagedKeys(t, it)
+	cancel()
+	<-donec
+
+	require.Equal(t, keyNames(100), append(keys, more...))
+	require.Equal(t, rev, it.Revision())
+}
+
+func TestPagedGetCompacted(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putKeys(t, cli, 20)
+	it := cli.NewPagedGet("key", clientv3.WithPrefix(), clientv3.WithLimit(5), clientv3.WithStrictRevision())
+	_, err := it.Next(t.Context())
+	require.NoError(t, err)
+	rev := it.Revision()
+
+	resp, err := cli.Put(t.Context(), "other", "v")
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), resp.Header.Revision)
+	require.NoError(t, err)
+
+	// the member would continue past the compaction with the cursor, which
+	// a strict pager refuses
+	_, err = it.Next(t.Context())
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+	require.False(t, it.Done())
+	require.False(t, it.ConsistencyDowngraded())
+	require.Equal(t, rev, it.Revision())
+}
+
+func putKeys(t *testing.T, cli *clientv3.Client, n int) {
+	for _, k := range keyNames(n) {
+		_, err := cli.Put(t.Context(), k, "v")
+		require.NoError(t, err)
+	}
+}
+
+func keyNames(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%03d", i)
+	}
+	return keys
+}
+
+// pageKeys returns the keys of the first page and of all the following pages
+// of p.
+func pageKeys(t *testing.T, p *clientv3.Pager, first *clientv3.GetResponse) []string {
+	var keys []string
+	for resp := first; resp != nil; {
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		var err error
+		resp, err = p.Next(ctx)
+		cancel()
+		require.NoError(t, err)
+	}
+	require.True(t, p.Done())
+	return keys
+}
+
+// pagedKeys returns the keys of the remaining pages of it, and the number of
+// pages.
+func pagedKeys(t *testing.T, it *clientv3.Pager) ([]string, int) {
+	var keys []string
+	pages := 0
+	for !it.Done() {
+		ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+		resp, err := it.Next(ctx)
+		cancel()
+		require.NoError(t, err)
+		for _, kv := range resp.Kvs {
+			keys = append(keys, string(kv.Key))
+		}
+		pages++
+	}
+	resp, err := it.Next(t.Context())
+	require.NoError(t, err)
+	require.Nil(t, resp)
+	return keys, pages
+}
+
+func TestGetMaxResponseBytes(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	// more keys than a page, so that the range is read in several pages
+	keys := make([]string, 1200)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%04d", i)
+	}
+	for i := 0; i < len(keys); i += 100 {
+		var ops []clientv3.Op
+		for _, k := range keys[i : i+100] {
+			ops = append(ops, clientv3.OpPut(k, k))
+		}
+		_, err := cli.Txn(ctx).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+	_, err := cli.Put(ctx, "other", "v")
+	require.NoError(t, err)
+
+	all, err := cli.Get(ctx, "key", clientv3.WithPrefix())
+	require.NoError(t, err)
+	size := func(kvs []*mvccpb.KeyValue) (n int64) {
+		for _, kv := range kvs {
+			n += int64(proto.Size(kv))
+		}
+		return n
+	}
+	getKeys := func(resp *clientv3.GetResponse) []string {
+		var got []string
+		for _, kv := range resp.Kvs {
+			got = append(got, string(kv.Key))
+		}
+		return got
+	}
+	reversed := slices.Clone(keys)
+	slices.Reverse(reversed)
+
+	tests := []struct {
+		name     string
+		opts     []clientv3.OpOption
+		want     []string
+		wantMore bool
+	}{
+		{
+			name: "whole range",
+			opts: []clientv3.OpOption{clientv3.WithMaxResponseBytes(size(all.Kvs))},
+			want: keys,
+		},
+		{
+			name:     "bounded",
+			opts:     []clientv3.OpOption{clientv3.WithMaxResponseBytes(size(all.Kvs[:1100]))},
+			want:     keys[:1100],
+			wantMore: true,
+		},
+		{
+			name:     "smaller than a key",
+			opts:     []clientv3.OpOption{clientv3.WithMaxResponseBytes(1), clientv3.WithSerializable()},
+			want:     keys[:1],
+			wantMore: true,
+		},
+		{
+			name:     "limit",
+			opts:     []clientv3.OpOption{clientv3.WithMaxResponseBytes(size(all.Kvs)), clientv3.WithLimit(1050)},
+			want:     keys[:1050],
+			wantMore: true,
+		},
+		{
+			name:     "descending keys",
+			opts:     []clientv3.OpOption{clientv3.WithMaxResponseBytes(size(all.Kvs[:1100])), clientv3.WithSort(clientv3.SortByKey, clientv3.SortDescend)},
+			want:     reversed[:1100],
+			wantMore: true,
+		},
+		{
+			name:     "sorted by value",
+			opts:     []clientv3.OpOption{clientv3.WithMaxResponseBytes(size(all.Kvs[:1100])), clientv3.WithSort(clientv3.SortByValue, clientv3.SortDescend)},
+			want:     reversed[:1100],
+			wantMore: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := cli.Get(ctx, "key", append(tt.opts, clientv3.WithPrefix())...)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, getKeys(resp))
+			require.Equal(t, tt.wantMore, resp.More)
+			require.Equal(t, int64(len(keys)), resp.Count)
+		})
+	}
+
+	// the range continues from the key following the last one
+	resp, err := cli.Get(ctx, "key", clientv3.WithPrefix(), clientv3.WithMaxResponseBytes(size(all.Kvs[:1100])))
+	require.NoError(t, err)
+	next := resp.Kvs[len(resp.Kvs)-1].Key
+	resp, err = cli.Get(ctx, string(next)+"\x00", clientv3.WithRange(clientv3.GetPrefixRangeEnd("key")), clientv3.WithMaxResponseBytes(size(all.Kvs)))
+	require.NoError(t, err)
+	require.Equal(t, keys[1100:], getKeys(resp))
+	require.False(t, resp.More)
+
+	_, err = cli.Get(ctx, "key", clientv3.WithPrefix(), clientv3.WithMaxResponseBytes(100), clientv3.WithCountOnly())
+	require.ErrorContains(t, err, "cannot be combined with WithCountOnly")
+}
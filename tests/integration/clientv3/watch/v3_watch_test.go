@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
@@ -1344,6 +1346,124 @@ func TestV3WatchWithPrevKV(t *testing.T) {
 	}
 }
 
+// TestV3WatchKeysOnly ensures that keys only watchers receive events without
+// values, while other watchers on the same stream still receive the values.
+func TestV3WatchKeysOnly(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	cli := clus.RandClient()
+	keysOnlyCh := cli.Watch(ctx, "foo", clientv3.WithKeysOnly(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	fullCh := cli.Watch(ctx, "foo", clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	for _, ch := range []clientv3.WatchChan{keysOnlyCh, fullCh} {
+		wresp := <-ch
+		require.Truef(t, wresp.Created, "expected created response, got %+v", wresp)
+	}
+
+	_, err := cli.Put(ctx, "foo", "bar1")
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo", "bar2")
+	require.NoError(t, err)
+	_, err = cli.Delete(ctx, "foo")
+	require.NoError(t, err)
+
+	recvEvents := func(ch clientv3.WatchChan) []*clientv3.Event {
+		var evs []*clientv3.Event
+		for len(evs) < 3 {
+			wresp, ok := <-ch
+			require.Truef(t, ok, "watch channel closed after %d events", len(evs))
+			require.NoError(t, wresp.Err())
+			evs = append(evs, wresp.Events...)
+		}
+		return evs
+	}
+
+	evs := recvEvents(keysOnlyCh)
+	wantTypes := []mvccpb.Event_EventType{mvccpb.PUT, mvccpb.PUT, mvccpb.DELETE}
+	for i, ev := range evs {
+		assert.Equalf(t, wantTypes[i], ev.Type, "#%d: unexpected event type", i)
+		assert.Equalf(t, "foo", string(ev.Kv.Key), "#%d: unexpected key", i)
+		assert.NotZerof(t, ev.Kv.ModRevision, "#%d: expected mod revision", i)
+		assert.Emptyf(t, ev.Kv.Value, "#%d: expected no value", i)
+		if i > 0 {
+			require.NotNilf(t, ev.PrevKv, "#%d: expected prev kv", i)
+			assert.Equalf(t, "foo", string(ev.PrevKv.Key), "#%d: unexpected prev key", i)
+			assert.Equalf(t, evs[i-1].Kv.ModRevision, ev.PrevKv.ModRevision, "#%d: unexpected prev mod revision", i)
+			assert.Emptyf(t, ev.PrevKv.Value, "#%d: expected no prev value", i)
+		}
+	}
+	assert.Equal(t, int64(2), evs[1].Kv.Version)
+	assert.Zero(t, evs[2].Kv.Version)
+
+	evs = recvEvents(fullCh)
+	assert.Equal(t, "bar1", string(evs[0].Kv.Value))
+	assert.Equal(t, "bar2", string(evs[1].Kv.Value))
+	assert.Equal(t, "bar1", string(evs[1].PrevKv.Value))
+	assert.Equal(t, "bar2", string(evs[2].PrevKv.Value))
+}
+
+// TestV3WatchVersionOnly ensures that version only watchers receive events
+// with only the key, mod revision and version, which are smaller than the
+// events of keys only watchers.
+func TestV3WatchVersionOnly(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	cli := clus.RandClient()
+	lresp, err := cli.Grant(ctx, 60)
+	require.NoError(t, err)
+
+	versionOnlyCh := cli.Watch(ctx, "foo", clientv3.WithVersionOnly(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	keysOnlyCh := cli.Watch(ctx, "foo", clientv3.WithKeysOnly(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	for _, ch := range []clientv3.WatchChan{versionOnlyCh, keysOnlyCh} {
+		wresp := <-ch
+		require.Truef(t, wresp.Created, "expected created response, got %+v", wresp)
+	}
+
+	_, err = cli.Put(ctx, "foo", "bar1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo", "bar2", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	_, err = cli.Delete(ctx, "foo")
+	require.NoError(t, err)
+
+	recvEvents := func(ch clientv3.WatchChan) []*clientv3.Event {
+		var evs []*clientv3.Event
+		for len(evs) < 3 {
+			wresp, ok := <-ch
+			require.Truef(t, ok, "watch channel closed after %d events", len(evs))
+			require.NoError(t, wresp.Err())
+			evs = append(evs, wresp.Events...)
+		}
+		return evs
+	}
+
+	evs := recvEvents(versionOnlyCh)
+	keysOnlyEvs := recvEvents(keysOnlyCh)
+	wantTypes := []mvccpb.Event_EventType{mvccpb.PUT, mvccpb.PUT, mvccpb.DELETE}
+	wantVersions := []int64{1, 2, 0}
+	for i, ev := range evs {
+		assert.Equalf(t, wantTypes[i], ev.Type, "#%d: unexpected event type", i)
+		assert.Equalf(t, &mvccpb.KeyValue{
+			Key:         []byte("foo"),
+			ModRevision: keysOnlyEvs[i].Kv.ModRevision,
+			Version:     wantVersions[i],
+		}, ev.Kv, "#%d: unexpected key-value", i)
+		assert.Nilf(t, ev.PrevKv, "#%d: expected no prev kv", i)
+
+		size, keysOnlySize := proto.Size((*mvccpb.Event)(ev)), proto.Size((*mvccpb.Event)(keysOnlyEvs[i]))
+		assert.Lessf(t, size, keysOnlySize, "#%d: expected a smaller event than keys only", i)
+	}
+}
+
 // TestV3WatchCancellation ensures that watch cancellation frees up server resources.
 func TestV3WatchCancellation(t *testing.T) {
 	integration.BeforeTest(t)
@@ -1424,18 +1544,12 @@ func TestV3WatchCloseCancelRace(t *testing.T) {
 // TestV3WatchProgressWaitsForSync checks that progress notifications
 // don't get sent until the watcher is synchronised
 func TestV3WatchProgressWaitsForSync(t *testing.T) {
-	// Disable for gRPC proxy, as it does not support requesting
-	// progress notifications
-	if integration.ThroughProxy {
-		t.Skip("grpc proxy currently does not support requesting progress notifications")
-	}
-
 	integration.BeforeTest(t)
 
 	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
 	defer clus.Terminate(t)
 
-	client := clus.RandClient()
+	client := newDirectClient(t, clus)
 	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
 	defer cancel()
 
@@ -1490,15 +1604,12 @@ func TestV3WatchProgressWaitsForSync(t *testing.T) {
 }
 
 func TestV3WatchProgressWaitsForSyncNoEvents(t *testing.T) {
-	if integration.ThroughProxy {
-		t.Skip("grpc proxy currently does not support requesting progress notifications")
-	}
 	integration.BeforeTest(t)
 
 	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
 	defer clus.Terminate(t)
 
-	client := clus.RandClient()
+	client := newDirectClient(t, clus)
 	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
 	defer cancel()
 
@@ -1533,6 +1644,17 @@ func TestV3WatchProgressWaitsForSyncNoEvents(t *testing.T) {
 	require.Truef(t, gotProgressNotification, "Expected to get progress notification")
 }
 
+// newDirectClient returns a client connected to the first member, without
+// going through the gRPC proxy even when the cluster clients do, since the
+// proxy does not support requesting progress notifications; the limitation
+// is covered by TestWatchRequestProgress.
+func newDirectClient(t *testing.T, clus *integration.Cluster) *clientv3.Client {
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	t.Cleanup(func() { cli.Close() })
+	return cli
+}
+
 // TestV3NoEventsLostOnCompact verifies that slow watchers exit with compacted watch response
 // if its next revision of events are compacted and no lost events sent to client.
 func TestV3NoEventsLostOnCompact(t *testing.T) {
@@ -1583,3 +1705,450 @@ func TestV3NoEventsLostOnCompact(t *testing.T) {
 	}
 	assert.Truef(t, compacted, "Expected stream to get compacted, instead we got %d events out of %d events", eventCount, writeCount)
 }
+
+// TestV3WatchCompactionRetry verifies that a slow watcher with a compaction
+// retry handler is re-created on the same channel from the revision returned
+// by the handler, instead of exiting with a compacted watch response.
+func TestV3WatchCompactionRetry(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy currently does not support requesting progress notifications")
+	}
+	integration.BeforeTest(t)
+	integration.SkipIfNoGoFail(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	client := clus.RandClient()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// sendLoop throughput is rate-limited to 1 event per second
+	require.NoError(t, gofail.Enable("beforeSendWatchResponse", `sleep("1s")`))
+	// the handler lists the key at the compact revision
+	listc := make(chan *clientv3.GetResponse, 1)
+	wch := client.Watch(ctx, "foo", clientv3.WithCompactionRetry(func(compactRev int64) int64 {
+		resp, err := client.Get(ctx, "foo", clientv3.WithRev(compactRev))
+		if err != nil {
+			return 0
+		}
+		listc <- resp
+		return compactRev + 1
+	}))
+
+	var rev int64
+	writeCount := mvcc.ChanBufLen() * 11 / 10
+	for i := 0; i < writeCount; i++ {
+		resp, err := client.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+		rev = resp.Header.Revision
+	}
+	_, err := client.Compact(ctx, rev)
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	require.NoError(t, gofail.Disable("beforeSendWatchResponse"))
+
+	resp, err := client.Put(ctx, "foo", "baz")
+	require.NoError(t, err)
+	lastRev := resp.Header.Revision
+
+	var lastEvent *clientv3.Event
+	for lastEvent == nil || lastEvent.Kv.ModRevision != lastRev {
+		wresp, ok := <-wch
+		require.Truef(t, ok, "watch channel closed before the event at revision %d", lastRev)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			if lastEvent != nil {
+				require.Greater(t, ev.Kv.ModRevision, lastEvent.Kv.ModRevision)
+			}
+			lastEvent = ev
+		}
+	}
+	assert.Equal(t, "baz", string(lastEvent.Kv.Value))
+
+	select {
+	case listed := <-listc:
+		require.Len(t, listed.Kvs, 1)
+		assert.Equal(t, rev, listed.Kvs[0].ModRevision)
+	default:
+		t.Fatal("expected the compaction retry handler to be called")
+	}
+}
+
+// TestV3WatchOnCompactedError verifies that a slow watcher whose compaction
+// callback fails is closed with an error wrapping both ErrCompacted and the
+// error of the callback.
+func TestV3WatchOnCompactedError(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy currently does not support requesting progress notifications")
+	}
+	integration.BeforeTest(t)
+	integration.SkipIfNoGoFail(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	client := clus.RandClient()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// sendLoop throughput is rate-limited to 1 event per second
+	require.NoError(t, gofail.Enable("beforeSendWatchResponse", `sleep("1s")`))
+	errSnapshot := errors.New("snapshot failed")
+	calledc := make(chan int64, 1)
+	wch := client.Watch(ctx, "foo", clientv3.WithOnCompacted(func(compactRev int64) (int64, error) {
+		calledc <- compactRev
+		return 0, errSnapshot
+	}))
+
+	var rev int64
+	writeCount := mvcc.ChanBufLen() * 11 / 10
+	for i := 0; i < writeCount; i++ {
+		resp, err := client.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+		rev = resp.Header.Revision
+	}
+	_, err := client.Compact(ctx, rev)
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	require.NoError(t, gofail.Disable("beforeSendWatchResponse"))
+
+	var last clientv3.WatchResponse
+	for wresp := range wch {
+		if err := wresp.Err(); err != nil {
+			last = wresp
+			break
+		}
+	}
+	require.ErrorIs(t, last.Err(), rpctypes.ErrCompacted)
+	require.ErrorIs(t, last.Err(), errSnapshot)
+	assert.Equal(t, rev, last.CompactRevision)
+	assert.Equal(t, rev, <-calledc)
+
+	_, ok := <-wch
+	assert.Falsef(t, ok, "expected the watch channel to be closed")
+}
+
+// TestV3WatchCompactWarning verifies that a slow watcher is warned of a
+// compaction within its margin, and keeps receiving its events.
+func TestV3WatchCompactWarning(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy shares server watchers among its clients")
+	}
+	integration.BeforeTest(t)
+	integration.SkipIfNoGoFail(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	client := clus.RandClient()
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// sendLoop throughput is rate-limited to 1 event per second
+	require.NoError(t, gofail.Enable("beforeSendWatchResponse", `sleep("1s")`))
+	writeCount := mvcc.ChanBufLen() * 11 / 10
+	wch := client.Watch(ctx, "foo", clientv3.WithCompactWarning(int64(writeCount)))
+	unwarned := client.Watch(ctx, "foo")
+
+	var firstRev, lastRev int64
+	for i := 0; i < writeCount; i++ {
+		resp, err := client.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+		if i == 0 {
+			firstRev = resp.Header.Revision
+		}
+		lastRev = resp.Header.Revision
+	}
+	// the watchers have not received the events after the first ones yet,
+	// so the compaction is within the margin without canceling them
+	_, err := client.Compact(ctx, firstRev+1)
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	require.NoError(t, gofail.Disable("beforeSendWatchResponse"))
+
+	receive := func(wch clientv3.WatchChan) (warnings []int64) {
+		var nextRev int64
+		for nextRev <= lastRev {
+			wresp, ok := <-wch
+			require.Truef(t, ok, "watch channel closed before the event at revision %d", lastRev)
+			require.NoError(t, wresp.Err())
+			if wresp.CompactWarningRevision != 0 {
+				warnings = append(warnings, wresp.CompactWarningRevision)
+				assert.False(t, wresp.IsProgressNotify())
+			}
+			for _, ev := range wresp.Events {
+				require.GreaterOrEqual(t, ev.Kv.ModRevision, nextRev)
+				nextRev = ev.Kv.ModRevision + 1
+			}
+		}
+		return warnings
+	}
+	assert.Equal(t, []int64{firstRev + 1}, receive(wch))
+	assert.Empty(t, receive(unwarned))
+}
+
+// TestV3WatchKeepAlive verifies that the server sends heartbeats on an idle
+// stream with a watcher created with keep_alive_interval, and that the client
+// does not deliver them.
+func TestV3WatchKeepAlive(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy does not forward keep_alive_interval")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo"), KeepAliveInterval: 100},
+	}}))
+	cresp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, cresp.Created)
+
+	last := time.Now()
+	for range 3 {
+		resp, err := wStream.Recv()
+		require.NoError(t, err)
+		assert.True(t, resp.Heartbeat)
+		assert.Equal(t, int64(clientv3.InvalidWatchID), resp.WatchId)
+		assert.Zero(t, resp.Header.Revision)
+		assert.Empty(t, resp.Events)
+		// heartbeats are only sent once the stream was idle for the interval
+		assert.GreaterOrEqual(t, time.Since(last), 90*time.Millisecond)
+		last = time.Now()
+	}
+
+	client := clus.RandClient()
+	wch := client.Watch(ctx, "foo", clientv3.WithKeepAlive(100*time.Millisecond), clientv3.WithCreatedNotify())
+	wresp := <-wch
+	require.True(t, wresp.Created)
+	time.Sleep(500 * time.Millisecond)
+	_, err = client.Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+	wresp = <-wch
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	assert.Equal(t, "bar", string(wresp.Events[0].Kv.Value))
+}
+
+// TestV3WatchLinearizableStart verifies that a watcher created with a
+// linearizable start on a member starts after the revisions committed
+// through the other members.
+func TestV3WatchLinearizableStart(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy does not forward linearizable_start")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	for i := range 3 {
+		presp, err := clus.Client(i).Put(ctx, "foo", fmt.Sprint(i))
+		require.NoError(t, err)
+
+		wctx, wcancel := context.WithCancel(ctx)
+		wch := clus.Client((i+1)%3).Watch(wctx, "foo", clientv3.WithLinearizableStart(), clientv3.WithCreatedNotify())
+		wresp := <-wch
+		require.NoError(t, wresp.Err())
+		require.True(t, wresp.Created)
+		assert.GreaterOrEqual(t, wresp.Header.Revision, presp.Header.Revision)
+		wcancel()
+	}
+}
+
+// TestV3WatchAckWindow ensures that the server holds back the revisions past
+// the ack window of a watcher until it acknowledges them, and that the client
+// acknowledges the revisions it receives.
+func TestV3WatchAckWindow(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy shares server watchers among its clients")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo"), AckWindow: 2},
+	}}))
+	cresp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, cresp.Created)
+
+	client := clus.RandClient()
+	var revs []int64
+	for range 4 {
+		resp, err := client.Put(ctx, "foo", "bar")
+		require.NoError(t, err)
+		revs = append(revs, resp.Header.Revision)
+	}
+
+	respc := make(chan *pb.WatchResponse)
+	go func() {
+		defer close(respc)
+		for {
+			resp, err := wStream.Recv()
+			if err != nil {
+				return
+			}
+			respc <- resp
+		}
+	}()
+	receive := func(n int) (got []int64) {
+		for len(got) < n {
+			select {
+			case resp, ok := <-respc:
+				require.True(t, ok, "watch stream closed")
+				for _, ev := range resp.Events {
+					got = append(got, ev.Kv.ModRevision)
+				}
+			case <-ctx.Done():
+				t.Fatalf("timed out waiting for %d events, got %v", n, got)
+			}
+		}
+		return got
+	}
+	assert.Equal(t, revs[:2], receive(2))
+	select {
+	case resp := <-respc:
+		t.Fatalf("unexpected watch response past the ack window %v", resp)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_AckRequest{
+		AckRequest: &pb.WatchAckRequest{WatchId: cresp.WatchId, Revision: revs[1]},
+	}}))
+	assert.Equal(t, revs[2:], receive(2))
+
+	// the client acknowledges the revisions it receives
+	wch := client.Watch(ctx, "bar", clientv3.WithAckWindow(2))
+	var lastRev int64
+	for range 10 {
+		resp, err := client.Put(ctx, "bar", "baz")
+		require.NoError(t, err)
+		lastRev = resp.Header.Revision
+	}
+	var nextRev int64
+	for nextRev <= lastRev {
+		wresp, ok := <-wch
+		require.Truef(t, ok, "watch channel closed before the event at revision %d", lastRev)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			if nextRev != 0 {
+				require.Equal(t, nextRev, ev.Kv.ModRevision)
+			}
+			nextRev = ev.Kv.ModRevision + 1
+		}
+	}
+}
+
+// TestV3WatchCancelRange ensures that a cancel range request cancels the
+// watchers of the stream whose keys are all in the range, and only them.
+func TestV3WatchCancelRange(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	wStream, err := integration.ToGRPC(clus.RandClient()).Watch.Watch(ctx)
+	require.NoError(t, err)
+
+	creqs := []*pb.WatchCreateRequest{
+		{Key: []byte("foo")},
+		{Key: []byte("foo/"), RangeEnd: []byte("foo0")},
+		{Key: []byte("foo/a")},
+		{Key: []byte("foo/b"), RangeEnd: []byte("\x00")},
+		{Key: []byte("bar")},
+	}
+	ids := make([]int64, len(creqs))
+	for i, creq := range creqs {
+		require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{CreateRequest: creq}}))
+		cresp, err := wStream.Recv()
+		require.NoError(t, err)
+		require.True(t, cresp.Created)
+		ids[i] = cresp.WatchId
+	}
+
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CancelRangeRequest{
+		CancelRangeRequest: &pb.WatchCancelRangeRequest{Key: []byte("foo/"), RangeEnd: []byte("foo0")},
+	}}))
+	var canceled []int64
+	for range 2 {
+		resp, err := wStream.Recv()
+		require.NoError(t, err)
+		require.True(t, resp.Canceled)
+		canceled = append(canceled, resp.WatchId)
+	}
+	require.ElementsMatch(t, []int64{ids[1], ids[2]}, canceled)
+
+	// the other watchers still receive their events
+	_, err = clus.RandClient().Put(ctx, "bar", "baz")
+	require.NoError(t, err)
+	resp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.False(t, resp.Canceled)
+	require.Equal(t, ids[4], resp.WatchId)
+	require.Len(t, resp.Events, 1)
+	require.Equal(t, []byte("bar"), resp.Events[0].Kv.Key)
+}
+
+// TestV3WatchLifecycleLogs verifies that the server logs the lifecycle of a
+// watcher whose range hash is logged verbosely.
+func TestV3WatchLifecycleLogs(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy shares server watchers among its clients")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	key := "watch-log-key"
+	hash := v3rpc.WatchRangeHash([]byte(key), nil)
+	v3rpc.SetWatchRangeVerbose(hash, true)
+	defer v3rpc.SetWatchRangeVerbose(hash, false)
+
+	client := clus.Client(0)
+	resp, err := client.Put(t.Context(), key, "1")
+	require.NoError(t, err)
+	_, err = client.Put(t.Context(), key, "2")
+	require.NoError(t, err)
+
+	// a watcher starting in the history is synced once it caught up
+	ctx, cancel := context.WithCancel(t.Context())
+	wch := client.Watch(ctx, key, clientv3.WithRev(resp.Header.Revision))
+	wresp := <-wch
+	require.NoError(t, wresp.Err())
+	_, err = client.Put(t.Context(), key, "3")
+	require.NoError(t, err)
+	wresp = <-wch
+	require.NoError(t, wresp.Err())
+	cancel()
+
+	for _, tc := range []struct {
+		msg   string
+		count int
+	}{
+		{msg: "watcher created", count: 1},
+		{msg: "sent watch response", count: 2},
+		{msg: "watcher synced", count: 1},
+		{msg: "watcher canceled", count: 1},
+	} {
+		expectCtx, expectCancel := context.WithTimeout(t.Context(), 5*time.Second)
+		_, err = clus.Members[0].LogObserver.ExpectFunc(expectCtx, func(log string) bool {
+			return strings.Contains(log, tc.msg) && strings.Contains(log, hash)
+		}, tc.count)
+		expectCancel()
+		require.NoErrorf(t, err, "expected %d %q logs for range hash %s", tc.count, tc.msg, hash)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchCatchUp ensures that the events replayed from the history are
+// marked as catch-up, and the events sent live are not.
+func TestWatchCatchUp(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	// revisions 2 to 4
+	putHistory(t, cli, "foo", 3)
+
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithRev(2))
+	var rev int64
+	for rev < 4 {
+		wresp := recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		require.True(t, wresp.IsCatchUp())
+		rev = wresp.Events[len(wresp.Events)-1].Kv.ModRevision
+	}
+
+	_, err := cli.Put(t.Context(), "foo", "live")
+	require.NoError(t, err)
+	wresp := recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.False(t, wresp.IsCatchUp())
+	require.Equal(t, "live", string(wresp.Events[0].Kv.Value))
+
+	// a watcher from the current revision is synced right away
+	wch = cli.Watch(t.Context(), "foo", clientv3.WithCreatedNotify())
+	require.True(t, recvWatchResponse(t, wch).Created)
+	_, err = cli.Put(t.Context(), "foo", "live2")
+	require.NoError(t, err)
+	wresp = recvWatchResponse(t, wch)
+	require.False(t, wresp.IsCatchUp())
+}
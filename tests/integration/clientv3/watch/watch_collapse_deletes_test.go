@@ -0,0 +1,82 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchCollapseDeletes ensures that WithCollapseDeletes sends a single
+// DELETE_RANGE event for the keys of the watched prefix deleted by a delete
+// range request, without their previous key-value pairs, and that the
+// NODELETE filter drops it.
+func TestWatchCollapseDeletes(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	for _, k := range []string{"bar", "foo/a", "foo/b", "foo/c"} {
+		_, err := cli.Put(ctx, k, "v")
+		require.NoError(t, err)
+	}
+	collapsed := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithCollapseDeletes(), clientv3.WithPrevKV(), clientv3.WithCreatedNotify())
+	noDelete := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithCollapseDeletes(), clientv3.WithFilterDelete(), clientv3.WithCreatedNotify())
+	plain := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	for _, wch := range []clientv3.WatchChan{collapsed, noDelete, plain} {
+		require.True(t, recvWatchResponse(t, wch).Created)
+	}
+
+	// revision 6
+	dresp, err := cli.Delete(ctx, "", clientv3.WithFromKey())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), dresp.Deleted)
+	// revision 7
+	_, err = cli.Put(ctx, "foo/d", "v")
+	require.NoError(t, err)
+
+	wresp := recvWatchResponse(t, collapsed)
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	ev := wresp.Events[0]
+	require.Equal(t, clientv3.EventTypeDeleteRange, ev.Type)
+	require.Equal(t, &mvccpb.KeyValue{Key: []byte("foo/"), ModRevision: 6}, ev.Kv)
+	require.Equal(t, "foo0", string(ev.RangeEnd))
+	require.Equal(t, int64(3), ev.Deleted)
+	require.Nil(t, ev.PrevKv)
+
+	wresp = recvWatchResponse(t, noDelete)
+	require.Len(t, wresp.Events, 1)
+	require.True(t, wresp.Events[0].IsCreate())
+
+	var deletes []string
+	for len(deletes) < 3 {
+		for _, ev := range recvWatchResponse(t, plain).Events {
+			require.Equal(t, clientv3.EventTypeDelete, ev.Type)
+			deletes = append(deletes, string(ev.Kv.Key))
+		}
+	}
+	require.Equal(t, []string{"foo/a", "foo/b", "foo/c"}, deletes)
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchCompare ensures that WithCompare only sends the events that flip
+// the result of the compare on the watched key, along with the result, and
+// that the created response has the result before the start revision.
+func TestWatchCompare(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, EnableWatchCompare: true})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	// revision 2
+	_, err := cli.Put(ctx, "leader", "other")
+	require.NoError(t, err)
+	isMe := clientv3.WithCompare(clientv3.Compare(clientv3.Value("leader"), "=", "me"))
+	wch := cli.Watch(ctx, "leader", isMe, clientv3.WithCreatedNotify())
+	wresp := recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	require.False(t, wresp.CompareResult)
+
+	// revisions 3 to 7
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("leader", "me"),
+		clientv3.OpPut("leader", "me"),
+		clientv3.OpPut("leader", "other"),
+		clientv3.OpDelete("leader"),
+		clientv3.OpPut("leader", "me"),
+	} {
+		_, err = cli.Do(ctx, op)
+		require.NoError(t, err)
+	}
+
+	want := []string{"PUT me@3 true", "PUT other@5 false", "PUT me@7 true"}
+	var evs []string
+	for len(evs) < len(want) {
+		wresp = recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			evs = append(evs, fmt.Sprintf("%s %s@%d %v", ev.Type, ev.Kv.Value, ev.Kv.ModRevision, wresp.CompareResult))
+		}
+	}
+	require.Equal(t, want, evs)
+
+	// the key of the compare is the watched key
+	wch = cli.Watch(ctx, "leader", clientv3.WithCompare(clientv3.Compare(clientv3.Value("other"), "=", "me")), clientv3.WithRev(4), clientv3.WithCreatedNotify())
+	wresp = recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	require.True(t, wresp.CompareResult)
+	wresp = recvWatchResponse(t, wch)
+	require.Len(t, wresp.Events, 2)
+	require.Equal(t, int64(5), wresp.Events[0].Kv.ModRevision)
+	require.Equal(t, int64(7), wresp.Events[1].Kv.ModRevision)
+	require.True(t, wresp.CompareResult)
+
+	wresp = recvWatchResponse(t, cli.Watch(ctx, "leader", isMe, clientv3.WithPrefix()))
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), "single key")
+}
+
+// TestWatchCompareDisabled ensures that watches with a compare are rejected
+// unless the WatchCompare feature is enabled.
+func TestWatchCompareDisabled(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	isMe := clientv3.WithCompare(clientv3.Compare(clientv3.Value("leader"), "=", "me"))
+	wresp := recvWatchResponse(t, clus.RandClient().Watch(t.Context(), "leader", isMe))
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), "WatchCompare")
+}
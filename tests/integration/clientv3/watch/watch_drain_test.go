@@ -0,0 +1,195 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchDrainOnStop ensures that a member being stopped sends each of its
+// watchers a final response with the server closing reason and the revision
+// they have received all the events up to, before closing the stream.
+func TestWatchDrainOnStop(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx := t.Context()
+	wStream, err := integration.ToGRPC(clus.Client(0)).Watch.Watch(ctx)
+	require.NoError(t, err)
+
+	watchIDs := make(map[int64]struct{})
+	for _, key := range []string{"foo", "bar"} {
+		require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+			CreateRequest: &pb.WatchCreateRequest{Key: []byte(key)},
+		}}))
+		resp, rerr := wStream.Recv()
+		require.NoError(t, rerr)
+		require.True(t, resp.Created)
+		watchIDs[resp.WatchId] = struct{}{}
+	}
+
+	kvc := integration.ToGRPC(clus.Client(1)).KV
+	presp, err := kvc.Put(ctx, &pb.PutRequest{Key: []byte("foo"), Value: []byte("1")})
+	require.NoError(t, err)
+	resp, err := wStream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 1)
+	rev := presp.Header.Revision
+
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		clus.Members[0].Stop(t)
+	}()
+
+	for len(watchIDs) > 0 {
+		resp, err = wStream.Recv()
+		require.NoError(t, err)
+		require.True(t, resp.Canceled)
+		require.Equal(t, rpctypes.ErrServerClosing.Error(), resp.CancelReason)
+		require.Equal(t, rev, resp.Header.Revision)
+		require.Contains(t, watchIDs, resp.WatchId)
+		delete(watchIDs, resp.WatchId)
+	}
+	_, err = wStream.Recv()
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	select {
+	case <-donec:
+	case <-time.After(10 * time.Second):
+		t.Fatal("member did not stop in time")
+	}
+}
+
+// TestWatchDrainResume ensures that a client watching on a member being
+// stopped resumes its watcher on another member without losing events.
+func TestWatchDrainResume(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx := t.Context()
+	wch := cli.Watch(ctx, "foo", clientv3.WithCreatedNotify())
+	wresp := recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	cli.SetEndpoints(clus.Members[0].GRPCURL, clus.Members[1].GRPCURL)
+
+	kv := clus.Client(1)
+	_, err = kv.Put(ctx, "foo", "1")
+	require.NoError(t, err)
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	require.Equal(t, "1", string(wresp.Events[0].Kv.Value))
+
+	clus.Members[0].Stop(t)
+	// the stopped member may have been the leader
+	clus.WaitMembersForLeader(t, clus.Members[1:])
+
+	_, err = kv.Put(ctx, "foo", "2")
+	require.NoError(t, err)
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	require.Equal(t, "2", string(wresp.Events[0].Kv.Value))
+}
+
+// TestWatchDrainNoDuplicates ensures that a client watching on a member being
+// stopped while events are being written receives each event exactly once.
+func TestWatchDrainNoDuplicates(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx := t.Context()
+	wch := cli.Watch(ctx, "foo", clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	wresp := recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	cli.SetEndpoints(clus.Members[0].GRPCURL, clus.Members[1].GRPCURL)
+
+	kv := clus.Client(1)
+	const puts = 100
+	revc := make(chan int64, puts)
+	go func() {
+		defer close(revc)
+		for i := 0; i < puts; i++ {
+			// the put is retried while the stopped member's leadership,
+			// if any, is taken over
+			for {
+				resp, perr := kv.Put(ctx, fmt.Sprintf("foo%d", i), "v")
+				if perr == nil {
+					revc <- resp.Header.Revision
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	var revs []int64
+	for len(revs) < puts/4 {
+		wresp = recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			revs = append(revs, ev.Kv.ModRevision)
+		}
+	}
+	clus.Members[0].Stop(t)
+
+	var putRevs []int64
+	for rev := range revc {
+		putRevs = append(putRevs, rev)
+	}
+	require.Len(t, putRevs, puts)
+	for revs[len(revs)-1] < putRevs[puts-1] {
+		wresp = recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			revs = append(revs, ev.Kv.ModRevision)
+		}
+	}
+
+	for i := 1; i < len(revs); i++ {
+		require.Greaterf(t, revs[i], revs[i-1], "event at revision %d delivered twice or out of order", revs[i])
+	}
+	require.Subset(t, revs, putRevs)
+}
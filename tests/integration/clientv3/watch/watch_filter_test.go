@@ -0,0 +1,145 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchFilterValuePrefix ensures that WithFilterValuePrefix only drops
+// PUT events whose value does not match, and that the filter order does not
+// change the events sent.
+func TestWatchFilterValuePrefix(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("foo/a", "x1"),
+		clientv3.OpPut("foo/b", "y1"),
+		clientv3.OpDelete("foo/a"),
+		clientv3.OpPut("foo/c", "x2"),
+		clientv3.OpPut("foo/b", "x3"),
+	} {
+		_, err := cli.Do(ctx, op)
+		require.NoError(t, err)
+	}
+
+	type event struct {
+		typ   mvccpb.Event_EventType
+		key   string
+		value string
+		rev   int64
+	}
+	recvEvents := func(wch clientv3.WatchChan, lastRev int64) []event {
+		var evs []event
+		for len(evs) == 0 || evs[len(evs)-1].rev < lastRev {
+			wresp := recvWatchResponse(t, wch)
+			require.NoError(t, wresp.Err())
+			for _, ev := range wresp.Events {
+				evs = append(evs, event{typ: ev.Type, key: string(ev.Kv.Key), value: string(ev.Kv.Value), rev: ev.Kv.ModRevision})
+			}
+		}
+		return evs
+	}
+
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithFilterValuePrefix("x"))
+	require.Equal(t, []event{
+		{typ: clientv3.EventTypePut, key: "foo/a", value: "x1", rev: 2},
+		{typ: clientv3.EventTypeDelete, key: "foo/a", rev: 4},
+		{typ: clientv3.EventTypePut, key: "foo/c", value: "x2", rev: 5},
+		{typ: clientv3.EventTypePut, key: "foo/b", value: "x3", rev: 6},
+	}, recvEvents(wch, 6))
+
+	for _, opts := range [][]clientv3.OpOption{
+		{clientv3.WithFilterDelete()},
+		{clientv3.WithFilterDelete(), clientv3.WithValueFiltersFirst()},
+	} {
+		opts = append(opts, clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithFilterValuePrefix("x"))
+		wch := cli.Watch(ctx, "foo/", opts...)
+		require.Equal(t, []event{
+			{typ: clientv3.EventTypePut, key: "foo/a", value: "x1", rev: 2},
+			{typ: clientv3.EventTypePut, key: "foo/c", value: "x2", rev: 5},
+			{typ: clientv3.EventTypePut, key: "foo/b", value: "x3", rev: 6},
+		}, recvEvents(wch, 6))
+	}
+}
+
+// TestWatchFilterValueSelector ensures that WithFilterValueSelector only drops
+// PUT events whose JSON value does not match, and that the watch is rejected
+// if the selector is malformed or too costly.
+func TestWatchFilterValueSelector(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, EnableWatchValueSelector: true})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("pods/a", `{"kind": "Pod", "spec": {"replicas": 1}}`),
+		clientv3.OpPut("pods/b", `{"kind": "Pod", "spec": {"replicas": 3}}`),
+		clientv3.OpPut("pods/c", `not json`),
+		clientv3.OpDelete("pods/a"),
+		clientv3.OpPut("pods/c", `{"kind": "Node", "spec": {"replicas": 5}}`),
+		clientv3.OpPut("pods/a", `{"kind": "Pod", "spec": {"replicas": 2}}`),
+	} {
+		_, err := cli.Do(ctx, op)
+		require.NoError(t, err)
+	}
+
+	var evs []string
+	wch := cli.Watch(ctx, "pods/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithFilterValueSelector(`$.kind == "Pod" && $.spec.replicas > 1`))
+	for len(evs) < 3 {
+		wresp := recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			evs = append(evs, fmt.Sprintf("%s %s@%d", ev.Type, ev.Kv.Key, ev.Kv.ModRevision))
+		}
+	}
+	require.Equal(t, []string{"PUT pods/b@3", "DELETE pods/a@5", "PUT pods/a@7"}, evs)
+
+	for _, selector := range []string{`kind == "Pod"`, strings.Repeat("!", 1000) + "$"} {
+		wresp := recvWatchResponse(t, cli.Watch(ctx, "pods/", clientv3.WithPrefix(), clientv3.WithFilterValueSelector(selector)))
+		require.True(t, wresp.Canceled)
+		require.ErrorContains(t, wresp.Err(), "value selector")
+	}
+}
+
+// TestWatchFilterValueSelectorDisabled ensures that watches with a value
+// selector are rejected unless the WatchValueSelector feature is enabled.
+func TestWatchFilterValueSelectorDisabled(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	wresp := recvWatchResponse(t, clus.RandClient().Watch(t.Context(), "pods/", clientv3.WithFilterValueSelector(`$.kind`)))
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), "WatchValueSelector")
+}
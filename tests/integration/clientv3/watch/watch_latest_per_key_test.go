@@ -0,0 +1,91 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchLatestPerKey ensures that a watcher created with WithLatestPerKey
+// on an old revision only receives the latest event of each key while it
+// catches up, and every event afterwards.
+func TestWatchLatestPerKey(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("foo/a", "1"),
+		clientv3.OpPut("foo/b", "1"),
+		clientv3.OpPut("foo/a", "2"),
+		clientv3.OpDelete("foo/b"),
+		clientv3.OpPut("foo/c", "1"),
+		clientv3.OpPut("foo/a", "3"),
+		clientv3.OpPut("bar", "1"),
+	} {
+		_, err := cli.Do(ctx, op)
+		require.NoError(t, err)
+	}
+
+	type event struct {
+		typ   mvccpb.Event_EventType
+		key   string
+		value string
+		rev   int64
+	}
+	recvEvents := func(wch clientv3.WatchChan, lastRev int64) []event {
+		var evs []event
+		for len(evs) == 0 || evs[len(evs)-1].rev < lastRev {
+			wresp := recvWatchResponse(t, wch)
+			require.NoError(t, wresp.Err())
+			for _, ev := range wresp.Events {
+				evs = append(evs, event{typ: ev.Type, key: string(ev.Kv.Key), value: string(ev.Kv.Value), rev: ev.Kv.ModRevision})
+			}
+		}
+		return evs
+	}
+
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithLatestPerKey())
+	require.Equal(t, []event{
+		{typ: clientv3.EventTypeDelete, key: "foo/b", rev: 5},
+		{typ: clientv3.EventTypePut, key: "foo/c", value: "1", rev: 6},
+		{typ: clientv3.EventTypePut, key: "foo/a", value: "3", rev: 7},
+	}, recvEvents(wch, 7))
+
+	// a watcher without the option receives the full history
+	fullWch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2))
+	require.Len(t, recvEvents(fullWch, 7), 6)
+
+	// live events are not coalesced
+	_, err := cli.Put(ctx, "foo/a", "4")
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo/a", "5")
+	require.NoError(t, err)
+	require.Equal(t, []event{
+		{typ: clientv3.EventTypePut, key: "foo/a", value: "4", rev: 9},
+		{typ: clientv3.EventTypePut, key: "foo/a", value: "5", rev: 10},
+	}, recvEvents(wch, 10))
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchMaxEventsPerResponse ensures that WithMaxEventsPerResponse splits
+// the events into responses of at most the given number of events, both
+// while catching up and once synced, without splitting a revision, and that
+// it composes with fragmentation.
+func TestWatchMaxEventsPerResponse(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, MaxRequestBytes: 1024})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	txn := func(n int, size int) {
+		var ops []clientv3.Op
+		for i := 0; i < n; i++ {
+			ops = append(ops, clientv3.OpPut(fmt.Sprintf("foo/%d", i), string(make([]byte, size))))
+		}
+		_, err := cli.Txn(ctx).Then(ops...).Commit()
+		require.NoError(t, err)
+	}
+
+	// revisions 2 to 8, then revisions 9 to 11 whose events exceed the
+	// request size when sent together, then revision 12 that exceeds the limit
+	for i := 0; i < 7; i++ {
+		txn(1, 10)
+	}
+	for i := 0; i < 3; i++ {
+		txn(1, 900)
+	}
+	txn(5, 10)
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithMaxEventsPerResponse(3), clientv3.WithFragment())
+	// revision 13 exceeds the limit, then revisions 14 to 16
+	txn(4, 10)
+	for i := 0; i < 3; i++ {
+		txn(1, 10)
+	}
+
+	var revs []int64
+	for len(revs) == 0 || revs[len(revs)-1] < 16 {
+		wresp := recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		require.NotEmpty(t, wresp.Events)
+
+		first, last := wresp.Events[0].Kv.ModRevision, wresp.Events[len(wresp.Events)-1].Kv.ModRevision
+		if first != last {
+			require.LessOrEqualf(t, len(wresp.Events), 3, "expected at most 3 events of revisions %d to %d", first, last)
+		}
+		if len(revs) != 0 {
+			require.Lessf(t, revs[len(revs)-1], first, "expected revision %d in a single response", first)
+		}
+		for _, ev := range wresp.Events {
+			revs = append(revs, ev.Kv.ModRevision)
+		}
+	}
+
+	var want []int64
+	for rev := int64(2); rev <= 11; rev++ {
+		want = append(want, rev)
+	}
+	want = append(want, 12, 12, 12, 12, 12, 13, 13, 13, 13, 14, 15, 16)
+	require.Equal(t, want, revs)
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+type recordingObserver struct {
+	mu      sync.Mutex
+	records []string
+}
+
+func (o *recordingObserver) record(format string, args ...any) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records = append(o.records, fmt.Sprintf(format, args...))
+}
+
+func (o *recordingObserver) OnWatchCreate(_ context.Context, key, _ string) {
+	o.record("create %s", key)
+}
+
+func (o *recordingObserver) OnWatchEvents(_ context.Context, key string, events []*clientv3.Event) {
+	o.record("events %s %d", key, len(events))
+}
+
+func (o *recordingObserver) OnWatchError(_ context.Context, key string, err error) {
+	if errors.Is(err, rpctypes.ErrCompacted) {
+		o.record("compacted %s", key)
+		return
+	}
+	o.record("error %s", key)
+}
+
+func (o *recordingObserver) OnWatchCancel(_ context.Context, key string) {
+	o.record("cancel %s", key)
+}
+
+func (o *recordingObserver) count(record string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := 0
+	for _, r := range o.records {
+		if r == record {
+			n++
+		}
+	}
+	return n
+}
+
+func (o *recordingObserver) has(records ...string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, r := range records {
+		if !slices.Contains(o.records, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestWatchObserver ensures that the WatchObserver of a client is notified
+// when its watchers are created, receive events, fail and are canceled, and
+// that a watcher resumed after a stream failure is not created again.
+func TestWatchObserver(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	obs := &recordingObserver{}
+	cli, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:     []string{clus.Members[0].GRPCURL},
+		WatchObserver: obs,
+	})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	wch := cli.Watch(ctx, "foo", clientv3.WithCreatedNotify())
+	require.True(t, recvWatchResponse(t, wch).Created)
+	require.True(t, obs.has("create foo"))
+
+	_, err = cli.Put(t.Context(), "foo", "1")
+	require.NoError(t, err)
+	require.Len(t, recvWatchResponse(t, wch).Events, 1)
+	require.True(t, obs.has("events foo 1"))
+
+	// the watcher is resumed once the member is back
+	clus.Members[0].Stop(t)
+	require.NoError(t, clus.Members[0].Restart(t))
+	_, err = cli.Put(t.Context(), "foo", "2")
+	require.NoError(t, err)
+	require.Len(t, recvWatchResponse(t, wch).Events, 1)
+	require.True(t, obs.has("error foo"))
+	require.Equal(t, 1, obs.count("create foo"))
+
+	cancel()
+	require.Eventually(t, func() bool { return obs.has("cancel foo") }, 5*time.Second, 10*time.Millisecond)
+
+	// a watcher canceled by the server for compaction
+	resp, err := cli.Put(t.Context(), "bar", "1")
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), resp.Header.Revision)
+	require.NoError(t, err)
+	wch = cli.Watch(t.Context(), "bar", clientv3.WithRev(1))
+	wresp := recvWatchResponse(t, wch)
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrCompacted)
+	require.Eventually(t, func() bool {
+		return obs.has("create bar", "compacted bar", "cancel bar")
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// putHistory puts n revisions of the given key.
+func putHistory(t *testing.T, cli *clientv3.Client, key string, n int) {
+	for i := 0; i < n; i++ {
+		_, err := cli.Put(t.Context(), key, fmt.Sprint(i))
+		require.NoError(t, err)
+	}
+}
+
+// TestWatchOldRevisionWarn ensures that a watcher with more revisions to
+// catch up on than the threshold is created with a warning.
+func TestWatchOldRevisionWarn(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, WatchOldRevisionThreshold: 20})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	// revisions 2 to 51
+	putHistory(t, cli, "foo", 50)
+
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithRev(2), clientv3.WithCreatedNotify())
+	wresp := recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	require.Contains(t, wresp.Warning, "50 revisions behind")
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.Empty(t, wresp.Warning)
+	require.Equal(t, int64(2), wresp.Events[0].Kv.ModRevision)
+
+	// without the created response, the warning comes with the first events
+	wch = cli.Watch(t.Context(), "foo", clientv3.WithRev(2))
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.Contains(t, wresp.Warning, "50 revisions behind")
+	require.Equal(t, int64(2), wresp.Events[0].Kv.ModRevision)
+
+	// within the threshold
+	wch = cli.Watch(t.Context(), "foo", clientv3.WithRev(40), clientv3.WithCreatedNotify())
+	wresp = recvWatchResponse(t, wch)
+	require.True(t, wresp.Created)
+	require.Empty(t, wresp.Warning)
+}
+
+// TestWatchOldRevisionReject ensures that a watcher with more revisions to
+// catch up on than the threshold is rejected in reject mode.
+func TestWatchOldRevisionReject(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, WatchOldRevisionThreshold: 20, WatchOldRevisionReject: true})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	putHistory(t, cli, "foo", 50)
+
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithRev(2))
+	wresp := recvWatchResponse(t, wch)
+	require.True(t, wresp.Canceled)
+	require.ErrorIs(t, wresp.Err(), rpctypes.ErrWatchStartRevisionTooOld)
+
+	// re-listing and watching from the revision of the range is accepted
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	wch = cli.Watch(t.Context(), "foo", clientv3.WithRev(gresp.Header.Revision+1), clientv3.WithCreatedNotify())
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.True(t, wresp.Created)
+	require.Empty(t, wresp.Warning)
+}
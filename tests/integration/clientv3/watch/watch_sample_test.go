@@ -0,0 +1,77 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchSampleEveryN ensures that WithSampleEveryN sends every Nth PUT
+// event of each key, both while catching up and once synced, and always
+// sends DELETE events.
+func TestWatchSampleEveryN(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	put := func(key string, n int) {
+		for i := 0; i < n; i++ {
+			_, err := cli.Put(ctx, key, fmt.Sprint(i))
+			require.NoError(t, err)
+		}
+	}
+
+	// revisions 2 to 6
+	put("foo/a", 5)
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithSampleEveryN(2))
+	// revisions 7 to 10
+	put("foo/b", 3)
+	_, err := cli.Delete(ctx, "foo/a")
+	require.NoError(t, err)
+
+	type event struct {
+		typ mvccpb.Event_EventType
+		key string
+		rev int64
+	}
+	var evs []event
+	for len(evs) == 0 || evs[len(evs)-1].rev < 10 {
+		wresp := recvWatchResponse(t, wch)
+		require.NoError(t, wresp.Err())
+		for _, ev := range wresp.Events {
+			evs = append(evs, event{typ: ev.Type, key: string(ev.Kv.Key), rev: ev.Kv.ModRevision})
+		}
+	}
+	require.Equal(t, []event{
+		{typ: clientv3.EventTypePut, key: "foo/a", rev: 2},
+		{typ: clientv3.EventTypePut, key: "foo/a", rev: 4},
+		{typ: clientv3.EventTypePut, key: "foo/a", rev: 6},
+		{typ: clientv3.EventTypePut, key: "foo/b", rev: 7},
+		{typ: clientv3.EventTypePut, key: "foo/b", rev: 9},
+		{typ: clientv3.EventTypeDelete, key: "foo/a", rev: 10},
+	}, evs)
+}
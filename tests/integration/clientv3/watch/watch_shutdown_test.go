@@ -0,0 +1,94 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchClientShutdown ensures that Shutdown delivers all the events
+// committed before it was called to the watch channels, which are closed
+// afterwards, and that the client refuses new requests.
+func TestWatchClientShutdown(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	ctx := t.Context()
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithCreatedNotify())
+	require.True(t, recvWatchResponse(t, wch).Created)
+
+	const n = 100
+	var lastRev int64
+	for i := 0; i < n; i++ {
+		resp, perr := clus.Client(0).Put(ctx, fmt.Sprintf("foo/%d", i), "v")
+		require.NoError(t, perr)
+		lastRev = resp.Header.Revision
+	}
+
+	// the channel is only read once the client is shutting down, so the
+	// events are flushed from the buffer of the canceled watcher
+	donec := make(chan []int64)
+	go func() {
+		for {
+			if _, gerr := cli.Get(ctx, "foo"); errors.Is(gerr, clientv3.ErrClientShuttingDown) {
+				break
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+		var revs []int64
+		for wresp := range wch {
+			for _, ev := range wresp.Events {
+				revs = append(revs, ev.Kv.ModRevision)
+			}
+		}
+		donec <- revs
+	}()
+	sctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	require.NoError(t, cli.Shutdown(sctx))
+
+	var revs []int64
+	select {
+	case revs = <-donec:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch channel not closed after shutdown")
+	}
+	require.Len(t, revs, n)
+	for i, rev := range revs {
+		require.Equal(t, lastRev-n+1+int64(i), rev)
+	}
+
+	_, err = cli.Get(ctx, "foo")
+	require.ErrorIs(t, err, clientv3.ErrClientShuttingDown)
+	_, ok := <-cli.Watch(ctx, "foo")
+	require.False(t, ok)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !cluster_proxy
+
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchSnapshotFallback ensures that a watcher created with
+// WithSnapshotFallback on a compacted revision receives the current state of
+// its range and then keeps receiving events instead of being canceled.
+func TestWatchSnapshotFallback(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	ctx := t.Context()
+	for _, op := range []clientv3.Op{
+		clientv3.OpPut("foo/a", "1"),
+		clientv3.OpPut("foo/b", "1"),
+		clientv3.OpPut("foo/a", "2"),
+		clientv3.OpDelete("foo/b"),
+		clientv3.OpPut("foo/c", "1"),
+		clientv3.OpPut("bar", "1"),
+	} {
+		_, err := cli.Do(ctx, op)
+		require.NoError(t, err)
+	}
+	// revision 7 is the last put
+	_, err := cli.Compact(ctx, 6)
+	require.NoError(t, err)
+
+	wch := cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithSnapshotFallback())
+	wresp := recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.True(t, wresp.Snapshot)
+	require.False(t, wresp.IsProgressNotify())
+	require.Equal(t, int64(7), wresp.Header.Revision)
+	kvs := make(map[string]string)
+	for _, ev := range wresp.Events {
+		require.Equal(t, clientv3.EventTypePut, ev.Type)
+		kvs[string(ev.Kv.Key)] = string(ev.Kv.Value)
+	}
+	require.Equal(t, map[string]string{"foo/a": "2", "foo/c": "1"}, kvs)
+
+	_, err = cli.Delete(ctx, "foo/a")
+	require.NoError(t, err)
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.False(t, wresp.Snapshot)
+	require.Len(t, wresp.Events, 1)
+	require.Equal(t, clientv3.EventTypeDelete, wresp.Events[0].Type)
+	require.Equal(t, int64(8), wresp.Events[0].Kv.ModRevision)
+
+	// the snapshot honors the put filter
+	wch = cli.Watch(ctx, "foo/", clientv3.WithPrefix(), clientv3.WithRev(2), clientv3.WithSnapshotFallback(), clientv3.WithFilterPut())
+	wresp = recvWatchResponse(t, wch)
+	require.NoError(t, wresp.Err())
+	require.True(t, wresp.Snapshot)
+	require.Empty(t, wresp.Events)
+}
+
+func recvWatchResponse(t *testing.T, wch clientv3.WatchChan) clientv3.WatchResponse {
+	t.Helper()
+	select {
+	case wresp, ok := <-wch:
+		require.Truef(t, ok, "watch channel closed")
+		return wresp
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watch response")
+	}
+	return clientv3.WatchResponse{}
+}
@@ -36,6 +36,7 @@ import (
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/namespace"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3rpc"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
@@ -586,17 +587,21 @@ func TestConfigurableWatchProgressNotifyInterval(t *testing.T) {
 	}
 }
 
+// TestWatchRequestProgress ensures that RequestProgress sends a progress
+// notification to all the watchers of a client connected to a member, and
+// none to the watchers of a client going through the gRPC proxy, which does
+// not support requesting progress notifications.
 func TestWatchRequestProgress(t *testing.T) {
-	if integration.ThroughProxy {
-		t.Skipf("grpc-proxy does not support WatchProgress yet")
-	}
 	testCases := []struct {
 		name     string
 		watchers []string
+		proxy    bool
 	}{
-		{"0-watcher", []string{}},
-		{"1-watcher", []string{"/"}},
-		{"2-watcher", []string{"/", "/"}},
+		{"0-watcher", []string{}, false},
+		{"1-watcher", []string{"/"}, false},
+		{"2-watcher", []string{"/", "/"}, false},
+		{"1-watcher-proxy", []string{"/"}, true},
+		{"2-watcher-proxy", []string{"/", "/"}, true},
 	}
 
 	for _, c := range testCases {
@@ -605,10 +610,19 @@ func TestWatchRequestProgress(t *testing.T) {
 
 			watchTimeout := 3 * time.Second
 
-			clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+			clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, EnableGRPCProxy: true})
 			defer clus.Terminate(t)
 
-			wc := clus.RandClient()
+			i := rand.Intn(len(clus.Members))
+			wc := clus.ProxyClient(i)
+			if !c.proxy {
+				// connect directly, even when the cluster clients go through
+				// the proxy
+				cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[i].GRPCURL}})
+				require.NoError(t, err)
+				defer cli.Close()
+				wc = cli
+			}
 
 			var watchChans []clientv3.WatchChan
 
@@ -634,6 +648,18 @@ func TestWatchRequestProgress(t *testing.T) {
 
 			require.NoError(t, wc.RequestProgress(t.Context()))
 
+			if c.proxy {
+				// the proxy drops the progress request
+				for _, rch := range watchChans {
+					select {
+					case resp := <-rch:
+						t.Fatalf("expected no response through the proxy, got %+v", resp)
+					case <-time.After(time.Second):
+					}
+				}
+				return
+			}
+
 			// verify all watch channels receive a progress notify
 			for _, rch := range watchChans {
 				select {
@@ -1224,6 +1250,241 @@ func TestWatchCancelDisconnected(t *testing.T) {
 	}
 }
 
+// TestWatchShareIdenticalWatches ensures identical watches share a watcher
+// on the server, which is only canceled once the last of them is canceled.
+func TestWatchShareIdenticalWatches(t *testing.T) {
+	if integration.ThroughProxy {
+		t.Skip("grpc proxy has leadership watchers of its own")
+	}
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+
+	watchers := func() int {
+		v, err := clus.Members[0].Metric("etcd_debugging_mvcc_watcher_total")
+		require.NoError(t, err)
+		n, err := strconv.Atoi(v)
+		require.NoError(t, err)
+		return n
+	}
+	base := watchers()
+
+	ctx1, cancel1 := context.WithCancel(t.Context())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(t.Context())
+	defer cancel2()
+	wch1 := cli.Watch(ctx1, "foo", clientv3.WithPrefix())
+	wch2 := cli.Watch(ctx2, "foo", clientv3.WithPrefix())
+	// a watch with other options has a watcher of its own
+	wch3 := cli.Watch(t.Context(), "foo", clientv3.WithPrefix(), clientv3.WithPrevKV())
+	require.Equal(t, base+2, watchers())
+
+	_, err := cli.Put(t.Context(), "foo1", "bar")
+	require.NoError(t, err)
+	for _, wch := range []clientv3.WatchChan{wch1, wch2, wch3} {
+		select {
+		case wresp := <-wch:
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, "foo1", string(wresp.Events[0].Kv.Key))
+		case <-time.After(5 * time.Second):
+			t.Fatal("took too long to receive the event")
+		}
+	}
+
+	// canceling one of the watches only closes its channel
+	cancel1()
+	select {
+	case _, ok := <-wch1:
+		require.False(t, ok, "expected closed channel")
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to close the canceled watch")
+	}
+	_, err = cli.Put(t.Context(), "foo2", "bar")
+	require.NoError(t, err)
+	select {
+	case wresp := <-wch2:
+		require.Len(t, wresp.Events, 1)
+		require.Equal(t, "foo2", string(wresp.Events[0].Kv.Key))
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to receive the event")
+	}
+	require.Equal(t, base+2, watchers())
+
+	// the shared watcher is canceled with the last watch
+	cancel2()
+	for i := 0; watchers() != base+1; i++ {
+		require.Less(t, i, 50, "shared watcher was not canceled")
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// TestWatchWithHandle ensures that the handle of a watcher tells why its
+// channel was closed, once the channel is drained.
+func TestWatchWithHandle(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	drain := func(wch clientv3.WatchChan, h *clientv3.WatchHandle) error {
+		t.Helper()
+		for {
+			select {
+			case _, ok := <-wch:
+				if !ok {
+					select {
+					case <-h.Done():
+					default:
+						t.Fatal("watch channel closed before the handle is done")
+					}
+					return h.Err()
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("took too long to close the watch channel")
+			}
+		}
+	}
+
+	// canceled context
+	ctx, cancel := context.WithCancel(t.Context())
+	wch, h := clientv3.WatchWithHandle(ctx, cli, "foo")
+	require.NoError(t, h.Err())
+	cancel()
+	require.ErrorIs(t, drain(wch, h), context.Canceled)
+
+	// compaction
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	presp, err := cli.Put(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	_, err = cli.Compact(t.Context(), presp.Header.Revision)
+	require.NoError(t, err)
+	wch, h = clientv3.WatchWithHandle(t.Context(), cli, "foo", clientv3.WithRev(1))
+	require.ErrorIs(t, drain(wch, h), rpctypes.ErrCompacted)
+
+	// the handle passes through a namespace
+	nsw := namespace.NewWatcher(cli.Watcher, "ns/")
+	wch, h = clientv3.WatchWithHandle(t.Context(), nsw, "foo", clientv3.WithRev(1))
+	require.ErrorIs(t, drain(wch, h), rpctypes.ErrCompacted)
+
+	// closed client
+	cli2, err := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints()})
+	require.NoError(t, err)
+	wch, h = clientv3.WatchWithHandle(t.Context(), cli2, "foo", clientv3.WithCreatedNotify())
+	<-wch
+	require.NoError(t, cli2.Close())
+	require.ErrorIs(t, drain(wch, h), clientv3.ErrWatcherClosed)
+}
+
+// TestWatchCancelAll ensures that CancelAll closes the channels of all the
+// watchers of a client, and returns once they are gone from the server.
+func TestWatchCancelAll(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	before, err := clus.Members[0].Metric("etcd_debugging_mvcc_watcher_total")
+	require.NoError(t, err)
+
+	type watch struct {
+		wch clientv3.WatchChan
+		h   *clientv3.WatchHandle
+	}
+	var watches []watch
+	for i := 0; i < 2; i++ {
+		// force separate streams in client
+		md := metadata.Pairs("some-key", fmt.Sprintf("%d", i))
+		ctx := metadata.NewOutgoingContext(t.Context(), md)
+		// identical watches share a watcher on the server
+		for j := 0; j < 3; j++ {
+			wch, h := clientv3.WatchWithHandle(ctx, cli, "foo")
+			watches = append(watches, watch{wch, h})
+		}
+		wch, h := clientv3.WatchWithHandle(ctx, cli, "foo", clientv3.WithRev(1))
+		watches = append(watches, watch{wch, h})
+	}
+	_, err = cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	for _, w := range watches {
+		select {
+		case wresp := <-w.wch:
+			require.Len(t, wresp.Events, 1)
+		case <-time.After(5 * time.Second):
+			t.Fatal("took too long to receive the event")
+		}
+	}
+
+	require.NoError(t, cli.CancelAll(t.Context()))
+	if !integration.ThroughProxy {
+		// the proxy confirms the cancellations before its own watchers are
+		// canceled on the server
+		after, merr := clus.Members[0].Metric("etcd_debugging_mvcc_watcher_total")
+		require.NoError(t, merr)
+		require.Equal(t, before, after)
+	}
+	for _, w := range watches {
+		select {
+		case _, ok := <-w.wch:
+			require.False(t, ok, "watch channel not closed")
+		case <-time.After(5 * time.Second):
+			t.Fatal("took too long to close the watch channel")
+		}
+		require.ErrorIs(t, w.h.Err(), clientv3.ErrWatchesCanceled)
+	}
+
+	// the watcher can still be used
+	wch := cli.Watch(t.Context(), "foo", clientv3.WithRev(1))
+	select {
+	case wresp := <-wch:
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+	case <-time.After(5 * time.Second):
+		t.Fatal("took too long to receive the event")
+	}
+}
+
+// TestWatchSubstreamsMetric ensures that the watchers of the streams of a
+// client connection are counted under its peer address, and that the series
+// is deleted once they are all canceled.
+func TestWatchSubstreamsMetric(t *testing.T) {
+	integration.BeforeTest(t)
+	if integration.ThroughProxy {
+		t.Skip("the proxy is the peer of the watch streams")
+	}
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	substreams := func() string {
+		v, merr := clus.Members[0].Metric("etcd_debugging_mvcc_watch_substreams")
+		require.NoError(t, merr)
+		return v
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	// a second stream on the same connection
+	ctx2 := metadata.NewOutgoingContext(t.Context(), metadata.Pairs("some-key", "some-value"))
+	for _, wch := range []clientv3.WatchChan{
+		cli.Watch(ctx, "foo", clientv3.WithCreatedNotify()),
+		cli.Watch(ctx, "bar", clientv3.WithCreatedNotify()),
+		cli.Watch(ctx2, "foo", clientv3.WithCreatedNotify()),
+	} {
+		<-wch
+	}
+	require.Equal(t, "3", substreams())
+
+	cancel()
+	require.Eventually(t, func() bool { return substreams() == "1" }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, cli.Close())
+	require.Eventually(t, func() bool { return substreams() == "" }, 5*time.Second, 10*time.Millisecond)
+}
+
 // TestWatchClose ensures that close does not return error
 func TestWatchClose(t *testing.T) {
 	runWatchTest(t, testWatchClose)
@@ -1315,3 +1576,38 @@ func collectEvents(ctx context.Context, watch clientv3.WatchChan) (events []*cli
 		}
 	}
 }
+
+// TestWatchMap ensures that a WatchMapView follows the keys of its prefix.
+func TestWatchMap(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	_, err := cli.Put(t.Context(), "foo/a", "1")
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "bar", "1")
+	require.NoError(t, err)
+
+	m, err := clientv3.WatchMap(t.Context(), cli, "foo/")
+	require.NoError(t, err)
+	kvs, _ := m.Snapshot()
+	require.Equal(t, map[string][]byte{"foo/a": []byte("1")}, kvs)
+
+	_, err = cli.Put(t.Context(), "foo/b", "2")
+	require.NoError(t, err)
+	resp, err := cli.Delete(t.Context(), "foo/a")
+	require.NoError(t, err)
+	for {
+		if _, rev := m.Snapshot(); rev >= resp.Header.Revision {
+			break
+		}
+		select {
+		case <-m.Changes():
+		case <-time.After(5 * time.Second):
+			t.Fatal("took too long to update the view")
+		}
+	}
+	kvs, _ = m.Snapshot()
+	require.Equal(t, map[string][]byte{"foo/b": []byte("2")}, kvs)
+}
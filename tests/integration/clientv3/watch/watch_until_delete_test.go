@@ -0,0 +1,79 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWatchUntilDelete ensures WatchUntilDelete returns once the key is
+// deleted, ignoring updates of the key, and immediately if it is absent.
+func TestWatchUntilDelete(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+	ctx := t.Context()
+
+	require.NoError(t, clientv3.WatchUntilDelete(ctx, cli, "foo"))
+
+	lresp, err := cli.Grant(ctx, 60)
+	require.NoError(t, err)
+	_, err = cli.Put(ctx, "foo", "bar", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+
+	donec := make(chan error, 1)
+	go func() { donec <- clientv3.WatchUntilDelete(ctx, cli, "foo") }()
+
+	_, err = cli.Put(ctx, "foo", "baz", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	select {
+	case err = <-donec:
+		t.Fatalf("returned before the key was deleted: %v", err)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	_, err = cli.Revoke(ctx, lresp.ID)
+	require.NoError(t, err)
+	select {
+	case err = <-donec:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the deletion")
+	}
+}
+
+func TestWatchUntilDeleteContextDone(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	_, err := cli.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 500*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, clientv3.WatchUntilDelete(ctx, cli, "foo"), context.DeadlineExceeded)
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestClockSkewStatus ensures the skew of the clock of a member is estimated
+// by the other members and reported in the status of the leader.
+func TestClockSkewStatus(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	// allow some error for the round trips of the samples
+	const tolerance = 500 * time.Millisecond
+
+	leader := clus.WaitLeader(t)
+	skew, err := clockSkewStatus(t, clus.Members[leader])
+	require.NoError(t, err)
+	require.Less(t, skew, tolerance, "unexpected clock skew without offset")
+
+	// restart the whole cluster, so that the clocks of the members are
+	// sampled from scratch with one of them shifted
+	offset := 2 * time.Second
+	for _, m := range clus.Members {
+		m.Stop(t)
+	}
+	clus.Members[2].ClockOffset = offset
+	for _, m := range clus.Members {
+		require.NoError(t, m.Restart(t))
+	}
+	leader = clus.WaitLeader(t)
+
+	// the clocks of the members which were not up yet when first sampled
+	// are sampled again after 5s
+	for i := 0; i < 150; i++ {
+		// the client may not have reconnected to the restarted member yet
+		skew, err = clockSkewStatus(t, clus.Members[leader])
+		if err == nil && skew > offset-tolerance && skew < offset+tolerance {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("max clock skew = %v (err: %v), want about %v", skew, err, offset)
+}
+
+func clockSkewStatus(t *testing.T, m *integration.Member) (time.Duration, error) {
+	resp, err := integration.ToGRPC(m.Client).Maintenance.Status(t.Context(), &pb.StatusRequest{})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.MaxClockSkew), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdctl_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gofail "go.etcd.io/gofail/runtime"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/etcdctl/v3/pkg/clusterops"
+	"go.etcd.io/etcd/etcdctl/v3/pkg/endpointops"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func clientConfig(clus *integration.Cluster) clientv3.Config {
+	return clientv3.Config{Endpoints: clus.Endpoints(), DialTimeout: 5 * time.Second}
+}
+
+func TestCheckHealth(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	ep := clus.Members[0].GRPCURL
+
+	h := endpointops.CheckHealth(t.Context(), clientConfig(clus), ep)
+	require.Equal(t, ep, h.Ep)
+	require.True(t, h.Health)
+	require.Empty(t, h.Error)
+	require.NotEmpty(t, h.Took)
+
+	_, err := integration.ToGRPC(clus.RandClient()).Maintenance.Alarm(t.Context(), &pb.AlarmRequest{
+		MemberID: uint64(clus.Members[0].ID()),
+		Action:   pb.AlarmRequest_ACTIVATE,
+		Alarm:    pb.AlarmType_NOSPACE,
+	})
+	require.NoError(t, err)
+	h = endpointops.CheckHealth(t.Context(), clientConfig(clus), ep)
+	require.False(t, h.Health)
+	require.Equal(t, "Active Alarm(s): NOSPACE ", h.Error)
+
+	clus.Members[0].Stop(t)
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	h = endpointops.CheckHealth(ctx, clientConfig(clus), ep)
+	require.False(t, h.Health)
+	require.NotEmpty(t, h.Error)
+}
+
+func TestCheckHealthWatch(t *testing.T) {
+	integration.BeforeTest(t)
+	integration.SkipIfNoGoFail(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	ep := clus.Members[0].GRPCURL
+
+	h := endpointops.CheckHealth(t.Context(), clientConfig(clus), ep, endpointops.WithWatchCheck(time.Second))
+	require.True(t, h.Health)
+	require.Empty(t, h.Error)
+
+	// the watch subsystem hangs while reads are served
+	require.NoError(t, gofail.Enable("beforeCreateWatch", `sleep("1s")`))
+	h = endpointops.CheckHealth(t.Context(), clientConfig(clus), ep)
+	require.True(t, h.Health)
+	h = endpointops.CheckHealth(t.Context(), clientConfig(clus), ep, endpointops.WithWatchCheck(200*time.Millisecond))
+	require.False(t, h.Health)
+	require.Equal(t, "watch was not created within 200ms", h.Error)
+
+	require.NoError(t, gofail.Disable("beforeCreateWatch"))
+	// let the server finish the hanging watch creation
+	time.Sleep(time.Second)
+}
+
+func TestGetStatus(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	for _, m := range clus.Members {
+		st, err := endpointops.GetStatus(t.Context(), clientConfig(clus), m.GRPCURL)
+		require.NoError(t, err)
+		require.Equal(t, m.GRPCURL, st.Ep)
+		require.Equal(t, uint64(m.ID()), st.Resp.Header.MemberId)
+	}
+
+	clus.Members[0].Stop(t)
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	_, err := endpointops.GetStatus(ctx, clientConfig(clus), clus.Members[0].GRPCURL)
+	require.Error(t, err)
+}
+
+func TestGetHashKV(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	resp, err := clus.RandClient().Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	rev := resp.Header.Revision
+
+	var hashes []uint32
+	for _, m := range clus.Members {
+		hkv, err := endpointops.GetHashKV(t.Context(), clientConfig(clus), m.GRPCURL, rev)
+		require.NoError(t, err)
+		require.Equal(t, m.GRPCURL, hkv.Ep)
+		hashes = append(hashes, hkv.Resp.Hash)
+	}
+	require.Equal(t, hashes[0], hashes[1])
+	require.Equal(t, hashes[0], hashes[2])
+
+	_, err = endpointops.GetHashKV(t.Context(), clientConfig(clus), clus.Members[0].GRPCURL, rev+1)
+	require.Error(t, err)
+}
+
+func TestClusterEndpoints(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	eps, err := endpointops.ClusterEndpoints(t.Context(), clientConfig(clus))
+	require.NoError(t, err)
+	var want []string
+	for _, m := range clus.Members {
+		for _, u := range m.ClientURLs {
+			want = append(want, u.String())
+		}
+	}
+	assert.ElementsMatch(t, want, eps)
+}
+
+func TestMemberList(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	resp, err := clusterops.MemberList(t.Context(), clientConfig(clus))
+	require.NoError(t, err)
+	require.Len(t, resp.Members, 3)
+
+	resp, err = clusterops.MemberList(t.Context(), clientConfig(clus), clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.Len(t, resp.Members, 3)
+}
+
+func TestAlarmList(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	resp, err := clusterops.AlarmList(t.Context(), clientConfig(clus))
+	require.NoError(t, err)
+	require.Empty(t, resp.Alarms)
+
+	_, err = integration.ToGRPC(clus.RandClient()).Maintenance.Alarm(t.Context(), &pb.AlarmRequest{
+		MemberID: uint64(clus.Members[0].ID()),
+		Action:   pb.AlarmRequest_ACTIVATE,
+		Alarm:    pb.AlarmType_NOSPACE,
+	})
+	require.NoError(t, err)
+	resp, err = clusterops.AlarmList(t.Context(), clientConfig(clus))
+	require.NoError(t, err)
+	require.Len(t, resp.Alarms, 1)
+	require.Equal(t, pb.AlarmType_NOSPACE, resp.Alarms[0].Alarm)
+}
@@ -67,7 +67,7 @@ func TestClusterProxyMemberList(t *testing.T) {
 
 	// test proxy member add
 	newMemberAddr := "127.0.0.2:6789"
-	grpcproxy.Register(lg, cts.c, prefix, newMemberAddr, 7)
+	grpcproxy.Register(lg, cts.c, prefix, newMemberAddr, 7, 0)
 	// wait some time for proxy update members
 	time.Sleep(200 * time.Millisecond)
 
@@ -131,7 +131,7 @@ func newClusterProxyServer(lg *zap.Logger, endpoints []string, prefix string, t
 		cts.server.Serve(cts.l)
 	}()
 
-	grpcproxy.Register(lg, client, prefix, cts.l.Addr().String(), 7)
+	grpcproxy.Register(lg, client, prefix, cts.l.Addr().String(), 7, 0)
 	cts.cp, cts.donec = grpcproxy.NewClusterProxy(lg, client, cts.l.Addr().String(), prefix)
 	cts.caddr = cts.l.Addr().String()
 	pb.RegisterClusterServer(cts.server, cts.cp)
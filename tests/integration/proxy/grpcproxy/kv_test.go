@@ -15,11 +15,16 @@
 package grpcproxy
 
 import (
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 	"google.golang.org/grpc"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
@@ -49,6 +54,80 @@ func TestKVProxyRange(t *testing.T) {
 	client.Close()
 }
 
+func TestKVProxyCacheHits(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvts := newKVProxyServer([]string{clus.Members[0].GRPCURL}, t)
+	defer kvts.close()
+
+	cfg := clientv3.Config{
+		Endpoints:   []string{kvts.l.Addr().String()},
+		DialTimeout: 5 * time.Second,
+	}
+	client, err := integration.NewClient(t, cfg)
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	hits, misses := gatherCounter(t, "etcd_grpc_proxy_cache_hits_total"), gatherCounter(t, "etcd_grpc_proxy_cache_misses_total")
+	for i := 0; i < 5; i++ {
+		resp, gerr := client.Get(t.Context(), "foo", clientv3.WithSerializable())
+		require.NoError(t, gerr)
+		require.Len(t, resp.Kvs, 1)
+	}
+	// the first Get fills the cache and the rest are served from it
+	require.InDelta(t, misses+1, gatherCounter(t, "etcd_grpc_proxy_cache_misses_total"), 0)
+	require.InDelta(t, hits+4, gatherCounter(t, "etcd_grpc_proxy_cache_hits_total"), 0)
+
+	// a write through the proxy invalidates the cached response
+	invalidations := gatherCounter(t, "etcd_grpc_proxy_cache_invalidations_total")
+	_, err = client.Put(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	require.InDelta(t, invalidations+1, gatherCounter(t, "etcd_grpc_proxy_cache_invalidations_total"), 0)
+	resp, err := client.Get(t.Context(), "foo", clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.Equal(t, "baz", string(resp.Kvs[0].Value))
+	require.InDelta(t, misses+2, gatherCounter(t, "etcd_grpc_proxy_cache_misses_total"), 0)
+
+	// clearing the cache forces the next Get to miss
+	mux := http.NewServeMux()
+	grpcproxy.HandleClearCache(zaptest.NewLogger(t), mux, kvts.kp)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	hresp, err := http.Post(srv.URL+grpcproxy.PathProxyCacheClear, "", nil)
+	require.NoError(t, err)
+	body, err := io.ReadAll(hresp.Body)
+	hresp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, hresp.StatusCode)
+	require.JSONEq(t, `{"cleared":1}`, string(body))
+
+	_, err = client.Get(t.Context(), "foo", clientv3.WithSerializable())
+	require.NoError(t, err)
+	require.InDelta(t, misses+3, gatherCounter(t, "etcd_grpc_proxy_cache_misses_total"), 0)
+}
+
+// gatherCounter returns the sum of all series of the named counter.
+func gatherCounter(t *testing.T, name string) float64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var v float64
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			v += m.GetCounter().GetValue()
+		}
+	}
+	return v
+}
+
 type kvproxyTestServer struct {
 	kp     pb.KVServer
 	c      *clientv3.Client
@@ -0,0 +1,153 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestLeaseProxyKeepAliveUpstreamDrops ensures that the leases kept alive
+// through the proxy do not expire while the upstream connection of the
+// proxy is repeatedly dropped.
+func TestLeaseProxyKeepAliveUpstreamDrops(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	lpts := newLeaseProxyServer(t, []string{clus.Members[0].GRPCURL})
+	defer lpts.close()
+	client := newLeaseProxyClient(t, lpts)
+
+	renewals := func(typ string) float64 {
+		return gatherCounterWithLabel(t, "etcd_grpc_proxy_lease_renewals_total", "type", typ)
+	}
+	coalesced := renewals("coalesced")
+
+	const ttl = 3
+	ids := keepAliveLeases(t, client, 5, ttl)
+
+	// keep dropping the upstream connection for longer than the TTL
+	deadline := time.Now().Add(2 * ttl * time.Second)
+	for time.Now().Before(deadline) {
+		clus.Members[0].Bridge().DropConnections()
+		time.Sleep(time.Second)
+	}
+
+	for _, id := range ids {
+		resp, err := clus.Client(0).TimeToLive(t.Context(), id)
+		require.NoError(t, err)
+		require.Positivef(t, resp.TTL, "lease %x expired", id)
+	}
+	require.Greater(t, renewals("coalesced"), coalesced)
+}
+
+// TestLeaseProxyKeepAliveFallback ensures that a lease whose remaining TTL
+// falls below the fallback fraction is renewed directly.
+func TestLeaseProxyKeepAliveFallback(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	// the remaining TTL falls below the fallback fraction long before the
+	// next coalesced renewal, a third of the TTL later
+	lpts := newLeaseProxyServer(t, []string{clus.Members[0].GRPCURL}, grpcproxy.WithLeaseKeepAliveFallback(0.9))
+	defer lpts.close()
+	client := newLeaseProxyClient(t, lpts)
+
+	fallback := gatherCounterWithLabel(t, "etcd_grpc_proxy_lease_renewals_total", "type", "fallback")
+	ids := keepAliveLeases(t, client, 1, 5)
+	require.Eventually(t, func() bool {
+		return gatherCounterWithLabel(t, "etcd_grpc_proxy_lease_renewals_total", "type", "fallback") > fallback
+	}, 5*time.Second, 100*time.Millisecond)
+
+	resp, err := clus.Client(0).TimeToLive(t.Context(), ids[0])
+	require.NoError(t, err)
+	require.Positive(t, resp.TTL)
+}
+
+// keepAliveLeases grants n leases with the given TTL and keeps them alive
+// until the end of the test.
+func keepAliveLeases(t *testing.T, client *clientv3.Client, n int, ttl int64) []clientv3.LeaseID {
+	var ids []clientv3.LeaseID
+	for i := 0; i < n; i++ {
+		resp, err := client.Grant(t.Context(), ttl)
+		require.NoError(t, err)
+		kac, err := client.KeepAlive(t.Context(), resp.ID)
+		require.NoError(t, err)
+		go func() {
+			for range kac {
+			}
+		}()
+		ids = append(ids, resp.ID)
+	}
+	return ids
+}
+
+type leaseProxyTestServer struct {
+	c      *clientv3.Client
+	server *grpc.Server
+	l      net.Listener
+}
+
+func (lpts *leaseProxyTestServer) close() {
+	lpts.server.Stop()
+	lpts.l.Close()
+	lpts.c.Close()
+}
+
+func newLeaseProxyServer(t *testing.T, endpoints []string, opts ...grpcproxy.LeaseProxyOption) *leaseProxyTestServer {
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	lp, _ := grpcproxy.NewLeaseProxy(client.Ctx(), client, opts...)
+
+	lpts := &leaseProxyTestServer{
+		c:      client,
+		server: grpc.NewServer(),
+	}
+	pb.RegisterLeaseServer(lpts.server, lp)
+
+	lpts.l, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go lpts.server.Serve(lpts.l)
+
+	return lpts
+}
+
+func newLeaseProxyClient(t *testing.T, lpts *leaseProxyTestServer) *clientv3.Client {
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   []string{lpts.l.Addr().String()},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	"go.etcd.io/etcd/tests/v3/framework/testutils"
+)
+
+// fixturesDir is resolved before the tests change the working directory.
+var fixturesDir = testutils.MustAbsPath("../../../fixtures")
+
+// fixtureTLSInfo returns the TLS info identified by the given certificate of
+// the fixtures.
+func fixtureTLSInfo(cert string) transport.TLSInfo {
+	return transport.TLSInfo{
+		CertFile:      filepath.Join(fixturesDir, cert+".crt"),
+		KeyFile:       filepath.Join(fixturesDir, cert+".key.insecure"),
+		TrustedCAFile: filepath.Join(fixturesDir, "ca.crt"),
+	}
+}
+
+func TestNamespaceProxyIsolation(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cfgPath := filepath.Join(t.TempDir(), "namespaces.json")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`{"namespaces":{"example.com":"a/","example2.com":"b/"}}`), 0o600))
+	nsCfg, err := grpcproxy.LoadNamespaceConfig(cfgPath)
+	require.NoError(t, err)
+	ns, err := grpcproxy.NewNamespacer(nsCfg)
+	require.NoError(t, err)
+	go ns.WatchConfigFile(t.Context(), zaptest.NewLogger(t), cfgPath, 10*time.Millisecond)
+
+	addr := newNamespacedProxyServer(t, clus.Members[0].GRPCURL, ns)
+	newClient := func(cert string) *clientv3.Client {
+		tlsInfo := fixtureTLSInfo(cert)
+		tlscfg, cerr := tlsInfo.ClientConfig()
+		require.NoError(t, cerr)
+		c, cerr := integration.NewClient(t, clientv3.Config{
+			Endpoints:   []string{addr},
+			DialTimeout: 5 * time.Second,
+			TLS:         tlscfg,
+		})
+		require.NoError(t, cerr)
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+	ca, cb := newClient("server"), newClient("server2")
+
+	wch := ca.Watch(t.Context(), "foo", clientv3.WithPrevKV())
+
+	_, err = cb.Put(t.Context(), "foo", "b")
+	require.NoError(t, err)
+	_, err = ca.Put(t.Context(), "foo", "a1")
+	require.NoError(t, err)
+	_, err = ca.Put(t.Context(), "foo", "a2")
+	require.NoError(t, err)
+
+	// each client only sees its own keys
+	for c, want := range map[*clientv3.Client]string{ca: "a2", cb: "b"} {
+		resp, gerr := c.Get(t.Context(), "", clientv3.WithFromKey())
+		require.NoError(t, gerr)
+		require.Len(t, resp.Kvs, 1)
+		require.Equal(t, "foo", string(resp.Kvs[0].Key))
+		require.Equal(t, want, string(resp.Kvs[0].Value))
+	}
+	resp, err := clus.Client(0).Get(t.Context(), "", clientv3.WithFromKey(), clientv3.WithKeysOnly())
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 2)
+	require.Equal(t, "a/foo", string(resp.Kvs[0].Key))
+	require.Equal(t, "b/foo", string(resp.Kvs[1].Key))
+
+	// the watch of a misses the events of b
+	for _, want := range []string{"a1", "a2"} {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			require.Len(t, wresp.Events, 1)
+			require.Equal(t, "foo", string(wresp.Events[0].Kv.Key))
+			require.Equal(t, want, string(wresp.Events[0].Kv.Value))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for watch event")
+		}
+	}
+
+	tresp, err := cb.Txn(t.Context()).
+		If(clientv3.Compare(clientv3.Value("foo"), "=", "b")).
+		Then(clientv3.OpDelete("foo", clientv3.WithPrevKV()), clientv3.OpGet("foo", clientv3.WithPrefix())).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, tresp.Succeeded)
+	prevKvs := tresp.Responses[0].GetResponseDeleteRange().PrevKvs
+	require.Len(t, prevKvs, 1)
+	require.Equal(t, "foo", string(prevKvs[0].Key))
+	require.Empty(t, tresp.Responses[1].GetResponseRange().Kvs)
+
+	gresp, err := ca.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Len(t, gresp.Kvs, 1)
+
+	// the keys of the leases of other namespaces are hidden
+	lresp, err := ca.Grant(t.Context(), 60)
+	require.NoError(t, err)
+	_, err = ca.Put(t.Context(), "leased", "a", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	_, err = clus.Client(0).Put(t.Context(), "b/leased", "b", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	ttl, err := ca.TimeToLive(t.Context(), lresp.ID, clientv3.WithAttachedKeys())
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("leased")}, ttl.Keys)
+
+	// clients without a namespace are rejected until a default is set
+	cn := newClient("client-nocn")
+	_, err = cn.Get(t.Context(), "foo")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`{"namespaces":{"example.com":"a/","example2.com":"b/"},"default":"shared/"}`), 0o600))
+	require.Eventually(t, func() bool {
+		return ns.Config().Default == "shared/"
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = cn.Put(t.Context(), "foo", "shared")
+	require.NoError(t, err)
+	resp, err = clus.Client(0).Get(t.Context(), "shared/foo")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+}
+
+func newNamespacedProxyServer(t *testing.T, endpoint string, ns *grpcproxy.Namespacer) string {
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   []string{endpoint},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	kvp, _ := grpcproxy.NewKvProxy(client)
+	watchp, _ := grpcproxy.NewWatchProxy(client.Ctx(), zaptest.NewLogger(t), client)
+	leasep, _ := grpcproxy.NewLeaseProxy(client.Ctx(), client)
+
+	tlsInfo := fixtureTLSInfo("server")
+	tlsInfo.ClientCertAuth = true
+	tlscfg, err := tlsInfo.ServerConfig()
+	require.NoError(t, err)
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlscfg)),
+		grpc.ChainUnaryInterceptor(ns.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(ns.StreamServerInterceptor()),
+	)
+	pb.RegisterKVServer(server, kvp)
+	pb.RegisterWatchServer(server, watchp)
+	pb.RegisterLeaseServer(server, leasep)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(l)
+	t.Cleanup(server.Stop)
+	return l.Addr().String()
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestKVProxyRateLimit(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	// a negligible rate, so that no request is admitted beyond the burst
+	rl, err := grpcproxy.NewRateLimiter(grpcproxy.RateLimitConfig{QPS: 0.001, Burst: 2})
+	require.NoError(t, err)
+
+	kvts := newRateLimitedKVProxyServer([]string{clus.Members[0].GRPCURL}, rl, t)
+	defer kvts.close()
+
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   []string{kvts.l.Addr().String()},
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	throttled := func(limit string) float64 {
+		return gatherCounterWithLabel(t, "etcd_grpc_proxy_throttled_requests_total", "limit", limit)
+	}
+	clientThrottled, globalThrottled := throttled("client"), throttled("global")
+
+	for i := 0; i < 2; i++ {
+		_, err = client.Get(t.Context(), "foo")
+		require.NoError(t, err)
+	}
+	_, err = client.Get(t.Context(), "foo")
+	require.ErrorIs(t, err, rpctypes.ErrTooManyRequests)
+	require.InDelta(t, clientThrottled+1, throttled("client"), 0)
+
+	// the limits are replaced through the handler
+	mux := http.NewServeMux()
+	grpcproxy.HandleRateLimit(zaptest.NewLogger(t), mux, rl)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	setConfig := func(cfg string) (int, string) {
+		req, rerr := http.NewRequest(http.MethodPut, srv.URL+grpcproxy.PathProxyRateLimit, strings.NewReader(cfg))
+		require.NoError(t, rerr)
+		resp, rerr := http.DefaultClient.Do(req)
+		require.NoError(t, rerr)
+		defer resp.Body.Close()
+		body, rerr := io.ReadAll(resp.Body)
+		require.NoError(t, rerr)
+		return resp.StatusCode, string(body)
+	}
+
+	code, _ := setConfig(`{"qps":-1}`)
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, body := setConfig(`{"global-qps":0.001,"global-burst":1}`)
+	require.Equal(t, http.StatusOK, code)
+	require.JSONEq(t, `{"qps":0,"burst":0,"global-qps":0.001,"global-burst":1}`, body)
+
+	_, err = client.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	_, err = client.Put(t.Context(), "foo", "bar")
+	require.ErrorIs(t, err, rpctypes.ErrTooManyRequests)
+	require.InDelta(t, globalThrottled+1, throttled("global"), 0)
+
+	code, _ = setConfig(`{}`)
+	require.Equal(t, http.StatusOK, code)
+	for i := 0; i < 5; i++ {
+		_, err = client.Get(t.Context(), "foo")
+		require.NoError(t, err)
+	}
+}
+
+// gatherCounterWithLabel returns the sum of the series of the named counter
+// that have the given label value.
+func gatherCounterWithLabel(t *testing.T, name, label, value string) float64 {
+	mfs, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var v float64
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, lp := range m.GetLabel() {
+				if lp.GetName() == label && lp.GetValue() == value {
+					v += m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return v
+}
+
+func newRateLimitedKVProxyServer(endpoints []string, rl *grpcproxy.RateLimiter, t *testing.T) *kvproxyTestServer {
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	kvp, _ := grpcproxy.NewKvProxy(client)
+
+	kvts := &kvproxyTestServer{
+		kp: kvp,
+		c:  client,
+	}
+	kvts.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(rl.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(rl.StreamServerInterceptor()),
+	)
+	pb.RegisterKVServer(kvts.server, kvts.kp)
+
+	kvts.l, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go kvts.server.Serve(kvts.l)
+
+	return kvts
+}
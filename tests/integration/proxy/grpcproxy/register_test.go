@@ -15,6 +15,9 @@
 package grpcproxy
 
 import (
+	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -38,11 +41,12 @@ func TestRegister(t *testing.T) {
 	testPrefix := "test-name"
 	wa := mustCreateWatcher(t, cli, testPrefix)
 
-	donec := grpcproxy.Register(zaptest.NewLogger(t), cli, testPrefix, paddr, 5)
+	donec := grpcproxy.Register(zaptest.NewLogger(t), cli, testPrefix, paddr, 5, 3)
 
 	ups := <-wa
 	require.Lenf(t, ups, 1, "len(ups) expected 1, got %d (%v)", len(ups), ups)
 	require.Equalf(t, ups[0].Endpoint.Addr, paddr, "ups[0].Addr expected %q, got %q", paddr, ups[0].Endpoint.Addr)
+	require.Equal(t, uint32(3), ups[0].Endpoint.Weight)
 
 	cli.Close()
 	clus.TakeClient(0)
@@ -53,6 +57,114 @@ func TestRegister(t *testing.T) {
 	}
 }
 
+func TestRegisterEndpointDeregister(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+	paddr := clus.Members[0].GRPCURL
+
+	testPrefix := "test-name"
+	wa := mustCreateWatcher(t, cli, testPrefix)
+
+	md := map[string]any{"name": "proxy-1", "zone": "us-east-1a"}
+	// the TTL is long enough for the test to fail if the key is only removed
+	// when the lease expires
+	r := grpcproxy.RegisterEndpoint(zaptest.NewLogger(t), cli, testPrefix, endpoints.Endpoint{Addr: paddr, Metadata: md, Weight: 2}, 60)
+
+	ups := <-wa
+	require.Len(t, ups, 1)
+	require.Equal(t, endpoints.Add, ups[0].Op)
+	require.Equal(t, paddr, ups[0].Endpoint.Addr)
+	require.Equal(t, uint32(2), ups[0].Endpoint.Weight)
+	require.Equal(t, md, ups[0].Endpoint.Metadata)
+
+	require.NoError(t, r.Deregister(t.Context()))
+	select {
+	case ups = <-wa:
+		require.Len(t, ups, 1)
+		require.Equal(t, endpoints.Delete, ups[0].Op)
+		require.Equal(t, testPrefix+"/"+paddr, ups[0].Key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("endpoint was not deleted after Deregister")
+	}
+	select {
+	case <-r.Done():
+	default:
+		t.Fatal("Done is not closed after Deregister")
+	}
+	require.NoError(t, r.Deregister(t.Context()))
+
+	resp, err := cli.Get(t.Context(), testPrefix, clientv3.WithPrefix())
+	require.NoError(t, err)
+	require.Empty(t, resp.Kvs)
+	leases, err := cli.Leases(t.Context())
+	require.NoError(t, err)
+	require.Empty(t, leases.Leases)
+}
+
+func TestRegisterEndpointHealthCheck(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.Client(0)
+	paddr := clus.Members[0].GRPCURL
+
+	testPrefix := "test-name"
+	wa := mustCreateWatcher(t, cli, testPrefix)
+
+	var healthy atomic.Bool
+	check := func(ctx context.Context) error {
+		if !healthy.Load() {
+			return errors.New("unhealthy")
+		}
+		return nil
+	}
+	r := grpcproxy.RegisterEndpoint(zaptest.NewLogger(t), cli, testPrefix, endpoints.Endpoint{Addr: paddr}, 60,
+		grpcproxy.WithRegisterBackoff(10*time.Millisecond, 100*time.Millisecond),
+		grpcproxy.WithRegisterHealthCheck(check, 100*time.Millisecond),
+	)
+	defer r.Deregister(t.Context())
+
+	select {
+	case ups := <-wa:
+		t.Fatalf("unhealthy proxy was registered: %v", ups)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	healthy.Store(true)
+	select {
+	case ups := <-wa:
+		require.Len(t, ups, 1)
+		require.Equal(t, endpoints.Add, ups[0].Op)
+		require.Equal(t, paddr, ups[0].Endpoint.Addr)
+	case <-time.After(5 * time.Second):
+		t.Fatal("healthy proxy was not registered")
+	}
+
+	// the TTL is long enough for the test to fail if the key is only removed
+	// when the lease expires
+	healthy.Store(false)
+	select {
+	case ups := <-wa:
+		require.Len(t, ups, 1)
+		require.Equal(t, endpoints.Delete, ups[0].Op)
+	case <-time.After(5 * time.Second):
+		t.Fatal("unhealthy proxy was not deregistered")
+	}
+
+	healthy.Store(true)
+	select {
+	case ups := <-wa:
+		require.Len(t, ups, 1)
+		require.Equal(t, endpoints.Add, ups[0].Op)
+	case <-time.After(5 * time.Second):
+		t.Fatal("proxy was not registered again once healthy")
+	}
+}
+
 func mustCreateWatcher(t *testing.T, c *clientv3.Client, prefix string) endpoints.WatchChannel {
 	em, err := endpoints.NewManager(c, prefix)
 	require.NoErrorf(t, err, "failed to create endpoints.Manager")
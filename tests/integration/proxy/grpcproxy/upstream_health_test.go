@@ -0,0 +1,75 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestUpstreamHealth(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	client, err := integration.NewClient(t, clientv3.Config{
+		Endpoints:   clus.Endpoints(),
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	clus.Members[2].Stop(t)
+
+	uc := grpcproxy.NewUpstreamHealthChecker(client)
+	go uc.Run(t.Context(), 100*time.Millisecond)
+
+	mux := http.NewServeMux()
+	grpcproxy.HandleUpstreamHealth(mux, uc)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	require.Eventually(t, func() bool {
+		resp, herr := http.Get(srv.URL + grpcproxy.PathProxyUpstreamHealth)
+		require.NoError(t, herr)
+		defer resp.Body.Close()
+		var hs []grpcproxy.UpstreamHealth
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&hs))
+		require.Len(t, hs, 3)
+		for i, h := range hs {
+			require.Equal(t, clus.Members[i].GRPCURL, h.Endpoint)
+			if i == 2 {
+				if h.LastError == "" || !h.LastConnect.IsZero() {
+					return false
+				}
+				continue
+			}
+			if h.LastError != "" || h.MemberID != clus.Members[i].ID().String() {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/proxy/grpcproxy"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+func TestWatchProxyMaxBroadcastWatchers(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	upstream, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{clus.Members[0].GRPCURL}})
+	require.NoError(t, err)
+	defer upstream.Close()
+
+	wp, _ := grpcproxy.NewWatchProxy(upstream.Ctx(), zaptest.NewLogger(t), upstream, grpcproxy.WithMaxBroadcastWatchers(3))
+	server := grpc.NewServer()
+	pb.RegisterWatchServer(server, wp)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(l)
+	defer server.Stop()
+
+	cli, err := integration.NewClient(t, clientv3.Config{Endpoints: []string{l.Addr().String()}, DialTimeout: 5 * time.Second})
+	require.NoError(t, err)
+	defer cli.Close()
+
+	presp, err := upstream.Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+
+	streams := func() int {
+		v, merr := clus.Members[0].Metric("etcd_debugging_mvcc_watch_stream_total")
+		require.NoError(t, merr)
+		n, perr := strconv.Atoi(v)
+		require.NoError(t, perr)
+		return n
+	}
+	before := streams()
+
+	// all watchers start on the same revision so they can share upstream
+	// watches, but at most 3 of them may share one.
+	const watchers = 10
+	wchs := make([]clientv3.WatchChan, watchers)
+	for i := range wchs {
+		wchs[i] = cli.Watch(t.Context(), "foo", clientv3.WithRev(presp.Header.Revision+1), clientv3.WithCreatedNotify())
+		wresp := <-wchs[i]
+		require.NoError(t, wresp.Err())
+		require.True(t, wresp.Created)
+	}
+
+	// one upstream watch on the shared stream, and three on dedicated ones.
+	require.Eventually(t, func() bool { return streams() == before+4 }, 5*time.Second, 10*time.Millisecond)
+
+	_, err = upstream.Put(t.Context(), "foo", "baz")
+	require.NoError(t, err)
+	for i, wch := range wchs {
+		select {
+		case wresp := <-wch:
+			require.NoError(t, wresp.Err())
+			require.Lenf(t, wresp.Events, 1, "watcher #%d", i)
+			require.Equal(t, "baz", string(wresp.Events[0].Kv.Value))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("watcher #%d: timed out waiting for the event", i)
+		}
+	}
+}
@@ -362,6 +362,162 @@ func TestV3AuthWithLeaseAttach(t *testing.T) {
 	}
 }
 
+// TestV3AuthWithLeaseTransfer verifies that a user without permissions can't
+// take over a lease, whether or not keys are attached to it.
+func TestV3AuthWithLeaseTransfer(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	users := []user{
+		{
+			name:     "user1",
+			password: "user1-123",
+			role:     "role1",
+		},
+	}
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, users)
+
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	rootc, cerr := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, cerr)
+	defer rootc.Close()
+
+	userc, cerr := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, cerr)
+	defer userc.Close()
+
+	leaseResp, err := rootc.Grant(t.Context(), 90)
+	require.NoError(t, err)
+	leaseID := leaseResp.ID
+
+	_, err = userc.Transfer(t.Context(), leaseID)
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	_, err = rootc.Put(t.Context(), "k1", "val", clientv3.WithLease(leaseID))
+	require.NoError(t, err)
+
+	_, err = userc.Transfer(t.Context(), leaseID)
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+
+	tresp, err := rootc.Transfer(t.Context(), leaseID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tresp.Epoch)
+}
+
+// TestV3AuthPerCallUsers verifies that two users with disjoint permissions
+// share one client, with requests and watchers made on their behalf.
+func TestV3AuthPerCallUsers(t *testing.T) {
+	integration.BeforeTest(t)
+	// JWT tokens are invalidated by a change of the auth store
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, AuthToken: integration.DefaultTokenJWT})
+	defer clus.Terminate(t)
+
+	users := []user{
+		{name: "alice", password: "alice-123", role: "alice", key: "alice/", end: "alice0"},
+		{name: "bob", password: "bob-123", role: "bob", key: "bob/", end: "bob0"},
+	}
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, users)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	// the client itself authenticates as root
+	cli, cerr := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, cerr)
+	defer cli.Close()
+
+	alice := clientv3.WithUser(t.Context(), "alice", "alice-123")
+	bob := clientv3.WithUser(t.Context(), "bob", "bob-123")
+
+	awch := cli.Watch(alice, "alice/", clientv3.WithPrefix())
+	bwch := cli.Watch(bob, "alice/", clientv3.WithPrefix())
+
+	_, err := cli.Put(alice, "alice/k", "v")
+	require.NoError(t, err)
+	_, err = cli.Put(alice, "bob/k", "v")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	_, err = cli.Put(bob, "bob/k", "v")
+	require.NoError(t, err)
+	_, err = cli.Get(bob, "alice/k")
+	require.ErrorIs(t, err, rpctypes.ErrPermissionDenied)
+	_, err = cli.Put(t.Context(), "root/k", "v")
+	require.NoError(t, err)
+
+	wresp := <-awch
+	require.NoError(t, wresp.Err())
+	require.Len(t, wresp.Events, 1)
+	require.Equal(t, "alice/k", string(wresp.Events[0].Kv.Key))
+	wresp = <-bwch
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), rpctypes.ErrPermissionDenied.Error())
+
+	// a change of the auth store makes the cached token of alice outdated,
+	// so it is refreshed and the request retried
+	_, err = cli.UserAdd(t.Context(), "carol", "carol-123")
+	require.NoError(t, err)
+	_, err = cli.Put(alice, "alice/k", "v2")
+	require.NoError(t, err)
+
+	resp, err := cli.Authenticate(t.Context(), "bob", "bob-123")
+	require.NoError(t, err)
+	_, err = cli.Get(clientv3.WithAuthToken(t.Context(), resp.Token), "bob/k")
+	require.NoError(t, err)
+	_, err = cli.Get(clientv3.WithAuthToken(t.Context(), "invalid"), "bob/k")
+	require.ErrorIs(t, err, rpctypes.ErrInvalidAuthToken)
+
+	_, err = cli.Get(clientv3.WithUser(t.Context(), "alice", "wrong"), "alice/k")
+	require.ErrorIs(t, err, rpctypes.ErrAuthFailed)
+}
+
+// TestV3AuthTokenRefreshBeforeExpiry verifies that a client refreshes its JWT
+// before it expires, and resumes its watchers on a new stream when the token
+// of their stream has expired.
+func TestV3AuthTokenRefreshBeforeExpiry(t *testing.T) {
+	integration.BeforeTest(t)
+	// the tokens expire after 2s
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, AuthToken: integration.DefaultTokenJWT})
+	defer clus.Terminate(t)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	// a single attempt leaves no room to refresh the token once a write fails
+	cli, cerr := integration.NewClient(t, clientv3.Config{
+		Endpoints:   clus.Client(0).Endpoints(),
+		Username:    "root",
+		Password:    "123",
+		RetryPolicy: clientv3.RetryPolicies{Writes: clientv3.RetryPolicy{MaxAttempts: 1}},
+	})
+	require.NoError(t, cerr)
+	defer cli.Close()
+
+	ctx := t.Context()
+	wch := cli.Watch(ctx, "foo")
+	_, err := cli.Put(ctx, "foo", "1")
+	require.NoError(t, err)
+	wresp := <-wch
+	require.NoError(t, wresp.Err())
+
+	time.Sleep(3 * time.Second)
+	_, err = cli.Txn(ctx).Then(clientv3.OpPut("foo", "2")).Commit()
+	require.NoError(t, err)
+	wresp = <-wch
+	require.NoError(t, wresp.Err())
+	require.Equal(t, "2", string(wresp.Events[0].Kv.Value))
+
+	// the stream of the first watcher was opened with an expired token
+	wch2 := cli.Watch(ctx, "foo", clientv3.WithCreatedNotify())
+	wresp = <-wch2
+	require.NoError(t, wresp.Err())
+	require.True(t, wresp.Created)
+	_, err = cli.Put(ctx, "foo", "3")
+	require.NoError(t, err)
+	for _, c := range []clientv3.WatchChan{wch, wch2} {
+		wresp = <-c
+		require.NoError(t, wresp.Err())
+		require.Len(t, wresp.Events, 1)
+		require.Equal(t, "3", string(wresp.Events[0].Kv.Value))
+	}
+}
+
 func authSetupUsers(t *testing.T, auth pb.AuthClient, users []user) {
 	for _, user := range users {
 		_, err := auth.UserAdd(t.Context(), &pb.AuthUserAddRequest{Name: user.name, Password: user.password, Options: &authpb.UserAddOptions{NoPassword: false}})
@@ -0,0 +1,220 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/automaintenance"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// autoMaintenanceWaitTimeout leaves room for the members of a cluster to be
+// connected to each other for a health interval, which the engine requires
+// to consider quorum safe.
+const autoMaintenanceWaitTimeout = 30 * time.Second
+
+func autoMaintenanceConfig() automaintenance.Config {
+	return automaintenance.Config{
+		Policy:              automaintenance.PolicyAggressive,
+		CompactionRetention: 10,
+		CheckInterval:       100 * time.Millisecond,
+	}
+}
+
+// TestAutoMaintenanceCompactsUnderQuotaPressure ensures the leader compacts
+// once the database crosses the compaction threshold of the quota, and does
+// not defragment itself.
+func TestAutoMaintenanceCompactsUnderQuotaPressure(t *testing.T) {
+	integration.BeforeTest(t)
+	cfg := autoMaintenanceConfig()
+	cfg.CompactionThreshold = 0.25
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:              1,
+		QuotaBackendBytes: 8 * 1024 * 1024,
+		AutoMaintenance:   cfg,
+	})
+	defer clus.Terminate(t)
+	m := clus.Members[0]
+
+	// 2.5MB of history crosses 0.25 of the quota without reaching it.
+	putValues(t, clus.Client(0), 160, 16*1024)
+
+	r := waitForAutoMaintenance(t, m, automaintenance.ActionCompact, automaintenance.ResultSucceeded)
+	assert.Contains(t, r.Reason, "of quota")
+	_, err := clus.Client(0).Get(t.Context(), "foo", clientv3.WithRev(2))
+	require.ErrorIs(t, err, rpctypes.ErrCompacted)
+
+	// The compaction freed most of the database, but the leader never
+	// defragments itself.
+	defer keepWriting(t, clus.Client(0))()
+	r = waitForAutoMaintenance(t, m, automaintenance.ActionDefrag, automaintenance.ResultSkipped)
+	assert.Contains(t, r.Reason, "member is the leader")
+	assert.Empty(t, autoMaintenanceRecords(m, automaintenance.ActionDefrag, automaintenance.ResultSucceeded))
+}
+
+// TestAutoMaintenanceCompactsOnNoSpaceAlarm ensures the NOSPACE alarm
+// triggers a compaction even below the compaction threshold.
+func TestAutoMaintenanceCompactsOnNoSpaceAlarm(t *testing.T) {
+	integration.BeforeTest(t)
+	quota := int64(16 * os.Getpagesize())
+	cfg := autoMaintenanceConfig()
+	cfg.CompactionThreshold = 1
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:              1,
+		QuotaBackendBytes: quota,
+		AutoMaintenance:   cfg,
+	})
+	defer clus.Terminate(t)
+	m := clus.Members[0]
+
+	// A put that does not fit in the quota raises the alarm while the
+	// database stays below the threshold.
+	putValues(t, clus.Client(0), 20, 16)
+	_, err := clus.Client(0).Put(t.Context(), "foo", string(make([]byte, quota)))
+	require.ErrorIs(t, err, rpctypes.ErrNoSpace)
+
+	r := waitForAutoMaintenance(t, m, automaintenance.ActionCompact, automaintenance.ResultSucceeded)
+	assert.Contains(t, r.Reason, "NOSPACE alarm is raised")
+}
+
+// TestAutoMaintenanceDefragsFollowersInTurn ensures fragmented followers are
+// defragmented one at a time while the leader is skipped.
+func TestAutoMaintenanceDefragsFollowersInTurn(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:            3,
+		AutoMaintenance: autoMaintenanceConfig(),
+	})
+	defer clus.Terminate(t)
+
+	// Fragment every member with a compacted history.
+	putValues(t, clus.Client(0), 100, 16*1024)
+	resp, err := clus.Client(0).Get(t.Context(), "foo")
+	require.NoError(t, err)
+	_, err = clus.Client(0).Compact(t.Context(), resp.Header.Revision, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	defer keepWriting(t, clus.Client(0))()
+
+	leader := clus.WaitLeader(t)
+	var defrags []automaintenance.Record
+	for i, m := range clus.Members {
+		if i == leader {
+			r := waitForAutoMaintenance(t, m, automaintenance.ActionDefrag, automaintenance.ResultSkipped)
+			assert.Contains(t, r.Reason, "member is the leader")
+			continue
+		}
+		r := waitForAutoMaintenance(t, m, automaintenance.ActionDefrag, automaintenance.ResultSucceeded)
+		assert.Less(t, r.DBSizeAfter, r.DBSizeBefore)
+		defrags = append(defrags, r)
+	}
+	require.Len(t, defrags, 2)
+	first, second := defrags[0], defrags[1]
+	if second.Time.Before(first.Time) {
+		first, second = second, first
+	}
+	assert.False(t, second.Time.Before(first.Time.Add(first.Took)), "followers defragmented at the same time")
+}
+
+// TestAutoMaintenanceSkipsWhenQuorumAtRisk ensures the engine does not act
+// while losing one more member would lose quorum, and acts again once the
+// cluster recovers.
+func TestAutoMaintenanceSkipsWhenQuorumAtRisk(t *testing.T) {
+	integration.BeforeTest(t)
+	cfg := autoMaintenanceConfig()
+	cfg.CompactionThreshold = 0.25
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:              3,
+		QuotaBackendBytes: 8 * 1024 * 1024,
+		AutoMaintenance:   cfg,
+	})
+	defer clus.Terminate(t)
+
+	leader := clus.WaitLeader(t)
+	follower := (leader + 1) % 3
+	clus.Members[follower].Stop(t)
+
+	putValues(t, clus.Client(leader), 160, 16*1024)
+
+	r := waitForAutoMaintenance(t, clus.Members[leader], automaintenance.ActionCompact, automaintenance.ResultSkipped)
+	assert.Contains(t, r.Reason, "quorum is at risk")
+	assert.Empty(t, autoMaintenanceRecords(clus.Members[leader], automaintenance.ActionCompact, automaintenance.ResultSucceeded))
+	_, err := clus.Client(leader).Get(t.Context(), "foo", clientv3.WithRev(2))
+	require.NoError(t, err)
+
+	require.NoError(t, clus.Members[follower].Restart(t))
+	leader = clus.WaitLeader(t)
+	waitForAutoMaintenance(t, clus.Members[leader], automaintenance.ActionCompact, automaintenance.ResultSucceeded)
+}
+
+func putValues(t *testing.T, c *clientv3.Client, n, size int) {
+	t.Helper()
+	val := strings.Repeat("a", size)
+	for i := 0; i < n; i++ {
+		_, err := c.Put(t.Context(), "foo", val)
+		require.NoError(t, err)
+	}
+}
+
+// keepWriting writes a key until the returned function is called. Pages freed
+// by a compaction are only accounted as free by later write transactions.
+func keepWriting(t *testing.T, c *clientv3.Client) (stop func()) {
+	ctx, cancel := context.WithCancel(t.Context())
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		for ctx.Err() == nil {
+			c.Put(ctx, "tick", "")
+			time.Sleep(100 * time.Millisecond)
+		}
+	}()
+	return func() {
+		cancel()
+		<-donec
+	}
+}
+
+func autoMaintenanceRecords(m *integration.Member, action, result string) []automaintenance.Record {
+	var rs []automaintenance.Record
+	for _, r := range m.Server.AutoMaintenance().History() {
+		if r.Action == action && r.Result == result {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+func waitForAutoMaintenance(t *testing.T, m *integration.Member, action, result string) automaintenance.Record {
+	t.Helper()
+	deadline := time.Now().Add(autoMaintenanceWaitTimeout)
+	for time.Now().Before(deadline) {
+		if rs := autoMaintenanceRecords(m, action, result); len(rs) > 0 {
+			return rs[0]
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s %s on member %s, history: %+v", action, result, m.Name, m.Server.AutoMaintenance().History())
+	return automaintenance.Record{}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/pkg/v3/featuregate"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestValueChecksumMigration ensures that a member restarted with value
+// checksums enabled migrates its values, reports the progress in its status,
+// and agrees on the hash of the keyspace with the members that did not.
+func TestValueChecksumMigration(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.RandClient()).KV
+	for i := 0; i < 10; i++ {
+		_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte(fmt.Sprintf("foo%d", i)), Value: []byte("bar")})
+		require.NoError(t, err)
+	}
+
+	m := clus.Members[0]
+	resp, err := integration.ToGRPC(m.Client).Maintenance.Status(t.Context(), &pb.StatusRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "plain", resp.ValueEncoding)
+	require.InDelta(t, 100, resp.ValueEncodingMigrationPercent, 0)
+
+	m.Stop(t)
+	require.NoError(t, m.ServerFeatureGate.(featuregate.MutableFeatureGate).Set("ValueChecksum=true"))
+	require.NoError(t, m.Restart(t))
+	clus.WaitLeader(t)
+
+	require.Eventually(t, func() bool {
+		resp, err = integration.ToGRPC(m.Client).Maintenance.Status(t.Context(), &pb.StatusRequest{})
+		return err == nil && resp.ValueEncodingMigrationPercent == 100
+	}, 10*time.Second, 100*time.Millisecond)
+	require.Equal(t, "checksum", resp.ValueEncoding)
+
+	rresp, err := integration.ToGRPC(m.Client).KV.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo"), RangeEnd: []byte("fop"), Serializable: true})
+	require.NoError(t, err)
+	require.Len(t, rresp.Kvs, 10)
+
+	var hashes []uint32
+	for _, mem := range clus.Members {
+		hresp, herr := integration.ToGRPC(mem.Client).Maintenance.HashKV(t.Context(), &pb.HashKVRequest{Revision: rresp.Header.Revision})
+		require.NoError(t, herr)
+		hashes = append(hashes, hresp.Hash)
+	}
+	require.Equal(t, hashes[0], hashes[1])
+	require.Equal(t, hashes[0], hashes[2])
+}
@@ -37,6 +37,10 @@ func (m *mockWatcher) RequestProgress(ctx context.Context) error {
 	return nil
 }
 
+func (m *mockWatcher) CancelAll(ctx context.Context) error {
+	return nil
+}
+
 func (m *mockWatcher) Close() error {
 	return nil
 }
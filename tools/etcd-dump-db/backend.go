@@ -72,7 +72,7 @@ func defaultDecoder(k, v []byte) {
 func keyDecoder(k, v []byte) {
 	rev := mvcc.BytesToBucketKey(k)
 	var kv mvccpb.KeyValue
-	if err := proto.Unmarshal(v, &kv); err != nil {
+	if err := mvcc.UnmarshalKeyValue(v, &kv); err != nil {
 		panic(err)
 	}
 	fmt.Printf("rev=%+v, value=[key %q | val %q | created %d | mod %d | ver %d]\n", rev, string(kv.Key), string(kv.Value), kv.CreateRevision, kv.ModRevision, kv.Version)